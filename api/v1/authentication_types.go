@@ -62,6 +62,26 @@ type AuthenticationSpec struct {
 	// DexDeployment configures the Dex Deployment.
 	// +optional
 	DexDeployment *DexDeployment `json:"dexDeployment,omitempty"`
+
+	// Session configures how long users stay logged in to the Manager UI.
+	// +optional
+	Session *AuthenticationSession `json:"session,omitempty"`
+}
+
+// AuthenticationSession configures the sessions of users that log in to the Manager UI.
+type AuthenticationSession struct {
+	// TokenLifetime is how long the ID tokens that Dex issues are valid, after which the Manager UI has to renew them
+	// with the identity provider. It can't be set when OIDC.Type is Tigera, since the identity provider issues the
+	// tokens then.
+	// Default: 15m
+	// +optional
+	TokenLifetime *metav1.Duration `json:"tokenLifetime,omitempty"`
+
+	// IdleTimeout logs users out of the Manager UI once they haven't used it for this long. The Manager UI logs out
+	// idle users, and Voltron rejects the requests of sessions that have been idle for longer.
+	// If omitted, users aren't logged out for being idle.
+	// +optional
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
 }
 
 // AuthenticationStatus defines the observed state of Authentication
@@ -67,6 +67,19 @@ type CalicoKubeControllersDeploymentPodSpec struct {
 	// WARNING: Please note that this field will override the default calico-kube-controllers Deployment tolerations.
 	// +optional
 	Tolerations []v1.Toleration `json:"tolerations"`
+
+	// DNSPolicy is the DNS policy of the pods.
+	// If specified, this overrides the DNS policy of the calico-kube-controllers Deployment.
+	// If omitted, the calico-kube-controllers Deployment will use its default DNS policy.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig specifies additional DNS parameters of the pods, such as nameservers, searches and options.
+	// It is merged with the DNS configuration generated from the DNS policy.
+	// If omitted, the calico-kube-controllers Deployment will not set any additional DNS parameters.
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
 }
 
 // CalicoKubeControllersDeploymentPodTemplateSpec is the calico-kube-controllers Deployment's PodTemplateSpec
@@ -206,3 +219,25 @@ func (c *CalicoKubeControllersDeployment) GetDeploymentStrategy() *appsv1.Deploy
 func (c *CalicoKubeControllersDeployment) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *CalicoKubeControllersDeployment) GetDNSPolicy() v1.DNSPolicy {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.DNSPolicy
+			}
+		}
+	}
+	return ""
+}
+
+func (c *CalicoKubeControllersDeployment) GetDNSConfig() *v1.PodDNSConfig {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.DNSConfig
+			}
+		}
+	}
+	return nil
+}
@@ -49,6 +49,23 @@ type CalicoNodeDaemonSetInitContainer struct {
 	// If used in conjunction with the deprecated ComponentResources, then this value takes precedence.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env allows customization of the named calico-node DaemonSet init container's environment variables.
+	// If specified, each variable is merged into the init container's environment by name, replacing any
+	// default value with the same name and appending the rest.
+	// If omitted, the calico-node DaemonSet will use its default environment for this init container.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+
+	// Image overrides the image of the named calico-node DaemonSet init container, for example to use a mirrored or patched image.
+	// If omitted, the calico-node DaemonSet will use its default image for this init container.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Disabled removes the named calico-node DaemonSet init container, for an init step that isn't needed in the cluster.
+	// If omitted, the calico-node DaemonSet will run this init container.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
 }
 
 // CalicoNodeDaemonSetPodSpec is the calico-node DaemonSet's PodSpec.
@@ -86,6 +103,19 @@ type CalicoNodeDaemonSetPodSpec struct {
 	// WARNING: Please note that this field will override the default calico-node DaemonSet tolerations.
 	// +optional
 	Tolerations []v1.Toleration `json:"tolerations"`
+
+	// DNSPolicy is the DNS policy of the pods.
+	// If specified, this overrides the DNS policy of the calico-node DaemonSet.
+	// If omitted, the calico-node DaemonSet will use its default DNS policy.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig specifies additional DNS parameters of the pods, such as nameservers, searches and options.
+	// It is merged with the DNS configuration generated from the DNS policy.
+	// If omitted, the calico-node DaemonSet will not set any additional DNS parameters.
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
 }
 
 // CalicoNodeDaemonSetPodTemplateSpec is the calico-node DaemonSet's PodTemplateSpec
@@ -154,11 +184,14 @@ func (c *CalicoNodeDaemonSet) GetInitContainers() []v1.Container {
 				if c.Spec.Template.Spec.InitContainers != nil {
 					cs := make([]v1.Container, len(c.Spec.Template.Spec.InitContainers))
 					for i, v := range c.Spec.Template.Spec.InitContainers {
-						// Only copy and return the container if it has resources set.
-						if v.Resources == nil {
+						// Only copy and return the init container if it has resources, env or an image set.
+						if v.Resources == nil && v.Env == nil && v.Image == "" {
 							continue
 						}
-						c := v1.Container{Name: v.Name, Resources: *v.Resources}
+						c := v1.Container{Name: v.Name, Env: v.Env, Image: v.Image}
+						if v.Resources != nil {
+							c.Resources = *v.Resources
+						}
 						cs[i] = c
 					}
 					return cs
@@ -169,6 +202,20 @@ func (c *CalicoNodeDaemonSet) GetInitContainers() []v1.Container {
 	return nil
 }
 
+// GetDisabledInitContainers returns the names of the init containers that are disabled.
+func (c *CalicoNodeDaemonSet) GetDisabledInitContainers() []string {
+	if c.Spec == nil || c.Spec.Template == nil || c.Spec.Template.Spec == nil {
+		return nil
+	}
+	var names []string
+	for _, v := range c.Spec.Template.Spec.InitContainers {
+		if v.Disabled != nil && *v.Disabled {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
 func (c *CalicoNodeDaemonSet) GetContainers() []v1.Container {
 	if c.Spec != nil {
 		if c.Spec.Template != nil {
@@ -240,3 +287,25 @@ func (c *CalicoNodeDaemonSet) GetDeploymentStrategy() *appsv1.DeploymentStrategy
 func (c *CalicoNodeDaemonSet) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *CalicoNodeDaemonSet) GetDNSPolicy() v1.DNSPolicy {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.DNSPolicy
+			}
+		}
+	}
+	return ""
+}
+
+func (c *CalicoNodeDaemonSet) GetDNSConfig() *v1.PodDNSConfig {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.DNSConfig
+			}
+		}
+	}
+	return nil
+}
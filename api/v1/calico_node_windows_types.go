@@ -49,6 +49,23 @@ type CalicoNodeWindowsDaemonSetInitContainer struct {
 	// If used in conjunction with the deprecated ComponentResources, then this value takes precedence.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env allows customization of the named calico-node-windows DaemonSet init container's environment variables.
+	// If specified, each variable is merged into the init container's environment by name, replacing any
+	// default value with the same name and appending the rest.
+	// If omitted, the calico-node-windows DaemonSet will use its default environment for this init container.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+
+	// Image overrides the image of the named calico-node-windows DaemonSet init container, for example to use a mirrored or patched image.
+	// If omitted, the calico-node-windows DaemonSet will use its default image for this init container.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Disabled removes the named calico-node-windows DaemonSet init container, for an init step that isn't needed in the cluster.
+	// If omitted, the calico-node-windows DaemonSet will run this init container.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
 }
 
 // CalicoNodeWindowsDaemonSetPodSpec is the calico-node-windows DaemonSet's PodSpec.
@@ -86,6 +103,19 @@ type CalicoNodeWindowsDaemonSetPodSpec struct {
 	// WARNING: Please note that this field will override the default calico-node-windows DaemonSet tolerations.
 	// +optional
 	Tolerations []v1.Toleration `json:"tolerations"`
+
+	// DNSPolicy is the DNS policy of the pods.
+	// If specified, this overrides the DNS policy of the calico-node-windows DaemonSet.
+	// If omitted, the calico-node-windows DaemonSet will use its default DNS policy.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig specifies additional DNS parameters of the pods, such as nameservers, searches and options.
+	// It is merged with the DNS configuration generated from the DNS policy.
+	// If omitted, the calico-node-windows DaemonSet will not set any additional DNS parameters.
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
 }
 
 // CalicoNodeWindowsDaemonSetPodTemplateSpec is the calico-node-windows DaemonSet's PodTemplateSpec
@@ -154,11 +184,14 @@ func (c *CalicoNodeWindowsDaemonSet) GetInitContainers() []v1.Container {
 				if c.Spec.Template.Spec.InitContainers != nil {
 					cs := make([]v1.Container, len(c.Spec.Template.Spec.InitContainers))
 					for i, v := range c.Spec.Template.Spec.InitContainers {
-						// Only copy and return the container if it has resources set.
-						if v.Resources == nil {
+						// Only copy and return the init container if it has resources, env or an image set.
+						if v.Resources == nil && v.Env == nil && v.Image == "" {
 							continue
 						}
-						c := v1.Container{Name: v.Name, Resources: *v.Resources}
+						c := v1.Container{Name: v.Name, Env: v.Env, Image: v.Image}
+						if v.Resources != nil {
+							c.Resources = *v.Resources
+						}
 						cs[i] = c
 					}
 					return cs
@@ -169,6 +202,20 @@ func (c *CalicoNodeWindowsDaemonSet) GetInitContainers() []v1.Container {
 	return nil
 }
 
+// GetDisabledInitContainers returns the names of the init containers that are disabled.
+func (c *CalicoNodeWindowsDaemonSet) GetDisabledInitContainers() []string {
+	if c.Spec == nil || c.Spec.Template == nil || c.Spec.Template.Spec == nil {
+		return nil
+	}
+	var names []string
+	for _, v := range c.Spec.Template.Spec.InitContainers {
+		if v.Disabled != nil && *v.Disabled {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
 func (c *CalicoNodeWindowsDaemonSet) GetContainers() []v1.Container {
 	if c.Spec != nil {
 		if c.Spec.Template != nil {
@@ -240,3 +287,25 @@ func (c *CalicoNodeWindowsDaemonSet) GetDeploymentStrategy() *appsv1.DeploymentS
 func (c *CalicoNodeWindowsDaemonSet) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *CalicoNodeWindowsDaemonSet) GetDNSPolicy() v1.DNSPolicy {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.DNSPolicy
+			}
+		}
+	}
+	return ""
+}
+
+func (c *CalicoNodeWindowsDaemonSet) GetDNSConfig() *v1.PodDNSConfig {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.DNSConfig
+			}
+		}
+	}
+	return nil
+}
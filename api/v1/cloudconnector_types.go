@@ -0,0 +1,218 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CloudConnectorSpec defines the desired state of CloudConnector.
+type CloudConnectorSpec struct {
+	// Endpoint is the https URL of the Calico Cloud service that the connector attaches the cluster to.
+	Endpoint string `json:"endpoint"`
+
+	// CredentialsSecretName is the name of the Secret in the tigera-operator namespace that holds the credentials
+	// the connector uses to authenticate the cluster with Calico Cloud. The operator copies the Secret into the
+	// connector's namespace and mounts it into the connector.
+	CredentialsSecretName string `json:"credentialsSecretName"`
+
+	// Proxy configures the connector to reach Calico Cloud through an HTTP proxy.
+	// +optional
+	Proxy *CloudConnectorProxy `json:"proxy,omitempty"`
+
+	// CloudConnectorDeployment configures the cloud connector Deployment.
+	// +optional
+	CloudConnectorDeployment *CloudConnectorDeployment `json:"cloudConnectorDeployment,omitempty"`
+}
+
+// CloudConnectorProxy configures the proxy that the connector uses for its outbound connections.
+type CloudConnectorProxy struct {
+	// HTTPSProxy is the URL of the proxy used for connections to Calico Cloud, for example
+	// "http://proxy.example.com:3128".
+	HTTPSProxy string `json:"httpsProxy"`
+
+	// NoProxy is a comma separated list of hosts, domains and CIDRs that the connector reaches without the proxy.
+	// The Kubernetes API server is always reached without the proxy.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// CloudConnectorDeployment is the configuration for the cloud connector Deployment.
+type CloudConnectorDeployment struct {
+
+	// Spec is the specification of the cloud connector Deployment.
+	// +optional
+	Spec *CloudConnectorDeploymentSpec `json:"spec,omitempty"`
+}
+
+// CloudConnectorDeploymentSpec defines configuration for the cloud connector Deployment.
+type CloudConnectorDeploymentSpec struct {
+
+	// Template describes the cloud connector Deployment pod that will be created.
+	// +optional
+	Template *CloudConnectorDeploymentPodTemplateSpec `json:"template,omitempty"`
+}
+
+// CloudConnectorDeploymentPodTemplateSpec is the cloud connector Deployment's PodTemplateSpec
+type CloudConnectorDeploymentPodTemplateSpec struct {
+
+	// Spec is the cloud connector Deployment's PodSpec.
+	// +optional
+	Spec *CloudConnectorDeploymentPodSpec `json:"spec,omitempty"`
+}
+
+// CloudConnectorDeploymentPodSpec is the cloud connector Deployment's PodSpec.
+type CloudConnectorDeploymentPodSpec struct {
+	// Containers is a list of cloud connector containers.
+	// If specified, this overrides the specified cloud connector Deployment containers.
+	// If omitted, the cloud connector Deployment will use its default values for its containers.
+	// +optional
+	Containers []CloudConnectorDeploymentContainer `json:"containers,omitempty"`
+}
+
+// CloudConnectorDeploymentContainer is a cloud connector Deployment container.
+type CloudConnectorDeploymentContainer struct {
+	// Name is an enum which identifies the cloud connector Deployment container by name.
+	// Supported values are: tigera-cloud-connector
+	// +kubebuilder:validation:Enum=tigera-cloud-connector
+	Name string `json:"name"`
+
+	// Resources allows customization of limits and requests for compute resources such as cpu and memory.
+	// If specified, this overrides the named cloud connector Deployment container's resources.
+	// If omitted, the cloud connector Deployment will use its default value for this container's resources.
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+
+// CloudConnector attaches the cluster to Calico Cloud. At most one instance of this resource is supported. It must be
+// named "tigera-secure".
+type CloudConnector struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired state for the CloudConnector.
+	Spec CloudConnectorSpec `json:"spec,omitempty"`
+	// Most recently observed state for the CloudConnector.
+	Status CloudConnectorStatus `json:"status,omitempty"`
+}
+
+// CloudConnectorStatus defines the observed state of CloudConnector.
+type CloudConnectorStatus struct {
+
+	// State provides user-readable status.
+	State string `json:"state,omitempty"`
+
+	// Conditions represents the latest observed set of conditions for the component. A component may be one or more of
+	// Ready, Progressing, Degraded or other customer types.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CloudConnectorList contains a list of CloudConnector
+type CloudConnectorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CloudConnector `json:"items"`
+}
+
+func (c *CloudConnectorDeployment) GetMetadata() *Metadata {
+	return nil
+}
+
+func (c *CloudConnectorDeployment) GetMinReadySeconds() *int32 {
+	return nil
+}
+
+func (c *CloudConnectorDeployment) GetPodTemplateMetadata() *Metadata {
+	return nil
+}
+
+func (c *CloudConnectorDeployment) GetInitContainers() []v1.Container {
+	return nil
+}
+
+func (c *CloudConnectorDeployment) GetContainers() []v1.Container {
+	if c != nil {
+		if c.Spec != nil {
+			if c.Spec.Template != nil {
+				if c.Spec.Template.Spec != nil {
+					if c.Spec.Template.Spec.Containers != nil {
+						cs := make([]v1.Container, len(c.Spec.Template.Spec.Containers))
+						for i, v := range c.Spec.Template.Spec.Containers {
+							// Only copy and return the container if it has resources set.
+							if v.Resources == nil {
+								continue
+							}
+							c := v1.Container{Name: v.Name, Resources: *v.Resources}
+							cs[i] = c
+						}
+						return cs
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (c *CloudConnectorDeployment) GetAffinity() *v1.Affinity {
+	return nil
+}
+
+func (c *CloudConnectorDeployment) GetTopologySpreadConstraints() []v1.TopologySpreadConstraint {
+	return nil
+}
+
+func (c *CloudConnectorDeployment) GetNodeSelector() map[string]string {
+	return nil
+}
+
+func (c *CloudConnectorDeployment) GetTolerations() []v1.Toleration {
+	return nil
+}
+
+func (c *CloudConnectorDeployment) GetTerminationGracePeriodSeconds() *int64 {
+	return nil
+}
+
+func (c *CloudConnectorDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
+	return nil
+}
+
+func (c *CloudConnectorDeployment) GetPriorityClassName() string {
+	return ""
+}
+
+func (c *CloudConnectorDeployment) GetDNSPolicy() v1.DNSPolicy {
+	return ""
+}
+
+func (c *CloudConnectorDeployment) GetDNSConfig() *v1.PodDNSConfig {
+	return nil
+}
+
+func init() {
+	SchemeBuilder.Register(&CloudConnector{}, &CloudConnectorList{})
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CloudProvider is a cloud provider whose published IP ranges can be turned into a network set.
+// +kubebuilder:validation:Enum=AWS;GCP
+type CloudProvider string
+
+const (
+	// CloudProviderAWS reads the ip-ranges.json format that AWS publishes its IP ranges in.
+	CloudProviderAWS CloudProvider = "AWS"
+
+	// CloudProviderGCP reads the cloud.json and goog.json formats that Google publishes its IP ranges in.
+	CloudProviderGCP CloudProvider = "GCP"
+)
+
+// CloudNetworkSetSpec defines the cloud provider IP ranges that the network set contains.
+type CloudNetworkSetSpec struct {
+	// Provider is the cloud provider whose IP ranges the network set contains. It determines the format of the feed
+	// and its default URL.
+	Provider CloudProvider `json:"provider"`
+
+	// Services limits the network set to the IP ranges of these services, e.g. S3 or EC2 for AWS. For GCP, the
+	// service of the ranges in cloud.json is "Google Cloud". If omitted, the ranges of every service are included.
+	// +optional
+	Services []string `json:"services,omitempty"`
+
+	// Regions limits the network set to the IP ranges of these regions, e.g. us-east-1 for AWS or us-east1 for GCP.
+	// If omitted, the ranges of every region are included.
+	// +optional
+	Regions []string `json:"regions,omitempty"`
+
+	// URL is the https URL of the feed of IP ranges. It can be used to read the feed from a mirror, or to read the
+	// ranges of Google APIs from https://www.gstatic.com/ipranges/goog.json, whose ranges have no service or region.
+	// Default for AWS: https://ip-ranges.amazonaws.com/ip-ranges.json
+	// Default for GCP: https://www.gstatic.com/ipranges/cloud.json
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// ChecksumURL is the https URL of the SHA-256 checksum of the feed, in the format written by sha256sum. If
+	// specified, the network set is only updated with a feed that matches its checksum.
+	// +optional
+	ChecksumURL string `json:"checksumURL,omitempty"`
+
+	// RefreshInterval is how often the feed is read to update the network set.
+	// Default: 24h
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// CloudNetworkSetStatus defines the observed state of the network set.
+type CloudNetworkSetStatus struct {
+	// LastRefreshTime is when the network set was last updated from the feed.
+	// +optional
+	LastRefreshTime *metav1.Time `json:"lastRefreshTime,omitempty"`
+
+	// Checksum is the SHA-256 checksum of the feed that the network set was last updated from.
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+
+	// Nets is the number of IP ranges in the network set.
+	// +optional
+	Nets int `json:"nets,omitempty"`
+
+	// Conditions represents the latest observed set of conditions for the network set. A network set that fails to
+	// refresh is Degraded and keeps the IP ranges of its last refresh.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.provider`
+// +kubebuilder:printcolumn:name="Nets",type=integer,JSONPath=`.status.nets`
+// +kubebuilder:printcolumn:name="Last Refresh",type=date,JSONPath=`.status.lastRefreshTime`
+
+// CloudNetworkSet is the Schema for the cloudnetworksets API. The operator keeps a GlobalNetworkSet with the same
+// name up to date with the IP ranges that the cloud provider publishes for the selected services and regions, so
+// that policy can allow egress to them.
+type CloudNetworkSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CloudNetworkSetSpec   `json:"spec,omitempty"`
+	Status CloudNetworkSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CloudNetworkSetList contains a list of CloudNetworkSet
+type CloudNetworkSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CloudNetworkSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CloudNetworkSet{}, &CloudNetworkSetList{})
+}
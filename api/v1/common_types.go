@@ -16,6 +16,11 @@ limitations under the License.
 
 package v1
 
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // Metadata contains the standard Kubernetes labels and annotations fields.
 type Metadata struct {
 	// Labels is a map of string keys and values that may match replicaset and
@@ -31,6 +36,98 @@ type Metadata struct {
 	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
+// ProbeTimings customizes the timing of a probe that the operator renders for a container. The probe's
+// handler is always set by the operator.
+type ProbeTimings struct {
+	// InitialDelaySeconds is the number of seconds after the container has started before the probe is initiated.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// PeriodSeconds is how often (in seconds) to perform the probe.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// TimeoutSeconds is the number of seconds after which the probe times out.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailureThreshold is the number of consecutive failures for the probe to be considered failed.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// Probe returns a probe with only the specified timings set.
+func (p *ProbeTimings) Probe() *v1.Probe {
+	if p == nil {
+		return nil
+	}
+	probe := &v1.Probe{}
+	if p.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *p.InitialDelaySeconds
+	}
+	if p.PeriodSeconds != nil {
+		probe.PeriodSeconds = *p.PeriodSeconds
+	}
+	if p.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *p.TimeoutSeconds
+	}
+	if p.FailureThreshold != nil {
+		probe.FailureThreshold = *p.FailureThreshold
+	}
+	return probe
+}
+
+// ServiceOptions customizes a Service that the operator renders for a component.
+type ServiceOptions struct {
+	// Type determines how the Service is exposed.
+	// If omitted, the Service is of type ClusterIP.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	Type *v1.ServiceType `json:"type,omitempty"`
+
+	// SessionAffinity is set on the Service. ClientIP keeps the requests of a client on the same pod, which
+	// avoids breaking long-lived connections such as websockets when the component is scaled.
+	// If omitted, the Service has no session affinity.
+	// +optional
+	// +kubebuilder:validation:Enum=None;ClientIP
+	SessionAffinity *v1.ServiceAffinity `json:"sessionAffinity,omitempty"`
+
+	// ExternalTrafficPolicy is set on the Service. It may only be specified when Type is NodePort or LoadBalancer.
+	// If omitted, the Kubernetes default of Cluster is used.
+	// +optional
+	// +kubebuilder:validation:Enum=Cluster;Local
+	ExternalTrafficPolicy *v1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+
+	// Headless renders the Service without a cluster IP, so that its DNS name resolves to the addresses of the
+	// component's pods. It may only be set when Type is ClusterIP.
+	// If omitted, the Service is given a cluster IP.
+	// +optional
+	Headless *bool `json:"headless,omitempty"`
+
+	// Metadata is a set of labels and annotations that are added to the Service, such as the annotations that
+	// cloud providers use to configure a LoadBalancer (for example, to make it internal or to choose its subnet).
+	// Labels and annotations that the operator sets on the Service take precedence.
+	// +optional
+	Metadata *Metadata `json:"metadata,omitempty"`
+}
+
+// RolloutStatus summarizes the rollout of the deployments and daemonsets rendered for a component. For daemonsets,
+// each scheduled pod counts as a replica.
+type RolloutStatus struct {
+	// DesiredReplicas is the number of replicas that the component's workloads should be running.
+	DesiredReplicas int32 `json:"desiredReplicas"`
+
+	// UpdatedReplicas is the number of replicas that are running the latest pod template.
+	UpdatedReplicas int32 `json:"updatedReplicas"`
+
+	// AvailableReplicas is the number of replicas that are available.
+	AvailableReplicas int32 `json:"availableReplicas"`
+}
+
 type LogLevel string
 
 const (
@@ -41,3 +138,14 @@ const (
 	LogLevelFatal LogLevel = "Fatal"
 	LogLevelError LogLevel = "Error"
 )
+
+// MaintenanceWindow is a recurring period during which the operator may roll out disruptive changes, such as
+// changes that restart the pods of a DaemonSet or that cause a rolling restart of Elasticsearch.
+type MaintenanceWindow struct {
+	// Schedule is a cron expression, in UTC, for the times at which the window opens. It has five fields: minute,
+	// hour, day of month, month and day of week. For example, "0 2 * * 6" opens the window at 02:00 every Saturday.
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window stays open. It must be at least one minute and at most 7 days.
+	Duration metav1.Duration `json:"duration"`
+}
@@ -58,6 +58,43 @@ type ComplianceControllerDeploymentPodSpec struct {
 	// If omitted, the compliance controller Deployment will use its default values for its containers.
 	// +optional
 	Containers []ComplianceControllerDeploymentContainer `json:"containers,omitempty"`
+
+	// NodeSelector is the compliance controller pod's scheduling constraints.
+	// If specified, each of the key/value pairs are added to the compliance controller Deployment nodeSelector provided
+	// the key does not already exist in the object's nodeSelector.
+	// If used in conjunction with ControlPlaneNodeSelector, that nodeSelector is set on the compliance controller Deployment
+	// and each of this field's key/value pairs are added to the compliance controller Deployment nodeSelector provided
+	// the key does not already exist in the object's nodeSelector.
+	// If omitted, the compliance controller Deployment will use its default value for nodeSelector.
+	// WARNING: Please note that this field will modify the default compliance controller Deployment nodeSelector.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations is the compliance controller pod's tolerations.
+	// If specified, this overrides any tolerations that may be set on the compliance controller Deployment.
+	// If omitted, the compliance controller Deployment will use its default value for tolerations.
+	// WARNING: Please note that this field will override the default compliance controller Deployment tolerations.
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+
+	// TopologySpreadConstraints describes how the compliance controller pods ought to spread across topology domains, such as zones.
+	// If specified, this overrides the topology spread constraints of the compliance controller Deployment.
+	// If omitted, the compliance controller Deployment will not have any topology spread constraints.
+	// +optional
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// DNSPolicy is the DNS policy of the pods.
+	// If specified, this overrides the DNS policy of the compliance controller Deployment.
+	// If omitted, the compliance controller Deployment will use its default DNS policy.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig specifies additional DNS parameters of the pods, such as nameservers, searches and options.
+	// It is merged with the DNS configuration generated from the DNS policy.
+	// If omitted, the compliance controller Deployment will not set any additional DNS parameters.
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
 }
 
 // ComplianceControllerDeploymentContainer is a compliance controller Deployment container.
@@ -86,6 +123,23 @@ type ComplianceControllerDeploymentInitContainer struct {
 	// If omitted, the compliance controller Deployment will use its default value for this init container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env allows customization of the named compliance controller Deployment init container's environment variables.
+	// If specified, each variable is merged into the init container's environment by name, replacing any
+	// default value with the same name and appending the rest.
+	// If omitted, the compliance controller Deployment will use its default environment for this init container.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+
+	// Image overrides the image of the named compliance controller Deployment init container, for example to use a mirrored or patched image.
+	// If omitted, the compliance controller Deployment will use its default image for this init container.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Disabled removes the named compliance controller Deployment init container, for an init step that isn't needed in the cluster.
+	// If omitted, the compliance controller Deployment will run this init container.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
 }
 
 func (c *ComplianceControllerDeployment) GetMetadata() *Metadata {
@@ -107,11 +161,14 @@ func (c *ComplianceControllerDeployment) GetInitContainers() []v1.Container {
 				if c.Spec.Template.Spec.InitContainers != nil {
 					cs := make([]v1.Container, len(c.Spec.Template.Spec.InitContainers))
 					for i, v := range c.Spec.Template.Spec.InitContainers {
-						// Only copy and return the init container if it has resources set.
-						if v.Resources == nil {
+						// Only copy and return the init container if it has resources, env or an image set.
+						if v.Resources == nil && v.Env == nil && v.Image == "" {
 							continue
 						}
-						c := v1.Container{Name: v.Name, Resources: *v.Resources}
+						c := v1.Container{Name: v.Name, Env: v.Env, Image: v.Image}
+						if v.Resources != nil {
+							c.Resources = *v.Resources
+						}
 						cs[i] = c
 					}
 					return cs
@@ -122,6 +179,20 @@ func (c *ComplianceControllerDeployment) GetInitContainers() []v1.Container {
 	return nil
 }
 
+// GetDisabledInitContainers returns the names of the init containers that are disabled.
+func (c *ComplianceControllerDeployment) GetDisabledInitContainers() []string {
+	if c.Spec == nil || c.Spec.Template == nil || c.Spec.Template.Spec == nil {
+		return nil
+	}
+	var names []string
+	for _, v := range c.Spec.Template.Spec.InitContainers {
+		if v.Disabled != nil && *v.Disabled {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
 func (c *ComplianceControllerDeployment) GetContainers() []v1.Container {
 	if c.Spec != nil {
 		if c.Spec.Template != nil {
@@ -149,14 +220,35 @@ func (c *ComplianceControllerDeployment) GetAffinity() *v1.Affinity {
 }
 
 func (c *ComplianceControllerDeployment) GetTopologySpreadConstraints() []v1.TopologySpreadConstraint {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.TopologySpreadConstraints
+			}
+		}
+	}
 	return nil
 }
 
 func (c *ComplianceControllerDeployment) GetNodeSelector() map[string]string {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.NodeSelector
+			}
+		}
+	}
 	return nil
 }
 
 func (c *ComplianceControllerDeployment) GetTolerations() []v1.Toleration {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.Tolerations
+			}
+		}
+	}
 	return nil
 }
 
@@ -171,3 +263,25 @@ func (c *ComplianceControllerDeployment) GetDeploymentStrategy() *appsv1.Deploym
 func (c *ComplianceControllerDeployment) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *ComplianceControllerDeployment) GetDNSPolicy() v1.DNSPolicy {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.DNSPolicy
+			}
+		}
+	}
+	return ""
+}
+
+func (c *ComplianceControllerDeployment) GetDNSConfig() *v1.PodDNSConfig {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.DNSConfig
+			}
+		}
+	}
+	return nil
+}
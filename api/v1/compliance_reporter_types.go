@@ -50,6 +50,19 @@ type ComplianceReporterPodSpec struct {
 	// If omitted, the ComplianceServer Deployment will use its default values for its containers.
 	// +optional
 	Containers []ComplianceReporterPodTemplateContainer `json:"containers,omitempty"`
+
+	// DNSPolicy is the DNS policy of the pods.
+	// If specified, this overrides the DNS policy of the ComplianceReporter PodSpec.
+	// If omitted, the ComplianceReporter PodSpec will use its default DNS policy.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig specifies additional DNS parameters of the pods, such as nameservers, searches and options.
+	// It is merged with the DNS configuration generated from the DNS policy.
+	// If omitted, the ComplianceReporter PodSpec will not set any additional DNS parameters.
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
 }
 
 // ComplianceReporterPodTemplateContainer is a ComplianceServer Deployment container.
@@ -78,6 +91,23 @@ type ComplianceReporterPodTemplateInitContainer struct {
 	// If omitted, the ComplianceServer Deployment will use its default value for this init container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env allows customization of the named ComplianceReporter PodSpec init container's environment variables.
+	// If specified, each variable is merged into the init container's environment by name, replacing any
+	// default value with the same name and appending the rest.
+	// If omitted, the ComplianceReporter PodSpec will use its default environment for this init container.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+
+	// Image overrides the image of the named ComplianceReporter PodSpec init container, for example to use a mirrored or patched image.
+	// If omitted, the ComplianceReporter PodSpec will use its default image for this init container.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Disabled removes the named ComplianceReporter PodSpec init container, for an init step that isn't needed in the cluster.
+	// If omitted, the ComplianceReporter PodSpec will run this init container.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
 }
 
 func (c *ComplianceReporterPodTemplate) GetMetadata() *Metadata {
@@ -98,11 +128,14 @@ func (c *ComplianceReporterPodTemplate) GetInitContainers() []v1.Container {
 			if c.Template.Spec.InitContainers != nil {
 				cs := make([]v1.Container, len(c.Template.Spec.InitContainers))
 				for i, v := range c.Template.Spec.InitContainers {
-					// Only copy and return the init container if it has resources set.
-					if v.Resources == nil {
+					// Only copy and return the init container if it has resources, env or an image set.
+					if v.Resources == nil && v.Env == nil && v.Image == "" {
 						continue
 					}
-					c := v1.Container{Name: v.Name, Resources: *v.Resources}
+					c := v1.Container{Name: v.Name, Env: v.Env, Image: v.Image}
+					if v.Resources != nil {
+						c.Resources = *v.Resources
+					}
 					cs[i] = c
 				}
 				return cs
@@ -112,6 +145,20 @@ func (c *ComplianceReporterPodTemplate) GetInitContainers() []v1.Container {
 	return nil
 }
 
+// GetDisabledInitContainers returns the names of the init containers that are disabled.
+func (c *ComplianceReporterPodTemplate) GetDisabledInitContainers() []string {
+	if c.Template == nil || c.Template.Spec == nil {
+		return nil
+	}
+	var names []string
+	for _, v := range c.Template.Spec.InitContainers {
+		if v.Disabled != nil && *v.Disabled {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
 func (c *ComplianceReporterPodTemplate) GetContainers() []v1.Container {
 	if c.Template != nil {
 		if c.Template.Spec != nil {
@@ -159,3 +206,21 @@ func (c *ComplianceReporterPodTemplate) GetDeploymentStrategy() *appsv1.Deployme
 func (c *ComplianceReporterPodTemplate) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *ComplianceReporterPodTemplate) GetDNSPolicy() v1.DNSPolicy {
+	if c.Template != nil {
+		if c.Template.Spec != nil {
+			return c.Template.Spec.DNSPolicy
+		}
+	}
+	return ""
+}
+
+func (c *ComplianceReporterPodTemplate) GetDNSConfig() *v1.PodDNSConfig {
+	if c.Template != nil {
+		if c.Template.Spec != nil {
+			return c.Template.Spec.DNSConfig
+		}
+	}
+	return nil
+}
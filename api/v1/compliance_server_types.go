@@ -58,6 +58,43 @@ type ComplianceServerDeploymentPodSpec struct {
 	// If omitted, the ComplianceServer Deployment will use its default values for its containers.
 	// +optional
 	Containers []ComplianceServerDeploymentContainer `json:"containers,omitempty"`
+
+	// NodeSelector is the ComplianceServer pod's scheduling constraints.
+	// If specified, each of the key/value pairs are added to the ComplianceServer Deployment nodeSelector provided
+	// the key does not already exist in the object's nodeSelector.
+	// If used in conjunction with ControlPlaneNodeSelector, that nodeSelector is set on the ComplianceServer Deployment
+	// and each of this field's key/value pairs are added to the ComplianceServer Deployment nodeSelector provided
+	// the key does not already exist in the object's nodeSelector.
+	// If omitted, the ComplianceServer Deployment will use its default value for nodeSelector.
+	// WARNING: Please note that this field will modify the default ComplianceServer Deployment nodeSelector.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations is the ComplianceServer pod's tolerations.
+	// If specified, this overrides any tolerations that may be set on the ComplianceServer Deployment.
+	// If omitted, the ComplianceServer Deployment will use its default value for tolerations.
+	// WARNING: Please note that this field will override the default ComplianceServer Deployment tolerations.
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+
+	// TopologySpreadConstraints describes how the ComplianceServer pods ought to spread across topology domains, such as zones.
+	// If specified, this overrides the topology spread constraints of the ComplianceServer Deployment.
+	// If omitted, the ComplianceServer Deployment will not have any topology spread constraints.
+	// +optional
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// DNSPolicy is the DNS policy of the pods.
+	// If specified, this overrides the DNS policy of the ComplianceServer Deployment.
+	// If omitted, the ComplianceServer Deployment will use its default DNS policy.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig specifies additional DNS parameters of the pods, such as nameservers, searches and options.
+	// It is merged with the DNS configuration generated from the DNS policy.
+	// If omitted, the ComplianceServer Deployment will not set any additional DNS parameters.
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
 }
 
 // ComplianceServerDeploymentContainer is a ComplianceServer Deployment container.
@@ -72,6 +109,26 @@ type ComplianceServerDeploymentContainer struct {
 	// If omitted, the ComplianceServer Deployment will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// StartupProbe allows customization of the timings of the named ComplianceServer Deployment container's startup probe.
+	// If specified, the given timings override the defaults of the startup probe rendered for this container.
+	// If omitted, the ComplianceServer Deployment will use its default startup probe timings for this container.
+	// +optional
+	StartupProbe *ProbeTimings `json:"startupProbe,omitempty"`
+
+	// LivenessProbe allows customization of the timings of the named ComplianceServer Deployment container's liveness
+	// probe.
+	// If specified, the given timings override the defaults of the liveness probe rendered for this container.
+	// If omitted, the ComplianceServer Deployment will use its default liveness probe timings for this container.
+	// +optional
+	LivenessProbe *ProbeTimings `json:"livenessProbe,omitempty"`
+
+	// ReadinessProbe allows customization of the timings of the named ComplianceServer Deployment container's
+	// readiness probe, which fails while the server can't reach its datastore.
+	// If specified, the given timings override the defaults of the readiness probe rendered for this container.
+	// If omitted, the ComplianceServer Deployment will use its default readiness probe timings for this container.
+	// +optional
+	ReadinessProbe *ProbeTimings `json:"readinessProbe,omitempty"`
 }
 
 // ComplianceServerDeploymentInitContainer is a ComplianceServer Deployment init container.
@@ -86,6 +143,23 @@ type ComplianceServerDeploymentInitContainer struct {
 	// If omitted, the ComplianceServer Deployment will use its default value for this init container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env allows customization of the named ComplianceServer Deployment init container's environment variables.
+	// If specified, each variable is merged into the init container's environment by name, replacing any
+	// default value with the same name and appending the rest.
+	// If omitted, the ComplianceServer Deployment will use its default environment for this init container.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+
+	// Image overrides the image of the named ComplianceServer Deployment init container, for example to use a mirrored or patched image.
+	// If omitted, the ComplianceServer Deployment will use its default image for this init container.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Disabled removes the named ComplianceServer Deployment init container, for an init step that isn't needed in the cluster.
+	// If omitted, the ComplianceServer Deployment will run this init container.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
 }
 
 func (c *ComplianceServerDeployment) GetMetadata() *Metadata {
@@ -107,11 +181,14 @@ func (c *ComplianceServerDeployment) GetInitContainers() []v1.Container {
 				if c.Spec.Template.Spec.InitContainers != nil {
 					cs := make([]v1.Container, len(c.Spec.Template.Spec.InitContainers))
 					for i, v := range c.Spec.Template.Spec.InitContainers {
-						// Only copy and return the init container if it has resources set.
-						if v.Resources == nil {
+						// Only copy and return the init container if it has resources, env or an image set.
+						if v.Resources == nil && v.Env == nil && v.Image == "" {
 							continue
 						}
-						c := v1.Container{Name: v.Name, Resources: *v.Resources}
+						c := v1.Container{Name: v.Name, Env: v.Env, Image: v.Image}
+						if v.Resources != nil {
+							c.Resources = *v.Resources
+						}
 						cs[i] = c
 					}
 					return cs
@@ -122,6 +199,20 @@ func (c *ComplianceServerDeployment) GetInitContainers() []v1.Container {
 	return nil
 }
 
+// GetDisabledInitContainers returns the names of the init containers that are disabled.
+func (c *ComplianceServerDeployment) GetDisabledInitContainers() []string {
+	if c.Spec == nil || c.Spec.Template == nil || c.Spec.Template.Spec == nil {
+		return nil
+	}
+	var names []string
+	for _, v := range c.Spec.Template.Spec.InitContainers {
+		if v.Disabled != nil && *v.Disabled {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
 func (c *ComplianceServerDeployment) GetContainers() []v1.Container {
 	if c.Spec != nil {
 		if c.Spec.Template != nil {
@@ -129,11 +220,19 @@ func (c *ComplianceServerDeployment) GetContainers() []v1.Container {
 				if c.Spec.Template.Spec.Containers != nil {
 					cs := make([]v1.Container, len(c.Spec.Template.Spec.Containers))
 					for i, v := range c.Spec.Template.Spec.Containers {
-						// Only copy and return the container if it has resources set.
-						if v.Resources == nil {
+						// Only copy and return the container if it has resources or probe timings set.
+						if v.Resources == nil && v.StartupProbe == nil && v.LivenessProbe == nil && v.ReadinessProbe == nil {
 							continue
 						}
-						c := v1.Container{Name: v.Name, Resources: *v.Resources}
+						c := v1.Container{
+							Name:           v.Name,
+							StartupProbe:   v.StartupProbe.Probe(),
+							LivenessProbe:  v.LivenessProbe.Probe(),
+							ReadinessProbe: v.ReadinessProbe.Probe(),
+						}
+						if v.Resources != nil {
+							c.Resources = *v.Resources
+						}
 						cs[i] = c
 					}
 					return cs
@@ -149,14 +248,35 @@ func (c *ComplianceServerDeployment) GetAffinity() *v1.Affinity {
 }
 
 func (c *ComplianceServerDeployment) GetTopologySpreadConstraints() []v1.TopologySpreadConstraint {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.TopologySpreadConstraints
+			}
+		}
+	}
 	return nil
 }
 
 func (c *ComplianceServerDeployment) GetNodeSelector() map[string]string {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.NodeSelector
+			}
+		}
+	}
 	return nil
 }
 
 func (c *ComplianceServerDeployment) GetTolerations() []v1.Toleration {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.Tolerations
+			}
+		}
+	}
 	return nil
 }
 
@@ -171,3 +291,25 @@ func (c *ComplianceServerDeployment) GetDeploymentStrategy() *appsv1.DeploymentS
 func (c *ComplianceServerDeployment) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *ComplianceServerDeployment) GetDNSPolicy() v1.DNSPolicy {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.DNSPolicy
+			}
+		}
+	}
+	return ""
+}
+
+func (c *ComplianceServerDeployment) GetDNSConfig() *v1.PodDNSConfig {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.DNSConfig
+			}
+		}
+	}
+	return nil
+}
@@ -42,6 +42,251 @@ type ComplianceSpec struct {
 	// ComplianceReporterPodTemplate configures the Compliance Reporter PodTemplate.
 	// +optional
 	ComplianceReporterPodTemplate *ComplianceReporterPodTemplate `json:"complianceReporterPodTemplate,omitempty"`
+
+	// ComplianceServerService configures the Compliance Server Service.
+	// +optional
+	ComplianceServerService *ServiceOptions `json:"complianceServerService,omitempty"`
+
+	// ComplianceServerCertificate configures the TLS certificate of the compliance server.
+	// +optional
+	ComplianceServerCertificate *ComplianceServerCertificate `json:"complianceServerCertificate,omitempty"`
+
+	// SIEMExport configures a scheduled export of compliance report summaries to object storage for SIEM ingestion.
+	// +optional
+	SIEMExport *SIEMExport `json:"siemExport,omitempty"`
+
+	// ReportSigning configures the compliance server to sign the reports that it serves for download, so that their
+	// integrity can be verified. The public key to verify the signatures with is published in the status.
+	// +optional
+	ReportSigning *ReportSigning `json:"reportSigning,omitempty"`
+
+	// TimeZone is the IANA time zone, for example America/New_York, that the daily snapshot hour and the schedules of
+	// GlobalReports are interpreted in. The time zone is set as the TZ of the compliance components that schedule and
+	// generate reports, and of the SIEM export CronJob. If it isn't specified, UTC is used.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// SnapshotHour is the hour of the day, in the TimeZone, at which the compliance snapshotter takes its daily
+	// snapshot of the cluster's resources.
+	// Default: 0
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=23
+	SnapshotHour *int32 `json:"snapshotHour,omitempty"`
+
+	// CISBenchmark configures which sections of the CIS benchmark the compliance benchmarker runs.
+	// +optional
+	CISBenchmark *CISBenchmark `json:"cisBenchmark,omitempty"`
+
+	// SnapshotScope limits the resources that the compliance snapshotter records, which reduces the size of the
+	// snapshot indices on large clusters. Reports only cover the resources that are recorded.
+	// +optional
+	SnapshotScope *SnapshotScope `json:"snapshotScope,omitempty"`
+
+	// ComplianceServerQueryLimits configures how the compliance server pages and limits the queries for reports.
+	// +optional
+	ComplianceServerQueryLimits *ComplianceServerQueryLimits `json:"complianceServerQueryLimits,omitempty"`
+
+	// PriorityClassName is the PriorityClass of the compliance pods: the controller, snapshotter and server, the
+	// benchmarker on every node and the reporters. Use it to keep the compliance pods from being evicted before other
+	// workloads when nodes are under pressure. If it isn't specified, the pods have the default priority.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// ReportRetention configures how long reports are kept in Elasticsearch and the object storage that they are
+	// archived to. It is not supported in managed clusters or multi-tenant management clusters.
+	// +optional
+	ReportRetention *ReportRetention `json:"reportRetention,omitempty"`
+
+	// Logging configures the log levels of the compliance components.
+	// +optional
+	Logging *ComplianceLogging `json:"logging,omitempty"`
+}
+
+// ComplianceLogging configures the log levels of the compliance components. The level of a component overrides the
+// LogSeverity of all components.
+type ComplianceLogging struct {
+	// LogSeverity is the log level of all compliance components.
+	// Default: Info
+	// +kubebuilder:validation:Enum=Trace;Debug;Info;Warn;Error;Fatal
+	// +optional
+	LogSeverity *LogLevel `json:"logSeverity,omitempty"`
+
+	// Controller is the log level of the compliance controller.
+	// +kubebuilder:validation:Enum=Trace;Debug;Info;Warn;Error;Fatal
+	// +optional
+	Controller *LogLevel `json:"controller,omitempty"`
+
+	// Snapshotter is the log level of the compliance snapshotter.
+	// +kubebuilder:validation:Enum=Trace;Debug;Info;Warn;Error;Fatal
+	// +optional
+	Snapshotter *LogLevel `json:"snapshotter,omitempty"`
+
+	// Benchmarker is the log level of the compliance benchmarker.
+	// +kubebuilder:validation:Enum=Trace;Debug;Info;Warn;Error;Fatal
+	// +optional
+	Benchmarker *LogLevel `json:"benchmarker,omitempty"`
+
+	// Server is the log level of the compliance server.
+	// +kubebuilder:validation:Enum=Trace;Debug;Info;Warn;Error;Fatal
+	// +optional
+	Server *LogLevel `json:"server,omitempty"`
+
+	// Reporter is the log level of the compliance reporter.
+	// +kubebuilder:validation:Enum=Trace;Debug;Info;Warn;Error;Fatal
+	// +optional
+	Reporter *LogLevel `json:"reporter,omitempty"`
+}
+
+// ReportRetention configures the retention of compliance reports.
+type ReportRetention struct {
+	// Duration is how long reports are kept in Elasticsearch after they are generated. The compliance server deletes
+	// older reports, after they are archived if an Archive is configured. It should be shorter than the
+	// ComplianceReports retention of the LogStorage, which deletes the reports otherwise. If it isn't specified, only
+	// the retention of the LogStorage applies.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Archive configures the object storage that the reporter archives each report to when it is generated, so that
+	// reports are kept after they are deleted from Elasticsearch.
+	// +optional
+	Archive *ReportArchive `json:"archive,omitempty"`
+}
+
+type ReportArchiveProvider string
+
+const (
+	// ReportArchiveProviderS3 archives reports to AWS S3 or an S3 compatible object storage service.
+	ReportArchiveProviderS3 ReportArchiveProvider = "S3"
+	// ReportArchiveProviderGCS archives reports to Google Cloud Storage.
+	ReportArchiveProviderGCS ReportArchiveProvider = "GCS"
+)
+
+// ReportArchive is the object storage bucket that reports are archived to.
+type ReportArchive struct {
+	// Provider is the object storage service of the bucket.
+	// +kubebuilder:validation:Enum=S3;GCS
+	Provider ReportArchiveProvider `json:"provider"`
+
+	// Bucket is the name of the bucket.
+	// +kubebuilder:validation:MinLength=1
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to the keys of the archived reports.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Region is the region of an S3 bucket.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Endpoint is the https URL of an S3 compatible object storage service. If omitted, AWS S3 is used.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// CredentialsSecretName is the name of the Secret in the tigera-operator namespace that holds the credentials of
+	// the bucket. For S3, it holds the access key under the access-key-id and secret-access-key keys. For GCS, it holds
+	// the JSON key of a service account under the key.json key.
+	// +kubebuilder:validation:MinLength=1
+	CredentialsSecretName string `json:"credentialsSecretName"`
+}
+
+// ComplianceServerCertificate configures the DNS names of the TLS certificate of the compliance server. Users can
+// provide their own certificate in the tigera-compliance-server-tls secret of the tigera-operator namespace instead of
+// the one that the operator issues. Such a certificate must include the DNS names of the compliance server service
+// and the ExtraDNSNames, otherwise the Compliance is degraded.
+type ComplianceServerCertificate struct {
+	// ExtraDNSNames are added to the DNS names of the certificate of the compliance server, for example the hostname
+	// of an external load balancer in front of it.
+	// +optional
+	ExtraDNSNames []string `json:"extraDNSNames,omitempty"`
+}
+
+// ComplianceServerQueryLimits configures the limits of the queries that the compliance server serves. If a limit
+// isn't specified, the default of the compliance server is used.
+type ComplianceServerQueryLimits struct {
+	// PageSize is the number of reports that the compliance server returns per page when listing reports.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10000
+	PageSize *int32 `json:"pageSize,omitempty"`
+
+	// QueryTimeout is how long the compliance server waits for a query for reports before it fails the request.
+	// +optional
+	QueryTimeout *metav1.Duration `json:"queryTimeout,omitempty"`
+
+	// MaxConcurrentDownloads is the number of report downloads that the compliance server serves at the same time.
+	// Further downloads are rejected until one completes.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentDownloads *int32 `json:"maxConcurrentDownloads,omitempty"`
+}
+
+// SnapshotResource is a resource that the compliance snapshotter records, as its plural name and API group.
+// +kubebuilder:validation:Enum=pods;namespaces;serviceaccounts;services;endpoints;nodes;networkpolicies.networking.k8s.io;tiers.projectcalico.org;globalnetworkpolicies.projectcalico.org;networkpolicies.projectcalico.org;stagedglobalnetworkpolicies.projectcalico.org;stagednetworkpolicies.projectcalico.org;stagedkubernetesnetworkpolicies.projectcalico.org;hostendpoints.projectcalico.org;globalnetworksets.projectcalico.org;networksets.projectcalico.org
+type SnapshotResource string
+
+// SnapshotScope selects the namespaces and resources that the compliance snapshotter records.
+type SnapshotScope struct {
+	// NamespaceSelector selects the namespaces whose resources are recorded. If it isn't specified, the resources of
+	// all namespaces are recorded. Cluster scoped resources are not affected by the namespace selectors.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// ExcludeNamespaceSelector selects namespaces whose resources are not recorded, even if they are selected by the
+	// NamespaceSelector.
+	// +optional
+	ExcludeNamespaceSelector *metav1.LabelSelector `json:"excludeNamespaceSelector,omitempty"`
+
+	// Resources lists the resources that are recorded. If it isn't specified, all resources are recorded.
+	// +optional
+	Resources []SnapshotResource `json:"resources,omitempty"`
+}
+
+type ControlPlaneChecks string
+
+const (
+	// ControlPlaneChecksAuto skips the control plane checks on managed Kubernetes services (AKS, EKS and GKE) and
+	// when the control plane is hosted outside of the cluster, and runs them otherwise.
+	ControlPlaneChecksAuto ControlPlaneChecks = "Auto"
+	// ControlPlaneChecksEnabled always runs the control plane checks.
+	ControlPlaneChecksEnabled ControlPlaneChecks = "Enabled"
+	// ControlPlaneChecksDisabled never runs the control plane checks.
+	ControlPlaneChecksDisabled ControlPlaneChecks = "Disabled"
+)
+
+// CISBenchmark configures the checks of the CIS benchmark.
+type CISBenchmark struct {
+	// ControlPlaneChecks controls whether the control plane sections of the benchmark, which cover the control plane
+	// components, etcd and the control plane configuration, are run. These can't be benchmarked on the nodes of a
+	// managed Kubernetes service, so they only clutter its reports with failures.
+	// Default: Auto
+	// +optional
+	// +kubebuilder:validation:Enum=Auto;Enabled;Disabled
+	ControlPlaneChecks *ControlPlaneChecks `json:"controlPlaneChecks,omitempty"`
+
+	// SkipChecks lists additional sections or checks of the benchmark to skip, by their number, for example 4.2 or
+	// 4.2.6.
+	// +optional
+	SkipChecks []string `json:"skipChecks,omitempty"`
+
+	// BenchmarkVersion is the variant and version of the CIS benchmark that the benchmarker runs, for example
+	// cis-1.23, eks-1.2.0, gke-1.4.0 or aks-1.0. If it isn't specified, the variant for the managed Kubernetes
+	// service of the cluster is used on AKS, EKS and GKE, and the benchmarker picks the version that matches the
+	// Kubernetes version of the cluster otherwise.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]+(-[0-9]+(\.[0-9]+)*)?$`
+	BenchmarkVersion string `json:"benchmarkVersion,omitempty"`
+}
+
+// ReportSigning configures the key that downloaded compliance reports are signed with. Each download is accompanied
+// by a detached signature of its content.
+type ReportSigning struct {
+	// KeySecretName is the name of a secret in the tigera-operator namespace that holds the private key to sign
+	// reports with, as a PEM encoded PKCS #8 Ed25519 or ECDSA key under the signing.key key. If it isn't specified,
+	// the operator generates and manages an Ed25519 key.
+	// +optional
+	KeySecretName string `json:"keySecretName,omitempty"`
 }
 
 // ComplianceStatus defines the observed state of Tigera compliance reporting capabilities.
@@ -54,6 +299,19 @@ type ComplianceStatus struct {
 	// Ready, Progressing, Degraded or other customer types.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the resource that the operator has reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Rollout summarizes the rollout of the component's workloads.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+
+	// ReportSigningPublicKey is the PEM encoded public key that the signatures of downloaded reports can be verified
+	// with. It is empty if report signing isn't enabled.
+	// +optional
+	ReportSigningPublicKey string `json:"reportSigningPublicKey,omitempty"`
 }
 
 // +kubebuilder:object:root=true
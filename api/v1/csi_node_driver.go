@@ -63,6 +63,19 @@ type CSINodeDriverDaemonSetPodSpec struct {
 	// WARNING: Please note that this field will override the default csi-node-driver DaemonSet tolerations.
 	// +optional
 	Tolerations []v1.Toleration `json:"tolerations"`
+
+	// DNSPolicy is the DNS policy of the pods.
+	// If specified, this overrides the DNS policy of the csi-node-driver DaemonSet.
+	// If omitted, the csi-node-driver DaemonSet will use its default DNS policy.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig specifies additional DNS parameters of the pods, such as nameservers, searches and options.
+	// It is merged with the DNS configuration generated from the DNS policy.
+	// If omitted, the csi-node-driver DaemonSet will not set any additional DNS parameters.
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
 }
 
 // CSINodeDriverDaemonSetPodTemplateSpec is the csi-node-driver DaemonSet's PodTemplateSpec
@@ -199,3 +212,25 @@ func (c *CSINodeDriverDaemonSet) GetDeploymentStrategy() *appsv1.DeploymentStrat
 func (c *CSINodeDriverDaemonSet) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *CSINodeDriverDaemonSet) GetDNSPolicy() v1.DNSPolicy {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.DNSPolicy
+			}
+		}
+	}
+	return ""
+}
+
+func (c *CSINodeDriverDaemonSet) GetDNSConfig() *v1.PodDNSConfig {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.DNSConfig
+			}
+		}
+	}
+	return nil
+}
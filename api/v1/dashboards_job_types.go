@@ -95,6 +95,28 @@ func (in *DashboardsJob) GetPriorityClassName() string {
 	return ""
 }
 
+func (in *DashboardsJob) GetDNSPolicy() v1.DNSPolicy {
+	if in.Spec != nil {
+		if in.Spec.Template != nil {
+			if in.Spec.Template.Spec != nil {
+				return in.Spec.Template.Spec.DNSPolicy
+			}
+		}
+	}
+	return ""
+}
+
+func (in *DashboardsJob) GetDNSConfig() *v1.PodDNSConfig {
+	if in.Spec != nil {
+		if in.Spec.Template != nil {
+			if in.Spec.Template.Spec != nil {
+				return in.Spec.Template.Spec.DNSConfig
+			}
+		}
+	}
+	return nil
+}
+
 // DashboardsJobSpec defines configuration for the Dashboards job.
 type DashboardsJobSpec struct {
 
@@ -119,6 +141,19 @@ type DashboardsJobPodSpec struct {
 	// If omitted, the Dashboard job will use its default values for its containers.
 	// +optional
 	Containers []DashboardsJobContainer `json:"containers,omitempty"`
+
+	// DNSPolicy is the DNS policy of the pods.
+	// If specified, this overrides the DNS policy of the Dashboard job.
+	// If omitted, the Dashboard job will use its default DNS policy.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig specifies additional DNS parameters of the pods, such as nameservers, searches and options.
+	// It is merged with the DNS configuration generated from the DNS policy.
+	// If omitted, the Dashboard job will not set any additional DNS parameters.
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
 }
 
 // DashboardsJobContainer is the Dashboards job container.
@@ -58,6 +58,25 @@ type DexDeploymentPodSpec struct {
 	// If omitted, the Dex Deployment will use its default values for its containers.
 	// +optional
 	Containers []DexDeploymentContainer `json:"containers,omitempty"`
+
+	// TopologySpreadConstraints describes how the Dex pods ought to spread across topology domains, such as zones.
+	// If specified, this overrides the topology spread constraints of the Dex Deployment.
+	// If omitted, the Dex Deployment will not have any topology spread constraints.
+	// +optional
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// DNSPolicy is the DNS policy of the pods.
+	// If specified, this overrides the DNS policy of the Dex Deployment.
+	// If omitted, the Dex Deployment will use its default DNS policy.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig specifies additional DNS parameters of the pods, such as nameservers, searches and options.
+	// It is merged with the DNS configuration generated from the DNS policy.
+	// If omitted, the Dex Deployment will not set any additional DNS parameters.
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
 }
 
 // DexDeploymentContainer is a Dex Deployment container.
@@ -86,6 +105,23 @@ type DexDeploymentInitContainer struct {
 	// If omitted, the Dex Deployment will use its default value for this init container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env allows customization of the named Dex Deployment init container's environment variables.
+	// If specified, each variable is merged into the init container's environment by name, replacing any
+	// default value with the same name and appending the rest.
+	// If omitted, the Dex Deployment will use its default environment for this init container.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+
+	// Image overrides the image of the named Dex Deployment init container, for example to use a mirrored or patched image.
+	// If omitted, the Dex Deployment will use its default image for this init container.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Disabled removes the named Dex Deployment init container, for an init step that isn't needed in the cluster.
+	// If omitted, the Dex Deployment will run this init container.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
 }
 
 func (c *DexDeployment) GetMetadata() *Metadata {
@@ -107,11 +143,14 @@ func (c *DexDeployment) GetInitContainers() []v1.Container {
 				if c.Spec.Template.Spec.InitContainers != nil {
 					cs := make([]v1.Container, len(c.Spec.Template.Spec.InitContainers))
 					for i, v := range c.Spec.Template.Spec.InitContainers {
-						// Only copy and return the init container if it has resources set.
-						if v.Resources == nil {
+						// Only copy and return the init container if it has resources, env or an image set.
+						if v.Resources == nil && v.Env == nil && v.Image == "" {
 							continue
 						}
-						c := v1.Container{Name: v.Name, Resources: *v.Resources}
+						c := v1.Container{Name: v.Name, Env: v.Env, Image: v.Image}
+						if v.Resources != nil {
+							c.Resources = *v.Resources
+						}
 						cs[i] = c
 					}
 					return cs
@@ -123,6 +162,20 @@ func (c *DexDeployment) GetInitContainers() []v1.Container {
 	return nil
 }
 
+// GetDisabledInitContainers returns the names of the init containers that are disabled.
+func (c *DexDeployment) GetDisabledInitContainers() []string {
+	if c.Spec == nil || c.Spec.Template == nil || c.Spec.Template.Spec == nil {
+		return nil
+	}
+	var names []string
+	for _, v := range c.Spec.Template.Spec.InitContainers {
+		if v.Disabled != nil && *v.Disabled {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
 func (c *DexDeployment) GetContainers() []v1.Container {
 	if c != nil {
 		if c.Spec != nil {
@@ -152,6 +205,13 @@ func (c *DexDeployment) GetAffinity() *v1.Affinity {
 }
 
 func (c *DexDeployment) GetTopologySpreadConstraints() []v1.TopologySpreadConstraint {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.TopologySpreadConstraints
+			}
+		}
+	}
 	return nil
 }
 
@@ -174,3 +234,29 @@ func (c *DexDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy {
 func (c *DexDeployment) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *DexDeployment) GetDNSPolicy() v1.DNSPolicy {
+	if c != nil {
+		if c.Spec != nil {
+			if c.Spec.Template != nil {
+				if c.Spec.Template.Spec != nil {
+					return c.Spec.Template.Spec.DNSPolicy
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func (c *DexDeployment) GetDNSConfig() *v1.PodDNSConfig {
+	if c != nil {
+		if c.Spec != nil {
+			if c.Spec.Template != nil {
+				if c.Spec.Template.Spec != nil {
+					return c.Spec.Template.Spec.DNSConfig
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -50,6 +50,23 @@ type EGWDeploymentInitContainer struct {
 	// If used in conjunction with the deprecated ComponentResources, then this value takes precedence.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env allows customization of the named EGW Deployment init container's environment variables.
+	// If specified, each variable is merged into the init container's environment by name, replacing any
+	// default value with the same name and appending the rest.
+	// If omitted, the EGW Deployment will use its default environment for this init container.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+
+	// Image overrides the image of the named EGW Deployment init container, for example to use a mirrored or patched image.
+	// If omitted, the EGW Deployment will use its default image for this init container.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Disabled removes the named EGW Deployment init container, for an init step that isn't needed in the cluster.
+	// If omitted, the EGW Deployment will run this init container.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
 }
 
 // EgressGatewaySpec defines the desired state of EgressGateway
@@ -137,6 +154,19 @@ type EgressGatewayDeploymentPodSpec struct {
 	// PriorityClassName allows to specify a PriorityClass resource to be used.
 	// +optional
 	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// DNSPolicy is the DNS policy of the pods.
+	// If specified, this overrides the DNS policy of the EGW Deployment.
+	// If omitted, the EGW Deployment will use its default DNS policy.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig specifies additional DNS parameters of the pods, such as nameservers, searches and options.
+	// It is merged with the DNS configuration generated from the DNS policy.
+	// If omitted, the EGW Deployment will not set any additional DNS parameters.
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
 }
 
 // EgressGatewayDeploymentPodTemplateSpec is the EGW Deployment's PodTemplateSpec
@@ -355,11 +385,14 @@ func (c *EgressGateway) GetInitContainers() []v1.Container {
 			if c.Spec.Template.Spec.InitContainers != nil {
 				cs := make([]v1.Container, len(c.Spec.Template.Spec.InitContainers))
 				for i, v := range c.Spec.Template.Spec.InitContainers {
-					// Only copy and return the init container if it has resources set.
-					if v.Resources == nil {
+					// Only copy and return the init container if it has resources, env or an image set.
+					if v.Resources == nil && v.Env == nil && v.Image == "" {
 						continue
 					}
-					c := v1.Container{Name: v.Name, Resources: *v.Resources}
+					c := v1.Container{Name: v.Name, Env: v.Env, Image: v.Image}
+					if v.Resources != nil {
+						c.Resources = *v.Resources
+					}
 					cs[i] = c
 				}
 				return cs
@@ -369,6 +402,20 @@ func (c *EgressGateway) GetInitContainers() []v1.Container {
 	return nil
 }
 
+// GetDisabledInitContainers returns the names of the init containers that are disabled.
+func (c *EgressGateway) GetDisabledInitContainers() []string {
+	if c.Spec.Template == nil || c.Spec.Template.Spec == nil {
+		return nil
+	}
+	var names []string
+	for _, v := range c.Spec.Template.Spec.InitContainers {
+		if v.Disabled != nil && *v.Disabled {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
 func (c *EgressGateway) GetContainers() []v1.Container {
 	if c.Spec.Template != nil {
 		if c.Spec.Template.Spec != nil {
@@ -414,6 +461,24 @@ func (c *EgressGateway) GetPriorityClassName() string {
 	return ""
 }
 
+func (c *EgressGateway) GetDNSPolicy() v1.DNSPolicy {
+	if c.Spec.Template != nil {
+		if c.Spec.Template.Spec != nil {
+			return c.Spec.Template.Spec.DNSPolicy
+		}
+	}
+	return ""
+}
+
+func (c *EgressGateway) GetDNSConfig() *v1.PodDNSConfig {
+	if c.Spec.Template != nil {
+		if c.Spec.Template.Spec != nil {
+			return c.Spec.Template.Spec.DNSConfig
+		}
+	}
+	return nil
+}
+
 func (c *EgressGateway) GetPodTemplateMetadata() *Metadata {
 	if c.Spec.Template != nil {
 		m := &Metadata{Labels: c.Spec.Template.Metadata.Labels, Annotations: c.Spec.Template.Metadata.Annotations}
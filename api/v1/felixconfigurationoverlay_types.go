@@ -0,0 +1,82 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeFelixConfigurationLabel is set on the node-scoped FelixConfigurations that the operator maintains for the
+// FelixProfiles of the Installation and for FelixConfigurationOverlays.
+const NodeFelixConfigurationLabel = "operator.tigera.io/node-felix-configuration"
+
+// FelixConfigurationOverlaysAnnotation lists the FelixConfigurationOverlays that a node-scoped FelixConfiguration
+// was rendered from, in the order that they were applied.
+const FelixConfigurationOverlaysAnnotation = "operator.tigera.io/felix-configuration-overlays"
+
+// FelixConfigurationOverlaySpec defines the Felix settings of a group of nodes.
+type FelixConfigurationOverlaySpec struct {
+	// NodeSelector selects the nodes that the overlay applies to by their labels, for example the nodes of a
+	// GPU node pool or the Windows nodes. An empty selector selects every node.
+	NodeSelector metav1.LabelSelector `json:"nodeSelector"`
+
+	// FelixConfiguration holds the FelixConfiguration spec fields to set for the selected nodes, for example
+	// {"logSeverityScreen": "Debug"}. It must only contain fields of the FelixConfiguration spec.
+	// +kubebuilder:validation:Type=object
+	FelixConfiguration apiextensionsv1.JSON `json:"felixConfiguration"`
+}
+
+// FelixConfigurationOverlayStatus defines the observed state of FelixConfigurationOverlay.
+type FelixConfigurationOverlayStatus struct {
+	// SelectedNodes is the number of nodes that the overlay applies to.
+	// +optional
+	SelectedNodes int32 `json:"selectedNodes,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Selected Nodes",type="integer",JSONPath=".status.selectedNodes"
+
+// FelixConfigurationOverlay sets Felix settings for the nodes that it selects. For every selected node, the operator
+// maintains a FelixConfiguration named node.<node name>, which Felix on the node applies on top of the default
+// FelixConfiguration. The FelixProfile that a node selects is applied first, followed by the overlays in the
+// alphabetical order of their names, so that a later overlay overrides the fields that are set before it.
+type FelixConfigurationOverlay struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired state for the overlay.
+	Spec FelixConfigurationOverlaySpec `json:"spec,omitempty"`
+
+	// Most recently observed state for the overlay.
+	Status FelixConfigurationOverlayStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FelixConfigurationOverlayList contains a list of FelixConfigurationOverlay
+type FelixConfigurationOverlayList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FelixConfigurationOverlay `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FelixConfigurationOverlay{}, &FelixConfigurationOverlayList{})
+}
@@ -58,6 +58,27 @@ type GuardianDeploymentPodSpec struct {
 	// If omitted, the guardian Deployment will use its default values for its containers.
 	// +optional
 	Containers []GuardianDeploymentContainer `json:"containers,omitempty"`
+
+	// TerminationGracePeriodSeconds is the duration in seconds the guardian pods need to terminate gracefully.
+	// If specified, this overrides the default termination grace period of the guardian Deployment.
+	// It is also the time the container is given to drain its connections after it receives its termination signal.
+	// If omitted, the guardian Deployment will use its default value for the termination grace period.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// DNSPolicy is the DNS policy of the pods.
+	// If specified, this overrides the DNS policy of the guardian Deployment.
+	// If omitted, the guardian Deployment will use its default DNS policy.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig specifies additional DNS parameters of the pods, such as nameservers, searches and options.
+	// It is merged with the DNS configuration generated from the DNS policy.
+	// If omitted, the guardian Deployment will not set any additional DNS parameters.
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
 }
 
 // GuardianDeploymentContainer is a guardian Deployment container.
@@ -84,6 +105,23 @@ type GuardianDeploymentInitContainer struct {
 	// If omitted, the guardian Deployment will use its default value for this init container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env allows customization of the named guardian Deployment init container's environment variables.
+	// If specified, each variable is merged into the init container's environment by name, replacing any
+	// default value with the same name and appending the rest.
+	// If omitted, the guardian Deployment will use its default environment for this init container.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+
+	// Image overrides the image of the named guardian Deployment init container, for example to use a mirrored or patched image.
+	// If omitted, the guardian Deployment will use its default image for this init container.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Disabled removes the named guardian Deployment init container, for an init step that isn't needed in the cluster.
+	// If omitted, the guardian Deployment will run this init container.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
 }
 
 func (c *GuardianDeployment) GetMetadata() *Metadata {
@@ -105,11 +143,14 @@ func (c *GuardianDeployment) GetInitContainers() []v1.Container {
 				if c.Spec.Template.Spec.InitContainers != nil {
 					cs := make([]v1.Container, len(c.Spec.Template.Spec.InitContainers))
 					for i, v := range c.Spec.Template.Spec.InitContainers {
-						// Only copy and return the init container if it has resources set.
-						if v.Resources == nil {
+						// Only copy and return the init container if it has resources, env or an image set.
+						if v.Resources == nil && v.Env == nil && v.Image == "" {
 							continue
 						}
-						c := v1.Container{Name: v.Name, Resources: *v.Resources}
+						c := v1.Container{Name: v.Name, Env: v.Env, Image: v.Image}
+						if v.Resources != nil {
+							c.Resources = *v.Resources
+						}
 						cs[i] = c
 					}
 					return cs
@@ -120,6 +161,20 @@ func (c *GuardianDeployment) GetInitContainers() []v1.Container {
 	return nil
 }
 
+// GetDisabledInitContainers returns the names of the init containers that are disabled.
+func (c *GuardianDeployment) GetDisabledInitContainers() []string {
+	if c.Spec == nil || c.Spec.Template == nil || c.Spec.Template.Spec == nil {
+		return nil
+	}
+	var names []string
+	for _, v := range c.Spec.Template.Spec.InitContainers {
+		if v.Disabled != nil && *v.Disabled {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
 func (c *GuardianDeployment) GetContainers() []v1.Container {
 	if c.Spec != nil {
 		if c.Spec.Template != nil {
@@ -159,6 +214,13 @@ func (c *GuardianDeployment) GetTolerations() []v1.Toleration {
 }
 
 func (c *GuardianDeployment) GetTerminationGracePeriodSeconds() *int64 {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.TerminationGracePeriodSeconds
+			}
+		}
+	}
 	return nil
 }
 
@@ -169,3 +231,25 @@ func (c *GuardianDeployment) GetDeploymentStrategy() *appsv1.DeploymentStrategy
 func (c *GuardianDeployment) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *GuardianDeployment) GetDNSPolicy() v1.DNSPolicy {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.DNSPolicy
+			}
+		}
+	}
+	return ""
+}
+
+func (c *GuardianDeployment) GetDNSConfig() *v1.PodDNSConfig {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.DNSConfig
+			}
+		}
+	}
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -110,6 +111,17 @@ type InstallationSpec struct {
 	// +optional
 	ControlPlaneTolerations []v1.Toleration `json:"controlPlaneTolerations,omitempty"`
 
+	// ControlPlaneTopology describes where the Kubernetes control plane of the cluster runs. Hosted is for clusters
+	// whose control plane runs outside of the cluster, e.g., OpenShift hosted control planes (HyperShift). Such
+	// clusters have no control plane nodes, so components are not given tolerations for control plane taints, control
+	// plane node labels can't be used in the ControlPlaneNodeSelector and the CIS benchmarker doesn't mount the
+	// host paths of control plane components.
+	// When not set, the operator sets this to Hosted if it detects an OpenShift cluster with an external control plane,
+	// and otherwise treats the cluster as Standard.
+	// +optional
+	// +kubebuilder:validation:Enum=Standard;Hosted
+	ControlPlaneTopology *ControlPlaneTopology `json:"controlPlaneTopology,omitempty"`
+
 	// ControlPlaneReplicas defines how many replicas of the control plane core components will be deployed.
 	// This field applies to all control plane components that support High Availability. Defaults to 2.
 	// +optional
@@ -125,6 +137,14 @@ type InstallationSpec struct {
 	// +optional
 	TyphaMetricsPort *int32 `json:"typhaMetricsPort,omitempty"`
 
+	// TyphaPort specifies which port calico/typha listens on for connections from calico/node. Since calico/typha
+	// runs on the host network, this can be used to avoid conflicts with other agents bound to the same port.
+	// Default: 5473
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	TyphaPort *int32 `json:"typhaPort,omitempty"`
+
 	// FlexVolumePath optionally specifies a custom path for FlexVolume. If not specified, FlexVolume will be
 	// enabled by default. If set to 'None', FlexVolume will be disabled. The default is based on the
 	// kubernetesProvider.
@@ -142,6 +162,51 @@ type InstallationSpec struct {
 	// +optional
 	NodeUpdateStrategy appsv1.DaemonSetUpdateStrategy `json:"nodeUpdateStrategy,omitempty"`
 
+	// CoordinatedNodeRollout rolls out changes to calico-node one node at a time under the control of the operator,
+	// instead of using spec.nodeUpdateStrategy. Each node is cordoned while its calico-node pod is replaced, and the
+	// operator waits for the node to be ready again before moving on to the next one. This limits the disruption of
+	// changes, such as switching the dataplane or the encapsulation, that can interrupt traffic on a node while
+	// calico-node restarts. Pods already running on the node aren't evicted.
+	// +optional
+	CoordinatedNodeRollout *CoordinatedNodeRollout `json:"coordinatedNodeRollout,omitempty"`
+
+	// NFTablesModeRollout configures how a change of nftablesMode in the default FelixConfiguration is rolled out to
+	// calico-node. The new mode is rolled out to a batch of canary nodes first, and the change is rolled back if
+	// calico-node doesn't become ready with it in time. The defaults are used if this is not set.
+	// +optional
+	NFTablesModeRollout *NFTablesModeRollout `json:"nftablesModeRollout,omitempty"`
+
+	// Preflight runs checks of the node prerequisites of the configured features on every Linux node, such as the
+	// kernel version that the eBPF dataplane needs, the WireGuard kernel module, reverse path filtering and the
+	// availability of the ports that Calico listens on. The results are published in status.preflight.
+	// +optional
+	Preflight *PreflightSpec `json:"preflight,omitempty"`
+
+	// Diagnostics configures the collection of Felix diagnostics from nodes. Diagnostics are collected from the nodes
+	// listed in the operator.tigera.io/collect-diagnostics annotation of the Installation, and from nodes whose
+	// calico-node pod is crash looping if collectOnCrashLoop is Enabled. The collections are listed in
+	// status.diagnostics.
+	// +optional
+	Diagnostics *DiagnosticsSpec `json:"diagnostics,omitempty"`
+
+	// WorkloadProvenance adds provenance metadata to the pods of the workloads that the operator renders: labels with
+	// the version and git commit of the operator, and an annotation with the image, digest and SBOM reference of each
+	// container. Digests and SBOM references are only known for images pinned by digest in an ImageSet. Enabling it,
+	// and upgrading the operator while it's enabled, restarts the pods.
+	// Default: Disabled
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	WorkloadProvenance *WorkloadProvenanceType `json:"workloadProvenance,omitempty"`
+
+	// ApplyValidation makes the operator validate all of the objects of a component with a server-side dry-run before
+	// applying any of them. If the API server rejects one of them, for instance because a CRD on the cluster is older
+	// than the operator expects, none of the objects are applied and the failures are reported together in the
+	// degraded condition of the TigeraStatus, instead of leaving the component partially applied.
+	// Default: Disabled
+	// +kubebuilder:validation:Enum=DryRun;Disabled
+	// +optional
+	ApplyValidation *ApplyValidationType `json:"applyValidation,omitempty"`
+
 	// Deprecated. Please use CalicoNodeDaemonSet, TyphaDeployment, and KubeControllersDeployment.
 	// ComponentResources can be used to customize the resource requirements for each component.
 	// Node, Typha, and KubeControllers are supported for installations.
@@ -154,6 +219,14 @@ type InstallationSpec struct {
 	// +optional
 	CertificateManagement *CertificateManagement `json:"certificateManagement,omitempty"`
 
+	// CertificateKeyAlgorithm is the algorithm and size of the keys of the CA and the certificates that the operator
+	// issues. When it changes, the operator issues a new CA and re-issues its certificates with the new algorithm.
+	// Certificates that users bring themselves are not affected.
+	// Default: RSA2048
+	// +kubebuilder:validation:Enum=RSA2048;RSA4096;ECDSAP256
+	// +optional
+	CertificateKeyAlgorithm *CertificateKeyAlgorithm `json:"certificateKeyAlgorithm,omitempty"`
+
 	// NonPrivileged configures Calico to be run in non-privileged containers as non-root users where possible.
 	// +optional
 	NonPrivileged *NonPrivilegedType `json:"nonPrivileged,omitempty"`
@@ -197,8 +270,174 @@ type InstallationSpec struct {
 	// Kubernetes Service CIDRs. Specifying this is required when using Calico for Windows.
 	// +optional
 	ServiceCIDRs []string `json:"serviceCIDRs,omitempty"`
+
+	// Telemetry configures the reporting of anonymized information about the installation to help prioritize
+	// features. Nothing is reported unless reporting is explicitly enabled.
+	// +optional
+	Telemetry *Telemetry `json:"telemetry,omitempty"`
+
+	// UpgradeHooks are Jobs that the operator runs before or after a stage of an upgrade, for example to back up
+	// data before the remaining components are upgraded. When upgrading to a new version, the operator rolls out
+	// the CRDs, the API server, Typha, calico-node and then the remaining components, one stage at a time, and
+	// doesn't start a stage until its pre hooks have succeeded.
+	// +optional
+	UpgradeHooks []UpgradeHook `json:"upgradeHooks,omitempty"`
+
+	// MaintenanceWindows restricts when disruptive changes, such as changes to the pod template of the calico-node
+	// DaemonSet, are rolled out. Outside of the windows these changes are deferred, while other changes are still
+	// applied immediately. If no windows are configured, changes are always rolled out immediately.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// FelixProfiles are named sets of Felix settings for groups of nodes. For every node labeled
+	// projectcalico.org/felix-profile=<profile name>, the operator maintains a FelixConfiguration named node.<node name>
+	// with the settings of that profile, which Felix on the node applies on top of the default FelixConfiguration.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	FelixProfiles []FelixProfile `json:"felixProfiles,omitempty"`
 }
 
+// FelixProfileNodeLabel is the node label that selects the FelixProfile that applies to a node.
+const FelixProfileNodeLabel = "projectcalico.org/felix-profile"
+
+// FelixProfile is a named set of Felix settings that nodes select with the projectcalico.org/felix-profile label.
+type FelixProfile struct {
+	// Name is the name of the profile, as used in the value of the projectcalico.org/felix-profile node label.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// Spec holds the FelixConfiguration spec fields to set for nodes that use this profile, for example
+	// {"logSeverityScreen": "Debug"}. It must only contain fields of the FelixConfiguration spec.
+	// +kubebuilder:validation:Type=object
+	Spec apiextensionsv1.JSON `json:"spec"`
+}
+
+// UpgradeStage is a step in the ordered rollout of a new version.
+//
+// One of: CRDs, APIServer, Typha, Node, Components
+type UpgradeStage string
+
+const (
+	UpgradeStageCRDs       UpgradeStage = "CRDs"
+	UpgradeStageAPIServer  UpgradeStage = "APIServer"
+	UpgradeStageTypha      UpgradeStage = "Typha"
+	UpgradeStageNode       UpgradeStage = "Node"
+	UpgradeStageComponents UpgradeStage = "Components"
+)
+
+// UpgradeHookPhase is when an upgrade hook runs relative to its stage.
+//
+// One of: Pre, Post
+type UpgradeHookPhase string
+
+const (
+	UpgradeHookPhasePre  UpgradeHookPhase = "Pre"
+	UpgradeHookPhasePost UpgradeHookPhase = "Post"
+)
+
+// UpgradeHook is a Job that runs in the operator namespace once per upgrade, before or after a stage is rolled out.
+// If the Job fails, the upgrade stops at that stage until the Job is deleted, after which it's run again.
+type UpgradeHook struct {
+	// Name identifies the hook and is used in the name of its Job. It must be a DNS label of at most 40 characters.
+	Name string `json:"name"`
+
+	// Stage is the upgrade stage that the hook runs around.
+	// +kubebuilder:validation:Enum=CRDs;APIServer;Typha;Node;Components
+	Stage UpgradeStage `json:"stage"`
+
+	// Phase is whether the hook runs before or after the stage is rolled out.
+	// +kubebuilder:validation:Enum=Pre;Post
+	Phase UpgradeHookPhase `json:"phase"`
+
+	// Image is the container image that the hook runs.
+	Image string `json:"image"`
+
+	// Command overrides the entrypoint of the image.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args are the arguments to the command.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env is a list of environment variables to set in the hook's container.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+
+	// ServiceAccountName is the service account, in the operator namespace, that the hook runs as.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// UpgradeStageState is the progress of an upgrade stage.
+//
+// One of: Pending, PreHooks, RollingOut, PostHooks, Complete
+type UpgradeStageState string
+
+const (
+	UpgradeStagePending    UpgradeStageState = "Pending"
+	UpgradeStagePreHooks   UpgradeStageState = "PreHooks"
+	UpgradeStageRollingOut UpgradeStageState = "RollingOut"
+	UpgradeStagePostHooks  UpgradeStageState = "PostHooks"
+	UpgradeStageComplete   UpgradeStageState = "Complete"
+)
+
+// UpgradeStatus records the progress of the most recent upgrade.
+type UpgradeStatus struct {
+	// FromVersion is the version that was installed when the upgrade started.
+	FromVersion string `json:"fromVersion"`
+
+	// ToVersion is the version being upgraded to.
+	ToVersion string `json:"toVersion"`
+
+	// Stages is the progress of each stage, in the order that they're rolled out.
+	Stages []UpgradeStageStatus `json:"stages"`
+}
+
+// UpgradeStageStatus is the progress of a single upgrade stage.
+type UpgradeStageStatus struct {
+	// Stage is the upgrade stage.
+	Stage UpgradeStage `json:"stage"`
+
+	// State is how far the stage has progressed.
+	State UpgradeStageState `json:"state"`
+
+	// Message explains what the stage is waiting for, if anything.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastTransitionTime is when the stage last changed state.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// Telemetry configures the periodic report of anonymized installation metrics. A report contains the product
+// variant, the Kubernetes provider, the enabled components, the operator version and the number of nodes rounded
+// down to a bucket, identified by a hash of the cluster's kube-system namespace UID. It contains no names, addresses
+// or other configuration.
+type Telemetry struct {
+	// Reporting enables or disables the reporting of telemetry.
+	// Default: Disabled
+	// +optional
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	Reporting *TelemetryReporting `json:"reporting,omitempty"`
+
+	// Endpoint is the HTTPS URL that reports are posted to. It is required when reporting is enabled. The operator
+	// runs on the host network, so no network policy of the operator applies to the reports. If the hosts are
+	// protected by host endpoint policy, it must allow egress from the hosts that run the operator to this endpoint.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+type TelemetryReporting string
+
+const (
+	TelemetryReportingEnabled  TelemetryReporting = "Enabled"
+	TelemetryReportingDisabled TelemetryReporting = "Disabled"
+)
+
 type Logging struct {
 	// Customized logging specification for calico-cni plugin
 	// +optional
@@ -224,6 +463,13 @@ type CNILogging struct {
 	LogFileMaxCount *uint32 `json:"logFileMaxCount,omitempty"`
 }
 
+type ControlPlaneTopology string
+
+const (
+	ControlPlaneTopologyStandard ControlPlaneTopology = "Standard"
+	ControlPlaneTopologyHosted   ControlPlaneTopology = "Hosted"
+)
+
 type FIPSMode string
 
 const (
@@ -383,6 +629,26 @@ var HostPortsTypesString []string = []string{
 	HostPortsDisabled.String(),
 }
 
+// BandwidthPluginType specifies whether the bandwidth CNI plugin is included in the CNI configuration.
+//
+// One of: Enabled, Disabled
+type BandwidthPluginType string
+
+const (
+	BandwidthPluginEnabled  BandwidthPluginType = "Enabled"
+	BandwidthPluginDisabled BandwidthPluginType = "Disabled"
+)
+
+// WireGuardType specifies whether traffic between pods on different nodes is encrypted with WireGuard.
+//
+// One of: Enabled, Disabled
+type WireGuardType string
+
+const (
+	WireGuardEnabled  WireGuardType = "Enabled"
+	WireGuardDisabled WireGuardType = "Disabled"
+)
+
 // MultiInterfaceMode describes the method of providing multiple pod interfaces.
 //
 // One of: None, Multus
@@ -397,6 +663,41 @@ const (
 	MultiInterfaceModeMultus MultiInterfaceMode = "Multus"
 )
 
+// MultusCompatibility configures Calico for clusters that attach secondary networks to pods with Multus.
+type MultusCompatibility struct {
+	// InterfaceExclude is a list of host interface names that Felix should ignore, for example the interfaces
+	// that back secondary networks. Entries wrapped in slashes, such as /^vf[0-9]+$/, are treated as regular
+	// expressions. When the default FelixConfiguration doesn't set interfaceExclude, the operator sets it to
+	// Felix's default of kube-ipvs0 followed by these entries.
+	// +optional
+	InterfaceExclude []string `json:"interfaceExclude,omitempty"`
+
+	// NetworkAttachments lists the network attachments to request for pods of operator rendered workloads.
+	// +optional
+	NetworkAttachments []WorkloadNetworkAttachment `json:"networkAttachments,omitempty"`
+}
+
+// MultusWorkload is an operator rendered workload that can be given Multus network attachments.
+//
+// One of: CalicoKubeControllers, APIServer
+type MultusWorkload string
+
+const (
+	MultusWorkloadCalicoKubeControllers MultusWorkload = "CalicoKubeControllers"
+	MultusWorkloadAPIServer             MultusWorkload = "APIServer"
+)
+
+// WorkloadNetworkAttachment requests Multus network attachments for the pods of a workload.
+type WorkloadNetworkAttachment struct {
+	// Workload is the workload whose pods request the network attachments.
+	// +kubebuilder:validation:Enum=CalicoKubeControllers;APIServer
+	Workload MultusWorkload `json:"workload"`
+
+	// Networks is the value of the k8s.v1.cni.cncf.io/networks annotation set on the workload's pods, for example
+	// "sriov-net1" or a JSON list of network selection elements.
+	Networks string `json:"networks"`
+}
+
 func HostPortsTypePtr(h HostPortsType) *HostPortsType {
 	return &h
 }
@@ -472,6 +773,14 @@ type CalicoNetworkSpec struct {
 	// +kubebuilder:validation:MaxItems=25
 	IPPools []IPPool `json:"ipPools,omitempty"`
 
+	// PodNetworkExpansions adds CIDRs to the pod network when it runs out of addresses. For each CIDR, the operator
+	// checks that it doesn't overlap with the IP pools in the cluster or the service CIDRs, and adds an IP pool to
+	// ipPools with the settings of an existing pool, including its encapsulation and outgoing NAT. To remove an
+	// expansion, remove it from this list before removing its pool from ipPools.
+	// Expansions require Calico IPAM.
+	// +optional
+	PodNetworkExpansions []PodNetworkExpansion `json:"podNetworkExpansions,omitempty"`
+
 	// MTU specifies the maximum transmission unit to use on the pod network.
 	// If not specified, Calico will perform MTU auto-detection based on the cluster network.
 	// +optional
@@ -493,6 +802,14 @@ type CalicoNetworkSpec struct {
 	// +kubebuilder:validation:Enum=Enabled;Disabled
 	HostPorts *HostPortsType `json:"hostPorts,omitempty"`
 
+	// BandwidthPlugin configures whether the bandwidth CNI plugin is chained after the Calico CNI plugin. When enabled,
+	// the ingress and egress traffic of pods is shaped according to their kubernetes.io/ingress-bandwidth and
+	// kubernetes.io/egress-bandwidth annotations. Valid only when using the Calico CNI plugin.
+	// Default: Enabled
+	// +optional
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	BandwidthPlugin *BandwidthPluginType `json:"bandwidthPlugin,omitempty"`
+
 	// MultiInterfaceMode configures what will configure multiple interface per pod. Only valid for Calico Enterprise installations
 	// using the Calico CNI plugin.
 	// Default: None
@@ -500,6 +817,19 @@ type CalicoNetworkSpec struct {
 	// +kubebuilder:validation:Enum=None;Multus
 	MultiInterfaceMode *MultiInterfaceMode `json:"multiInterfaceMode,omitempty"`
 
+	// MultusCompatibility configures Calico to coexist with secondary networks, such as SR-IOV or DPDK, that are
+	// attached to pods by Multus.
+	// +optional
+	MultusCompatibility *MultusCompatibility `json:"multusCompatibility,omitempty"`
+
+	// WireGuard configures whether felix encrypts the traffic between pods on different nodes with WireGuard, for each
+	// IP family that has an IP pool. The operator sets wireguardEnabled and wireguardEnabledV6 in the default
+	// FelixConfiguration accordingly, unless they have been set by someone else. If not specified, the operator leaves
+	// WireGuard to the FelixConfiguration.
+	// +optional
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	WireGuard *WireGuardType `json:"wireGuard,omitempty"`
+
 	// ContainerIPForwarding configures whether ip forwarding will be enabled for containers in the CNI configuration.
 	// Default: Disabled
 	// +optional
@@ -627,6 +957,17 @@ func (nt NATOutgoingType) String() string {
 
 const NodeSelectorDefault string = "all()"
 
+// PodNetworkExpansion adds a CIDR to the pod network.
+type PodNetworkExpansion struct {
+	// CIDR is the range of pod addresses to add.
+	CIDR string `json:"cidr"`
+
+	// Template is the name of the IP pool in ipPools whose settings the new pool copies: its encapsulation,
+	// outgoing NAT, node selector, block size and allowed uses. Defaults to the first pool of the same IP family.
+	// +optional
+	Template string `json:"template,omitempty"`
+}
+
 type IPPool struct {
 	// Name is the name of the IP pool. If omitted, this will be generated.
 	Name string `json:"name,omitempty"`
@@ -897,12 +1238,418 @@ type InstallationStatus struct {
 	// version deployed.
 	CalicoVersion string `json:"calicoVersion,omitempty"`
 
+	// EKSNetworking describes the Amazon VPC CNI configuration detected from the aws-node daemonset, and the
+	// installation decisions the operator made because of it. It is only set when the aws-node daemonset exists.
+	// +optional
+	EKSNetworking *EKSNetworkingStatus `json:"eksNetworking,omitempty"`
+
+	// Upgrade records the progress of the most recent upgrade between versions.
+	// +optional
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+
+	// RolledBack lists the components that are rolled back to their last known-good render, as requested by the
+	// operator.tigera.io/rollback annotation. The spec of the Installation isn't applied to these components.
+	// +optional
+	RolledBack []RolledBackComponent `json:"rolledBack,omitempty"`
+
+	// DeferredRollouts lists the workloads with disruptive changes that are waiting for the next maintenance window.
+	// +optional
+	DeferredRollouts []string `json:"deferredRollouts,omitempty"`
+
+	// NodeRollout records the progress of a coordinated rollout of calico-node, if spec.coordinatedNodeRollout is set.
+	// +optional
+	NodeRollout *NodeRolloutStatus `json:"nodeRollout,omitempty"`
+
+	// NFTablesModeRollout records the nftables mode that calico-node runs with, and the progress of the most recent
+	// change of nftablesMode in the default FelixConfiguration.
+	// +optional
+	NFTablesModeRollout *NFTablesModeRolloutStatus `json:"nftablesModeRollout,omitempty"`
+
+	// EncapsulationMigration records the progress of moving IP pools from one encapsulation to another.
+	// It is removed once the migration completes.
+	// +optional
+	EncapsulationMigration *EncapsulationMigrationStatus `json:"encapsulationMigration,omitempty"`
+
+	// Preflight holds the results of the preflight checks, if spec.preflight is set.
+	// +optional
+	Preflight *PreflightStatus `json:"preflight,omitempty"`
+
+	// Diagnostics lists the collections of Felix diagnostics whose Jobs still exist.
+	// +optional
+	Diagnostics []DiagnosticsCollection `json:"diagnostics,omitempty"`
+
+	// TyphaHealth summarizes the connections from Felix to Typha, as scraped from the Prometheus metrics of Typha. It
+	// is only set if spec.typhaMetricsPort is set.
+	// +optional
+	TyphaHealth *TyphaHealthStatus `json:"typhaHealth,omitempty"`
+
 	// Conditions represents the latest observed set of conditions for the component. A component may be one or more of
 	// Ready, Progressing, Degraded or other customer types.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// EKSNetworkingStatus describes how the Amazon VPC CNI is configured on an EKS cluster.
+type EKSNetworkingStatus struct {
+	// IPv6 is true when the Amazon VPC CNI assigns IPv6 addresses to pods (ENABLE_IPv6).
+	// +optional
+	IPv6 bool `json:"ipv6,omitempty"`
+
+	// CustomNetworking is true when the Amazon VPC CNI places pods on the subnets selected by ENIConfig
+	// resources rather than on the node's primary subnet (AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG).
+	// +optional
+	CustomNetworking bool `json:"customNetworking,omitempty"`
+
+	// Decisions lists the configuration the operator chose, or rejects, because of the detected settings.
+	// +optional
+	Decisions []string `json:"decisions,omitempty"`
+}
+
+// CoordinatedNodeRollout configures the rollout of calico-node one node at a time.
+type CoordinatedNodeRollout struct {
+	// ReadinessConditions are the types of node conditions that must be True, in addition to the new calico-node pod
+	// being ready, before a node is uncordoned and the rollout moves on. For example, a condition that a node problem
+	// detector sets once the node's network is verified.
+	// +optional
+	ReadinessConditions []v1.NodeConditionType `json:"readinessConditions,omitempty"`
+}
+
+// NodeRolloutStatus describes the progress of a coordinated rollout of calico-node.
+type NodeRolloutStatus struct {
+	// Generation is the template generation of the calico-node DaemonSet that is being rolled out.
+	Generation int64 `json:"generation"`
+
+	// UpdatedNodes is the number of nodes that run the calico-node pod of the generation.
+	UpdatedNodes int32 `json:"updatedNodes"`
+
+	// TotalNodes is the number of nodes that run a calico-node pod.
+	TotalNodes int32 `json:"totalNodes"`
+
+	// CurrentNode is the node that is cordoned while its calico-node pod is replaced.
+	// +optional
+	CurrentNode string `json:"currentNode,omitempty"`
+
+	// Message describes what the rollout is waiting for.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// NFTablesModeRollout configures the rollout of a change of the nftables mode of Felix.
+type NFTablesModeRollout struct {
+	// CanaryNodes is the number of nodes that the new mode is rolled out to first. Nodes labeled
+	// operator.tigera.io/nftables-canary=true are picked before other nodes.
+	// Default: 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CanaryNodes *int32 `json:"canaryNodes,omitempty"`
+
+	// HealthCheckTimeout is how long a calico-node pod that runs the new mode has to become ready before the change
+	// is rolled back.
+	// Default: 5m
+	// +optional
+	HealthCheckTimeout *metav1.Duration `json:"healthCheckTimeout,omitempty"`
+}
+
+// NFTablesModeRolloutPhase is the step that the rollout of a change of the nftables mode is at.
+//
+// One of: Canary, Rolling, Complete, RolledBack
+type NFTablesModeRolloutPhase string
+
+const (
+	// NFTablesModeRolloutCanary is the step where only the calico-node pods of the canary nodes are replaced, and
+	// the rollout waits for them to become ready.
+	NFTablesModeRolloutCanary NFTablesModeRolloutPhase = "Canary"
+
+	// NFTablesModeRolloutRolling is the step where the new mode is rolled out to the remaining nodes.
+	NFTablesModeRolloutRolling NFTablesModeRolloutPhase = "Rolling"
+
+	// NFTablesModeRolloutComplete is reached once every calico-node pod runs the new mode and is ready.
+	NFTablesModeRolloutComplete NFTablesModeRolloutPhase = "Complete"
+
+	// NFTablesModeRolloutRolledBack is reached if calico-node didn't become ready with the new mode. calico-node is
+	// rolled back to the previous mode until nftablesMode is changed again.
+	NFTablesModeRolloutRolledBack NFTablesModeRolloutPhase = "RolledBack"
+)
+
+// NFTablesModeRolloutStatus describes the rollout of a change of the nftables mode of Felix.
+type NFTablesModeRolloutStatus struct {
+	// Mode is the nftables mode that is rolled out, or that calico-node runs with once the rollout is complete.
+	Mode string `json:"mode"`
+
+	// PreviousMode is the mode that calico-node ran with before the rollout, and that it is rolled back to.
+	// +optional
+	PreviousMode string `json:"previousMode,omitempty"`
+
+	// Phase is the step that the rollout is at.
+	Phase NFTablesModeRolloutPhase `json:"phase"`
+
+	// CanaryNodes are the nodes that the new mode was rolled out to first.
+	// +optional
+	CanaryNodes []string `json:"canaryNodes,omitempty"`
+
+	// Message describes what the rollout is waiting for, or why it was rolled back.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PreflightMode is how the results of the preflight checks are used.
+//
+// One of: Enforce, Report
+type PreflightMode string
+
+const (
+	// PreflightModeEnforce holds back the features whose checks fail on any node.
+	PreflightModeEnforce PreflightMode = "Enforce"
+
+	// PreflightModeReport only publishes the results.
+	PreflightModeReport PreflightMode = "Report"
+)
+
+// WorkloadProvenanceType specifies whether rendered pods are labeled and annotated with their provenance.
+//
+// One of: Enabled, Disabled
+type WorkloadProvenanceType string
+
+const (
+	WorkloadProvenanceEnabled  WorkloadProvenanceType = "Enabled"
+	WorkloadProvenanceDisabled WorkloadProvenanceType = "Disabled"
+)
+
+// ApplyValidationType specifies whether the objects of a component are validated with a server-side dry-run before
+// they are applied.
+//
+// One of: DryRun, Disabled
+type ApplyValidationType string
+
+const (
+	ApplyValidationDryRun   ApplyValidationType = "DryRun"
+	ApplyValidationDisabled ApplyValidationType = "Disabled"
+)
+
+// CertificateKeyAlgorithm is the algorithm and size of the keys of the certificates that the operator issues.
+//
+// One of: RSA2048, RSA4096, ECDSAP256
+type CertificateKeyAlgorithm string
+
+const (
+	CertificateKeyAlgorithmRSA2048   CertificateKeyAlgorithm = "RSA2048"
+	CertificateKeyAlgorithmRSA4096   CertificateKeyAlgorithm = "RSA4096"
+	CertificateKeyAlgorithmECDSAP256 CertificateKeyAlgorithm = "ECDSAP256"
+)
+
+// CollectOnCrashLoopType specifies whether diagnostics are collected from nodes whose calico-node pod is crash looping.
+//
+// One of: Enabled, Disabled
+type CollectOnCrashLoopType string
+
+const (
+	CollectOnCrashLoopEnabled  CollectOnCrashLoopType = "Enabled"
+	CollectOnCrashLoopDisabled CollectOnCrashLoopType = "Disabled"
+)
+
+// DiagnosticsSpec configures the collection of Felix diagnostics from nodes.
+type DiagnosticsSpec struct {
+	// PersistentVolumeClaimName is the name of a PersistentVolumeClaim in the calico-system namespace that the
+	// diagnostics of each node are written to, as an archive named after the node and the time of the collection. The
+	// claim needs the ReadWriteMany access mode to collect from more than one node at a time.
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+
+	// CollectOnCrashLoop collects diagnostics from a node once its calico-node pod is crash looping. They are
+	// collected again once a day while the pod keeps crash looping.
+	// Default: Disabled
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	CollectOnCrashLoop *CollectOnCrashLoopType `json:"collectOnCrashLoop,omitempty"`
+}
+
+// DiagnosticsCollectionReason is why diagnostics were collected from a node.
+//
+// One of: Requested, CrashLoop
+type DiagnosticsCollectionReason string
+
+const (
+	DiagnosticsCollectionRequested DiagnosticsCollectionReason = "Requested"
+	DiagnosticsCollectionCrashLoop DiagnosticsCollectionReason = "CrashLoop"
+)
+
+// DiagnosticsCollectionState is the state of a collection of diagnostics.
+//
+// One of: Running, Succeeded, Failed
+type DiagnosticsCollectionState string
+
+const (
+	DiagnosticsCollectionRunning   DiagnosticsCollectionState = "Running"
+	DiagnosticsCollectionSucceeded DiagnosticsCollectionState = "Succeeded"
+	DiagnosticsCollectionFailed    DiagnosticsCollectionState = "Failed"
+)
+
+// DiagnosticsCollection describes the collection of Felix diagnostics from a node.
+type DiagnosticsCollection struct {
+	// Node is the name of the node.
+	Node string `json:"node"`
+
+	// Reason is why the diagnostics are collected.
+	Reason DiagnosticsCollectionReason `json:"reason"`
+
+	// Job is the name of the Job in the calico-system namespace that collects the diagnostics.
+	Job string `json:"job"`
+
+	// File is the path of the archive in the PersistentVolumeClaim.
+	File string `json:"file"`
+
+	// State is the state of the collection.
+	State DiagnosticsCollectionState `json:"state"`
+}
+
+// PreflightSpec configures the preflight checks of node prerequisites.
+type PreflightSpec struct {
+	// Mode is how the results are used. In Enforce mode, the eBPF dataplane isn't enabled in Felix until every node
+	// has passed its checks, and on a new cluster calico-node and Typha aren't installed until every node has passed
+	// the checks of the ports that they listen on. The ports are only checked in Enforce mode, since calico-node
+	// would otherwise start listening on them while the checks run. Default: Report
+	// +kubebuilder:validation:Enum=Enforce;Report
+	// +optional
+	Mode *PreflightMode `json:"mode,omitempty"`
+}
+
+// FelixConfigurationConflict is the type of the Installation status condition that reports whether fields of the
+// default FelixConfiguration that the operator manages have been edited by users.
+const FelixConfigurationConflict StatusConditionType = "FelixConfigurationConflict"
+
+// TyphaHealthStatus summarizes the connections from Felix to Typha.
+type TyphaHealthStatus struct {
+	// LastScrapeTime is when the metrics of Typha were last scraped.
+	LastScrapeTime metav1.Time `json:"lastScrapeTime"`
+
+	// Connections is the number of Felix connections across all Typha instances.
+	Connections int32 `json:"connections"`
+
+	// DisconnectsPerHour is the rate at which Felix connections were closed between the last two scrapes, across the
+	// Typha instances that were scraped both times. A high rate means that Felix keeps reconnecting, for example because
+	// Typha is too busy to keep up with its clients.
+	// +optional
+	DisconnectsPerHour *int32 `json:"disconnectsPerHour,omitempty"`
+
+	// Instances holds the connection counts of each Typha instance.
+	// +optional
+	Instances []TyphaInstanceHealth `json:"instances,omitempty"`
+}
+
+// TyphaInstanceHealth holds the connection counts of a Typha instance.
+type TyphaInstanceHealth struct {
+	// Pod is the name of the Typha pod.
+	Pod string `json:"pod"`
+
+	// Node is the name of the node that the pod runs on.
+	// +optional
+	Node string `json:"node,omitempty"`
+
+	// Connections is the number of Felix connections to the instance.
+	Connections int32 `json:"connections"`
+
+	// Disconnects is the number of Felix connections to the instance that were closed since it started.
+	Disconnects int64 `json:"disconnects"`
+
+	// Error is why the metrics of the instance couldn't be scraped. The counts are zero if it's set.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// PreflightStatus holds the results of the preflight checks.
+type PreflightStatus struct {
+	// ChecksHash identifies the run of the checks that the results are for. The checks are run again when the
+	// features that they are for change, and periodically while any node fails a check.
+	ChecksHash string `json:"checksHash"`
+
+	// Checks are the checks that were run.
+	// +optional
+	Checks []string `json:"checks,omitempty"`
+
+	// Complete is true once every node has reported its results. The preflight DaemonSet is removed then.
+	Complete bool `json:"complete"`
+
+	// CompletionTime is when every node had reported its results.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Nodes are the results of each node that has reported.
+	// +optional
+	Nodes []NodePreflightResult `json:"nodes,omitempty"`
+}
+
+// NodePreflightResult holds the results of the preflight checks on a node.
+type NodePreflightResult struct {
+	// Node is the name of the node.
+	Node string `json:"node"`
+
+	// Failures are the checks that failed on the node. The node passed every other check.
+	// +optional
+	Failures []PreflightFailure `json:"failures,omitempty"`
+}
+
+// PreflightFailure describes a failed preflight check.
+type PreflightFailure struct {
+	// Check is the name of the check, such as bpf-kernel, wireguard, rp-filter or port:179/tcp.
+	Check string `json:"check"`
+
+	// Message explains why the check failed.
+	Message string `json:"message"`
+}
+
+// EncapsulationMigrationPhase is the step that an encapsulation migration is at.
+//
+// One of: EnablingTunnels, UpdatingPools
+type EncapsulationMigrationPhase string
+
+const (
+	// EncapsulationMigrationEnablingTunnels is the step where both tunnels are enabled in Felix and the IP pools
+	// keep their old encapsulation until calico-node has rolled out.
+	EncapsulationMigrationEnablingTunnels EncapsulationMigrationPhase = "EnablingTunnels"
+
+	// EncapsulationMigrationUpdatingPools is the step where the IP pools have their new encapsulation and the
+	// migration waits for every node to allocate an address for the new tunnel.
+	EncapsulationMigrationUpdatingPools EncapsulationMigrationPhase = "UpdatingPools"
+)
+
+// EncapsulationMigrationStatus describes the move of IP pools from one encapsulation to another.
+type EncapsulationMigrationStatus struct {
+	// From is the encapsulation that the pools are moving from. The cross-subnet modes are recorded as the
+	// tunnel that they use, so this is one of IPIP, VXLAN or None.
+	From EncapsulationType `json:"from"`
+
+	// To is the encapsulation that the pools are moving to: one of IPIP, VXLAN or None.
+	To EncapsulationType `json:"to"`
+
+	// Pools are the CIDRs of the IP pools that are moving.
+	Pools []string `json:"pools"`
+
+	// Phase is the step that the migration is at.
+	Phase EncapsulationMigrationPhase `json:"phase"`
+
+	// TransitionalFelixSettings are the fields of the default FelixConfiguration that the migration enabled so that
+	// both tunnels are up while the nodes switch over. They are unset again when the migration completes.
+	// +optional
+	TransitionalFelixSettings []string `json:"transitionalFelixSettings,omitempty"`
+
+	// Message describes what the migration is waiting for.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RolledBackComponent describes a component that runs its last known-good render instead of the current spec.
+type RolledBackComponent struct {
+	// Name is the name of the component's Deployment or DaemonSet.
+	Name string `json:"name"`
+
+	// KnownGoodGeneration is the generation of the Installation that the known-good render was produced from.
+	// It is not set if there is no known-good render to roll back to.
+	// +optional
+	KnownGoodGeneration int64 `json:"knownGoodGeneration,omitempty"`
+
+	// Message describes how the component diverges from the spec.
+	Message string `json:"message"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
@@ -928,6 +1675,21 @@ func (installation *InstallationSpec) BPFEnabled() bool {
 		*installation.CalicoNetwork.LinuxDataplane == LinuxDataplaneBPF
 }
 
+// HostedControlPlane is an extension method that returns true if the Installation resource
+// has the control plane topology set to "Hosted" otherwise false.
+func (installation *InstallationSpec) HostedControlPlane() bool {
+	return installation.ControlPlaneTopology != nil &&
+		*installation.ControlPlaneTopology == ControlPlaneTopologyHosted
+}
+
+// TelemetryEnabled is an extension method that returns true if the Installation resource
+// has telemetry reporting explicitly set to "Enabled" otherwise false.
+func (installation *InstallationSpec) TelemetryEnabled() bool {
+	return installation.Telemetry != nil &&
+		installation.Telemetry.Reporting != nil &&
+		*installation.Telemetry.Reporting == TelemetryReportingEnabled
+}
+
 // +kubebuilder:object:root=true
 
 // InstallationList contains a list of Installation
@@ -36,8 +36,56 @@ type IntrusionDetectionSpec struct {
 	// IntrusionDetectionControllerDeployment configures the IntrusionDetection Controller Deployment.
 	// +optional
 	IntrusionDetectionControllerDeployment *IntrusionDetectionControllerDeployment `json:"intrusionDetectionControllerDeployment,omitempty"`
+
+	// ThreatFeedMirror configures threat feeds that are read from an internal mirror, for clusters that can't reach
+	// the public sources of the feeds. The operator creates a GlobalThreatFeed for each feed in the mirror's index.
+	// It is not supported in multi-tenant management clusters.
+	// +optional
+	ThreatFeedMirror *ThreatFeedMirror `json:"threatFeedMirror,omitempty"`
+
+	// ThreatFeedExport, when Enabled, makes the operator write an index of the GlobalThreatFeeds that are pulled from
+	// external sources to the tigera-threat-feed-export ConfigMap in the tigera-operator namespace. The feeds in the
+	// index can be downloaded and copied to a mirror, and the index imported by the threatFeedMirror of an air-gapped
+	// cluster. It is not supported in multi-tenant management clusters.
+	// Default: Disabled
+	// +optional
+	ThreatFeedExport *ThreatFeedExportType `json:"threatFeedExport,omitempty"`
+
+	// SIEMExport configures a scheduled export of security events, including alerts, to object storage for SIEM ingestion.
+	// +optional
+	SIEMExport *SIEMExport `json:"siemExport,omitempty"`
+}
+
+// ThreatFeedMirror is an internal mirror of threat feeds.
+type ThreatFeedMirror struct {
+	// URL is the https URL of the mirror. Each feed is read from the URL followed by the file of the feed in the index.
+	URL string `json:"url"`
+
+	// IndexConfigMapName is the name of the ConfigMap in the tigera-operator namespace whose index.json key holds the
+	// index of the feeds in the mirror, in the format of the tigera-threat-feed-export ConfigMap.
+	IndexConfigMapName string `json:"indexConfigMapName"`
+
+	// CASecretName is the name of the Secret in the tigera-operator namespace whose tls.crt key holds the certificate
+	// of the CA that signed the certificate of the mirror. If omitted, the certificate of the mirror must be signed by
+	// a CA in the system root certificates.
+	// +optional
+	CASecretName string `json:"caSecretName,omitempty"`
+
+	// AuthSecretName is the name of the Secret in the tigera-operator namespace whose authorization key holds the
+	// value of the Authorization header that is sent to the mirror, e.g. "Bearer <token>". If omitted, no
+	// credentials are sent.
+	// +optional
+	AuthSecretName string `json:"authSecretName,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=Enabled;Disabled
+type ThreatFeedExportType string
+
+const (
+	ThreatFeedExportEnabled  ThreatFeedExportType = "Enabled"
+	ThreatFeedExportDisabled ThreatFeedExportType = "Disabled"
+)
+
 type AnomalyDetectionSpec struct {
 
 	// StorageClassName is now deprecated, and configuring it has no effect.
@@ -54,6 +102,14 @@ type IntrusionDetectionStatus struct {
 	// Ready, Progressing, Degraded or other customer types.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the resource that the operator has reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Rollout summarizes the rollout of the component's workloads.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -133,6 +189,19 @@ type IntrusionDetectionControllerDeploymentPodSpec struct {
 	// If omitted, the IntrusionDetectionController Deployment will use its default values for its containers.
 	// +optional
 	Containers []IntrusionDetectionControllerDeploymentContainer `json:"containers,omitempty"`
+
+	// DNSPolicy is the DNS policy of the pods.
+	// If specified, this overrides the DNS policy of the IntrusionDetectionController Deployment.
+	// If omitted, the IntrusionDetectionController Deployment will use its default DNS policy.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig specifies additional DNS parameters of the pods, such as nameservers, searches and options.
+	// It is merged with the DNS configuration generated from the DNS policy.
+	// If omitted, the IntrusionDetectionController Deployment will not set any additional DNS parameters.
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
 }
 
 // IntrusionDetectionControllerDeploymentContainer is a IntrusionDetectionController Deployment container.
@@ -147,6 +216,12 @@ type IntrusionDetectionControllerDeploymentContainer struct {
 	// If omitted, the IntrusionDetection Deployment will use its default value for this container's resources.
 	// +optional
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// StartupProbe allows customization of the timings of the named IntrusionDetectionController Deployment container's startup probe.
+	// If specified, the given timings override the defaults of the startup probe rendered for this container.
+	// If omitted, the IntrusionDetectionController Deployment will use its default startup probe timings for this container.
+	// +optional
+	StartupProbe *ProbeTimings `json:"startupProbe,omitempty"`
 }
 
 // IntrusionDetectionControllerDeploymentInitContainer is a IntrusionDetectionController Deployment init container.
@@ -161,6 +236,23 @@ type IntrusionDetectionControllerDeploymentInitContainer struct {
 	// If omitted, the IntrusionDetectionController Deployment will use its default value for this init container's resources.
 	// +optional
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env allows customization of the named IntrusionDetectionController Deployment init container's environment variables.
+	// If specified, each variable is merged into the init container's environment by name, replacing any
+	// default value with the same name and appending the rest.
+	// If omitted, the IntrusionDetectionController Deployment will use its default environment for this init container.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Image overrides the image of the named IntrusionDetectionController Deployment init container, for example to use a mirrored or patched image.
+	// If omitted, the IntrusionDetectionController Deployment will use its default image for this init container.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Disabled removes the named IntrusionDetectionController Deployment init container, for an init step that isn't needed in the cluster.
+	// If omitted, the IntrusionDetectionController Deployment will run this init container.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
 }
 
 func (c *IntrusionDetectionControllerDeployment) GetMetadata() *Metadata {
@@ -182,11 +274,14 @@ func (c *IntrusionDetectionControllerDeployment) GetInitContainers() []corev1.Co
 				if c.Spec.Template.Spec.InitContainers != nil {
 					cs := make([]corev1.Container, len(c.Spec.Template.Spec.InitContainers))
 					for i, v := range c.Spec.Template.Spec.InitContainers {
-						// Only copy and return the init container if it has resources set.
-						if v.Resources == nil {
+						// Only copy and return the init container if it has resources, env or an image set.
+						if v.Resources == nil && v.Env == nil && v.Image == "" {
 							continue
 						}
-						c := corev1.Container{Name: v.Name, Resources: *v.Resources}
+						c := corev1.Container{Name: v.Name, Env: v.Env, Image: v.Image}
+						if v.Resources != nil {
+							c.Resources = *v.Resources
+						}
 						cs[i] = c
 					}
 					return cs
@@ -198,6 +293,20 @@ func (c *IntrusionDetectionControllerDeployment) GetInitContainers() []corev1.Co
 	return nil
 }
 
+// GetDisabledInitContainers returns the names of the init containers that are disabled.
+func (c *IntrusionDetectionControllerDeployment) GetDisabledInitContainers() []string {
+	if c == nil || c.Spec.Template == nil || c.Spec.Template.Spec == nil {
+		return nil
+	}
+	var names []string
+	for _, v := range c.Spec.Template.Spec.InitContainers {
+		if v.Disabled != nil && *v.Disabled {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
 func (c *IntrusionDetectionControllerDeployment) GetContainers() []corev1.Container {
 	if c != nil {
 		if c.Spec != nil {
@@ -206,11 +315,14 @@ func (c *IntrusionDetectionControllerDeployment) GetContainers() []corev1.Contai
 					if c.Spec.Template.Spec.Containers != nil {
 						cs := make([]corev1.Container, len(c.Spec.Template.Spec.Containers))
 						for i, v := range c.Spec.Template.Spec.Containers {
-							// Only copy and return the init container if it has resources set.
-							if v.Resources == nil {
+							// Only copy and return the container if it has resources or a startup probe set.
+							if v.Resources == nil && v.StartupProbe == nil {
 								continue
 							}
-							c := corev1.Container{Name: v.Name, Resources: *v.Resources}
+							c := corev1.Container{Name: v.Name, StartupProbe: v.StartupProbe.Probe()}
+							if v.Resources != nil {
+								c.Resources = *v.Resources
+							}
 							cs[i] = c
 						}
 						return cs
@@ -250,6 +362,32 @@ func (c *IntrusionDetectionControllerDeployment) GetPriorityClassName() string {
 	return ""
 }
 
+func (c *IntrusionDetectionControllerDeployment) GetDNSPolicy() corev1.DNSPolicy {
+	if c != nil {
+		if c.Spec != nil {
+			if c.Spec.Template != nil {
+				if c.Spec.Template.Spec != nil {
+					return c.Spec.Template.Spec.DNSPolicy
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func (c *IntrusionDetectionControllerDeployment) GetDNSConfig() *corev1.PodDNSConfig {
+	if c != nil {
+		if c.Spec != nil {
+			if c.Spec.Template != nil {
+				if c.Spec.Template.Spec != nil {
+					return c.Spec.Template.Spec.DNSConfig
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func init() {
 	SchemeBuilder.Register(&IntrusionDetection{}, &IntrusionDetectionList{})
 }
@@ -58,6 +58,19 @@ type L7LogCollectorDaemonSetPodSpec struct {
 	// If omitted, the L7LogCollector DaemonSet will use its default values for its containers.
 	// +optional
 	Containers []L7LogCollectorDaemonSetContainer `json:"containers,omitempty"`
+
+	// DNSPolicy is the DNS policy of the pods.
+	// If specified, this overrides the DNS policy of the L7LogCollector DaemonSet.
+	// If omitted, the L7LogCollector DaemonSet will use its default DNS policy.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig specifies additional DNS parameters of the pods, such as nameservers, searches and options.
+	// It is merged with the DNS configuration generated from the DNS policy.
+	// If omitted, the L7LogCollector DaemonSet will not set any additional DNS parameters.
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
 }
 
 // L7LogCollectorDaemonSetContainer is a L7LogCollector DaemonSet container.
@@ -84,6 +97,23 @@ type L7LogCollectorDaemonSetInitContainer struct {
 	// If omitted, the L7LogCollector DaemonSet will use its default value for this init container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env allows customization of the named L7LogCollector DaemonSet init container's environment variables.
+	// If specified, each variable is merged into the init container's environment by name, replacing any
+	// default value with the same name and appending the rest.
+	// If omitted, the L7LogCollector DaemonSet will use its default environment for this init container.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+
+	// Image overrides the image of the named L7LogCollector DaemonSet init container, for example to use a mirrored or patched image.
+	// If omitted, the L7LogCollector DaemonSet will use its default image for this init container.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Disabled removes the named L7LogCollector DaemonSet init container, for an init step that isn't needed in the cluster.
+	// If omitted, the L7LogCollector DaemonSet will run this init container.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
 }
 
 func (c *L7LogCollectorDaemonSet) GetMetadata() *Metadata {
@@ -105,11 +135,14 @@ func (c *L7LogCollectorDaemonSet) GetInitContainers() []v1.Container {
 				if c.Spec.Template.Spec.InitContainers != nil {
 					cs := make([]v1.Container, len(c.Spec.Template.Spec.InitContainers))
 					for i, v := range c.Spec.Template.Spec.InitContainers {
-						// Only copy and return the init container if it has resources set.
-						if v.Resources == nil {
+						// Only copy and return the init container if it has resources, env or an image set.
+						if v.Resources == nil && v.Env == nil && v.Image == "" {
 							continue
 						}
-						c := v1.Container{Name: v.Name, Resources: *v.Resources}
+						c := v1.Container{Name: v.Name, Env: v.Env, Image: v.Image}
+						if v.Resources != nil {
+							c.Resources = *v.Resources
+						}
 						cs[i] = c
 					}
 					return cs
@@ -121,6 +154,20 @@ func (c *L7LogCollectorDaemonSet) GetInitContainers() []v1.Container {
 	return nil
 }
 
+// GetDisabledInitContainers returns the names of the init containers that are disabled.
+func (c *L7LogCollectorDaemonSet) GetDisabledInitContainers() []string {
+	if c.Spec == nil || c.Spec.Template == nil || c.Spec.Template.Spec == nil {
+		return nil
+	}
+	var names []string
+	for _, v := range c.Spec.Template.Spec.InitContainers {
+		if v.Disabled != nil && *v.Disabled {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
 func (c *L7LogCollectorDaemonSet) GetContainers() []v1.Container {
 	if c != nil {
 		if c.Spec != nil {
@@ -172,3 +219,29 @@ func (c *L7LogCollectorDaemonSet) GetDeploymentStrategy() *appsv1.DeploymentStra
 func (c *L7LogCollectorDaemonSet) GetPriorityClassName() string {
 	return ""
 }
+
+func (c *L7LogCollectorDaemonSet) GetDNSPolicy() v1.DNSPolicy {
+	if c != nil {
+		if c.Spec != nil {
+			if c.Spec.Template != nil {
+				if c.Spec.Template.Spec != nil {
+					return c.Spec.Template.Spec.DNSPolicy
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func (c *L7LogCollectorDaemonSet) GetDNSConfig() *v1.PodDNSConfig {
+	if c != nil {
+		if c.Spec != nil {
+			if c.Spec.Template != nil {
+				if c.Spec.Template.Spec != nil {
+					return c.Spec.Template.Spec.DNSConfig
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -67,6 +67,16 @@ type LogStorageSpec struct {
 
 	// ElasticsearchMetricsDeployment configures the tigera-elasticsearch-metric Deployment.
 	ElasticsearchMetricsDeployment *ElasticsearchMetricsDeployment `json:"elasticsearchMetricsDeployment,omitempty"`
+
+	// ESGatewayDeployment configures the es-gateway Deployment.
+	// +optional
+	ESGatewayDeployment *ESGatewayDeployment `json:"esGatewayDeployment,omitempty"`
+
+	// MaintenanceWindows restricts when changes that cause a rolling restart of Elasticsearch are rolled out.
+	// Outside of the windows these changes are deferred, while other changes are still applied immediately. If no
+	// windows are configured, changes are always rolled out immediately.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
 }
 
 // LogStorageStatus defines the observed state of Tigera flow and DNS log storage.
@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -31,6 +32,41 @@ type ManagementClusterSpec struct {
 	// TLS provides options for configuring how Managed Clusters can establish an mTLS connection with the Management Cluster.
 	// +optional
 	TLS *TLS `json:"tls,omitempty"`
+
+	// TunnelService configures the Service that managed clusters connect to. If set, the operator renders the
+	// tigera-manager-tunnel Service in front of the tunnel port of Voltron, so that it doesn't need to be created
+	// manually. It isn't supported in multi-tenant management clusters.
+	// +optional
+	TunnelService *TunnelServiceOptions `json:"tunnelService,omitempty"`
+}
+
+// TunnelServiceOptions configures the Service that managed clusters connect to.
+type TunnelServiceOptions struct {
+	// Type determines how the Service is exposed.
+	// Default: NodePort
+	// +optional
+	// +kubebuilder:validation:Enum=NodePort;LoadBalancer
+	Type *v1.ServiceType `json:"type,omitempty"`
+
+	// Port is the port that managed clusters connect to. For a NodePort Service, it's the node port, which must be in
+	// the node port range of the cluster. For a LoadBalancer Service, it's the port of the load balancer.
+	// If omitted, a NodePort Service is assigned a node port by Kubernetes, and a LoadBalancer Service listens on 9449.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *int32 `json:"port,omitempty"`
+
+	// LoadBalancerSourceRanges restricts the CIDRs that may connect to a LoadBalancer Service, on cloud providers that
+	// support it. It may only be specified when Type is LoadBalancer.
+	// +optional
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+
+	// ExternalTrafficPolicy is set on the Service. Local preserves the source IP of the managed clusters and avoids a
+	// second hop, but only nodes that run Voltron accept connections.
+	// If omitted, the Kubernetes default of Cluster is used.
+	// +optional
+	// +kubebuilder:validation:Enum=Cluster;Local
+	ExternalTrafficPolicy *v1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
 }
 
 type TLS struct {
@@ -27,6 +27,13 @@ type ManagementClusterConnectionSpec struct {
 	// +optional
 	ManagementClusterAddr string `json:"managementClusterAddr,omitempty"`
 
+	// FailoverManagementClusterAddrs lists further addresses where the managed cluster can reach the management cluster,
+	// in the same form as managementClusterAddr. When managementClusterAddr is unreachable, Guardian tries these
+	// addresses in order until it can establish the tunnel, so that the managed cluster keeps reporting to the
+	// management cluster. This field is used by managed clusters only.
+	// +optional
+	FailoverManagementClusterAddrs []string `json:"failoverManagementClusterAddrs,omitempty"`
+
 	// TLS provides options for configuring how Managed Clusters can establish an mTLS connection with the Management Cluster.
 	// +optional
 	TLS *ManagementClusterTLS `json:"tls,omitempty"`
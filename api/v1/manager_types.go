@@ -28,10 +28,47 @@ type ManagerSpec struct {
 	// ManagerDeployment configures the Manager Deployment.
 	// +optional
 	ManagerDeployment *ManagerDeployment `json:"managerDeployment,omitempty"`
+
+	// ManagerService configures the Manager Service.
+	// +optional
+	ManagerService *ServiceOptions `json:"managerService,omitempty"`
+
+	// Ingress exposes the Manager UI outside of the cluster through an Ingress that the operator renders and owns. On
+	// OpenShift, a Route is rendered instead.
+	// If omitted, the Manager UI is only exposed through the Manager Service.
+	// +optional
+	Ingress *ManagerIngress `json:"ingress,omitempty"`
+}
+
+// ManagerIngress configures the Ingress, or the Route on OpenShift, that exposes the Manager UI. The Manager serves
+// HTTPS, so the ingress controller must connect to it over TLS. For ingress controllers that don't do so by default,
+// this is usually configured with an annotation, such as nginx.ingress.kubernetes.io/backend-protocol: HTTPS.
+type ManagerIngress struct {
+	// Host is the fully qualified domain name that the Manager UI is served at.
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+
+	// TLSSecretName is the name of a kubernetes.io/tls Secret in the namespace of the Manager, holding the certificate
+	// that is presented to browsers for the host. On OpenShift, the certificate and key are copied into the Route.
+	// If omitted, the default certificate of the ingress controller is presented.
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// IngressClassName is the IngressClass of the Ingress. It's not used on OpenShift.
+	// If omitted, the default IngressClass of the cluster is used.
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
+	// Annotations are added to the Ingress or Route, to configure the ingress controller.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // ManagerDeployment is the configuration for the Manager Deployment.
 type ManagerDeployment struct {
+	// Metadata is a subset of a Kubernetes object's metadata that is added to the Deployment.
+	// +optional
+	Metadata *Metadata `json:"metadata,omitempty"`
 
 	// Spec is the specification of the Manager Deployment.
 	// +optional
@@ -48,6 +85,10 @@ type ManagerDeploymentSpec struct {
 
 // ManagerDeploymentPodTemplateSpec is the Manager Deployment's PodTemplateSpec
 type ManagerDeploymentPodTemplateSpec struct {
+	// Metadata is a subset of a Kubernetes object's metadata that is added to
+	// the pod's metadata.
+	// +optional
+	Metadata *Metadata `json:"metadata,omitempty"`
 
 	// Spec is the Manager Deployment's PodSpec.
 	// +optional
@@ -67,6 +108,58 @@ type ManagerDeploymentPodSpec struct {
 	// If omitted, the Manager Deployment will use its default values for its containers.
 	// +optional
 	Containers []ManagerDeploymentContainer `json:"containers,omitempty"`
+
+	// Affinity is a group of affinity scheduling rules for the Manager pods.
+	// If specified, this overrides any affinity that may be set on the Manager Deployment.
+	// If omitted, the Manager Deployment will use its default value for affinity.
+	// WARNING: Please note that this field will override the default Manager Deployment affinity.
+	// +optional
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+
+	// NodeSelector is the Manager pod's scheduling constraints.
+	// If specified, each of the key/value pairs are added to the Manager Deployment nodeSelector provided
+	// the key does not already exist in the object's nodeSelector.
+	// If used in conjunction with ControlPlaneNodeSelector, that nodeSelector is set on the Manager Deployment
+	// and each of this field's key/value pairs are added to the Manager Deployment nodeSelector provided
+	// the key does not already exist in the object's nodeSelector.
+	// If omitted, the Manager Deployment will use its default value for nodeSelector.
+	// WARNING: Please note that this field will modify the default Manager Deployment nodeSelector.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations is the Manager pod's tolerations.
+	// If specified, this overrides any tolerations that may be set on the Manager Deployment.
+	// If omitted, the Manager Deployment will use its default value for tolerations.
+	// WARNING: Please note that this field will override the default Manager Deployment tolerations.
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+
+	// TerminationGracePeriodSeconds is the duration in seconds the Manager pods need to terminate gracefully.
+	// If specified, this overrides the default termination grace period of the Manager Deployment.
+	// It is also the time the container is given to drain its connections after it receives its termination signal.
+	// If omitted, the Manager Deployment will use its default value for the termination grace period.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// TopologySpreadConstraints describes how the Manager pods ought to spread across topology domains, such as zones.
+	// If specified, this overrides the topology spread constraints of the Manager Deployment.
+	// If omitted, the Manager Deployment will not have any topology spread constraints.
+	// +optional
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// DNSPolicy is the DNS policy of the pods.
+	// If specified, this overrides the DNS policy of the Manager Deployment.
+	// If omitted, the Manager Deployment will use its default DNS policy.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig specifies additional DNS parameters of the pods, such as nameservers, searches and options.
+	// It is merged with the DNS configuration generated from the DNS policy.
+	// If omitted, the Manager Deployment will not set any additional DNS parameters.
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
 }
 
 // ManagerDeploymentContainer is a Manager Deployment container.
@@ -81,6 +174,12 @@ type ManagerDeploymentContainer struct {
 	// If omitted, the Manager Deployment will use its default value for this container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// StartupProbe allows customization of the timings of the named Manager Deployment container's startup probe.
+	// If specified, the given timings override the defaults of the startup probe rendered for this container.
+	// If omitted, the Manager Deployment will use its default startup probe timings for this container.
+	// +optional
+	StartupProbe *ProbeTimings `json:"startupProbe,omitempty"`
 }
 
 // ManagerDeploymentInitContainer is a Manager Deployment init container.
@@ -96,6 +195,23 @@ type ManagerDeploymentInitContainer struct {
 	// If used in conjunction with the deprecated ComponentResources, then this value takes precedence.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env allows customization of the named Manager Deployment init container's environment variables.
+	// If specified, each variable is merged into the init container's environment by name, replacing any
+	// default value with the same name and appending the rest.
+	// If omitted, the Manager Deployment will use its default environment for this init container.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+
+	// Image overrides the image of the named Manager Deployment init container, for example to use a mirrored or patched image.
+	// If omitted, the Manager Deployment will use its default image for this init container.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Disabled removes the named Manager Deployment init container, for an init step that isn't needed in the cluster.
+	// If omitted, the Manager Deployment will run this init container.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
 }
 
 // ManagerStatus defines the observed state of the Calico Enterprise manager GUI.
@@ -108,6 +224,14 @@ type ManagerStatus struct {
 	// Ready, Progressing, Degraded or other customer types.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the resource that the operator has reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Rollout summarizes the rollout of the component's workloads.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -136,7 +260,7 @@ type ManagerList struct {
 }
 
 func (c *ManagerDeployment) GetMetadata() *Metadata {
-	return nil
+	return c.Metadata
 }
 
 func (c *ManagerDeployment) GetMinReadySeconds() *int32 {
@@ -144,6 +268,11 @@ func (c *ManagerDeployment) GetMinReadySeconds() *int32 {
 }
 
 func (c *ManagerDeployment) GetPodTemplateMetadata() *Metadata {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			return c.Spec.Template.Metadata
+		}
+	}
 	return nil
 }
 
@@ -154,11 +283,14 @@ func (c *ManagerDeployment) GetInitContainers() []v1.Container {
 				if c.Spec.Template.Spec.InitContainers != nil {
 					cs := make([]v1.Container, len(c.Spec.Template.Spec.InitContainers))
 					for i, v := range c.Spec.Template.Spec.InitContainers {
-						// Only copy and return the init container if it has resources set.
-						if v.Resources == nil {
+						// Only copy and return the init container if it has resources, env or an image set.
+						if v.Resources == nil && v.Env == nil && v.Image == "" {
 							continue
 						}
-						c := v1.Container{Name: v.Name, Resources: *v.Resources}
+						c := v1.Container{Name: v.Name, Env: v.Env, Image: v.Image}
+						if v.Resources != nil {
+							c.Resources = *v.Resources
+						}
 						cs[i] = c
 					}
 					return cs
@@ -170,6 +302,20 @@ func (c *ManagerDeployment) GetInitContainers() []v1.Container {
 	return nil
 }
 
+// GetDisabledInitContainers returns the names of the init containers that are disabled.
+func (c *ManagerDeployment) GetDisabledInitContainers() []string {
+	if c.Spec == nil || c.Spec.Template == nil || c.Spec.Template.Spec == nil {
+		return nil
+	}
+	var names []string
+	for _, v := range c.Spec.Template.Spec.InitContainers {
+		if v.Disabled != nil && *v.Disabled {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
 func (c *ManagerDeployment) GetContainers() []v1.Container {
 	if c != nil {
 		if c.Spec != nil {
@@ -178,11 +324,14 @@ func (c *ManagerDeployment) GetContainers() []v1.Container {
 					if c.Spec.Template.Spec.Containers != nil {
 						cs := make([]v1.Container, len(c.Spec.Template.Spec.Containers))
 						for i, v := range c.Spec.Template.Spec.Containers {
-							// Only copy and return the init container if it has resources set.
-							if v.Resources == nil {
+							// Only copy and return the container if it has resources or a startup probe set.
+							if v.Resources == nil && v.StartupProbe == nil {
 								continue
 							}
-							c := v1.Container{Name: v.Name, Resources: *v.Resources}
+							c := v1.Container{Name: v.Name, StartupProbe: v.StartupProbe.Probe()}
+							if v.Resources != nil {
+								c.Resources = *v.Resources
+							}
 							cs[i] = c
 						}
 						return cs
@@ -195,22 +344,59 @@ func (c *ManagerDeployment) GetContainers() []v1.Container {
 }
 
 func (c *ManagerDeployment) GetAffinity() *v1.Affinity {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.Affinity
+			}
+		}
+	}
 	return nil
 }
 
 func (c *ManagerDeployment) GetTopologySpreadConstraints() []v1.TopologySpreadConstraint {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.TopologySpreadConstraints
+			}
+		}
+	}
 	return nil
 }
 
 func (c *ManagerDeployment) GetNodeSelector() map[string]string {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.NodeSelector
+			}
+		}
+	}
 	return nil
 }
 
 func (c *ManagerDeployment) GetTolerations() []v1.Toleration {
+	if c.Spec != nil {
+		if c.Spec.Template != nil {
+			if c.Spec.Template.Spec != nil {
+				return c.Spec.Template.Spec.Tolerations
+			}
+		}
+	}
 	return nil
 }
 
 func (c *ManagerDeployment) GetTerminationGracePeriodSeconds() *int64 {
+	if c != nil {
+		if c.Spec != nil {
+			if c.Spec.Template != nil {
+				if c.Spec.Template.Spec != nil {
+					return c.Spec.Template.Spec.TerminationGracePeriodSeconds
+				}
+			}
+		}
+	}
 	return nil
 }
 
@@ -222,6 +408,32 @@ func (c *ManagerDeployment) GetPriorityClassName() string {
 	return ""
 }
 
+func (c *ManagerDeployment) GetDNSPolicy() v1.DNSPolicy {
+	if c != nil {
+		if c.Spec != nil {
+			if c.Spec.Template != nil {
+				if c.Spec.Template.Spec != nil {
+					return c.Spec.Template.Spec.DNSPolicy
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func (c *ManagerDeployment) GetDNSConfig() *v1.PodDNSConfig {
+	if c != nil {
+		if c.Spec != nil {
+			if c.Spec.Template != nil {
+				if c.Spec.Template.Spec != nil {
+					return c.Spec.Template.Spec.DNSConfig
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func init() {
 	SchemeBuilder.Register(&Manager{}, &ManagerList{})
 }
@@ -36,6 +36,80 @@ type MonitorSpec struct {
 	// AlertManager is the configuration for the AlertManager.
 	// +optional
 	AlertManager *AlertManager `json:"alertManager,omitempty"`
+
+	// Metrics controls which of the Calico metrics are kept by the Prometheus that the operator runs. Metrics that are
+	// labeled per policy or per endpoint can produce a very large number of series on big clusters.
+	// +optional
+	Metrics *MonitorMetrics `json:"metrics,omitempty"`
+}
+
+type DefaultRelabelingsType string
+
+const (
+	DefaultRelabelingsEnabled  DefaultRelabelingsType = "Enabled"
+	DefaultRelabelingsDisabled DefaultRelabelingsType = "Disabled"
+)
+
+// MetricsTarget identifies a set of Calico metrics that the Prometheus of the operator scrapes.
+// +kubebuilder:validation:Enum=CalicoNode;Typha;KubeControllers;Fluentd;Elasticsearch;QueryServer
+type MetricsTarget string
+
+const (
+	MetricsTargetCalicoNode      MetricsTarget = "CalicoNode"
+	MetricsTargetTypha           MetricsTarget = "Typha"
+	MetricsTargetKubeControllers MetricsTarget = "KubeControllers"
+	MetricsTargetFluentd         MetricsTarget = "Fluentd"
+	MetricsTargetElasticsearch   MetricsTarget = "Elasticsearch"
+	MetricsTargetQueryServer     MetricsTarget = "QueryServer"
+)
+
+type MetricRelabelingAction string
+
+const (
+	// MetricRelabelingDrop drops the series whose source label values match the regex.
+	MetricRelabelingDrop MetricRelabelingAction = "Drop"
+	// MetricRelabelingKeep drops the series whose source label values don't match the regex.
+	MetricRelabelingKeep MetricRelabelingAction = "Keep"
+	// MetricRelabelingLabelDrop removes the labels whose names match the regex from every series.
+	MetricRelabelingLabelDrop MetricRelabelingAction = "LabelDrop"
+)
+
+type MonitorMetrics struct {
+	// DefaultRelabelings controls whether the operator drops the high-cardinality series that Calico's dashboards and
+	// alerts don't use. The defaults are applied before the Relabelings.
+	// Default: Enabled
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	DefaultRelabelings *DefaultRelabelingsType `json:"defaultRelabelings,omitempty"`
+
+	// Relabelings are applied, in order, to the metrics of each target before they are ingested.
+	// +optional
+	Relabelings []MetricRelabeling `json:"relabelings,omitempty"`
+}
+
+// MetricRelabeling is a rule that drops or keeps Calico metrics, or drops labels from them.
+type MetricRelabeling struct {
+	// Targets are the sets of metrics that the rule applies to. When empty, the rule applies to all of them.
+	// +optional
+	Targets []MetricsTarget `json:"targets,omitempty"`
+
+	// Action is what is done with the series or labels that match.
+	// +kubebuilder:validation:Enum=Drop;Keep;LabelDrop
+	Action MetricRelabelingAction `json:"action"`
+
+	// SourceLabels are the labels whose values, joined with ";", are matched against the regex by the Drop and Keep
+	// actions. They must not be set for the LabelDrop action, which matches label names.
+	// Default: __name__
+	// +optional
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+
+	// Regex is the RE2 regular expression that is matched. It is anchored at both ends.
+	Regex string `json:"regex"`
+}
+
+// UseDefaultRelabelings returns whether the operator's default relabelings are applied.
+func (m *MonitorMetrics) UseDefaultRelabelings() bool {
+	return m == nil || m.DefaultRelabelings == nil || *m.DefaultRelabelings == DefaultRelabelingsEnabled
 }
 
 type ExternalPrometheus struct {
@@ -0,0 +1,110 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OperatorConfigSpec defines the desired state of OperatorConfig.
+type OperatorConfigSpec struct {
+	// Tracing configures the export of traces of the operator's work, and of the components that support it.
+	// Tracing is disabled if this is not set.
+	// +optional
+	Tracing *OperatorTracing `json:"tracing,omitempty"`
+
+	// Controllers configures the operator's controllers by name. Controllers that are not listed use their defaults.
+	// Changes take effect when the operator restarts, which it does by itself when they change.
+	// +optional
+	Controllers []OperatorControllerConfig `json:"controllers,omitempty"`
+}
+
+// OperatorControllerConfig configures one of the operator's controllers.
+type OperatorControllerConfig struct {
+	// Name is the name of the controller, e.g., "manager-controller".
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// MaxConcurrentReconciles is the maximum number of requests that the controller reconciles at the same time. It
+	// only applies in multi-tenant mode, where the requests of different tenants are reconciled in parallel and the
+	// requests of each tenant one at a time.
+	// Default: 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentReconciles *int32 `json:"maxConcurrentReconciles,omitempty"`
+}
+
+// ComponentTracingType specifies whether the components that support tracing export their traces.
+// +kubebuilder:validation:Enum=Enabled;Disabled
+type ComponentTracingType string
+
+const (
+	ComponentTracingEnabled  ComponentTracingType = "Enabled"
+	ComponentTracingDisabled ComponentTracingType = "Disabled"
+)
+
+// OperatorTracing configures where traces are exported to, and how many of them are.
+type OperatorTracing struct {
+	// Endpoint is the URL of the OTLP/HTTP receiver that traces are exported to, for example
+	// "http://otel-collector.observability.svc:4318".
+	Endpoint string `json:"endpoint"`
+
+	// SamplingPercentage is the percentage of reconciles that are traced. A trace that is started by another
+	// component is sampled if that component sampled it.
+	// Default: 100
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	SamplingPercentage *int32 `json:"samplingPercentage,omitempty"`
+
+	// Components specifies whether the components that support tracing are configured to export their traces to
+	// the same endpoint. Enabling it restarts those components.
+	// Default: Disabled
+	// +optional
+	Components *ComponentTracingType `json:"components,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// OperatorConfig configures the operator itself, rather than any of the components that it installs. At most one
+// instance of this resource is supported. It must be named "default".
+type OperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired state for the operator.
+	Spec OperatorConfigSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OperatorConfigList contains a list of OperatorConfig
+type OperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OperatorConfig `json:"items"`
+}
+
+// ComponentsEnabled returns whether the components that support tracing export their traces.
+func (t *OperatorTracing) ComponentsEnabled() bool {
+	return t != nil && t.Components != nil && *t.Components == ComponentTracingEnabled
+}
+
+func init() {
+	SchemeBuilder.Register(&OperatorConfig{}, &OperatorConfigList{})
+}
@@ -66,6 +66,19 @@ type PolicyRecommendationDeploymentPodSpec struct {
 	// If omitted, the PolicyRecommendation Deployment will use its default values for its containers.
 	// +optional
 	Containers []PolicyRecommendationDeploymentContainer `json:"containers,omitempty"`
+
+	// DNSPolicy is the DNS policy of the pods.
+	// If specified, this overrides the DNS policy of the PolicyRecommendation Deployment.
+	// If omitted, the PolicyRecommendation Deployment will use its default DNS policy.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig specifies additional DNS parameters of the pods, such as nameservers, searches and options.
+	// It is merged with the DNS configuration generated from the DNS policy.
+	// If omitted, the PolicyRecommendation Deployment will not set any additional DNS parameters.
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
 }
 
 // PolicyRecommendationDeploymentContainer is a PolicyRecommendation Deployment container.
@@ -93,12 +106,34 @@ type PolicyRecommendationDeploymentInitContainer struct {
 	// If omitted, the PolicyRecommendation Deployment will use its default value for this init container's resources.
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env allows customization of the named PolicyRecommendation Deployment init container's environment variables.
+	// If specified, each variable is merged into the init container's environment by name, replacing any
+	// default value with the same name and appending the rest.
+	// If omitted, the PolicyRecommendation Deployment will use its default environment for this init container.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+
+	// Image overrides the image of the named PolicyRecommendation Deployment init container, for example to use a mirrored or patched image.
+	// If omitted, the PolicyRecommendation Deployment will use its default image for this init container.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Disabled removes the named PolicyRecommendation Deployment init container, for an init step that isn't needed in the cluster.
+	// If omitted, the PolicyRecommendation Deployment will run this init container.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
 }
 
 // PolicyRecommendationStatus defines the observed state of Tigera policy recommendation.
 type PolicyRecommendationStatus struct {
 	// State provides user-readable status.
 	State string `json:"state,omitempty"`
+
+	// Conditions represents the latest observed set of conditions for the component. A component may be one or more of
+	// Ready, Progressing, Degraded or other customer types.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -144,11 +179,14 @@ func (c *PolicyRecommendationDeployment) GetInitContainers() []v1.Container {
 					if c.Spec.Template.Spec.InitContainers != nil {
 						cs := make([]v1.Container, len(c.Spec.Template.Spec.InitContainers))
 						for i, v := range c.Spec.Template.Spec.InitContainers {
-							// Only copy and return the init container if it has resources set.
-							if v.Resources == nil {
+							// Only copy and return the init container if it has resources, env or an image set.
+							if v.Resources == nil && v.Env == nil && v.Image == "" {
 								continue
 							}
-							c := v1.Container{Name: v.Name, Resources: *v.Resources}
+							c := v1.Container{Name: v.Name, Env: v.Env, Image: v.Image}
+							if v.Resources != nil {
+								c.Resources = *v.Resources
+							}
 							cs[i] = c
 						}
 						return cs
@@ -160,6 +198,20 @@ func (c *PolicyRecommendationDeployment) GetInitContainers() []v1.Container {
 	return nil
 }
 
+// GetDisabledInitContainers returns the names of the init containers that are disabled.
+func (c *PolicyRecommendationDeployment) GetDisabledInitContainers() []string {
+	if c.Spec == nil || c.Spec.Template == nil || c.Spec.Template.Spec == nil {
+		return nil
+	}
+	var names []string
+	for _, v := range c.Spec.Template.Spec.InitContainers {
+		if v.Disabled != nil && *v.Disabled {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
 func (c *PolicyRecommendationDeployment) GetContainers() []v1.Container {
 	if c != nil {
 		if c.Spec != nil {
@@ -212,6 +264,32 @@ func (c *PolicyRecommendationDeployment) GetPriorityClassName() string {
 	return ""
 }
 
+func (c *PolicyRecommendationDeployment) GetDNSPolicy() v1.DNSPolicy {
+	if c != nil {
+		if c.Spec != nil {
+			if c.Spec.Template != nil {
+				if c.Spec.Template.Spec != nil {
+					return c.Spec.Template.Spec.DNSPolicy
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func (c *PolicyRecommendationDeployment) GetDNSConfig() *v1.PodDNSConfig {
+	if c != nil {
+		if c.Spec != nil {
+			if c.Spec.Template != nil {
+				if c.Spec.Template.Spec != nil {
+					return c.Spec.Template.Spec.DNSConfig
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func init() {
 	SchemeBuilder.Register(&PolicyRecommendation{}, &PolicyRecommendationList{})
 }
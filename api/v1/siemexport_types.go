@@ -0,0 +1,54 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+// SIEMExport configures a scheduled Job that exports data to object storage in JSON Lines format, one JSON object
+// per line, so that a SIEM can ingest it. Each run exports the data created since the previous run. It is not
+// supported in managed clusters or multi-tenant management clusters.
+type SIEMExport struct {
+	// Schedule is a cron expression for the times at which the export runs.
+	// Default: "0 * * * *" (hourly)
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// ObjectStorage is the bucket that the data is exported to.
+	ObjectStorage SIEMExportObjectStorage `json:"objectStorage"`
+}
+
+// SIEMExportObjectStorage is an S3 compatible object storage bucket.
+type SIEMExportObjectStorage struct {
+	// Bucket is the name of the bucket.
+	// +kubebuilder:validation:MinLength=1
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to the keys of the exported objects.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Region is the region of the bucket.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Endpoint is the https URL of an S3 compatible object storage service. If omitted, AWS S3 is used.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// CredentialsSecretName is the name of the Secret in the tigera-operator namespace that holds the access key of
+	// the bucket, under the access-key-id and secret-access-key keys.
+	// +kubebuilder:validation:MinLength=1
+	CredentialsSecretName string `json:"credentialsSecretName"`
+}
@@ -33,6 +33,70 @@ type TigeraStatusStatus struct {
 	// Conditions represents the latest observed set of conditions for this component. A component may be one or more of
 	// Available, Progressing, or Degraded.
 	Conditions []TigeraStatusCondition `json:"conditions"`
+
+	// Versions lists the images that the containers of this component's workloads run.
+	// +optional
+	Versions []ComponentVersion `json:"versions,omitempty"`
+
+	// AppliedRevisions lists the revision of the desired state that the operator last applied for each of the parts
+	// that make up this component, and when it was applied.
+	// +optional
+	AppliedRevisions []AppliedRevision `json:"appliedRevisions,omitempty"`
+
+	// Certificates lists the validity of the certificates that the operator manages, and when it renews those that it
+	// issued. It's only set on the status of the certificates component.
+	// +optional
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+}
+
+// CertificateStatus is the validity of a certificate that the operator manages.
+type CertificateStatus struct {
+	// Name is the name of the secret that holds the certificate.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the secret that holds the certificate.
+	Namespace string `json:"namespace"`
+
+	// Issuer is the common name of the issuer of the certificate.
+	Issuer string `json:"issuer"`
+
+	// NotAfter is the time that the certificate expires.
+	NotAfter metav1.Time `json:"notAfter"`
+
+	// RenewAfter is the time after which the operator re-issues the certificate. It's only set for the certificates
+	// that the operator issued, other certificates must be replaced before they expire by whoever provided them.
+	// +optional
+	RenewAfter *metav1.Time `json:"renewAfter,omitempty"`
+}
+
+// AppliedRevision is the revision of the desired state that the operator last applied for a part of a component.
+type AppliedRevision struct {
+	// Component is the name of the part of the component that the revision was rendered for. For parts that the
+	// operator renders per tenant, it ends with the namespace of the tenant.
+	Component string `json:"component"`
+
+	// Revision is a hash of the objects that the operator rendered for the part. It changes whenever any of them do.
+	Revision string `json:"revision"`
+
+	// AppliedAt is the time that the revision was first applied successfully.
+	AppliedAt metav1.Time `json:"appliedAt"`
+}
+
+// ComponentVersion is the image that a container of one of a component's workloads runs.
+type ComponentVersion struct {
+	// Workload is the kind, namespace and name of the workload, such as DaemonSet/calico-system/calico-node.
+	Workload string `json:"workload"`
+
+	// Container is the name of the container.
+	Container string `json:"container"`
+
+	// Image is the image of the container in the workload's pod template.
+	Image string `json:"image"`
+
+	// ImageID is the digest of the image that the workload's pods run, as reported by the container runtime. It is
+	// only set when every pod of the workload runs the same image, so it's left out during a rollout.
+	// +optional
+	ImageID string `json:"imageID,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -117,26 +181,28 @@ type TigeraStatusList struct {
 type TigeraStatusReason string
 
 const (
-	AllObjectsAvailable       TigeraStatusReason = "AllObjectsAvailable"
-	ResourceNotReady          TigeraStatusReason = "ResourceNotReady"
-	PodFailure                TigeraStatusReason = "PodFailure"
-	CertificateError          TigeraStatusReason = "CertificateError"
-	InvalidConfigurationError TigeraStatusReason = "InvalidConfigurationError"
-	ResourceCreateError       TigeraStatusReason = "ResourceCreateError"
-	ResourceMigrationError    TigeraStatusReason = "ResourceMigrationError"
-	ResourceNotFound          TigeraStatusReason = "ResourceNotFound"
-	ResourcePatchError        TigeraStatusReason = "ResourcePatchError"
-	ResourceReadError         TigeraStatusReason = "ResourceReadError"
-	ResourceRenderingError    TigeraStatusReason = "ResourceRenderingError"
-	ResourceScalingError      TigeraStatusReason = "ResourceScalingError"
-	ResourceUpdateError       TigeraStatusReason = "ResourceUpdateError"
-	ResourceValidationError   TigeraStatusReason = "ResourceValidationError"
-	MigrationError            TigeraStatusReason = "MigrationError"
-	InternalServerError       TigeraStatusReason = "InternalServerError"
-	NotApplicable             TigeraStatusReason = "NotApplicable"
-	UpgradeError              TigeraStatusReason = "UpgradeError"
-	Unknown                   TigeraStatusReason = "Unknown"
-	ImageSetError             TigeraStatusReason = "ImageSetError"
+	AllObjectsAvailable        TigeraStatusReason = "AllObjectsAvailable"
+	ResourceNotReady           TigeraStatusReason = "ResourceNotReady"
+	DependencyNotReady         TigeraStatusReason = "DependencyNotReady"
+	PodFailure                 TigeraStatusReason = "PodFailure"
+	CertificateError           TigeraStatusReason = "CertificateError"
+	InvalidConfigurationError  TigeraStatusReason = "InvalidConfigurationError"
+	ProviderConfigurationError TigeraStatusReason = "ProviderConfigurationError"
+	ResourceCreateError        TigeraStatusReason = "ResourceCreateError"
+	ResourceMigrationError     TigeraStatusReason = "ResourceMigrationError"
+	ResourceNotFound           TigeraStatusReason = "ResourceNotFound"
+	ResourcePatchError         TigeraStatusReason = "ResourcePatchError"
+	ResourceReadError          TigeraStatusReason = "ResourceReadError"
+	ResourceRenderingError     TigeraStatusReason = "ResourceRenderingError"
+	ResourceScalingError       TigeraStatusReason = "ResourceScalingError"
+	ResourceUpdateError        TigeraStatusReason = "ResourceUpdateError"
+	ResourceValidationError    TigeraStatusReason = "ResourceValidationError"
+	MigrationError             TigeraStatusReason = "MigrationError"
+	InternalServerError        TigeraStatusReason = "InternalServerError"
+	NotApplicable              TigeraStatusReason = "NotApplicable"
+	UpgradeError               TigeraStatusReason = "UpgradeError"
+	Unknown                    TigeraStatusReason = "Unknown"
+	ImageSetError              TigeraStatusReason = "ImageSetError"
 )
 
 func init() {
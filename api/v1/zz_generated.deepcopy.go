@@ -108,6 +108,18 @@ func (in *APIServerDeploymentInitContainer) DeepCopyInto(out *APIServerDeploymen
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerDeploymentInitContainer.
@@ -163,6 +175,11 @@ func (in *APIServerDeploymentPodSpec) DeepCopyInto(out *APIServerDeploymentPodSp
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerDeploymentPodSpec.
@@ -546,6 +563,22 @@ func (in *ApplicationLayerStatus) DeepCopy() *ApplicationLayerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppliedRevision) DeepCopyInto(out *AppliedRevision) {
+	*out = *in
+	in.AppliedAt.DeepCopyInto(&out.AppliedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedRevision.
+func (in *AppliedRevision) DeepCopy() *AppliedRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(AppliedRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Authentication) DeepCopyInto(out *Authentication) {
 	*out = *in
@@ -680,6 +713,31 @@ func (in *AuthenticationOpenshift) DeepCopy() *AuthenticationOpenshift {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthenticationSession) DeepCopyInto(out *AuthenticationSession) {
+	*out = *in
+	if in.TokenLifetime != nil {
+		in, out := &in.TokenLifetime, &out.TokenLifetime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationSession.
+func (in *AuthenticationSession) DeepCopy() *AuthenticationSession {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthenticationSession)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthenticationSpec) DeepCopyInto(out *AuthenticationSpec) {
 	*out = *in
@@ -703,6 +761,11 @@ func (in *AuthenticationSpec) DeepCopyInto(out *AuthenticationSpec) {
 		*out = new(DexDeployment)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Session != nil {
+		in, out := &in.Session, &out.Session
+		*out = new(AuthenticationSession)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationSpec.
@@ -737,6 +800,31 @@ func (in *AuthenticationStatus) DeepCopy() *AuthenticationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CISBenchmark) DeepCopyInto(out *CISBenchmark) {
+	*out = *in
+	if in.ControlPlaneChecks != nil {
+		in, out := &in.ControlPlaneChecks, &out.ControlPlaneChecks
+		*out = new(ControlPlaneChecks)
+		**out = **in
+	}
+	if in.SkipChecks != nil {
+		in, out := &in.SkipChecks, &out.SkipChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CISBenchmark.
+func (in *CISBenchmark) DeepCopy() *CISBenchmark {
+	if in == nil {
+		return nil
+	}
+	out := new(CISBenchmark)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CNILogging) DeepCopyInto(out *CNILogging) {
 	*out = *in
@@ -866,6 +954,11 @@ func (in *CSINodeDriverDaemonSetPodSpec) DeepCopyInto(out *CSINodeDriverDaemonSe
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSINodeDriverDaemonSetPodSpec.
@@ -1002,6 +1095,11 @@ func (in *CalicoKubeControllersDeploymentPodSpec) DeepCopyInto(out *CalicoKubeCo
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoKubeControllersDeploymentPodSpec.
@@ -1089,6 +1187,11 @@ func (in *CalicoNetworkSpec) DeepCopyInto(out *CalicoNetworkSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PodNetworkExpansions != nil {
+		in, out := &in.PodNetworkExpansions, &out.PodNetworkExpansions
+		*out = make([]PodNetworkExpansion, len(*in))
+		copy(*out, *in)
+	}
 	if in.MTU != nil {
 		in, out := &in.MTU, &out.MTU
 		*out = new(int32)
@@ -1109,11 +1212,26 @@ func (in *CalicoNetworkSpec) DeepCopyInto(out *CalicoNetworkSpec) {
 		*out = new(HostPortsType)
 		**out = **in
 	}
+	if in.BandwidthPlugin != nil {
+		in, out := &in.BandwidthPlugin, &out.BandwidthPlugin
+		*out = new(BandwidthPluginType)
+		**out = **in
+	}
 	if in.MultiInterfaceMode != nil {
 		in, out := &in.MultiInterfaceMode, &out.MultiInterfaceMode
 		*out = new(MultiInterfaceMode)
 		**out = **in
 	}
+	if in.MultusCompatibility != nil {
+		in, out := &in.MultusCompatibility, &out.MultusCompatibility
+		*out = new(MultusCompatibility)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WireGuard != nil {
+		in, out := &in.WireGuard, &out.WireGuard
+		*out = new(WireGuardType)
+		**out = **in
+	}
 	if in.ContainerIPForwarding != nil {
 		in, out := &in.ContainerIPForwarding, &out.ContainerIPForwarding
 		*out = new(ContainerIPForwardingType)
@@ -1194,6 +1312,18 @@ func (in *CalicoNodeDaemonSetInitContainer) DeepCopyInto(out *CalicoNodeDaemonSe
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoNodeDaemonSetInitContainer.
@@ -1242,6 +1372,11 @@ func (in *CalicoNodeDaemonSetPodSpec) DeepCopyInto(out *CalicoNodeDaemonSetPodSp
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoNodeDaemonSetPodSpec.
@@ -1357,6 +1492,18 @@ func (in *CalicoNodeWindowsDaemonSetInitContainer) DeepCopyInto(out *CalicoNodeW
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoNodeWindowsDaemonSetInitContainer.
@@ -1405,6 +1552,11 @@ func (in *CalicoNodeWindowsDaemonSetPodSpec) DeepCopyInto(out *CalicoNodeWindows
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoNodeWindowsDaemonSetPodSpec.
@@ -1624,30 +1776,27 @@ func (in *CertificateManagement) DeepCopy() *CertificateManagement {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CommonPrometheusFields) DeepCopyInto(out *CommonPrometheusFields) {
+func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
 	*out = *in
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]PrometheusContainer, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+	if in.RenewAfter != nil {
+		in, out := &in.RenewAfter, &out.RenewAfter
+		*out = (*in).DeepCopy()
 	}
-	in.Resources.DeepCopyInto(&out.Resources)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonPrometheusFields.
-func (in *CommonPrometheusFields) DeepCopy() *CommonPrometheusFields {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateStatus.
+func (in *CertificateStatus) DeepCopy() *CertificateStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CommonPrometheusFields)
+	out := new(CertificateStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Compliance) DeepCopyInto(out *Compliance) {
+func (in *CloudConnector) DeepCopyInto(out *CloudConnector) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1655,18 +1804,18 @@ func (in *Compliance) DeepCopyInto(out *Compliance) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Compliance.
-func (in *Compliance) DeepCopy() *Compliance {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudConnector.
+func (in *CloudConnector) DeepCopy() *CloudConnector {
 	if in == nil {
 		return nil
 	}
-	out := new(Compliance)
+	out := new(CloudConnector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Compliance) DeepCopyObject() runtime.Object {
+func (in *CloudConnector) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1674,47 +1823,27 @@ func (in *Compliance) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComplianceBenchmarkerDaemonSet) DeepCopyInto(out *ComplianceBenchmarkerDaemonSet) {
+func (in *CloudConnectorDeployment) DeepCopyInto(out *CloudConnectorDeployment) {
 	*out = *in
 	if in.Spec != nil {
 		in, out := &in.Spec, &out.Spec
-		*out = new(ComplianceBenchmarkerDaemonSetSpec)
-		(*in).DeepCopyInto(*out)
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceBenchmarkerDaemonSet.
-func (in *ComplianceBenchmarkerDaemonSet) DeepCopy() *ComplianceBenchmarkerDaemonSet {
-	if in == nil {
-		return nil
-	}
-	out := new(ComplianceBenchmarkerDaemonSet)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComplianceBenchmarkerDaemonSetContainer) DeepCopyInto(out *ComplianceBenchmarkerDaemonSetContainer) {
-	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
+		*out = new(CloudConnectorDeploymentSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceBenchmarkerDaemonSetContainer.
-func (in *ComplianceBenchmarkerDaemonSetContainer) DeepCopy() *ComplianceBenchmarkerDaemonSetContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudConnectorDeployment.
+func (in *CloudConnectorDeployment) DeepCopy() *CloudConnectorDeployment {
 	if in == nil {
 		return nil
 	}
-	out := new(ComplianceBenchmarkerDaemonSetContainer)
+	out := new(CloudConnectorDeployment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComplianceBenchmarkerDaemonSetInitContainer) DeepCopyInto(out *ComplianceBenchmarkerDaemonSetInitContainer) {
+func (in *CloudConnectorDeploymentContainer) DeepCopyInto(out *CloudConnectorDeploymentContainer) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
@@ -1723,240 +1852,225 @@ func (in *ComplianceBenchmarkerDaemonSetInitContainer) DeepCopyInto(out *Complia
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceBenchmarkerDaemonSetInitContainer.
-func (in *ComplianceBenchmarkerDaemonSetInitContainer) DeepCopy() *ComplianceBenchmarkerDaemonSetInitContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudConnectorDeploymentContainer.
+func (in *CloudConnectorDeploymentContainer) DeepCopy() *CloudConnectorDeploymentContainer {
 	if in == nil {
 		return nil
 	}
-	out := new(ComplianceBenchmarkerDaemonSetInitContainer)
+	out := new(CloudConnectorDeploymentContainer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComplianceBenchmarkerDaemonSetPodSpec) DeepCopyInto(out *ComplianceBenchmarkerDaemonSetPodSpec) {
+func (in *CloudConnectorDeploymentPodSpec) DeepCopyInto(out *CloudConnectorDeploymentPodSpec) {
 	*out = *in
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]ComplianceBenchmarkerDaemonSetInitContainer, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	if in.Containers != nil {
 		in, out := &in.Containers, &out.Containers
-		*out = make([]ComplianceBenchmarkerDaemonSetContainer, len(*in))
+		*out = make([]CloudConnectorDeploymentContainer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceBenchmarkerDaemonSetPodSpec.
-func (in *ComplianceBenchmarkerDaemonSetPodSpec) DeepCopy() *ComplianceBenchmarkerDaemonSetPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudConnectorDeploymentPodSpec.
+func (in *CloudConnectorDeploymentPodSpec) DeepCopy() *CloudConnectorDeploymentPodSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ComplianceBenchmarkerDaemonSetPodSpec)
+	out := new(CloudConnectorDeploymentPodSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComplianceBenchmarkerDaemonSetPodTemplateSpec) DeepCopyInto(out *ComplianceBenchmarkerDaemonSetPodTemplateSpec) {
+func (in *CloudConnectorDeploymentPodTemplateSpec) DeepCopyInto(out *CloudConnectorDeploymentPodTemplateSpec) {
 	*out = *in
 	if in.Spec != nil {
 		in, out := &in.Spec, &out.Spec
-		*out = new(ComplianceBenchmarkerDaemonSetPodSpec)
+		*out = new(CloudConnectorDeploymentPodSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceBenchmarkerDaemonSetPodTemplateSpec.
-func (in *ComplianceBenchmarkerDaemonSetPodTemplateSpec) DeepCopy() *ComplianceBenchmarkerDaemonSetPodTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudConnectorDeploymentPodTemplateSpec.
+func (in *CloudConnectorDeploymentPodTemplateSpec) DeepCopy() *CloudConnectorDeploymentPodTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ComplianceBenchmarkerDaemonSetPodTemplateSpec)
+	out := new(CloudConnectorDeploymentPodTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComplianceBenchmarkerDaemonSetSpec) DeepCopyInto(out *ComplianceBenchmarkerDaemonSetSpec) {
+func (in *CloudConnectorDeploymentSpec) DeepCopyInto(out *CloudConnectorDeploymentSpec) {
 	*out = *in
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
-		*out = new(ComplianceBenchmarkerDaemonSetPodTemplateSpec)
+		*out = new(CloudConnectorDeploymentPodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceBenchmarkerDaemonSetSpec.
-func (in *ComplianceBenchmarkerDaemonSetSpec) DeepCopy() *ComplianceBenchmarkerDaemonSetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudConnectorDeploymentSpec.
+func (in *CloudConnectorDeploymentSpec) DeepCopy() *CloudConnectorDeploymentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ComplianceBenchmarkerDaemonSetSpec)
+	out := new(CloudConnectorDeploymentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComplianceControllerDeployment) DeepCopyInto(out *ComplianceControllerDeployment) {
+func (in *CloudConnectorList) DeepCopyInto(out *CloudConnectorList) {
 	*out = *in
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(ComplianceControllerDeploymentSpec)
-		(*in).DeepCopyInto(*out)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudConnector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceControllerDeployment.
-func (in *ComplianceControllerDeployment) DeepCopy() *ComplianceControllerDeployment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudConnectorList.
+func (in *CloudConnectorList) DeepCopy() *CloudConnectorList {
 	if in == nil {
 		return nil
 	}
-	out := new(ComplianceControllerDeployment)
+	out := new(CloudConnectorList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudConnectorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComplianceControllerDeploymentContainer) DeepCopyInto(out *ComplianceControllerDeploymentContainer) {
+func (in *CloudConnectorProxy) DeepCopyInto(out *CloudConnectorProxy) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceControllerDeploymentContainer.
-func (in *ComplianceControllerDeploymentContainer) DeepCopy() *ComplianceControllerDeploymentContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudConnectorProxy.
+func (in *CloudConnectorProxy) DeepCopy() *CloudConnectorProxy {
 	if in == nil {
 		return nil
 	}
-	out := new(ComplianceControllerDeploymentContainer)
+	out := new(CloudConnectorProxy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComplianceControllerDeploymentInitContainer) DeepCopyInto(out *ComplianceControllerDeploymentInitContainer) {
+func (in *CloudConnectorSpec) DeepCopyInto(out *CloudConnectorSpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(CloudConnectorProxy)
+		**out = **in
+	}
+	if in.CloudConnectorDeployment != nil {
+		in, out := &in.CloudConnectorDeployment, &out.CloudConnectorDeployment
+		*out = new(CloudConnectorDeployment)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceControllerDeploymentInitContainer.
-func (in *ComplianceControllerDeploymentInitContainer) DeepCopy() *ComplianceControllerDeploymentInitContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudConnectorSpec.
+func (in *CloudConnectorSpec) DeepCopy() *CloudConnectorSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ComplianceControllerDeploymentInitContainer)
+	out := new(CloudConnectorSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComplianceControllerDeploymentPodSpec) DeepCopyInto(out *ComplianceControllerDeploymentPodSpec) {
+func (in *CloudConnectorStatus) DeepCopyInto(out *CloudConnectorStatus) {
 	*out = *in
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]ComplianceControllerDeploymentInitContainer, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]ComplianceControllerDeploymentContainer, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceControllerDeploymentPodSpec.
-func (in *ComplianceControllerDeploymentPodSpec) DeepCopy() *ComplianceControllerDeploymentPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudConnectorStatus.
+func (in *CloudConnectorStatus) DeepCopy() *CloudConnectorStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ComplianceControllerDeploymentPodSpec)
+	out := new(CloudConnectorStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComplianceControllerDeploymentPodTemplateSpec) DeepCopyInto(out *ComplianceControllerDeploymentPodTemplateSpec) {
+func (in *CloudNetworkSet) DeepCopyInto(out *CloudNetworkSet) {
 	*out = *in
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(ComplianceControllerDeploymentPodSpec)
-		(*in).DeepCopyInto(*out)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceControllerDeploymentPodTemplateSpec.
-func (in *ComplianceControllerDeploymentPodTemplateSpec) DeepCopy() *ComplianceControllerDeploymentPodTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudNetworkSet.
+func (in *CloudNetworkSet) DeepCopy() *CloudNetworkSet {
 	if in == nil {
 		return nil
 	}
-	out := new(ComplianceControllerDeploymentPodTemplateSpec)
+	out := new(CloudNetworkSet)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComplianceControllerDeploymentSpec) DeepCopyInto(out *ComplianceControllerDeploymentSpec) {
-	*out = *in
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(ComplianceControllerDeploymentPodTemplateSpec)
-		(*in).DeepCopyInto(*out)
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceControllerDeploymentSpec.
-func (in *ComplianceControllerDeploymentSpec) DeepCopy() *ComplianceControllerDeploymentSpec {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudNetworkSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(ComplianceControllerDeploymentSpec)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComplianceList) DeepCopyInto(out *ComplianceList) {
+func (in *CloudNetworkSetList) DeepCopyInto(out *CloudNetworkSetList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Compliance, len(*in))
+		*out = make([]CloudNetworkSet, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceList.
-func (in *ComplianceList) DeepCopy() *ComplianceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudNetworkSetList.
+func (in *CloudNetworkSetList) DeepCopy() *CloudNetworkSetList {
 	if in == nil {
 		return nil
 	}
-	out := new(ComplianceList)
+	out := new(CloudNetworkSetList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ComplianceList) DeepCopyObject() runtime.Object {
+func (in *CloudNetworkSetList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1964,14 +2078,510 @@ func (in *ComplianceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComplianceReporterPodSpec) DeepCopyInto(out *ComplianceReporterPodSpec) {
+func (in *CloudNetworkSetSpec) DeepCopyInto(out *CloudNetworkSetSpec) {
 	*out = *in
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]ComplianceReporterPodTemplateInitContainer, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudNetworkSetSpec.
+func (in *CloudNetworkSetSpec) DeepCopy() *CloudNetworkSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudNetworkSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudNetworkSetStatus) DeepCopyInto(out *CloudNetworkSetStatus) {
+	*out = *in
+	if in.LastRefreshTime != nil {
+		in, out := &in.LastRefreshTime, &out.LastRefreshTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudNetworkSetStatus.
+func (in *CloudNetworkSetStatus) DeepCopy() *CloudNetworkSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudNetworkSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommonPrometheusFields) DeepCopyInto(out *CommonPrometheusFields) {
+	*out = *in
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]PrometheusContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonPrometheusFields.
+func (in *CommonPrometheusFields) DeepCopy() *CommonPrometheusFields {
+	if in == nil {
+		return nil
+	}
+	out := new(CommonPrometheusFields)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Compliance) DeepCopyInto(out *Compliance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Compliance.
+func (in *Compliance) DeepCopy() *Compliance {
+	if in == nil {
+		return nil
+	}
+	out := new(Compliance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Compliance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceBenchmarkerDaemonSet) DeepCopyInto(out *ComplianceBenchmarkerDaemonSet) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(ComplianceBenchmarkerDaemonSetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceBenchmarkerDaemonSet.
+func (in *ComplianceBenchmarkerDaemonSet) DeepCopy() *ComplianceBenchmarkerDaemonSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceBenchmarkerDaemonSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceBenchmarkerDaemonSetContainer) DeepCopyInto(out *ComplianceBenchmarkerDaemonSetContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceBenchmarkerDaemonSetContainer.
+func (in *ComplianceBenchmarkerDaemonSetContainer) DeepCopy() *ComplianceBenchmarkerDaemonSetContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceBenchmarkerDaemonSetContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceBenchmarkerDaemonSetInitContainer) DeepCopyInto(out *ComplianceBenchmarkerDaemonSetInitContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceBenchmarkerDaemonSetInitContainer.
+func (in *ComplianceBenchmarkerDaemonSetInitContainer) DeepCopy() *ComplianceBenchmarkerDaemonSetInitContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceBenchmarkerDaemonSetInitContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceBenchmarkerDaemonSetPodSpec) DeepCopyInto(out *ComplianceBenchmarkerDaemonSetPodSpec) {
+	*out = *in
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]ComplianceBenchmarkerDaemonSetInitContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ComplianceBenchmarkerDaemonSetContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceBenchmarkerDaemonSetPodSpec.
+func (in *ComplianceBenchmarkerDaemonSetPodSpec) DeepCopy() *ComplianceBenchmarkerDaemonSetPodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceBenchmarkerDaemonSetPodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceBenchmarkerDaemonSetPodTemplateSpec) DeepCopyInto(out *ComplianceBenchmarkerDaemonSetPodTemplateSpec) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(ComplianceBenchmarkerDaemonSetPodSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceBenchmarkerDaemonSetPodTemplateSpec.
+func (in *ComplianceBenchmarkerDaemonSetPodTemplateSpec) DeepCopy() *ComplianceBenchmarkerDaemonSetPodTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceBenchmarkerDaemonSetPodTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceBenchmarkerDaemonSetSpec) DeepCopyInto(out *ComplianceBenchmarkerDaemonSetSpec) {
+	*out = *in
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(ComplianceBenchmarkerDaemonSetPodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceBenchmarkerDaemonSetSpec.
+func (in *ComplianceBenchmarkerDaemonSetSpec) DeepCopy() *ComplianceBenchmarkerDaemonSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceBenchmarkerDaemonSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceControllerDeployment) DeepCopyInto(out *ComplianceControllerDeployment) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(ComplianceControllerDeploymentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceControllerDeployment.
+func (in *ComplianceControllerDeployment) DeepCopy() *ComplianceControllerDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceControllerDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceControllerDeploymentContainer) DeepCopyInto(out *ComplianceControllerDeploymentContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceControllerDeploymentContainer.
+func (in *ComplianceControllerDeploymentContainer) DeepCopy() *ComplianceControllerDeploymentContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceControllerDeploymentContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceControllerDeploymentInitContainer) DeepCopyInto(out *ComplianceControllerDeploymentInitContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceControllerDeploymentInitContainer.
+func (in *ComplianceControllerDeploymentInitContainer) DeepCopy() *ComplianceControllerDeploymentInitContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceControllerDeploymentInitContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceControllerDeploymentPodSpec) DeepCopyInto(out *ComplianceControllerDeploymentPodSpec) {
+	*out = *in
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]ComplianceControllerDeploymentInitContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ComplianceControllerDeploymentContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceControllerDeploymentPodSpec.
+func (in *ComplianceControllerDeploymentPodSpec) DeepCopy() *ComplianceControllerDeploymentPodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceControllerDeploymentPodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceControllerDeploymentPodTemplateSpec) DeepCopyInto(out *ComplianceControllerDeploymentPodTemplateSpec) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(ComplianceControllerDeploymentPodSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceControllerDeploymentPodTemplateSpec.
+func (in *ComplianceControllerDeploymentPodTemplateSpec) DeepCopy() *ComplianceControllerDeploymentPodTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceControllerDeploymentPodTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceControllerDeploymentSpec) DeepCopyInto(out *ComplianceControllerDeploymentSpec) {
+	*out = *in
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(ComplianceControllerDeploymentPodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceControllerDeploymentSpec.
+func (in *ComplianceControllerDeploymentSpec) DeepCopy() *ComplianceControllerDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceControllerDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceList) DeepCopyInto(out *ComplianceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Compliance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceList.
+func (in *ComplianceList) DeepCopy() *ComplianceList {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComplianceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceLogging) DeepCopyInto(out *ComplianceLogging) {
+	*out = *in
+	if in.LogSeverity != nil {
+		in, out := &in.LogSeverity, &out.LogSeverity
+		*out = new(LogLevel)
+		**out = **in
+	}
+	if in.Controller != nil {
+		in, out := &in.Controller, &out.Controller
+		*out = new(LogLevel)
+		**out = **in
+	}
+	if in.Snapshotter != nil {
+		in, out := &in.Snapshotter, &out.Snapshotter
+		*out = new(LogLevel)
+		**out = **in
+	}
+	if in.Benchmarker != nil {
+		in, out := &in.Benchmarker, &out.Benchmarker
+		*out = new(LogLevel)
+		**out = **in
+	}
+	if in.Server != nil {
+		in, out := &in.Server, &out.Server
+		*out = new(LogLevel)
+		**out = **in
+	}
+	if in.Reporter != nil {
+		in, out := &in.Reporter, &out.Reporter
+		*out = new(LogLevel)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceLogging.
+func (in *ComplianceLogging) DeepCopy() *ComplianceLogging {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceLogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceReporterPodSpec) DeepCopyInto(out *ComplianceReporterPodSpec) {
+	*out = *in
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]ComplianceReporterPodTemplateInitContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Containers != nil {
 		in, out := &in.Containers, &out.Containers
@@ -1980,6 +2590,11 @@ func (in *ComplianceReporterPodSpec) DeepCopyInto(out *ComplianceReporterPodSpec
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceReporterPodSpec.
@@ -2040,6 +2655,18 @@ func (in *ComplianceReporterPodTemplateInitContainer) DeepCopyInto(out *Complian
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceReporterPodTemplateInitContainer.
@@ -2072,6 +2699,26 @@ func (in *ComplianceReporterPodTemplateSpec) DeepCopy() *ComplianceReporterPodTe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceServerCertificate) DeepCopyInto(out *ComplianceServerCertificate) {
+	*out = *in
+	if in.ExtraDNSNames != nil {
+		in, out := &in.ExtraDNSNames, &out.ExtraDNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceServerCertificate.
+func (in *ComplianceServerCertificate) DeepCopy() *ComplianceServerCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceServerCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComplianceServerDeployment) DeepCopyInto(out *ComplianceServerDeployment) {
 	*out = *in
@@ -2100,6 +2747,21 @@ func (in *ComplianceServerDeploymentContainer) DeepCopyInto(out *ComplianceServe
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ProbeTimings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ProbeTimings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ProbeTimings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceServerDeploymentContainer.
@@ -2120,6 +2782,18 @@ func (in *ComplianceServerDeploymentInitContainer) DeepCopyInto(out *ComplianceS
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceServerDeploymentInitContainer.
@@ -2149,6 +2823,32 @@ func (in *ComplianceServerDeploymentPodSpec) DeepCopyInto(out *ComplianceServerD
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceServerDeploymentPodSpec.
@@ -2201,6 +2901,36 @@ func (in *ComplianceServerDeploymentSpec) DeepCopy() *ComplianceServerDeployment
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceServerQueryLimits) DeepCopyInto(out *ComplianceServerQueryLimits) {
+	*out = *in
+	if in.PageSize != nil {
+		in, out := &in.PageSize, &out.PageSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QueryTimeout != nil {
+		in, out := &in.QueryTimeout, &out.QueryTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxConcurrentDownloads != nil {
+		in, out := &in.MaxConcurrentDownloads, &out.MaxConcurrentDownloads
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceServerQueryLimits.
+func (in *ComplianceServerQueryLimits) DeepCopy() *ComplianceServerQueryLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceServerQueryLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComplianceSnapshotterDeployment) DeepCopyInto(out *ComplianceSnapshotterDeployment) {
 	*out = *in
@@ -2249,6 +2979,18 @@ func (in *ComplianceSnapshotterDeploymentInitContainer) DeepCopyInto(out *Compli
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceSnapshotterDeploymentInitContainer.
@@ -2278,6 +3020,25 @@ func (in *ComplianceSnapshotterDeploymentPodSpec) DeepCopyInto(out *ComplianceSn
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceSnapshotterDeploymentPodSpec.
@@ -2358,6 +3119,56 @@ func (in *ComplianceSpec) DeepCopyInto(out *ComplianceSpec) {
 		*out = new(ComplianceReporterPodTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ComplianceServerService != nil {
+		in, out := &in.ComplianceServerService, &out.ComplianceServerService
+		*out = new(ServiceOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ComplianceServerCertificate != nil {
+		in, out := &in.ComplianceServerCertificate, &out.ComplianceServerCertificate
+		*out = new(ComplianceServerCertificate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SIEMExport != nil {
+		in, out := &in.SIEMExport, &out.SIEMExport
+		*out = new(SIEMExport)
+		**out = **in
+	}
+	if in.ReportSigning != nil {
+		in, out := &in.ReportSigning, &out.ReportSigning
+		*out = new(ReportSigning)
+		**out = **in
+	}
+	if in.SnapshotHour != nil {
+		in, out := &in.SnapshotHour, &out.SnapshotHour
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CISBenchmark != nil {
+		in, out := &in.CISBenchmark, &out.CISBenchmark
+		*out = new(CISBenchmark)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SnapshotScope != nil {
+		in, out := &in.SnapshotScope, &out.SnapshotScope
+		*out = new(SnapshotScope)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ComplianceServerQueryLimits != nil {
+		in, out := &in.ComplianceServerQueryLimits, &out.ComplianceServerQueryLimits
+		*out = new(ComplianceServerQueryLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReportRetention != nil {
+		in, out := &in.ReportRetention, &out.ReportRetention
+		*out = new(ReportRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(ComplianceLogging)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceSpec.
@@ -2380,6 +3191,11 @@ func (in *ComplianceStatus) DeepCopyInto(out *ComplianceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceStatus.
@@ -2412,6 +3228,41 @@ func (in *ComponentResource) DeepCopy() *ComponentResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentVersion) DeepCopyInto(out *ComponentVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentVersion.
+func (in *ComponentVersion) DeepCopy() *ComponentVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoordinatedNodeRollout) DeepCopyInto(out *CoordinatedNodeRollout) {
+	*out = *in
+	if in.ReadinessConditions != nil {
+		in, out := &in.ReadinessConditions, &out.ReadinessConditions
+		*out = make([]corev1.NodeConditionType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoordinatedNodeRollout.
+func (in *CoordinatedNodeRollout) DeepCopy() *CoordinatedNodeRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(CoordinatedNodeRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DashboardsJob) DeepCopyInto(out *DashboardsJob) {
 	*out = *in
@@ -2462,6 +3313,11 @@ func (in *DashboardsJobPodSpec) DeepCopyInto(out *DashboardsJobPodSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardsJobPodSpec.
@@ -2562,6 +3418,18 @@ func (in *DexDeploymentInitContainer) DeepCopyInto(out *DexDeploymentInitContain
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DexDeploymentInitContainer.
@@ -2591,6 +3459,18 @@ func (in *DexDeploymentPodSpec) DeepCopyInto(out *DexDeploymentPodSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DexDeploymentPodSpec.
@@ -2613,32 +3493,67 @@ func (in *DexDeploymentPodTemplateSpec) DeepCopyInto(out *DexDeploymentPodTempla
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DexDeploymentPodTemplateSpec.
-func (in *DexDeploymentPodTemplateSpec) DeepCopy() *DexDeploymentPodTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DexDeploymentPodTemplateSpec.
+func (in *DexDeploymentPodTemplateSpec) DeepCopy() *DexDeploymentPodTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DexDeploymentPodTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DexDeploymentSpec) DeepCopyInto(out *DexDeploymentSpec) {
+	*out = *in
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(DexDeploymentPodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DexDeploymentSpec.
+func (in *DexDeploymentSpec) DeepCopy() *DexDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DexDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiagnosticsCollection) DeepCopyInto(out *DiagnosticsCollection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiagnosticsCollection.
+func (in *DiagnosticsCollection) DeepCopy() *DiagnosticsCollection {
 	if in == nil {
 		return nil
 	}
-	out := new(DexDeploymentPodTemplateSpec)
+	out := new(DiagnosticsCollection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DexDeploymentSpec) DeepCopyInto(out *DexDeploymentSpec) {
+func (in *DiagnosticsSpec) DeepCopyInto(out *DiagnosticsSpec) {
 	*out = *in
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(DexDeploymentPodTemplateSpec)
-		(*in).DeepCopyInto(*out)
+	if in.CollectOnCrashLoop != nil {
+		in, out := &in.CollectOnCrashLoop, &out.CollectOnCrashLoop
+		*out = new(CollectOnCrashLoopType)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DexDeploymentSpec.
-func (in *DexDeploymentSpec) DeepCopy() *DexDeploymentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiagnosticsSpec.
+func (in *DiagnosticsSpec) DeepCopy() *DiagnosticsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DexDeploymentSpec)
+	out := new(DiagnosticsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2691,6 +3606,18 @@ func (in *ECKOperatorStatefulSetInitContainer) DeepCopyInto(out *ECKOperatorStat
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ECKOperatorStatefulSetInitContainer.
@@ -2720,6 +3647,11 @@ func (in *ECKOperatorStatefulSetPodSpec) DeepCopyInto(out *ECKOperatorStatefulSe
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ECKOperatorStatefulSetPodSpec.
@@ -2800,6 +3732,18 @@ func (in *EGWDeploymentInitContainer) DeepCopyInto(out *EGWDeploymentInitContain
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EGWDeploymentInitContainer.
@@ -2860,6 +3804,18 @@ func (in *EKSLogForwarderDeploymentInitContainer) DeepCopyInto(out *EKSLogForwar
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EKSLogForwarderDeploymentInitContainer.
@@ -2889,6 +3845,11 @@ func (in *EKSLogForwarderDeploymentPodSpec) DeepCopyInto(out *EKSLogForwarderDep
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EKSLogForwarderDeploymentPodSpec.
@@ -2941,6 +3902,172 @@ func (in *EKSLogForwarderDeploymentSpec) DeepCopy() *EKSLogForwarderDeploymentSp
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EKSNetworkingStatus) DeepCopyInto(out *EKSNetworkingStatus) {
+	*out = *in
+	if in.Decisions != nil {
+		in, out := &in.Decisions, &out.Decisions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EKSNetworkingStatus.
+func (in *EKSNetworkingStatus) DeepCopy() *EKSNetworkingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EKSNetworkingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESGatewayDeployment) DeepCopyInto(out *ESGatewayDeployment) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(ESGatewayDeploymentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESGatewayDeployment.
+func (in *ESGatewayDeployment) DeepCopy() *ESGatewayDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(ESGatewayDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESGatewayDeploymentContainer) DeepCopyInto(out *ESGatewayDeploymentContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESGatewayDeploymentContainer.
+func (in *ESGatewayDeploymentContainer) DeepCopy() *ESGatewayDeploymentContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(ESGatewayDeploymentContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESGatewayDeploymentInitContainer) DeepCopyInto(out *ESGatewayDeploymentInitContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESGatewayDeploymentInitContainer.
+func (in *ESGatewayDeploymentInitContainer) DeepCopy() *ESGatewayDeploymentInitContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(ESGatewayDeploymentInitContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESGatewayDeploymentPodSpec) DeepCopyInto(out *ESGatewayDeploymentPodSpec) {
+	*out = *in
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]ESGatewayDeploymentInitContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ESGatewayDeploymentContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESGatewayDeploymentPodSpec.
+func (in *ESGatewayDeploymentPodSpec) DeepCopy() *ESGatewayDeploymentPodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ESGatewayDeploymentPodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESGatewayDeploymentPodTemplateSpec) DeepCopyInto(out *ESGatewayDeploymentPodTemplateSpec) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(ESGatewayDeploymentPodSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESGatewayDeploymentPodTemplateSpec.
+func (in *ESGatewayDeploymentPodTemplateSpec) DeepCopy() *ESGatewayDeploymentPodTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ESGatewayDeploymentPodTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESGatewayDeploymentSpec) DeepCopyInto(out *ESGatewayDeploymentSpec) {
+	*out = *in
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(ESGatewayDeploymentPodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESGatewayDeploymentSpec.
+func (in *ESGatewayDeploymentSpec) DeepCopy() *ESGatewayDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ESGatewayDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EgressGateway) DeepCopyInto(out *EgressGateway) {
 	*out = *in
@@ -3016,6 +4143,11 @@ func (in *EgressGatewayDeploymentPodSpec) DeepCopyInto(out *EgressGatewayDeploym
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressGatewayDeploymentPodSpec.
@@ -3294,6 +4426,18 @@ func (in *ElasticsearchMetricsDeploymentInitContainer) DeepCopyInto(out *Elastic
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMetricsDeploymentInitContainer.
@@ -3323,6 +4467,11 @@ func (in *ElasticsearchMetricsDeploymentPodSpec) DeepCopyInto(out *Elasticsearch
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMetricsDeploymentPodSpec.
@@ -3375,6 +4524,31 @@ func (in *ElasticsearchMetricsDeploymentSpec) DeepCopy() *ElasticsearchMetricsDe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncapsulationMigrationStatus) DeepCopyInto(out *EncapsulationMigrationStatus) {
+	*out = *in
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TransitionalFelixSettings != nil {
+		in, out := &in.TransitionalFelixSettings, &out.TransitionalFelixSettings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncapsulationMigrationStatus.
+func (in *EncapsulationMigrationStatus) DeepCopy() *EncapsulationMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EncapsulationMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Endpoint) DeepCopyInto(out *Endpoint) {
 	*out = *in
@@ -3411,60 +4585,167 @@ func (in *Endpoint) DeepCopyInto(out *Endpoint) {
 			}
 		}
 	}
-	if in.RelabelConfigs != nil {
-		in, out := &in.RelabelConfigs, &out.RelabelConfigs
-		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+	if in.RelabelConfigs != nil {
+		in, out := &in.RelabelConfigs, &out.RelabelConfigs
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
+func (in *Endpoint) DeepCopy() *Endpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(Endpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoySettings) DeepCopyInto(out *EnvoySettings) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoySettings.
+func (in *EnvoySettings) DeepCopy() *EnvoySettings {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoySettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalPrometheus) DeepCopyInto(out *ExternalPrometheus) {
+	*out = *in
+	if in.ServiceMonitor != nil {
+		in, out := &in.ServiceMonitor, &out.ServiceMonitor
+		*out = new(ServiceMonitor)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalPrometheus.
+func (in *ExternalPrometheus) DeepCopy() *ExternalPrometheus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalPrometheus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FelixConfigurationOverlay) DeepCopyInto(out *FelixConfigurationOverlay) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FelixConfigurationOverlay.
+func (in *FelixConfigurationOverlay) DeepCopy() *FelixConfigurationOverlay {
+	if in == nil {
+		return nil
+	}
+	out := new(FelixConfigurationOverlay)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FelixConfigurationOverlay) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FelixConfigurationOverlayList) DeepCopyInto(out *FelixConfigurationOverlayList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FelixConfigurationOverlay, len(*in))
 		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(monitoringv1.RelabelConfig)
-				(*in).DeepCopyInto(*out)
-			}
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
-func (in *Endpoint) DeepCopy() *Endpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FelixConfigurationOverlayList.
+func (in *FelixConfigurationOverlayList) DeepCopy() *FelixConfigurationOverlayList {
 	if in == nil {
 		return nil
 	}
-	out := new(Endpoint)
+	out := new(FelixConfigurationOverlayList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FelixConfigurationOverlayList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EnvoySettings) DeepCopyInto(out *EnvoySettings) {
+func (in *FelixConfigurationOverlaySpec) DeepCopyInto(out *FelixConfigurationOverlaySpec) {
 	*out = *in
+	in.NodeSelector.DeepCopyInto(&out.NodeSelector)
+	in.FelixConfiguration.DeepCopyInto(&out.FelixConfiguration)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoySettings.
-func (in *EnvoySettings) DeepCopy() *EnvoySettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FelixConfigurationOverlaySpec.
+func (in *FelixConfigurationOverlaySpec) DeepCopy() *FelixConfigurationOverlaySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(EnvoySettings)
+	out := new(FelixConfigurationOverlaySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalPrometheus) DeepCopyInto(out *ExternalPrometheus) {
+func (in *FelixConfigurationOverlayStatus) DeepCopyInto(out *FelixConfigurationOverlayStatus) {
 	*out = *in
-	if in.ServiceMonitor != nil {
-		in, out := &in.ServiceMonitor, &out.ServiceMonitor
-		*out = new(ServiceMonitor)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FelixConfigurationOverlayStatus.
+func (in *FelixConfigurationOverlayStatus) DeepCopy() *FelixConfigurationOverlayStatus {
+	if in == nil {
+		return nil
 	}
+	out := new(FelixConfigurationOverlayStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalPrometheus.
-func (in *ExternalPrometheus) DeepCopy() *ExternalPrometheus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FelixProfile) DeepCopyInto(out *FelixProfile) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FelixProfile.
+func (in *FelixProfile) DeepCopy() *FelixProfile {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalPrometheus)
+	out := new(FelixProfile)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3517,6 +4798,18 @@ func (in *FluentdDaemonSetInitContainer) DeepCopyInto(out *FluentdDaemonSetInitC
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdDaemonSetInitContainer.
@@ -3546,6 +4839,11 @@ func (in *FluentdDaemonSetPodSpec) DeepCopyInto(out *FluentdDaemonSetPodSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdDaemonSetPodSpec.
@@ -3666,6 +4964,18 @@ func (in *GuardianDeploymentInitContainer) DeepCopyInto(out *GuardianDeploymentI
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardianDeploymentInitContainer.
@@ -3695,6 +5005,16 @@ func (in *GuardianDeploymentPodSpec) DeepCopyInto(out *GuardianDeploymentPodSpec
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardianDeploymentPodSpec.
@@ -4076,6 +5396,11 @@ func (in *InstallationSpec) DeepCopyInto(out *InstallationSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ControlPlaneTopology != nil {
+		in, out := &in.ControlPlaneTopology, &out.ControlPlaneTopology
+		*out = new(ControlPlaneTopology)
+		**out = **in
+	}
 	if in.ControlPlaneReplicas != nil {
 		in, out := &in.ControlPlaneReplicas, &out.ControlPlaneReplicas
 		*out = new(int32)
@@ -4091,7 +5416,42 @@ func (in *InstallationSpec) DeepCopyInto(out *InstallationSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.TyphaPort != nil {
+		in, out := &in.TyphaPort, &out.TyphaPort
+		*out = new(int32)
+		**out = **in
+	}
 	in.NodeUpdateStrategy.DeepCopyInto(&out.NodeUpdateStrategy)
+	if in.CoordinatedNodeRollout != nil {
+		in, out := &in.CoordinatedNodeRollout, &out.CoordinatedNodeRollout
+		*out = new(CoordinatedNodeRollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NFTablesModeRollout != nil {
+		in, out := &in.NFTablesModeRollout, &out.NFTablesModeRollout
+		*out = new(NFTablesModeRollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Preflight != nil {
+		in, out := &in.Preflight, &out.Preflight
+		*out = new(PreflightSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Diagnostics != nil {
+		in, out := &in.Diagnostics, &out.Diagnostics
+		*out = new(DiagnosticsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkloadProvenance != nil {
+		in, out := &in.WorkloadProvenance, &out.WorkloadProvenance
+		*out = new(WorkloadProvenanceType)
+		**out = **in
+	}
+	if in.ApplyValidation != nil {
+		in, out := &in.ApplyValidation, &out.ApplyValidation
+		*out = new(ApplyValidationType)
+		**out = **in
+	}
 	if in.ComponentResources != nil {
 		in, out := &in.ComponentResources, &out.ComponentResources
 		*out = make([]ComponentResource, len(*in))
@@ -4104,6 +5464,11 @@ func (in *InstallationSpec) DeepCopyInto(out *InstallationSpec) {
 		*out = new(CertificateManagement)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CertificateKeyAlgorithm != nil {
+		in, out := &in.CertificateKeyAlgorithm, &out.CertificateKeyAlgorithm
+		*out = new(CertificateKeyAlgorithm)
+		**out = **in
+	}
 	if in.NonPrivileged != nil {
 		in, out := &in.NonPrivileged, &out.NonPrivileged
 		*out = new(NonPrivilegedType)
@@ -4159,6 +5524,30 @@ func (in *InstallationSpec) DeepCopyInto(out *InstallationSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Telemetry != nil {
+		in, out := &in.Telemetry, &out.Telemetry
+		*out = new(Telemetry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeHooks != nil {
+		in, out := &in.UpgradeHooks, &out.UpgradeHooks
+		*out = make([]UpgradeHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.FelixProfiles != nil {
+		in, out := &in.FelixProfiles, &out.FelixProfiles
+		*out = make([]FelixProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallationSpec.
@@ -4179,6 +5568,56 @@ func (in *InstallationStatus) DeepCopyInto(out *InstallationStatus) {
 		*out = new(InstallationSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EKSNetworking != nil {
+		in, out := &in.EKSNetworking, &out.EKSNetworking
+		*out = new(EKSNetworkingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RolledBack != nil {
+		in, out := &in.RolledBack, &out.RolledBack
+		*out = make([]RolledBackComponent, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeferredRollouts != nil {
+		in, out := &in.DeferredRollouts, &out.DeferredRollouts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeRollout != nil {
+		in, out := &in.NodeRollout, &out.NodeRollout
+		*out = new(NodeRolloutStatus)
+		**out = **in
+	}
+	if in.NFTablesModeRollout != nil {
+		in, out := &in.NFTablesModeRollout, &out.NFTablesModeRollout
+		*out = new(NFTablesModeRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EncapsulationMigration != nil {
+		in, out := &in.EncapsulationMigration, &out.EncapsulationMigration
+		*out = new(EncapsulationMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Preflight != nil {
+		in, out := &in.Preflight, &out.Preflight
+		*out = new(PreflightStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Diagnostics != nil {
+		in, out := &in.Diagnostics, &out.Diagnostics
+		*out = make([]DiagnosticsCollection, len(*in))
+		copy(*out, *in)
+	}
+	if in.TyphaHealth != nil {
+		in, out := &in.TyphaHealth, &out.TyphaHealth
+		*out = new(TyphaHealthStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -4273,6 +5712,11 @@ func (in *IntrusionDetectionControllerDeploymentContainer) DeepCopyInto(out *Int
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ProbeTimings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionControllerDeploymentContainer.
@@ -4293,6 +5737,18 @@ func (in *IntrusionDetectionControllerDeploymentInitContainer) DeepCopyInto(out
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionControllerDeploymentInitContainer.
@@ -4322,6 +5778,11 @@ func (in *IntrusionDetectionControllerDeploymentPodSpec) DeepCopyInto(out *Intru
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionControllerDeploymentPodSpec.
@@ -4422,6 +5883,21 @@ func (in *IntrusionDetectionSpec) DeepCopyInto(out *IntrusionDetectionSpec) {
 		*out = new(IntrusionDetectionControllerDeployment)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ThreatFeedMirror != nil {
+		in, out := &in.ThreatFeedMirror, &out.ThreatFeedMirror
+		*out = new(ThreatFeedMirror)
+		**out = **in
+	}
+	if in.ThreatFeedExport != nil {
+		in, out := &in.ThreatFeedExport, &out.ThreatFeedExport
+		*out = new(ThreatFeedExportType)
+		**out = **in
+	}
+	if in.SIEMExport != nil {
+		in, out := &in.SIEMExport, &out.SIEMExport
+		*out = new(SIEMExport)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionSpec.
@@ -4444,6 +5920,11 @@ func (in *IntrusionDetectionStatus) DeepCopyInto(out *IntrusionDetectionStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntrusionDetectionStatus.
@@ -4504,6 +5985,18 @@ func (in *KibanaInitContainer) DeepCopyInto(out *KibanaInitContainer) {
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaInitContainer.
@@ -4533,6 +6026,11 @@ func (in *KibanaPodSpec) DeepCopyInto(out *KibanaPodSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaPodSpec.
@@ -4633,6 +6131,18 @@ func (in *L7LogCollectorDaemonSetInitContainer) DeepCopyInto(out *L7LogCollector
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L7LogCollectorDaemonSetInitContainer.
@@ -4662,6 +6172,11 @@ func (in *L7LogCollectorDaemonSetPodSpec) DeepCopyInto(out *L7LogCollectorDaemon
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L7LogCollectorDaemonSetPodSpec.
@@ -4762,6 +6277,18 @@ func (in *LinseedDeploymentInitContainer) DeepCopyInto(out *LinseedDeploymentIni
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinseedDeploymentInitContainer.
@@ -4791,6 +6318,18 @@ func (in *LinseedDeploymentPodSpec) DeepCopyInto(out *LinseedDeploymentPodSpec)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinseedDeploymentPodSpec.
@@ -4982,6 +6521,11 @@ func (in *LogCollectorStatus) DeepCopyInto(out *LogCollectorStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollectorStatus.
@@ -5125,6 +6669,16 @@ func (in *LogStorageSpec) DeepCopyInto(out *LogStorageSpec) {
 		*out = new(ElasticsearchMetricsDeployment)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ESGatewayDeployment != nil {
+		in, out := &in.ESGatewayDeployment, &out.ESGatewayDeployment
+		*out = new(ESGatewayDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStorageSpec.
@@ -5179,6 +6733,22 @@ func (in *Logging) DeepCopy() *Logging {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagementCluster) DeepCopyInto(out *ManagementCluster) {
 	*out = *in
@@ -5267,6 +6837,11 @@ func (in *ManagementClusterConnectionList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagementClusterConnectionSpec) DeepCopyInto(out *ManagementClusterConnectionSpec) {
 	*out = *in
+	if in.FailoverManagementClusterAddrs != nil {
+		in, out := &in.FailoverManagementClusterAddrs, &out.FailoverManagementClusterAddrs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
 		*out = new(ManagementClusterTLS)
@@ -5351,6 +6926,11 @@ func (in *ManagementClusterSpec) DeepCopyInto(out *ManagementClusterSpec) {
 		*out = new(TLS)
 		**out = **in
 	}
+	if in.TunnelService != nil {
+		in, out := &in.TunnelService, &out.TunnelService
+		*out = new(TunnelServiceOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterSpec.
@@ -5408,6 +6988,11 @@ func (in *Manager) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagerDeployment) DeepCopyInto(out *ManagerDeployment) {
 	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(Metadata)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Spec != nil {
 		in, out := &in.Spec, &out.Spec
 		*out = new(ManagerDeploymentSpec)
@@ -5433,6 +7018,11 @@ func (in *ManagerDeploymentContainer) DeepCopyInto(out *ManagerDeploymentContain
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ProbeTimings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerDeploymentContainer.
@@ -5453,6 +7043,18 @@ func (in *ManagerDeploymentInitContainer) DeepCopyInto(out *ManagerDeploymentIni
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerDeploymentInitContainer.
@@ -5475,13 +7077,49 @@ func (in *ManagerDeploymentPodSpec) DeepCopyInto(out *ManagerDeploymentPodSpec)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]ManagerDeploymentContainer, len(*in))
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ManagerDeploymentContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerDeploymentPodSpec.
@@ -5497,6 +7135,11 @@ func (in *ManagerDeploymentPodSpec) DeepCopy() *ManagerDeploymentPodSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagerDeploymentPodTemplateSpec) DeepCopyInto(out *ManagerDeploymentPodTemplateSpec) {
 	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(Metadata)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Spec != nil {
 		in, out := &in.Spec, &out.Spec
 		*out = new(ManagerDeploymentPodSpec)
@@ -5534,6 +7177,33 @@ func (in *ManagerDeploymentSpec) DeepCopy() *ManagerDeploymentSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagerIngress) DeepCopyInto(out *ManagerIngress) {
+	*out = *in
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerIngress.
+func (in *ManagerIngress) DeepCopy() *ManagerIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagerIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagerList) DeepCopyInto(out *ManagerList) {
 	*out = *in
@@ -5574,6 +7244,16 @@ func (in *ManagerSpec) DeepCopyInto(out *ManagerSpec) {
 		*out = new(ManagerDeployment)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ManagerService != nil {
+		in, out := &in.ManagerService, &out.ManagerService
+		*out = new(ServiceOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(ManagerIngress)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerSpec.
@@ -5596,6 +7276,11 @@ func (in *ManagerStatus) DeepCopyInto(out *ManagerStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagerStatus.
@@ -5637,6 +7322,31 @@ func (in *Metadata) DeepCopy() *Metadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricRelabeling) DeepCopyInto(out *MetricRelabeling) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]MetricsTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceLabels != nil {
+		in, out := &in.SourceLabels, &out.SourceLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricRelabeling.
+func (in *MetricRelabeling) DeepCopy() *MetricRelabeling {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricRelabeling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Monitor) DeepCopyInto(out *Monitor) {
 	*out = *in
@@ -5696,6 +7406,33 @@ func (in *MonitorList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitorMetrics) DeepCopyInto(out *MonitorMetrics) {
+	*out = *in
+	if in.DefaultRelabelings != nil {
+		in, out := &in.DefaultRelabelings, &out.DefaultRelabelings
+		*out = new(DefaultRelabelingsType)
+		**out = **in
+	}
+	if in.Relabelings != nil {
+		in, out := &in.Relabelings, &out.Relabelings
+		*out = make([]MetricRelabeling, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorMetrics.
+func (in *MonitorMetrics) DeepCopy() *MonitorMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitorMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MonitorSpec) DeepCopyInto(out *MonitorSpec) {
 	*out = *in
@@ -5714,6 +7451,11 @@ func (in *MonitorSpec) DeepCopyInto(out *MonitorSpec) {
 		*out = new(AlertManager)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(MonitorMetrics)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorSpec.
@@ -5738,131 +7480,366 @@ func (in *MonitorStatus) DeepCopyInto(out *MonitorStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorStatus.
-func (in *MonitorStatus) DeepCopy() *MonitorStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorStatus.
+func (in *MonitorStatus) DeepCopy() *MonitorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultusCompatibility) DeepCopyInto(out *MultusCompatibility) {
+	*out = *in
+	if in.InterfaceExclude != nil {
+		in, out := &in.InterfaceExclude, &out.InterfaceExclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]WorkloadNetworkAttachment, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultusCompatibility.
+func (in *MultusCompatibility) DeepCopy() *MultusCompatibility {
+	if in == nil {
+		return nil
+	}
+	out := new(MultusCompatibility)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NFTablesModeRollout) DeepCopyInto(out *NFTablesModeRollout) {
+	*out = *in
+	if in.CanaryNodes != nil {
+		in, out := &in.CanaryNodes, &out.CanaryNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HealthCheckTimeout != nil {
+		in, out := &in.HealthCheckTimeout, &out.HealthCheckTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NFTablesModeRollout.
+func (in *NFTablesModeRollout) DeepCopy() *NFTablesModeRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(NFTablesModeRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NFTablesModeRolloutStatus) DeepCopyInto(out *NFTablesModeRolloutStatus) {
+	*out = *in
+	if in.CanaryNodes != nil {
+		in, out := &in.CanaryNodes, &out.CanaryNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NFTablesModeRolloutStatus.
+func (in *NFTablesModeRolloutStatus) DeepCopy() *NFTablesModeRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NFTablesModeRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeAddressAutodetection) DeepCopyInto(out *NodeAddressAutodetection) {
+	*out = *in
+	if in.FirstFound != nil {
+		in, out := &in.FirstFound, &out.FirstFound
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Kubernetes != nil {
+		in, out := &in.Kubernetes, &out.Kubernetes
+		*out = new(KubernetesAutodetectionMethod)
+		**out = **in
+	}
+	if in.CIDRS != nil {
+		in, out := &in.CIDRS, &out.CIDRS
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAddressAutodetection.
+func (in *NodeAddressAutodetection) DeepCopy() *NodeAddressAutodetection {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAddressAutodetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeAffinity) DeepCopyInto(out *NodeAffinity) {
+	*out = *in
+	if in.PreferredDuringSchedulingIgnoredDuringExecution != nil {
+		in, out := &in.PreferredDuringSchedulingIgnoredDuringExecution, &out.PreferredDuringSchedulingIgnoredDuringExecution
+		*out = make([]corev1.PreferredSchedulingTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		in, out := &in.RequiredDuringSchedulingIgnoredDuringExecution, &out.RequiredDuringSchedulingIgnoredDuringExecution
+		*out = new(corev1.NodeSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAffinity.
+func (in *NodeAffinity) DeepCopy() *NodeAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePreflightResult) DeepCopyInto(out *NodePreflightResult) {
+	*out = *in
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]PreflightFailure, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePreflightResult.
+func (in *NodePreflightResult) DeepCopy() *NodePreflightResult {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePreflightResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeRolloutStatus) DeepCopyInto(out *NodeRolloutStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRolloutStatus.
+func (in *NodeRolloutStatus) DeepCopy() *NodeRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSet) DeepCopyInto(out *NodeSet) {
+	*out = *in
+	if in.SelectionAttributes != nil {
+		in, out := &in.SelectionAttributes, &out.SelectionAttributes
+		*out = make([]NodeSetSelectionAttribute, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSet.
+func (in *NodeSet) DeepCopy() *NodeSet {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetSelectionAttribute) DeepCopyInto(out *NodeSetSelectionAttribute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetSelectionAttribute.
+func (in *NodeSetSelectionAttribute) DeepCopy() *NodeSetSelectionAttribute {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetSelectionAttribute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Nodes) DeepCopyInto(out *Nodes) {
+	*out = *in
+	if in.NodeSets != nil {
+		in, out := &in.NodeSets, &out.NodeSets
+		*out = make([]NodeSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceRequirements != nil {
+		in, out := &in.ResourceRequirements, &out.ResourceRequirements
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Nodes.
+func (in *Nodes) DeepCopy() *Nodes {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitorStatus)
+	out := new(Nodes)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeAddressAutodetection) DeepCopyInto(out *NodeAddressAutodetection) {
+func (in *OperatorConfig) DeepCopyInto(out *OperatorConfig) {
 	*out = *in
-	if in.FirstFound != nil {
-		in, out := &in.FirstFound, &out.FirstFound
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Kubernetes != nil {
-		in, out := &in.Kubernetes, &out.Kubernetes
-		*out = new(KubernetesAutodetectionMethod)
-		**out = **in
-	}
-	if in.CIDRS != nil {
-		in, out := &in.CIDRS, &out.CIDRS
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAddressAutodetection.
-func (in *NodeAddressAutodetection) DeepCopy() *NodeAddressAutodetection {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfig.
+func (in *OperatorConfig) DeepCopy() *OperatorConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeAddressAutodetection)
+	out := new(OperatorConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OperatorConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeAffinity) DeepCopyInto(out *NodeAffinity) {
+func (in *OperatorConfigList) DeepCopyInto(out *OperatorConfigList) {
 	*out = *in
-	if in.PreferredDuringSchedulingIgnoredDuringExecution != nil {
-		in, out := &in.PreferredDuringSchedulingIgnoredDuringExecution, &out.PreferredDuringSchedulingIgnoredDuringExecution
-		*out = make([]corev1.PreferredSchedulingTerm, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OperatorConfig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.RequiredDuringSchedulingIgnoredDuringExecution != nil {
-		in, out := &in.RequiredDuringSchedulingIgnoredDuringExecution, &out.RequiredDuringSchedulingIgnoredDuringExecution
-		*out = new(corev1.NodeSelector)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAffinity.
-func (in *NodeAffinity) DeepCopy() *NodeAffinity {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigList.
+func (in *OperatorConfigList) DeepCopy() *OperatorConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeAffinity)
+	out := new(OperatorConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OperatorConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSet) DeepCopyInto(out *NodeSet) {
+func (in *OperatorConfigSpec) DeepCopyInto(out *OperatorConfigSpec) {
 	*out = *in
-	if in.SelectionAttributes != nil {
-		in, out := &in.SelectionAttributes, &out.SelectionAttributes
-		*out = make([]NodeSetSelectionAttribute, len(*in))
-		copy(*out, *in)
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(OperatorTracing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = make([]OperatorControllerConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSet.
-func (in *NodeSet) DeepCopy() *NodeSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigSpec.
+func (in *OperatorConfigSpec) DeepCopy() *OperatorConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSet)
+	out := new(OperatorConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetSelectionAttribute) DeepCopyInto(out *NodeSetSelectionAttribute) {
+func (in *OperatorControllerConfig) DeepCopyInto(out *OperatorControllerConfig) {
 	*out = *in
+	if in.MaxConcurrentReconciles != nil {
+		in, out := &in.MaxConcurrentReconciles, &out.MaxConcurrentReconciles
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetSelectionAttribute.
-func (in *NodeSetSelectionAttribute) DeepCopy() *NodeSetSelectionAttribute {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorControllerConfig.
+func (in *OperatorControllerConfig) DeepCopy() *OperatorControllerConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSetSelectionAttribute)
+	out := new(OperatorControllerConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Nodes) DeepCopyInto(out *Nodes) {
+func (in *OperatorTracing) DeepCopyInto(out *OperatorTracing) {
 	*out = *in
-	if in.NodeSets != nil {
-		in, out := &in.NodeSets, &out.NodeSets
-		*out = make([]NodeSet, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.SamplingPercentage != nil {
+		in, out := &in.SamplingPercentage, &out.SamplingPercentage
+		*out = new(int32)
+		**out = **in
 	}
-	if in.ResourceRequirements != nil {
-		in, out := &in.ResourceRequirements, &out.ResourceRequirements
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = new(ComponentTracingType)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Nodes.
-func (in *Nodes) DeepCopy() *Nodes {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorTracing.
+func (in *OperatorTracing) DeepCopy() *OperatorTracing {
 	if in == nil {
 		return nil
 	}
-	out := new(Nodes)
+	out := new(OperatorTracing)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -5942,6 +7919,18 @@ func (in *PacketCaptureAPIDeploymentInitContainer) DeepCopyInto(out *PacketCaptu
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIDeploymentInitContainer.
@@ -5971,6 +7960,11 @@ func (in *PacketCaptureAPIDeploymentPodSpec) DeepCopyInto(out *PacketCaptureAPID
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCaptureAPIDeploymentPodSpec.
@@ -6122,13 +8116,28 @@ func (in *PathMatch) DeepCopy() *PathMatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodNetworkExpansion) DeepCopyInto(out *PodNetworkExpansion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodNetworkExpansion.
+func (in *PodNetworkExpansion) DeepCopy() *PodNetworkExpansion {
+	if in == nil {
+		return nil
+	}
+	out := new(PodNetworkExpansion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyRecommendation) DeepCopyInto(out *PolicyRecommendation) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendation.
@@ -6197,6 +8206,18 @@ func (in *PolicyRecommendationDeploymentInitContainer) DeepCopyInto(out *PolicyR
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationDeploymentInitContainer.
@@ -6226,6 +8247,11 @@ func (in *PolicyRecommendationDeploymentPodSpec) DeepCopyInto(out *PolicyRecomme
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationDeploymentPodSpec.
@@ -6333,6 +8359,13 @@ func (in *PolicyRecommendationSpec) DeepCopy() *PolicyRecommendationSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyRecommendationStatus) DeepCopyInto(out *PolicyRecommendationStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRecommendationStatus.
@@ -6345,6 +8378,107 @@ func (in *PolicyRecommendationStatus) DeepCopy() *PolicyRecommendationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreflightFailure) DeepCopyInto(out *PreflightFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreflightFailure.
+func (in *PreflightFailure) DeepCopy() *PreflightFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(PreflightFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreflightSpec) DeepCopyInto(out *PreflightSpec) {
+	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(PreflightMode)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreflightSpec.
+func (in *PreflightSpec) DeepCopy() *PreflightSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PreflightSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreflightStatus) DeepCopyInto(out *PreflightStatus) {
+	*out = *in
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodePreflightResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreflightStatus.
+func (in *PreflightStatus) DeepCopy() *PreflightStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PreflightStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeTimings) DeepCopyInto(out *ProbeTimings) {
+	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeTimings.
+func (in *ProbeTimings) DeepCopy() *ProbeTimings {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeTimings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Prometheus) DeepCopyInto(out *Prometheus) {
 	*out = *in
@@ -6375,32 +8509,87 @@ func (in *PrometheusContainer) DeepCopyInto(out *PrometheusContainer) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusContainer.
-func (in *PrometheusContainer) DeepCopy() *PrometheusContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusContainer.
+func (in *PrometheusContainer) DeepCopy() *PrometheusContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusSpec) DeepCopyInto(out *PrometheusSpec) {
+	*out = *in
+	if in.CommonPrometheusFields != nil {
+		in, out := &in.CommonPrometheusFields, &out.CommonPrometheusFields
+		*out = new(CommonPrometheusFields)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusSpec.
+func (in *PrometheusSpec) DeepCopy() *PrometheusSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportArchive) DeepCopyInto(out *ReportArchive) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportArchive.
+func (in *ReportArchive) DeepCopy() *ReportArchive {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportArchive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportRetention) DeepCopyInto(out *ReportRetention) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(ReportArchive)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportRetention.
+func (in *ReportRetention) DeepCopy() *ReportRetention {
 	if in == nil {
 		return nil
 	}
-	out := new(PrometheusContainer)
+	out := new(ReportRetention)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PrometheusSpec) DeepCopyInto(out *PrometheusSpec) {
+func (in *ReportSigning) DeepCopyInto(out *ReportSigning) {
 	*out = *in
-	if in.CommonPrometheusFields != nil {
-		in, out := &in.CommonPrometheusFields, &out.CommonPrometheusFields
-		*out = new(CommonPrometheusFields)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusSpec.
-func (in *PrometheusSpec) DeepCopy() *PrometheusSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportSigning.
+func (in *ReportSigning) DeepCopy() *ReportSigning {
 	if in == nil {
 		return nil
 	}
-	out := new(PrometheusSpec)
+	out := new(ReportSigning)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -6450,6 +8639,36 @@ func (in *Retention) DeepCopy() *Retention {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolledBackComponent) DeepCopyInto(out *RolledBackComponent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolledBackComponent.
+func (in *RolledBackComponent) DeepCopy() *RolledBackComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(RolledBackComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *S3StoreSpec) DeepCopyInto(out *S3StoreSpec) {
 	*out = *in
@@ -6465,6 +8684,37 @@ func (in *S3StoreSpec) DeepCopy() *S3StoreSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SIEMExport) DeepCopyInto(out *SIEMExport) {
+	*out = *in
+	out.ObjectStorage = in.ObjectStorage
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SIEMExport.
+func (in *SIEMExport) DeepCopy() *SIEMExport {
+	if in == nil {
+		return nil
+	}
+	out := new(SIEMExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SIEMExportObjectStorage) DeepCopyInto(out *SIEMExportObjectStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SIEMExportObjectStorage.
+func (in *SIEMExportObjectStorage) DeepCopy() *SIEMExportObjectStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(SIEMExportObjectStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SNIMatch) DeepCopyInto(out *SNIMatch) {
 	*out = *in
@@ -6509,6 +8759,76 @@ func (in *ServiceMonitor) DeepCopy() *ServiceMonitor {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceOptions) DeepCopyInto(out *ServiceOptions) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(corev1.ServiceType)
+		**out = **in
+	}
+	if in.SessionAffinity != nil {
+		in, out := &in.SessionAffinity, &out.SessionAffinity
+		*out = new(corev1.ServiceAffinity)
+		**out = **in
+	}
+	if in.ExternalTrafficPolicy != nil {
+		in, out := &in.ExternalTrafficPolicy, &out.ExternalTrafficPolicy
+		*out = new(corev1.ServiceExternalTrafficPolicy)
+		**out = **in
+	}
+	if in.Headless != nil {
+		in, out := &in.Headless, &out.Headless
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(Metadata)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceOptions.
+func (in *ServiceOptions) DeepCopy() *ServiceOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotScope) DeepCopyInto(out *SnapshotScope) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludeNamespaceSelector != nil {
+		in, out := &in.ExcludeNamespaceSelector, &out.ExcludeNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]SnapshotResource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotScope.
+func (in *SnapshotScope) DeepCopy() *SnapshotScope {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SplunkStoreSpec) DeepCopyInto(out *SplunkStoreSpec) {
 	*out = *in
@@ -6755,6 +9075,26 @@ func (in *TLSTerminatedRouteSpec) DeepCopy() *TLSTerminatedRouteSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Telemetry) DeepCopyInto(out *Telemetry) {
+	*out = *in
+	if in.Reporting != nil {
+		in, out := &in.Reporting, &out.Reporting
+		*out = new(TelemetryReporting)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Telemetry.
+func (in *Telemetry) DeepCopy() *Telemetry {
+	if in == nil {
+		return nil
+	}
+	out := new(Telemetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Tenant) DeepCopyInto(out *Tenant) {
 	*out = *in
@@ -6884,6 +9224,21 @@ func (in *TenantStatus) DeepCopy() *TenantStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThreatFeedMirror) DeepCopyInto(out *ThreatFeedMirror) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThreatFeedMirror.
+func (in *ThreatFeedMirror) DeepCopy() *ThreatFeedMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(ThreatFeedMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TigeraStatus) DeepCopyInto(out *TigeraStatus) {
 	*out = *in
@@ -6984,6 +9339,25 @@ func (in *TigeraStatusStatus) DeepCopyInto(out *TigeraStatusStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]ComponentVersion, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedRevisions != nil {
+		in, out := &in.AppliedRevisions, &out.AppliedRevisions
+		*out = make([]AppliedRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TigeraStatusStatus.
@@ -6996,6 +9370,41 @@ func (in *TigeraStatusStatus) DeepCopy() *TigeraStatusStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TunnelServiceOptions) DeepCopyInto(out *TunnelServiceOptions) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(corev1.ServiceType)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExternalTrafficPolicy != nil {
+		in, out := &in.ExternalTrafficPolicy, &out.ExternalTrafficPolicy
+		*out = new(corev1.ServiceExternalTrafficPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TunnelServiceOptions.
+func (in *TunnelServiceOptions) DeepCopy() *TunnelServiceOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TunnelServiceOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TyphaAffinity) DeepCopyInto(out *TyphaAffinity) {
 	*out = *in
@@ -7069,6 +9478,18 @@ func (in *TyphaDeploymentInitContainer) DeepCopyInto(out *TyphaDeploymentInitCon
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TyphaDeploymentInitContainer.
@@ -7129,6 +9550,11 @@ func (in *TyphaDeploymentPodSpec) DeepCopyInto(out *TyphaDeploymentPodSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TyphaDeploymentPodSpec.
@@ -7216,6 +9642,117 @@ func (in *TyphaDeploymentStrategy) DeepCopy() *TyphaDeploymentStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TyphaHealthStatus) DeepCopyInto(out *TyphaHealthStatus) {
+	*out = *in
+	in.LastScrapeTime.DeepCopyInto(&out.LastScrapeTime)
+	if in.DisconnectsPerHour != nil {
+		in, out := &in.DisconnectsPerHour, &out.DisconnectsPerHour
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]TyphaInstanceHealth, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TyphaHealthStatus.
+func (in *TyphaHealthStatus) DeepCopy() *TyphaHealthStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TyphaHealthStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TyphaInstanceHealth) DeepCopyInto(out *TyphaInstanceHealth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TyphaInstanceHealth.
+func (in *TyphaInstanceHealth) DeepCopy() *TyphaInstanceHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(TyphaInstanceHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeHook) DeepCopyInto(out *UpgradeHook) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeHook.
+func (in *UpgradeHook) DeepCopy() *UpgradeHook {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStageStatus) DeepCopyInto(out *UpgradeStageStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStageStatus.
+func (in *UpgradeStageStatus) DeepCopy() *UpgradeStageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]UpgradeStageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserMatch) DeepCopyInto(out *UserMatch) {
 	*out = *in
@@ -7260,3 +9797,18 @@ func (in *WindowsNodeSpec) DeepCopy() *WindowsNodeSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadNetworkAttachment) DeepCopyInto(out *WorkloadNetworkAttachment) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadNetworkAttachment.
+func (in *WorkloadNetworkAttachment) DeepCopy() *WorkloadNetworkAttachment {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadNetworkAttachment)
+	in.DeepCopyInto(out)
+	return out
+}
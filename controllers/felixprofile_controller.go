@@ -0,0 +1,41 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+	installation "github.com/tigera/operator/pkg/controller/installation"
+	"github.com/tigera/operator/pkg/controller/options"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FelixProfileReconciler maintains the node-scoped FelixConfigurations of the Installation's FelixProfiles and of
+// the FelixConfigurationOverlays.
+type FelixProfileReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=operator.tigera.io,resources=felixconfigurationoverlays,verbs=get;list;watch
+// +kubebuilder:rbac:groups=operator.tigera.io,resources=felixconfigurationoverlays/status,verbs=get;update;patch
+
+func (r *FelixProfileReconciler) SetupWithManager(mgr ctrl.Manager, opts options.AddOptions) error {
+	return installation.AddFelixProfileController(mgr, opts)
+}
@@ -43,5 +43,8 @@ func (r *SecretsReconciler) SetupWithManager(mgr ctrl.Manager, opts options.AddO
 	if err := secrets.AddTenantController(mgr, opts); err != nil {
 		return err
 	}
+	if err := secrets.AddCertificatesController(mgr, opts); err != nil {
+		return err
+	}
 	return nil
 }
@@ -177,6 +177,13 @@ var (
 		Registry: "{{ .Registry }}",
 	}
 {{- end }}
+{{ with index .Components "siem-exporter" }}
+	ComponentSIEMExporter = component{
+		Version:  "{{ .Version }}",
+		Image:    "{{ .Image }}",
+		Registry: "{{ .Registry }}",
+	}
+{{- end }}
 {{ with .Components.kibana }}
 	ComponentKibana = component{
 		Version:  "{{ .Version }}",
@@ -212,6 +219,13 @@ var (
 		Registry: "{{ .Registry }}",
 	}
 {{- end }}
+{{ with index .Components "cloud-connector" }}
+	ComponentCloudConnector = component{
+		Version:  "{{ .Version }}",
+		Image:    "{{ .Image }}",
+		Registry: "{{ .Registry }}",
+	}
+{{- end }}
 {{ with index .Components "policy-recommendation" }}
 	ComponentPolicyRecommendation = component{
 		Version:  "{{ .Version }}",
@@ -385,11 +399,13 @@ var (
 		ComponentGuardian,
 		ComponentIntrusionDetectionController,
 		ComponentSecurityEventWebhooksProcessor,
+		ComponentSIEMExporter,
 		ComponentKibana,
 		ComponentManager,
 		ComponentDex,
 		ComponentManagerProxy,
 		ComponentPacketCapture,
+		ComponentCloudConnector,
 		ComponentPolicyRecommendation,
 		ComponentEgressGateway,
 		ComponentL7Collector,
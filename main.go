@@ -23,6 +23,9 @@ import (
 	goruntime "runtime"
 	"strings"
 	"time"
+	// Embed the time zone database, so that time zones in the configuration can be validated without relying on
+	// the image that the operator runs in.
+	_ "time/tzdata"
 
 	"github.com/cloudflare/cfssl/log"
 
@@ -39,15 +42,19 @@ import (
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/crds"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/intrusiondetection/dpi"
 	"github.com/tigera/operator/pkg/render/logstorage"
 	"github.com/tigera/operator/pkg/render/logstorage/eck"
+	"github.com/tigera/operator/pkg/tracing"
+	"github.com/tigera/operator/pkg/webhook"
 	"github.com/tigera/operator/version"
 
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -104,6 +111,11 @@ func main() {
 	var sgSetup bool
 	var manageCRDs bool
 	var preDelete bool
+	var kubeAPIQPS float64
+	var kubeAPIBurst int
+	var clusterDomainOverride string
+	var enableAdmissionWebhooks bool
+	var logFormat string
 
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", true,
 		"Enable leader election for controller manager. "+
@@ -124,11 +136,25 @@ func main() {
 		"Operator should manage the projectcalico.org and operator.tigera.io CRDs.")
 	flag.BoolVar(&preDelete, "pre-delete", false,
 		"Run helm pre-deletion hook logic, then exit.")
+	flag.Float64Var(&kubeAPIQPS, "kube-api-qps", 0,
+		"Maximum queries per second from the operator to the Kubernetes API server. Uses the client default if unset.")
+	flag.IntVar(&kubeAPIBurst, "kube-api-burst", 0,
+		"Maximum burst of queries from the operator to the Kubernetes API server. Uses the client default if unset.")
+	flag.StringVar(&clusterDomainOverride, "cluster-domain", "",
+		"The DNS domain of the cluster. Detected from the resolv.conf of the operator or the kubelet configuration if unset.")
+	flag.BoolVar(&enableAdmissionWebhooks, "enable-admission-webhooks", false,
+		"Serve the operator's admission webhooks, which reject invalid FelixConfigurations and Installations before they are persisted.")
+	flag.StringVar(&logFormat, "log-format", "",
+		"The format of the operator's logs. Possible values: text, json. Uses the encoder of the zap flags if unset. The log lines of a reconcile carry its reconcileID.")
 
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
+	if err := logging.SetFormat(&opts, logFormat); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 	ctrl.SetLogger(zap.New(zap.WriteTo(os.Stdout), zap.UseFlagOptions(&opts)))
 
 	if showVersion {
@@ -184,6 +210,12 @@ func main() {
 		log.Error(err, "")
 		os.Exit(1)
 	}
+	if kubeAPIQPS > 0 {
+		cfg.QPS = float32(kubeAPIQPS)
+	}
+	if kubeAPIBurst > 0 {
+		cfg.Burst = kubeAPIBurst
+	}
 
 	c, err := client.New(cfg, client.Options{Scheme: scheme})
 	if err != nil {
@@ -234,7 +266,7 @@ func main() {
 	active.WaitUntilActive(cs, c, sigHandler, setupLog)
 	log.Info("Active operator: proceeding")
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: metricsAddr(),
 		Port:               9443,
@@ -371,11 +403,14 @@ func main() {
 	}
 	setupLog.WithValues("required", enterpriseCRDExists).Info("Checking if TSEE controllers are required")
 
-	clusterDomain, err := dns.GetClusterDomain(dns.DefaultResolveConfPath)
-	if err != nil {
-		clusterDomain = dns.DefaultClusterDomain
-		log.Error(err, fmt.Sprintf("Couldn't find the cluster domain from the resolv.conf, defaulting to %s", clusterDomain))
+	clusterDomain, clusterDomainSource, err := dns.DetectClusterDomain(ctx, clientset, clusterDomainOverride, dns.DefaultResolveConfPath)
+	if err != nil && clusterDomainSource == dns.ClusterDomainSourceDefault {
+		log.Error(err, fmt.Sprintf("Couldn't detect the cluster domain, defaulting to %s", clusterDomain))
+	} else if err != nil {
+		setupLog.Error(err, "Invalid cluster domain")
+		os.Exit(1)
 	}
+	setupLog.WithValues("clusterDomain", clusterDomain, "source", clusterDomainSource).Info("Checking cluster domain")
 
 	kubernetesVersion, err := common.GetKubernetesVersion(clientset)
 	if err != nil {
@@ -420,6 +455,18 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Load the OperatorConfig, if it exists, for the settings that the controllers are created with. The
+	// OperatorConfig controller restarts the operator when they change.
+	operatorConfig, err := utils.GetOperatorConfig(ctx, c)
+	if err != nil {
+		if !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			log.Error(err, "Failed to load OperatorConfig")
+			os.Exit(1)
+		}
+		operatorConfig = nil
+	}
+	maxConcurrentReconciles := utils.MaxConcurrentReconciles(operatorConfig)
+
 	options := options.AddOptions{
 		DetectedProvider:    provider,
 		EnterpriseCRDExists: enterpriseCRDExists,
@@ -429,6 +476,8 @@ func main() {
 		ShutdownContext:     ctx,
 		MultiTenant:         multiTenant,
 		ElasticExternal:     utils.UseExternalElastic(bootConfig),
+
+		MaxConcurrentReconciles: maxConcurrentReconciles,
 	}
 
 	// Before we start any controllers, make sure our options are valid.
@@ -443,9 +492,25 @@ func main() {
 		os.Exit(1)
 	}
 
+	if enableAdmissionWebhooks {
+		if err := webhook.AddToManager(mgr, options); err != nil {
+			setupLog.Error(err, "unable to create admission webhooks")
+			os.Exit(1)
+		}
+	}
+
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctx); err != nil {
-		setupLog.Error(err, "problem running manager")
+	runErr := mgr.Start(ctx)
+
+	// The reconciles have stopped, export the spans that haven't been yet.
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := tracing.Shutdown(flushCtx); err != nil {
+		setupLog.Error(err, "failed to export the remaining spans")
+	}
+	cancelFlush()
+
+	if runErr != nil {
+		setupLog.Error(runErr, "problem running manager")
 		os.Exit(1)
 	}
 }
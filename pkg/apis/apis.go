@@ -20,6 +20,7 @@ import (
 	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
 	kbv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/kibana/v1"
 	configv1 "github.com/openshift/api/config/v1"
+	routev1 "github.com/openshift/api/route/v1"
 	ocsv1 "github.com/openshift/api/security/v1"
 	tigera "github.com/tigera/api/pkg/apis/projectcalico/v3"
 	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
@@ -44,6 +45,7 @@ func init() {
 	AddToSchemes = append(AddToSchemes, apiextensions.AddToScheme)
 	AddToSchemes = append(AddToSchemes, tigera.AddToScheme)
 	AddToSchemes = append(AddToSchemes, ocsv1.AddToScheme)
+	AddToSchemes = append(AddToSchemes, routev1.Install)
 	AddToSchemes = append(AddToSchemes, esv1.SchemeBuilder.AddToScheme)
 	AddToSchemes = append(AddToSchemes, kbv1.SchemeBuilder.AddToScheme)
 	AddToSchemes = append(AddToSchemes, policyv1.SchemeBuilder.AddToScheme)
@@ -29,6 +29,15 @@ const (
 	IptablesBackendNFTables = "NFT"
 )
 
+// +kubebuilder:validation:Enum=Disabled;Enabled;Auto
+type NFTablesMode string
+
+const (
+	NFTablesModeDisabled NFTablesMode = "Disabled"
+	NFTablesModeEnabled  NFTablesMode = "Enabled"
+	NFTablesModeAuto     NFTablesMode = "Auto"
+)
+
 // +kubebuilder:validation:Enum=DoNothing;Enable;Disable
 type AWSSrcDstCheckOption string
 
@@ -112,6 +121,9 @@ type FelixConfigurationSpec struct {
 	// IptablesBackend specifies which backend of iptables will be used. The default is legacy.
 	IptablesBackend *IptablesBackend `json:"iptablesBackend,omitempty" validate:"omitempty,iptablesBackend"`
 
+	// NFTablesMode configures nftables support in Felix. [Default: Disabled]
+	NFTablesMode *NFTablesMode `json:"nftablesMode,omitempty"`
+
 	// XDPRefreshInterval is the period at which Felix re-checks all XDP state to ensure that no
 	// other process has accidentally broken Calico's BPF maps or attached programs. Set to 0 to
 	// disable XDP refresh. [Default: 90s]
@@ -342,6 +354,36 @@ type FelixConfigurationSpec struct {
 	// BPFKubeProxyEndpointSlicesEnabled in BPF mode, controls whether Felix's
 	// embedded kube-proxy accepts EndpointSlices or not.
 	BPFKubeProxyEndpointSlicesEnabled *bool `json:"bpfKubeProxyEndpointSlicesEnabled,omitempty" validate:"omitempty"`
+	// BPFHostConntrackBypass controls whether to bypass Linux conntrack in BPF mode for
+	// workloads and services. [Default: true - bypass Linux conntrack]
+	BPFHostConntrackBypass *bool `json:"bpfHostConntrackBypass,omitempty"`
+	// BPFMapSizeConntrack sets the size for the conntrack map.  This map must be large enough to hold
+	// an entry for each active connection.  Warning: changing the size of the conntrack map can cause disruption.
+	BPFMapSizeConntrack *int `json:"bpfMapSizeConntrack,omitempty"`
+	// BPFMapSizeNATFrontend sets the size for nat front end map.
+	// FrontendMap should be large enough to hold an entry for each nodeport, external IP and each port in each service.
+	BPFMapSizeNATFrontend *int `json:"bpfMapSizeNATFrontend,omitempty"`
+	// BPFMapSizeNATBackend sets the size for nat back end map.
+	// This is the total number of endpoints. This is mostly more than the size of the number of services.
+	BPFMapSizeNATBackend *int `json:"bpfMapSizeNATBackend,omitempty"`
+	// BPFMapSizeNATAffinity sets the size for the nat affinity map, which holds an entry for each
+	// client that is pinned to a backend of a service with session affinity.
+	BPFMapSizeNATAffinity *int `json:"bpfMapSizeNATAffinity,omitempty"`
+	// BPFMapSizeRoute sets the size for the routes map.  The routes map should be large enough
+	// to hold one entry per workload and a handful of entries per host (enough to cover its own IPs and
+	// tunnel IPs).
+	BPFMapSizeRoute *int `json:"bpfMapSizeRoute,omitempty"`
+	// BPFMapSizeIPSets sets the size for ipsets map.  The IP sets map must be large enough to hold an entry
+	// for each endpoint matched by every selector in the source/destination matches in network policy.  Selectors
+	// such as "all()" can result in large numbers of entries (one entry per endpoint in that case).
+	BPFMapSizeIPSets *int `json:"bpfMapSizeIPSets,omitempty"`
+	// BPFPSNATPorts sets the range from which we randomly pick a port if there is a source port
+	// collision. This should be within the ephemeral range as defined by RFC 6056 (1024–65535) and
+	// preferably outside the  ephemeral ranges used by common operating systems. Linux uses
+	// 32768–60999, while others mostly use the IANA defined range 49152–65535. It is not necessarily
+	// a problem if this range overlaps with the operating systems. Both ends of the range are
+	// inclusive. [Default: 20000:29999]
+	BPFPSNATPorts *numorstring.Port `json:"bpfPSNATPorts,omitempty"`
 
 	// RouteSource configures where Felix gets its routing information.
 	// - WorkloadIPs: use workload endpoints to construct routes.
@@ -388,6 +430,46 @@ type FelixConfigurationSpec struct {
 	// EgressIPVXLANVNI is the VNI ID of vxlan tunnel device for egress traffic. [Default: 4097]
 	EgressIPVXLANVNI *int `json:"egressIPVXLANVNI,omitempty"`
 
+	// FlowLogsFlushInterval configures the interval at which Felix exports flow logs.
+	FlowLogsFlushInterval *metav1.Duration `json:"flowLogsFlushInterval,omitempty" configv1timescale:"seconds"`
+	// FlowLogsFileEnabled when set to true, enables logging flow logs to a file. If false no flow logging to file will occur.
+	FlowLogsFileEnabled *bool `json:"flowLogsFileEnabled,omitempty"`
+	// FlowLogsFileEnabledForAllowed is used to enable/disable flow logs entries created for allowed connections. Default is true.
+	// This parameter only takes effect when FlowLogsFileEnabled is set to true.
+	FlowLogsFileEnabledForAllowed *bool `json:"flowLogsFileEnabledForAllowed,omitempty"`
+	// FlowLogsFileEnabledForDenied is used to enable/disable flow logs entries created for denied flows. Default is true.
+	// This parameter only takes effect when FlowLogsFileEnabled is set to true.
+	FlowLogsFileEnabledForDenied *bool `json:"flowLogsFileEnabledForDenied,omitempty"`
+	// FlowLogsFileAggregationKindForAllowed is used to choose the type of aggregation for flow log entries created for
+	// allowed connections. [Default: 2 - pod prefix name based aggregation].
+	// Accepted values are 0, 1 and 2.
+	// 0 - No aggregation.
+	// 1 - Source port based aggregation.
+	// 2 - Pod prefix name based aggreagation.
+	// +kubebuilder:validation:Enum=0;1;2
+	FlowLogsFileAggregationKindForAllowed *int `json:"flowLogsFileAggregationKindForAllowed,omitempty" validate:"omitempty,flowLogAggregationKind"`
+	// FlowLogsFileAggregationKindForDenied is used to choose the type of aggregation for flow log entries created for
+	// denied connections. [Default: 1 - source port based aggregation].
+	// Accepted values are 0, 1, 2 and 3.
+	// 0 - No aggregation.
+	// 1 - Source port based aggregation.
+	// 2 - Pod prefix name based aggregation.
+	// 3 - No destination ports based aggregation.
+	// +kubebuilder:validation:Enum=0;1;2;3
+	FlowLogsFileAggregationKindForDenied *int `json:"flowLogsFileAggregationKindForDenied,omitempty" validate:"omitempty,flowLogAggregationKind"`
+	// FlowLogsFileIncludeLabels is used to configure if endpoint labels are included in a Flow log entry written to file.
+	FlowLogsFileIncludeLabels *bool `json:"flowLogsFileIncludeLabels,omitempty"`
+	// FlowLogsFileIncludePolicies is used to configure if policy information are included in a Flow log entry written to file.
+	FlowLogsFileIncludePolicies *bool `json:"flowLogsFileIncludePolicies,omitempty"`
+	// FlowLogsFileIncludeService is used to configure if the destination service is included in a Flow log entry written to file.
+	// The service information can only be included if the flow was explicitly determined to be directed at the service (e.g.
+	// when the pre-DNAT destination corresponds to the service ClusterIP and port).
+	FlowLogsFileIncludeService *bool `json:"flowLogsFileIncludeService,omitempty"`
+	// FlowLogsEnableHostEndpoint enables Flow logs reporting for HostEndpoints.
+	FlowLogsEnableHostEndpoint *bool `json:"flowLogsEnableHostEndpoint,omitempty"`
+	// FlowLogsEnableNetworkSets enables Flow logs reporting for GlobalNetworkSets.
+	FlowLogsEnableNetworkSets *bool `json:"flowLogsEnableNetworkSets,omitempty"`
+
 	// The DNS servers that Felix should trust. Each entry here must be `<ip>[:<port>]` - indicating an
 	// explicit DNS server IP - or `k8s-service:[<namespace>/]<name>[:port]` - indicating a Kubernetes DNS
 	// service. `<port>` defaults to the first service port, or 53 for an IP, and `<namespace>` to
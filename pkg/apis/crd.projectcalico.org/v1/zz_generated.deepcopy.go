@@ -377,6 +377,11 @@ func (in *FelixConfigurationSpec) DeepCopyInto(out *FelixConfigurationSpec) {
 		*out = new(IptablesBackend)
 		**out = **in
 	}
+	if in.NFTablesMode != nil {
+		in, out := &in.NFTablesMode, &out.NFTablesMode
+		*out = new(NFTablesMode)
+		**out = **in
+	}
 	if in.XDPRefreshInterval != nil {
 		in, out := &in.XDPRefreshInterval, &out.XDPRefreshInterval
 		*out = new(metav1.Duration)
@@ -623,6 +628,46 @@ func (in *FelixConfigurationSpec) DeepCopyInto(out *FelixConfigurationSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.BPFHostConntrackBypass != nil {
+		in, out := &in.BPFHostConntrackBypass, &out.BPFHostConntrackBypass
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BPFMapSizeConntrack != nil {
+		in, out := &in.BPFMapSizeConntrack, &out.BPFMapSizeConntrack
+		*out = new(int)
+		**out = **in
+	}
+	if in.BPFMapSizeNATFrontend != nil {
+		in, out := &in.BPFMapSizeNATFrontend, &out.BPFMapSizeNATFrontend
+		*out = new(int)
+		**out = **in
+	}
+	if in.BPFMapSizeNATBackend != nil {
+		in, out := &in.BPFMapSizeNATBackend, &out.BPFMapSizeNATBackend
+		*out = new(int)
+		**out = **in
+	}
+	if in.BPFMapSizeNATAffinity != nil {
+		in, out := &in.BPFMapSizeNATAffinity, &out.BPFMapSizeNATAffinity
+		*out = new(int)
+		**out = **in
+	}
+	if in.BPFMapSizeRoute != nil {
+		in, out := &in.BPFMapSizeRoute, &out.BPFMapSizeRoute
+		*out = new(int)
+		**out = **in
+	}
+	if in.BPFMapSizeIPSets != nil {
+		in, out := &in.BPFMapSizeIPSets, &out.BPFMapSizeIPSets
+		*out = new(int)
+		**out = **in
+	}
+	if in.BPFPSNATPorts != nil {
+		in, out := &in.BPFPSNATPorts, &out.BPFPSNATPorts
+		*out = new(libnumorstring.Port)
+		**out = **in
+	}
 	if in.RouteTableRange != nil {
 		in, out := &in.RouteTableRange, &out.RouteTableRange
 		*out = new(RouteTableRange)
@@ -693,6 +738,61 @@ func (in *FelixConfigurationSpec) DeepCopyInto(out *FelixConfigurationSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.FlowLogsFlushInterval != nil {
+		in, out := &in.FlowLogsFlushInterval, &out.FlowLogsFlushInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.FlowLogsFileEnabled != nil {
+		in, out := &in.FlowLogsFileEnabled, &out.FlowLogsFileEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FlowLogsFileEnabledForAllowed != nil {
+		in, out := &in.FlowLogsFileEnabledForAllowed, &out.FlowLogsFileEnabledForAllowed
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FlowLogsFileEnabledForDenied != nil {
+		in, out := &in.FlowLogsFileEnabledForDenied, &out.FlowLogsFileEnabledForDenied
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FlowLogsFileAggregationKindForAllowed != nil {
+		in, out := &in.FlowLogsFileAggregationKindForAllowed, &out.FlowLogsFileAggregationKindForAllowed
+		*out = new(int)
+		**out = **in
+	}
+	if in.FlowLogsFileAggregationKindForDenied != nil {
+		in, out := &in.FlowLogsFileAggregationKindForDenied, &out.FlowLogsFileAggregationKindForDenied
+		*out = new(int)
+		**out = **in
+	}
+	if in.FlowLogsFileIncludeLabels != nil {
+		in, out := &in.FlowLogsFileIncludeLabels, &out.FlowLogsFileIncludeLabels
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FlowLogsFileIncludePolicies != nil {
+		in, out := &in.FlowLogsFileIncludePolicies, &out.FlowLogsFileIncludePolicies
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FlowLogsFileIncludeService != nil {
+		in, out := &in.FlowLogsFileIncludeService, &out.FlowLogsFileIncludeService
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FlowLogsEnableHostEndpoint != nil {
+		in, out := &in.FlowLogsEnableHostEndpoint, &out.FlowLogsEnableHostEndpoint
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FlowLogsEnableNetworkSets != nil {
+		in, out := &in.FlowLogsEnableNetworkSets, &out.FlowLogsEnableNetworkSets
+		*out = new(bool)
+		**out = **in
+	}
 	if in.DNSTrustedServers != nil {
 		in, out := &in.DNSTrustedServers, &out.DNSTrustedServers
 		*out = new([]string)
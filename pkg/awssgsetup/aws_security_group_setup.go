@@ -17,6 +17,8 @@ package awssgsetup
 import (
 	"context"
 	"fmt"
+	"os"
+	"strconv"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -31,6 +33,9 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// defaultTyphaPort is the port that Typha listens on if the setup job isn't told otherwise.
+const defaultTyphaPort = 5473
+
 var log = logf.Log.WithName("AWS_SG_Setup")
 var TRACE = 7
 var DEBUG = 5
@@ -77,6 +82,11 @@ func SetupAWSSecurityGroups(ctx context.Context, client client.Client) error {
 
 	ec2Cli := ec2.New(sess)
 
+	typhaPort, err := getTyphaPort()
+	if err != nil {
+		return err
+	}
+
 	// Get SG ids in VPC
 	// Get one with filter tag:Name with *-master-sg
 	// Get one with filter tag:Name with *-worker-sg
@@ -103,7 +113,7 @@ func SetupAWSSecurityGroups(ctx context.Context, client client.Client) error {
 		{
 			srcSGId:  aws.StringValue(masterSg.GroupId),
 			protocol: "tcp",
-			port:     aws.Int64(5473),
+			port:     aws.Int64(typhaPort),
 		},
 		{
 			srcSGId:  aws.StringValue(workerSg.GroupId),
@@ -117,7 +127,7 @@ func SetupAWSSecurityGroups(ctx context.Context, client client.Client) error {
 		{
 			srcSGId:  aws.StringValue(workerSg.GroupId),
 			protocol: "tcp",
-			port:     aws.Int64(5473),
+			port:     aws.Int64(typhaPort),
 		},
 	}
 	err = allowIngressToSG(ec2Cli, masterSg, src)
@@ -133,6 +143,20 @@ func SetupAWSSecurityGroups(ctx context.Context, client client.Client) error {
 	return nil
 }
 
+// getTyphaPort returns the port that Typha listens on, which the operator passes to the setup job in the TYPHA_PORT
+// environment variable.
+func getTyphaPort() (int64, error) {
+	port := os.Getenv("TYPHA_PORT")
+	if port == "" {
+		return defaultTyphaPort, nil
+	}
+	p, err := strconv.ParseInt(port, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid TYPHA_PORT %q: %v", port, err)
+	}
+	return p, nil
+}
+
 // getAWSCreds reads the aws-creds Secret that is created by an Openshift install and returns
 // the id and secret.
 func getAWSCreds(ctx context.Context, client client.Client) (id, secret string, err error) {
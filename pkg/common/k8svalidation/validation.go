@@ -19,6 +19,7 @@ https://github.com/kubernetes/kubernetes/blob/e9b96b167fbe5bb7c16824f5515501434c
 */
 import (
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 
@@ -35,6 +36,17 @@ import (
 
 const isNotPositiveErrorMsg string = `must be greater than zero`
 
+const (
+	// Limits on various DNS parameters. These are derived from
+	// restrictions in Linux libc name resolution handling.
+	// Max number of DNS name servers.
+	MaxDNSNameservers = 3
+	// Max number of domains in the search path list.
+	MaxDNSSearchPaths = 32
+	// Max number of characters in the search path.
+	MaxDNSSearchListChars = 2048
+)
+
 var validateNamespaceName = apimachineryvalidation.ValidateNamespaceName
 
 // ValidateNodeName can be used to check whether the given node name is valid.
@@ -515,3 +527,67 @@ func validateMinDomains(fldPath *field.Path, minDomains *int32, action core.Unsa
 	}
 	return allErrs
 }
+
+// ValidateDNSPolicy tests that the given DNS policy is one of the supported values.
+func ValidateDNSPolicy(dnsPolicy *core.DNSPolicy, fldPath *field.Path) field.ErrorList {
+	allErrors := field.ErrorList{}
+	switch *dnsPolicy {
+	case core.DNSClusterFirstWithHostNet, core.DNSClusterFirst, core.DNSDefault, core.DNSNone:
+	case "":
+		allErrors = append(allErrors, field.Required(fldPath, ""))
+	default:
+		validValues := []string{string(core.DNSClusterFirstWithHostNet), string(core.DNSClusterFirst), string(core.DNSDefault), string(core.DNSNone)}
+		allErrors = append(allErrors, field.NotSupported(fldPath, dnsPolicy, validValues))
+	}
+	return allErrors
+}
+
+// ValidatePodDNSConfig tests that the given DNS config is valid for the given DNS policy. The DNS policy may be nil
+// if it is not known.
+func ValidatePodDNSConfig(dnsConfig *core.PodDNSConfig, dnsPolicy *core.DNSPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// Validate DNSNone case. Must provide at least one DNS name server.
+	if dnsPolicy != nil && *dnsPolicy == core.DNSNone {
+		if dnsConfig == nil {
+			return append(allErrs, field.Required(fldPath, fmt.Sprintf("must provide `dnsConfig` when `dnsPolicy` is %s", core.DNSNone)))
+		}
+		if len(dnsConfig.Nameservers) == 0 {
+			return append(allErrs, field.Required(fldPath.Child("nameservers"), fmt.Sprintf("must provide at least one DNS nameserver when `dnsPolicy` is %s", core.DNSNone)))
+		}
+	}
+
+	if dnsConfig != nil {
+		// Validate nameservers.
+		if len(dnsConfig.Nameservers) > MaxDNSNameservers {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("nameservers"), dnsConfig.Nameservers, fmt.Sprintf("must not have more than %v nameservers", MaxDNSNameservers)))
+		}
+		for i, ns := range dnsConfig.Nameservers {
+			if ip := net.ParseIP(ns); ip == nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("nameservers").Index(i), ns, "must be valid IP address"))
+			}
+		}
+		// Validate searches.
+		if len(dnsConfig.Searches) > MaxDNSSearchPaths {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("searches"), dnsConfig.Searches, fmt.Sprintf("must not have more than %v search paths", MaxDNSSearchPaths)))
+		}
+		// Include the space between search paths.
+		if len(strings.Join(dnsConfig.Searches, " ")) > MaxDNSSearchListChars {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("searches"), dnsConfig.Searches, fmt.Sprintf("must not have more than %v characters (including spaces) in the search list", MaxDNSSearchListChars)))
+		}
+		for i, search := range dnsConfig.Searches {
+			// it is fine to have a trailing dot
+			search = strings.TrimSuffix(search, ".")
+			for _, msg := range validation.IsDNS1123Subdomain(search) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("searches").Index(i), search, msg))
+			}
+		}
+		// Validate options.
+		for i, option := range dnsConfig.Options {
+			if len(option.Name) == 0 {
+				allErrs = append(allErrs, field.Required(fldPath.Child("options").Index(i), "must not be empty"))
+			}
+		}
+	}
+	return allErrs
+}
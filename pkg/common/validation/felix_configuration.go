@@ -0,0 +1,119 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+)
+
+const (
+	// Felix refuses to use route tables outside of this range: 0 is unspecified and 253-255 are the kernel's
+	// default, main and local tables.
+	minRouteTable = 1
+	maxRouteTable = 252
+)
+
+// ValidateFelixConfigurationSpec checks the FelixConfiguration fields, and combinations of them, that felix would
+// otherwise only reject at runtime, after it has been rolled out to every node.
+func ValidateFelixConfigurationSpec(spec *crdv1.FelixConfigurationSpec) error {
+	return FelixConfigurationSpecErrors(spec).ToAggregate()
+}
+
+// FelixConfigurationSpecErrors returns the errors that ValidateFelixConfigurationSpec reports for the spec, one for
+// each field or combination of fields that felix can't use.
+func FelixConfigurationSpecErrors(spec *crdv1.FelixConfigurationSpec) field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if isTrue(spec.BPFEnabled) && spec.IptablesBackend != nil && *spec.IptablesBackend == crdv1.IptablesBackendNFTables {
+		errs = append(errs, field.Invalid(specPath.Child("iptablesBackend"), *spec.IptablesBackend,
+			"the eBPF dataplane does not support the NFT iptables backend, set iptablesBackend to Legacy or disable bpfEnabled"))
+	}
+
+	if isTrue(spec.WireguardHostEncryptionEnabled) && !isTrue(spec.WireguardEnabled) && !isTrue(spec.WireguardEnabledV6) {
+		errs = append(errs, field.Invalid(specPath.Child("wireguardHostEncryptionEnabled"), true,
+			"host encryption requires wireguardEnabled or wireguardEnabledV6"))
+	}
+
+	for _, m := range []struct {
+		name string
+		size *int
+	}{
+		{"bpfMapSizeConntrack", spec.BPFMapSizeConntrack},
+		{"bpfMapSizeNATFrontend", spec.BPFMapSizeNATFrontend},
+		{"bpfMapSizeNATBackend", spec.BPFMapSizeNATBackend},
+		{"bpfMapSizeNATAffinity", spec.BPFMapSizeNATAffinity},
+		{"bpfMapSizeRoute", spec.BPFMapSizeRoute},
+		{"bpfMapSizeIPSets", spec.BPFMapSizeIPSets},
+	} {
+		if m.size != nil && *m.size <= 0 {
+			errs = append(errs, field.Invalid(specPath.Child(m.name), *m.size, "BPF map sizes must be greater than zero"))
+		}
+	}
+
+	if r := spec.RouteTableRange; r != nil {
+		rangePath := specPath.Child("routeTableRange")
+		if r.Min > r.Max {
+			errs = append(errs, field.Invalid(rangePath, *r, "min must not be greater than max"))
+		}
+		if r.Min < minRouteTable || r.Max > maxRouteTable {
+			errs = append(errs, field.Invalid(rangePath, *r,
+				"the range must be within 1-252, tables 253-255 are reserved by the kernel"))
+		}
+	}
+
+	for _, p := range []struct {
+		name string
+		port *int
+	}{
+		{"metadataPort", spec.MetadataPort},
+		{"vxlanPort", spec.VXLANPort},
+		{"healthPort", spec.HealthPort},
+		{"prometheusMetricsPort", spec.PrometheusMetricsPort},
+		{"prometheusReporterPort", spec.PrometheusReporterPort},
+		{"wireguardListeningPort", spec.WireguardListeningPort},
+		{"wireguardListeningPortV6", spec.WireguardListeningPortV6},
+		{"egressIPVXLANPort", spec.EgressIPVXLANPort},
+	} {
+		if p.port != nil && (*p.port < 1 || *p.port > 65535) {
+			errs = append(errs, field.Invalid(specPath.Child(p.name), *p.port, "ports must be within 1-65535"))
+		}
+	}
+
+	// Entries of the interface exclusion list that are wrapped in '/' are regular expressions.
+	for _, iface := range strings.Split(spec.InterfaceExclude, ",") {
+		if len(iface) > 1 && strings.HasPrefix(iface, "/") && strings.HasSuffix(iface, "/") {
+			if _, err := regexp.Compile(iface[1 : len(iface)-1]); err != nil {
+				errs = append(errs, field.Invalid(specPath.Child("interfaceExclude"), iface, err.Error()))
+			}
+		}
+	}
+	if spec.BPFDataIfacePattern != "" {
+		if _, err := regexp.Compile(spec.BPFDataIfacePattern); err != nil {
+			errs = append(errs, field.Invalid(specPath.Child("bpfDataIfacePattern"), spec.BPFDataIfacePattern, err.Error()))
+		}
+	}
+
+	return errs
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+var _ = DescribeTable("Test FelixConfiguration validation",
+	func(spec crdv1.FelixConfigurationSpec, expectedErr string) {
+		err := ValidateFelixConfigurationSpec(&spec)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		}
+	},
+	Entry("empty spec", crdv1.FelixConfigurationSpec{}, ""),
+	Entry("BPF with the legacy iptables backend",
+		crdv1.FelixConfigurationSpec{BPFEnabled: ptr.BoolToPtr(true), IptablesBackend: ptr.ToPtr(crdv1.IptablesBackend(crdv1.IptablesBackendLegacy))}, ""),
+	Entry("BPF with the NFT iptables backend",
+		crdv1.FelixConfigurationSpec{BPFEnabled: ptr.BoolToPtr(true), IptablesBackend: ptr.ToPtr(crdv1.IptablesBackend(crdv1.IptablesBackendNFTables))},
+		"spec.iptablesBackend"),
+	Entry("NFT iptables backend without BPF",
+		crdv1.FelixConfigurationSpec{BPFEnabled: ptr.BoolToPtr(false), IptablesBackend: ptr.ToPtr(crdv1.IptablesBackend(crdv1.IptablesBackendNFTables))}, ""),
+	Entry("WireGuard with VXLAN",
+		crdv1.FelixConfigurationSpec{WireguardEnabled: ptr.BoolToPtr(true), VXLANEnabled: ptr.BoolToPtr(true)}, ""),
+	Entry("WireGuard with IP-in-IP",
+		crdv1.FelixConfigurationSpec{WireguardEnabled: ptr.BoolToPtr(true), IPIPEnabled: ptr.BoolToPtr(true)}, ""),
+	Entry("WireGuard host encryption without WireGuard",
+		crdv1.FelixConfigurationSpec{WireguardHostEncryptionEnabled: ptr.BoolToPtr(true)}, "spec.wireguardHostEncryptionEnabled"),
+	Entry("WireGuard host encryption with IPv6 WireGuard",
+		crdv1.FelixConfigurationSpec{WireguardHostEncryptionEnabled: ptr.BoolToPtr(true), WireguardEnabledV6: ptr.BoolToPtr(true)}, ""),
+	Entry("BPF map sizes",
+		crdv1.FelixConfigurationSpec{BPFMapSizeConntrack: ptr.ToPtr(1024000), BPFMapSizeRoute: ptr.ToPtr(262144)}, ""),
+	Entry("zero BPF route map size",
+		crdv1.FelixConfigurationSpec{BPFMapSizeRoute: ptr.ToPtr(0)}, "spec.bpfMapSizeRoute"),
+	Entry("negative BPF conntrack map size",
+		crdv1.FelixConfigurationSpec{BPFMapSizeConntrack: ptr.ToPtr(-1)}, "spec.bpfMapSizeConntrack"),
+	Entry("valid route table range",
+		crdv1.FelixConfigurationSpec{RouteTableRange: &crdv1.RouteTableRange{Min: 1, Max: 250}}, ""),
+	Entry("inverted route table range",
+		crdv1.FelixConfigurationSpec{RouteTableRange: &crdv1.RouteTableRange{Min: 100, Max: 50}}, "min must not be greater than max"),
+	Entry("route table range overlapping the kernel tables",
+		crdv1.FelixConfigurationSpec{RouteTableRange: &crdv1.RouteTableRange{Min: 200, Max: 254}}, "tables 253-255 are reserved"),
+	Entry("route table range including table 0",
+		crdv1.FelixConfigurationSpec{RouteTableRange: &crdv1.RouteTableRange{Min: 0, Max: 10}}, "spec.routeTableRange"),
+	Entry("valid ports",
+		crdv1.FelixConfigurationSpec{HealthPort: ptr.ToPtr(9099), VXLANPort: ptr.ToPtr(4789), WireguardListeningPort: ptr.ToPtr(51820)}, ""),
+	Entry("zero health port",
+		crdv1.FelixConfigurationSpec{HealthPort: ptr.ToPtr(0)}, "spec.healthPort"),
+	Entry("out of range VXLAN port",
+		crdv1.FelixConfigurationSpec{VXLANPort: ptr.ToPtr(65536)}, "spec.vxlanPort"),
+	Entry("valid interface exclusions",
+		crdv1.FelixConfigurationSpec{InterfaceExclude: "kube-ipvs0,/^vf[0-9]+$/"}, ""),
+	Entry("invalid interface exclusion regex",
+		crdv1.FelixConfigurationSpec{InterfaceExclude: "kube-ipvs0,/^vf[0-9+$/"}, "spec.interfaceExclude"),
+	Entry("invalid BPF data interface pattern",
+		crdv1.FelixConfigurationSpec{BPFDataIfacePattern: "^(en.*|eth.*"}, "spec.bpfDataIfacePattern"),
+)
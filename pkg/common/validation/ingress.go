@@ -0,0 +1,51 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common/k8svalidation"
+)
+
+// ValidateManagerIngress validates the options of the Ingress, or Route, that exposes the Manager UI.
+func ValidateManagerIngress(opts *operatorv1.ManagerIngress) error {
+	if opts == nil {
+		return nil
+	}
+
+	if errs := validation.IsDNS1123Subdomain(opts.Host); len(errs) > 0 {
+		return fmt.Errorf("host %q is not a valid domain name: %s", opts.Host, strings.Join(errs, ", "))
+	}
+	if opts.TLSSecretName != "" {
+		if errs := validation.IsDNS1123Subdomain(opts.TLSSecretName); len(errs) > 0 {
+			return fmt.Errorf("tlsSecretName %q is not a valid secret name: %s", opts.TLSSecretName, strings.Join(errs, ", "))
+		}
+	}
+	if opts.IngressClassName != nil {
+		if errs := validation.IsDNS1123Subdomain(*opts.IngressClassName); len(errs) > 0 {
+			return fmt.Errorf("ingressClassName %q is not a valid IngressClass name: %s", *opts.IngressClassName, strings.Join(errs, ", "))
+		}
+	}
+	if err := k8svalidation.ValidateAnnotations(opts.Annotations, field.NewPath("annotations")).ToAggregate(); err != nil {
+		return fmt.Errorf("annotations are invalid: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	opv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+var _ = DescribeTable("Test manager ingress validation",
+	func(opts *opv1.ManagerIngress, expectValid bool) {
+		err := ValidateManagerIngress(opts)
+		if expectValid {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(HaveOccurred())
+		}
+	},
+	Entry("nil options", nil, true),
+	Entry("host only", &opv1.ManagerIngress{Host: "manager.example.com"}, true),
+	Entry("all options",
+		&opv1.ManagerIngress{
+			Host:             "manager.example.com",
+			TLSSecretName:    "manager-ingress-tls",
+			IngressClassName: ptr.ToPtr("nginx"),
+			Annotations:      map[string]string{"nginx.ingress.kubernetes.io/backend-protocol": "HTTPS"},
+		}, true),
+	Entry("missing host", &opv1.ManagerIngress{}, false),
+	Entry("wildcard host", &opv1.ManagerIngress{Host: "*.example.com"}, false),
+	Entry("invalid secret name", &opv1.ManagerIngress{Host: "manager.example.com", TLSSecretName: "Bad_Name"}, false),
+	Entry("invalid ingress class name", &opv1.ManagerIngress{Host: "manager.example.com", IngressClassName: ptr.ToPtr("Bad_Name")}, false),
+	Entry("invalid annotations",
+		&opv1.ManagerIngress{Host: "manager.example.com", Annotations: map[string]string{"bad key!": "value"}}, false),
+)
@@ -92,6 +92,18 @@ func ValidateReplicatedPodResourceOverrides(overrides components.ReplicatedPodRe
 		return fmt.Errorf("spec.Template.Spec.TerminationGracePeriodSeconds is invalid: cannot be negative")
 	}
 
+	dnsPolicy := overrides.GetDNSPolicy()
+	if dnsPolicy != "" {
+		if errs := k8svalidation.ValidateDNSPolicy(&dnsPolicy, field.NewPath("spec", "template", "spec", "dnsPolicy")); errs.ToAggregate() != nil {
+			return fmt.Errorf("spec.Template.Spec.DNSPolicy is invalid: %w", errs.ToAggregate())
+		}
+	}
+	if dnsConfig := overrides.GetDNSConfig(); dnsConfig != nil || dnsPolicy == corev1.DNSNone {
+		if errs := k8svalidation.ValidatePodDNSConfig(dnsConfig, &dnsPolicy, field.NewPath("spec", "template", "spec", "dnsConfig")); errs.ToAggregate() != nil {
+			return fmt.Errorf("spec.Template.Spec.DNSConfig is invalid: %w", errs.ToAggregate())
+		}
+	}
+
 	if st := overrides.GetDeploymentStrategy(); st != nil {
 		if err := k8svalidation.ValidateDeploymentStrategy(st, field.NewPath("spec", "strategy")); err.ToAggregate() != nil {
 			return fmt.Errorf("spec.Strategy is invalid: %w", err.ToAggregate())
@@ -174,6 +174,37 @@ var _ = Describe("Test overrides validation (TyphaDeployment)", func() {
 		Expect(err.Error()).Should(HavePrefix("spec.Template.Spec.TerminationGracePeriodSeconds is invalid: cannot be negative"))
 	})
 
+	It("should accept a dnsConfig", func() {
+		overrides.Spec.Template.Spec.DNSConfig = &corev1.PodDNSConfig{
+			Nameservers: []string{"169.254.20.10"},
+			Searches:    []string{"svc.cluster.local."},
+			Options:     []corev1.PodDNSConfigOption{{Name: "ndots"}},
+		}
+		err := ValidateReplicatedPodResourceOverrides(overrides, typha.ValidateTyphaDeploymentContainer, typha.ValidateTyphaDeploymentInitContainer)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject dnsPolicy=None without nameservers", func() {
+		overrides.Spec.Template.Spec.DNSPolicy = corev1.DNSNone
+		err := ValidateReplicatedPodResourceOverrides(overrides, typha.ValidateTyphaDeploymentContainer, typha.ValidateTyphaDeploymentInitContainer)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).Should(HavePrefix("spec.Template.Spec.DNSConfig is invalid"))
+	})
+
+	It("should reject an invalid dnsPolicy", func() {
+		overrides.Spec.Template.Spec.DNSPolicy = "Custom"
+		err := ValidateReplicatedPodResourceOverrides(overrides, typha.ValidateTyphaDeploymentContainer, typha.ValidateTyphaDeploymentInitContainer)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).Should(HavePrefix("spec.Template.Spec.DNSPolicy is invalid"))
+	})
+
+	It("should reject a dnsConfig with an invalid nameserver", func() {
+		overrides.Spec.Template.Spec.DNSConfig = &corev1.PodDNSConfig{Nameservers: []string{"not-an-ip"}}
+		err := ValidateReplicatedPodResourceOverrides(overrides, typha.ValidateTyphaDeploymentContainer, typha.ValidateTyphaDeploymentInitContainer)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).Should(HavePrefix("spec.Template.Spec.DNSConfig is invalid"))
+	})
+
 	intOrStr := func(v string) *intstr.IntOrString {
 		if v == "" {
 			return nil
@@ -0,0 +1,69 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"net"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ValidateServiceOptions validates the given service options. The enum values of the individual fields are
+// validated by the CRD, so only the combinations of fields are checked here.
+func ValidateServiceOptions(opts *operatorv1.ServiceOptions) error {
+	if opts == nil {
+		return nil
+	}
+
+	serviceType := corev1.ServiceTypeClusterIP
+	if opts.Type != nil {
+		serviceType = *opts.Type
+	}
+
+	if opts.ExternalTrafficPolicy != nil && serviceType != corev1.ServiceTypeNodePort && serviceType != corev1.ServiceTypeLoadBalancer {
+		return fmt.Errorf("externalTrafficPolicy may only be set when type is NodePort or LoadBalancer, not %s", serviceType)
+	}
+	if opts.Headless != nil && *opts.Headless && serviceType != corev1.ServiceTypeClusterIP {
+		return fmt.Errorf("headless may only be set when type is ClusterIP, not %s", serviceType)
+	}
+	if err := validateMetadata(opts.Metadata); err != nil {
+		return fmt.Errorf("metadata is invalid: %w", err)
+	}
+	return nil
+}
+
+// ValidateTunnelServiceOptions validates the options of the Service that managed clusters connect to.
+func ValidateTunnelServiceOptions(opts *operatorv1.TunnelServiceOptions) error {
+	if opts == nil {
+		return nil
+	}
+
+	serviceType := corev1.ServiceTypeNodePort
+	if opts.Type != nil {
+		serviceType = *opts.Type
+	}
+
+	if len(opts.LoadBalancerSourceRanges) > 0 && serviceType != corev1.ServiceTypeLoadBalancer {
+		return fmt.Errorf("loadBalancerSourceRanges may only be set when type is LoadBalancer, not %s", serviceType)
+	}
+	for _, cidr := range opts.LoadBalancerSourceRanges {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("loadBalancerSourceRanges has an invalid CIDR %q: %w", cidr, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	opv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+var _ = DescribeTable("Test service options validation",
+	func(opts *opv1.ServiceOptions, expectValid bool) {
+		err := ValidateServiceOptions(opts)
+		if expectValid {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(HaveOccurred())
+		}
+	},
+	Entry("nil options", nil, true),
+	Entry("empty options", &opv1.ServiceOptions{}, true),
+	Entry("session affinity",
+		&opv1.ServiceOptions{SessionAffinity: ptr.ToPtr(corev1.ServiceAffinityClientIP)}, true),
+	Entry("external traffic policy on a LoadBalancer",
+		&opv1.ServiceOptions{
+			Type:                  ptr.ToPtr(corev1.ServiceTypeLoadBalancer),
+			ExternalTrafficPolicy: ptr.ToPtr(corev1.ServiceExternalTrafficPolicyLocal),
+		}, true),
+	Entry("external traffic policy on a NodePort",
+		&opv1.ServiceOptions{
+			Type:                  ptr.ToPtr(corev1.ServiceTypeNodePort),
+			ExternalTrafficPolicy: ptr.ToPtr(corev1.ServiceExternalTrafficPolicyLocal),
+		}, true),
+	Entry("external traffic policy without a type",
+		&opv1.ServiceOptions{ExternalTrafficPolicy: ptr.ToPtr(corev1.ServiceExternalTrafficPolicyLocal)}, false),
+	Entry("headless ClusterIP",
+		&opv1.ServiceOptions{Type: ptr.ToPtr(corev1.ServiceTypeClusterIP), Headless: ptr.ToPtr(true)}, true),
+	Entry("headless LoadBalancer",
+		&opv1.ServiceOptions{Type: ptr.ToPtr(corev1.ServiceTypeLoadBalancer), Headless: ptr.ToPtr(true)}, false),
+	Entry("non-headless LoadBalancer",
+		&opv1.ServiceOptions{Type: ptr.ToPtr(corev1.ServiceTypeLoadBalancer), Headless: ptr.ToPtr(false)}, true),
+	Entry("load balancer annotations",
+		&opv1.ServiceOptions{
+			Type: ptr.ToPtr(corev1.ServiceTypeLoadBalancer),
+			Metadata: &opv1.Metadata{
+				Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-type": "nlb"},
+			},
+		}, true),
+	Entry("invalid labels",
+		&opv1.ServiceOptions{Metadata: &opv1.Metadata{Labels: map[string]string{"bad key!": "value"}}}, false),
+)
+
+var _ = DescribeTable("Test tunnel service options validation",
+	func(opts *opv1.TunnelServiceOptions, expectValid bool) {
+		err := ValidateTunnelServiceOptions(opts)
+		if expectValid {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(HaveOccurred())
+		}
+	},
+	Entry("nil options", nil, true),
+	Entry("empty options", &opv1.TunnelServiceOptions{}, true),
+	Entry("node port",
+		&opv1.TunnelServiceOptions{Port: ptr.Int32ToPtr(30449)}, true),
+	Entry("source ranges on a LoadBalancer",
+		&opv1.TunnelServiceOptions{
+			Type:                     ptr.ToPtr(corev1.ServiceTypeLoadBalancer),
+			LoadBalancerSourceRanges: []string{"10.0.0.0/8", "fd00::/8"},
+		}, true),
+	Entry("source ranges on a NodePort",
+		&opv1.TunnelServiceOptions{LoadBalancerSourceRanges: []string{"10.0.0.0/8"}}, false),
+	Entry("invalid source range",
+		&opv1.TunnelServiceOptions{
+			Type:                     ptr.ToPtr(corev1.ServiceTypeLoadBalancer),
+			LoadBalancerSourceRanges: []string{"10.0.0.1"},
+		}, false),
+)
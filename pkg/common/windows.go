@@ -29,9 +29,11 @@ const (
 	CalicoWindowsUpgradeResourceName = "calico-windows-upgrade"
 )
 
+// HasWindowsNodes returns true if the cluster has at least one Windows node.
 func HasWindowsNodes(c client.Client) (bool, error) {
+	// Only the existence of a Windows node matters, so don't retrieve more than one.
 	nodes := corev1.NodeList{}
-	err := c.List(context.Background(), &nodes, client.MatchingLabels{"kubernetes.io/os": "windows"})
+	err := c.List(context.Background(), &nodes, client.MatchingLabels{"kubernetes.io/os": "windows"}, client.Limit(1))
 	if err != nil {
 		return false, err
 	}
@@ -155,6 +155,12 @@ var (
 		Registry: "",
 	}
 
+	ComponentSIEMExporter = component{
+		Version:  "master",
+		Image:    "tigera/siem-exporter",
+		Registry: "",
+	}
+
 	ComponentKibana = component{
 		Version:  "master",
 		Image:    "tigera/kibana",
@@ -185,6 +191,12 @@ var (
 		Registry: "",
 	}
 
+	ComponentCloudConnector = component{
+		Version:  "master",
+		Image:    "tigera/cloud-connector",
+		Registry: "",
+	}
+
 	ComponentPolicyRecommendation = component{
 		Version:  "master",
 		Image:    "tigera/policy-recommendation",
@@ -335,11 +347,13 @@ var (
 		ComponentGuardian,
 		ComponentIntrusionDetectionController,
 		ComponentSecurityEventWebhooksProcessor,
+		ComponentSIEMExporter,
 		ComponentKibana,
 		ComponentManager,
 		ComponentDex,
 		ComponentManagerProxy,
 		ComponentPacketCapture,
+		ComponentCloudConnector,
 		ComponentPolicyRecommendation,
 		ComponentEgressGateway,
 		ComponentL7Collector,
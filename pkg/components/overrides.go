@@ -57,4 +57,17 @@ type ReplicatedPodResourceOverrides interface {
 
 	// GetPriorityClassName() returns the value used to override a DaemonSet/Deployment's priorityClassName.
 	GetPriorityClassName() string
+
+	// GetDNSPolicy returns the value used to override a DaemonSet/Deployment's dnsPolicy.
+	GetDNSPolicy() corev1.DNSPolicy
+
+	// GetDNSConfig returns the value used to override a DaemonSet/Deployment's dnsConfig.
+	GetDNSConfig() *corev1.PodDNSConfig
+}
+
+// InitContainerDisablingOverrides is implemented by overrides that can remove init containers from a DaemonSet or
+// Deployment, for init steps that aren't needed in the cluster.
+type InitContainerDisablingOverrides interface {
+	// GetDisabledInitContainers returns the names of the init containers to remove.
+	GetDisabledInitContainers() []string
 }
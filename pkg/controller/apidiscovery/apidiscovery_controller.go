@@ -0,0 +1,90 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apidiscovery
+
+import (
+	"context"
+	"fmt"
+
+	apiregv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+)
+
+// The API discovery controller detects APIs that become available after the operator has started, and wakes up the
+// controllers that are waiting for them so that they add their watches straight away. It watches APIServices, since
+// every API group has one: the projectcalico.org/v3 APIs are served through the APIService of the Calico API server, and
+// the Kubernetes API server registers an APIService for the group of each CustomResourceDefinition.
+
+const ControllerName = "api-discovery-controller"
+
+var log = logf.Log.WithName("controller_apidiscovery")
+
+// Add creates a new API discovery controller and adds it to the Manager.
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	c, err := ctrlruntime.NewController(ControllerName, mgr, controller.Options{Reconciler: &ReconcileAPIDiscovery{}})
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", ControllerName, err)
+	}
+
+	if err = c.WatchObject(&apiregv1.APIService{}, &handler.EnqueueRequestForObject{}, apiServiceAvailablePredicate); err != nil {
+		return fmt.Errorf("%s failed to watch APIService resource: %w", ControllerName, err)
+	}
+	return nil
+}
+
+// apiServiceAvailablePredicate passes the events of APIServices that have become available. The APIServices that are
+// available when the operator starts are passed too, since watches may have been waiting for them since before then.
+var apiServiceAvailablePredicate = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return isAvailable(e.Object.(*apiregv1.APIService))
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return !isAvailable(e.ObjectOld.(*apiregv1.APIService)) && isAvailable(e.ObjectNew.(*apiregv1.APIService))
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		return false
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return false
+	},
+}
+
+func isAvailable(s *apiregv1.APIService) bool {
+	for _, c := range s.Status.Conditions {
+		if c.Type == apiregv1.Available {
+			return c.Status == apiregv1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// ReconcileAPIDiscovery wakes up the controllers that are waiting for an API to become available.
+type ReconcileAPIDiscovery struct{}
+
+func (r *ReconcileAPIDiscovery) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log.V(1).Info("API became available, retrying pending watches", "APIService", request.Name)
+	utils.NotifyAPIsChanged()
+	return reconcile.Result{}, nil
+}
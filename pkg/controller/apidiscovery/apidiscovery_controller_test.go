@@ -0,0 +1,66 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apidiscovery
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	apiregv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/tigera/operator/pkg/controller/utils"
+)
+
+var _ = Describe("API discovery controller", func() {
+	apiService := func(status apiregv1.ConditionStatus) *apiregv1.APIService {
+		return &apiregv1.APIService{
+			ObjectMeta: metav1.ObjectMeta{Name: "v3.projectcalico.org"},
+			Status: apiregv1.APIServiceStatus{
+				Conditions: []apiregv1.APIServiceCondition{{Type: apiregv1.Available, Status: status}},
+			},
+		}
+	}
+
+	It("should only pass the events of APIServices that become available", func() {
+		available, unavailable := apiService(apiregv1.ConditionTrue), apiService(apiregv1.ConditionFalse)
+
+		Expect(apiServiceAvailablePredicate.Create(event.CreateEvent{Object: available})).To(BeTrue())
+		Expect(apiServiceAvailablePredicate.Create(event.CreateEvent{Object: unavailable})).To(BeFalse())
+		Expect(apiServiceAvailablePredicate.Create(event.CreateEvent{Object: &apiregv1.APIService{}})).To(BeFalse())
+
+		Expect(apiServiceAvailablePredicate.Update(event.UpdateEvent{ObjectOld: unavailable, ObjectNew: available})).To(BeTrue())
+		Expect(apiServiceAvailablePredicate.Update(event.UpdateEvent{ObjectOld: available, ObjectNew: available})).To(BeFalse())
+		Expect(apiServiceAvailablePredicate.Update(event.UpdateEvent{ObjectOld: available, ObjectNew: unavailable})).To(BeFalse())
+
+		Expect(apiServiceAvailablePredicate.Delete(event.DeleteEvent{Object: available})).To(BeFalse())
+	})
+
+	It("should wake up the controllers waiting for an API", func() {
+		changed := utils.APIsChanged()
+		Consistently(changed).ShouldNot(BeClosed())
+
+		r := &ReconcileAPIDiscovery{}
+		_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "v3.projectcalico.org"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeClosed())
+		Expect(utils.APIsChanged()).NotTo(BeClosed())
+	})
+})
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -43,10 +44,12 @@ import (
 	"github.com/tigera/operator/pkg/controller/k8sapi"
 	"github.com/tigera/operator/pkg/controller/options"
 	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/upgrade"
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/controller/utils/imageset"
 	"github.com/tigera/operator/pkg/ctrlruntime"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render"
 	rcertificatemanagement "github.com/tigera/operator/pkg/render/certificatemanagement"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
@@ -78,9 +81,9 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		}
 
 		// Watch for changes to Tier, as its status is used as input to determine whether network policy should be reconciled by this controller.
-		go utils.WaitToAddTierWatch(networkpolicy.TigeraComponentTierName, c, k8sClient, log, r.tierWatchReady)
+		go utils.WaitToAddTierWatch(opts.ShutdownContext, networkpolicy.TigeraComponentTierName, c, k8sClient, log, r.tierWatchReady)
 
-		go utils.WaitToAddNetworkPolicyWatches(c, k8sClient, log, []types.NamespacedName{
+		go utils.WaitToAddNetworkPolicyWatches(opts.ShutdownContext, c, k8sClient, log, []types.NamespacedName{
 			{Name: render.APIServerPolicyName, Namespace: rmeta.APIServerNamespace(operatorv1.TigeraSecureEnterprise)},
 		})
 	}
@@ -107,7 +110,7 @@ func newReconciler(mgr manager.Manager, opts options.AddOptions) *ReconcileAPISe
 // add adds watches for resources that are available at startup
 func add(c ctrlruntime.Controller, r *ReconcileAPIServer) error {
 	// Watch for changes to primary resource APIServer
-	err := c.WatchObject(&operatorv1.APIServer{}, &handler.EnqueueRequestForObject{})
+	err := c.WatchObject(&operatorv1.APIServer{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate)
 	if err != nil {
 		log.V(5).Info("Failed to create APIServer watch", "err", err)
 		return fmt.Errorf("apiserver-controller failed to watch primary resource: %v", err)
@@ -124,13 +127,13 @@ func add(c ctrlruntime.Controller, r *ReconcileAPIServer) error {
 
 	if r.enterpriseCRDsExist {
 		// Watch for changes to primary resource ManagementCluster
-		err = c.WatchObject(&operatorv1.ManagementCluster{}, &handler.EnqueueRequestForObject{})
+		err = c.WatchObject(&operatorv1.ManagementCluster{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate)
 		if err != nil {
 			return fmt.Errorf("apiserver-controller failed to watch primary resource: %v", err)
 		}
 
 		// Watch for changes to primary resource ManagementClusterConnection
-		err = c.WatchObject(&operatorv1.ManagementClusterConnection{}, &handler.EnqueueRequestForObject{})
+		err = c.WatchObject(&operatorv1.ManagementClusterConnection{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate)
 		if err != nil {
 			return fmt.Errorf("apiserver-controller failed to watch primary resource: %v", err)
 		}
@@ -144,7 +147,7 @@ func add(c ctrlruntime.Controller, r *ReconcileAPIServer) error {
 		}
 
 		// Watch for changes to authentication
-		err = c.WatchObject(&operatorv1.Authentication{}, &handler.EnqueueRequestForObject{})
+		err = c.WatchObject(&operatorv1.Authentication{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate)
 		if err != nil {
 			return fmt.Errorf("apiserver-controller failed to watch resource: %w", err)
 		}
@@ -203,7 +206,7 @@ type ReconcileAPIServer struct {
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
 func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling APIServer")
 
 	instance, msg, err := utils.GetAPIServer(ctx, r.client)
@@ -252,13 +255,25 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying installation", err, reqLogger)
 		return reconcile.Result{}, err
 	}
+
+	// During an upgrade, the API server is rolled out once the CRDs have been updated and the pre hooks of its stage
+	// have succeeded.
+	installStatus, err := utils.GetInstallationStatus(ctx, r.client)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying installation status", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	if !upgrade.Allowed(installStatus.Upgrade, operatorv1.UpgradeStageAPIServer) {
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for the upgrade to reach the API server", nil, reqLogger)
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
 	if variant == "" {
 		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Installation to be ready", nil, reqLogger)
 		return reconcile.Result{}, nil
 	}
 	ns := rmeta.APIServerNamespace(variant)
 
-	certificateManager, err := certificatemanager.Create(r.client, installationSpec, r.clusterDomain, common.OperatorNamespace())
+	certificateManager, err := certificatemanager.Create(r.client, installationSpec, r.clusterDomain, common.OperatorNamespace(), certificatemanager.WithLogger(reqLogger))
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
 		return reconcile.Result{}, err
@@ -364,7 +379,7 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 	}
 
 	// Create a component handler to manage the rendered component.
-	handler := utils.NewComponentHandler(log, r.client, r.scheme, instance)
+	handler := utils.NewComponentHandler(logging.ForReconcile(ctx, log), r.client, r.scheme, instance)
 
 	// Render the desired objects from the CRD and create or update them.
 	reqLogger.V(3).Info("rendering components")
@@ -404,11 +419,14 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 	// v3 NetworkPolicy will fail to reconcile if the API server deployment is unhealthy. In case the API Server
 	// deployment becomes unhealthy and reconciliation of non-NetworkPolicy resources in the apiserver controller
 	// would resolve it, we render the network policies of components last to prevent a chicken-and-egg scenario.
+	var policyComponent render.Component
+	allComponents := components
 	if includeV3NetworkPolicy {
-		components = append(components, render.APIServerPolicy(&apiServerCfg))
+		policyComponent = render.APIServerPolicy(&apiServerCfg)
+		allComponents = append(allComponents, policyComponent)
 	}
 
-	if err = imageset.ApplyImageSet(ctx, r.client, variant, components...); err != nil {
+	if err = imageset.ApplyImageSet(ctx, r.client, variant, allComponents...); err != nil {
 		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
 		return reconcile.Result{}, err
 	}
@@ -420,6 +438,25 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 		}
 	}
 
+	if policyComponent != nil {
+		// The policies are served by the API server itself. While an upgrade is rolling out, wait for the new replica
+		// set to be ready before writing them, rather than going through a partially upgraded API server.
+		rolling, err := apiServerRollingOut(ctx, r.client, variant)
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error reading API server deployment", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		if rolling {
+			reqLogger.Info("Waiting for the API server rollout to complete before updating its policies")
+			r.status.ClearDegraded()
+			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+		}
+		if err := handler.CreateOrUpdateOrDelete(context.Background(), policyComponent, r.status); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error creating / updating resource", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
 	// Clear the degraded bit if we've reached this far.
 	r.status.ClearDegraded()
 
@@ -436,6 +473,26 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 	return reconcile.Result{}, nil
 }
 
+// apiServerRollingOut returns true if the API server deployment is part way through replacing its pods, i.e. the new
+// replica set isn't yet ready or pods from the previous replica set are still running.
+func apiServerRollingOut(ctx context.Context, c client.Client, variant operatorv1.ProductVariant) (bool, error) {
+	name := "calico-apiserver"
+	if variant == operatorv1.TigeraSecureEnterprise {
+		name = "tigera-apiserver"
+	}
+	d := &appsv1.Deployment{}
+	if err := c.Get(ctx, client.ObjectKey{Name: name, Namespace: rmeta.APIServerNamespace(variant)}, d); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if d.Status.ObservedGeneration < d.Generation {
+		return true, nil
+	}
+	return d.Status.Replicas > d.Status.UpdatedReplicas || d.Status.UpdatedReplicas > d.Status.AvailableReplicas, nil
+}
+
 func validateAPIServerResource(instance *operatorv1.APIServer) error {
 	// Verify the APIServerDeployment overrides, if specified, is valid.
 	if d := instance.Spec.APIServerDeployment; d != nil {
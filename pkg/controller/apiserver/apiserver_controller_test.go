@@ -318,6 +318,41 @@ var _ = Describe("apiserver controller tests", func() {
 			Expect(policies.Items[0].Name).To(Equal("allow-tigera.cnx-apiserver-access"))
 		})
 
+		It("should wait for the API server rollout before updating the allow-tigera policy", func() {
+			Expect(cli.Create(ctx, installation)).To(BeNil())
+			Expect(cli.Create(ctx, &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "tigera-apiserver", Namespace: "tigera-system"},
+				Status:     appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 2},
+			})).To(BeNil())
+
+			r := ReconcileAPIServer{
+				client:              cli,
+				scheme:              scheme,
+				provider:            operatorv1.ProviderNone,
+				enterpriseCRDsExist: true,
+				status:              mockStatus,
+				tierWatchReady:      ready,
+			}
+			result, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(utils.StandardRetry))
+
+			policies := v3.NetworkPolicyList{}
+			Expect(cli.List(ctx, &policies)).ToNot(HaveOccurred())
+			Expect(policies.Items).To(HaveLen(0))
+
+			// Once the new replica set is ready, the policy is written.
+			d := &appsv1.Deployment{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-apiserver", Namespace: "tigera-system"}, d)).To(BeNil())
+			d.Status = appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}
+			Expect(cli.Status().Update(ctx, d)).To(BeNil())
+
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(cli.List(ctx, &policies)).ToNot(HaveOccurred())
+			Expect(policies.Items).To(HaveLen(1))
+		})
+
 		It("should omit allow-tigera policy and not degrade when tier is not ready", func() {
 			Expect(cli.Create(ctx, installation)).To(BeNil())
 			Expect(cli.Delete(ctx, &v3.Tier{ObjectMeta: metav1.ObjectMeta{Name: "allow-tigera"}})).NotTo(HaveOccurred())
@@ -27,6 +27,7 @@ import (
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/controller/utils/imageset"
 	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render"
 	"github.com/tigera/operator/pkg/render/applicationlayer"
 	"github.com/tigera/operator/pkg/render/applicationlayer/embed"
@@ -75,7 +76,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		return err
 	}
 
-	go utils.WaitToAddLicenseKeyWatch(c, k8sClient, log, licenseAPIReady)
+	go utils.WaitToAddLicenseKeyWatch(opts.ShutdownContext, c, k8sClient, log, licenseAPIReady)
 
 	return add(mgr, c)
 }
@@ -99,7 +100,7 @@ func add(mgr manager.Manager, c ctrlruntime.Controller) error {
 	var err error
 
 	// Watch for changes to primary resource applicationlayer.
-	err = c.WatchObject(&operatorv1.ApplicationLayer{}, &handler.EnqueueRequestForObject{})
+	err = c.WatchObject(&operatorv1.ApplicationLayer{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate)
 	if err != nil {
 		return err
 	}
@@ -165,7 +166,7 @@ type ReconcileApplicationLayer struct {
 // Reconcile reads that state of the cluster for a ApplicationLayer object and makes changes
 // based on the state read and what is in the ApplicationLayer.Spec.
 func (r *ReconcileApplicationLayer) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling ApplicationLayer")
 
 	instance, err := getApplicationLayer(ctx, r.client)
@@ -232,6 +233,10 @@ func (r *ReconcileApplicationLayer) Reconcile(ctx context.Context, request recon
 		return reconcile.Result{}, nil
 	}
 
+	if _, result, err := (utils.Prerequisites{UpgradeStage: operatorv1.UpgradeStageComponents}).Check(ctx, r.client, r.status, reqLogger); result != nil {
+		return *result, err
+	}
+
 	if operatorv1.IsFIPSModeEnabled(installation.FIPSMode) {
 		msg := errors.New("ApplicationLayer features cannot be used in combination with FIPSMode=Enabled")
 		r.status.SetDegraded(operatorv1.ResourceValidationError, msg.Error(), nil, reqLogger)
@@ -280,7 +285,7 @@ func (r *ReconcileApplicationLayer) Reconcile(ctx context.Context, request recon
 	}
 	component := applicationlayer.ApplicationLayer(config)
 
-	ch := utils.NewComponentHandler(log, r.client, r.scheme, instance)
+	ch := utils.NewComponentHandler(logging.ForReconcile(ctx, log), r.client, r.scheme, instance)
 
 	if err = imageset.ApplyImageSet(ctx, r.client, variant, component); err != nil {
 		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
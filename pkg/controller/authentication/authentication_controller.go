@@ -17,13 +17,12 @@ package authentication
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 
-	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
-
 	"github.com/go-ldap/ldap"
 	oprv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/common"
@@ -34,6 +33,7 @@ import (
 	"github.com/tigera/operator/pkg/controller/utils/imageset"
 	"github.com/tigera/operator/pkg/ctrlruntime"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render"
 	rcertificatemanagement "github.com/tigera/operator/pkg/render/certificatemanagement"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
@@ -83,8 +83,8 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		return fmt.Errorf("%s failed to establish a connection to k8s: %w", controllerName, err)
 	}
 
-	go utils.WaitToAddTierWatch(networkpolicy.TigeraComponentTierName, c, k8sClient, log, tierWatchReady)
-	go utils.WaitToAddNetworkPolicyWatches(c, k8sClient, log, []types.NamespacedName{
+	go utils.WaitToAddTierWatch(opts.ShutdownContext, networkpolicy.TigeraComponentTierName, c, k8sClient, log, tierWatchReady)
+	go utils.WaitToAddNetworkPolicyWatches(opts.ShutdownContext, c, k8sClient, log, []types.NamespacedName{
 		{Name: render.DexPolicyName, Namespace: render.DexNamespace},
 		{Name: networkpolicy.TigeraComponentDefaultDenyPolicyName, Namespace: render.DexNamespace},
 	})
@@ -170,7 +170,7 @@ type ReconcileAuthentication struct {
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
 func (r *ReconcileAuthentication) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling ", "controller", controllerName)
 
 	// Fetch the Authentication spec. If present, we deploy dex in the cluster.
@@ -234,21 +234,11 @@ func (r *ReconcileAuthentication) Reconcile(ctx context.Context, request reconci
 		return reconcile.Result{}, nil
 	}
 
-	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
-	if !r.tierWatchReady.IsReady() {
-		r.status.SetDegraded(oprv1.ResourceNotReady, "Waiting for Tier watch to be established", nil, reqLogger)
-		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
-	}
-
-	// Ensure the allow-tigera tier exists, before rendering any network policies within it.
-	if err := r.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
-		if errors.IsNotFound(err) {
-			r.status.SetDegraded(oprv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", err, reqLogger)
-			return reconcile.Result{}, nil
-		} else {
-			r.status.SetDegraded(oprv1.ResourceReadError, "Error querying allow-tigera tier", err, reqLogger)
-			return reconcile.Result{}, err
-		}
+	if _, result, err := (utils.Prerequisites{
+		UpgradeStage: oprv1.UpgradeStageComponents,
+		TierWatch:    r.tierWatchReady,
+	}).Check(ctx, r.client, r.status, reqLogger); result != nil {
+		return *result, err
 	}
 
 	// Make sure Authentication and ManagementClusterConnection are not present at the same time.
@@ -263,7 +253,7 @@ func (r *ReconcileAuthentication) Reconcile(ctx context.Context, request reconci
 	}
 
 	// Secret used for TLS between dex and other components.
-	certificateManager, err := certificatemanager.Create(r.client, install, r.clusterDomain, common.OperatorNamespace())
+	certificateManager, err := certificatemanager.Create(r.client, install, r.clusterDomain, common.OperatorNamespace(), certificatemanager.WithLogger(reqLogger))
 	if err != nil {
 		r.status.SetDegraded(oprv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
 		return reconcile.Result{}, err
@@ -312,7 +302,7 @@ func (r *ReconcileAuthentication) Reconcile(ctx context.Context, request reconci
 	dexCfg := render.NewDexConfig(install.CertificateManagement, authentication, dexSecret, idpSecret, r.clusterDomain)
 
 	// Create a component handler to manage the rendered component.
-	hlr := utils.NewComponentHandler(log, r.client, r.scheme, authentication)
+	hlr := utils.NewComponentHandler(logging.ForReconcile(ctx, log), r.client, r.scheme, authentication)
 
 	dexComponentCfg := &render.DexComponentConfiguration{
 		PullSecrets:    pullSecrets,
@@ -462,5 +452,19 @@ func validateAuthentication(authentication *oprv1.Authentication, multiTenant bo
 		}
 	}
 
+	if session := authentication.Spec.Session; session != nil {
+		if session.TokenLifetime != nil {
+			if oidc != nil && oidc.Type == oprv1.OIDCTypeTigera {
+				return fmt.Errorf("the token lifetime is set by the identity provider when Authentication.Spec.OIDC.Type is Tigera, please remove Authentication.Spec.Session.TokenLifetime")
+			}
+			if session.TokenLifetime.Duration < time.Minute {
+				return fmt.Errorf("the token lifetime must be at least 1m, please modify Authentication.Spec.Session.TokenLifetime")
+			}
+		}
+		if session.IdleTimeout != nil && session.IdleTimeout.Duration < time.Minute {
+			return fmt.Errorf("the idle timeout must be at least 1m, please modify Authentication.Spec.Session.IdleTimeout")
+		}
+	}
+
 	return nil
 }
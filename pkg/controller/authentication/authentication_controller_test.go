@@ -630,6 +630,18 @@ var _ = Describe("authentication controller tests", func() {
 		Entry("Expect prompt type to be used without other values", &operatorv1.Authentication{Spec: operatorv1.AuthenticationSpec{OIDC: copyAndAddPromptTypes(oidc, []operatorv1.PromptType{operatorv1.PromptTypeNone})}}, false, true),
 		Entry("Expect prompt type to fail when none is combined", &operatorv1.Authentication{Spec: operatorv1.AuthenticationSpec{OIDC: copyAndAddPromptTypes(oidc, []operatorv1.PromptType{operatorv1.PromptTypeNone, operatorv1.PromptTypeLogin})}}, false, false),
 		Entry("Expect prompt type to be able to be combined", &operatorv1.Authentication{Spec: operatorv1.AuthenticationSpec{OIDC: copyAndAddPromptTypes(oidc, []operatorv1.PromptType{operatorv1.PromptTypeSelectAccount, operatorv1.PromptTypeLogin})}}, false, true),
+		Entry("Expect session settings to pass validation", &operatorv1.Authentication{Spec: operatorv1.AuthenticationSpec{OIDC: oidc, Session: &operatorv1.AuthenticationSession{
+			TokenLifetime: &metav1.Duration{Duration: time.Hour}, IdleTimeout: &metav1.Duration{Duration: 15 * time.Minute}}}}, false, true),
+		Entry("Expect a token lifetime to fail validation for Tigera OIDC", &operatorv1.Authentication{Spec: operatorv1.AuthenticationSpec{
+			OIDC:    &operatorv1.AuthenticationOIDC{IssuerURL: iss, UsernameClaim: "email", Type: operatorv1.OIDCTypeTigera},
+			Session: &operatorv1.AuthenticationSession{TokenLifetime: &metav1.Duration{Duration: time.Hour}}}}, false, false),
+		Entry("Expect an idle timeout to pass validation for Tigera OIDC", &operatorv1.Authentication{Spec: operatorv1.AuthenticationSpec{
+			OIDC:    &operatorv1.AuthenticationOIDC{IssuerURL: iss, UsernameClaim: "email", Type: operatorv1.OIDCTypeTigera},
+			Session: &operatorv1.AuthenticationSession{IdleTimeout: &metav1.Duration{Duration: time.Hour}}}}, true, true),
+		Entry("Expect a short token lifetime to fail validation", &operatorv1.Authentication{Spec: operatorv1.AuthenticationSpec{LDAP: ldap, Session: &operatorv1.AuthenticationSession{
+			TokenLifetime: &metav1.Duration{Duration: time.Second}}}}, false, false),
+		Entry("Expect a short idle timeout to fail validation", &operatorv1.Authentication{Spec: operatorv1.AuthenticationSpec{LDAP: ldap, Session: &operatorv1.AuthenticationSession{
+			IdleTimeout: &metav1.Duration{Duration: time.Second}}}}, false, false),
 	)
 })
 
@@ -70,6 +70,9 @@ type certificateManager struct {
 	log     logr.Logger
 	tenant  *operatorv1.Tenant
 
+	// keyAlgorithm is the algorithm and size of the keys of the certificates that are issued.
+	keyAlgorithm operatorv1.CertificateKeyAlgorithm
+
 	// Controls whether this instance of the certificate manager is allowed to
 	// create new CAs. Most instances should simply read the existing CA and use it to sign
 	// certificates.
@@ -95,6 +98,9 @@ type CertificateManager interface {
 	// It will include:
 	// - A bundle with Calico's root certificates + any user supplied certificates in /etc/pki/tls/certs/tigera-ca-bundle.crt.
 	CreateTrustedBundle(certificates ...certificatemanagement.CertificateInterface) certificatemanagement.TrustedBundle
+	// CreateNamedTrustedBundle creates a TrustedBundle like CreateTrustedBundle, but with its own ConfigMap name, for a
+	// component that only needs to trust some of the certificates of its namespace.
+	CreateNamedTrustedBundle(name string, certificates ...certificatemanagement.CertificateInterface) certificatemanagement.TrustedBundle
 	// CreateTrustedBundleWithSystemRootCertificates creates a TrustedBundle, which provides standardized methods for mounting a bundle of certificates to trust.
 	// It will include:
 	// - A bundle with Calico's root certificates + any user supplied certificates in /etc/pki/tls/certs/tigera-ca-bundle.crt.
@@ -154,7 +160,7 @@ func Create(cli client.Client, installation *operatorv1.InstallationSpec, cluste
 
 	// Create a certificatemanager instance and apply any user-provided options to
 	// initialize it.
-	cm := &certificateManager{log: log}
+	cm := &certificateManager{log: log, keyAlgorithm: tls.KeyAlgorithmOrDefault(nil)}
 	for _, opt := range opts {
 		if err := opt(cm); err != nil {
 			return nil, err
@@ -171,6 +177,7 @@ func Create(cli client.Client, installation *operatorv1.InstallationSpec, cluste
 
 	var certificateManagementEnabled bool
 	if installation != nil {
+		cm.keyAlgorithm = tls.KeyAlgorithmOrDefault(installation.CertificateKeyAlgorithm)
 		imageSet, err := imageset.GetImageSet(context.Background(), cli, installation.Variant)
 		if err != nil {
 			return nil, err
@@ -217,10 +224,17 @@ func Create(cli client.Client, installation *operatorv1.InstallationSpec, cluste
 			cm.log.V(2).Info("No existing CA secret")
 		}
 
-		if len(caSecret.Data) == 0 ||
+		newCA := len(caSecret.Data) == 0 ||
 			len(caSecret.Data[corev1.TLSPrivateKeyKey]) == 0 ||
-			len(caSecret.Data[corev1.TLSCertKey]) == 0 {
+			len(caSecret.Data[corev1.TLSCertKey]) == 0
+		if !newCA && cm.allowCACreation && cm.keyAlgorithmChanged(caSecret.Data[corev1.TLSCertKey]) {
+			// The CA and the certificates that it issued are replaced. The certificates are re-issued once their
+			// authority key id no longer matches the CA.
+			cm.log.Info("The CA has a different key algorithm than configured, generating a new CA", "namespace", ns, "keyAlgorithm", cm.keyAlgorithm)
+			newCA = true
+		}
 
+		if newCA {
 			if !cm.allowCACreation {
 				// Most controllers should NOT allow CA creation. For single-tenant, this is handled at cluster startup by the secret controller.
 				// For multi-tenant clusters, each tenant has its own CA that is created by the tenant controller.
@@ -228,7 +242,7 @@ func Create(cli client.Client, installation *operatorv1.InstallationSpec, cluste
 			}
 			// No existing CA data - we need to generate a new one.
 			cm.log.Info("Generating a new CA", "namespace", ns)
-			cryptoCA, err = tls.MakeCA(rmeta.TigeraOperatorCAIssuerPrefix)
+			cryptoCA, err = tls.MakeCAForKeyAlgorithm(rmeta.TigeraOperatorCAIssuerPrefix, cm.keyAlgorithm)
 			if err != nil {
 				return nil, err
 			}
@@ -286,6 +300,16 @@ func Create(cli client.Client, installation *operatorv1.InstallationSpec, cluste
 	return cm, nil
 }
 
+// keyAlgorithmChanged returns whether the CA was created by the operator with a different key algorithm than the
+// configured one. A CA that was provided by the user is kept as is.
+func (cm *certificateManager) keyAlgorithmChanged(certificatePEM []byte) bool {
+	cert, err := certificatemanagement.ParseCertificate(certificatePEM)
+	if err != nil || cert.Subject.CommonName != rmeta.TigeraOperatorCAIssuerPrefix {
+		return false
+	}
+	return !tls.HasKeyAlgorithm(cert, cm.keyAlgorithm)
+}
+
 func (cm *certificateManager) KeyPair() certificatemanagement.KeyPairInterface {
 	return cm.keyPair
 }
@@ -324,17 +348,33 @@ func (cm *certificateManager) GetOrCreateKeyPair(cli client.Client, secretName,
 	} else if keyPair != nil {
 		err = HasExpectedDNSNames(secretName, secretNamespace, x509Cert, dnsNames)
 		if err == nil {
-			return keyPair, nil
+			// A certificate of the operator also shouldn't keep names that no longer apply, for example after the
+			// cluster domain changed. Re-issuing it changes its hash annotation, which rolls the pods that mount it.
+			stale := staleDNSNames(x509Cert, dnsNames)
+			keyAlgorithmChanged := !tls.HasKeyAlgorithm(x509Cert, cm.keyAlgorithm)
+			renewalDue := time.Now().After(RenewalTime(x509Cert))
+			if keyPair.BYO() || (len(stale) == 0 && !keyAlgorithmChanged && !renewalDue) {
+				return keyPair, nil
+			}
+			if keyAlgorithmChanged {
+				cm.log.Info("KeyPair has a different key algorithm than configured, will create a new one", "namespace", secretNamespace, "name", secretName, "keyAlgorithm", cm.keyAlgorithm)
+			} else if renewalDue {
+				cm.log.Info("KeyPair is due for renewal, will create a new one", "namespace", secretNamespace, "name", secretName, "notAfter", x509Cert.NotAfter)
+			} else {
+				cm.log.Info("KeyPair has DNS names that are no longer expected, will create a new one", "namespace", secretNamespace, "name", secretName, "staleNames", stale)
+			}
 		} else if keyPair.BYO() {
-			cm.log.V(3).Info("secret %s has invalid DNS names, the expected names are: %v", secretName, dnsNames)
+			cm.log.V(3).Info("Secret has invalid DNS names", "namespace", secretNamespace, "name", secretName, "expectedNames", dnsNames)
 			return keyPair, nil
+		} else {
+			cm.log.Info("KeyPair is missing expected DNS names, will create a new one", "namespace", secretNamespace, "name", secretName)
 		}
 	} else if keyPair == nil {
 		cm.log.V(1).Info("Keypair wasn't found, create a new one", "namespace", secretNamespace, "name", secretName)
 	}
 
 	// If we reach here, it means we need to create a new KeyPair.
-	tlsCfg, err := cm.MakeServerCertForDuration(sets.NewString(dnsNames...), tls.DefaultCertificateDuration, tls.SetServerAuth, tls.SetClientAuth)
+	tlsCfg, err := tls.MakeServerCertForKeyAlgorithm(cm.CA, cm.keyAlgorithm, sets.NewString(dnsNames...), tls.DefaultCertificateDuration, tls.SetServerAuth, tls.SetClientAuth)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create signed cert pair: %s", err)
 	}
@@ -501,6 +541,12 @@ func (cm *certificateManager) getKeyPair(cli client.Client, secretName, secretNa
 	}, x509Cert, nil
 }
 
+// RenewalTime returns the time after which the operator re-issues a certificate that it signed. This is once two thirds
+// of its validity period have passed, so that a certificate is replaced well before it expires.
+func RenewalTime(cert *x509.Certificate) time.Time {
+	return cert.NotBefore.Add(cert.NotAfter.Sub(cert.NotBefore) * 2 / 3)
+}
+
 // HasRequiredKeyUsage returns true if the given certificate is valid
 // for use as both a server certificate, as well as a client certificate for mTLS connections.
 func HasRequiredKeyUsage(cert *x509.Certificate, required []x509.ExtKeyUsage) bool {
@@ -557,6 +603,11 @@ func HasExpectedDNSNames(secretName, secretNamespace string, cert *x509.Certific
 	return ErrInvalidCertDNSNames(secretName, secretNamespace)
 }
 
+// staleDNSNames returns the DNS names of the certificate that aren't expected.
+func staleDNSNames(cert *x509.Certificate, expectedDNSNames []string) []string {
+	return sets.NewString(cert.DNSNames...).Difference(sets.NewString(expectedDNSNames...)).List()
+}
+
 // CreateTrustedBundle creates a TrustedBundle, which provides standardized methods for mounting a bundle of certificates to trust.
 // It will include:
 // - A bundle with Calico's root certificates + any user supplied certificates in /etc/pki/tls/certs/tigera-ca-bundle.crt.
@@ -564,6 +615,11 @@ func (cm *certificateManager) CreateTrustedBundle(certificates ...certificateman
 	return certificatemanagement.CreateTrustedBundle(append([]certificatemanagement.CertificateInterface{cm.keyPair}, certificates...)...)
 }
 
+// CreateNamedTrustedBundle creates a TrustedBundle like CreateTrustedBundle, but with its own ConfigMap name.
+func (cm *certificateManager) CreateNamedTrustedBundle(name string, certificates ...certificatemanagement.CertificateInterface) certificatemanagement.TrustedBundle {
+	return certificatemanagement.CreateNamedTrustedBundle(name, append([]certificatemanagement.CertificateInterface{cm.keyPair}, certificates...)...)
+}
+
 // CreateTrustedBundleWithSystemRootCertificates creates a TrustedBundle, which provides standardized methods for mounting a bundle of certificates to trust.
 // It will include:
 // - A bundle with Calico's root certificates + any user supplied certificates in /etc/pki/tls/certs/tigera-ca-bundle.crt.
@@ -20,6 +20,7 @@ package certificatemanager_test
 import (
 	"context"
 	"crypto/x509"
+	"encoding/pem"
 	"runtime"
 	"strings"
 	"time"
@@ -206,6 +207,59 @@ var _ = Describe("Test CertificateManagement suite", func() {
 			Expect(keyPair2.HashAnnotationValue()).NotTo(Equal(keyPair.HashAnnotationValue()))
 		})
 
+		It("should issue the CA and key pairs with the configured key algorithm", func() {
+			alg := operatorv1.CertificateKeyAlgorithmECDSAP256
+			certificateManager, err := certificatemanager.Create(cli, &operatorv1.InstallationSpec{CertificateKeyAlgorithm: &alg}, clusterDomain, common.OperatorNamespace(), certificatemanager.AllowCACreation())
+			Expect(err).NotTo(HaveOccurred())
+			caCert, err := certificatemanagement.ParseCertificate(certificateManager.KeyPair().GetCertificatePEM())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tls.HasKeyAlgorithm(caCert, alg)).To(BeTrue())
+
+			keyPair, err := certificateManager.GetOrCreateKeyPair(cli, appSecretName, appNs, appDNSNames)
+			Expect(err).NotTo(HaveOccurred())
+			cert, err := certificatemanagement.ParseCertificate(keyPair.GetCertificatePEM())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tls.HasKeyAlgorithm(cert, alg)).To(BeTrue())
+			Expect(cert.CheckSignatureFrom(caCert)).NotTo(HaveOccurred())
+
+			By("verifying the CA can be read back from its secret")
+			Expect(cli.Create(ctx, certificateManager.KeyPair().Secret(common.OperatorNamespace()))).NotTo(HaveOccurred())
+			certificateManager2, err := certificatemanager.Create(cli, &operatorv1.InstallationSpec{CertificateKeyAlgorithm: &alg}, clusterDomain, common.OperatorNamespace())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(certificateManager2.KeyPair().GetCertificatePEM()).To(Equal(certificateManager.KeyPair().GetCertificatePEM()))
+		})
+
+		It("should rotate the CA and key pairs when the key algorithm changes", func() {
+			By("storing an RSA 2048 CA and key pair")
+			Expect(cli.Create(ctx, certificateManager.KeyPair().Secret(common.OperatorNamespace()))).NotTo(HaveOccurred())
+			keyPair, err := certificateManager.GetOrCreateKeyPair(cli, appSecretName, appNs, appDNSNames)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.Create(ctx, keyPair.Secret(appNs))).NotTo(HaveOccurred())
+
+			By("verifying a certificate manager that can't create a CA keeps the existing one")
+			alg := operatorv1.CertificateKeyAlgorithmRSA4096
+			withAlg := &operatorv1.InstallationSpec{CertificateKeyAlgorithm: &alg}
+			readOnly, err := certificatemanager.Create(cli, withAlg, clusterDomain, common.OperatorNamespace())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(readOnly.KeyPair().GetCertificatePEM()).To(Equal(certificateManager.KeyPair().GetCertificatePEM()))
+
+			By("verifying the CA is replaced")
+			certificateManager2, err := certificatemanager.Create(cli, withAlg, clusterDomain, common.OperatorNamespace(), certificatemanager.AllowCACreation())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(certificateManager2.KeyPair().GetCertificatePEM()).NotTo(Equal(certificateManager.KeyPair().GetCertificatePEM()))
+			caCert, err := certificatemanagement.ParseCertificate(certificateManager2.KeyPair().GetCertificatePEM())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tls.HasKeyAlgorithm(caCert, alg)).To(BeTrue())
+
+			By("verifying the key pair is re-issued")
+			keyPair2, err := certificateManager2.GetOrCreateKeyPair(cli, appSecretName, appNs, appDNSNames)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keyPair2.HashAnnotationValue()).NotTo(Equal(keyPair.HashAnnotationValue()))
+			cert, err := certificatemanagement.ParseCertificate(keyPair2.GetCertificatePEM())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tls.HasKeyAlgorithm(cert, alg)).To(BeTrue())
+		})
+
 		It("should be able to fetch a key pair if it exists", func() {
 			By("verifying that it returns nil if the key pair does not exist")
 			key, err := certificateManager.GetKeyPair(cli, appSecretName, appNs, nil)
@@ -291,6 +345,26 @@ var _ = Describe("Test CertificateManagement suite", func() {
 				Expect(kp.GetCertificatePEM()).NotTo(Equal(secret.Data[corev1.TLSCertKey]))
 			})
 
+			It("should create a new secret when it is due for renewal", func() {
+				ca, err := crypto.GetCAFromBytes(certificateManager.KeyPair().GetCertificatePEM(), certificateManager.KeyPair().Secret("").Data[corev1.TLSPrivateKeyKey])
+				Expect(err).NotTo(HaveOccurred())
+				modernOpts := []crypto.CertificateExtensionFunc{tls.SetServerAuth, tls.SetClientAuth}
+
+				By("keeping a secret that is not due for renewal yet")
+				s, err := secret.CreateTLSSecret(ca, appSecretName, appNs, corev1.TLSPrivateKeyKey, corev1.TLSCertKey, 2*time.Second, modernOpts, appSecretName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.Create(ctx, s)).NotTo(HaveOccurred())
+				kp, err := certificateManager.GetOrCreateKeyPair(cli, s.Name, s.Namespace, []string{appSecretName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(kp.GetCertificatePEM()).To(Equal(s.Data[corev1.TLSCertKey]))
+
+				By("replacing it once two thirds of its validity period have passed, before it expires")
+				time.Sleep(1500 * time.Millisecond)
+				kp, err = certificateManager.GetOrCreateKeyPair(cli, s.Name, s.Namespace, []string{appSecretName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(kp.GetCertificatePEM()).NotTo(Equal(s.Data[corev1.TLSCertKey]))
+			})
+
 			It("should create a new secret when a legacy operator secret has expired", func() {
 				secret := expiredLegacySecret
 				Expect(cli.Create(ctx, secret)).NotTo(HaveOccurred())
@@ -462,6 +536,33 @@ var _ = Describe("Test CertificateManagement suite", func() {
 			Expect(keyPair.GetIssuer()).NotTo(Equal(certificateManager.KeyPair()))
 		})
 
+		It("replaces a secret that has DNS names that are no longer expected", func() {
+			staleDNSNames := append([]string{"stale-name.cluster.local"}, appDNSNames...)
+			keyPair, err := certificateManager.GetOrCreateKeyPair(cli, appSecretName, appNs, staleDNSNames)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.Create(ctx, keyPair.Secret(appNs))).NotTo(HaveOccurred())
+
+			By("verifying the secret is kept while its names are expected")
+			keyPair2, err := certificateManager.GetOrCreateKeyPair(cli, appSecretName, appNs, staleDNSNames)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keyPair2.HashAnnotationValue()).To(Equal(keyPair.HashAnnotationValue()))
+
+			By("verifying it is re-issued without the stale name")
+			keyPair2, err = certificateManager.GetOrCreateKeyPair(cli, appSecretName, appNs, appDNSNames)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keyPair2.HashAnnotationValue()).NotTo(Equal(keyPair.HashAnnotationValue()))
+			cert, err := certificatemanagement.ParseCertificate(keyPair2.GetCertificatePEM())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cert.DNSNames).To(ConsistOf(appDNSNames))
+
+			By("verifying a BYO secret with extra names is not replaced")
+			Expect(cli.Delete(ctx, keyPair.Secret(appNs))).NotTo(HaveOccurred())
+			Expect(cli.Create(ctx, byoSecret)).NotTo(HaveOccurred())
+			keyPair, err = certificateManager.GetOrCreateKeyPair(cli, appSecretName, appNs, []string{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keyPair.BYO()).To(BeTrue())
+		})
+
 		It("renders the right spec for legacy certs (<= v1.24)", func() {
 			By("creating a legacy secret and then create a KeyPair using the certificateManager")
 			Expect(cli.Create(ctx, legacySecret)).NotTo(HaveOccurred())
@@ -609,6 +710,47 @@ var _ = Describe("Test CertificateManagement suite", func() {
 			Expect(trustedBundle.HashAnnotations()).To(HaveKey("tigera-operator.hash.operator.tigera.io/legacy-secret-wcku"))
 		})
 
+		It("should leave out expired and superseded certificates", func() {
+			modernOpts := []crypto.CertificateExtensionFunc{tls.SetServerAuth, tls.SetClientAuth}
+			expired, err := secret.CreateTLSSecret(nil, "expired-secret", appNs, "key.key", "cert.crt", -time.Hour, modernOpts, "expired")
+			Expect(err).NotTo(HaveOccurred())
+			// Only keep the leaf, since the CA that signed it has not expired.
+			block, _ := pem.Decode(expired.Data["cert.crt"])
+			expired.Data["cert.crt"] = pem.EncodeToMemory(block)
+			old, err := secret.CreateTLSSecret(nil, "byo-secret", appNs, "key.key", "cert.crt", time.Hour, modernOpts, "byo")
+			Expect(err).NotTo(HaveOccurred())
+			current, err := secret.CreateTLSSecret(nil, "byo-secret", appNs, "key.key", "cert.crt", time.Hour, modernOpts, "byo")
+			Expect(err).NotTo(HaveOccurred())
+
+			trustedBundle := certificateManager.CreateTrustedBundle(
+				certificatemanagement.NewCertificate("expired-secret", appNs, expired.Data["cert.crt"], nil),
+				certificatemanagement.NewCertificate("byo-secret", appNs, old.Data["cert.crt"], nil),
+			)
+			trustedBundle.AddCertificates(certificatemanagement.NewCertificate("byo-secret", appNs, current.Data["cert.crt"], nil))
+
+			bundle := trustedBundle.ConfigMap(appNs).Data[certificatemanagement.TrustedCertConfigMapKeyName]
+			Expect(strings.Count(bundle, "certificate name:")).To(Equal(2))
+			Expect(bundle).To(ContainSubstring(string(current.Data["cert.crt"])))
+			Expect(bundle).NotTo(ContainSubstring(string(old.Data["cert.crt"])))
+			Expect(bundle).NotTo(ContainSubstring(string(expired.Data["cert.crt"])))
+		})
+
+		It("should create a bundle with its own name", func() {
+			trustedBundle := certificateManager.CreateNamedTrustedBundle("tigera-ca-bundle-my-app")
+			Expect(trustedBundle.Volume().Name).To(Equal("tigera-ca-bundle-my-app"))
+			Expect(trustedBundle.Volume().ConfigMap.Name).To(Equal("tigera-ca-bundle-my-app"))
+			configMap := trustedBundle.ConfigMap(appNs)
+			Expect(configMap.Name).To(Equal("tigera-ca-bundle-my-app"))
+			Expect(configMap.Annotations).To(HaveKey("tigera-operator.hash.operator.tigera.io/tigera-ca-private"))
+			Expect(trustedBundle.VolumeMounts(rmeta.OSTypeLinux)).To(Equal([]corev1.VolumeMount{
+				{
+					Name:      "tigera-ca-bundle-my-app",
+					MountPath: "/etc/pki/tls/certs",
+					ReadOnly:  true,
+				},
+			}))
+		})
+
 		It("should load the system certificates into the bundle", func() {
 			if runtime.GOOS != "linux" {
 				Skip("Skip for users that run this test outside of a container on incompatible systems.")
@@ -628,6 +770,47 @@ var _ = Describe("Test CertificateManagement suite", func() {
 			Expect(numBlocks > 1).To(BeTrue()) // We expect tens of them most likely.
 		})
 
+		It("should move the system certificates into a ConfigMap of their own when the bundle is too large", func() {
+			if runtime.GOOS != "linux" {
+				Skip("Skip for users that run this test outside of a container on incompatible systems.")
+			}
+			trustedBundle, err := certificateManager.CreateTrustedBundleWithSystemRootCertificates()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(trustedBundle.ConfigMaps(appNs)).To(HaveLen(1))
+			Expect(trustedBundle.Volume().ConfigMap).NotTo(BeNil())
+
+			By("adding certificates that don't fit in one ConfigMap with the system certificates")
+			trustedBundle.AddCertificates(certificatemanagement.NewCertificate("large", appNs, []byte(strings.Repeat("x", corev1.MaxSecretSize-10*1024)), nil))
+			configMaps := trustedBundle.ConfigMaps(appNs)
+			Expect(configMaps).To(HaveLen(2))
+			Expect(configMaps[0].Name).To(Equal("tigera-ca-bundle"))
+			Expect(configMaps[0].Data).To(HaveKey(certificatemanagement.TrustedCertConfigMapKeyName))
+			Expect(configMaps[0].Data).NotTo(HaveKey(certificatemanagement.RHELRootCertificateBundleName))
+			Expect(configMaps[1].Name).To(Equal("tigera-ca-bundle-system"))
+			Expect(configMaps[1].Data[certificatemanagement.RHELRootCertificateBundleName]).To(ContainSubstring("-----BEGIN CERTIFICATE-----"))
+			for _, cm := range configMaps {
+				size := 0
+				for _, v := range cm.Data {
+					size += len(v)
+				}
+				Expect(size).To(BeNumerically("<=", corev1.MaxSecretSize))
+			}
+
+			By("projecting both ConfigMaps into the volume")
+			Expect(trustedBundle.Volume()).To(Equal(corev1.Volume{
+				Name: "tigera-ca-bundle",
+				VolumeSource: corev1.VolumeSource{
+					Projected: &corev1.ProjectedVolumeSource{
+						Sources: []corev1.VolumeProjection{
+							{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "tigera-ca-bundle"}}},
+							{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "tigera-ca-bundle-system"}}},
+						},
+					},
+				},
+			}))
+			Expect(trustedBundle.VolumeMounts(rmeta.OSTypeLinux)).To(HaveLen(2))
+		})
+
 		It("should load the system certificates into a multi-tenant bundle", func() {
 			if runtime.GOOS != "linux" {
 				Skip("Skip for users that run this test outside of a container on incompatible systems.")
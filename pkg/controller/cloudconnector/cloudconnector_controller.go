@@ -0,0 +1,232 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudconnector
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/controller/utils/imageset"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/logging"
+	"github.com/tigera/operator/pkg/render/cloudconnector"
+)
+
+const (
+	ControllerName = "cloud-connector-controller"
+	ResourceName   = "cloud-connector"
+)
+
+var log = logf.Log.WithName("controller_cloud_connector")
+
+// Add creates a new CloudConnector Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	if !opts.EnterpriseCRDExists {
+		// No need to start this controller
+		return nil
+	}
+
+	r := newReconciler(mgr, opts)
+
+	c, err := ctrlruntime.NewController(ControllerName, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", ControllerName, err)
+	}
+
+	if err = c.WatchObject(&operatorv1.CloudConnector{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
+		return fmt.Errorf("%s failed to watch resource: %w", ControllerName, err)
+	}
+
+	if err = utils.AddInstallationWatch(c); err != nil {
+		return fmt.Errorf("%s failed to watch Installation resource: %w", ControllerName, err)
+	}
+
+	// The connector exports its traces if the OperatorConfig asks for it.
+	if err = utils.AddOperatorConfigWatch(c); err != nil {
+		return fmt.Errorf("%s failed to watch OperatorConfig resource: %w", ControllerName, err)
+	}
+
+	// The name of the credentials secret is chosen by the user, so watch every secret in the operator namespace.
+	if err = utils.AddSecretsWatch(c, "", common.OperatorNamespace()); err != nil {
+		return fmt.Errorf("%s failed to watch the Secret resource: %w", ControllerName, err)
+	}
+
+	if err = utils.AddReplicatedSecretsWatch(c, mgr); err != nil {
+		return fmt.Errorf("%s failed to watch replicated secrets: %w", ControllerName, err)
+	}
+
+	if err = imageset.AddImageSetWatch(c); err != nil {
+		return fmt.Errorf("%s failed to watch ImageSet: %w", ControllerName, err)
+	}
+
+	if err = utils.AddTigeraStatusWatch(c, ResourceName); err != nil {
+		return fmt.Errorf("%s failed to watch cloud-connector TigeraStatus: %w", ControllerName, err)
+	}
+
+	log.V(5).Info("Controller created and Watches setup")
+
+	return nil
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, opts options.AddOptions) reconcile.Reconciler {
+	r := &ReconcileCloudConnector{
+		client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		provider: opts.DetectedProvider,
+		status:   status.New(mgr.GetClient(), ResourceName, opts.KubernetesVersion),
+	}
+	r.status.Run(opts.ShutdownContext)
+	return r
+}
+
+// blank assignment to verify that ReconcileCloudConnector implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileCloudConnector{}
+
+// ReconcileCloudConnector reconciles a CloudConnector object
+type ReconcileCloudConnector struct {
+	client   client.Client
+	scheme   *runtime.Scheme
+	provider operatorv1.Provider
+	status   status.StatusManager
+}
+
+// Reconcile reads that state of the cluster for a CloudConnector object and makes changes based on the state read
+// and what is in the CloudConnector.Spec
+func (r *ReconcileCloudConnector) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling CloudConnector")
+
+	cc, err := utils.GetCloudConnector(ctx, r.client)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			reqLogger.V(3).Info("CloudConnector CR not found", "err", err)
+			r.status.OnCRNotFound()
+			return reconcile.Result{}, nil
+		}
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying CloudConnector", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	r.status.OnCRFound()
+	reqLogger.V(2).Info("Loaded config", "config", cc)
+
+	defer r.status.SetMetaData(&cc.ObjectMeta)
+
+	// Changes for updating CloudConnector status conditions.
+	if request.Name == ResourceName && request.Namespace == "" {
+		ts := &operatorv1.TigeraStatus{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: ResourceName}, ts); err != nil {
+			return reconcile.Result{}, err
+		}
+		cc.Status.Conditions = status.UpdateStatusCondition(cc.Status.Conditions, ts.Status.Conditions)
+		if err := r.client.Status().Update(ctx, cc); err != nil {
+			log.WithValues("reason", err).Info("Failed to create cloud connector status conditions.")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if err = cloudconnector.ValidateCloudConnector(cc); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Invalid CloudConnector", err, reqLogger)
+		return reconcile.Result{}, nil
+	}
+
+	variant, installationSpec, err := utils.GetInstallation(ctx, r.client)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying installation", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	if variant != operatorv1.TigeraSecureEnterprise {
+		r.status.SetDegraded(operatorv1.ResourceNotReady, fmt.Sprintf("Waiting for Installation variant to be %s", operatorv1.TigeraSecureEnterprise), nil, reqLogger)
+		return reconcile.Result{}, nil
+	}
+
+	if _, result, err := (utils.Prerequisites{UpgradeStage: operatorv1.UpgradeStageComponents}).Check(ctx, r.client, r.status, reqLogger); result != nil {
+		return *result, err
+	}
+
+	credentials := &corev1.Secret{}
+	key := types.NamespacedName{Name: cc.Spec.CredentialsSecretName, Namespace: common.OperatorNamespace()}
+	if err = r.client.Get(ctx, key, credentials); err != nil {
+		if errors.IsNotFound(err) {
+			r.status.SetDegraded(operatorv1.ResourceNotFound, fmt.Sprintf("Waiting for secret %s/%s", key.Namespace, key.Name), err, reqLogger)
+			return reconcile.Result{}, nil
+		}
+		r.status.SetDegraded(operatorv1.ResourceReadError, fmt.Sprintf("Error reading secret %s/%s", key.Namespace, key.Name), err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	pullSecrets, err := utils.GetNetworkingPullSecrets(installationSpec, r.client)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	component := cloudconnector.CloudConnector(&cloudconnector.Config{
+		PullSecrets:       pullSecrets,
+		OpenShift:         r.provider.IsOpenShift(),
+		Installation:      installationSpec,
+		CloudConnector:    cc,
+		CredentialsSecret: credentials,
+	})
+
+	if err = imageset.ApplyImageSet(ctx, r.client, variant, component); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	handler := utils.NewComponentHandler(logging.ForReconcile(ctx, log), r.client, r.scheme, cc)
+	if err = handler.CreateOrUpdateOrDelete(ctx, component, r.status); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error creating / updating resource", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	// Clear the degraded bit if we've reached this far.
+	r.status.ClearDegraded()
+
+	if !r.status.IsAvailable() {
+		// Schedule a kick to check again in the near future. Hopefully by then things will be available.
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
+
+	// Everything is available - update the CR status.
+	cc.Status.State = operatorv1.TigeraStatusReady
+	if err = r.client.Status().Update(ctx, cc); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudconnector
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render/cloudconnector"
+	"github.com/tigera/operator/test"
+)
+
+var _ = Describe("cloud connector controller tests", func() {
+	var (
+		cli        client.Client
+		scheme     *runtime.Scheme
+		ctx        context.Context
+		mockStatus *status.MockStatus
+		cc         *operatorv1.CloudConnector
+		r          ReconcileCloudConnector
+	)
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(appsv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(rbacv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+
+		ctx = context.Background()
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+
+		Expect(cli.Create(ctx, &operatorv1.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Status: operatorv1.InstallationStatus{
+				Variant:  operatorv1.TigeraSecureEnterprise,
+				Computed: &operatorv1.InstallationSpec{},
+			},
+			Spec: operatorv1.InstallationSpec{
+				Variant:  operatorv1.TigeraSecureEnterprise,
+				Registry: "some.registry.org/",
+			},
+		})).NotTo(HaveOccurred())
+
+		mockStatus = &status.MockStatus{}
+		mockStatus.On("AddDeployments", mock.Anything).Return()
+		mockStatus.On("IsAvailable").Return(true)
+		mockStatus.On("OnCRFound").Return()
+		mockStatus.On("ClearDegraded")
+		mockStatus.On("ReadyToMonitor")
+		mockStatus.On("SetMetaData", mock.Anything).Return()
+
+		r = ReconcileCloudConnector{
+			client:   cli,
+			scheme:   scheme,
+			provider: operatorv1.ProviderNone,
+			status:   mockStatus,
+		}
+
+		cc = &operatorv1.CloudConnector{
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+			Spec: operatorv1.CloudConnectorSpec{
+				Endpoint:              "https://connect.calicocloud.io",
+				CredentialsSecretName: "calico-cloud-credentials",
+			},
+		}
+	})
+
+	It("should do nothing when the CloudConnector doesn't exist", func() {
+		mockStatus.On("OnCRNotFound").Return()
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "OnCRNotFound")
+	})
+
+	It("should render the connector with the credentials from the operator namespace", func() {
+		Expect(cli.Create(ctx, cc)).NotTo(HaveOccurred())
+		Expect(cli.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "calico-cloud-credentials", Namespace: common.OperatorNamespace()},
+			Data:       map[string][]byte{"token": []byte("t0ken")},
+		})).NotTo(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		d := appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: cloudconnector.DeploymentName, Namespace: cloudconnector.Namespace},
+		}
+		Expect(test.GetResource(cli, &d)).To(BeNil())
+		Expect(d.Spec.Template.Spec.Containers).To(HaveLen(1))
+		Expect(d.Spec.Template.Spec.Containers[0].Image).To(Equal("some.registry.org/tigera/cloud-connector:master"))
+
+		s := &corev1.Secret{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: cloudconnector.CredentialsSecretName, Namespace: cloudconnector.Namespace}, s)).NotTo(HaveOccurred())
+		Expect(s.Data).To(HaveKeyWithValue("token", []byte("t0ken")))
+
+		instance, err := utils.GetCloudConnector(ctx, cli)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(instance.Status.State).To(Equal(operatorv1.TigeraStatusReady))
+	})
+
+	It("should degrade until the credentials secret exists", func() {
+		mockStatus.On("SetDegraded", operatorv1.ResourceNotFound, mock.Anything, mock.Anything, mock.Anything).Return()
+		Expect(cli.Create(ctx, cc)).NotTo(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceNotFound, "Waiting for secret tigera-operator/calico-cloud-credentials", mock.Anything, mock.Anything)
+
+		d := appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: cloudconnector.DeploymentName, Namespace: cloudconnector.Namespace},
+		}
+		Expect(test.GetResource(cli, &d)).NotTo(BeNil())
+	})
+
+	It("should degrade when the endpoint isn't https", func() {
+		mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Invalid CloudConnector", mock.Anything, mock.Anything).Return()
+		cc.Spec.Endpoint = "http://connect.calicocloud.io"
+		Expect(cli.Create(ctx, cc)).NotTo(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Invalid CloudConnector", mock.Anything, mock.Anything)
+	})
+})
@@ -0,0 +1,219 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudnetworkset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/logging"
+	"github.com/tigera/operator/pkg/render/cloudnetworkset"
+)
+
+// The CloudNetworkSet controller keeps a GlobalNetworkSet up to date with the IP ranges that a cloud provider
+// publishes for each CloudNetworkSet. Each reconcile handles every CloudNetworkSet, refreshing those that are due and
+// requeueing for the next one that will be. A network set that fails to refresh keeps its IP ranges from the last
+// successful refresh. The GlobalNetworkSets are owned by their CloudNetworkSet, so they're garbage collected with it.
+
+const (
+	ControllerName = "cloudnetworkset-controller"
+
+	// DefaultRefreshInterval is how often a feed is read when the CloudNetworkSet doesn't specify it.
+	DefaultRefreshInterval = 24 * time.Hour
+
+	// retryInterval is how long to wait before reading a feed again after a failure.
+	retryInterval = 5 * time.Minute
+)
+
+var log = logf.Log.WithName("controller_cloudnetworkset")
+
+// Add creates a new CloudNetworkSet Controller and adds it to the Manager.
+// The Manager will set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	r := newReconciler(mgr)
+
+	c, err := ctrlruntime.NewController(ControllerName, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", ControllerName, err)
+	}
+
+	if err = c.WatchObject(&operatorv1.CloudNetworkSet{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
+		return fmt.Errorf("%s failed to watch CloudNetworkSet resource: %w", ControllerName, err)
+	}
+
+	// The GlobalNetworkSets are served by the API server, so the network sets wait for it to be ready.
+	if err = utils.AddAPIServerWatch(c); err != nil {
+		return fmt.Errorf("%s failed to watch APIServer resource: %w", ControllerName, err)
+	}
+
+	return nil
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) *ReconcileCloudNetworkSet {
+	return &ReconcileCloudNetworkSet{
+		client: mgr.GetClient(),
+		scheme: mgr.GetScheme(),
+		fetch:  HTTPFetcher,
+		now:    time.Now,
+	}
+}
+
+var _ reconcile.Reconciler = &ReconcileCloudNetworkSet{}
+
+type ReconcileCloudNetworkSet struct {
+	client client.Client
+	scheme *runtime.Scheme
+	fetch  Fetcher
+	now    func() time.Time
+}
+
+func (r *ReconcileCloudNetworkSet) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.V(1).Info("Reconciling CloudNetworkSets")
+
+	cnsList := &operatorv1.CloudNetworkSetList{}
+	if err := r.client.List(ctx, cnsList); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to list CloudNetworkSets: %w", err)
+	}
+	if len(cnsList.Items) == 0 {
+		return reconcile.Result{}, nil
+	}
+
+	if !utils.IsAPIServerReady(r.client, reqLogger) {
+		for i := range cnsList.Items {
+			r.setCondition(ctx, &cnsList.Items[i], operatorv1.ComponentDegraded, operatorv1.ResourceNotReady, "Waiting for the API server to be ready")
+		}
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
+
+	var requeueAfter time.Duration
+	var errs []error
+	for i := range cnsList.Items {
+		after, err := r.reconcileNetworkSet(ctx, &cnsList.Items[i], reqLogger)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if after > 0 && (requeueAfter == 0 || after < requeueAfter) {
+			requeueAfter = after
+		}
+	}
+	if len(errs) > 0 {
+		return reconcile.Result{}, fmt.Errorf("failed to update GlobalNetworkSets: %v", errs)
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcileNetworkSet refreshes the GlobalNetworkSet of the CloudNetworkSet if it's due, and returns how long until
+// it's due again. Failures to read the feed are reported in the status of the CloudNetworkSet and retried later;
+// failures to write the GlobalNetworkSet are returned.
+func (r *ReconcileCloudNetworkSet) reconcileNetworkSet(ctx context.Context, cns *operatorv1.CloudNetworkSet, reqLogger logr.Logger) (time.Duration, error) {
+	logc := reqLogger.WithValues("CloudNetworkSet", cns.Name)
+	if err := validate(&cns.Spec); err != nil {
+		r.setCondition(ctx, cns, operatorv1.ComponentDegraded, operatorv1.ResourceValidationError, err.Error())
+		return 0, nil
+	}
+
+	now := r.now()
+	interval := refreshInterval(cns)
+	due, err := r.refreshDue(ctx, cns, now, interval)
+	if err != nil {
+		r.setCondition(ctx, cns, operatorv1.ComponentDegraded, operatorv1.ResourceReadError, err.Error())
+		return 0, err
+	}
+	if !due {
+		return cns.Status.LastRefreshTime.Add(interval).Sub(now), nil
+	}
+
+	nets, checksum, err := readFeed(ctx, r.fetch, &cns.Spec)
+	if err != nil {
+		logc.Info("Failed to refresh the network set", "error", err.Error())
+		r.setCondition(ctx, cns, operatorv1.ComponentDegraded, operatorv1.ResourceReadError, err.Error())
+		return min(retryInterval, interval), nil
+	}
+
+	component := cloudnetworkset.CloudNetworkSet(&cloudnetworkset.Config{CloudNetworkSet: cns, Nets: nets})
+	if err = utils.NewComponentHandler(logging.ForReconcile(ctx, log), r.client, r.scheme, cns).CreateOrUpdateOrDelete(ctx, component, nil); err != nil {
+		r.setCondition(ctx, cns, operatorv1.ComponentDegraded, operatorv1.ResourceUpdateError, err.Error())
+		return 0, err
+	}
+	logc.V(1).Info("Refreshed the network set", "nets", len(nets), "checksum", checksum)
+
+	cns.Status.LastRefreshTime = &metav1.Time{Time: now}
+	cns.Status.Checksum = checksum
+	cns.Status.Nets = len(nets)
+	r.setCondition(ctx, cns, operatorv1.ComponentReady, operatorv1.AllObjectsAvailable, fmt.Sprintf("Refreshed %d IP ranges", len(nets)))
+	return interval, nil
+}
+
+// refreshDue returns true if the network set hasn't been refreshed since the CloudNetworkSet last changed, if the
+// refresh interval has passed, or if the GlobalNetworkSet is missing.
+func (r *ReconcileCloudNetworkSet) refreshDue(ctx context.Context, cns *operatorv1.CloudNetworkSet, now time.Time, interval time.Duration) (bool, error) {
+	ready := status.FindStatusCondition(cns.Status.Conditions, operatorv1.ComponentReady)
+	if cns.Status.LastRefreshTime == nil || ready == nil || ready.Status != metav1.ConditionTrue || ready.ObservedGeneration != cns.Generation {
+		return true, nil
+	}
+	if !now.Before(cns.Status.LastRefreshTime.Add(interval)) {
+		return true, nil
+	}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: cns.Name}, &v3.GlobalNetworkSet{}); err != nil {
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to read GlobalNetworkSet %s: %w", cns.Name, err)
+	}
+	return false, nil
+}
+
+// setCondition sets the given status condition of the CloudNetworkSet, sets the other standard conditions to false,
+// and updates its status.
+func (r *ReconcileCloudNetworkSet) setCondition(ctx context.Context, cns *operatorv1.CloudNetworkSet, ctype operatorv1.StatusConditionType, reason operatorv1.TigeraStatusReason, msg string) {
+	for _, t := range []operatorv1.StatusConditionType{operatorv1.ComponentReady, operatorv1.ComponentProgressing, operatorv1.ComponentDegraded} {
+		if t == ctype {
+			status.SetStatusCondition(&cns.Status.Conditions, t, metav1.ConditionTrue, string(reason), msg, cns.Generation)
+		} else {
+			status.SetStatusCondition(&cns.Status.Conditions, t, metav1.ConditionFalse, string(operatorv1.Unknown), "", cns.Generation)
+		}
+	}
+	if err := r.client.Status().Update(ctx, cns); err != nil {
+		log.WithValues("Name", cns.Name, "error", err).Info("Error updating status")
+	}
+}
+
+func refreshInterval(cns *operatorv1.CloudNetworkSet) time.Duration {
+	if cns.Spec.RefreshInterval != nil {
+		return cns.Spec.RefreshInterval.Duration
+	}
+	return DefaultRefreshInterval
+}
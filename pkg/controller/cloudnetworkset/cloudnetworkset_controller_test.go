@@ -0,0 +1,218 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudnetworkset
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/controller/status"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render/cloudnetworkset"
+)
+
+const awsFeed = `{
+  "syncToken": "1700000000",
+  "prefixes": [
+    {"ip_prefix": "3.5.140.0/22", "region": "ap-northeast-2", "service": "AMAZON"},
+    {"ip_prefix": "3.5.140.0/22", "region": "ap-northeast-2", "service": "S3"},
+    {"ip_prefix": "52.216.0.0/15", "region": "us-east-1", "service": "S3"},
+    {"ip_prefix": "18.208.0.0/13", "region": "us-east-1", "service": "EC2"}
+  ],
+  "ipv6_prefixes": [
+    {"ipv6_prefix": "2600:1f68:8000::/39", "region": "us-east-1", "service": "S3"}
+  ]
+}`
+
+const gcpFeed = `{
+  "syncToken": "1700000000",
+  "prefixes": [
+    {"ipv4Prefix": "34.1.208.0/20", "service": "Google Cloud", "scope": "africa-south1"},
+    {"ipv6Prefix": "2600:1900:8000::/44", "service": "Google Cloud", "scope": "us-east1"},
+    {"ipv4Prefix": "34.23.0.0/16", "service": "Google Cloud", "scope": "us-east1"}
+  ]
+}`
+
+var _ = Describe("CloudNetworkSet controller tests", func() {
+	var (
+		r       *ReconcileCloudNetworkSet
+		c       client.Client
+		ctx     context.Context
+		now     time.Time
+		feeds   map[string]string
+		fetched []string
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+
+		now = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+		feeds = map[string]string{AWSFeedURL: awsFeed, GCPFeedURL: gcpFeed}
+		fetched = nil
+		r = &ReconcileCloudNetworkSet{
+			client: c,
+			scheme: scheme,
+			fetch: func(ctx context.Context, url string) ([]byte, error) {
+				fetched = append(fetched, url)
+				data, ok := feeds[url]
+				if !ok {
+					return nil, fmt.Errorf("%s is unavailable", url)
+				}
+				return []byte(data), nil
+			},
+			now: func() time.Time { return now },
+		}
+
+		Expect(c.Create(ctx, &operatorv1.APIServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Status:     operatorv1.APIServerStatus{State: operatorv1.TigeraStatusReady},
+		})).NotTo(HaveOccurred())
+	})
+
+	createCNS := func(name string, spec operatorv1.CloudNetworkSetSpec) {
+		Expect(c.Create(ctx, &operatorv1.CloudNetworkSet{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec})).NotTo(HaveOccurred())
+	}
+
+	getCNS := func(name string) *operatorv1.CloudNetworkSet {
+		cns := &operatorv1.CloudNetworkSet{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: name}, cns)).NotTo(HaveOccurred())
+		return cns
+	}
+
+	getNets := func(name string) []string {
+		gns := &v3.GlobalNetworkSet{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: name}, gns)).NotTo(HaveOccurred())
+		return gns.Spec.Nets
+	}
+
+	reconcileNow := func() reconcile.Result {
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	It("should render the IP ranges of the selected services and regions", func() {
+		createCNS("aws-s3", operatorv1.CloudNetworkSetSpec{Provider: operatorv1.CloudProviderAWS, Services: []string{"s3"}})
+		createCNS("gcp-us-east1", operatorv1.CloudNetworkSetSpec{Provider: operatorv1.CloudProviderGCP, Regions: []string{"us-east1"}})
+
+		result := reconcileNow()
+		Expect(result.RequeueAfter).To(Equal(DefaultRefreshInterval))
+
+		Expect(getNets("aws-s3")).To(Equal([]string{"2600:1f68:8000::/39", "3.5.140.0/22", "52.216.0.0/15"}))
+		Expect(getNets("gcp-us-east1")).To(Equal([]string{"2600:1900:8000::/44", "34.23.0.0/16"}))
+
+		gns := &v3.GlobalNetworkSet{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "aws-s3"}, gns)).NotTo(HaveOccurred())
+		Expect(gns.Labels).To(HaveKeyWithValue(cloudnetworkset.NameLabel, "aws-s3"))
+		Expect(gns.Labels).To(HaveKeyWithValue(cloudnetworkset.ProviderLabel, "aws"))
+		Expect(gns.OwnerReferences).To(HaveLen(1))
+		Expect(gns.OwnerReferences[0].Kind).To(Equal("CloudNetworkSet"))
+
+		cns := getCNS("aws-s3")
+		sum := sha256.Sum256([]byte(awsFeed))
+		Expect(cns.Status.Checksum).To(Equal(hex.EncodeToString(sum[:])))
+		Expect(cns.Status.Nets).To(Equal(3))
+		Expect(cns.Status.LastRefreshTime.Time).To(BeTemporally("==", now))
+		ready := status.FindStatusCondition(cns.Status.Conditions, operatorv1.ComponentReady)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+	})
+
+	It("should only read the feeds again once the refresh interval has passed", func() {
+		createCNS("aws", operatorv1.CloudNetworkSetSpec{
+			Provider:        operatorv1.CloudProviderAWS,
+			RefreshInterval: &metav1.Duration{Duration: time.Hour},
+		})
+		reconcileNow()
+		Expect(fetched).To(HaveLen(1))
+
+		now = now.Add(10 * time.Minute)
+		Expect(reconcileNow().RequeueAfter).To(Equal(50 * time.Minute))
+		Expect(fetched).To(HaveLen(1))
+
+		now = now.Add(50 * time.Minute)
+		Expect(reconcileNow().RequeueAfter).To(Equal(time.Hour))
+		Expect(fetched).To(HaveLen(2))
+	})
+
+	It("should keep the IP ranges of the last refresh when the feed fails to validate", func() {
+		createCNS("aws", operatorv1.CloudNetworkSetSpec{Provider: operatorv1.CloudProviderAWS, Regions: []string{"us-east-1"}})
+		reconcileNow()
+		Expect(getNets("aws")).To(HaveLen(3))
+
+		feeds[AWSFeedURL] = `{"prefixes": [{"ip_prefix": "not-a-cidr", "region": "us-east-1", "service": "S3"}]}`
+		now = now.Add(DefaultRefreshInterval)
+		Expect(reconcileNow().RequeueAfter).To(Equal(retryInterval))
+		Expect(getNets("aws")).To(HaveLen(3))
+
+		cns := getCNS("aws")
+		degraded := status.FindStatusCondition(cns.Status.Conditions, operatorv1.ComponentDegraded)
+		Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+		Expect(degraded.Message).To(ContainSubstring(`invalid IP range "not-a-cidr"`))
+		Expect(cns.Status.LastRefreshTime.Time).To(BeTemporally("==", now.Add(-DefaultRefreshInterval)))
+	})
+
+	It("should reject a feed that doesn't match its checksum", func() {
+		mirror := "https://mirror.example.com/ip-ranges.json"
+		checksumURL := mirror + ".sha256"
+		feeds[mirror] = awsFeed
+		feeds[checksumURL] = "0000000000000000000000000000000000000000000000000000000000000000  ip-ranges.json\n"
+		createCNS("aws", operatorv1.CloudNetworkSetSpec{Provider: operatorv1.CloudProviderAWS, URL: mirror, ChecksumURL: checksumURL})
+
+		reconcileNow()
+		Expect(c.Get(ctx, client.ObjectKey{Name: "aws"}, &v3.GlobalNetworkSet{})).To(HaveOccurred())
+		degraded := status.FindStatusCondition(getCNS("aws").Status.Conditions, operatorv1.ComponentDegraded)
+		Expect(degraded.Message).To(ContainSubstring("doesn't match the checksum"))
+
+		sum := sha256.Sum256([]byte(awsFeed))
+		feeds[checksumURL] = hex.EncodeToString(sum[:]) + "  ip-ranges.json\n"
+		reconcileNow()
+		Expect(getNets("aws")).To(HaveLen(4))
+	})
+
+	It("should not empty the network set when nothing matches", func() {
+		createCNS("aws", operatorv1.CloudNetworkSetSpec{Provider: operatorv1.CloudProviderAWS, Services: []string{"DYNAMODB"}})
+		reconcileNow()
+		Expect(c.Get(ctx, client.ObjectKey{Name: "aws"}, &v3.GlobalNetworkSet{})).To(HaveOccurred())
+		degraded := status.FindStatusCondition(getCNS("aws").Status.Conditions, operatorv1.ComponentDegraded)
+		Expect(degraded.Message).To(ContainSubstring("none of the IP ranges"))
+	})
+
+	It("should reject feeds that aren't read over https", func() {
+		createCNS("aws", operatorv1.CloudNetworkSetSpec{Provider: operatorv1.CloudProviderAWS, URL: "http://mirror.example.com/ip-ranges.json"})
+		reconcileNow()
+		Expect(fetched).To(BeEmpty())
+		degraded := status.FindStatusCondition(getCNS("aws").Status.Conditions, operatorv1.ComponentDegraded)
+		Expect(degraded.Reason).To(Equal(string(operatorv1.ResourceValidationError)))
+	})
+})
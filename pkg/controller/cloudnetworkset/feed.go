@@ -0,0 +1,231 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudnetworkset
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+)
+
+const (
+	AWSFeedURL = "https://ip-ranges.amazonaws.com/ip-ranges.json"
+	GCPFeedURL = "https://www.gstatic.com/ipranges/cloud.json"
+
+	// maxFeedSize limits how much of a feed is read. The feeds of the providers are a few MB at most.
+	maxFeedSize = 32 << 20
+)
+
+// Fetcher returns the content at the URL.
+type Fetcher func(ctx context.Context, url string) ([]byte, error)
+
+// HTTPFetcher reads the content at the URL over HTTP.
+func HTTPFetcher(ctx context.Context, u string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", u, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxFeedSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", u, maxFeedSize)
+	}
+	return data, nil
+}
+
+// feedURL returns the URL of the feed of the CloudNetworkSet.
+func feedURL(spec *operatorv1.CloudNetworkSetSpec) string {
+	if spec.URL != "" {
+		return spec.URL
+	}
+	if spec.Provider == operatorv1.CloudProviderGCP {
+		return GCPFeedURL
+	}
+	return AWSFeedURL
+}
+
+// validate returns an error if the spec of the CloudNetworkSet is invalid.
+func validate(spec *operatorv1.CloudNetworkSetSpec) error {
+	switch spec.Provider {
+	case operatorv1.CloudProviderAWS, operatorv1.CloudProviderGCP:
+	default:
+		return fmt.Errorf("provider %q is not supported", spec.Provider)
+	}
+	for field, u := range map[string]string{"url": spec.URL, "checksumURL": spec.ChecksumURL} {
+		if u == "" {
+			continue
+		}
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return fmt.Errorf("%s %q is invalid: %w", field, u, err)
+		}
+		if parsed.Scheme != "https" || parsed.Host == "" {
+			return fmt.Errorf("%s %q must be an https URL", field, u)
+		}
+	}
+	if spec.RefreshInterval != nil && spec.RefreshInterval.Duration < time.Minute {
+		return fmt.Errorf("refreshInterval %s is shorter than the minimum of 1m", spec.RefreshInterval.Duration)
+	}
+	return nil
+}
+
+// readFeed reads the feed of the CloudNetworkSet and returns the IP ranges that match its services and regions, along
+// with the SHA-256 checksum of the feed. The feed is rejected if it doesn't match the checksum that ChecksumURL
+// serves, if any of its IP ranges is invalid, or if none of them match, so that a broken feed doesn't empty the set.
+func readFeed(ctx context.Context, fetch Fetcher, spec *operatorv1.CloudNetworkSetSpec) ([]string, string, error) {
+	u := feedURL(spec)
+	data, err := fetch(ctx, u)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read %s: %w", u, err)
+	}
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+
+	if spec.ChecksumURL != "" {
+		expected, err := fetch(ctx, spec.ChecksumURL)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read checksum %s: %w", spec.ChecksumURL, err)
+		}
+		// sha256sum writes the checksum followed by the file name.
+		fields := strings.Fields(string(expected))
+		if len(fields) == 0 || !strings.EqualFold(fields[0], checksum) {
+			return nil, "", fmt.Errorf("%s doesn't match the checksum from %s", u, spec.ChecksumURL)
+		}
+	}
+
+	var prefixes []prefix
+	switch spec.Provider {
+	case operatorv1.CloudProviderGCP:
+		prefixes, err = parseGCP(data)
+	default:
+		prefixes, err = parseAWS(data)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s: %w", u, err)
+	}
+
+	seen := map[string]bool{}
+	nets := []string{}
+	for _, p := range prefixes {
+		if _, _, err := net.ParseCIDR(p.cidr); err != nil {
+			return nil, "", fmt.Errorf("%s has an invalid IP range %q", u, p.cidr)
+		}
+		if !matches(spec.Services, p.service) || !matches(spec.Regions, p.region) || seen[p.cidr] {
+			continue
+		}
+		seen[p.cidr] = true
+		nets = append(nets, p.cidr)
+	}
+	if len(nets) == 0 {
+		return nil, "", fmt.Errorf("none of the IP ranges in %s match the services %v and regions %v", u, spec.Services, spec.Regions)
+	}
+	sort.Strings(nets)
+	return nets, checksum, nil
+}
+
+// prefix is an IP range in a feed, with the service and region it belongs to.
+type prefix struct {
+	cidr    string
+	service string
+	region  string
+}
+
+// parseAWS parses the ip-ranges.json format. AWS lists some ranges under both the AMAZON service and a more specific
+// service.
+func parseAWS(data []byte) ([]prefix, error) {
+	var feed struct {
+		Prefixes []struct {
+			IPPrefix string `json:"ip_prefix"`
+			Region   string `json:"region"`
+			Service  string `json:"service"`
+		} `json:"prefixes"`
+		IPv6Prefixes []struct {
+			IPv6Prefix string `json:"ipv6_prefix"`
+			Region     string `json:"region"`
+			Service    string `json:"service"`
+		} `json:"ipv6_prefixes"`
+	}
+	if err := json.Unmarshal(data, &feed); err != nil {
+		return nil, err
+	}
+	var prefixes []prefix
+	for _, p := range feed.Prefixes {
+		prefixes = append(prefixes, prefix{cidr: p.IPPrefix, service: p.Service, region: p.Region})
+	}
+	for _, p := range feed.IPv6Prefixes {
+		prefixes = append(prefixes, prefix{cidr: p.IPv6Prefix, service: p.Service, region: p.Region})
+	}
+	return prefixes, nil
+}
+
+// parseGCP parses the cloud.json and goog.json formats. The ranges in goog.json have no service or scope.
+func parseGCP(data []byte) ([]prefix, error) {
+	var feed struct {
+		Prefixes []struct {
+			IPv4Prefix string `json:"ipv4Prefix"`
+			IPv6Prefix string `json:"ipv6Prefix"`
+			Service    string `json:"service"`
+			Scope      string `json:"scope"`
+		} `json:"prefixes"`
+	}
+	if err := json.Unmarshal(data, &feed); err != nil {
+		return nil, err
+	}
+	var prefixes []prefix
+	for _, p := range feed.Prefixes {
+		cidr := p.IPv4Prefix
+		if cidr == "" {
+			cidr = p.IPv6Prefix
+		}
+		prefixes = append(prefixes, prefix{cidr: cidr, service: p.Service, region: p.Scope})
+	}
+	return prefixes, nil
+}
+
+// matches returns true if the value is one of the wanted values, ignoring case, or if there are no wanted values.
+func matches(wanted []string, value string) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+	for _, w := range wanted {
+		if strings.EqualFold(w, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -44,6 +44,7 @@ import (
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/controller/utils/imageset"
 	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/monitor"
@@ -83,10 +84,10 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	}
 
 	// Watch for changes to License and Tier, as their status is used as input to determine whether network policy should be reconciled by this controller.
-	go utils.WaitToAddLicenseKeyWatch(c, k8sClient, log, nil)
-	go utils.WaitToAddTierWatch(networkpolicy.TigeraComponentTierName, c, k8sClient, log, tierWatchReady)
+	go utils.WaitToAddLicenseKeyWatch(opts.ShutdownContext, c, k8sClient, log, nil)
+	go utils.WaitToAddTierWatch(opts.ShutdownContext, networkpolicy.TigeraComponentTierName, c, k8sClient, log, tierWatchReady)
 
-	go utils.WaitToAddNetworkPolicyWatches(c, k8sClient, log, []types.NamespacedName{
+	go utils.WaitToAddNetworkPolicyWatches(opts.ShutdownContext, c, k8sClient, log, []types.NamespacedName{
 		{Name: render.GuardianPolicyName, Namespace: render.GuardianNamespace},
 		{Name: networkpolicy.TigeraComponentDefaultDenyPolicyName, Namespace: render.GuardianNamespace},
 	})
@@ -129,13 +130,13 @@ func newReconciler(
 // add adds a new controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, c ctrlruntime.Controller) error {
 	// Watch for changes to primary resource ManagementCluster
-	err := c.WatchObject(&operatorv1.ManagementCluster{}, &handler.EnqueueRequestForObject{})
+	err := c.WatchObject(&operatorv1.ManagementCluster{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate)
 	if err != nil {
 		return fmt.Errorf("%s failed to watch primary resource: %w", controllerName, err)
 	}
 
 	// Watch for changes to primary resource ManagementClusterConnection
-	err = c.WatchObject(&operatorv1.ManagementClusterConnection{}, &handler.EnqueueRequestForObject{})
+	err = c.WatchObject(&operatorv1.ManagementClusterConnection{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate)
 	if err != nil {
 		return fmt.Errorf("%s failed to watch primary resource: %w", controllerName, err)
 	}
@@ -158,6 +159,10 @@ func add(mgr manager.Manager, c ctrlruntime.Controller) error {
 		return fmt.Errorf("%s failed to watch Secret resource %s: %w", controllerName, certificatemanagement.CASecretName, err)
 	}
 
+	if err = utils.AddReplicatedSecretsWatch(c, mgr); err != nil {
+		return fmt.Errorf("%s failed to watch replicated secrets: %w", controllerName, err)
+	}
+
 	if err = utils.AddInstallationWatch(c); err != nil {
 		return fmt.Errorf("%s failed to watch Installation resource: %w", controllerName, err)
 	}
@@ -192,7 +197,7 @@ type ReconcileConnection struct {
 // processed again if the returned error is non-nil or Result.Requeue is true, otherwise upon completion it will
 // remove the work from the queue.
 func (r *ReconcileConnection) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling the management cluster connection")
 	result := reconcile.Result{}
 
@@ -201,6 +206,10 @@ func (r *ReconcileConnection) Reconcile(ctx context.Context, request reconcile.R
 		return result, err
 	}
 
+	if _, result, err := (utils.Prerequisites{UpgradeStage: operatorv1.UpgradeStageComponents}).Check(ctx, r.Client, r.status, reqLogger); result != nil {
+		return *result, err
+	}
+
 	managementCluster, err := utils.GetManagementCluster(ctx, r.Client)
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Error reading ManagementCluster", err, reqLogger)
@@ -252,13 +261,20 @@ func (r *ReconcileConnection) Reconcile(ctx context.Context, request reconcile.R
 
 	log.V(2).Info("Loaded ManagementClusterConnection config", "config", managementClusterConnection)
 
+	for _, addr := range managementClusterConnection.Spec.FailoverManagementClusterAddrs {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("Invalid failover management cluster address %q", addr), err, reqLogger)
+			return reconcile.Result{}, nil
+		}
+	}
+
 	pullSecrets, err := utils.GetNetworkingPullSecrets(instl, r.Client)
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", err, reqLogger)
 		return result, err
 	}
 
-	certificateManager, err := certificatemanager.Create(r.Client, instl, r.clusterDomain, common.OperatorNamespace())
+	certificateManager, err := certificatemanager.Create(r.Client, instl, r.clusterDomain, common.OperatorNamespace(), certificatemanager.WithLogger(reqLogger))
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
 		return reconcile.Result{}, err
@@ -325,7 +341,7 @@ func (r *ReconcileConnection) Reconcile(ctx context.Context, request reconcile.R
 
 	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
 	if !r.tierWatchReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", nil, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", r.tierWatchReady.Err(), reqLogger)
 		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 	}
 
@@ -363,9 +379,10 @@ func (r *ReconcileConnection) Reconcile(ctx context.Context, request reconcile.R
 		}
 	}
 
-	ch := utils.NewComponentHandler(log, r.Client, r.Scheme, managementClusterConnection)
+	ch := utils.NewComponentHandler(logging.ForReconcile(ctx, log), r.Client, r.Scheme, managementClusterConnection)
 	guardianCfg := &render.GuardianConfiguration{
 		URL:                         managementClusterConnection.Spec.ManagementClusterAddr,
+		FailoverURLs:                managementClusterConnection.Spec.FailoverManagementClusterAddrs,
 		TunnelCAType:                managementClusterConnection.Spec.TLS.CA,
 		PullSecrets:                 pullSecrets,
 		OpenShift:                   r.Provider.IsOpenShift(),
@@ -432,11 +449,17 @@ func networkPolicyRequiresEgressAccessControl(connection *operatorv1.ManagementC
 	}
 }
 
+// managementClusterAddrHasDomain returns whether any of the addresses that Guardian may connect to is a domain name.
 func managementClusterAddrHasDomain(connection *operatorv1.ManagementClusterConnection) (bool, error) {
-	host, _, err := net.SplitHostPort(connection.Spec.ManagementClusterAddr)
-	if err != nil {
-		return false, err
+	addrs := append([]string{connection.Spec.ManagementClusterAddr}, connection.Spec.FailoverManagementClusterAddrs...)
+	for _, addr := range addrs {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return false, err
+		}
+		if net.ParseIP(host) == nil {
+			return true, nil
+		}
 	}
-
-	return net.ParseIP(host) == nil, nil
+	return false, nil
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -160,6 +161,32 @@ var _ = Describe("ManagementClusterConnection controller tests", func() {
 		})
 	})
 
+	Context("failover management cluster addresses", func() {
+		It("should configure Guardian to fail over to the additional addresses", func() {
+			cfg.Spec.FailoverManagementClusterAddrs = []string{"127.0.0.2:12345", "backup.example.com:9449"}
+			Expect(c.Update(ctx, cfg)).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(test.GetResource(c, dpl)).To(BeNil())
+			guardian := test.GetContainer(dpl.Spec.Template.Spec.Containers, render.GuardianDeploymentName)
+			Expect(guardian).ToNot(BeNil())
+			Expect(guardian.Env).To(ContainElement(corev1.EnvVar{Name: "GUARDIAN_VOLTRON_URL", Value: "127.0.0.1:12345"}))
+			Expect(guardian.Env).To(ContainElement(corev1.EnvVar{Name: "GUARDIAN_VOLTRON_FAILOVER_URLS", Value: "127.0.0.2:12345,backup.example.com:9449"}))
+		})
+
+		It("should degrade when a failover address has no port", func() {
+			cfg.Spec.FailoverManagementClusterAddrs = []string{"backup.example.com"}
+			Expect(c.Update(ctx, cfg)).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, `Invalid failover management cluster address "backup.example.com"`, mock.Anything, mock.Anything)
+			Expect(c.Get(ctx, client.ObjectKey{Name: render.GuardianDeploymentName, Namespace: render.GuardianNamespace}, dpl)).To(HaveOccurred())
+		})
+	})
+
 	Context("image reconciliation", func() {
 		It("should use builtin images", func() {
 			r = clusterconnection.NewReconcilerWithShims(c, scheme, mockStatus, operatorv1.ProviderNone, ready)
@@ -17,14 +17,18 @@ package compliance
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -34,6 +38,7 @@ import (
 
 	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/common/validation"
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
 	"github.com/tigera/operator/pkg/controller/options"
 	"github.com/tigera/operator/pkg/controller/status"
@@ -42,9 +47,11 @@ import (
 	"github.com/tigera/operator/pkg/controller/utils/imageset"
 	"github.com/tigera/operator/pkg/ctrlruntime"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render"
 	rcertificatemanagement "github.com/tigera/operator/pkg/render/certificatemanagement"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/siemexport"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 )
 
@@ -52,6 +59,9 @@ const ResourceName = "compliance"
 
 var log = logf.Log.WithName("controller_compliance")
 
+// cisCheckNumber matches the number of a section or check of the CIS benchmark, for example 4 or 4.2.6.
+var cisCheckNumber = regexp.MustCompile(`^[0-9]+(\.[0-9]+)*$`)
+
 // Add creates a new Compliance Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager, opts options.AddOptions) error {
@@ -66,7 +76,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	reconciler := newReconciler(mgr, opts, licenseAPIReady, tierWatchReady)
 
 	// Create a new controller
-	complianceController, err := ctrlruntime.NewController("compliance-controller", mgr, controller.Options{Reconciler: reconciler})
+	complianceController, err := ctrlruntime.NewController("compliance-controller", mgr, utils.ControllerOptions("compliance-controller", reconciler, opts))
 	if err != nil {
 		return err
 	}
@@ -76,7 +86,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	var eventHandler handler.EventHandler = &handler.EnqueueRequestForObject{}
 	if opts.MultiTenant {
 		eventHandler = utils.EnqueueAllTenants(mgr.GetClient())
-		if err = complianceController.WatchObject(&operatorv1.Tenant{}, &handler.EnqueueRequestForObject{}); err != nil {
+		if err = complianceController.WatchObject(&operatorv1.Tenant{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
 			return fmt.Errorf("compliance-controller failed to watch Tenant resource: %w", err)
 		}
 	}
@@ -89,30 +99,31 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 
 	installNS, _, watchNamespaces := tenancy.GetWatchNamespaces(opts.MultiTenant, render.PolicyRecommendationNamespace)
 
-	go utils.WaitToAddLicenseKeyWatch(complianceController, k8sClient, log, licenseAPIReady)
+	go utils.WaitToAddLicenseKeyWatch(opts.ShutdownContext, complianceController, k8sClient, log, licenseAPIReady)
 
-	go utils.WaitToAddTierWatch(networkpolicy.TigeraComponentTierName, complianceController, k8sClient, log, tierWatchReady)
-	go utils.WaitToAddNetworkPolicyWatches(complianceController, k8sClient, log, []types.NamespacedName{
+	go utils.WaitToAddTierWatch(opts.ShutdownContext, networkpolicy.TigeraComponentTierName, complianceController, k8sClient, log, tierWatchReady)
+	go utils.WaitToAddNetworkPolicyWatches(opts.ShutdownContext, complianceController, k8sClient, log, []types.NamespacedName{
 		{Name: render.ComplianceAccessPolicyName, Namespace: installNS},
 		{Name: render.ComplianceServerPolicyName, Namespace: installNS},
+		{Name: siemexport.PolicyName, Namespace: installNS},
 		{Name: networkpolicy.TigeraComponentDefaultDenyPolicyName, Namespace: installNS},
 	})
 
 	// Watch for changes to primary resource Compliance
-	err = complianceController.WatchObject(&operatorv1.Compliance{}, &handler.EnqueueRequestForObject{})
+	err = complianceController.WatchObject(&operatorv1.Compliance{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate)
 	if err != nil {
 		return err
 	}
 
-	if err = complianceController.WatchObject(&operatorv1.Installation{}, eventHandler); err != nil {
+	if err = complianceController.WatchObject(&operatorv1.Installation{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("compliance-controller failed to watch Installation resource: %w", err)
 	}
 
-	if err = complianceController.WatchObject(&operatorv1.ImageSet{}, eventHandler); err != nil {
+	if err = complianceController.WatchObject(&operatorv1.ImageSet{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("compliance-controller failed to watch ImageSet: %w", err)
 	}
 
-	if err = complianceController.WatchObject(&operatorv1.APIServer{}, eventHandler); err != nil {
+	if err = complianceController.WatchObject(&operatorv1.APIServer{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("compliance-controller failed to watch APIServer resource: %w", err)
 	}
 
@@ -129,17 +140,22 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		}
 	}
 
+	// Watch the sources of the secrets copied into the compliance namespace, so that the copies are kept in sync.
+	if err = utils.AddReplicatedSecretsWatch(complianceController, mgr); err != nil {
+		return fmt.Errorf("compliance-controller failed to watch replicated secrets: %w", err)
+	}
+
 	// Watch for changes to primary resource ManagementCluster
-	if err = complianceController.WatchObject(&operatorv1.ManagementCluster{}, eventHandler); err != nil {
+	if err = complianceController.WatchObject(&operatorv1.ManagementCluster{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("compliance-controller failed to watch primary resource: %w", err)
 	}
 
 	// Watch for changes to primary resource ManagementClusterConnection
-	if err = complianceController.WatchObject(&operatorv1.ManagementClusterConnection{}, eventHandler); err != nil {
+	if err = complianceController.WatchObject(&operatorv1.ManagementClusterConnection{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("compliance-controller failed to watch primary resource: %w", err)
 	}
 
-	if err = complianceController.WatchObject(&operatorv1.Authentication{}, eventHandler); err != nil {
+	if err = complianceController.WatchObject(&operatorv1.Authentication{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("compliance-controller failed to watch resource: %w", err)
 	}
 
@@ -148,21 +164,36 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		return fmt.Errorf("compliance-controller failed to watch compliance Tigerastatus: %w", err)
 	}
 
+	// Watch the secrets that the reconciler reads, such as the credentials of the SIEM export.
+	if err = reconciler.dependencyTracker.Start(complianceController); err != nil {
+		return fmt.Errorf("compliance-controller failed to watch its dependencies: %w", err)
+	}
+
 	return nil
 }
 
 // newReconciler returns a new *reconcile.Reconciler
-func newReconciler(mgr manager.Manager, opts options.AddOptions, licenseAPIReady *utils.ReadyFlag, tierWatchReady *utils.ReadyFlag) reconcile.Reconciler {
+func newReconciler(mgr manager.Manager, opts options.AddOptions, licenseAPIReady *utils.ReadyFlag, tierWatchReady *utils.ReadyFlag) *ReconcileCompliance {
+	// The secrets that the reconciler reads without watching them explicitly, such as the credentials of the SIEM
+	// export, live in the truth and install namespaces. In multi-tenant clusters, changes to them need to reach every
+	// tenant's Compliance.
+	var trackerHandler handler.EventHandler = &handler.EnqueueRequestForObject{}
+	if opts.MultiTenant {
+		trackerHandler = utils.EnqueueTenantInstances(mgr.GetClient(), &operatorv1.ComplianceList{})
+	}
+	helper := utils.NewNamespaceHelper(opts.MultiTenant, render.ComplianceNamespace, "")
+	tracker := utils.NewDependencyTracker(trackerHandler, helper.TruthNamespace(), helper.InstallNamespace())
 	r := &ReconcileCompliance{
-		client:          mgr.GetClient(),
-		scheme:          mgr.GetScheme(),
-		provider:        opts.DetectedProvider,
-		status:          status.New(mgr.GetClient(), "compliance", opts.KubernetesVersion),
-		clusterDomain:   opts.ClusterDomain,
-		licenseAPIReady: licenseAPIReady,
-		tierWatchReady:  tierWatchReady,
-		multiTenant:     opts.MultiTenant,
-		externalElastic: opts.ElasticExternal,
+		client:            tracker.Client(mgr.GetClient()),
+		scheme:            mgr.GetScheme(),
+		provider:          opts.DetectedProvider,
+		status:            status.New(mgr.GetClient(), "compliance", opts.KubernetesVersion),
+		clusterDomain:     opts.ClusterDomain,
+		licenseAPIReady:   licenseAPIReady,
+		tierWatchReady:    tierWatchReady,
+		multiTenant:       opts.MultiTenant,
+		externalElastic:   opts.ElasticExternal,
+		dependencyTracker: tracker,
 	}
 	r.status.Run(opts.ShutdownContext)
 	return r
@@ -184,6 +215,9 @@ type ReconcileCompliance struct {
 	tierWatchReady  *utils.ReadyFlag
 	multiTenant     bool
 	externalElastic bool
+
+	// dependencyTracker watches the secrets read through the client.
+	dependencyTracker *utils.DependencyTracker
 }
 
 func GetCompliance(ctx context.Context, cli client.Client, mt bool, ns string) (*operatorv1.Compliance, error) {
@@ -206,7 +240,7 @@ func GetCompliance(ctx context.Context, cli client.Client, mt bool, ns string) (
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
 func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	helper := utils.NewNamespaceHelper(r.multiTenant, render.ComplianceNamespace, request.Namespace)
-	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name, "installNS", helper.InstallNamespace(), "truthNS", helper.TruthNamespace())
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name, "installNS", helper.InstallNamespace(), "truthNS", helper.TruthNamespace())
 	reqLogger.Info("Reconciling Compliance")
 
 	// We skip requests without a namespace specified in multi-tenant setups.
@@ -252,49 +286,30 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 			return reconcile.Result{}, err
 		}
 		instance.Status.Conditions = status.UpdateStatusCondition(instance.Status.Conditions, ts.Status.Conditions)
+		instance.Status.ObservedGeneration = status.ObservedGeneration(ts.Status.Conditions)
+		instance.Status.Rollout = r.status.RolloutStatus()
 		if err := r.client.Status().Update(ctx, instance); err != nil {
 			log.WithValues("reason", err).Info("Failed to create Compliance status conditions.")
 			return reconcile.Result{}, err
 		}
 	}
 
-	if !utils.IsAPIServerReady(r.client, reqLogger) {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tigera API server to be ready", nil, reqLogger)
+	if err = validation.ValidateServiceOptions(instance.Spec.ComplianceServerService); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Compliance spec.complianceServerService is not valid", err, reqLogger)
 		return reconcile.Result{}, err
 	}
 
-	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
-	if !r.tierWatchReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", err, reqLogger)
-		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
-	}
-
-	// Ensure the allow-tigera tier exists, before rendering any network policies within it.
-	if err := r.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
-		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", err, reqLogger)
-			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
-		} else {
-			log.Error(err, "Error querying allow-tigera tier")
-			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying allow-tigera tier", err, reqLogger)
-			return reconcile.Result{}, err
-		}
-	}
-
-	if !r.licenseAPIReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for LicenseKeyAPI to be ready", nil, reqLogger)
-		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
-	}
-
-	license, err := utils.FetchLicenseKey(ctx, r.client)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "License not found", err, reqLogger)
-			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
-		}
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying license", err, reqLogger)
-		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	prereqs, result, err := utils.Prerequisites{
+		UpgradeStage: operatorv1.UpgradeStageComponents,
+		APIServer:    true,
+		TierWatch:    r.tierWatchReady,
+		LicenseAPI:   r.licenseAPIReady,
+		License:      true,
+	}.Check(ctx, r.client, r.status, reqLogger)
+	if result != nil {
+		return *result, err
 	}
+	license := prereqs.License
 
 	// Query for the installation object.
 	variant, network, err := utils.GetInstallation(ctx, r.client)
@@ -331,6 +346,126 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, err
 	}
 
+	if tz := instance.Spec.TimeZone; tz != "" {
+		if _, err = time.LoadLocation(tz); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("Invalid time zone %q", tz), err, reqLogger)
+			return reconcile.Result{}, nil
+		}
+	}
+
+	if bench := instance.Spec.CISBenchmark; bench != nil {
+		for _, check := range bench.SkipChecks {
+			if !cisCheckNumber.MatchString(check) {
+				r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("Invalid CIS benchmark check %q, expected a number like 4.2.6", check), nil, reqLogger)
+				return reconcile.Result{}, nil
+			}
+		}
+	}
+
+	if scope := instance.Spec.SnapshotScope; scope != nil {
+		if _, err = render.ComplianceSnapshotScopeEnv(scope); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, "Invalid snapshot scope", err, reqLogger)
+			return reconcile.Result{}, nil
+		}
+	}
+
+	if c := instance.Spec.ComplianceServerCertificate; c != nil {
+		for _, name := range c.ExtraDNSNames {
+			if errs := k8svalidation.IsDNS1123Subdomain(strings.TrimPrefix(name, "*.")); len(errs) > 0 {
+				r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("Invalid compliance server DNS name %q", name), fmt.Errorf("%s", strings.Join(errs, ", ")), reqLogger)
+				return reconcile.Result{}, nil
+			}
+		}
+	}
+
+	if limits := instance.Spec.ComplianceServerQueryLimits; limits != nil && limits.QueryTimeout != nil && limits.QueryTimeout.Duration <= 0 {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "The compliance server query timeout must be positive", nil, reqLogger)
+		return reconcile.Result{}, nil
+	}
+
+	// Users can change the templates of the report types, and add their own, with a ConfigMap. Report types are only
+	// rendered in clusters without tenants.
+	var reportTemplates map[string]v3.ReportTypeSpec
+	if !r.multiTenant {
+		cm := &corev1.ConfigMap{}
+		err = r.client.Get(ctx, client.ObjectKey{Name: render.ComplianceReportTemplatesConfigMap, Namespace: common.OperatorNamespace()}, cm)
+		if err == nil {
+			if reportTemplates, err = render.ParseComplianceReportTemplates(cm); err != nil {
+				r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("Invalid report templates in ConfigMap %s", render.ComplianceReportTemplatesConfigMap), err, reqLogger)
+				return reconcile.Result{}, nil
+			}
+		} else if !errors.IsNotFound(err) {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error reading the report templates", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
+	siemExport := instance.Spec.SIEMExport
+	var siemExportCredentials *corev1.Secret
+	if siemExport != nil {
+		if err = utils.ValidateSIEMExport(siemExport, r.multiTenant, managementClusterConnection); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, "Invalid SIEM export configuration", err, reqLogger)
+			return reconcile.Result{}, nil
+		}
+		siemExportCredentials, err = utils.GetSIEMExportCredentials(ctx, r.client, siemExport)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				r.status.SetDegraded(operatorv1.ResourceNotFound, "Waiting for the credentials of the SIEM export", err, reqLogger)
+				return reconcile.Result{}, nil
+			}
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error reading the credentials of the SIEM export", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
+	var reportArchiveCredentials *corev1.Secret
+	if retention := instance.Spec.ReportRetention; retention != nil {
+		if r.multiTenant {
+			err = fmt.Errorf("reportRetention is not supported in multi-tenant management clusters")
+		} else if managementClusterConnection != nil {
+			err = fmt.Errorf("reportRetention is not supported in managed clusters, since reports are stored by the management cluster")
+		} else {
+			err = validateReportRetention(retention)
+		}
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, "Invalid report retention configuration", err, reqLogger)
+			return reconcile.Result{}, nil
+		}
+		if retention.Archive != nil {
+			reportArchiveCredentials, err = getReportArchiveCredentials(ctx, r.client, retention.Archive)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					r.status.SetDegraded(operatorv1.ResourceNotFound, "Waiting for the credentials of the report archive", err, reqLogger)
+					return reconcile.Result{}, nil
+				}
+				r.status.SetDegraded(operatorv1.ResourceReadError, "Error reading the credentials of the report archive", err, reqLogger)
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	var reportSigningKey *corev1.Secret
+	var reportSigningKeyGenerated bool
+	var signingPublicKey string
+	if signing := instance.Spec.ReportSigning; signing != nil {
+		if managementClusterConnection != nil {
+			err = fmt.Errorf("reportSigning is not supported in managed clusters, since reports are downloaded from the management cluster")
+			r.status.SetDegraded(operatorv1.ResourceValidationError, "Invalid report signing configuration", err, reqLogger)
+			return reconcile.Result{}, nil
+		}
+		reportSigningKey, reportSigningKeyGenerated, err = getReportSigningKey(ctx, r.client, signing, helper.TruthNamespace())
+		if err == nil {
+			signingPublicKey, err = reportSigningPublicKey(reportSigningKey)
+		}
+		if errors.IsNotFound(err) {
+			r.status.SetDegraded(operatorv1.ResourceNotFound, fmt.Sprintf("Waiting for report signing key Secret %s", signing.KeySecretName), err, reqLogger)
+			return reconcile.Result{}, nil
+		} else if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, "Failed to read the report signing key", err, reqLogger)
+			return reconcile.Result{}, nil
+		}
+	}
+
 	var opts []certificatemanager.Option
 
 	opts = append(opts, certificatemanager.WithTenant(tenant), certificatemanager.WithLogger(reqLogger))
@@ -368,6 +503,8 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 	}
 	bundleMaker := certificateManager.CreateTrustedBundle(managerInternalTLSSecret, linseedCertificate)
 	trustedBundle := bundleMaker.(certificatemanagement.TrustedBundleRO)
+	// The benchmarker runs on every node and only talks to Linseed, so it gets a bundle of its own.
+	benchmarkerBundle := certificateManager.CreateNamedTrustedBundle(render.ComplianceBenchmarkerTrustedBundle, linseedCertificate)
 	if r.multiTenant {
 		// For multi-tenant systems, we load the pre-created bundle for this tenant instead of using the one we built here.
 		// Multi-tenant compliance need the bundle variant that includes system root certificates, in order to verify external auth providers.
@@ -377,6 +514,7 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 			return reconcile.Result{}, err
 		}
 		bundleMaker = nil
+		benchmarkerBundle = nil
 	}
 
 	// Get the key pairs for each component, generating them as needed.
@@ -399,17 +537,44 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 		}
 	}
 
+	// The SIEM export presents its key pair to Linseed, like the other compliance components.
+	var siemExportKeyPair certificatemanagement.KeyPairInterface
+	if siemExport != nil {
+		keyPairName := siemexport.KeyPairName(siemexport.SourceComplianceReports)
+		siemExportKeyPair, err = certificateManager.GetOrCreateKeyPair(r.client, keyPairName, helper.TruthNamespace(), []string{"localhost"})
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("failed to retrieve / validate  %s", keyPairName), err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
 	var complianceServerKeyPair certificatemanagement.KeyPairInterface
 	if managementClusterConnection == nil {
+		dnsNames := dns.GetServiceDNSNames(render.ComplianceServiceName, helper.InstallNamespace(), r.clusterDomain)
+		if c := instance.Spec.ComplianceServerCertificate; c != nil {
+			dnsNames = append(dnsNames, c.ExtraDNSNames...)
+		}
 		complianceServerKeyPair, err = certificateManager.GetOrCreateKeyPair(
 			r.client,
 			render.ComplianceServerCertSecret,
 			helper.TruthNamespace(),
-			dns.GetServiceDNSNames(render.ComplianceServiceName, helper.InstallNamespace(), r.clusterDomain))
+			dnsNames)
 		if err != nil {
 			r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("failed to retrieve / validate  %s", render.ComplianceServerCertSecret), err, reqLogger)
 			return reconcile.Result{}, err
 		}
+		// The certificate manager keeps a certificate that the user provided even if it lacks some of the names, but
+		// clients can't reach the compliance server by those names.
+		if complianceServerKeyPair.BYO() {
+			cert, err := certificatemanagement.ParseCertificate(complianceServerKeyPair.GetCertificatePEM())
+			if err == nil {
+				err = certificatemanager.HasExpectedDNSNames(render.ComplianceServerCertSecret, helper.TruthNamespace(), cert, dnsNames)
+			}
+			if err != nil {
+				r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("The certificate in secret %s must include the DNS names %s", render.ComplianceServerCertSecret, strings.Join(dnsNames, ", ")), err, reqLogger)
+				return reconcile.Result{}, nil
+			}
+		}
 	}
 	certificateManager.AddToStatusManager(r.status, helper.InstallNamespace())
 
@@ -425,7 +590,7 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 	}
 
 	// Create a component handler to manage the rendered component.
-	handler := utils.NewComponentHandler(log, r.client, r.scheme, instance)
+	handler := utils.NewComponentHandler(logging.ForReconcile(ctx, log), r.client, r.scheme, instance)
 
 	keyValidatorConfig, err := utils.GetKeyValidatorConfig(ctx, r.client, authenticationCR, r.clusterDomain)
 	if err != nil {
@@ -441,6 +606,7 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 	openshift := r.provider.IsOpenShift()
 	complianceCfg := &render.ComplianceConfiguration{
 		TrustedBundle:               trustedBundle,
+		BenchmarkerTrustedBundle:    benchmarkerBundle,
 		Installation:                network,
 		ServerKeyPair:               complianceServerKeyPair,
 		ControllerKeyPair:           controllerKeyPair.Interface,
@@ -458,6 +624,9 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 		Tenant:                      tenant,
 		Compliance:                  instance,
 		ExternalElastic:             r.externalElastic,
+		ReportSigningKey:            reportSigningKey,
+		ReportTemplates:             reportTemplates,
+		ReportArchiveCredentials:    reportArchiveCredentials,
 	}
 
 	// Render the desired objects from the CRD and create or update them.
@@ -467,25 +636,50 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, err
 	}
 
-	if err = imageset.ApplyImageSet(ctx, r.client, variant, comp); err != nil {
+	components := []render.Component{comp}
+	if reportSigningKeyGenerated {
+		components = append(components, render.NewPassthrough(reportSigningKey))
+	}
+	if !r.multiTenant {
+		// The export is rendered without its configuration when it's disabled, so that it's removed.
+		components = append(components, siemexport.SIEMExport(&siemexport.Config{
+			Source:        siemexport.SourceComplianceReports,
+			Namespace:     helper.InstallNamespace(),
+			Export:        siemExport,
+			Credentials:   siemExportCredentials,
+			TimeZone:      instance.Spec.TimeZone,
+			Installation:  network,
+			PullSecrets:   pullSecrets,
+			OpenShift:     openshift,
+			TrustedBundle: trustedBundle,
+			KeyPair:       siemExportKeyPair,
+		}))
+	}
+
+	if err = imageset.ApplyImageSet(ctx, r.client, variant, components...); err != nil {
 		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
 		return reconcile.Result{}, err
 	}
-	certificateComponent := rcertificatemanagement.CertificateManagement(&rcertificatemanagement.Config{
+	certificateCfg := &rcertificatemanagement.Config{
 		Namespace:       helper.InstallNamespace(),
 		TruthNamespace:  helper.TruthNamespace(),
-		ServiceAccounts: []string{render.ComplianceServerServiceAccount, render.ComplianceBenchmarkerServiceAccount, render.ComplianceSnapshotterServiceAccount, render.ComplianceControllerServiceAccount, render.ComplianceReporterServiceAccount},
+		ServiceAccounts: []string{render.ComplianceServerServiceAccount, render.ComplianceBenchmarkerServiceAccount, render.ComplianceSnapshotterServiceAccount, render.ComplianceControllerServiceAccount, render.ComplianceReporterServiceAccount, siemexport.Name},
 		KeyPairOptions: []rcertificatemanagement.KeyPairOption{
 			rcertificatemanagement.NewKeyPairOption(complianceServerKeyPair, true, true),
 			rcertificatemanagement.NewKeyPairOption(controllerKeyPair.Interface, true, true),
 			rcertificatemanagement.NewKeyPairOption(benchmarkerKeyPair.Interface, true, true),
 			rcertificatemanagement.NewKeyPairOption(snapshotterKeyPair.Interface, true, true),
 			rcertificatemanagement.NewKeyPairOption(reporterKeyPair.Interface, true, true),
+			rcertificatemanagement.NewKeyPairOption(siemExportKeyPair, true, true),
 		},
 		TrustedBundle: bundleMaker,
-	})
+	}
+	if benchmarkerBundle != nil {
+		certificateCfg.ComponentTrustedBundles = []certificatemanagement.TrustedBundle{benchmarkerBundle}
+	}
+	certificateComponent := rcertificatemanagement.CertificateManagement(certificateCfg)
 
-	for _, comp := range []render.Component{namespaceComp, certificateComponent, comp} {
+	for _, comp := range append([]render.Component{namespaceComp, certificateComponent}, components...) {
 		if err := handler.CreateOrUpdateOrDelete(ctx, comp, r.status); err != nil {
 			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error creating / updating / deleting resource", err, reqLogger)
 			return reconcile.Result{}, err
@@ -509,6 +703,7 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 
 	// Everything is available - update the CRD status.
 	instance.Status.State = operatorv1.TigeraStatusReady
+	instance.Status.ReportSigningPublicKey = signingPublicKey
 	if err = r.client.Status().Update(ctx, instance); err != nil {
 		return reconcile.Result{}, err
 	}
@@ -16,7 +16,16 @@ package compliance
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
@@ -39,8 +48,10 @@ import (
 	"github.com/tigera/operator/pkg/apis"
 	"github.com/tigera/operator/pkg/controller/status"
 	"github.com/tigera/operator/pkg/render"
+	"github.com/tigera/operator/pkg/render/siemexport"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -68,6 +79,7 @@ var _ = Describe("Compliance controller tests", func() {
 		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
 		Expect(appsv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
 		Expect(rbacv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(batchv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
 		Expect(operatorv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
 
 		// Create a client that will have a crud interface of k8s objects.
@@ -82,6 +94,7 @@ var _ = Describe("Compliance controller tests", func() {
 		mockStatus.On("AddStatefulSets", mock.Anything).Return()
 		mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
 		mockStatus.On("AddCronJobs", mock.Anything)
+		mockStatus.On("RemoveCronJobs", mock.Anything)
 		mockStatus.On("IsAvailable").Return(true)
 		mockStatus.On("OnCRFound").Return()
 		mockStatus.On("AddCertificateSigningRequests", mock.Anything).Return()
@@ -89,6 +102,7 @@ var _ = Describe("Compliance controller tests", func() {
 		mockStatus.On("SetDegraded", "Waiting for LicenseKeyAPI to be ready", "").Return().Maybe()
 		mockStatus.On("ReadyToMonitor")
 		mockStatus.On("SetMetaData", mock.Anything).Return()
+		mockStatus.On("RolloutStatus").Return(nil)
 
 		// Create an object we can use throughout the test to do the compliance reconcile loops.
 		// As the parameters in the client changes, we expect the outcomes of the reconcile loops to change.
@@ -185,11 +199,16 @@ var _ = Describe("Compliance controller tests", func() {
 			Namespace: render.ComplianceNamespace,
 		}, &dpl)).NotTo(HaveOccurred())
 		Expect(dpl.Spec.Template.ObjectMeta.Name).To(Equal(render.ComplianceControllerName))
+
+		By("creating the trusted bundle of the benchmarker")
+		Expect(c.Get(ctx, client.ObjectKey{
+			Name:      render.ComplianceBenchmarkerTrustedBundle,
+			Namespace: render.ComplianceNamespace,
+		}, &corev1.ConfigMap{})).NotTo(HaveOccurred())
 	})
 
-	It("should reconcile if the compliance server cert is user-supplied", func() {
-		// This test just validates that user-provided certs reconcile and do
-		// not overwrite the certs.
+	It("should not overwrite a user-supplied compliance server cert that lacks the expected DNS names", func() {
+		// This test validates that user-provided certs are not overwritten, and that the missing names are reported.
 		By("reconciling when clustertype is Standalone")
 		result, err := r.Reconcile(ctx, reconcile.Request{})
 		Expect(err).NotTo(HaveOccurred())
@@ -219,10 +238,13 @@ var _ = Describe("Compliance controller tests", func() {
 
 		assertExpectedCertDNSNames(c, oldDNSNames...)
 
-		By("checking that an error occurred and the cert didn't change")
+		By("checking that the missing names are reported and the cert didn't change")
+		msg := fmt.Sprintf("The certificate in secret %s must include the DNS names %s", render.ComplianceServerCertSecret, strings.Join(expectedDNSNames, ", "))
+		mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, msg, mock.Anything, mock.Anything).Return()
 		result, err = r.Reconcile(ctx, reconcile.Request{})
 		Expect(err).NotTo(HaveOccurred())
 		Expect(result.Requeue).NotTo(BeTrue())
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, msg, mock.Anything, mock.Anything)
 		assertExpectedCertDNSNames(c, oldDNSNames...)
 	})
 
@@ -263,6 +285,26 @@ var _ = Describe("Compliance controller tests", func() {
 		assertExpectedCertDNSNames(c, dnsNames...)
 	})
 
+	It("should add the extra DNS names to the compliance server cert", func() {
+		cr.Spec.ComplianceServerCertificate = &operatorv1.ComplianceServerCertificate{ExtraDNSNames: []string{"compliance.example.com"}}
+		Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		assertExpectedCertDNSNames(c, append(expectedDNSNames, "compliance.example.com")...)
+	})
+
+	It("should reject invalid extra DNS names of the compliance server cert", func() {
+		cr.Spec.ComplianceServerCertificate = &operatorv1.ComplianceServerCertificate{ExtraDNSNames: []string{"Compliance_LB"}}
+		Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+
+		msg := `Invalid compliance server DNS name "Compliance_LB"`
+		mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, msg, mock.Anything, mock.Anything).Return()
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, msg, mock.Anything, mock.Anything)
+	})
+
 	It("test that Compliance creates a TLS cert secret if not provided and add an OwnerReference to it", func() {
 		_, err := r.Reconcile(ctx, reconcile.Request{})
 		Expect(err).NotTo(HaveOccurred())
@@ -379,6 +421,308 @@ var _ = Describe("Compliance controller tests", func() {
 		Expect(crb.Subjects).To(HaveLen(1))
 	})
 
+	Context("SIEM export", func() {
+		BeforeEach(func() {
+			cr.Spec.SIEMExport = &operatorv1.SIEMExport{
+				ObjectStorage: operatorv1.SIEMExportObjectStorage{
+					Bucket:                "siem",
+					CredentialsSecretName: "siem-credentials",
+				},
+			}
+			Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+		})
+
+		It("should wait for the credentials and then create the export CronJob", func() {
+			mockStatus.On("SetDegraded", operatorv1.ResourceNotFound, "Waiting for the credentials of the SIEM export", mock.Anything, mock.Anything).Return().Once()
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceNotFound, "Waiting for the credentials of the SIEM export", mock.Anything, mock.Anything)
+			Expect(c.Get(ctx, client.ObjectKey{Name: siemexport.Name, Namespace: render.ComplianceNamespace}, &batchv1.CronJob{})).To(HaveOccurred())
+
+			Expect(c.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "siem-credentials", Namespace: common.OperatorNamespace()},
+				Data: map[string][]byte{
+					siemexport.AccessKeyIDKey:     []byte("id"),
+					siemexport.SecretAccessKeyKey: []byte("secret"),
+				},
+			})).NotTo(HaveOccurred())
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			cj := &batchv1.CronJob{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: siemexport.Name, Namespace: render.ComplianceNamespace}, cj)).NotTo(HaveOccurred())
+			Expect(cj.Spec.Schedule).To(Equal(siemexport.DefaultSchedule))
+			Expect(c.Get(ctx, client.ObjectKey{Name: siemexport.CredentialsSecretName, Namespace: render.ComplianceNamespace}, &corev1.Secret{})).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKey{
+				Name:      siemexport.KeyPairName(siemexport.SourceComplianceReports),
+				Namespace: render.ComplianceNamespace,
+			}, &corev1.Secret{})).NotTo(HaveOccurred())
+
+			By("removing the export once it's disabled")
+			Expect(c.Get(ctx, client.ObjectKey{Name: cr.Name}, cr)).NotTo(HaveOccurred())
+			cr.Spec.SIEMExport = nil
+			Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKey{Name: siemexport.Name, Namespace: render.ComplianceNamespace}, cj)).To(HaveOccurred())
+		})
+
+		It("should not support the export in managed clusters", func() {
+			Expect(c.Create(ctx, &operatorv1.ManagementClusterConnection{
+				ObjectMeta: metav1.ObjectMeta{Name: utils.DefaultTSEEInstanceKey.Name},
+			})).NotTo(HaveOccurred())
+			mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Invalid SIEM export configuration", mock.Anything, mock.Anything).Return().Once()
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Invalid SIEM export configuration", mock.Anything, mock.Anything)
+		})
+	})
+
+	Context("report retention", func() {
+		BeforeEach(func() {
+			cr.Spec.ReportRetention = &operatorv1.ReportRetention{
+				Duration: &metav1.Duration{Duration: 30 * 24 * time.Hour},
+				Archive: &operatorv1.ReportArchive{
+					Provider:              operatorv1.ReportArchiveProviderS3,
+					Bucket:                "reports",
+					CredentialsSecretName: "archive-credentials",
+				},
+			}
+			Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+		})
+
+		It("should wait for the credentials and then copy them into the compliance namespace", func() {
+			mockStatus.On("SetDegraded", operatorv1.ResourceNotFound, "Waiting for the credentials of the report archive", mock.Anything, mock.Anything).Return().Once()
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceNotFound, "Waiting for the credentials of the report archive", mock.Anything, mock.Anything)
+
+			Expect(c.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "archive-credentials", Namespace: common.OperatorNamespace()},
+				Data: map[string][]byte{
+					render.ComplianceReportArchiveAccessKeyIDKey: []byte("id"),
+					render.ComplianceReportArchiveSecretKeyKey:   []byte("secret"),
+				},
+			})).NotTo(HaveOccurred())
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			s := &corev1.Secret{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: render.ComplianceReportArchiveCredentialsSecret, Namespace: render.ComplianceNamespace}, s)).NotTo(HaveOccurred())
+			Expect(s.Data).To(HaveKeyWithValue(render.ComplianceReportArchiveAccessKeyIDKey, []byte("id")))
+
+			d := &appsv1.Deployment{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: render.ComplianceServerName, Namespace: render.ComplianceNamespace}, d)).NotTo(HaveOccurred())
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "TIGERA_COMPLIANCE_REPORT_RETENTION", Value: "720h0m0s"}))
+
+			By("removing the credentials once the archive is disabled")
+			Expect(c.Get(ctx, client.ObjectKey{Name: cr.Name}, cr)).NotTo(HaveOccurred())
+			cr.Spec.ReportRetention.Archive = nil
+			Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKey{Name: render.ComplianceReportArchiveCredentialsSecret, Namespace: render.ComplianceNamespace}, s)).To(HaveOccurred())
+		})
+
+		It("should reject credentials without the keys of the provider", func() {
+			cr.Spec.ReportRetention.Archive.Provider = operatorv1.ReportArchiveProviderGCS
+			Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "archive-credentials", Namespace: common.OperatorNamespace()},
+				Data: map[string][]byte{
+					render.ComplianceReportArchiveAccessKeyIDKey: []byte("id"),
+					render.ComplianceReportArchiveSecretKeyKey:   []byte("secret"),
+				},
+			})).NotTo(HaveOccurred())
+
+			mockStatus.On("SetDegraded", operatorv1.ResourceReadError, "Error reading the credentials of the report archive", mock.Anything, mock.Anything).Return()
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).To(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceReadError, "Error reading the credentials of the report archive", mock.Anything, mock.Anything)
+		})
+
+		It("should reject an endpoint for GCS", func() {
+			cr.Spec.ReportRetention.Archive.Provider = operatorv1.ReportArchiveProviderGCS
+			cr.Spec.ReportRetention.Archive.Endpoint = "https://storage.example.com"
+			Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+
+			mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Invalid report retention configuration", mock.Anything, mock.Anything).Return()
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Invalid report retention configuration", mock.Anything, mock.Anything)
+		})
+
+		It("should not support the retention in managed clusters", func() {
+			Expect(c.Create(ctx, &operatorv1.ManagementClusterConnection{
+				ObjectMeta: metav1.ObjectMeta{Name: utils.DefaultTSEEInstanceKey.Name},
+			})).NotTo(HaveOccurred())
+			mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Invalid report retention configuration", mock.Anything, mock.Anything).Return()
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Invalid report retention configuration", mock.Anything, mock.Anything)
+		})
+	})
+
+	It("should reject an unknown time zone", func() {
+		cr.Spec.TimeZone = "Mars/Olympus_Mons"
+		Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+
+		msg := `Invalid time zone "Mars/Olympus_Mons"`
+		mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, msg, mock.Anything, mock.Anything).Return()
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, msg, mock.Anything, mock.Anything)
+	})
+
+	It("should reject CIS benchmark checks that aren't check numbers", func() {
+		cr.Spec.CISBenchmark = &operatorv1.CISBenchmark{SkipChecks: []string{"4.2.6", "4.2.x"}}
+		Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+
+		msg := `Invalid CIS benchmark check "4.2.x", expected a number like 4.2.6`
+		mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, msg, mock.Anything, mock.Anything).Return()
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, msg, mock.Anything, mock.Anything)
+	})
+
+	It("should reject an invalid snapshot scope", func() {
+		cr.Spec.SnapshotScope = &operatorv1.SnapshotScope{
+			NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: metav1.LabelSelectorOpIn},
+			}},
+		}
+		Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+
+		mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Invalid snapshot scope", mock.Anything, mock.Anything).Return()
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Invalid snapshot scope", mock.Anything, mock.Anything)
+	})
+
+	It("should reject a query timeout that isn't positive", func() {
+		cr.Spec.ComplianceServerQueryLimits = &operatorv1.ComplianceServerQueryLimits{QueryTimeout: &metav1.Duration{}}
+		Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+
+		msg := "The compliance server query timeout must be positive"
+		mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, msg, mock.Anything, mock.Anything).Return()
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, msg, mock.Anything, mock.Anything)
+	})
+
+	Context("report templates", func() {
+		createTemplates := func(data map[string]string) {
+			Expect(c.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: render.ComplianceReportTemplatesConfigMap, Namespace: common.OperatorNamespace()},
+				Data:       data,
+			})).NotTo(HaveOccurred())
+		}
+
+		It("should render the report types and templates from the ConfigMap", func() {
+			createTemplates(map[string]string{
+				"inventory": "downloadTemplates:\n- name: summary.csv\n  template: custom\n",
+				"my-report": "uiSummaryTemplate:\n  name: ui-summary.json\n  template: '{}'\n",
+			})
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			inventory := &v3.GlobalReportType{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "inventory"}, inventory)).NotTo(HaveOccurred())
+			Expect(inventory.Spec.DownloadTemplates).To(ContainElement(v3.ReportTemplate{Name: "summary.csv", Template: "custom"}))
+
+			custom := &v3.GlobalReportType{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "my-report"}, custom)).NotTo(HaveOccurred())
+			Expect(custom.Spec.UISummaryTemplate).To(Equal(v3.ReportTemplate{Name: "ui-summary.json", Template: "{}"}))
+		})
+
+		It("should reject invalid report templates", func() {
+			createTemplates(map[string]string{"my-report": "downloadTemplates: []\n"})
+
+			msg := "Invalid report templates in ConfigMap " + render.ComplianceReportTemplatesConfigMap
+			mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, msg, mock.Anything, mock.Anything).Return()
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, msg, mock.Anything, mock.Anything)
+		})
+	})
+
+	Context("report signing", func() {
+		BeforeEach(func() {
+			cr.Spec.ReportSigning = &operatorv1.ReportSigning{}
+			Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+		})
+
+		getPublicKey := func() crypto.PublicKey {
+			Expect(c.Get(ctx, client.ObjectKey{Name: cr.Name}, cr)).NotTo(HaveOccurred())
+			block, _ := pem.Decode([]byte(cr.Status.ReportSigningPublicKey))
+			Expect(block).NotTo(BeNil())
+			pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+			Expect(err).NotTo(HaveOccurred())
+			return pub
+		}
+
+		It("should generate a signing key and publish its public key", func() {
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			key := &corev1.Secret{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: render.ComplianceReportSigningKeySecret, Namespace: common.OperatorNamespace()}, key)).NotTo(HaveOccurred())
+			signer, err := parseReportSigningKey(key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(signer).To(BeAssignableToTypeOf(ed25519.PrivateKey{}))
+			Expect(getPublicKey()).To(Equal(signer.Public()))
+
+			copied := &corev1.Secret{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: render.ComplianceReportSigningKeySecret, Namespace: render.ComplianceNamespace}, copied)).NotTo(HaveOccurred())
+			Expect(copied.Data).To(Equal(key.Data))
+
+			By("keeping the same key on the next reconcile")
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getPublicKey()).To(Equal(signer.Public()))
+		})
+
+		It("should sign reports with a user provided key", func() {
+			ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			der, err := x509.MarshalPKCS8PrivateKey(ecKey)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "user-signing-key", Namespace: common.OperatorNamespace()},
+				Data: map[string][]byte{
+					render.ComplianceReportSigningKeyKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}),
+				},
+			})).NotTo(HaveOccurred())
+			cr.Spec.ReportSigning.KeySecretName = "user-signing-key"
+			Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getPublicKey()).To(Equal(ecKey.Public()))
+			Expect(c.Get(ctx, client.ObjectKey{Name: render.ComplianceReportSigningKeySecret, Namespace: common.OperatorNamespace()}, &corev1.Secret{})).To(HaveOccurred())
+		})
+
+		It("should reject keys that aren't Ed25519 or ECDSA", func() {
+			rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+			der, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "user-signing-key", Namespace: common.OperatorNamespace()},
+				Data: map[string][]byte{
+					render.ComplianceReportSigningKeyKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}),
+				},
+			})).NotTo(HaveOccurred())
+			cr.Spec.ReportSigning.KeySecretName = "user-signing-key"
+			Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+
+			mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Failed to read the report signing key", mock.Anything, mock.Anything).Return()
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Failed to read the report signing key", mock.Anything, mock.Anything)
+		})
+	})
+
 	Context("image reconciliation", func() {
 		It("should use builtin images", func() {
 			_, err := r.Reconcile(ctx, reconcile.Request{})
@@ -716,6 +1060,7 @@ var _ = Describe("Compliance controller tests", func() {
 			Expect(instance.Status.Conditions[0].Reason).To(Equal(string(operatorv1.AllObjectsAvailable)))
 			Expect(instance.Status.Conditions[0].Message).To(Equal("All Objects are available"))
 			Expect(instance.Status.Conditions[0].ObservedGeneration).To(Equal(generation))
+			Expect(instance.Status.ObservedGeneration).To(Equal(generation))
 		})
 
 		It("should reconcile with empty tigerastatus conditions ", func() {
@@ -0,0 +1,74 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/render"
+	"github.com/tigera/operator/pkg/render/siemexport"
+)
+
+// validateReportRetention returns an error if the retention of reports is invalid.
+func validateReportRetention(retention *operatorv1.ReportRetention) error {
+	if retention.Duration != nil && retention.Duration.Duration <= 0 {
+		return fmt.Errorf("reportRetention.duration must be positive")
+	}
+	archive := retention.Archive
+	if archive == nil {
+		return nil
+	}
+	if archive.Bucket == "" {
+		return fmt.Errorf("reportRetention.archive.bucket must be specified")
+	}
+	if archive.CredentialsSecretName == "" {
+		return fmt.Errorf("reportRetention.archive.credentialsSecretName must be specified")
+	}
+	switch archive.Provider {
+	case operatorv1.ReportArchiveProviderS3:
+		return siemexport.ValidateEndpoint("reportRetention.archive.endpoint", archive.Endpoint)
+	case operatorv1.ReportArchiveProviderGCS:
+		if archive.Region != "" || archive.Endpoint != "" {
+			return fmt.Errorf("reportRetention.archive.region and endpoint are only supported for S3")
+		}
+		return nil
+	}
+	return fmt.Errorf("reportRetention.archive.provider %q is not supported", archive.Provider)
+}
+
+// getReportArchiveCredentials reads the secret in the operator namespace that holds the credentials of the bucket that
+// reports are archived to, and checks that it has the keys of the provider.
+func getReportArchiveCredentials(ctx context.Context, cli client.Client, archive *operatorv1.ReportArchive) (*corev1.Secret, error) {
+	s := &corev1.Secret{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: archive.CredentialsSecretName, Namespace: common.OperatorNamespace()}, s); err != nil {
+		return nil, err
+	}
+	keys := []string{render.ComplianceReportArchiveAccessKeyIDKey, render.ComplianceReportArchiveSecretKeyKey}
+	if archive.Provider == operatorv1.ReportArchiveProviderGCS {
+		keys = []string{render.ComplianceReportArchiveGCSKeyKey}
+	}
+	for _, k := range keys {
+		if len(s.Data[k]) == 0 {
+			return nil, fmt.Errorf("Secret %s/%s has no %s key", s.Namespace, s.Name, k)
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/render"
+)
+
+// getReportSigningKey returns the secret that holds the key that reports are signed with. A user provided key is read
+// from the operator namespace. Otherwise, the key that the operator generated in the truth namespace is returned, and
+// if there isn't one yet, a new key is generated. The returned bool is true if the secret was generated and needs to
+// be created.
+func getReportSigningKey(ctx context.Context, cli client.Client, signing *operatorv1.ReportSigning, truthNS string) (*corev1.Secret, bool, error) {
+	if signing.KeySecretName != "" {
+		s := &corev1.Secret{}
+		if err := cli.Get(ctx, client.ObjectKey{Name: signing.KeySecretName, Namespace: common.OperatorNamespace()}, s); err != nil {
+			return nil, false, err
+		}
+		if _, err := parseReportSigningKey(s); err != nil {
+			return nil, false, err
+		}
+		return s, false, nil
+	}
+
+	s := &corev1.Secret{}
+	err := cli.Get(ctx, client.ObjectKey{Name: render.ComplianceReportSigningKeySecret, Namespace: truthNS}, s)
+	if err == nil {
+		if _, err := parseReportSigningKey(s); err != nil {
+			return nil, false, err
+		}
+		return s, false, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, false, err
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to generate the report signing key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to encode the report signing key: %w", err)
+	}
+	return &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: render.ComplianceReportSigningKeySecret, Namespace: truthNS},
+		Data: map[string][]byte{
+			render.ComplianceReportSigningKeyKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}),
+		},
+	}, true, nil
+}
+
+// parseReportSigningKey returns the signer in the given secret, which must be an Ed25519 or ECDSA key.
+func parseReportSigningKey(s *corev1.Secret) (crypto.Signer, error) {
+	block, _ := pem.Decode(s.Data[render.ComplianceReportSigningKeyKey])
+	if block == nil {
+		return nil, fmt.Errorf("Secret %s/%s has no PEM encoded key under %s", s.Namespace, s.Name, render.ComplianceReportSigningKeyKey)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("the key in Secret %s/%s is not a PKCS #8 private key: %w", s.Namespace, s.Name, err)
+	}
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return k, nil
+	case *ecdsa.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("the key in Secret %s/%s must be an Ed25519 or ECDSA key, not %T", s.Namespace, s.Name, key)
+	}
+}
+
+// reportSigningPublicKey returns the PEM encoded public key of the report signing key in the given secret.
+func reportSigningPublicKey(s *corev1.Secret) (string, error) {
+	signer, err := parseReportSigningKey(s)
+	if err != nil {
+		return "", err
+	}
+	der, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the report signing public key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
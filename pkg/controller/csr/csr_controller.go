@@ -33,6 +33,7 @@ import (
 	"github.com/tigera/operator/pkg/controller/options"
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render"
 	rmonitor "github.com/tigera/operator/pkg/render/monitor"
 	"github.com/tigera/operator/pkg/tls"
@@ -91,12 +92,12 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	}
 
 	if opts.EnterpriseCRDExists {
-		if err = c.WatchObject(&operatorv1.Monitor{}, &handler.EnqueueRequestForObject{}); err != nil {
+		if err = c.WatchObject(&operatorv1.Monitor{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
 			return fmt.Errorf("monitor-controller failed to watch primary resource: %w", err)
 		}
 	}
 
-	if err = c.WatchObject(&operatorv1.Installation{}, &handler.EnqueueRequestForObject{}); err != nil {
+	if err = c.WatchObject(&operatorv1.Installation{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("monitor-controller failed to watch primary resource: %w", err)
 	}
 
@@ -155,7 +156,7 @@ type reconcileCSR struct {
 }
 
 func (r *reconcileCSR) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling CSR Controller")
 	csrList := &certificatesv1.CertificateSigningRequestList{}
 
@@ -179,7 +180,7 @@ func (r *reconcileCSR) Reconcile(ctx context.Context, request reconcile.Request)
 		needsCSRRole = monitorCR.Spec.ExternalPrometheus != nil
 	}
 
-	componentHandler := utils.NewComponentHandler(log, r.client, r.scheme, instance)
+	componentHandler := utils.NewComponentHandler(logging.ForReconcile(ctx, log), r.client, r.scheme, instance)
 	var passthrough render.Component
 	if needsCSRRole {
 		// This controller creates the cluster role for any pod in the cluster that requires certificate management.
@@ -0,0 +1,305 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diagnostics collects Felix diagnostics from nodes for support cases. A Job runs on each node that
+// diagnostics are collected from, and writes an archive of the node's dataplane state and calico-node logs to a
+// PersistentVolumeClaim. Diagnostics are collected on request, from the nodes listed in an annotation of the
+// Installation, and optionally from nodes whose calico-node pod is crash looping.
+package diagnostics
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/ptr"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/securitycontext"
+)
+
+const (
+	// Annotation lists the comma separated names of the nodes to collect diagnostics from. Diagnostics are collected
+	// once from each listed node; remove a node from the list and add it again to collect them again.
+	Annotation = "operator.tigera.io/collect-diagnostics"
+
+	// ReasonLabel is set on the Jobs that collect diagnostics, with the reason of the collection as the value.
+	ReasonLabel = "operator.tigera.io/diagnostics-reason"
+
+	// fileAnnotation and nodeAnnotation are set on the Jobs that collect diagnostics, with the path of the archive in
+	// the volume and the name of the node.
+	fileAnnotation = "operator.tigera.io/diagnostics-file"
+	nodeAnnotation = "operator.tigera.io/diagnostics-node"
+
+	jobAppLabel = "calico-diagnostics"
+
+	// crashLoopJobTTL is how long a finished Job for a crash looping pod is kept. Diagnostics are collected again
+	// once it's removed, if the pod is still crash looping.
+	crashLoopJobTTL = 24 * 60 * 60
+
+	// jobDeadline is how long a collection may take before its Job fails.
+	jobDeadline = 10 * 60
+)
+
+// collectScript writes the dataplane state of the node and the calico-node logs to the archive at /diagnostics/$FILE.
+// Commands that aren't available, such as the eBPF ones when eBPF isn't in use, only leave their error in the archive.
+const collectScript = `set -u
+dir=$(mktemp -d)
+cd "$dir"
+run() { name=$1; shift; "$@" > "$name" 2>&1 || true; }
+run ip-addr.txt ip addr
+run ip-link.txt ip -d link
+run ip-rule.txt ip rule
+run ip-route.txt ip route show table all
+run ip6-route.txt ip -6 route show table all
+run ip-neigh.txt ip neigh
+run iptables-save.txt iptables-save -c
+run ip6tables-save.txt ip6tables-save -c
+run ipset.txt ipset list
+run nft.txt nft list ruleset
+run sockets.txt ss -anp
+run sysctl.txt sysctl -a
+if [ -d /sys/fs/bpf/tc ]; then
+  run bpf-ifstate.txt calico-node -bpf ifstate dump
+  run bpf-routes.txt calico-node -bpf routes dump
+  run bpf-nat.txt calico-node -bpf nat dump
+  run bpf-conntrack.txt calico-node -bpf conntrack dump
+fi
+mkdir -p logs/calico logs/pods
+cp -r /var/log/calico/. logs/calico/ 2>/dev/null || true
+cp -r /var/log/pods/calico-system_calico-node-* logs/pods/ 2>/dev/null || true
+tar -czf "/diagnostics/$FILE.tmp" . && mv "/diagnostics/$FILE.tmp" "/diagnostics/$FILE"
+`
+
+// Collect creates the Jobs of the diagnostics collections that the Installation asks for, removes the Jobs of requests
+// that were withdrawn, and returns the collections whose Jobs exist.
+func Collect(ctx context.Context, c client.Client, scheme *runtime.Scheme, installation *operatorv1.Installation, image string, now time.Time) ([]operatorv1.DiagnosticsCollection, error) {
+	if installation.Spec.Diagnostics == nil && len(installation.Status.Diagnostics) == 0 {
+		// There is nothing to collect or clean up.
+		return nil, nil
+	}
+
+	jobs := &batchv1.JobList{}
+	if err := c.List(ctx, jobs, client.InNamespace(common.CalicoNamespace), client.MatchingLabels{"k8s-app": jobAppLabel}); err != nil {
+		return installation.Status.Diagnostics, err
+	}
+	existing := map[string]*batchv1.Job{}
+	for i := range jobs.Items {
+		existing[jobs.Items[i].Name] = &jobs.Items[i]
+	}
+
+	desired := map[string]*batchv1.Job{}
+	if installation.Spec.Diagnostics != nil {
+		for _, node := range requestedNodes(installation) {
+			if err := c.Get(ctx, types.NamespacedName{Name: node}, &corev1.Node{}); err != nil {
+				if apierrors.IsNotFound(err) {
+					return installation.Status.Diagnostics, fmt.Errorf("node %s in the %s annotation doesn't exist", node, Annotation)
+				}
+				return installation.Status.Diagnostics, err
+			}
+			job := collectionJob(installation, image, node, operatorv1.DiagnosticsCollectionRequested, node, now)
+			desired[job.Name] = job
+		}
+		if installation.Spec.Diagnostics.CollectOnCrashLoop != nil && *installation.Spec.Diagnostics.CollectOnCrashLoop == operatorv1.CollectOnCrashLoopEnabled {
+			pods := &corev1.PodList{}
+			if err := c.List(ctx, pods, client.InNamespace(common.CalicoNamespace), client.MatchingLabels{"k8s-app": common.NodeDaemonSetName}); err != nil {
+				return installation.Status.Diagnostics, err
+			}
+			for _, pod := range pods.Items {
+				if pod.Spec.NodeName != "" && crashLooping(&pod) {
+					// The Job is named after the pod, so that diagnostics are collected once for each crash looping pod.
+					job := collectionJob(installation, image, pod.Spec.NodeName, operatorv1.DiagnosticsCollectionCrashLoop, string(pod.UID), now)
+					desired[job.Name] = job
+				}
+			}
+		}
+	}
+
+	for name, job := range desired {
+		if _, ok := existing[name]; ok {
+			continue
+		}
+		if err := controllerutil.SetControllerReference(installation, job, scheme); err != nil {
+			return installation.Status.Diagnostics, err
+		}
+		if err := c.Create(ctx, job); err != nil {
+			return installation.Status.Diagnostics, fmt.Errorf("failed to create Job to collect diagnostics from node %s: %w", job.Annotations[nodeAnnotation], err)
+		}
+		existing[name] = job
+	}
+
+	// Jobs of withdrawn requests are removed along with their pods. Jobs for crash looping pods are left for their TTL
+	// to remove, so that they aren't recreated while the pod is still crash looping.
+	for name, job := range existing {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+		if installation.Spec.Diagnostics != nil && operatorv1.DiagnosticsCollectionReason(job.Labels[ReasonLabel]) == operatorv1.DiagnosticsCollectionCrashLoop {
+			continue
+		}
+		if err := c.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+			return installation.Status.Diagnostics, err
+		}
+		delete(existing, name)
+	}
+
+	var collections []operatorv1.DiagnosticsCollection
+	for name, job := range existing {
+		collections = append(collections, operatorv1.DiagnosticsCollection{
+			Node:   job.Annotations[nodeAnnotation],
+			Reason: operatorv1.DiagnosticsCollectionReason(job.Labels[ReasonLabel]),
+			Job:    name,
+			File:   job.Annotations[fileAnnotation],
+			State:  state(job),
+		})
+	}
+	sort.Slice(collections, func(i, j int) bool {
+		if collections[i].Node != collections[j].Node {
+			return collections[i].Node < collections[j].Node
+		}
+		return collections[i].Job < collections[j].Job
+	})
+	return collections, nil
+}
+
+// Running returns true if any of the collections hasn't finished.
+func Running(collections []operatorv1.DiagnosticsCollection) bool {
+	for _, c := range collections {
+		if c.State == operatorv1.DiagnosticsCollectionRunning {
+			return true
+		}
+	}
+	return false
+}
+
+func requestedNodes(installation *operatorv1.Installation) []string {
+	var nodes []string
+	for _, node := range strings.Split(installation.Annotations[Annotation], ",") {
+		if node = strings.TrimSpace(node); node != "" {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// crashLooping returns true if a container of the pod is waiting to be restarted after crashing repeatedly.
+func crashLooping(pod *corev1.Pod) bool {
+	for _, cs := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff" {
+			return true
+		}
+	}
+	return false
+}
+
+func state(job *batchv1.Job) operatorv1.DiagnosticsCollectionState {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return operatorv1.DiagnosticsCollectionSucceeded
+		case batchv1.JobFailed:
+			return operatorv1.DiagnosticsCollectionFailed
+		}
+	}
+	return operatorv1.DiagnosticsCollectionRunning
+}
+
+// JobName returns the name of the Job that collects diagnostics from the node for the given reason. The key tells
+// collections for the same reason apart.
+func JobName(node string, reason operatorv1.DiagnosticsCollectionReason, key string) string {
+	h := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", node, reason, key)))
+	return fmt.Sprintf("calico-diagnostics-%s", hex.EncodeToString(h[:])[:10])
+}
+
+func collectionJob(installation *operatorv1.Installation, image, node string, reason operatorv1.DiagnosticsCollectionReason, key string, now time.Time) *batchv1.Job {
+	file := fmt.Sprintf("%s-%s.tar.gz", node, now.UTC().Format("20060102-150405"))
+	labels := map[string]string{"k8s-app": jobAppLabel, ReasonLabel: string(reason)}
+
+	var ttl *int32
+	if reason == operatorv1.DiagnosticsCollectionCrashLoop {
+		ttl = ptr.Int32ToPtr(crashLoopJobTTL)
+	}
+
+	hostPath := func(name, path string) corev1.Volume {
+		return corev1.Volume{Name: name, VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: path}}}
+	}
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{Kind: "Job", APIVersion: "batch/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        JobName(node, reason, key),
+			Namespace:   common.CalicoNamespace,
+			Labels:      labels,
+			Annotations: map[string]string{nodeAnnotation: node, fileAnnotation: file},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            ptr.Int32ToPtr(1),
+			ActiveDeadlineSeconds:   ptr.Int64ToPtr(jobDeadline),
+			TTLSecondsAfterFinished: ttl,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					// The diagnostics are of the host's network namespace.
+					NodeName:                     node,
+					HostNetwork:                  true,
+					DNSPolicy:                    corev1.DNSClusterFirstWithHostNet,
+					Tolerations:                  rmeta.TolerateAll,
+					RestartPolicy:                corev1.RestartPolicyNever,
+					AutomountServiceAccountToken: ptr.BoolToPtr(false),
+					ImagePullSecrets:             installation.Spec.ImagePullSecrets,
+					Containers: []corev1.Container{{
+						Name:            "collect",
+						Image:           image,
+						Command:         []string{"/bin/sh", "-c", collectScript},
+						Env:             []corev1.EnvVar{{Name: "FILE", Value: file}},
+						SecurityContext: securitycontext.NewRootContext(true),
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "diagnostics", MountPath: "/diagnostics"},
+							{Name: "var-log-calico", MountPath: "/var/log/calico", ReadOnly: true},
+							{Name: "var-log-pods", MountPath: "/var/log/pods", ReadOnly: true},
+							{Name: "bpffs", MountPath: "/sys/fs/bpf"},
+						},
+					}},
+					Volumes: []corev1.Volume{
+						{
+							Name: "diagnostics",
+							VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: installation.Spec.Diagnostics.PersistentVolumeClaimName,
+							}},
+						},
+						hostPath("var-log-calico", "/var/log/calico"),
+						hostPath("var-log-pods", "/var/log/pods"),
+						hostPath("bpffs", "/sys/fs/bpf"),
+					},
+				},
+			},
+		},
+	}
+}
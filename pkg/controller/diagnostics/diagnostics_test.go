@@ -0,0 +1,172 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostics_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/diagnostics"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+var _ = Describe("Diagnostics tests", func() {
+	var (
+		c            client.Client
+		ctx          context.Context
+		scheme       *runtime.Scheme
+		installation *operatorv1.Installation
+		now          time.Time
+	)
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(batchv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(corev1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+		now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+		installation = &operatorv1.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", UID: "installation-uid"},
+			Spec: operatorv1.InstallationSpec{
+				Diagnostics: &operatorv1.DiagnosticsSpec{PersistentVolumeClaimName: "diags"},
+			},
+		}
+		for _, node := range []string{"node-a", "node-b"} {
+			Expect(c.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: node}})).NotTo(HaveOccurred())
+		}
+	})
+
+	getJob := func(name string) *batchv1.Job {
+		job := &batchv1.Job{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: name, Namespace: common.CalicoNamespace}, job)).NotTo(HaveOccurred())
+		return job
+	}
+
+	It("should collect diagnostics from the requested nodes", func() {
+		installation.Annotations = map[string]string{diagnostics.Annotation: "node-a, node-b"}
+		collections, err := diagnostics.Collect(ctx, c, scheme, installation, "calico/node:test", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(collections).To(HaveLen(2))
+		Expect(collections[0]).To(Equal(operatorv1.DiagnosticsCollection{
+			Node:   "node-a",
+			Reason: operatorv1.DiagnosticsCollectionRequested,
+			Job:    diagnostics.JobName("node-a", operatorv1.DiagnosticsCollectionRequested, "node-a"),
+			File:   "node-a-20240501-120000.tar.gz",
+			State:  operatorv1.DiagnosticsCollectionRunning,
+		}))
+		Expect(diagnostics.Running(collections)).To(BeTrue())
+
+		job := getJob(collections[0].Job)
+		Expect(job.OwnerReferences).To(HaveLen(1))
+		Expect(job.Spec.TTLSecondsAfterFinished).To(BeNil())
+		spec := job.Spec.Template.Spec
+		Expect(spec.NodeName).To(Equal("node-a"))
+		Expect(spec.HostNetwork).To(BeTrue())
+		Expect(spec.Containers[0].Image).To(Equal("calico/node:test"))
+		Expect(spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "FILE", Value: "node-a-20240501-120000.tar.gz"}))
+		Expect(spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("diags"))
+
+		// The collections keep their file as time passes, and report when they finish.
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		Expect(c.Status().Update(ctx, job)).NotTo(HaveOccurred())
+		collections, err = diagnostics.Collect(ctx, c, scheme, installation, "calico/node:test", now.Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(collections[0].State).To(Equal(operatorv1.DiagnosticsCollectionSucceeded))
+		Expect(collections[0].File).To(Equal("node-a-20240501-120000.tar.gz"))
+		Expect(collections[1].State).To(Equal(operatorv1.DiagnosticsCollectionRunning))
+
+		// Removing a node from the annotation removes its Job.
+		installation.Annotations[diagnostics.Annotation] = "node-b"
+		collections, err = diagnostics.Collect(ctx, c, scheme, installation, "calico/node:test", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(collections).To(HaveLen(1))
+		Expect(collections[0].Node).To(Equal("node-b"))
+		jobs := &batchv1.JobList{}
+		Expect(c.List(ctx, jobs)).NotTo(HaveOccurred())
+		Expect(jobs.Items).To(HaveLen(1))
+	})
+
+	It("should reject nodes that don't exist", func() {
+		installation.Annotations = map[string]string{diagnostics.Annotation: "node-x"}
+		_, err := diagnostics.Collect(ctx, c, scheme, installation, "calico/node:test", now)
+		Expect(err).To(MatchError(ContainSubstring("node node-x in the operator.tigera.io/collect-diagnostics annotation doesn't exist")))
+	})
+
+	It("should collect diagnostics from nodes whose calico-node pod is crash looping", func() {
+		crashLoop := func(name, node, uid string, reason string) {
+			Expect(c.Create(ctx, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: common.CalicoNamespace,
+					UID:       types.UID(uid),
+					Labels:    map[string]string{"k8s-app": common.NodeDaemonSetName},
+				},
+				Spec: corev1.PodSpec{NodeName: node},
+				Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "calico-node",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}},
+				}}},
+			})).NotTo(HaveOccurred())
+		}
+		crashLoop("calico-node-a", "node-a", "uid-a", "CrashLoopBackOff")
+		crashLoop("calico-node-b", "node-b", "uid-b", "ContainerCreating")
+
+		// Crash looping pods are ignored unless enabled.
+		collections, err := diagnostics.Collect(ctx, c, scheme, installation, "calico/node:test", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(collections).To(BeEmpty())
+
+		enabled := operatorv1.CollectOnCrashLoopEnabled
+		installation.Spec.Diagnostics.CollectOnCrashLoop = &enabled
+		collections, err = diagnostics.Collect(ctx, c, scheme, installation, "calico/node:test", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(collections).To(HaveLen(1))
+		Expect(collections[0].Node).To(Equal("node-a"))
+		Expect(collections[0].Reason).To(Equal(operatorv1.DiagnosticsCollectionCrashLoop))
+		Expect(getJob(collections[0].Job).Spec.TTLSecondsAfterFinished).To(Equal(ptr.Int32ToPtr(24 * 60 * 60)))
+
+		// The Job is kept once the pod recovers, until its TTL removes it.
+		pod := &corev1.Pod{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: "calico-node-a", Namespace: common.CalicoNamespace}, pod)).NotTo(HaveOccurred())
+		Expect(c.Delete(ctx, pod)).NotTo(HaveOccurred())
+		collections, err = diagnostics.Collect(ctx, c, scheme, installation, "calico/node:test", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(collections).To(HaveLen(1))
+
+		// Without the diagnostics configuration, every Job is removed.
+		installation.Status.Diagnostics = collections
+		installation.Spec.Diagnostics = nil
+		collections, err = diagnostics.Collect(ctx, c, scheme, installation, "calico/node:test", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(collections).To(BeEmpty())
+	})
+})
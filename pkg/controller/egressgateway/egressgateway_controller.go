@@ -18,7 +18,6 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/go-logr/logr"
 	ocsv1 "github.com/openshift/api/security/v1"
@@ -45,6 +44,7 @@ import (
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/controller/utils/imageset"
 	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/egressgateway"
@@ -78,7 +78,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		return err
 	}
 
-	go utils.WaitToAddLicenseKeyWatch(c, k8sClient, log, licenseAPIReady)
+	go utils.WaitToAddLicenseKeyWatch(opts.ShutdownContext, c, k8sClient, log, licenseAPIReady)
 
 	return add(mgr, c)
 }
@@ -105,7 +105,7 @@ func add(_ manager.Manager, c ctrlruntime.Controller) error {
 	var err error
 
 	// Watch for changes to primary resource Egress Gateway.
-	err = c.WatchObject(&operatorv1.EgressGateway{}, &handler.EnqueueRequestForObject{})
+	err = c.WatchObject(&operatorv1.EgressGateway{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate)
 	if err != nil {
 		return err
 	}
@@ -146,7 +146,7 @@ type ReconcileEgressGateway struct {
 // Reconcile reads that state of the cluster for an EgressGateway object and makes changes
 // based on the state read and what is in the EgressGateway.Spec.
 func (r *ReconcileEgressGateway) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling EgressGateway")
 
 	// Get all the Egress Gateway resources available.
@@ -158,7 +158,7 @@ func (r *ReconcileEgressGateway) Reconcile(ctx context.Context, request reconcil
 	}
 
 	// If there are no Egress Gateway resources, return.
-	ch := utils.NewComponentHandler(log, r.client, r.scheme, nil)
+	ch := utils.NewComponentHandler(logging.ForReconcile(ctx, log), r.client, r.scheme, nil)
 	if len(egws) == 0 {
 		var objects []client.Object
 		if r.provider.IsOpenShift() {
@@ -244,9 +244,11 @@ func (r *ReconcileEgressGateway) Reconcile(ctx context.Context, request reconcil
 	// Get the unready EGW.
 	unreadyEGW := getUnreadyEgressGateway(egws)
 
-	if !r.licenseAPIReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for LicenseKeyAPI to be ready", nil, reqLogger)
-		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	if _, result, err := (utils.Prerequisites{
+		UpgradeStage: operatorv1.UpgradeStageComponents,
+		LicenseAPI:   r.licenseAPIReady,
+	}).Check(ctx, r.client, r.status, reqLogger); result != nil {
+		return *result, err
 	}
 
 	variant, installation, err := utils.GetInstallation(ctx, r.client)
@@ -406,7 +408,7 @@ func (r *ReconcileEgressGateway) reconcileEgressGateway(ctx context.Context, egw
 	}
 
 	component := egressgateway.EgressGateway(config)
-	ch := utils.NewComponentHandler(log, r.client, r.scheme, egw)
+	ch := utils.NewComponentHandler(logging.ForReconcile(ctx, log), r.client, r.scheme, egw)
 
 	if err = imageset.ApplyImageSet(ctx, r.client, variant, component); err != nil {
 		reqLogger.Error(err, "Error with images from ImageSet")
@@ -665,27 +667,15 @@ func setAvailable(cli client.Client, ctx context.Context, egw *operatorv1.Egress
 	updateEGWStatusConditions(cli, ctx, egw, operatorv1.ComponentAvailable, metav1.ConditionTrue, reason, msg, true)
 }
 
-// updateEGWStatusConditions sets the status conditions of the EGW resource and if updateStatus is True, status of the EGW resource
-// is updated in the datastore.
-func updateEGWStatusConditions(cli client.Client, ctx context.Context, egw *operatorv1.EgressGateway, ctype operatorv1.StatusConditionType, status metav1.ConditionStatus, reason, msg string, updateStatus bool) {
-	found := false
-	for idx, cond := range egw.Status.Conditions {
-		if cond.Type == string(ctype) {
-			cond.Status = status
-			cond.Reason = reason
-			cond.Message = msg
-			found = true
+// updateEGWStatusConditions sets the given status condition of the EGW resource, and sets the other standard
+// conditions to false. If updateStatus is True, status of the EGW resource is updated in the datastore.
+func updateEGWStatusConditions(cli client.Client, ctx context.Context, egw *operatorv1.EgressGateway, ctype operatorv1.StatusConditionType, condStatus metav1.ConditionStatus, reason, msg string, updateStatus bool) {
+	for _, t := range []operatorv1.StatusConditionType{operatorv1.ComponentAvailable, operatorv1.ComponentProgressing, operatorv1.ComponentDegraded} {
+		if t == ctype {
+			status.SetStatusCondition(&egw.Status.Conditions, t, condStatus, reason, msg, egw.Generation)
 		} else {
-			cond.Status = metav1.ConditionFalse
-			cond.Reason = string(operatorv1.Unknown)
-			cond.Message = ""
+			status.SetStatusCondition(&egw.Status.Conditions, t, metav1.ConditionFalse, string(operatorv1.Unknown), "", egw.Generation)
 		}
-		cond.LastTransitionTime = metav1.NewTime(time.Now())
-		egw.Status.Conditions[idx] = cond
-	}
-	if !found {
-		condition := metav1.Condition{Type: string(ctype), Status: status, Reason: reason, Message: msg, LastTransitionTime: metav1.NewTime(time.Now())}
-		egw.Status.Conditions = append(egw.Status.Conditions, condition)
 	}
 	if updateStatus {
 		if err := cli.Status().Update(ctx, egw); err != nil {
@@ -695,10 +685,8 @@ func updateEGWStatusConditions(cli client.Client, ctx context.Context, egw *oper
 }
 
 func getDegradedMsg(egw *operatorv1.EgressGateway) string {
-	for _, cond := range egw.Status.Conditions {
-		if cond.Type == string(operatorv1.ComponentDegraded) {
-			return cond.Message
-		}
+	if cond := status.FindStatusCondition(egw.Status.Conditions, operatorv1.ComponentDegraded); cond != nil {
+		return cond.Message
 	}
 	return ""
 }
@@ -23,8 +23,10 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/elastic/cloud-on-k8s/v2/pkg/utils/stringsutil"
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
@@ -38,6 +40,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -63,18 +66,28 @@ import (
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/components"
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
+	"github.com/tigera/operator/pkg/controller/diagnostics"
 	"github.com/tigera/operator/pkg/controller/k8sapi"
+	"github.com/tigera/operator/pkg/controller/maintenance"
 	"github.com/tigera/operator/pkg/controller/migration"
 	"github.com/tigera/operator/pkg/controller/migration/convert"
+	"github.com/tigera/operator/pkg/controller/nftablesrollout"
+	"github.com/tigera/operator/pkg/controller/noderollout"
 	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/preflight"
+	"github.com/tigera/operator/pkg/controller/rollback"
 	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/typhahealth"
+	"github.com/tigera/operator/pkg/controller/upgrade"
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/controller/utils/imageset"
 	"github.com/tigera/operator/pkg/crds"
 	"github.com/tigera/operator/pkg/ctrlruntime"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render"
 	rcertificatemanagement "github.com/tigera/operator/pkg/render/certificatemanagement"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/common/resourcequota"
 	"github.com/tigera/operator/pkg/render/kubecontrollers"
@@ -145,9 +158,9 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		}
 
 		// Watch for changes to Tier, as its status is used as input to determine whether network policy should be reconciled by this controller.
-		go utils.WaitToAddTierWatch(networkpolicy.TigeraComponentTierName, c, k8sClient, log, ri.tierWatchReady)
+		go utils.WaitToAddTierWatch(opts.ShutdownContext, networkpolicy.TigeraComponentTierName, c, k8sClient, log, ri.tierWatchReady)
 
-		go utils.WaitToAddNetworkPolicyWatches(c, k8sClient, log, []types.NamespacedName{
+		go utils.WaitToAddNetworkPolicyWatches(opts.ShutdownContext, c, k8sClient, log, []types.NamespacedName{
 			{Name: kubecontrollers.KubeControllerNetworkPolicyName, Namespace: common.CalicoNamespace},
 		},
 		)
@@ -193,6 +206,7 @@ func newReconciler(mgr manager.Manager, opts options.AddOptions) (*ReconcileInst
 		clusterDomain:        opts.ClusterDomain,
 		manageCRDs:           opts.ManageCRDs,
 		tierWatchReady:       &utils.ReadyFlag{},
+		readTyphaMetrics:     typhahealth.HTTPMetricsReader,
 		newComponentHandler:  utils.NewComponentHandler,
 	}
 	r.status.Run(opts.ShutdownContext)
@@ -366,6 +380,9 @@ type ReconcileInstallation struct {
 	manageCRDs           bool
 	tierWatchReady       *utils.ReadyFlag
 
+	// readTyphaMetrics reads the Prometheus metrics of a Typha pod. Useful stub for unit testing.
+	readTyphaMetrics typhahealth.MetricsReader
+
 	// newComponentHandler returns a new component handler. Useful stub for unit testing.
 	newComponentHandler func(log logr.Logger, client client.Client, scheme *runtime.Scheme, cr metav1.Object) utils.ComponentHandler
 }
@@ -395,6 +412,17 @@ func updateInstallationWithDefaults(ctx context.Context, client client.Client, i
 		return err
 	}
 
+	if instance.Spec.ControlPlaneTopology == nil && instance.Spec.KubernetesProvider.IsOpenShift() {
+		hosted, err := isOpenShiftHostedControlPlane(ctx, client)
+		if err != nil {
+			return err
+		}
+		if hosted {
+			topology := operator.ControlPlaneTopologyHosted
+			instance.Spec.ControlPlaneTopology = &topology
+		}
+	}
+
 	awsNode := &appsv1.DaemonSet{}
 	key := types.NamespacedName{Name: "aws-node", Namespace: metav1.NamespaceSystem}
 	err = client.Get(ctx, key, awsNode)
@@ -424,6 +452,8 @@ func MergeAndFillDefaults(i *operator.Installation, awsNode *appsv1.DaemonSet, c
 		if err := updateInstallationForAWSNode(i, awsNode); err != nil {
 			return fmt.Errorf("could not resolve AWS node configuration: %s", err.Error())
 		}
+	} else {
+		i.Status.EKSNetworking = nil
 	}
 
 	return fillDefaults(i, currentPools)
@@ -560,6 +590,10 @@ func fillDefaults(instance *operator.Installation, currentPools *crdv1.IPPoolLis
 			case operator.ProviderEKS:
 				// On EKS, we use VXLAN mode with Calico CNI so default BGP off.
 				instance.Spec.CalicoNetwork.BGP = &disabled
+			case operator.ProviderAKS:
+				// On AKS with Calico CNI (BYOCNI), the Azure network doesn't route pod IPs or carry IP-in-IP, so we use
+				// VXLAN mode and default BGP off.
+				instance.Spec.CalicoNetwork.BGP = &disabled
 			default:
 				// Other platforms assume BGP is needed.
 				instance.Spec.CalicoNetwork.BGP = &enabled
@@ -635,6 +669,11 @@ func fillDefaults(instance *operator.Installation, currentPools *crdv1.IPPoolLis
 			instance.Spec.CalicoNetwork.HostPorts = &hp
 		}
 
+		if instance.Spec.CalicoNetwork.BandwidthPlugin == nil {
+			bp := operator.BandwidthPluginEnabled
+			instance.Spec.CalicoNetwork.BandwidthPlugin = &bp
+		}
+
 		if instance.Spec.CalicoNetwork.MultiInterfaceMode == nil {
 			mm := operator.MultiInterfaceModeNone
 			instance.Spec.CalicoNetwork.MultiInterfaceMode = &mm
@@ -735,7 +774,7 @@ func mergeProvider(cr *operator.Installation, provider operator.Provider) error
 }
 
 func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling Installation.operator.tigera.io")
 
 	newActiveCM, err := r.checkActive(reqLogger)
@@ -810,11 +849,14 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		r.status.SetDegraded(operator.ResourceReadError, "Error querying installation", err, reqLogger)
 		return reconcile.Result{}, err
 	}
+	// Defaulting records what it detected about the Amazon VPC CNI in the status. Hold on to it, since writing the
+	// defaults back replaces the status with the stored one.
+	eksNetworking := instance.Status.EKSNetworking
 	reqLogger.V(2).Info("Loaded config", "installation", instance)
 
 	// Validate the configuration.
 	if err := validateCustomResource(instance); err != nil {
-		r.status.SetDegraded(operator.InvalidConfigurationError, "Invalid Installation provided", err, reqLogger)
+		r.status.SetDegraded(validationReason(err), "Invalid Installation provided", err, reqLogger)
 		return reconcile.Result{}, err
 	}
 
@@ -894,11 +936,50 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 
 		// Validate the configuration.
 		if err := validateCustomResource(instance); err != nil {
-			r.status.SetDegraded(operator.InvalidConfigurationError, "Invalid computed config", err, reqLogger)
+			r.status.SetDegraded(validationReason(err), "Invalid computed config", err, reqLogger)
 			return reconcile.Result{}, err
 		}
 	}
 
+	// Azure Network Policy Manager enforces network policy with its own iptables rules, which conflict with Calico's.
+	if instance.Spec.KubernetesProvider.IsAKS() {
+		npm, err := azureNPMDaemonSet(ctx, r.client)
+		if err != nil {
+			r.status.SetDegraded(operator.ResourceReadError, "Unable to check for Azure Network Policy Manager", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		if npm != "" {
+			r.status.SetDegraded(operator.ProviderConfigurationError,
+				fmt.Sprintf("Azure Network Policy Manager (daemonset %s) conflicts with Calico network policy, disable it with 'az aks update --network-policy none'", npm),
+				nil, reqLogger)
+			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+		}
+	}
+
+	// When the installed version differs from this operator's, roll out the new version one stage at a time. Stages
+	// that haven't been reached yet are left at the installed version.
+	targetVersion := components.CalicoRelease
+	if instance.Spec.Variant == operator.TigeraSecureEnterprise {
+		targetVersion = components.EnterpriseRelease
+	}
+	upgradeStatus := upgrade.Start(instance.Status.Upgrade.DeepCopy(), instance.Status.CalicoVersion, targetVersion)
+	coordinator := upgrade.NewCoordinator(r.client, r.scheme, instance, instance.Spec.UpgradeHooks, instance.Spec.ImagePullSecrets, r.upgradeRolledOut(&instance.Spec, instance.Status.DeferredRollouts))
+	upgradeErr := coordinator.Advance(ctx, upgradeStatus)
+	if !reflect.DeepEqual(upgradeStatus, instance.Status.Upgrade) {
+		if err := r.writeStatus(ctx, instance, func(s *operator.InstallationStatus) { s.Upgrade = upgradeStatus }); err != nil {
+			r.status.SetDegraded(operator.ResourceUpdateError, "Failed to write upgrade status", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+	if upgradeErr != nil {
+		r.status.SetDegraded(operator.UpgradeError, "Upgrade is blocked", upgradeErr, reqLogger)
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
+	if !upgrade.Allowed(upgradeStatus, operator.UpgradeStageCRDs) {
+		reqLogger.Info("Waiting for pre-upgrade hooks before updating CRDs")
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
+
 	if err = r.updateCRDs(ctx, instance.Spec.Variant, reqLogger); err != nil {
 		return reconcile.Result{}, err
 	}
@@ -1100,13 +1181,48 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		return reconcile.Result{}, err
 	}
 
-	// Set any non-default FelixConfiguration values that we need.
+	// Carry over the BPF setting of an older operator. The defaults that are derived from the Installation are applied
+	// by the felix defaults controller.
 	felixConfiguration, err := utils.PatchFelixConfiguration(ctx, r.client, func(fc *crdv1.FelixConfiguration) (bool, error) {
-		return r.setDefaultsOnFelixConfiguration(ctx, instance, fc, reqLogger)
+		return r.migrateBPFEnabledOnFelixConfiguration(ctx, fc, reqLogger)
 	})
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	if err = r.reportFelixConfigurationConflicts(ctx, instance, felixConfiguration); err != nil {
+		r.status.SetDegraded(operator.ResourceUpdateError, "Failed to report FelixConfiguration conflicts", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	// Check the node prerequisites of the configured features. The results are written straight away, since they can
+	// hold back calico-node, and the reconcile doesn't get to the end while calico-node isn't available.
+	healthPort := felixHealthPort(&instance.Spec, felixConfiguration)
+	preflightStatus, preflightCfg, err := preflight.Reconcile(ctx, r.client, instance, healthPort, time.Now())
+	if err != nil {
+		r.status.SetDegraded(operator.ResourceReadError, "Error reading preflight results", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	if !reflect.DeepEqual(preflightStatus, instance.Status.Preflight) {
+		if err := r.writeStatus(ctx, instance, func(s *operator.InstallationStatus) { s.Preflight = preflightStatus }); err != nil {
+			r.status.SetDegraded(operator.ResourceUpdateError, "Failed to write preflight status", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+	portsAllowed := preflight.PortsAllowed(instance)
+
+	// Roll out a change of the nftables mode in the FelixConfiguration, canary nodes first. Like the preflight results,
+	// its progress is written straight away.
+	nftablesRollout, err := nftablesrollout.Advance(ctx, r.client, instance.Spec.NFTablesModeRollout, nftablesrollout.DesiredMode(felixConfiguration), instance.Status.NFTablesModeRollout, time.Now())
+	if !reflect.DeepEqual(nftablesRollout, instance.Status.NFTablesModeRollout) {
+		if err := r.writeStatus(ctx, instance, func(s *operator.InstallationStatus) { s.NFTablesModeRollout = nftablesRollout }); err != nil {
+			r.status.SetDegraded(operator.ResourceUpdateError, "Failed to write nftables mode rollout status", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+	if err != nil {
+		r.status.SetDegraded(operator.ResourceUpdateError, "Error rolling out the nftables mode", err, reqLogger)
+		return reconcile.Result{}, err
+	}
 
 	// nodeReporterMetricsPort is a port used in Enterprise to host internal metrics.
 	// Operator is responsible for creating a service which maps to that port.
@@ -1161,6 +1277,16 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		calicoVersion = components.EnterpriseRelease
 	}
 
+	// calico/node and calico/typha both run on the host network, so make sure that the ports they bind to don't conflict.
+	reporterPort := 0
+	if instance.Spec.Variant == operator.TigeraSecureEnterprise {
+		reporterPort = nodeReporterMetricsPort
+	}
+	if err := validateHostNetworkPorts(&instance.Spec, healthPort, reporterPort); err != nil {
+		r.status.SetDegraded(operator.InvalidConfigurationError, "Conflicting host network ports", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
 	kubeControllersMetricsPort, err := utils.GetKubeControllerMetricsPort(ctx, r.client)
 	if err != nil {
 		r.status.SetDegraded(operator.ResourceReadError, "Unable to read KubeControllersConfiguration", err, reqLogger)
@@ -1261,9 +1387,11 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		TLS:               typhaNodeTLS,
 		MigrateNamespaces: needNsMigration,
 		ClusterDomain:     r.clusterDomain,
-		FelixHealthPort:   *felixConfiguration.Spec.HealthPort,
+		FelixHealthPort:   healthPort,
+	}
+	if upgrade.Allowed(upgradeStatus, operator.UpgradeStageTypha) && portsAllowed {
+		components = append(components, render.Typha(&typhaCfg))
 	}
-	components = append(components, render.Typha(&typhaCfg))
 
 	// See the section 'Use of Finalizers for graceful termination' at the top of this file for terminating details.
 	canRemoveCNI := false
@@ -1334,17 +1462,26 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		MigrateNamespaces:       needNsMigration,
 		CanRemoveCNIFinalizer:   canRemoveCNI,
 		PrometheusServerTLS:     nodePrometheusTLS,
-		FelixHealthPort:         *felixConfiguration.Spec.HealthPort,
+		FelixHealthPort:         healthPort,
 		BindMode:                bgpConfiguration.Spec.BindMode,
+		NFTablesMode:            nftablesrollout.RenderedMode(nftablesRollout),
+		NFTablesModeCanary:      nftablesrollout.Canary(nftablesRollout),
 	}
-	components = append(components, render.Node(&nodeCfg))
+	if upgrade.Allowed(upgradeStatus, operator.UpgradeStageNode) && portsAllowed {
+		components = append(components, render.Node(&nodeCfg))
+	}
+
+	// The remaining components are the last stage of an upgrade.
+	upgradeComponents := upgrade.Allowed(upgradeStatus, operator.UpgradeStageComponents)
 
 	csiCfg := render.CSIConfiguration{
 		Installation: &instance.Spec,
 		Terminating:  installationMarkedForDeletion,
 		OpenShift:    instance.Spec.KubernetesProvider.IsOpenShift(),
 	}
-	components = append(components, render.CSI(&csiCfg))
+	if upgradeComponents {
+		components = append(components, render.CSI(&csiCfg))
+	}
 
 	// Build a configuration for rendering calico/kube-controllers.
 	kubeControllersCfg := kubecontrollers.KubeControllersConfiguration{
@@ -1360,12 +1497,14 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		Namespace:                   common.CalicoNamespace,
 		BindingNamespaces:           []string{common.CalicoNamespace},
 	}
-	components = append(components, kubecontrollers.NewCalicoKubeControllers(&kubeControllersCfg))
+	if upgradeComponents {
+		components = append(components, kubecontrollers.NewCalicoKubeControllers(&kubeControllersCfg))
+	}
 
 	// v3 NetworkPolicy will fail to reconcile if the API server deployment is unhealthy. In case the API Server
 	// deployment becomes unhealthy and reconciliation of non-NetworkPolicy resources in the core controller
 	// would resolve it, we render the network policies of components last to prevent a chicken-and-egg scenario.
-	if includeV3NetworkPolicy {
+	if includeV3NetworkPolicy && upgradeComponents {
 		components = append(components,
 			kubecontrollers.NewCalicoKubeControllersPolicy(&kubeControllersCfg),
 			render.NewPassthrough(networkpolicy.AllowTigeraDefaultDeny(common.CalicoNamespace)),
@@ -1383,20 +1522,51 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		return reconcile.Result{}, err
 	}
 
-	if err = imageset.ResolveImages(imageSet, components...); err != nil {
+	// The preflight DaemonSet is short-lived and doesn't disrupt anything, so it's handled apart from the other
+	// components: its changes aren't deferred to a maintenance window or rolled back.
+	preflightComponent := render.Preflight(preflightCfg)
+	if err = imageset.ResolveImages(imageSet, append(components, preflightComponent)...); err != nil {
 		r.status.SetDegraded(operator.ResourceValidationError, "Error resolving ImageSet for components", err, reqLogger)
 		return reconcile.Result{}, err
 	}
 
+	// Workloads listed in the rollback annotation are applied from their last known-good render instead of the spec.
+	rollbackTracker, err := rollback.NewTracker(ctx, r.client, r.scheme, instance)
+	if err != nil {
+		r.status.SetDegraded(operator.ResourceReadError, "Error reading known-good renders", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	rolledBack := rollbackTracker.RolledBack()
+	for _, rb := range rolledBack {
+		reqLogger.Info("Component diverges from the Installation spec", "component", rb.Name, "reason", rb.Message)
+	}
+
+	// Disruptive changes, such as those that restart calico-node, wait for a maintenance window.
+	maintenanceOpen, nextWindow, err := maintenance.Open(instance.Spec.MaintenanceWindows, time.Now())
+	if err != nil {
+		r.status.SetDegraded(operator.ResourceValidationError, "Error parsing maintenance windows", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	deferrer := maintenance.NewDeferrer(ctx, r.client, maintenanceOpen)
+
 	// Create a component handler to create or update the rendered components.
 	handler := r.newComponentHandler(log, r.client, r.scheme, instance)
 	for _, component := range components {
-		if err := handler.CreateOrUpdateOrDelete(ctx, component, nil); err != nil {
+		if err := handler.CreateOrUpdateOrDelete(ctx, rollbackTracker.Wrap(deferrer.Wrap(component)), nil); err != nil {
 			r.status.SetDegraded(operator.ResourceUpdateError, "Error creating / updating resource", err, reqLogger)
 			return reconcile.Result{}, err
 		}
 	}
 
+	if err := handler.CreateOrUpdateOrDelete(ctx, preflightComponent, nil); err != nil {
+		r.status.SetDegraded(operator.ResourceUpdateError, "Error creating / updating resource", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	if !portsAllowed {
+		r.status.SetDegraded(operator.ResourceNotReady, "Waiting for the preflight checks of the ports to pass before installing calico-node", errors.New(preflight.Summary(instance.Status.Preflight)), reqLogger)
+		return reconcile.Result{RequeueAfter: preflight.RequeueAfter(instance.Status.Preflight, time.Now())}, nil
+	}
+
 	// TODO: We handle too many components in this controller at the moment. Once we are done consolidating,
 	// we can have the CreateOrUpdate logic handle this for us.
 	r.status.AddDaemonsets([]types.NamespacedName{{Name: common.NodeDaemonSetName, Namespace: common.CalicoNamespace}})
@@ -1421,6 +1591,57 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		}
 	}
 
+	// Replace the calico-node pods one node at a time, if the rollout is coordinated by the operator. Its progress is
+	// written straight away, since the reconcile doesn't get to the end while calico-node isn't available. The pods
+	// of the canary nodes of a change of the nftables mode are replaced first, before the other nodes are rolled out.
+	nodeRollout := instance.Status.NodeRollout
+	if !nftablesrollout.Canary(nftablesRollout) {
+		nodeRollout, err = noderollout.Advance(ctx, r.client, instance.Spec.CoordinatedNodeRollout, instance.Status.NodeRollout)
+		if !reflect.DeepEqual(nodeRollout, instance.Status.NodeRollout) {
+			if err := r.writeStatus(ctx, instance, func(s *operator.InstallationStatus) { s.NodeRollout = nodeRollout }); err != nil {
+				r.status.SetDegraded(operator.ResourceUpdateError, "Failed to write node rollout status", err, reqLogger)
+				return reconcile.Result{}, err
+			}
+		}
+		if err != nil {
+			r.status.SetDegraded(operator.ResourceUpdateError, "Error rolling out calico-node", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
+	// Collect Felix diagnostics from the nodes that they're requested from, or whose calico-node pod is crash looping.
+	// Like the rollout, the collections are written straight away.
+	nodeImage, err := render.NodeImage(&instance.Spec, imageSet)
+	if err != nil {
+		r.status.SetDegraded(operator.ResourceValidationError, "Error resolving ImageSet for components", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	collections, err := diagnostics.Collect(ctx, r.client, r.scheme, instance, nodeImage, time.Now())
+	if !reflect.DeepEqual(collections, instance.Status.Diagnostics) {
+		if err := r.writeStatus(ctx, instance, func(s *operator.InstallationStatus) { s.Diagnostics = collections }); err != nil {
+			r.status.SetDegraded(operator.ResourceUpdateError, "Failed to write diagnostics status", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+	if err != nil {
+		r.status.SetDegraded(operator.ResourceUpdateError, "Error collecting diagnostics", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	// Summarize the connections from Felix to Typha, if Typha serves metrics. Like the diagnostics, the summary is
+	// written straight away, since Typha saturation is most useful to see when things aren't healthy.
+	typhaHealth, err := typhahealth.Collect(ctx, r.client, instance, r.readTyphaMetrics, time.Now())
+	if err != nil {
+		r.status.SetDegraded(operator.ResourceReadError, "Error collecting Typha connection health", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	if !reflect.DeepEqual(typhaHealth, instance.Status.TyphaHealth) {
+		if err := r.writeStatus(ctx, instance, func(s *operator.InstallationStatus) { s.TyphaHealth = typhaHealth }); err != nil {
+			r.status.SetDegraded(operator.ResourceUpdateError, "Failed to write Typha health status", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
 	// Determine which MTU to use in the status fields.
 	statusMTU := 0
 	if instance.Spec.CalicoNetwork != nil && instance.Spec.CalicoNetwork.MTU != nil {
@@ -1480,8 +1701,15 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 
 	// We can clear the degraded state now since as far as we know everything is in order.
 	r.status.ClearDegraded()
+	available := r.status.IsAvailable()
 
-	if !r.status.IsAvailable() {
+	// A change of the nftables mode that was rolled back is reported as degraded until the FelixConfiguration changes
+	// again. calico-node runs the previous mode, so this doesn't hold up the rest of the reconcile.
+	if nftablesrollout.RolledBack(nftablesRollout) {
+		r.status.SetDegraded(operator.ResourceNotReady, "Change of the nftables mode was rolled back", errors.New(nftablesRollout.Message), reqLogger)
+	}
+
+	if !available {
 		// Schedule a kick to check again in the near future. Hopefully by then
 		// things will be available.
 		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
@@ -1492,19 +1720,62 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		return reconcile.Result{}, errors.New("the MTU size should be between Max int32 (2147483647) and 0")
 	}
 	instance.Status.MTU = int32(statusMTU)
-	// Variant and CalicoVersion must be updated at the same time.
-	instance.Status.Variant = instance.Spec.Variant
-	instance.Status.CalicoVersion = calicoVersion
+	// Variant and CalicoVersion must be updated at the same time, and only once every stage of an upgrade is complete.
+	upgrading := upgrade.InProgress(upgradeStatus)
+	if !upgrading {
+		instance.Status.Variant = instance.Spec.Variant
+		instance.Status.CalicoVersion = calicoVersion
+
+		// Everything is available, so whatever has rolled out is now the known-good render to roll back to.
+		if err = rollbackTracker.Record(ctx); err != nil {
+			r.status.SetDegraded(operator.ResourceUpdateError, "Error storing known-good renders", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+	instance.Status.Upgrade = upgradeStatus
+	instance.Status.RolledBack = rolledBack
+	instance.Status.DeferredRollouts = deferrer.Deferred()
 	if imageSet == nil {
 		instance.Status.ImageSet = ""
 	} else {
 		instance.Status.ImageSet = imageSet.Name
 	}
 	instance.Status.Computed = &instance.Spec
+	instance.Status.EKSNetworking = eksNetworking
 	if err = r.client.Status().Update(ctx, instance); err != nil {
 		return reconcile.Result{}, err
 	}
 
+	if upgrading {
+		// Hook Jobs and rollouts aren't watched, so check on the upgrade periodically until it's done.
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
+	if noderollout.InProgress(nodeRollout) || nftablesrollout.InProgress(nftablesRollout) {
+		// Pods and nodes aren't watched, so check on the rollout periodically until it's done.
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
+	if diagnostics.Running(collections) {
+		// Jobs aren't watched, so check on the collections until they finish.
+		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	}
+	if d := preflight.RequeueAfter(instance.Status.Preflight, time.Now()); d > 0 {
+		// Preflight pods aren't watched, so check on the results until every node has reported, and run failed checks
+		// again later.
+		return reconcile.Result{RequeueAfter: d}, nil
+	}
+	// Typha metrics aren't watched, so scrape them again periodically.
+	scrapeIn := typhahealth.RequeueAfter(instance.Status.TyphaHealth, time.Now())
+	if len(instance.Status.DeferredRollouts) > 0 && nextWindow > 0 {
+		reqLogger.Info("Deferring disruptive changes until the next maintenance window", "workloads", instance.Status.DeferredRollouts, "opensIn", nextWindow)
+		if scrapeIn > 0 && scrapeIn < nextWindow {
+			return reconcile.Result{RequeueAfter: scrapeIn}, nil
+		}
+		return reconcile.Result{RequeueAfter: nextWindow}, nil
+	}
+	if scrapeIn > 0 {
+		return reconcile.Result{RequeueAfter: scrapeIn}, nil
+	}
+
 	reqLogger.V(1).Info("Finished reconciling Installation")
 	return reconcile.Result{}, nil
 }
@@ -1605,87 +1876,11 @@ func getOrCreateTyphaNodeTLSConfig(cli client.Client, certificateManager certifi
 	}, nil
 }
 
-// setDefaultOnFelixConfiguration will take the passed in fc and add any defaulting needed
-// based on the install config.
-func (r *ReconcileInstallation) setDefaultsOnFelixConfiguration(ctx context.Context, install *operator.Installation, fc *crdv1.FelixConfiguration, reqLogger logr.Logger) (bool, error) {
+// migrateBPFEnabledOnFelixConfiguration will take the passed in fc and enable BPF on it if the calico-node
+// daemonset was rendered with BPF enabled by an older operator.
+func (r *ReconcileInstallation) migrateBPFEnabledOnFelixConfiguration(ctx context.Context, fc *crdv1.FelixConfiguration, reqLogger logr.Logger) (bool, error) {
 	updated := false
 
-	switch install.Spec.CNI.Type {
-	// If we're using the AWS CNI plugin we need to ensure the route tables that calico-node
-	// uses do not conflict with the ones the AWS CNI plugin uses so default them
-	// in the FelixConfiguration if they are not already set.
-	case operator.PluginAmazonVPC:
-		if fc.Spec.RouteTableRange == nil {
-			updated = true
-			// Defaulting based on that AWS might be using the following:
-			// - The ENI device number + 1
-			//   Currently the max number of ENIs for any host is 15.
-			//   p4d.24xlarge is reported to support 4x15 ENI but it uses 4 cards
-			//   and AWS CNI only uses ENIs on card 0.
-			// - The VLAN table ID + 100 (there is doubt if this is true)
-			fc.Spec.RouteTableRange = &crdv1.RouteTableRange{
-				Min: 65,
-				Max: 99,
-			}
-		}
-	case operator.PluginGKE:
-		if fc.Spec.RouteTableRange == nil {
-			updated = true
-			// Don't conflict with the GKE CNI plugin's routes.
-			fc.Spec.RouteTableRange = &crdv1.RouteTableRange{
-				Min: 10,
-				Max: 250,
-			}
-		}
-	}
-
-	// Determine the felix health port to use. Prefer the configuration from FelixConfiguration,
-	// but default to 9099 (or 9199 on OpenShift). We will also write back whatever we select to FelixConfiguration.
-	felixHealthPort := 9099
-	if install.Spec.KubernetesProvider.IsOpenShift() {
-		felixHealthPort = 9199
-	}
-	if fc.Spec.HealthPort == nil {
-		fc.Spec.HealthPort = &felixHealthPort
-		updated = true
-	}
-	vxlanVNI := 4096
-	if fc.Spec.VXLANVNI == nil {
-		fc.Spec.VXLANVNI = &vxlanVNI
-		updated = true
-	}
-
-	if install.Spec.Variant == operator.TigeraSecureEnterprise {
-		// Some platforms need a different default setting for dnsTrustedServers, because their DNS service is not named "kube-dns".
-		dnsService := ""
-		switch install.Spec.KubernetesProvider {
-		case operator.ProviderOpenShift:
-			dnsService = "k8s-service:openshift-dns/dns-default"
-		case operator.ProviderRKE2:
-			dnsService = "k8s-service:kube-system/rke2-coredns-rke2-coredns"
-		}
-		if dnsService != "" {
-			felixDefault := "k8s-service:kube-dns"
-			trustedServers := []string{dnsService}
-			// Keep any other values that are already configured, excepting the value
-			// that we are setting and the kube-dns default.
-			existingSetting := ""
-			if fc.Spec.DNSTrustedServers != nil {
-				existingSetting = strings.Join(*(fc.Spec.DNSTrustedServers), ",")
-				for _, server := range *(fc.Spec.DNSTrustedServers) {
-					if server != felixDefault && server != dnsService {
-						trustedServers = append(trustedServers, server)
-					}
-				}
-			}
-			newSetting := strings.Join(trustedServers, ",")
-			if newSetting != existingSetting {
-				fc.Spec.DNSTrustedServers = &trustedServers
-				updated = true
-			}
-		}
-	}
-
 	// If BPF is enabled, but not set on FelixConfiguration, do so here. This could happen when an older
 	// version of operator is replaced by the new one. Older versions of the operator used an
 	// environment variable to enable BPF, but we no longer do so. In order to prevent disruption
@@ -1732,7 +1927,7 @@ func (r *ReconcileInstallation) setBPFUpdatesOnFelixConfiguration(ctx context.Co
 		if err != nil {
 			return false, err
 		}
-		if !bpfEnabledOnFelixConfig(fc) && isRolloutCompleteWithBPFVolumes(ds) {
+		if !bpfEnabledOnFelixConfig(fc) && isRolloutCompleteWithBPFVolumes(ds) && preflight.Allowed(install, render.PreflightCheckBPFKernel) {
 			err := setBPFEnabledOnFelixConfiguration(fc, bpfEnabledOnInstall)
 			if err != nil {
 				reqLogger.Error(err, "Unable to enable eBPF data plane")
@@ -1791,6 +1986,122 @@ func (r *ReconcileInstallation) checkActive(log logr.Logger) (*corev1.ConfigMap,
 	}
 }
 
+// writeStatus persists the part of the status that update sets, such as the progress of an upgrade, so that it's
+// visible even if this reconcile goes no further. For example, the API server controller needs to see when the upgrade
+// has reached its stage.
+func (r *ReconcileInstallation) writeStatus(ctx context.Context, instance *operator.Installation, update func(*operator.InstallationStatus)) error {
+	// Update a copy, since the update replaces the object with the stored one and that would drop the defaults and
+	// overlay applied to the spec in memory.
+	cp := instance.DeepCopy()
+	update(&cp.Status)
+	if err := r.client.Status().Update(ctx, cp); err != nil {
+		return err
+	}
+	instance.ResourceVersion = cp.ResourceVersion
+	update(&instance.Status)
+	return nil
+}
+
+// reportFelixConfigurationConflicts sets the FelixConfigurationConflict condition of the Installation while users have
+// edited fields of the default FelixConfiguration that the operator manages, and removes it once there are none.
+func (r *ReconcileInstallation) reportFelixConfigurationConflicts(ctx context.Context, instance *operator.Installation, fc *crdv1.FelixConfiguration) error {
+	_, edited, err := utils.FelixConfigurationConflicts(fc)
+	if err != nil {
+		return err
+	}
+
+	conditions := append([]metav1.Condition(nil), instance.Status.Conditions...)
+	if len(edited) > 0 {
+		status.SetStatusCondition(&conditions, operator.FelixConfigurationConflict, metav1.ConditionTrue, "UserEditsPreserved",
+			fmt.Sprintf("These fields of the default FelixConfiguration are managed by the operator but were edited: %s. "+
+				"The edits are kept until the operator needs to change the fields again, which reverts them and records "+
+				"a FieldsOverridden event on the FelixConfiguration.", strings.Join(edited, ", ")),
+			instance.Generation)
+	} else {
+		// Only report the condition while there are conflicts, so that it doesn't clutter the status otherwise.
+		meta.RemoveStatusCondition(&conditions, string(operator.FelixConfigurationConflict))
+	}
+	if reflect.DeepEqual(conditions, instance.Status.Conditions) {
+		return nil
+	}
+	return r.writeStatus(ctx, instance, func(s *operator.InstallationStatus) { s.Conditions = conditions })
+}
+
+// upgradeRolledOut returns the check for whether a stage of an upgrade has finished rolling out. A workload whose
+// rollout is deferred until a maintenance window hasn't rolled out, even if its pods are all up to date.
+func (r *ReconcileInstallation) upgradeRolledOut(installation *operator.InstallationSpec, deferred []string) upgrade.RolloutCheck {
+	return func(ctx context.Context, stage operator.UpgradeStage) (bool, error) {
+		switch stage {
+		case operator.UpgradeStageAPIServer:
+			return apiServerUpgraded(ctx, r.client, installation)
+		case operator.UpgradeStageTypha:
+			return deploymentRolledOut(ctx, r.client, types.NamespacedName{Name: common.TyphaDeploymentName, Namespace: common.CalicoNamespace})
+		case operator.UpgradeStageNode:
+			if slices.Contains(deferred, common.NodeDaemonSetName) {
+				return false, nil
+			}
+			ds := &appsv1.DaemonSet{}
+			if err := r.client.Get(ctx, types.NamespacedName{Name: common.NodeDaemonSetName, Namespace: common.CalicoNamespace}, ds); err != nil {
+				return false, err
+			}
+			return upgrade.DaemonSetRolledOut(ds), nil
+		case operator.UpgradeStageComponents:
+			return deploymentRolledOut(ctx, r.client, types.NamespacedName{Name: common.KubeControllersDeploymentName, Namespace: common.CalicoNamespace})
+		}
+		// The CRDs are updated before this controller goes any further, so they're rolled out once applied.
+		return true, nil
+	}
+}
+
+// apiServerUpgraded returns true if there is no API server, or the API server controller has rolled out the API server
+// image of this operator's version.
+func apiServerUpgraded(ctx context.Context, c client.Client, installation *operator.InstallationSpec) (bool, error) {
+	if _, _, err := utils.GetAPIServer(ctx, c); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	imageSet, err := imageset.GetImageSet(ctx, c, installation.Variant)
+	if err != nil {
+		return false, err
+	}
+	image, err := render.APIServerImage(installation, imageSet)
+	if err != nil {
+		return false, err
+	}
+
+	name := "calico-apiserver"
+	if installation.Variant == operator.TigeraSecureEnterprise {
+		name = "tigera-apiserver"
+	}
+	d := &appsv1.Deployment{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: rmeta.APIServerNamespace(installation.Variant)}, d); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, container := range d.Spec.Template.Spec.Containers {
+		if container.Image == image {
+			return upgrade.DeploymentRolledOut(d), nil
+		}
+	}
+	return false, nil
+}
+
+// deploymentRolledOut returns true if the deployment doesn't exist or has finished rolling out.
+func deploymentRolledOut(ctx context.Context, c client.Client, key types.NamespacedName) (bool, error) {
+	d := &appsv1.Deployment{}
+	if err := c.Get(ctx, key, d); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return upgrade.DeploymentRolledOut(d), nil
+}
+
 func (r *ReconcileInstallation) updateCRDs(ctx context.Context, variant operator.ProductVariant, log logr.Logger) error {
 	if !r.manageCRDs {
 		return nil
@@ -1848,6 +2159,43 @@ func isOpenshiftOnAws(install *operator.Installation, ctx context.Context, clien
 	return (infra.Status.PlatformStatus.Type == "AWS"), nil
 }
 
+// isOpenShiftHostedControlPlane returns true if running on OpenShift with a hosted control plane (HyperShift), which
+// OpenShift reports as an External control plane topology in the infrastructure status. The OpenShift API that the
+// operator uses predates this field, so the infrastructure configuration is read as an unstructured object.
+func isOpenShiftHostedControlPlane(ctx context.Context, client client.Client) (bool, error) {
+	infra := &unstructured.Unstructured{}
+	infra.SetGroupVersionKind(configv1.GroupVersion.WithKind("Infrastructure"))
+	if err := client.Get(ctx, types.NamespacedName{Name: openshiftNetworkConfig}, infra); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to read OpenShift infrastructure configuration: %s", err.Error())
+	}
+	topology, _, err := unstructured.NestedString(infra.Object, "status", "controlPlaneTopology")
+	if err != nil {
+		return false, fmt.Errorf("unable to read OpenShift control plane topology: %s", err.Error())
+	}
+	return topology == "External", nil
+}
+
+// azureNPMDaemonSets are the daemonsets that AKS runs Azure Network Policy Manager with on Linux and Windows nodes.
+var azureNPMDaemonSets = []types.NamespacedName{
+	{Name: "azure-npm", Namespace: metav1.NamespaceSystem},
+	{Name: "azure-npm-win", Namespace: metav1.NamespaceSystem},
+}
+
+// azureNPMDaemonSet returns the namespaced name of the Azure Network Policy Manager daemonset, if it's running.
+func azureNPMDaemonSet(ctx context.Context, client client.Client) (string, error) {
+	for _, key := range azureNPMDaemonSets {
+		if err := client.Get(ctx, key, &appsv1.DaemonSet{}); err == nil {
+			return key.String(), nil
+		} else if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("unable to read %s daemonset: %s", key, err.Error())
+		}
+	}
+	return "", nil
+}
+
 func updateInstallationForAWSNode(i *operator.Installation, ds *appsv1.DaemonSet) error {
 	if ds == nil {
 		return nil
@@ -1860,9 +2208,62 @@ func updateInstallationForAWSNode(i *operator.Installation, ds *appsv1.DaemonSet
 	if i.Spec.CNI.Type == "" {
 		i.Spec.CNI.Type = operator.PluginAmazonVPC
 	}
+
+	cfg := getAWSNodeConfig(ds)
+	eks := &operator.EKSNetworkingStatus{IPv6: cfg.ipv6, CustomNetworking: cfg.customNetworking}
+	if i.Spec.CNI.Type == operator.PluginAmazonVPC {
+		if i.Spec.CalicoNetwork == nil {
+			i.Spec.CalicoNetwork = &operator.CalicoNetworkSpec{}
+		}
+		nodeInternalIP := operator.NodeInternalIP
+
+		// Felix only enforces policy on IPv6 pods when it has an IPv6 node address, so make sure one is detected. The
+		// node's internal IP is used since IPv6 nodes don't necessarily have a route to the internet.
+		if cfg.ipv6 && i.Spec.CalicoNetwork.NodeAddressAutodetectionV6 == nil {
+			i.Spec.CalicoNetwork.NodeAddressAutodetectionV6 = &operator.NodeAddressAutodetection{Kubernetes: &nodeInternalIP}
+			eks.Decisions = append(eks.Decisions, "IPv6 pod addressing is enabled, so the node IPv6 address is detected from the Kubernetes NodeInternalIP")
+		}
+
+		// With custom networking, pods are attached to secondary ENIs on ENIConfig subnets that don't hold the node's
+		// own address. Interface based detection can pick one of those, so the BPF dataplane uses the node's internal IP.
+		bpf := i.Spec.CalicoNetwork.LinuxDataplane != nil && *i.Spec.CalicoNetwork.LinuxDataplane == operator.LinuxDataplaneBPF
+		if cfg.customNetworking && bpf && i.Spec.CalicoNetwork.NodeAddressAutodetectionV4 == nil {
+			i.Spec.CalicoNetwork.NodeAddressAutodetectionV4 = &operator.NodeAddressAutodetection{Kubernetes: &nodeInternalIP}
+			eks.Decisions = append(eks.Decisions, "custom networking (ENIConfig) is enabled, so the node IPv4 address is detected from the Kubernetes NodeInternalIP")
+		}
+	}
+	if cfg.ipv6 {
+		eks.Decisions = append(eks.Decisions, "the VPC routes IPv6 pod traffic natively, so IP pools must not use IP-in-IP or VXLAN encapsulation")
+	}
+	i.Status.EKSNetworking = eks
 	return nil
 }
 
+// awsNodeConfig is the part of the Amazon VPC CNI configuration that affects how Calico is installed alongside it.
+type awsNodeConfig struct {
+	ipv6             bool
+	customNetworking bool
+}
+
+// getAWSNodeConfig reads the Amazon VPC CNI configuration from the environment of the aws-node container.
+func getAWSNodeConfig(ds *appsv1.DaemonSet) awsNodeConfig {
+	cfg := awsNodeConfig{}
+	for _, c := range ds.Spec.Template.Spec.Containers {
+		if c.Name != "aws-node" {
+			continue
+		}
+		for _, env := range c.Env {
+			switch env.Name {
+			case "ENABLE_IPv6":
+				cfg.ipv6 = strings.EqualFold(env.Value, "true")
+			case "AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG":
+				cfg.customNetworking = strings.EqualFold(env.Value, "true")
+			}
+		}
+	}
+	return cfg
+}
+
 func addCRDWatches(c ctrlruntime.Controller, v operator.ProductVariant) error {
 	pred := predicate.Funcs{
 		CreateFunc: func(e event.CreateEvent) bool {
@@ -25,6 +25,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/go-logr/logr"
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/stretchr/testify/mock"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -32,12 +33,16 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	schedv1 "k8s.io/api/scheduling/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	kfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
@@ -50,9 +55,11 @@ import (
 	"github.com/tigera/operator/pkg/components"
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
 	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/upgrade"
 	"github.com/tigera/operator/pkg/controller/utils"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	"github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/render/monitor"
@@ -60,6 +67,38 @@ import (
 	"github.com/tigera/operator/test"
 )
 
+// completeUpgrade reconciles until every stage of the upgrade recorded in the Installation's status has completed,
+// marking the workloads of each stage as rolled out along the way.
+func completeUpgrade(ctx context.Context, c client.Client, r *ReconcileInstallation) {
+	for i := 0; i <= 2*len(upgrade.Stages); i++ {
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		ExpectWithOffset(1, err).ShouldNot(HaveOccurred())
+
+		for _, name := range []string{common.TyphaDeploymentName, "calico-kube-controllers"} {
+			d := &appsv1.Deployment{}
+			if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: common.CalicoNamespace}, d); err != nil {
+				continue
+			}
+			replicas := int32(1)
+			if d.Spec.Replicas != nil {
+				replicas = *d.Spec.Replicas
+			}
+			d.Status.ObservedGeneration = d.Generation
+			d.Status.Replicas = replicas
+			d.Status.UpdatedReplicas = replicas
+			d.Status.AvailableReplicas = replicas
+			ExpectWithOffset(1, c.Status().Update(ctx, d)).NotTo(HaveOccurred())
+		}
+
+		inst := &operator.Installation{}
+		ExpectWithOffset(1, c.Get(ctx, types.NamespacedName{Name: "default"}, inst)).NotTo(HaveOccurred())
+		if !upgrade.InProgress(inst.Status.Upgrade) && inst.Status.CalicoVersion == inst.Status.Upgrade.ToVersion {
+			return
+		}
+	}
+	Fail("upgrade did not complete")
+}
+
 var errMismatchedError = fmt.Errorf("installation spec.kubernetesProvider 'DockerEnterprise' does not match auto-detected value 'OpenShift'")
 
 type fakeNamespaceMigration struct{}
@@ -436,19 +475,25 @@ var _ = Describe("Testing core-controller installation", func() {
 			instance.Status.CalicoVersion = "v3.14"
 			Expect(c.Update(ctx, instance)).NotTo(HaveOccurred())
 
+			// The version is only recorded once every stage of the upgrade has rolled out.
 			_, err := r.Reconcile(ctx, reconcile.Request{})
 			Expect(err).ShouldNot(HaveOccurred())
+			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, instance)).NotTo(HaveOccurred())
+			Expect(instance.Status.CalicoVersion).To(Equal("v3.14"))
+			Expect(instance.Status.Upgrade).NotTo(BeNil())
+			Expect(instance.Status.Upgrade.ToVersion).To(Equal(components.EnterpriseRelease))
 
+			completeUpgrade(ctx, c, &r)
 			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, instance)).NotTo(HaveOccurred())
 			Expect(instance.Status.CalicoVersion).To(Equal(components.EnterpriseRelease))
+			Expect(upgrade.InProgress(instance.Status.Upgrade)).To(BeFalse())
 			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, instance)).NotTo(HaveOccurred())
 
 			instance.Status.CalicoVersion = "v3.23"
 			instance.Spec.Variant = operator.Calico
 			Expect(c.Update(ctx, instance)).NotTo(HaveOccurred())
 
-			_, err = r.Reconcile(ctx, reconcile.Request{})
-			Expect(err).ShouldNot(HaveOccurred())
+			completeUpgrade(ctx, c, &r)
 			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, instance)).NotTo(HaveOccurred())
 			Expect(instance.Status.CalicoVersion).To(Equal(components.CalicoRelease))
 		})
@@ -860,15 +905,12 @@ var _ = Describe("Testing core-controller installation", func() {
 			_, err := r.Reconcile(ctx, reconcile.Request{})
 			Expect(err).ShouldNot(HaveOccurred())
 
-			// We should get a felix configuration with the health port defaulted (but nothing else).
+			// We should get a felix configuration with BPF disabled. The rest of the defaults are applied by the felix
+			// defaults controller.
 			fc := &crdv1.FelixConfiguration{}
 			err = c.Get(ctx, types.NamespacedName{Name: "default"}, fc)
 			Expect(err).ShouldNot(HaveOccurred())
-			Expect(fc.Spec.HealthPort).NotTo(BeNil())
-			Expect(*fc.Spec.HealthPort).To(Equal(9099))
-
-			// This is only set on EKS / GKE.
-			Expect(fc.Spec.RouteTableRange).To(BeNil())
+			Expect(fc.Spec.HealthPort).To(BeNil())
 
 			// Should set correct annoation and BPFEnabled field.
 			Expect(fc.Annotations).NotTo(BeNil())
@@ -967,99 +1009,6 @@ var _ = Describe("Testing core-controller installation", func() {
 			Expect(*fc.Spec.BPFEnabled).To(BeTrue())
 		})
 
-		It("generates FelixConfiguration with correct DNS service for Rancher", func() {
-			cr.Spec.KubernetesProvider = operator.ProviderRKE2
-			Expect(c.Create(ctx, cr)).NotTo(HaveOccurred())
-			_, err := r.Reconcile(ctx, reconcile.Request{})
-			Expect(err).ShouldNot(HaveOccurred())
-
-			// We should get a felix configuration with Rancher's DNS service.
-			fc := &crdv1.FelixConfiguration{}
-			err = c.Get(ctx, types.NamespacedName{Name: "default"}, fc)
-			Expect(err).ShouldNot(HaveOccurred())
-			Expect(fc.Spec.DNSTrustedServers).NotTo(BeNil())
-			Expect(*fc.Spec.DNSTrustedServers).To(ConsistOf("k8s-service:kube-system/rke2-coredns-rke2-coredns"))
-		})
-
-		It("should Reconcile with AWS CNI config", func() {
-			cr.Spec.CNI = &operator.CNISpec{Type: operator.PluginAmazonVPC}
-			Expect(c.Create(ctx, cr)).NotTo(HaveOccurred())
-			_, err := r.Reconcile(ctx, reconcile.Request{})
-			Expect(err).ShouldNot(HaveOccurred())
-
-			// Check that FelixConfiguration is created with RouteTableRange
-			fc := &crdv1.FelixConfiguration{}
-			err = c.Get(ctx, types.NamespacedName{Name: "default"}, fc)
-			Expect(err).ShouldNot(HaveOccurred())
-			Expect(fc.Spec.RouteTableRange).NotTo(BeNil())
-			Expect(*fc.Spec.RouteTableRange).To(Equal(crdv1.RouteTableRange{Min: 65, Max: 99}))
-		})
-
-		It("should Reconcile with GKE CNI config", func() {
-			cr.Spec.CNI = &operator.CNISpec{Type: operator.PluginGKE}
-			Expect(c.Create(ctx, cr)).NotTo(HaveOccurred())
-			_, err := r.Reconcile(ctx, reconcile.Request{})
-			Expect(err).ShouldNot(HaveOccurred())
-
-			// Check that FelixConfiguration is created with RouteTableRange
-			fc := &crdv1.FelixConfiguration{}
-			err = c.Get(ctx, types.NamespacedName{Name: "default"}, fc)
-			Expect(err).ShouldNot(HaveOccurred())
-			Expect(fc.Spec.RouteTableRange).NotTo(BeNil())
-			Expect(*fc.Spec.RouteTableRange).To(Equal(crdv1.RouteTableRange{Min: 10, Max: 250}))
-		})
-
-		It("should Reconcile with AWS CNI and not change existing FelixConfig", func() {
-			fc := &crdv1.FelixConfiguration{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "default",
-				},
-				Spec: crdv1.FelixConfigurationSpec{
-					RouteTableRange:   &crdv1.RouteTableRange{Min: 15, Max: 55},
-					LogSeverityScreen: "Error",
-				},
-			}
-			err := c.Create(ctx, fc)
-			Expect(err).ShouldNot(HaveOccurred())
-			cr.Spec.CNI = &operator.CNISpec{Type: operator.PluginAmazonVPC}
-			Expect(c.Create(ctx, cr)).NotTo(HaveOccurred())
-			_, err = r.Reconcile(ctx, reconcile.Request{})
-			Expect(err).ShouldNot(HaveOccurred())
-
-			// Check that FelixConfiguration has not changed
-			fc = &crdv1.FelixConfiguration{}
-			err = c.Get(ctx, types.NamespacedName{Name: "default"}, fc)
-			Expect(err).ShouldNot(HaveOccurred())
-			Expect(fc.Spec.RouteTableRange).NotTo(BeNil())
-			Expect(*fc.Spec.RouteTableRange).To(Equal(crdv1.RouteTableRange{Min: 15, Max: 55}))
-			Expect(fc.Spec.LogSeverityScreen).To(Equal("Error"))
-		})
-
-		It("should Reconcile with AWS CNI and update existing FelixConfig", func() {
-			fc := &crdv1.FelixConfiguration{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "default",
-				},
-				Spec: crdv1.FelixConfigurationSpec{
-					LogSeverityScreen: "Error",
-				},
-			}
-			err := c.Create(ctx, fc)
-			Expect(err).ShouldNot(HaveOccurred())
-			cr.Spec.CNI = &operator.CNISpec{Type: operator.PluginAmazonVPC}
-			Expect(c.Create(ctx, cr)).NotTo(HaveOccurred())
-			_, err = r.Reconcile(ctx, reconcile.Request{})
-			Expect(err).ShouldNot(HaveOccurred())
-
-			// Check that FelixConfiguration is created with RouteTableRange
-			fc = &crdv1.FelixConfiguration{}
-			err = c.Get(ctx, types.NamespacedName{Name: "default"}, fc)
-			Expect(err).ShouldNot(HaveOccurred())
-			Expect(fc.Spec.RouteTableRange).NotTo(BeNil())
-			Expect(*fc.Spec.RouteTableRange).To(Equal(crdv1.RouteTableRange{Min: 65, Max: 99}))
-			Expect(fc.Spec.LogSeverityScreen).To(Equal("Error"))
-		})
-
 		It("should Reconcile with FelixConfig natPortRange set", func() {
 			fc := &crdv1.FelixConfiguration{
 				ObjectMeta: metav1.ObjectMeta{
@@ -1246,6 +1195,26 @@ var _ = Describe("Testing core-controller installation", func() {
 			Expect(cr.Status.Conditions).To(HaveLen(0))
 		})
 
+		It("should report user edits of the FelixConfiguration fields that the operator manages", func() {
+			Expect(c.Create(ctx, &crdv1.FelixConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "default",
+					Annotations: map[string]string{utils.FelixConfigurationManagedFieldsAnnotation: `{"healthPort":9099,"vxlanVNI":4096}`},
+				},
+				Spec: crdv1.FelixConfigurationSpec{HealthPort: ptr.ToPtr(9199), VXLANVNI: ptr.ToPtr(4096)},
+			})).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, &operator.TigeraStatus{ObjectMeta: metav1.ObjectMeta{Name: "calico"}})).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, cr)).NotTo(HaveOccurred())
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "calico"}})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, cr)).ShouldNot(HaveOccurred())
+			condition := meta.FindStatusCondition(cr.Status.Conditions, string(operator.FelixConfigurationConflict))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring("were edited: healthPort."))
+		})
+
 		It("should reconcile with creating new installation status with multiple conditions as true", func() {
 			generation := int64(2)
 			ts := &operator.TigeraStatus{
@@ -1749,3 +1718,89 @@ func (f *fakeComponentHandler) CreateOrUpdateOrDelete(ctx context.Context, compo
 	f.objectsToDelete = append(f.objectsToDelete, d...)
 	return nil
 }
+
+var _ = Describe("hosted control plane detection", func() {
+	var (
+		ctx      context.Context
+		topology string
+		c        client.Client
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		topology = ""
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(appsv1.AddToScheme(scheme)).NotTo(HaveOccurred())
+
+		// The OpenShift API that the operator uses predates the control plane topology, so the fake client can't store
+		// it. Fill it in on reads of the infrastructure configuration instead.
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, cli client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				u, ok := obj.(*unstructured.Unstructured)
+				if !ok || u.GetKind() != "Infrastructure" {
+					return cli.Get(ctx, key, obj, opts...)
+				}
+				if topology == "" {
+					return apierrors.NewNotFound(configv1.Resource("infrastructures"), key.Name)
+				}
+				return unstructured.SetNestedField(u.Object, topology, "status", "controlPlaneTopology")
+			},
+		}).Build()
+	})
+
+	defaults := func(provider operator.Provider, topology *operator.ControlPlaneTopology) *operator.Installation {
+		instance := &operator.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec:       operator.InstallationSpec{ControlPlaneTopology: topology},
+		}
+		Expect(updateInstallationWithDefaults(ctx, c, instance, provider)).NotTo(HaveOccurred())
+		return instance
+	}
+
+	It("defaults to a hosted topology on OpenShift with an external control plane", func() {
+		topology = "External"
+		Expect(defaults(operator.ProviderOpenShift, nil).Spec.HostedControlPlane()).To(BeTrue())
+	})
+
+	It("leaves the topology unset on OpenShift with a highly available control plane", func() {
+		topology = "HighlyAvailable"
+		Expect(defaults(operator.ProviderOpenShift, nil).Spec.ControlPlaneTopology).To(BeNil())
+	})
+
+	It("leaves the topology unset when the infrastructure configuration doesn't exist", func() {
+		Expect(defaults(operator.ProviderOpenShift, nil).Spec.ControlPlaneTopology).To(BeNil())
+	})
+
+	It("doesn't override an explicit topology", func() {
+		topology = "External"
+		standard := operator.ControlPlaneTopologyStandard
+		Expect(*defaults(operator.ProviderOpenShift, &standard).Spec.ControlPlaneTopology).To(Equal(standard))
+	})
+})
+
+var _ = Describe("Azure Network Policy Manager detection", func() {
+	var (
+		ctx context.Context
+		c   client.Client
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(appsv1.AddToScheme(scheme)).NotTo(HaveOccurred())
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+	})
+
+	It("doesn't report anything when Azure NPM isn't running", func() {
+		Expect(azureNPMDaemonSet(ctx, c)).To(BeEmpty())
+	})
+
+	It("reports the Azure NPM daemonset", func() {
+		Expect(c.Create(ctx, &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "azure-npm-win", Namespace: "kube-system"},
+		})).NotTo(HaveOccurred())
+		Expect(azureNPMDaemonSet(ctx, c)).To(Equal("kube-system/azure-npm-win"))
+	})
+})
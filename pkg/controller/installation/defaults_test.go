@@ -120,6 +120,7 @@ var _ = Describe("Defaulting logic tests", func() {
 		var linuxPolicySetupTimeoutSeconds int32 = 1
 
 		hpEnabled := operator.HostPortsEnabled
+		bpEnabled := operator.BandwidthPluginEnabled
 		disabled := operator.BGPDisabled
 		miMode := operator.MultiInterfaceModeNone
 		dpIptables := operator.LinuxDataplaneIptables
@@ -170,6 +171,7 @@ var _ = Describe("Defaulting logic tests", func() {
 						FirstFound: &false_,
 					},
 					HostPorts:                      &hpEnabled,
+					BandwidthPlugin:                &bpEnabled,
 					MultiInterfaceMode:             &miMode,
 					LinuxPolicySetupTimeoutSeconds: &linuxPolicySetupTimeoutSeconds,
 				},
@@ -217,6 +219,7 @@ var _ = Describe("Defaulting logic tests", func() {
 		dpBPF := operator.LinuxDataplaneBPF
 		winDataplaneDisabled := operator.WindowsDataplaneDisabled
 		hpEnabled := operator.HostPortsEnabled
+		bpEnabled := operator.BandwidthPluginEnabled
 		npDisabled := operator.NonPrivilegedDisabled
 		instance := &operator.Installation{
 			Spec: operator.InstallationSpec{
@@ -254,6 +257,7 @@ var _ = Describe("Defaulting logic tests", func() {
 					},
 					MultiInterfaceMode: &miMode,
 					HostPorts:          &hpEnabled,
+					BandwidthPlugin:    &bpEnabled,
 				},
 				ControlPlaneReplicas: &replicas,
 				NodeMetricsPort:      &nodeMetricsPort,
@@ -531,6 +535,24 @@ var _ = Describe("Defaulting logic tests", func() {
 		})
 	})
 
+	// Tests for Calico Networking on AKS (BYOCNI) should go in this context.
+	Context("with Calico Networking on AKS", func() {
+		It("should default properly", func() {
+			instance := &operator.Installation{
+				Spec: operator.InstallationSpec{
+					KubernetesProvider: operator.ProviderAKS,
+					CNI: &operator.CNISpec{
+						Type: operator.PluginCalico,
+					},
+				},
+			}
+			err := fillDefaults(instance, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*instance.Spec.CalicoNetwork.BGP).To(Equal(operator.BGPDisabled))
+			Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
+		})
+	})
+
 	Context("updateInstallationForAWSNode", func() {
 		It("should set the CNI to AmazonVPC", func() {
 			instance := &operator.Installation{
@@ -541,6 +563,78 @@ var _ = Describe("Defaulting logic tests", func() {
 			err := updateInstallationForAWSNode(instance, &appsv1.DaemonSet{})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(instance.Spec.CNI.Type).To(Equal(operator.PluginAmazonVPC))
+			Expect(instance.Status.EKSNetworking).To(Equal(&operator.EKSNetworkingStatus{}))
+		})
+
+		awsNode := func(env ...v1.EnvVar) *appsv1.DaemonSet {
+			ds := &appsv1.DaemonSet{}
+			ds.Spec.Template.Spec.Containers = []v1.Container{{Name: "aws-node", Env: env}}
+			return ds
+		}
+
+		It("should detect IPv6 node addresses on IPv6 clusters", func() {
+			instance := &operator.Installation{Spec: operator.InstallationSpec{KubernetesProvider: operator.ProviderEKS}}
+			err := updateInstallationForAWSNode(instance, awsNode(v1.EnvVar{Name: "ENABLE_IPv6", Value: "true"}))
+			Expect(err).NotTo(HaveOccurred())
+
+			nodeInternalIP := operator.NodeInternalIP
+			Expect(instance.Spec.CalicoNetwork.NodeAddressAutodetectionV6).To(Equal(&operator.NodeAddressAutodetection{Kubernetes: &nodeInternalIP}))
+			Expect(instance.Status.EKSNetworking.IPv6).To(BeTrue())
+			Expect(instance.Status.EKSNetworking.CustomNetworking).To(BeFalse())
+			Expect(instance.Status.EKSNetworking.Decisions).To(HaveLen(2))
+
+			Expect(fillDefaults(instance, nil)).NotTo(HaveOccurred())
+			Expect(instance.Spec.CalicoNetwork.NodeAddressAutodetectionV4).To(BeNil())
+			Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should not override a configured IPv6 autodetection method", func() {
+			instance := &operator.Installation{
+				Spec: operator.InstallationSpec{
+					CalicoNetwork: &operator.CalicoNetworkSpec{
+						NodeAddressAutodetectionV6: &operator.NodeAddressAutodetection{Interface: "eth0"},
+					},
+				},
+			}
+			err := updateInstallationForAWSNode(instance, awsNode(v1.EnvVar{Name: "ENABLE_IPv6", Value: "true"}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Spec.CalicoNetwork.NodeAddressAutodetectionV6).To(Equal(&operator.NodeAddressAutodetection{Interface: "eth0"}))
+			Expect(instance.Status.EKSNetworking.Decisions).To(HaveLen(1))
+		})
+
+		It("should detect the node IPv4 address from Kubernetes with custom networking and BPF", func() {
+			bpf := operator.LinuxDataplaneBPF
+			instance := &operator.Installation{
+				Spec: operator.InstallationSpec{
+					KubernetesProvider: operator.ProviderEKS,
+					CalicoNetwork:      &operator.CalicoNetworkSpec{LinuxDataplane: &bpf},
+				},
+			}
+			err := updateInstallationForAWSNode(instance, awsNode(v1.EnvVar{Name: "AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG", Value: "true"}))
+			Expect(err).NotTo(HaveOccurred())
+
+			nodeInternalIP := operator.NodeInternalIP
+			Expect(instance.Spec.CalicoNetwork.NodeAddressAutodetectionV4).To(Equal(&operator.NodeAddressAutodetection{Kubernetes: &nodeInternalIP}))
+			Expect(instance.Status.EKSNetworking.CustomNetworking).To(BeTrue())
+			Expect(instance.Status.EKSNetworking.Decisions).To(HaveLen(1))
+		})
+
+		It("should leave IPv4 autodetection off with custom networking and iptables", func() {
+			instance := &operator.Installation{Spec: operator.InstallationSpec{KubernetesProvider: operator.ProviderEKS}}
+			err := updateInstallationForAWSNode(instance, awsNode(v1.EnvVar{Name: "AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG", Value: "true"}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fillDefaults(instance, nil)).NotTo(HaveOccurred())
+			Expect(instance.Spec.CalicoNetwork.NodeAddressAutodetectionV4).To(BeNil())
+			Expect(instance.Status.EKSNetworking.Decisions).To(BeEmpty())
+		})
+
+		It("should clear the EKS status when aws-node is not present", func() {
+			instance := &operator.Installation{
+				Spec:   operator.InstallationSpec{KubernetesProvider: operator.ProviderEKS},
+				Status: operator.InstallationStatus{EKSNetworking: &operator.EKSNetworkingStatus{IPv6: true}},
+			}
+			Expect(MergeAndFillDefaults(instance, nil, nil)).NotTo(HaveOccurred())
+			Expect(instance.Status.EKSNetworking).To(BeNil())
 		})
 	})
 
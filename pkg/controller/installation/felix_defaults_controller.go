@@ -0,0 +1,315 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/logging"
+)
+
+var logfd = logf.Log.WithName("controller_felix_defaults")
+
+// FelixDefaultsFieldManager is the field manager that the defaults of the default FelixConfiguration are applied
+// with. The fields it owns are the ones that the operator set, and may change again as the Installation changes.
+const FelixDefaultsFieldManager = "tigera-operator-felix-defaults"
+
+// legacyFelixDefaultsFieldManager is the field manager that older versions of the operator updated the default
+// FelixConfiguration with, before the defaults were applied. The defaults that it set are adopted as ours.
+const legacyFelixDefaultsFieldManager = "operator"
+
+const (
+	defaultFelixHealthPort          = 9099
+	defaultOpenShiftFelixHealthPort = 9199
+
+	// felixDefaultDNSTrustedServer is the value of dnsTrustedServers that felix uses when it isn't set. It is replaced
+	// by the DNS service of the platforms where that service isn't named "kube-dns".
+	felixDefaultDNSTrustedServer = "k8s-service:kube-dns"
+)
+
+// AddFelixDefaultsController creates a controller that applies the operator's defaults to the default
+// FelixConfiguration, and adds it to the Manager.
+func AddFelixDefaultsController(mgr manager.Manager, opts options.AddOptions) error {
+	r := &ReconcileFelixDefaults{client: mgr.GetClient()}
+
+	c, err := ctrlruntime.NewController("tigera-felix-defaults-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return fmt.Errorf("failed to create tigera-felix-defaults-controller: %w", err)
+	}
+
+	// All events are reconciled in the same way, so map them all to the Installation.
+	enqueueInstallation := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: utils.DefaultInstanceKey}}
+	})
+	if err = c.WatchObject(&operatorv1.Installation{}, enqueueInstallation, utils.ObjectChangedPredicate); err != nil {
+		return fmt.Errorf("tigera-felix-defaults-controller failed to watch Installation resource: %w", err)
+	}
+	isDefault := predicate.NewPredicateFuncs(func(o client.Object) bool { return o.GetName() == "default" })
+	if err = c.WatchObject(&crdv1.FelixConfiguration{}, enqueueInstallation, isDefault); err != nil {
+		return fmt.Errorf("tigera-felix-defaults-controller failed to watch FelixConfiguration resource: %w", err)
+	}
+	return nil
+}
+
+// ReconcileFelixDefaults applies the defaults that the operator derives from the Installation to the default
+// FelixConfiguration.
+type ReconcileFelixDefaults struct {
+	client client.Client
+}
+
+func (r *ReconcileFelixDefaults) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := logging.ForReconcile(ctx, logfd).WithValues("Request.Name", request.Name)
+	reqLogger.V(1).Info("Reconciling FelixConfiguration defaults")
+
+	_, installation, err := utils.GetInstallation(ctx, r.client)
+	if apierrors.IsNotFound(err) {
+		return reconcile.Result{}, nil
+	} else if err != nil {
+		reqLogger.Error(err, "Error querying Installation")
+		return reconcile.Result{}, err
+	}
+	if installation.CNI == nil {
+		// The defaults depend on the defaulted Installation, which the core controller hasn't written yet. The
+		// Installation is watched, so we'll be back when it has.
+		reqLogger.V(1).Info("Waiting for the Installation to be defaulted")
+		return reconcile.Result{}, nil
+	}
+
+	current := &crdv1.FelixConfiguration{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: "default"}, current); err != nil && !apierrors.IsNotFound(err) {
+		reqLogger.Error(err, "Error querying FelixConfiguration")
+		return reconcile.Result{}, err
+	}
+
+	fc, force, err := felixDefaultsToApply(installation, current)
+	if err != nil {
+		reqLogger.Error(err, "Error working out the FelixConfiguration defaults")
+		return reconcile.Result{}, err
+	}
+
+	// Without forcing, the apply fails rather than take over a field that someone else has set since we read it. It
+	// is only forced to take over the fields that we have to: those that an older operator set, and the merged DNS
+	// trusted servers.
+	opts := []client.PatchOption{client.FieldOwner(FelixDefaultsFieldManager)}
+	if force {
+		opts = append(opts, client.ForceOwnership)
+	}
+	if err := r.client.Patch(ctx, fc, client.Apply, opts...); err != nil {
+		reqLogger.Error(err, "Error applying FelixConfiguration defaults")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// felixDefaultsToApply returns the default FelixConfiguration to apply, and whether the apply must force the
+// ownership of the fields in it. It holds each default that is not set on the current FelixConfiguration, or that was
+// set by us or by an older operator. A field that was set by us and no longer has a default is left out, so that the
+// apply removes it. The DNS service of the platform is always merged into the DNS trusted servers.
+func felixDefaultsToApply(install *operatorv1.InstallationSpec, current *crdv1.FelixConfiguration) (*crdv1.FelixConfiguration, bool, error) {
+	spec := felixConfigurationDefaults(install)
+	if spec.DNSTrustedServers != nil {
+		spec.DNSTrustedServers = mergeDNSTrustedServers((*spec.DNSTrustedServers)[0], current.Spec.DNSTrustedServers)
+	}
+	defaults, err := felixSpecFields(spec)
+	if err != nil {
+		return nil, false, err
+	}
+	set, err := felixSpecFields(&current.Spec)
+	if err != nil {
+		return nil, false, err
+	}
+	owned, err := felixOwnedFields(current, FelixDefaultsFieldManager, metav1.ManagedFieldsOperationApply)
+	if err != nil {
+		return nil, false, err
+	}
+	legacy, err := felixOwnedFields(current, legacyFelixDefaultsFieldManager, metav1.ManagedFieldsOperationUpdate)
+	if err != nil {
+		return nil, false, err
+	}
+
+	apply := map[string]json.RawMessage{}
+	force := false
+	for field, value := range defaults {
+		_, isSet := set[field]
+		switch {
+		case isSet && legacy[field]:
+			// Forcing takes the field over from the older operator, so that it is only adopted once.
+			force = true
+		case field == "dnsTrustedServers":
+			force = force || (isSet && !owned[field] && !bytes.Equal(set[field], value))
+		case isSet && !owned[field]:
+			continue
+		}
+		apply[field] = value
+	}
+	raw, err := json.Marshal(apply)
+	if err != nil {
+		return nil, false, err
+	}
+	fc := &crdv1.FelixConfiguration{
+		TypeMeta:   metav1.TypeMeta{Kind: "FelixConfiguration", APIVersion: "crd.projectcalico.org/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+	}
+	if err := json.Unmarshal(raw, &fc.Spec); err != nil {
+		return nil, false, err
+	}
+	return fc, force, nil
+}
+
+// mergeDNSTrustedServers returns the DNS trusted servers with the DNS service of the platform first, followed by the
+// other servers that are set, except for the default of felix that the DNS service replaces.
+func mergeDNSTrustedServers(dnsService string, current *[]string) *[]string {
+	servers := []string{dnsService}
+	if current != nil {
+		for _, server := range *current {
+			if server != felixDefaultDNSTrustedServer && server != dnsService {
+				servers = append(servers, server)
+			}
+		}
+	}
+	return &servers
+}
+
+// felixConfigurationDefaults returns the FelixConfiguration settings that the operator defaults from the
+// Installation.
+func felixConfigurationDefaults(install *operatorv1.InstallationSpec) *crdv1.FelixConfigurationSpec {
+	spec := &crdv1.FelixConfigurationSpec{}
+
+	switch install.CNI.Type {
+	// If we're using the AWS CNI plugin we need to ensure the route tables that calico-node
+	// uses do not conflict with the ones the AWS CNI plugin uses.
+	case operatorv1.PluginAmazonVPC:
+		// Defaulting based on that AWS might be using the following:
+		// - The ENI device number + 1
+		//   Currently the max number of ENIs for any host is 15.
+		//   p4d.24xlarge is reported to support 4x15 ENI but it uses 4 cards
+		//   and AWS CNI only uses ENIs on card 0.
+		// - The VLAN table ID + 100 (there is doubt if this is true)
+		spec.RouteTableRange = &crdv1.RouteTableRange{Min: 65, Max: 99}
+	case operatorv1.PluginGKE:
+		// Don't conflict with the GKE CNI plugin's routes.
+		spec.RouteTableRange = &crdv1.RouteTableRange{Min: 10, Max: 250}
+	}
+
+	// Make Felix ignore the host interfaces that back Multus secondary networks, keeping its own default exclusion.
+	if cn := install.CalicoNetwork; cn != nil && cn.MultusCompatibility != nil && len(cn.MultusCompatibility.InterfaceExclude) > 0 {
+		spec.InterfaceExclude = strings.Join(append([]string{"kube-ipvs0"}, cn.MultusCompatibility.InterfaceExclude...), ",")
+	}
+
+	healthPort := defaultFelixHealthPort
+	if install.KubernetesProvider.IsOpenShift() {
+		healthPort = defaultOpenShiftFelixHealthPort
+	}
+	spec.HealthPort = &healthPort
+
+	if install.Variant == operatorv1.TigeraSecureEnterprise {
+		// Some platforms need a different default setting for dnsTrustedServers, because their DNS service is not named "kube-dns".
+		switch install.KubernetesProvider {
+		case operatorv1.ProviderOpenShift:
+			spec.DNSTrustedServers = &[]string{"k8s-service:openshift-dns/dns-default"}
+		case operatorv1.ProviderRKE2:
+			spec.DNSTrustedServers = &[]string{"k8s-service:kube-system/rke2-coredns-rke2-coredns"}
+		}
+	}
+
+	if cn := install.CalicoNetwork; cn != nil && cn.WireGuard != nil {
+		enabled := *cn.WireGuard == operatorv1.WireGuardEnabled
+		v4, v6 := false, false
+		for _, pool := range cn.IPPools {
+			if ip, _, err := net.ParseCIDR(pool.CIDR); err == nil && ip.To4() != nil {
+				v4 = true
+			} else if err == nil {
+				v6 = true
+			}
+		}
+		if v4 {
+			spec.WireguardEnabled = &enabled
+		}
+		if v6 {
+			spec.WireguardEnabledV6 = &enabled
+		}
+	}
+
+	return spec
+}
+
+// felixHealthPort returns the port of felix's health endpoint, which is the one configured in the FelixConfiguration
+// or otherwise the one it is defaulted to.
+func felixHealthPort(install *operatorv1.InstallationSpec, fc *crdv1.FelixConfiguration) int {
+	if fc.Spec.HealthPort != nil {
+		return *fc.Spec.HealthPort
+	}
+	if install.KubernetesProvider.IsOpenShift() {
+		return defaultOpenShiftFelixHealthPort
+	}
+	return defaultFelixHealthPort
+}
+
+// felixSpecFields returns the JSON encoding of each field that is set in the given spec, by JSON name.
+func felixSpecFields(spec *crdv1.FelixConfigurationSpec) (map[string]json.RawMessage, error) {
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// felixOwnedFields returns the JSON names of the spec fields of the FelixConfiguration that are owned by the given
+// field manager through the given operation.
+func felixOwnedFields(fc *crdv1.FelixConfiguration, manager string, operation metav1.ManagedFieldsOperationType) (map[string]bool, error) {
+	owned := map[string]bool{}
+	for _, entry := range fc.ManagedFields {
+		if entry.Manager != manager || entry.Operation != operation || entry.FieldsV1 == nil {
+			continue
+		}
+		fields := struct {
+			Spec map[string]json.RawMessage `json:"f:spec"`
+		}{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			return nil, fmt.Errorf("failed to decode the managed fields of FelixConfiguration %s: %w", fc.Name, err)
+		}
+		for f := range fields.Spec {
+			if name, ok := strings.CutPrefix(f, "f:"); ok {
+				owned[name] = true
+			}
+		}
+	}
+	return owned, nil
+}
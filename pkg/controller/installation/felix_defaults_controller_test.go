@@ -0,0 +1,267 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installation
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operator "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+var _ = Describe("FelixConfiguration defaults controller tests", func() {
+	var (
+		ctx          context.Context
+		cli          client.Client
+		r            *ReconcileFelixDefaults
+		installation *operator.Installation
+	)
+
+	getFelixConfiguration := func() *crdv1.FelixConfiguration {
+		fc := &crdv1.FelixConfiguration{}
+		Expect(cli.Get(ctx, types.NamespacedName{Name: "default"}, fc)).NotTo(HaveOccurred())
+		return fc
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		r = &ReconcileFelixDefaults{client: cli}
+
+		// An Installation that has been defaulted by the core controller.
+		installation = &operator.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: operator.InstallationSpec{
+				Variant:            operator.Calico,
+				KubernetesProvider: operator.ProviderNone,
+				CNI:                &operator.CNISpec{Type: operator.PluginCalico},
+			},
+		}
+	})
+
+	It("should apply the defaults to the default FelixConfiguration", func() {
+		Expect(cli.Create(ctx, installation)).NotTo(HaveOccurred())
+		Expect(cli.Create(ctx, &crdv1.FelixConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).NotTo(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: utils.DefaultInstanceKey})
+		Expect(err).NotTo(HaveOccurred())
+
+		fc := getFelixConfiguration()
+		Expect(fc.Spec.HealthPort).To(Equal(ptr.ToPtr(9099)))
+		// Felix's own defaults are left to felix.
+		Expect(fc.Spec.VXLANPort).To(BeNil())
+		Expect(fc.Spec.VXLANVNI).To(BeNil())
+		// This is only set on EKS / GKE.
+		Expect(fc.Spec.RouteTableRange).To(BeNil())
+		Expect(fc.Spec.WireguardEnabled).To(BeNil())
+	})
+
+	It("should not clobber the fields that the user set", func() {
+		installation.Spec.CNI.Type = operator.PluginAmazonVPC
+		Expect(cli.Create(ctx, installation)).NotTo(HaveOccurred())
+		Expect(cli.Create(ctx, &crdv1.FelixConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: crdv1.FelixConfigurationSpec{
+				RouteTableRange:   &crdv1.RouteTableRange{Min: 15, Max: 55},
+				HealthPort:        ptr.ToPtr(9000),
+				LogSeverityScreen: "Error",
+			},
+		})).NotTo(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: utils.DefaultInstanceKey})
+		Expect(err).NotTo(HaveOccurred())
+
+		fc := getFelixConfiguration()
+		Expect(*fc.Spec.RouteTableRange).To(Equal(crdv1.RouteTableRange{Min: 15, Max: 55}))
+		Expect(fc.Spec.HealthPort).To(Equal(ptr.ToPtr(9000)))
+		Expect(fc.Spec.LogSeverityScreen).To(Equal("Error"))
+	})
+
+	It("should wait for the Installation to be defaulted", func() {
+		installation.Spec.CNI = nil
+		Expect(cli.Create(ctx, installation)).NotTo(HaveOccurred())
+		Expect(cli.Create(ctx, &crdv1.FelixConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).NotTo(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: utils.DefaultInstanceKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(getFelixConfiguration().Spec).To(Equal(crdv1.FelixConfigurationSpec{}))
+	})
+
+	It("should update the fields that it owns, and only those", func() {
+		installation.Spec.CNI.Type = operator.PluginGKE
+		current := &crdv1.FelixConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "default",
+				ManagedFields: []metav1.ManagedFieldsEntry{
+					{
+						Manager:   FelixDefaultsFieldManager,
+						Operation: metav1.ManagedFieldsOperationApply,
+						FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:routeTableRange":{},"f:interfaceExclude":{}}}`)},
+					},
+					{
+						Manager:   "kubectl",
+						Operation: metav1.ManagedFieldsOperationUpdate,
+						FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:healthPort":{}}}`)},
+					},
+				},
+			},
+			Spec: crdv1.FelixConfigurationSpec{
+				RouteTableRange:  &crdv1.RouteTableRange{Min: 65, Max: 99},
+				InterfaceExclude: "kube-ipvs0,dpdk0",
+				HealthPort:       ptr.ToPtr(9000),
+			},
+		}
+
+		fc, force, err := felixDefaultsToApply(&installation.Spec, current)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(force).To(BeFalse())
+		Expect(fc.TypeMeta).To(Equal(metav1.TypeMeta{Kind: "FelixConfiguration", APIVersion: "crd.projectcalico.org/v1"}))
+		Expect(fc.Name).To(Equal("default"))
+		// The route table range follows the Installation, the interface exclusion is no longer applied so that it is
+		// removed, and the health port is left to the user.
+		Expect(fc.Spec).To(Equal(crdv1.FelixConfigurationSpec{
+			RouteTableRange: &crdv1.RouteTableRange{Min: 10, Max: 250},
+		}))
+	})
+
+	It("should adopt the defaults that an older operator set when upgrading", func() {
+		installation.Spec.CNI.Type = operator.PluginGKE
+		installation.Spec.KubernetesProvider = operator.ProviderOpenShift
+		current := &crdv1.FelixConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "default",
+				ManagedFields: []metav1.ManagedFieldsEntry{
+					{
+						Manager:   "operator",
+						Operation: metav1.ManagedFieldsOperationUpdate,
+						FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:routeTableRange":{},"f:healthPort":{},"f:vxlanVNI":{}}}`)},
+					},
+					{
+						Manager:   "kubectl",
+						Operation: metav1.ManagedFieldsOperationUpdate,
+						FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:logSeverityScreen":{}}}`)},
+					},
+				},
+			},
+			Spec: crdv1.FelixConfigurationSpec{
+				RouteTableRange:   &crdv1.RouteTableRange{Min: 65, Max: 99},
+				HealthPort:        ptr.ToPtr(9099),
+				VXLANVNI:          ptr.ToPtr(4096),
+				LogSeverityScreen: "Error",
+			},
+		}
+
+		fc, force, err := felixDefaultsToApply(&installation.Spec, current)
+		Expect(err).NotTo(HaveOccurred())
+		// The fields that the older operator set follow the Installation, which means taking them over from it. Those
+		// that aren't defaulted anymore are left as they are.
+		Expect(force).To(BeTrue())
+		Expect(fc.Spec).To(Equal(crdv1.FelixConfigurationSpec{
+			RouteTableRange: &crdv1.RouteTableRange{Min: 10, Max: 250},
+			HealthPort:      ptr.ToPtr(9199),
+		}))
+	})
+
+	It("should merge the DNS service of the platform into the DNS trusted servers when upgrading", func() {
+		installation.Spec.Variant = operator.TigeraSecureEnterprise
+		installation.Spec.KubernetesProvider = operator.ProviderRKE2
+		current := &crdv1.FelixConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "default",
+				ManagedFields: []metav1.ManagedFieldsEntry{
+					{
+						Manager:   "kubectl",
+						Operation: metav1.ManagedFieldsOperationUpdate,
+						FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:dnsTrustedServers":{}}}`)},
+					},
+				},
+			},
+			Spec: crdv1.FelixConfigurationSpec{
+				DNSTrustedServers: &[]string{"k8s-service:kube-dns", "10.0.0.53"},
+			},
+		}
+
+		fc, force, err := felixDefaultsToApply(&installation.Spec, current)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(force).To(BeTrue())
+		Expect(fc.Spec.DNSTrustedServers).To(Equal(&[]string{"k8s-service:kube-system/rke2-coredns-rke2-coredns", "10.0.0.53"}))
+
+		// Once merged, the servers are applied as they are, without taking them over again.
+		current.Spec.DNSTrustedServers = fc.Spec.DNSTrustedServers
+		fc, force, err = felixDefaultsToApply(&installation.Spec, current)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(force).To(BeFalse())
+		Expect(fc.Spec.DNSTrustedServers).To(Equal(&[]string{"k8s-service:kube-system/rke2-coredns-rke2-coredns", "10.0.0.53"}))
+	})
+
+	DescribeTable("working out the defaults",
+		func(spec operator.InstallationSpec, expected crdv1.FelixConfigurationSpec) {
+			if spec.CNI == nil {
+				spec.CNI = &operator.CNISpec{Type: operator.PluginCalico}
+			}
+			if expected.HealthPort == nil {
+				expected.HealthPort = ptr.ToPtr(9099)
+			}
+			Expect(*felixConfigurationDefaults(&spec)).To(Equal(expected))
+		},
+		Entry("default", operator.InstallationSpec{}, crdv1.FelixConfigurationSpec{}),
+		Entry("OpenShift", operator.InstallationSpec{KubernetesProvider: operator.ProviderOpenShift},
+			crdv1.FelixConfigurationSpec{HealthPort: ptr.ToPtr(9199)}),
+		Entry("AWS CNI", operator.InstallationSpec{CNI: &operator.CNISpec{Type: operator.PluginAmazonVPC}},
+			crdv1.FelixConfigurationSpec{RouteTableRange: &crdv1.RouteTableRange{Min: 65, Max: 99}}),
+		Entry("GKE CNI", operator.InstallationSpec{CNI: &operator.CNISpec{Type: operator.PluginGKE}},
+			crdv1.FelixConfigurationSpec{RouteTableRange: &crdv1.RouteTableRange{Min: 10, Max: 250}}),
+		Entry("Multus", operator.InstallationSpec{CalicoNetwork: &operator.CalicoNetworkSpec{
+			MultusCompatibility: &operator.MultusCompatibility{InterfaceExclude: []string{"/^vf[0-9]+$/", "dpdk0"}},
+		}}, crdv1.FelixConfigurationSpec{InterfaceExclude: "kube-ipvs0,/^vf[0-9]+$/,dpdk0"}),
+		Entry("Enterprise on RKE2", operator.InstallationSpec{Variant: operator.TigeraSecureEnterprise, KubernetesProvider: operator.ProviderRKE2},
+			crdv1.FelixConfigurationSpec{DNSTrustedServers: &[]string{"k8s-service:kube-system/rke2-coredns-rke2-coredns"}}),
+		Entry("Enterprise on OpenShift", operator.InstallationSpec{Variant: operator.TigeraSecureEnterprise, KubernetesProvider: operator.ProviderOpenShift},
+			crdv1.FelixConfigurationSpec{HealthPort: ptr.ToPtr(9199), DNSTrustedServers: &[]string{"k8s-service:openshift-dns/dns-default"}}),
+		Entry("Calico on RKE2", operator.InstallationSpec{Variant: operator.Calico, KubernetesProvider: operator.ProviderRKE2},
+			crdv1.FelixConfigurationSpec{}),
+		Entry("WireGuard enabled on IPv4", operator.InstallationSpec{CalicoNetwork: &operator.CalicoNetworkSpec{
+			WireGuard: ptr.ToPtr(operator.WireGuardEnabled),
+			IPPools:   []operator.IPPool{{CIDR: "192.168.0.0/16"}},
+		}}, crdv1.FelixConfigurationSpec{WireguardEnabled: ptr.ToPtr(true)}),
+		Entry("WireGuard enabled on dual stack", operator.InstallationSpec{CalicoNetwork: &operator.CalicoNetworkSpec{
+			WireGuard: ptr.ToPtr(operator.WireGuardEnabled),
+			IPPools:   []operator.IPPool{{CIDR: "192.168.0.0/16"}, {CIDR: "fd00:10:244::/64"}},
+		}}, crdv1.FelixConfigurationSpec{WireguardEnabled: ptr.ToPtr(true), WireguardEnabledV6: ptr.ToPtr(true)}),
+		Entry("WireGuard disabled on IPv6", operator.InstallationSpec{CalicoNetwork: &operator.CalicoNetworkSpec{
+			WireGuard: ptr.ToPtr(operator.WireGuardDisabled),
+			IPPools:   []operator.IPPool{{CIDR: "fd00:10:244::/64"}},
+		}}, crdv1.FelixConfigurationSpec{WireguardEnabledV6: ptr.ToPtr(false)}),
+		Entry("WireGuard left to the FelixConfiguration", operator.InstallationSpec{CalicoNetwork: &operator.CalicoNetworkSpec{
+			IPPools: []operator.IPPool{{CIDR: "192.168.0.0/16"}},
+		}}, crdv1.FelixConfigurationSpec{}),
+	)
+})
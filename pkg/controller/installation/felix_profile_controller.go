@@ -0,0 +1,347 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	cvalidation "github.com/tigera/operator/pkg/common/validation"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/logging"
+)
+
+var logfp = logf.Log.WithName("controller_felix_profile")
+
+// nodeFelixConfigurationPrefix is the prefix of the name of a node-scoped FelixConfiguration, which felix applies on
+// top of the default FelixConfiguration.
+const nodeFelixConfigurationPrefix = "node."
+
+// AddFelixProfileController creates a controller that maintains a node-scoped FelixConfiguration for every node that
+// selects one of the Installation's FelixProfiles or is selected by a FelixConfigurationOverlay, and adds it to the
+// Manager.
+func AddFelixProfileController(mgr manager.Manager, opts options.AddOptions) error {
+	r := &ReconcileFelixProfiles{
+		client: mgr.GetClient(),
+		status: status.New(mgr.GetClient(), "felix-profiles", opts.KubernetesVersion),
+	}
+	r.status.Run(opts.ShutdownContext)
+
+	c, err := ctrlruntime.NewController("tigera-felix-profile-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return fmt.Errorf("failed to create tigera-felix-profile-controller: %w", err)
+	}
+
+	// All events are reconciled in the same way, so map them all to the Installation.
+	enqueueInstallation := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: utils.DefaultInstanceKey}}
+	})
+	if err = c.WatchObject(&operatorv1.Installation{}, enqueueInstallation, utils.ObjectChangedPredicate); err != nil {
+		return fmt.Errorf("tigera-felix-profile-controller failed to watch Installation resource: %w", err)
+	}
+	if err = c.WatchObject(&operatorv1.FelixConfigurationOverlay{}, enqueueInstallation); err != nil {
+		return fmt.Errorf("tigera-felix-profile-controller failed to watch FelixConfigurationOverlay resource: %w", err)
+	}
+	if err = c.WatchObject(&corev1.Node{}, enqueueInstallation); err != nil {
+		return fmt.Errorf("tigera-felix-profile-controller failed to watch Nodes: %w", err)
+	}
+	if err = c.WatchObject(&crdv1.FelixConfiguration{}, enqueueInstallation); err != nil {
+		return fmt.Errorf("tigera-felix-profile-controller failed to watch FelixConfiguration resource: %w", err)
+	}
+	if err = utils.AddTigeraStatusWatch(c, "felix-profiles"); err != nil {
+		return fmt.Errorf("tigera-felix-profile-controller failed to watch felix-profiles Tigerastatus: %w", err)
+	}
+	return nil
+}
+
+// ReconcileFelixProfiles reconciles the node-scoped FelixConfigurations of the Installation's FelixProfiles and of
+// the FelixConfigurationOverlays.
+type ReconcileFelixProfiles struct {
+	client client.Client
+	status status.StatusManager
+}
+
+func (r *ReconcileFelixProfiles) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := logging.ForReconcile(ctx, logfp).WithValues("Request.Name", request.Name)
+	reqLogger.V(1).Info("Reconciling FelixProfiles")
+
+	var profiles []operatorv1.FelixProfile
+	_, installation, err := utils.GetInstallation(ctx, r.client)
+	if err != nil && !apierrors.IsNotFound(err) {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying Installation", err, reqLogger)
+		return reconcile.Result{}, err
+	} else if err == nil {
+		profiles = installation.FelixProfiles
+	}
+
+	overlays := &operatorv1.FelixConfigurationOverlayList{}
+	if err := r.client.List(ctx, overlays); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error listing FelixConfigurationOverlays", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	sort.Slice(overlays.Items, func(i, j int) bool { return overlays.Items[i].Name < overlays.Items[j].Name })
+
+	// Work out the FelixConfiguration of each node that selects a profile or is selected by an overlay.
+	desired := map[string]*crdv1.FelixConfiguration{}
+	selectedNodes := map[string]int32{}
+	if len(profiles) > 0 || len(overlays.Items) > 0 {
+		r.status.OnCRFound()
+
+		specs := map[string]*crdv1.FelixConfigurationSpec{}
+		for _, p := range profiles {
+			spec, err := FelixProfileSpec(p)
+			if err != nil {
+				r.status.SetDegraded(operatorv1.InvalidConfigurationError, fmt.Sprintf("Invalid FelixProfile %s", p.Name), err, reqLogger)
+				return reconcile.Result{}, nil
+			}
+			specs[p.Name] = spec
+		}
+		overlaySpecs := make([]*crdv1.FelixConfigurationSpec, len(overlays.Items))
+		selectors := make([]labels.Selector, len(overlays.Items))
+		for i, o := range overlays.Items {
+			spec, selector, err := FelixConfigurationOverlaySpec(&o)
+			if err != nil {
+				r.status.SetDegraded(operatorv1.InvalidConfigurationError, fmt.Sprintf("Invalid FelixConfigurationOverlay %s", o.Name), err, reqLogger)
+				return reconcile.Result{}, nil
+			}
+			overlaySpecs[i], selectors[i] = spec, selector
+		}
+
+		nodes := &corev1.NodeList{}
+		if err := r.client.List(ctx, nodes); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error listing Nodes", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		for _, node := range nodes.Items {
+			var layers []*crdv1.FelixConfigurationSpec
+			fcLabels := map[string]string{operatorv1.NodeFelixConfigurationLabel: "true"}
+			if profile, ok := node.Labels[operatorv1.FelixProfileNodeLabel]; ok {
+				if spec, ok := specs[profile]; ok {
+					layers = append(layers, spec)
+					fcLabels[operatorv1.FelixProfileNodeLabel] = profile
+				} else {
+					reqLogger.Info("Node selects a FelixProfile that does not exist", "node", node.Name, "profile", profile)
+				}
+			}
+			var applied []string
+			for i, o := range overlays.Items {
+				if !selectors[i].Matches(labels.Set(node.Labels)) {
+					continue
+				}
+				layers = append(layers, overlaySpecs[i])
+				applied = append(applied, o.Name)
+				selectedNodes[o.Name]++
+			}
+			if len(layers) == 0 {
+				continue
+			}
+
+			spec, err := mergeFelixSpecs(layers)
+			if err != nil {
+				r.status.SetDegraded(operatorv1.InvalidConfigurationError, fmt.Sprintf("Invalid Felix settings for node %s", node.Name), err, reqLogger)
+				return reconcile.Result{}, nil
+			}
+			fc := &crdv1.FelixConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   nodeFelixConfigurationPrefix + node.Name,
+					Labels: fcLabels,
+					// Let the garbage collector remove the FelixConfiguration together with its node.
+					OwnerReferences: []metav1.OwnerReference{{APIVersion: "v1", Kind: "Node", Name: node.Name, UID: node.UID}},
+				},
+				Spec: *spec,
+			}
+			if len(applied) > 0 {
+				fc.Annotations = map[string]string{operatorv1.FelixConfigurationOverlaysAnnotation: strings.Join(applied, ",")}
+			}
+			desired[fc.Name] = fc
+		}
+	} else {
+		r.status.OnCRNotFound()
+	}
+
+	// Remove the FelixConfigurations of nodes that no longer select a profile or are no longer selected by an overlay.
+	// Those that were created before the operator set the NodeFelixConfigurationLabel only carry the profile label.
+	managed := map[string]*crdv1.FelixConfiguration{}
+	for _, label := range []string{operatorv1.NodeFelixConfigurationLabel, operatorv1.FelixProfileNodeLabel} {
+		list := &crdv1.FelixConfigurationList{}
+		if err := r.client.List(ctx, list, client.HasLabels{label}); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error listing FelixConfigurations", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		for i := range list.Items {
+			managed[list.Items[i].Name] = &list.Items[i]
+		}
+	}
+	for _, fc := range managed {
+		if _, ok := desired[fc.Name]; ok {
+			continue
+		}
+		if err := r.client.Delete(ctx, fc); err != nil && !apierrors.IsNotFound(err) {
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, fmt.Sprintf("Error deleting FelixConfiguration %s", fc.Name), err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
+	for name, want := range desired {
+		current := &crdv1.FelixConfiguration{}
+		err := r.client.Get(ctx, types.NamespacedName{Name: name}, current)
+		if apierrors.IsNotFound(err) {
+			if err := r.client.Create(ctx, want); err != nil {
+				r.status.SetDegraded(operatorv1.ResourceCreateError, fmt.Sprintf("Error creating FelixConfiguration %s", name), err, reqLogger)
+				return reconcile.Result{}, err
+			}
+			continue
+		} else if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, fmt.Sprintf("Error querying FelixConfiguration %s", name), err, reqLogger)
+			return reconcile.Result{}, err
+		}
+
+		if _, ok := managed[name]; !ok {
+			// The FelixConfiguration of this node was created by the user, leave it to them.
+			reqLogger.Info("Not applying Felix settings to a node-scoped FelixConfiguration that the operator did not create", "name", name)
+			continue
+		}
+		applied := want.Annotations[operatorv1.FelixConfigurationOverlaysAnnotation]
+		if reflect.DeepEqual(current.Spec, want.Spec) && reflect.DeepEqual(current.Labels, want.Labels) &&
+			current.Annotations[operatorv1.FelixConfigurationOverlaysAnnotation] == applied {
+			continue
+		}
+		current.Labels = want.Labels
+		current.Spec = want.Spec
+		if applied != "" {
+			if current.Annotations == nil {
+				current.Annotations = map[string]string{}
+			}
+			current.Annotations[operatorv1.FelixConfigurationOverlaysAnnotation] = applied
+		} else {
+			delete(current.Annotations, operatorv1.FelixConfigurationOverlaysAnnotation)
+		}
+		if err := r.client.Update(ctx, current); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, fmt.Sprintf("Error updating FelixConfiguration %s", name), err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
+	for i := range overlays.Items {
+		o := &overlays.Items[i]
+		if o.Status.SelectedNodes == selectedNodes[o.Name] {
+			continue
+		}
+		o.Status.SelectedNodes = selectedNodes[o.Name]
+		if err := r.client.Status().Update(ctx, o); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, fmt.Sprintf("Error updating the status of FelixConfigurationOverlay %s", o.Name), err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
+	r.status.ClearDegraded()
+	return reconcile.Result{}, nil
+}
+
+// FelixProfileSpec decodes the FelixConfiguration spec of the given profile, and checks that it only holds known
+// fields that felix can use together.
+func FelixProfileSpec(p operatorv1.FelixProfile) (*crdv1.FelixConfigurationSpec, error) {
+	if errs := validation.IsValidLabelValue(p.Name); len(errs) > 0 {
+		return nil, fmt.Errorf("name %q is not a valid label value: %v", p.Name, errs)
+	}
+
+	spec, err := decodeFelixSpec(p.Spec.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("spec is not a valid FelixConfiguration spec: %w", err)
+	}
+	if err := cvalidation.ValidateFelixConfigurationSpec(spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// FelixConfigurationOverlaySpec decodes the FelixConfiguration spec and the node selector of the given overlay, and
+// checks that the spec only holds known fields that felix can use together.
+func FelixConfigurationOverlaySpec(o *operatorv1.FelixConfigurationOverlay) (*crdv1.FelixConfigurationSpec, labels.Selector, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&o.Spec.NodeSelector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("nodeSelector is invalid: %w", err)
+	}
+	spec, err := decodeFelixSpec(o.Spec.FelixConfiguration.Raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("felixConfiguration is not a valid FelixConfiguration spec: %w", err)
+	}
+	if err := cvalidation.ValidateFelixConfigurationSpec(spec); err != nil {
+		return nil, nil, err
+	}
+	return spec, selector, nil
+}
+
+func decodeFelixSpec(raw []byte) (*crdv1.FelixConfigurationSpec, error) {
+	spec := &crdv1.FelixConfigurationSpec{}
+	if len(raw) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(spec); err != nil {
+			return nil, err
+		}
+	}
+	return spec, nil
+}
+
+// mergeFelixSpecs returns the spec that sets each field to its value in the last of the given specs that sets it,
+// and checks that felix can use the resulting fields together.
+func mergeFelixSpecs(layers []*crdv1.FelixConfigurationSpec) (*crdv1.FelixConfigurationSpec, error) {
+	merged := map[string]json.RawMessage{}
+	for _, layer := range layers {
+		fields, err := felixSpecFields(layer)
+		if err != nil {
+			return nil, err
+		}
+		for field, value := range fields {
+			merged[field] = value
+		}
+	}
+	raw, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	spec := &crdv1.FelixConfigurationSpec{}
+	if err := json.Unmarshal(raw, spec); err != nil {
+		return nil, err
+	}
+	if err := cvalidation.ValidateFelixConfigurationSpec(spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
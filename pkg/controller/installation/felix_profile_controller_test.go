@@ -0,0 +1,287 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installation
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/stretchr/testify/mock"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operator "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+var _ = Describe("FelixProfile controller tests", func() {
+	var (
+		ctx          context.Context
+		cli          client.Client
+		mockStatus   *status.MockStatus
+		r            *ReconcileFelixProfiles
+		installation *operator.Installation
+	)
+
+	profile := func(name, spec string) operator.FelixProfile {
+		return operator.FelixProfile{Name: name, Spec: apiextensionsv1.JSON{Raw: []byte(spec)}}
+	}
+
+	createNode := func(name, profile string, extraLabels ...string) {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name + "-uid"), Labels: map[string]string{}}}
+		if profile != "" {
+			node.Labels[operator.FelixProfileNodeLabel] = profile
+		}
+		for _, l := range extraLabels {
+			node.Labels[l] = "true"
+		}
+		Expect(cli.Create(ctx, node)).NotTo(HaveOccurred())
+	}
+
+	createOverlay := func(name, label, spec string) *operator.FelixConfigurationOverlay {
+		o := &operator.FelixConfigurationOverlay{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: operator.FelixConfigurationOverlaySpec{
+				NodeSelector:       metav1.LabelSelector{MatchLabels: map[string]string{label: "true"}},
+				FelixConfiguration: apiextensionsv1.JSON{Raw: []byte(spec)},
+			},
+		}
+		Expect(cli.Create(ctx, o)).NotTo(HaveOccurred())
+		return o
+	}
+
+	reconcileProfiles := func() {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: utils.DefaultInstanceKey})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getFelixConfiguration := func(name string) (*crdv1.FelixConfiguration, error) {
+		fc := &crdv1.FelixConfiguration{}
+		return fc, cli.Get(ctx, types.NamespacedName{Name: name}, fc)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(corev1.AddToScheme(scheme)).NotTo(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+
+		mockStatus = &status.MockStatus{}
+		mockStatus.On("OnCRFound").Return()
+		mockStatus.On("OnCRNotFound").Return()
+		mockStatus.On("ClearDegraded").Return()
+		mockStatus.On("SetDegraded", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+		r = &ReconcileFelixProfiles{client: cli, status: mockStatus}
+
+		installation = &operator.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: operator.InstallationSpec{
+				FelixProfiles: []operator.FelixProfile{
+					profile("edge", `{"logSeverityScreen": "Debug", "healthPort": 9199}`),
+					profile("gpu", `{"bpfEnabled": true}`),
+				},
+			},
+		}
+		Expect(cli.Create(ctx, installation)).NotTo(HaveOccurred())
+	})
+
+	It("should maintain a FelixConfiguration for each node that selects a profile", func() {
+		createNode("edge-1", "edge")
+		createNode("gpu-1", "gpu")
+		createNode("plain-1", "")
+		createNode("other-1", "missing")
+		reconcileProfiles()
+
+		fc, err := getFelixConfiguration("node.edge-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fc.Labels).To(Equal(map[string]string{operator.FelixProfileNodeLabel: "edge", operator.NodeFelixConfigurationLabel: "true"}))
+		Expect(fc.OwnerReferences).To(ConsistOf(HaveField("Name", "edge-1")))
+		Expect(fc.Spec.LogSeverityScreen).To(Equal("Debug"))
+		Expect(fc.Spec.HealthPort).To(Equal(ptr.ToPtr(9199)))
+
+		fc, err = getFelixConfiguration("node.gpu-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fc.Spec.BPFEnabled).To(Equal(ptr.BoolToPtr(true)))
+
+		_, err = getFelixConfiguration("node.plain-1")
+		Expect(err).To(HaveOccurred())
+		_, err = getFelixConfiguration("node.other-1")
+		Expect(err).To(HaveOccurred())
+		mockStatus.AssertNotCalled(GinkgoT(), "SetDegraded", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	It("should follow changes to profiles and node labels", func() {
+		createNode("edge-1", "edge")
+		createNode("edge-2", "edge")
+		reconcileProfiles()
+
+		installation.Spec.FelixProfiles[0] = profile("edge", `{"logSeverityScreen": "Warning"}`)
+		Expect(cli.Update(ctx, installation)).NotTo(HaveOccurred())
+		node := &corev1.Node{}
+		Expect(cli.Get(ctx, types.NamespacedName{Name: "edge-2"}, node)).NotTo(HaveOccurred())
+		node.Labels = nil
+		Expect(cli.Update(ctx, node)).NotTo(HaveOccurred())
+		reconcileProfiles()
+
+		fc, err := getFelixConfiguration("node.edge-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fc.Spec.LogSeverityScreen).To(Equal("Warning"))
+		Expect(fc.Spec.HealthPort).To(BeNil())
+		_, err = getFelixConfiguration("node.edge-2")
+		Expect(err).To(HaveOccurred())
+
+		// Removing all profiles removes the remaining FelixConfigurations.
+		installation.Spec.FelixProfiles = nil
+		Expect(cli.Update(ctx, installation)).NotTo(HaveOccurred())
+		reconcileProfiles()
+		_, err = getFelixConfiguration("node.edge-1")
+		Expect(err).To(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "OnCRNotFound")
+	})
+
+	It("should leave node-scoped FelixConfigurations that users created alone", func() {
+		createNode("edge-1", "edge")
+		Expect(cli.Create(ctx, &crdv1.FelixConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "node.edge-1"},
+			Spec:       crdv1.FelixConfigurationSpec{LogSeverityScreen: "Info"},
+		})).NotTo(HaveOccurred())
+		reconcileProfiles()
+
+		fc, err := getFelixConfiguration("node.edge-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fc.Spec.LogSeverityScreen).To(Equal("Info"))
+		Expect(fc.Labels).To(BeEmpty())
+	})
+
+	It("should apply the overlays that select a node on top of its profile", func() {
+		createNode("edge-1", "edge", "gpu")
+		createNode("gpu-1", "", "gpu", "windows")
+		createNode("plain-1", "")
+		createOverlay("50-gpu", "gpu", `{"logSeverityScreen": "Info", "interfaceExclude": "gpu0"}`)
+		createOverlay("90-windows", "windows", `{"logSeverityScreen": "Warning"}`)
+		reconcileProfiles()
+
+		fc, err := getFelixConfiguration("node.edge-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fc.Labels).To(Equal(map[string]string{operator.FelixProfileNodeLabel: "edge", operator.NodeFelixConfigurationLabel: "true"}))
+		Expect(fc.Annotations).To(HaveKeyWithValue(operator.FelixConfigurationOverlaysAnnotation, "50-gpu"))
+		Expect(fc.Spec.LogSeverityScreen).To(Equal("Info"))
+		Expect(fc.Spec.HealthPort).To(Equal(ptr.ToPtr(9199)))
+		Expect(fc.Spec.InterfaceExclude).To(Equal("gpu0"))
+
+		// Overlays are applied in the order of their names.
+		fc, err = getFelixConfiguration("node.gpu-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fc.Labels).To(Equal(map[string]string{operator.NodeFelixConfigurationLabel: "true"}))
+		Expect(fc.Annotations).To(HaveKeyWithValue(operator.FelixConfigurationOverlaysAnnotation, "50-gpu,90-windows"))
+		Expect(fc.Spec.LogSeverityScreen).To(Equal("Warning"))
+		Expect(fc.Spec.InterfaceExclude).To(Equal("gpu0"))
+
+		_, err = getFelixConfiguration("node.plain-1")
+		Expect(err).To(HaveOccurred())
+
+		o := &operator.FelixConfigurationOverlay{}
+		Expect(cli.Get(ctx, types.NamespacedName{Name: "50-gpu"}, o)).NotTo(HaveOccurred())
+		Expect(o.Status.SelectedNodes).To(Equal(int32(2)))
+		mockStatus.AssertNotCalled(GinkgoT(), "SetDegraded", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+		// Removing the overlays leaves the profile, and removes the FelixConfiguration of the node without one.
+		Expect(cli.Delete(ctx, o)).NotTo(HaveOccurred())
+		Expect(cli.Delete(ctx, &operator.FelixConfigurationOverlay{ObjectMeta: metav1.ObjectMeta{Name: "90-windows"}})).NotTo(HaveOccurred())
+		reconcileProfiles()
+
+		fc, err = getFelixConfiguration("node.edge-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fc.Annotations).NotTo(HaveKey(operator.FelixConfigurationOverlaysAnnotation))
+		Expect(fc.Spec.LogSeverityScreen).To(Equal("Debug"))
+		Expect(fc.Spec.InterfaceExclude).To(BeEmpty())
+		_, err = getFelixConfiguration("node.gpu-1")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should maintain FelixConfigurations for overlays without any profiles", func() {
+		installation.Spec.FelixProfiles = nil
+		Expect(cli.Update(ctx, installation)).NotTo(HaveOccurred())
+		createNode("gpu-1", "", "gpu")
+		createOverlay("gpu", "gpu", `{"logSeverityScreen": "Info"}`)
+		reconcileProfiles()
+
+		fc, err := getFelixConfiguration("node.gpu-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fc.Spec.LogSeverityScreen).To(Equal("Info"))
+		mockStatus.AssertCalled(GinkgoT(), "OnCRFound")
+		mockStatus.AssertNotCalled(GinkgoT(), "OnCRNotFound")
+	})
+
+	It("should degrade when the settings of a node can't be used together", func() {
+		createNode("gpu-1", "gpu", "nft")
+		createOverlay("nft", "nft", `{"iptablesBackend": "NFT"}`)
+		reconcileProfiles()
+
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operator.InvalidConfigurationError, "Invalid Felix settings for node gpu-1", mock.Anything, mock.Anything)
+		_, err := getFelixConfiguration("node.gpu-1")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should degrade when an overlay is invalid", func() {
+		createNode("gpu-1", "", "gpu")
+		createOverlay("gpu", "gpu", `{"notAFelixField": true}`)
+		reconcileProfiles()
+
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operator.InvalidConfigurationError, "Invalid FelixConfigurationOverlay gpu", mock.Anything, mock.Anything)
+	})
+
+	It("should degrade when a profile is invalid", func() {
+		installation.Spec.FelixProfiles = []operator.FelixProfile{profile("edge", `{"notAFelixField": true}`)}
+		Expect(cli.Update(ctx, installation)).NotTo(HaveOccurred())
+		createNode("edge-1", "edge")
+		reconcileProfiles()
+
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operator.InvalidConfigurationError, "Invalid FelixProfile edge", mock.Anything, mock.Anything)
+		_, err := getFelixConfiguration("node.edge-1")
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("FelixProfileSpec",
+		func(p operator.FelixProfile, expectValid bool) {
+			_, err := FelixProfileSpec(p)
+			if expectValid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("empty spec", profile("edge", `{}`), true),
+		Entry("known fields", profile("edge", `{"logSeverityScreen": "Debug", "routeTableRange": {"min": 1, "max": 100}}`), true),
+		Entry("unknown field", profile("edge", `{"logSeverity": "Debug"}`), false),
+		Entry("invalid field combination", profile("edge", `{"bpfEnabled": true, "iptablesBackend": "NFT"}`), false),
+		Entry("name that is not a label value", profile("edge profile", `{}`), false),
+	)
+})
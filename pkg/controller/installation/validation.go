@@ -15,9 +15,12 @@
 package installation
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net"
 	"path"
+	"regexp"
 	"strings"
 
 	operatorv1 "github.com/tigera/operator/api/v1"
@@ -28,13 +31,27 @@ import (
 	kubecontrollers "github.com/tigera/operator/pkg/common/validation/kube-controllers"
 	typha "github.com/tigera/operator/pkg/common/validation/typha"
 	"github.com/tigera/operator/pkg/controller/k8sapi"
+	"github.com/tigera/operator/pkg/controller/maintenance"
+	"github.com/tigera/operator/pkg/controller/telemetry"
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/render"
 	appsv1 "k8s.io/api/apps/v1"
 
 	"k8s.io/apimachinery/pkg/api/resource"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ValidateInstallation checks the given Installation in the same way as the core controller does once it has filled
+// in the defaults. The defaults are filled in on a copy, so the given Installation is not changed.
+func ValidateInstallation(ctx context.Context, c client.Client, instance *operatorv1.Installation, provider operatorv1.Provider) error {
+	defaulted := instance.DeepCopy()
+	if err := updateInstallationWithDefaults(ctx, c, defaulted, provider); err != nil {
+		return err
+	}
+	return validateCustomResource(defaulted)
+}
+
 // validateCustomResource validates that the given custom resource is correct. This
 // should be called after populating defaults and before rendering objects.
 func validateCustomResource(instance *operatorv1.Installation) error {
@@ -158,6 +175,29 @@ func validateCustomResource(instance *operatorv1.Installation) error {
 				}
 			}
 
+			// Check that the encapsulation mode on the IP pool is supported by the network of the provider.
+			if instance.Spec.KubernetesProvider.IsAKS() && instance.Spec.CNI.Type == operatorv1.PluginCalico {
+				switch pool.Encapsulation {
+				case operatorv1.EncapsulationIPIP, operatorv1.EncapsulationIPIPCrossSubnet:
+					return &providerError{
+						provider: instance.Spec.KubernetesProvider,
+						err:      fmt.Errorf("%s encapsulation is not supported by Azure networking, which drops IP-in-IP traffic, but it is set for %s; use VXLAN", pool.Encapsulation, pool.CIDR),
+					}
+				}
+			}
+
+			// On IPv6 EKS clusters, the VPC routes pod traffic natively and there's no overlay to carry it.
+			if eks := instance.Status.EKSNetworking; eks != nil && eks.IPv6 {
+				switch pool.Encapsulation {
+				case "", operatorv1.EncapsulationNone:
+				default:
+					return &providerError{
+						provider: operatorv1.ProviderEKS,
+						err:      fmt.Errorf("%s encapsulation is not supported on IPv6 clusters, where the VPC routes pod traffic natively, but it is set for %s; use None", pool.Encapsulation, pool.CIDR),
+					}
+				}
+			}
+
 			// Check that the encapsulation mode on the IP pool is compatible with the CNI plugin that is in-use.
 			if instance.Spec.CNI.Type == operatorv1.PluginCalico {
 				switch instance.Spec.CNI.IPAM.Type {
@@ -247,12 +287,26 @@ func validateCustomResource(instance *operatorv1.Installation) error {
 			}
 		}
 
+		if instance.Spec.CalicoNetwork.BandwidthPlugin != nil {
+			if *instance.Spec.CalicoNetwork.BandwidthPlugin == operatorv1.BandwidthPluginEnabled {
+				if instance.Spec.CNI.Type != operatorv1.PluginCalico {
+					return fmt.Errorf("spec.calicoNetwork.bandwidthPlugin is supported only for Calico CNI")
+				}
+			}
+		}
+
 		if instance.Spec.CalicoNetwork.MultiInterfaceMode != nil {
 			if instance.Spec.CNI.Type != operatorv1.PluginCalico {
 				return fmt.Errorf("spec.calicoNetwork.multiInterfaceMode is supported only for Calico CNI")
 			}
 		}
 
+		if mc := instance.Spec.CalicoNetwork.MultusCompatibility; mc != nil {
+			if err := validateMultusCompatibility(mc); err != nil {
+				return err
+			}
+		}
+
 		if instance.Spec.CalicoNetwork.ContainerIPForwarding != nil {
 			if instance.Spec.CNI.Type != operatorv1.PluginCalico {
 				return fmt.Errorf("spec.calicoNetwork.containerIPForwarding is supported only for Calico CNI")
@@ -411,9 +465,124 @@ func validateCustomResource(instance *operatorv1.Installation) error {
 		}
 	}
 
+	// Hosted control planes don't run on nodes of the cluster, so there are no control plane nodes to select.
+	if instance.Spec.HostedControlPlane() {
+		for _, label := range []string{"node-role.kubernetes.io/master", "node-role.kubernetes.io/control-plane"} {
+			if _, ok := instance.Spec.ControlPlaneNodeSelector[label]; ok {
+				return fmt.Errorf("Installation spec.ControlPlaneNodeSelector must not select %s nodes when spec.ControlPlaneTopology is Hosted", label)
+			}
+		}
+	}
+
+	if instance.Spec.TelemetryEnabled() {
+		if _, err := telemetry.ParseEndpoint(instance.Spec.Telemetry.Endpoint); err != nil {
+			return fmt.Errorf("Installation spec.Telemetry.Endpoint is not valid: %w", err)
+		}
+	}
+
+	if err := validateUpgradeHooks(instance.Spec.UpgradeHooks); err != nil {
+		return err
+	}
+
+	if err := maintenance.Validate(instance.Spec.MaintenanceWindows); err != nil {
+		return fmt.Errorf("spec.maintenanceWindows: %w", err)
+	}
+
+	for _, p := range instance.Spec.FelixProfiles {
+		if _, err := FelixProfileSpec(p); err != nil {
+			return fmt.Errorf("Installation spec.FelixProfiles %s is not valid: %w", p.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// validateUpgradeHooks checks that upgrade hooks have unique names that can be used in the names of their Jobs, and
+// that they run at a valid point of the upgrade.
+func validateUpgradeHooks(hooks []operatorv1.UpgradeHook) error {
+	names := map[string]bool{}
+	for _, hook := range hooks {
+		if errs := k8svalidation.IsDNS1123Label(hook.Name); len(errs) > 0 || len(hook.Name) > 40 {
+			return fmt.Errorf("spec.upgradeHooks name %q must be a DNS label of at most 40 characters", hook.Name)
+		}
+		if names[hook.Name] {
+			return fmt.Errorf("spec.upgradeHooks has more than one hook named %s", hook.Name)
+		}
+		names[hook.Name] = true
+
+		switch hook.Stage {
+		case operatorv1.UpgradeStageCRDs, operatorv1.UpgradeStageAPIServer, operatorv1.UpgradeStageTypha,
+			operatorv1.UpgradeStageNode, operatorv1.UpgradeStageComponents:
+		default:
+			return fmt.Errorf("spec.upgradeHooks %s has an invalid stage %q", hook.Name, hook.Stage)
+		}
+		switch hook.Phase {
+		case operatorv1.UpgradeHookPhasePre, operatorv1.UpgradeHookPhasePost:
+		default:
+			return fmt.Errorf("spec.upgradeHooks %s has an invalid phase %q", hook.Name, hook.Phase)
+		}
+		if hook.Image == "" {
+			return fmt.Errorf("spec.upgradeHooks %s must specify an image", hook.Name)
+		}
+	}
+	return nil
+}
+
+// validateMultusCompatibility checks the interface names and network attachments of the Multus compatibility settings.
+func validateMultusCompatibility(mc *operatorv1.MultusCompatibility) error {
+	for _, iface := range mc.InterfaceExclude {
+		if iface == "" || strings.Contains(iface, ",") {
+			return fmt.Errorf("spec.calicoNetwork.multusCompatibility.interfaceExclude entry %q is not a valid interface name", iface)
+		}
+		if len(iface) > 1 && strings.HasPrefix(iface, "/") && strings.HasSuffix(iface, "/") {
+			if _, err := regexp.Compile(iface[1 : len(iface)-1]); err != nil {
+				return fmt.Errorf("spec.calicoNetwork.multusCompatibility.interfaceExclude entry %q is not a valid regular expression: %w", iface, err)
+			}
+		}
+	}
+
+	seen := map[operatorv1.MultusWorkload]bool{}
+	for _, na := range mc.NetworkAttachments {
+		switch na.Workload {
+		case operatorv1.MultusWorkloadCalicoKubeControllers, operatorv1.MultusWorkloadAPIServer:
+		default:
+			return fmt.Errorf("spec.calicoNetwork.multusCompatibility.networkAttachments workload %q is not supported", na.Workload)
+		}
+		if seen[na.Workload] {
+			return fmt.Errorf("spec.calicoNetwork.multusCompatibility.networkAttachments has more than one entry for %s", na.Workload)
+		}
+		seen[na.Workload] = true
+		if strings.TrimSpace(na.Networks) == "" {
+			return fmt.Errorf("spec.calicoNetwork.multusCompatibility.networkAttachments for %s must specify networks", na.Workload)
+		}
+	}
 	return nil
 }
 
+// providerError is returned by validation for configuration that can't work on the cluster's Kubernetes provider, so
+// that it's reported with a provider-specific reason rather than as generic invalid configuration.
+type providerError struct {
+	provider operatorv1.Provider
+	err      error
+}
+
+func (e *providerError) Error() string {
+	return fmt.Sprintf("invalid configuration for %s: %v", e.provider, e.err)
+}
+
+func (e *providerError) Unwrap() error {
+	return e.err
+}
+
+// validationReason returns the TigeraStatus reason to report for the given validation error.
+func validationReason(err error) operatorv1.TigeraStatusReason {
+	var pErr *providerError
+	if errors.As(err, &pErr) {
+		return operatorv1.ProviderConfigurationError
+	}
+	return operatorv1.InvalidConfigurationError
+}
+
 // validateNodeAddressDetection checks that at most one form of IP auto-detection is configured per-family.
 func validateNodeAddressDetection(ad *operatorv1.NodeAddressAutodetection) error {
 	numEnabled := 0
@@ -449,6 +618,44 @@ func validateNodeAddressDetection(ad *operatorv1.NodeAddressAutodetection) error
 	return nil
 }
 
+// validateHostNetworkPorts checks that the ports bound by calico/node and calico/typha don't conflict with each other.
+// Both run on the host network, so a port can only be used by one of them. A reporterPort of zero is ignored.
+func validateHostNetworkPorts(spec *operatorv1.InstallationSpec, felixHealthPort, reporterPort int) error {
+	typhaPort := int(render.TyphaPort)
+	if spec.TyphaPort != nil {
+		typhaPort = int(*spec.TyphaPort)
+	}
+
+	type namedPort struct {
+		name string
+		port int
+	}
+	ports := []namedPort{
+		{"spec.typhaPort", typhaPort},
+		{"FelixConfiguration healthPort", felixHealthPort},
+		// Typha binds its health endpoint to the port below the one used by Felix.
+		{"calico-typha health port", felixHealthPort - 1},
+	}
+	if spec.TyphaMetricsPort != nil {
+		ports = append(ports, namedPort{"spec.typhaMetricsPort", int(*spec.TyphaMetricsPort)})
+	}
+	if spec.NodeMetricsPort != nil {
+		ports = append(ports, namedPort{"spec.nodeMetricsPort", int(*spec.NodeMetricsPort)})
+	}
+	if reporterPort != 0 {
+		ports = append(ports, namedPort{"FelixConfiguration prometheusReporterPort", reporterPort})
+	}
+
+	seen := map[int]string{}
+	for _, p := range ports {
+		if other, ok := seen[p.port]; ok {
+			return fmt.Errorf("%s and %s are both set to port %d", other, p.name, p.port)
+		}
+		seen[p.port] = p.name
+	}
+	return nil
+}
+
 func validateHostPorts(hp *operatorv1.HostPortsType) error {
 	if hp == nil {
 		return fmt.Errorf("HostPorts must be set, it should be one of %s",
@@ -15,7 +15,9 @@
 package installation
 
 import (
+	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/tigera/operator/pkg/render"
 
@@ -26,9 +28,11 @@ import (
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	operator "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/controller/k8sapi"
+	"github.com/tigera/operator/pkg/ptr"
 )
 
 var _ = Describe("Installation validation tests", func() {
@@ -246,6 +250,23 @@ var _ = Describe("Installation validation tests", func() {
 		Expect(err).NotTo(HaveOccurred())
 	})
 
+	It("should only allow the bandwidth plugin with the Calico CNI plugin", func() {
+		bpf := operator.LinuxDataplaneBPF
+		bgp := operator.BGPDisabled
+		en := operator.BandwidthPluginEnabled
+		dis := operator.BandwidthPluginDisabled
+		instance.Spec.CalicoNetwork.LinuxDataplane = &bpf
+		instance.Spec.CalicoNetwork.BGP = &bgp
+		instance.Spec.CNI.Type = operator.PluginAmazonVPC
+		instance.Spec.CNI.IPAM.Type = operator.IPAMPluginAmazonVPC
+		instance.Spec.CalicoNetwork.BandwidthPlugin = &en
+		err := validateCustomResource(instance)
+		Expect(err).To(HaveOccurred())
+		instance.Spec.CalicoNetwork.BandwidthPlugin = &dis
+		err = validateCustomResource(instance)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
 	It("should prevent IPIP if BGP is disabled", func() {
 		disabled := operator.BGPDisabled
 		instance.Spec.CalicoNetwork.BGP = &disabled
@@ -407,6 +428,118 @@ var _ = Describe("Installation validation tests", func() {
 		Expect(err).To(HaveOccurred())
 	})
 
+	It("should report IPIP on AKS as a provider configuration error", func() {
+		bgp := operator.BGPEnabled
+		instance.Spec.KubernetesProvider = operator.ProviderAKS
+		instance.Spec.CalicoNetwork.BGP = &bgp
+		instance.Spec.CalicoNetwork.IPPools = []operator.IPPool{{CIDR: "192.168.0.0/16", Encapsulation: operator.EncapsulationIPIP}}
+		err := validateCustomResource(instance)
+		Expect(err).To(MatchError(ContainSubstring("invalid configuration for AKS: IPIP encapsulation is not supported by Azure networking")))
+		Expect(validationReason(err)).To(Equal(operator.ProviderConfigurationError))
+
+		instance.Spec.CalicoNetwork.IPPools[0].Encapsulation = operator.EncapsulationVXLAN
+		Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
+		Expect(validationReason(fmt.Errorf("generic"))).To(Equal(operator.InvalidConfigurationError))
+	})
+
+	It("should validate Multus compatibility settings", func() {
+		instance.Spec.CalicoNetwork.MultusCompatibility = &operator.MultusCompatibility{
+			InterfaceExclude: []string{"dpdk0", "/^vf[0-9]+$/"},
+			NetworkAttachments: []operator.WorkloadNetworkAttachment{
+				{Workload: operator.MultusWorkloadCalicoKubeControllers, Networks: "sriov-net1"},
+			},
+		}
+		Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
+
+		instance.Spec.CalicoNetwork.MultusCompatibility.InterfaceExclude = []string{"/^vf[0-9+$/"}
+		Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("is not a valid regular expression")))
+
+		instance.Spec.CalicoNetwork.MultusCompatibility.InterfaceExclude = []string{"eth1,eth2"}
+		Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("is not a valid interface name")))
+
+		instance.Spec.CalicoNetwork.MultusCompatibility.InterfaceExclude = nil
+		instance.Spec.CalicoNetwork.MultusCompatibility.NetworkAttachments = append(instance.Spec.CalicoNetwork.MultusCompatibility.NetworkAttachments,
+			operator.WorkloadNetworkAttachment{Workload: operator.MultusWorkloadCalicoKubeControllers, Networks: "sriov-net2"})
+		Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("more than one entry for CalicoKubeControllers")))
+
+		instance.Spec.CalicoNetwork.MultusCompatibility.NetworkAttachments = []operator.WorkloadNetworkAttachment{{Workload: operator.MultusWorkloadAPIServer}}
+		Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("must specify networks")))
+	})
+
+	It("should validate upgrade hooks", func() {
+		instance.Spec.UpgradeHooks = []operator.UpgradeHook{
+			{Name: "backup-datastore", Stage: operator.UpgradeStageCRDs, Phase: operator.UpgradeHookPhasePre, Image: "example.com/backup:v1"},
+			{Name: "smoke-test", Stage: operator.UpgradeStageNode, Phase: operator.UpgradeHookPhasePost, Image: "example.com/smoke:v1"},
+		}
+		Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
+
+		instance.Spec.UpgradeHooks[1].Name = "backup-datastore"
+		Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("more than one hook named backup-datastore")))
+
+		instance.Spec.UpgradeHooks[1].Name = "Smoke_Test"
+		Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("must be a DNS label")))
+
+		instance.Spec.UpgradeHooks[1].Name = "smoke-test"
+		instance.Spec.UpgradeHooks[1].Stage = "Felix"
+		Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("invalid stage")))
+
+		instance.Spec.UpgradeHooks[1].Stage = operator.UpgradeStageNode
+		instance.Spec.UpgradeHooks[1].Phase = "During"
+		Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("invalid phase")))
+
+		instance.Spec.UpgradeHooks[1].Phase = operator.UpgradeHookPhasePost
+		instance.Spec.UpgradeHooks[1].Image = ""
+		Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("must specify an image")))
+	})
+
+	It("should validate maintenance windows", func() {
+		instance.Spec.MaintenanceWindows = []operator.MaintenanceWindow{{Schedule: "0 2 * * 6", Duration: metav1.Duration{Duration: 4 * time.Hour}}}
+		Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
+
+		instance.Spec.MaintenanceWindows[0].Schedule = "0 2 * *"
+		Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("spec.maintenanceWindows: schedule \"0 2 * *\" must have 5 fields")))
+	})
+
+	It("should report encapsulation on IPv6 EKS clusters as a provider configuration error", func() {
+		bgp := operator.BGPEnabled
+		instance.Spec.KubernetesProvider = operator.ProviderEKS
+		instance.Spec.CalicoNetwork.BGP = &bgp
+		instance.Status.EKSNetworking = &operator.EKSNetworkingStatus{IPv6: true}
+		instance.Spec.CalicoNetwork.IPPools = []operator.IPPool{{CIDR: "fd00::/64", Encapsulation: operator.EncapsulationVXLAN}}
+		err := validateCustomResource(instance)
+		Expect(err).To(MatchError(ContainSubstring("invalid configuration for EKS: VXLAN encapsulation is not supported on IPv6 clusters")))
+		Expect(validationReason(err)).To(Equal(operator.ProviderConfigurationError))
+
+		instance.Spec.CalicoNetwork.IPPools[0].Encapsulation = operator.EncapsulationNone
+		Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
+	})
+
+	It("should not allow selecting control plane nodes when the control plane is hosted", func() {
+		hosted := operator.ControlPlaneTopologyHosted
+		instance.Spec.ControlPlaneTopology = &hosted
+		instance.Spec.ControlPlaneNodeSelector = map[string]string{"node-role.kubernetes.io/control-plane": ""}
+		Expect(validateCustomResource(instance)).To(MatchError(
+			"Installation spec.ControlPlaneNodeSelector must not select node-role.kubernetes.io/control-plane nodes when spec.ControlPlaneTopology is Hosted"))
+
+		instance.Spec.ControlPlaneNodeSelector = map[string]string{"infra": "true"}
+		Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
+	})
+
+	It("should require a valid endpoint when telemetry reporting is enabled", func() {
+		enabled := operator.TelemetryReportingEnabled
+		instance.Spec.Telemetry = &operator.Telemetry{Reporting: &enabled}
+		Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("Installation spec.Telemetry.Endpoint is not valid")))
+
+		instance.Spec.Telemetry.Endpoint = "https://telemetry.example.com/report"
+		Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
+	})
+
+	It("should not require an endpoint when telemetry reporting is disabled", func() {
+		disabled := operator.TelemetryReportingDisabled
+		instance.Spec.Telemetry = &operator.Telemetry{Reporting: &disabled}
+		Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
+	})
+
 	Describe("validate Calico CNI plugin Type", func() {
 		DescribeTable("test invalid IPAM",
 			func(ipam operator.IPAMPluginType) {
@@ -1160,4 +1293,35 @@ var _ = Describe("Installation validation tests", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	Describe("validate host network ports", func() {
+		It("should accept the default ports", func() {
+			Expect(validateHostNetworkPorts(&instance.Spec, 9099, 9081)).NotTo(HaveOccurred())
+		})
+
+		It("should accept distinct custom ports", func() {
+			instance.Spec.TyphaPort = ptr.Int32ToPtr(5474)
+			instance.Spec.TyphaMetricsPort = ptr.Int32ToPtr(9093)
+			instance.Spec.NodeMetricsPort = ptr.Int32ToPtr(9091)
+			Expect(validateHostNetworkPorts(&instance.Spec, 9099, 9081)).NotTo(HaveOccurred())
+		})
+
+		It("should reject typha and node metrics on the same port", func() {
+			instance.Spec.TyphaMetricsPort = ptr.Int32ToPtr(9091)
+			instance.Spec.NodeMetricsPort = ptr.Int32ToPtr(9091)
+			Expect(validateHostNetworkPorts(&instance.Spec, 9099, 0)).To(MatchError(
+				"spec.typhaMetricsPort and spec.nodeMetricsPort are both set to port 9091"))
+		})
+
+		It("should reject a typha port that conflicts with the typha health port", func() {
+			instance.Spec.TyphaPort = ptr.Int32ToPtr(9098)
+			Expect(validateHostNetworkPorts(&instance.Spec, 9099, 0)).To(HaveOccurred())
+		})
+
+		It("should only check the reporter port when it is set", func() {
+			instance.Spec.NodeMetricsPort = ptr.Int32ToPtr(9081)
+			Expect(validateHostNetworkPorts(&instance.Spec, 9099, 0)).NotTo(HaveOccurred())
+			Expect(validateHostNetworkPorts(&instance.Spec, 9099, 9081)).To(HaveOccurred())
+		})
+	})
 })
@@ -53,6 +53,7 @@ import (
 	"github.com/tigera/operator/pkg/controller/utils/imageset"
 	"github.com/tigera/operator/pkg/ctrlruntime"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render"
 	"github.com/tigera/operator/pkg/render/monitor"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
@@ -74,7 +75,7 @@ func AddWindowsController(mgr manager.Manager, opts options.AddOptions) error {
 	}
 
 	// Watch for changes to primary resource Installation
-	err = c.WatchObject(&operatorv1.Installation{}, &handler.EnqueueRequestForObject{})
+	err = c.WatchObject(&operatorv1.Installation{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate)
 	if err != nil {
 		return fmt.Errorf("tigera-windows-controller failed to watch primary resource: %w", err)
 	}
@@ -154,7 +155,7 @@ func AddWindowsController(mgr manager.Manager, opts options.AddOptions) error {
 	if err != nil {
 		return fmt.Errorf("tigera-windows-controller failed to establish a connection to k8s: %w", err)
 	}
-	go utils.WaitToAddResourceWatch(c, k8sClient, logw, ri.ipamConfigWatchReady, []client.Object{&apiv3.IPAMConfiguration{TypeMeta: metav1.TypeMeta{Kind: apiv3.KindIPAMConfiguration}}})
+	go utils.WaitToAddResourceWatch(opts.ShutdownContext, c, k8sClient, logw, ri.ipamConfigWatchReady, []client.Object{&apiv3.IPAMConfiguration{TypeMeta: metav1.TypeMeta{Kind: apiv3.KindIPAMConfiguration}}})
 
 	if ri.enterpriseCRDsExist {
 		for _, ns := range []string{common.CalicoNamespace, common.OperatorNamespace()} {
@@ -212,7 +213,7 @@ func newWindowsReconciler(mgr manager.Manager, opts options.AddOptions) (*Reconc
 // and what is in the Installation.Spec. The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
 func (r *ReconcileWindows) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	reqLogger := logw.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger := logging.ForReconcile(ctx, logw).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling Installation.operator.tigera.io")
 
 	// Get the installation object if it exists so that we can save the original
@@ -293,7 +294,7 @@ func (r *ReconcileWindows) Reconcile(ctx context.Context, request reconcile.Requ
 		return reconcile.Result{}, err
 	}
 
-	certificateManager, err := certificatemanager.Create(r.client, &instance.Spec, r.clusterDomain, common.OperatorNamespace())
+	certificateManager, err := certificatemanager.Create(r.client, &instance.Spec, r.clusterDomain, common.OperatorNamespace(), certificatemanager.WithLogger(reqLogger))
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
 		return reconcile.Result{}, err
@@ -317,7 +318,7 @@ func (r *ReconcileWindows) Reconcile(ctx context.Context, request reconcile.Requ
 	// Fetch and validate default IPAMConfiguration for StrictAffinity when using Calico IPAM
 	if instance.Spec.CNI.Type == operatorv1.PluginCalico && instance.Spec.CNI.IPAM.Type == operatorv1.IPAMPluginCalico {
 		if !r.ipamConfigWatchReady.IsReady() {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for IPAMConfiguration watch to be established", nil, logw)
+			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for IPAMConfiguration watch to be established", r.ipamConfigWatchReady.Err(), logw)
 			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 		}
 		ipamConfiguration := &apiv3.IPAMConfiguration{}
@@ -375,7 +376,7 @@ func (r *ReconcileWindows) Reconcile(ctx context.Context, request reconcile.Requ
 	}
 	kubeDNSIPs := kubeDNSService.Spec.ClusterIPs
 
-	// felixConfiguration.Spec.VXLANVNI is defaulted by fillDefaults() in the core controller, so if it isn't set, requeue to wait until it is
+	// felixConfiguration.Spec.VXLANVNI is defaulted by the felix defaults controller, so if it isn't set, requeue to wait until it is
 	if felixConfiguration.Spec.VXLANVNI == nil {
 		err = fmt.Errorf("VXLANVNI not specified in FelixConfigurationSpec")
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Error reading VXLANVNI from FelixConfiguration", err, reqLogger)
@@ -18,8 +18,6 @@ import (
 	"context"
 	"fmt"
 
-	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
-
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -29,7 +27,6 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -49,11 +46,13 @@ import (
 	"github.com/tigera/operator/pkg/controller/utils/imageset"
 	"github.com/tigera/operator/pkg/ctrlruntime"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render"
 	rcertificatemanagement "github.com/tigera/operator/pkg/render/certificatemanagement"
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/intrusiondetection/dpi"
+	"github.com/tigera/operator/pkg/render/siemexport"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 )
 
@@ -72,12 +71,13 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	licenseAPIReady := &utils.ReadyFlag{}
 	dpiAPIReady := &utils.ReadyFlag{}
 	tierWatchReady := &utils.ReadyFlag{}
+	threatFeedWatchReady := &utils.ReadyFlag{}
 
 	// Create the reconciler
-	reconciler := newReconciler(mgr, opts, licenseAPIReady, dpiAPIReady, tierWatchReady)
+	reconciler := newReconciler(mgr, opts, licenseAPIReady, dpiAPIReady, tierWatchReady, threatFeedWatchReady)
 
 	// Create a new controller
-	c, err := ctrlruntime.NewController("intrusiondetection-controller", mgr, controller.Options{Reconciler: reconcile.Reconciler(reconciler)})
+	c, err := ctrlruntime.NewController("intrusiondetection-controller", mgr, utils.ControllerOptions("intrusiondetection-controller", reconcile.Reconciler(reconciler), opts))
 	if err != nil {
 		return fmt.Errorf("failed to create intrusiondetection-controller: %v", err)
 	}
@@ -103,31 +103,36 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	}
 	if !opts.MultiTenant {
 		// DPI is only supported in single-tenant mode.
-		go utils.WaitToAddResourceWatch(c, k8sClient, log, dpiAPIReady,
+		go utils.WaitToAddResourceWatch(opts.ShutdownContext, c, k8sClient, log, dpiAPIReady,
 			[]client.Object{&v3.DeepPacketInspection{TypeMeta: metav1.TypeMeta{Kind: v3.KindDeepPacketInspection}}})
 		policiesToWatch = append(policiesToWatch, types.NamespacedName{Name: dpi.DeepPacketInspectionPolicyName, Namespace: dpi.DeepPacketInspectionNamespace})
+		policiesToWatch = append(policiesToWatch, types.NamespacedName{Name: siemexport.PolicyName, Namespace: installNS})
+
+		// The threat feed mirror and export are only supported in single-tenant mode.
+		go utils.WaitToAddResourceWatch(opts.ShutdownContext, c, k8sClient, log, threatFeedWatchReady,
+			[]client.Object{&v3.GlobalThreatFeed{TypeMeta: metav1.TypeMeta{Kind: v3.KindGlobalThreatFeed}}})
 	}
-	go utils.WaitToAddNetworkPolicyWatches(c, k8sClient, log, policiesToWatch)
-	go utils.WaitToAddLicenseKeyWatch(c, k8sClient, log, licenseAPIReady)
-	go utils.WaitToAddTierWatch(networkpolicy.TigeraComponentTierName, c, k8sClient, log, tierWatchReady)
+	go utils.WaitToAddNetworkPolicyWatches(opts.ShutdownContext, c, k8sClient, log, policiesToWatch)
+	go utils.WaitToAddLicenseKeyWatch(opts.ShutdownContext, c, k8sClient, log, licenseAPIReady)
+	go utils.WaitToAddTierWatch(opts.ShutdownContext, networkpolicy.TigeraComponentTierName, c, k8sClient, log, tierWatchReady)
 
 	// Watch for changes to operator.tigera.io APIs.
-	if err = c.WatchObject(&operatorv1.IntrusionDetection{}, &handler.EnqueueRequestForObject{}); err != nil {
+	if err = c.WatchObject(&operatorv1.IntrusionDetection{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("intrusiondetection-controller failed to watch primary resource: %v", err)
 	}
-	if err = c.WatchObject(&operatorv1.LogCollector{}, eventHandler); err != nil {
+	if err = c.WatchObject(&operatorv1.LogCollector{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("intrusiondetection-controller failed to watch LogCollector resource: %v", err)
 	}
-	if err = c.WatchObject(&operatorv1.ManagementCluster{}, eventHandler); err != nil {
+	if err = c.WatchObject(&operatorv1.ManagementCluster{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("intrusiondetection-controller failed to watch primary resource: %w", err)
 	}
-	if err = c.WatchObject(&operatorv1.Installation{}, eventHandler); err != nil {
+	if err = c.WatchObject(&operatorv1.Installation{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("intrusiondetection-controller failed to watch Installation resource: %w", err)
 	}
-	if err = c.WatchObject(&operatorv1.APIServer{}, eventHandler); err != nil {
+	if err = c.WatchObject(&operatorv1.APIServer{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("intrusiondetection-controller failed to watch APIServer resource: %w", err)
 	}
-	if err = c.WatchObject(&operatorv1.ImageSet{}, eventHandler); err != nil {
+	if err = c.WatchObject(&operatorv1.ImageSet{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("intrusiondetection-controller failed to watch ImageSet: %w", err)
 	}
 	if err = utils.AddTigeraStatusWatch(c, tigeraStatusName); err != nil {
@@ -155,6 +160,10 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		return fmt.Errorf("intrusiondetection-controller failed to watch the Secret resource: %v", err)
 	}
 
+	if err = utils.AddReplicatedSecretsWatch(c, mgr); err != nil {
+		return fmt.Errorf("intrusiondetection-controller failed to watch replicated secrets: %v", err)
+	}
+
 	if err = utils.AddConfigMapWatch(c, relasticsearch.ClusterConfigConfigMapName, truthNS, eventHandler); err != nil {
 		return fmt.Errorf("intrusiondetection-controller failed to watch the ConfigMap resource: %v", err)
 	}
@@ -163,22 +172,38 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		return fmt.Errorf("intrusiondetection-controller failed to watch the ConfigMap resource: %v", err)
 	}
 
+	// Watch the secrets and config maps that the reconciler reads, such as those of the threat feed mirror.
+	if err = reconciler.dependencyTracker.Start(c); err != nil {
+		return fmt.Errorf("intrusiondetection-controller failed to watch its dependencies: %w", err)
+	}
+
 	return nil
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager, opts options.AddOptions, licenseAPIReady *utils.ReadyFlag, dpiAPIReady *utils.ReadyFlag, tierWatchReady *utils.ReadyFlag) reconcile.Reconciler {
+func newReconciler(mgr manager.Manager, opts options.AddOptions, licenseAPIReady *utils.ReadyFlag, dpiAPIReady *utils.ReadyFlag, tierWatchReady *utils.ReadyFlag, threatFeedWatchReady *utils.ReadyFlag) *ReconcileIntrusionDetection {
+	// The secrets that the reconciler reads without watching them explicitly, such as those of the threat feed mirror,
+	// live in the truth and install namespaces. In multi-tenant clusters, changes to them need to reach every tenant's
+	// IntrusionDetection.
+	var trackerHandler handler.EventHandler = &handler.EnqueueRequestForObject{}
+	if opts.MultiTenant {
+		trackerHandler = utils.EnqueueTenantInstances(mgr.GetClient(), &operatorv1.IntrusionDetectionList{})
+	}
+	helper := utils.NewNamespaceHelper(opts.MultiTenant, render.IntrusionDetectionNamespace, "")
+	tracker := utils.NewDependencyTracker(trackerHandler, helper.TruthNamespace(), helper.InstallNamespace())
 	r := &ReconcileIntrusionDetection{
-		client:          mgr.GetClient(),
-		scheme:          mgr.GetScheme(),
-		provider:        opts.DetectedProvider,
-		status:          status.New(mgr.GetClient(), tigeraStatusName, opts.KubernetesVersion),
-		clusterDomain:   opts.ClusterDomain,
-		licenseAPIReady: licenseAPIReady,
-		dpiAPIReady:     dpiAPIReady,
-		tierWatchReady:  tierWatchReady,
-		multiTenant:     opts.MultiTenant,
-		elasticExternal: opts.ElasticExternal,
+		client:               tracker.Client(mgr.GetClient()),
+		scheme:               mgr.GetScheme(),
+		provider:             opts.DetectedProvider,
+		status:               status.New(mgr.GetClient(), tigeraStatusName, opts.KubernetesVersion),
+		clusterDomain:        opts.ClusterDomain,
+		licenseAPIReady:      licenseAPIReady,
+		dpiAPIReady:          dpiAPIReady,
+		tierWatchReady:       tierWatchReady,
+		threatFeedWatchReady: threatFeedWatchReady,
+		multiTenant:          opts.MultiTenant,
+		elasticExternal:      opts.ElasticExternal,
+		dependencyTracker:    tracker,
 	}
 	r.status.Run(opts.ShutdownContext)
 	return r
@@ -201,6 +226,12 @@ type ReconcileIntrusionDetection struct {
 	tierWatchReady  *utils.ReadyFlag
 	multiTenant     bool
 	elasticExternal bool
+
+	// threatFeedWatchReady is set once the GlobalThreatFeeds are watched, so that they're listed from the cache.
+	threatFeedWatchReady *utils.ReadyFlag
+
+	// dependencyTracker watches the secrets and config maps read through the client.
+	dependencyTracker *utils.DependencyTracker
 }
 
 func getIntrusionDetection(ctx context.Context, cli client.Client, mt bool, ns string) (*operatorv1.IntrusionDetection, error) {
@@ -224,7 +255,7 @@ func getIntrusionDetection(ctx context.Context, cli client.Client, mt bool, ns s
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
 func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	helper := utils.NewNamespaceHelper(r.multiTenant, render.IntrusionDetectionNamespace, request.Namespace)
-	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name, "installNS", helper.InstallNamespace(), "truthNS", helper.TruthNamespace())
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name, "installNS", helper.InstallNamespace(), "truthNS", helper.TruthNamespace())
 	reqLogger.Info("Reconciling IntrusionDetection")
 
 	// We skip requests without a namespace specified in multi-tenant setups.
@@ -267,6 +298,8 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 			return reconcile.Result{}, err
 		}
 		instance.Status.Conditions = status.UpdateStatusCondition(instance.Status.Conditions, ts.Status.Conditions)
+		instance.Status.ObservedGeneration = status.ObservedGeneration(ts.Status.Conditions)
+		instance.Status.Rollout = r.status.RolloutStatus()
 		if err := r.client.Status().Update(ctx, instance); err != nil {
 			log.WithValues("reason", err).Info("Failed to create IntrusionDetection status conditions.")
 			return reconcile.Result{}, err
@@ -292,55 +325,41 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 		return reconcile.Result{}, err
 	}
 
-	if !utils.IsAPIServerReady(r.client, reqLogger) {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tigera API server to be ready", nil, reqLogger)
-		return reconcile.Result{}, err
+	if err := validateThreatFeeds(&instance.Spec, r.multiTenant); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Invalid threat feed configuration", err, reqLogger)
+		return reconcile.Result{}, nil
 	}
 
-	if !isManagedCluster && !r.elasticExternal {
-		// Check if Elasticsearch is ready.
-		elasticsearch, err := utils.GetElasticsearch(ctx, r.client)
-		if err != nil {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred trying to retrieve Elasticsearch", err, reqLogger)
-			return reconcile.Result{}, err
-		}
-		if elasticsearch == nil || elasticsearch.Status.Phase != esv1.ElasticsearchReadyPhase {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Elasticsearch cluster to be operational", nil, reqLogger)
-			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	siemExport := instance.Spec.SIEMExport
+	var siemExportCredentials *corev1.Secret
+	if siemExport != nil {
+		if err = utils.ValidateSIEMExport(siemExport, r.multiTenant, managementClusterConnection); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, "Invalid SIEM export configuration", err, reqLogger)
+			return reconcile.Result{}, nil
 		}
-	}
-
-	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
-	if !r.tierWatchReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", nil, reqLogger)
-		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
-	}
-
-	// Ensure the allow-tigera tier exists, before rendering any network policies within it.
-	if err := r.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
-		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", err, reqLogger)
-			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
-		} else {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Error querying allow-tigera tier", err, reqLogger)
+		siemExportCredentials, err = utils.GetSIEMExportCredentials(ctx, r.client, siemExport)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				r.status.SetDegraded(operatorv1.ResourceNotFound, "Waiting for the credentials of the SIEM export", err, reqLogger)
+				return reconcile.Result{}, nil
+			}
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error reading the credentials of the SIEM export", err, reqLogger)
 			return reconcile.Result{}, err
 		}
 	}
 
-	if !r.licenseAPIReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for LicenseKeyAPI to be ready", nil, reqLogger)
-		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
-	}
-
-	license, err := utils.FetchLicenseKey(ctx, r.client)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "License not found", err, reqLogger)
-			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
-		}
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying license", err, reqLogger)
-		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+	prereqs, result, err := utils.Prerequisites{
+		UpgradeStage:  operatorv1.UpgradeStageComponents,
+		APIServer:     true,
+		Elasticsearch: !isManagedCluster && !r.elasticExternal,
+		TierWatch:     r.tierWatchReady,
+		LicenseAPI:    r.licenseAPIReady,
+		License:       true,
+	}.Check(ctx, r.client, r.status, reqLogger)
+	if result != nil {
+		return *result, err
 	}
+	license := prereqs.License
 
 	// Query for the installation object.
 	variant, network, err := utils.GetInstallation(context.Background(), r.client)
@@ -404,6 +423,16 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 		return reconcile.Result{}, err
 	}
 
+	// siemExportKeyPair is the key pair the SIEM export presents to Linseed.
+	var siemExportKeyPair certificatemanagement.KeyPairInterface
+	if siemExport != nil {
+		siemExportKeyPair, err = certificateManager.GetOrCreateKeyPair(r.client, siemexport.KeyPairName(siemexport.SourceSecurityEvents), helper.TruthNamespace(), []string{"localhost"})
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceCreateError, "Error creating TLS certificate", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
 	if !r.multiTenant && !r.dpiAPIReady.IsReady() {
 		// DPI is only supported in single-tenant clusters, so we don't need to check for it in multi-tenant.
 		log.Info("Waiting for DeepPacketInspection API to be ready")
@@ -431,6 +460,66 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 		bundleMaker.AddCertificates(managerInternalTLSSecret)
 	}
 
+	var threatFeedMirror *render.ThreatFeedMirrorConfiguration
+	var threatFeedExport *render.ThreatFeedIndex
+	var staleThreatFeeds []string
+	if !r.multiTenant {
+		if !r.threatFeedWatchReady.IsReady() {
+			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for GlobalThreatFeed watch to be established", r.threatFeedWatchReady.Err(), reqLogger)
+			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+		}
+		feeds := &v3.GlobalThreatFeedList{}
+		if err := r.client.List(ctx, feeds); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to list GlobalThreatFeeds", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+
+		if mirror := instance.Spec.ThreatFeedMirror; mirror != nil {
+			index, err := getThreatFeedIndex(ctx, r.client, mirror)
+			if errors.IsNotFound(err) {
+				r.status.SetDegraded(operatorv1.ResourceNotFound, fmt.Sprintf("Waiting for threat feed mirror index ConfigMap %s", mirror.IndexConfigMapName), err, reqLogger)
+				return reconcile.Result{}, nil
+			} else if err != nil {
+				r.status.SetDegraded(operatorv1.ResourceValidationError, "Failed to read threat feed mirror index", err, reqLogger)
+				return reconcile.Result{}, nil
+			}
+			authSecret, err := getThreatFeedMirrorAuth(ctx, r.client, mirror)
+			if errors.IsNotFound(err) {
+				r.status.SetDegraded(operatorv1.ResourceNotFound, fmt.Sprintf("Waiting for threat feed mirror auth Secret %s", mirror.AuthSecretName), err, reqLogger)
+				return reconcile.Result{}, nil
+			} else if err != nil {
+				r.status.SetDegraded(operatorv1.ResourceValidationError, "Failed to read threat feed mirror auth", err, reqLogger)
+				return reconcile.Result{}, nil
+			}
+			if mirror.CASecretName != "" {
+				caCert, err := certificateManager.GetCertificate(r.client, mirror.CASecretName, common.OperatorNamespace())
+				if err != nil {
+					r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("Failed to retrieve / validate %s", mirror.CASecretName), err, reqLogger)
+					return reconcile.Result{}, nil
+				} else if caCert == nil {
+					r.status.SetDegraded(operatorv1.ResourceNotFound, fmt.Sprintf("Waiting for threat feed mirror CA Secret %s", mirror.CASecretName), nil, reqLogger)
+					return reconcile.Result{}, nil
+				}
+				bundleMaker.AddCertificates(caCert)
+			}
+			threatFeedMirror = &render.ThreatFeedMirrorConfiguration{URL: mirror.URL, Index: index, AuthSecret: authSecret}
+		}
+
+		var index *render.ThreatFeedIndex
+		if threatFeedMirror != nil {
+			index = threatFeedMirror.Index
+		}
+		staleThreatFeeds, err = staleMirroredThreatFeeds(feeds.Items, index)
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, "Threat feed mirror conflicts with an existing GlobalThreatFeed", err, reqLogger)
+			return reconcile.Result{}, nil
+		}
+
+		if instance.Spec.ThreatFeedExport != nil && *instance.Spec.ThreatFeedExport == operatorv1.ThreatFeedExportEnabled {
+			threatFeedExport = render.ExportThreatFeeds(feeds.Items)
+		}
+	}
+
 	trustedBundle := bundleMaker.(certificatemanagement.TrustedBundleRO)
 	if r.multiTenant {
 		// For multi-tenant systems, we load the pre-created bundle for this tenant instead of using the one we built here.
@@ -443,7 +532,7 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 	}
 
 	// Create a component handler to manage the rendered component.
-	handler := utils.NewComponentHandler(log, r.client, r.scheme, instance)
+	handler := utils.NewComponentHandler(logging.ForReconcile(ctx, log), r.client, r.scheme, instance)
 
 	// Determine the namespaces to which we must bind the cluster role.
 	namespaces, err := helper.TenantNamespaces(r.client)
@@ -471,6 +560,9 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 		BindNamespaces:               namespaces,
 		Tenant:                       tenant,
 		ExternalElastic:              r.elasticExternal,
+		ThreatFeedMirror:             threatFeedMirror,
+		StaleThreatFeeds:             staleThreatFeeds,
+		ThreatFeedExport:             threatFeedExport,
 	}
 	intrusionDetectionComponent := render.IntrusionDetection(intrusionDetectionCfg)
 
@@ -494,9 +586,10 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 		rcertificatemanagement.CertificateManagement(&rcertificatemanagement.Config{
 			Namespace:       helper.InstallNamespace(),
 			TruthNamespace:  helper.TruthNamespace(),
-			ServiceAccounts: []string{render.IntrusionDetectionName},
+			ServiceAccounts: []string{render.IntrusionDetectionName, siemexport.Name},
 			KeyPairOptions: []rcertificatemanagement.KeyPairOption{
 				rcertificatemanagement.NewKeyPairOption(intrusionDetectionCfg.IntrusionDetectionCertSecret, true, true),
+				rcertificatemanagement.NewKeyPairOption(siemExportKeyPair, true, true),
 			},
 			TrustedBundle: bundleMaker,
 		}),
@@ -504,6 +597,24 @@ func (r *ReconcileIntrusionDetection) Reconcile(ctx context.Context, request rec
 	}
 
 	if !r.multiTenant {
+		// The SIEM export is rendered without its configuration when it's disabled, so that it's removed.
+		siemExportComponent := siemexport.SIEMExport(&siemexport.Config{
+			Source:        siemexport.SourceSecurityEvents,
+			Namespace:     helper.InstallNamespace(),
+			Export:        siemExport,
+			Credentials:   siemExportCredentials,
+			Installation:  network,
+			PullSecrets:   pullSecrets,
+			OpenShift:     r.provider.IsOpenShift(),
+			TrustedBundle: trustedBundle,
+			KeyPair:       siemExportKeyPair,
+		})
+		if err = imageset.ApplyImageSet(ctx, r.client, variant, siemExportComponent); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		components = append(components, siemExportComponent)
+
 		// DPI is only supported in single-tenant / zero-tenant clusters.
 
 		// FIXME: core controller creates TyphaNodeTLSConfig, this controller should only get it.
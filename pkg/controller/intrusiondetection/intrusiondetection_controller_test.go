@@ -52,6 +52,8 @@ import (
 	rtest "github.com/tigera/operator/pkg/render/common/test"
 	"github.com/tigera/operator/pkg/render/intrusiondetection/dpi"
 	"github.com/tigera/operator/pkg/render/logstorage/eck"
+	"github.com/tigera/operator/pkg/render/siemexport"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 	"github.com/tigera/operator/test"
 )
 
@@ -85,6 +87,7 @@ var _ = Describe("IntrusionDetection controller tests", func() {
 		mockStatus.On("RemoveDaemonsets", mock.Anything).Return()
 		mockStatus.On("AddStatefulSets", mock.Anything).Return()
 		mockStatus.On("AddCronJobs", mock.Anything)
+		mockStatus.On("RemoveCronJobs", mock.Anything)
 		mockStatus.On("IsAvailable").Return(true)
 		mockStatus.On("OnCRFound").Return()
 		mockStatus.On("ClearDegraded")
@@ -99,15 +102,17 @@ var _ = Describe("IntrusionDetection controller tests", func() {
 
 		mockStatus.On("ReadyToMonitor")
 		mockStatus.On("SetMetaData", mock.Anything).Return()
+		mockStatus.On("RolloutStatus").Return(nil)
 
 		r = ReconcileIntrusionDetection{
-			client:          c,
-			scheme:          scheme,
-			provider:        operatorv1.ProviderNone,
-			status:          mockStatus,
-			licenseAPIReady: &utils.ReadyFlag{},
-			dpiAPIReady:     &utils.ReadyFlag{},
-			tierWatchReady:  &utils.ReadyFlag{},
+			client:               c,
+			scheme:               scheme,
+			provider:             operatorv1.ProviderNone,
+			status:               mockStatus,
+			licenseAPIReady:      &utils.ReadyFlag{},
+			dpiAPIReady:          &utils.ReadyFlag{},
+			tierWatchReady:       &utils.ReadyFlag{},
+			threatFeedWatchReady: &utils.ReadyFlag{},
 		}
 
 		// We start off with a 'standard' installation, with nothing special
@@ -185,6 +190,7 @@ var _ = Describe("IntrusionDetection controller tests", func() {
 		r.licenseAPIReady.MarkAsReady()
 		r.dpiAPIReady.MarkAsReady()
 		r.tierWatchReady.MarkAsReady()
+		r.threatFeedWatchReady.MarkAsReady()
 	})
 
 	Context("image reconciliation", func() {
@@ -312,13 +318,14 @@ var _ = Describe("IntrusionDetection controller tests", func() {
 			readyFlag = &utils.ReadyFlag{}
 			readyFlag.MarkAsReady()
 			r = ReconcileIntrusionDetection{
-				client:          c,
-				scheme:          scheme,
-				provider:        operatorv1.ProviderNone,
-				status:          mockStatus,
-				licenseAPIReady: readyFlag,
-				dpiAPIReady:     readyFlag,
-				tierWatchReady:  readyFlag,
+				client:               c,
+				scheme:               scheme,
+				provider:             operatorv1.ProviderNone,
+				status:               mockStatus,
+				licenseAPIReady:      readyFlag,
+				dpiAPIReady:          readyFlag,
+				tierWatchReady:       readyFlag,
+				threatFeedWatchReady: readyFlag,
 			}
 		})
 
@@ -619,6 +626,156 @@ var _ = Describe("IntrusionDetection controller tests", func() {
 		})
 	})
 
+	Context("Threat feed mirror and export", func() {
+		var caSecret *corev1.Secret
+
+		BeforeEach(func() {
+			mockStatus.On("SetDegraded", mock.Anything, mock.Anything).Return()
+
+			caSecret = rtest.CreateCertSecret("mirror-ca", common.OperatorNamespace(), "mirror.example.com")
+			Expect(c.Create(ctx, caSecret)).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "mirror-auth", Namespace: common.OperatorNamespace()},
+				Data:       map[string][]byte{render.ThreatFeedMirrorAuthKey: []byte("Bearer token")},
+			})).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "mirror-index", Namespace: common.OperatorNamespace()},
+				Data: map[string]string{render.ThreatFeedIndexKey: `{"feeds": [
+					{"name": "blocklist", "content": "IPSet", "period": "12h", "file": "feeds/blocklist.txt"}
+				]}`},
+			})).NotTo(HaveOccurred())
+
+			// A feed that is pulled from its source, and a mirrored feed that is no longer in the index.
+			Expect(c.Create(ctx, &v3.GlobalThreatFeed{
+				ObjectMeta: metav1.ObjectMeta{Name: "feodo"},
+				Spec: v3.GlobalThreatFeedSpec{
+					Content: v3.ThreatFeedContentIPset,
+					Pull: &v3.Pull{HTTP: &v3.HTTPPull{
+						URL:    "https://feodotracker.abuse.ch/downloads/ipblocklist.txt",
+						Format: v3.ThreatFeedFormat{NewlineDelimited: &v3.ThreatFeedFormatNewlineDelimited{}},
+					}},
+				},
+			})).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, &v3.GlobalThreatFeed{
+				ObjectMeta: metav1.ObjectMeta{Name: "removed", Labels: map[string]string{render.ThreatFeedMirrorLabel: "true"}},
+				Spec:       v3.GlobalThreatFeedSpec{Pull: &v3.Pull{HTTP: &v3.HTTPPull{URL: "https://mirror.example.com/removed"}}},
+			})).NotTo(HaveOccurred())
+
+			ids := &operatorv1.IntrusionDetection{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, ids)).NotTo(HaveOccurred())
+			export := operatorv1.ThreatFeedExportEnabled
+			ids.Spec.ThreatFeedExport = &export
+			ids.Spec.ThreatFeedMirror = &operatorv1.ThreatFeedMirror{
+				URL:                "https://mirror.example.com/threat-feeds/",
+				IndexConfigMapName: "mirror-index",
+				CASecretName:       "mirror-ca",
+				AuthSecretName:     "mirror-auth",
+			}
+			Expect(c.Update(ctx, ids)).NotTo(HaveOccurred())
+		})
+
+		It("should create the feeds of the mirror and export the pulled feeds", func() {
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			feed := &v3.GlobalThreatFeed{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "blocklist"}, feed)).NotTo(HaveOccurred())
+			Expect(feed.Labels).To(HaveKeyWithValue(render.ThreatFeedMirrorLabel, "true"))
+			Expect(feed.Spec.Pull.Period).To(Equal("12h"))
+			Expect(feed.Spec.Pull.HTTP.URL).To(Equal("https://mirror.example.com/threat-feeds/feeds/blocklist.txt"))
+			Expect(feed.Spec.Pull.HTTP.Headers).To(HaveLen(1))
+			Expect(feed.Spec.Pull.HTTP.Headers[0].ValueFrom.SecretKeyRef.Name).To(Equal(render.ThreatFeedMirrorAuthSecretName))
+
+			auth := &corev1.Secret{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: render.ThreatFeedMirrorAuthSecretName, Namespace: render.IntrusionDetectionNamespace}, auth)).NotTo(HaveOccurred())
+			Expect(auth.Data).To(HaveKeyWithValue(render.ThreatFeedMirrorAuthKey, []byte("Bearer token")))
+
+			Expect(c.Get(ctx, client.ObjectKey{Name: "removed"}, &v3.GlobalThreatFeed{})).To(HaveOccurred())
+
+			bundle := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: certificatemanagement.TrustedCertConfigMapName, Namespace: render.IntrusionDetectionNamespace}, bundle)).NotTo(HaveOccurred())
+			Expect(bundle.Data[certificatemanagement.TrustedCertConfigMapKeyName]).To(ContainSubstring(string(caSecret.Data[corev1.TLSCertKey])))
+
+			cm := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: render.ThreatFeedExportConfigMapName, Namespace: common.OperatorNamespace()}, cm)).NotTo(HaveOccurred())
+			index, err := render.ParseThreatFeedIndex([]byte(cm.Data[render.ThreatFeedIndexKey]))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(index.Feeds).To(HaveLen(1))
+			Expect(index.Feeds[0].Name).To(Equal("feodo"))
+			Expect(index.Feeds[0].SourceURL).To(Equal("https://feodotracker.abuse.ch/downloads/ipblocklist.txt"))
+			Expect(index.Feeds[0].Format.NewlineDelimited).NotTo(BeNil())
+		})
+
+		It("should not replace a feed that isn't read from the mirror", func() {
+			mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Threat feed mirror conflicts with an existing GlobalThreatFeed", mock.Anything, mock.Anything).Return()
+			Expect(c.Create(ctx, &v3.GlobalThreatFeed{ObjectMeta: metav1.ObjectMeta{Name: "blocklist"}})).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Threat feed mirror conflicts with an existing GlobalThreatFeed", mock.Anything, mock.Anything)
+
+			feed := &v3.GlobalThreatFeed{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "blocklist"}, feed)).NotTo(HaveOccurred())
+			Expect(feed.Labels).NotTo(HaveKey(render.ThreatFeedMirrorLabel))
+		})
+
+		It("should remove the mirrored feeds and the export once they're disabled", func() {
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			ids := &operatorv1.IntrusionDetection{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, ids)).NotTo(HaveOccurred())
+			ids.Spec.ThreatFeedMirror = nil
+			ids.Spec.ThreatFeedExport = nil
+			Expect(c.Update(ctx, ids)).NotTo(HaveOccurred())
+
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKey{Name: "blocklist"}, &v3.GlobalThreatFeed{})).To(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKey{Name: "feodo"}, &v3.GlobalThreatFeed{})).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKey{Name: render.ThreatFeedMirrorAuthSecretName, Namespace: render.IntrusionDetectionNamespace}, &corev1.Secret{})).To(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKey{Name: render.ThreatFeedExportConfigMapName, Namespace: common.OperatorNamespace()}, &corev1.ConfigMap{})).To(HaveOccurred())
+		})
+	})
+
+	Context("SIEM export", func() {
+		BeforeEach(func() {
+			ids := &operatorv1.IntrusionDetection{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, ids)).NotTo(HaveOccurred())
+			ids.Spec.SIEMExport = &operatorv1.SIEMExport{
+				Schedule: "*/30 * * * *",
+				ObjectStorage: operatorv1.SIEMExportObjectStorage{
+					Bucket:                "siem",
+					Endpoint:              "https://minio.example.com:9000",
+					CredentialsSecretName: "siem-credentials",
+				},
+			}
+			Expect(c.Update(ctx, ids)).NotTo(HaveOccurred())
+		})
+
+		It("should wait for the credentials and then create the export CronJob", func() {
+			mockStatus.On("SetDegraded", operatorv1.ResourceNotFound, "Waiting for the credentials of the SIEM export", mock.Anything, mock.Anything).Return()
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceNotFound, "Waiting for the credentials of the SIEM export", mock.Anything, mock.Anything)
+
+			Expect(c.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "siem-credentials", Namespace: common.OperatorNamespace()},
+				Data: map[string][]byte{
+					siemexport.AccessKeyIDKey:     []byte("id"),
+					siemexport.SecretAccessKeyKey: []byte("secret"),
+				},
+			})).NotTo(HaveOccurred())
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			cj := &batchv1.CronJob{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: siemexport.Name, Namespace: render.IntrusionDetectionNamespace}, cj)).NotTo(HaveOccurred())
+			Expect(cj.Spec.Schedule).To(Equal("*/30 * * * *"))
+			Expect(cj.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "SIEM_EXPORT_SOURCE", Value: "security-events"}))
+		})
+	})
+
 	Context("External ES mode", func() {
 		BeforeEach(func() {
 			// Delete the Elasticsearch CR. This is created for ECK only.
@@ -0,0 +1,104 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intrusiondetection
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/render"
+)
+
+// validateThreatFeeds returns an error if the threat feed mirror or export of the IntrusionDetection is invalid.
+func validateThreatFeeds(spec *operatorv1.IntrusionDetectionSpec, multiTenant bool) error {
+	exportEnabled := spec.ThreatFeedExport != nil && *spec.ThreatFeedExport == operatorv1.ThreatFeedExportEnabled
+	if multiTenant && (spec.ThreatFeedMirror != nil || exportEnabled) {
+		return fmt.Errorf("threatFeedMirror and threatFeedExport are not supported in multi-tenant management clusters")
+	}
+	mirror := spec.ThreatFeedMirror
+	if mirror == nil {
+		return nil
+	}
+	u, err := url.Parse(mirror.URL)
+	if err != nil {
+		return fmt.Errorf("threatFeedMirror.url %q is invalid: %w", mirror.URL, err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("threatFeedMirror.url %q must be an https URL", mirror.URL)
+	}
+	if mirror.IndexConfigMapName == "" {
+		return fmt.Errorf("threatFeedMirror.indexConfigMapName must be specified")
+	}
+	return nil
+}
+
+// getThreatFeedIndex reads the index of the threat feeds in the mirror from its ConfigMap in the operator namespace.
+func getThreatFeedIndex(ctx context.Context, cli client.Client, mirror *operatorv1.ThreatFeedMirror) (*render.ThreatFeedIndex, error) {
+	cm := &corev1.ConfigMap{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: mirror.IndexConfigMapName, Namespace: common.OperatorNamespace()}, cm); err != nil {
+		return nil, err
+	}
+	data, ok := cm.Data[render.ThreatFeedIndexKey]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap %s/%s has no %s key", cm.Namespace, cm.Name, render.ThreatFeedIndexKey)
+	}
+	return render.ParseThreatFeedIndex([]byte(data))
+}
+
+// getThreatFeedMirrorAuth reads the secret in the operator namespace that holds the Authorization header of the
+// mirror, if one is configured.
+func getThreatFeedMirrorAuth(ctx context.Context, cli client.Client, mirror *operatorv1.ThreatFeedMirror) (*corev1.Secret, error) {
+	if mirror.AuthSecretName == "" {
+		return nil, nil
+	}
+	s := &corev1.Secret{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: mirror.AuthSecretName, Namespace: common.OperatorNamespace()}, s); err != nil {
+		return nil, err
+	}
+	if len(s.Data[render.ThreatFeedMirrorAuthKey]) == 0 {
+		return nil, fmt.Errorf("Secret %s/%s has no %s key", s.Namespace, s.Name, render.ThreatFeedMirrorAuthKey)
+	}
+	return s, nil
+}
+
+// staleMirroredThreatFeeds returns the mirrored GlobalThreatFeeds that are not in the index, so they can be removed.
+// The index is nil if there is no mirror. It returns an error if a feed in the index would replace a GlobalThreatFeed
+// that wasn't created from the mirror.
+func staleMirroredThreatFeeds(feeds []v3.GlobalThreatFeed, index *render.ThreatFeedIndex) ([]string, error) {
+	inIndex := map[string]bool{}
+	if index != nil {
+		for _, f := range index.Feeds {
+			inIndex[f.Name] = true
+		}
+	}
+	var stale []string
+	for _, f := range feeds {
+		mirrored := f.Labels[render.ThreatFeedMirrorLabel] != ""
+		switch {
+		case inIndex[f.Name] && !mirrored:
+			return nil, fmt.Errorf("GlobalThreatFeed %s already exists and isn't read from the threat feed mirror", f.Name)
+		case !inIndex[f.Name] && mirrored:
+			stale = append(stale, f.Name)
+		}
+	}
+	return stale, nil
+}
@@ -125,6 +125,15 @@ func fillDefaults(ctx context.Context, client client.Client, instance *operator.
 						Encapsulation: operator.EncapsulationVXLAN,
 					},
 				}
+			case operator.ProviderAKS:
+				// On AKS, Azure networking drops IP-in-IP traffic, so use VXLAN encap by default.
+				instance.Spec.CalicoNetwork.IPPools = []operator.IPPool{
+					{
+						Name:          defaultPoolName,
+						CIDR:          "192.168.0.0/16",
+						Encapsulation: operator.EncapsulationVXLAN,
+					},
+				}
 			default:
 				instance.Spec.CalicoNetwork.IPPools = []operator.IPPool{
 					{
@@ -170,7 +179,9 @@ func fillDefaults(ctx context.Context, client client.Client, instance *operator.
 		if err == nil && addr.To4() != nil {
 			// This is an IPv4 pool.
 			if pool.Encapsulation == "" {
-				if instance.Spec.CNI.Type == operator.PluginCalico {
+				if instance.Spec.CNI.Type == operator.PluginCalico && instance.Spec.KubernetesProvider.IsAKS() {
+					pool.Encapsulation = operator.EncapsulationVXLAN
+				} else if instance.Spec.CNI.Type == operator.PluginCalico {
 					pool.Encapsulation = operator.EncapsulationIPIP
 				} else {
 					pool.Encapsulation = operator.EncapsulationNone
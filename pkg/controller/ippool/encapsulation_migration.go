@@ -0,0 +1,322 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ippool
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operator "github.com/tigera/operator/api/v1"
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/noderollout"
+	"github.com/tigera/operator/pkg/controller/upgrade"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+// An IP pool that moves to a different tunnel is migrated in steps, so that traffic between pods keeps flowing while
+// the nodes switch over:
+//
+//   - Both tunnels are enabled in the default FelixConfiguration, and the pools keep their old encapsulation until
+//     calico-node has rolled out, including any change to the DaemonSet that came with the new encapsulation.
+//   - The pools are updated, and the migration waits until every node has an address for the new tunnel.
+//   - The settings that were enabled for the transition are unset again, so that Felix goes back to enabling the
+//     tunnels that the pools use.
+//
+// Changes between an encapsulation and its cross-subnet mode use the same tunnel and are applied directly.
+
+const (
+	// calico-node records the tunnel addresses that it allocates for a node in these annotations on the node.
+	ipipTunnelAddrAnnotation    = "projectcalico.org/IPv4IPIPTunnelAddr"
+	vxlanTunnelAddrAnnotation   = "projectcalico.org/IPv4VXLANTunnelAddr"
+	vxlanV6TunnelAddrAnnotation = "projectcalico.org/IPv6VXLANTunnelAddr"
+
+	felixIPIPEnabledSettingName  = "ipipEnabled"
+	felixVXLANEnabledSettingName = "vxlanEnabled"
+)
+
+// tunnelOf returns the tunnel that the encapsulation uses, treating the cross-subnet modes like the modes that always
+// encapsulate.
+func tunnelOf(e operator.EncapsulationType) operator.EncapsulationType {
+	switch e {
+	case operator.EncapsulationIPIP, operator.EncapsulationIPIPCrossSubnet:
+		return operator.EncapsulationIPIP
+	case operator.EncapsulationVXLAN, operator.EncapsulationVXLANCrossSubnet:
+		return operator.EncapsulationVXLAN
+	default:
+		return operator.EncapsulationNone
+	}
+}
+
+// planEncapsulationMigration compares the IP pools in the Installation with the pools that the operator owns in the
+// cluster, and returns the migration that moves pools to a different tunnel, or nil if there is none. All the pools
+// must move in the same direction.
+func planEncapsulationMigration(installation *operator.Installation, ourPools map[string]crdv1.IPPool) (*operator.EncapsulationMigrationStatus, error) {
+	var planned *operator.EncapsulationMigrationStatus
+	for _, p := range installation.Spec.CalicoNetwork.IPPools {
+		current, ok := ourPools[p.CIDR]
+		if !ok {
+			continue
+		}
+		v1p := operator.IPPool{}
+		v1p.FromProjectCalicoV1(current)
+		from, to := tunnelOf(v1p.Encapsulation), tunnelOf(p.Encapsulation)
+		if from == to {
+			continue
+		}
+		if planned == nil {
+			planned = &operator.EncapsulationMigrationStatus{From: from, To: to, Phase: operator.EncapsulationMigrationEnablingTunnels}
+		} else if planned.From != from || planned.To != to {
+			return nil, fmt.Errorf("IP pool %s moves from %s to %s encapsulation while IP pools %s move from %s to %s; "+
+				"migrate the pools in one direction at a time",
+				p.CIDR, from, to, strings.Join(planned.Pools, ", "), planned.From, planned.To)
+		}
+		planned.Pools = append(planned.Pools, p.CIDR)
+	}
+	if planned != nil {
+		sort.Strings(planned.Pools)
+	}
+	return planned, nil
+}
+
+// validateEncapsulationMigration checks that the planned migration can start, or that it is the migration that is
+// already in progress. The errors explain how to make the change instead.
+func validateEncapsulationMigration(ctx context.Context, c client.Client, installation *operator.Installation, planned *operator.EncapsulationMigrationStatus) error {
+	if planned == nil {
+		return nil
+	}
+	if m := installation.Status.EncapsulationMigration; m != nil {
+		if m.From != planned.From || m.To != planned.To || !slices.Equal(m.Pools, planned.Pools) {
+			err := fmt.Errorf("an encapsulation migration of IP pools %s from %s to %s is in progress; set their encapsulation to %s "+
+				"and wait for the migration to complete before changing it again", strings.Join(m.Pools, ", "), m.From, m.To, m.To)
+			if m.Phase == operator.EncapsulationMigrationEnablingTunnels {
+				err = fmt.Errorf("%w, or set it back to %s to cancel the migration", err, m.From)
+			}
+			return err
+		}
+		return nil
+	}
+
+	if upgrade.InProgress(installation.Status.Upgrade) {
+		return fmt.Errorf("cannot migrate IP pools %s from %s to %s encapsulation while the upgrade to %s is in progress; "+
+			"wait for the upgrade to complete", strings.Join(planned.Pools, ", "), planned.From, planned.To, installation.Status.Upgrade.ToVersion)
+	}
+	for _, cidr := range planned.Pools {
+		if planned.To == operator.EncapsulationIPIP && strings.Contains(cidr, ":") {
+			return fmt.Errorf("cannot migrate IPv6 pool %s to IPIP encapsulation, which only supports IPv4; use VXLAN", cidr)
+		}
+	}
+
+	// A tunnel that is explicitly disabled in Felix can't carry traffic during the migration.
+	fc := &crdv1.FelixConfiguration{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "default"}, fc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	for _, tunnel := range []operator.EncapsulationType{planned.From, planned.To} {
+		if setting, name := felixTunnelSetting(fc, tunnel); setting != nil && *setting != nil && !**setting {
+			return fmt.Errorf("cannot migrate IP pools %s from %s to %s encapsulation while FelixConfiguration default sets %s "+
+				"to false; remove the setting so that both tunnels can be enabled during the migration",
+				strings.Join(planned.Pools, ", "), planned.From, planned.To, name)
+		}
+	}
+	return nil
+}
+
+// holdsPool returns true if the IP pool must keep its current encapsulation for now.
+func holdsPool(m *operator.EncapsulationMigrationStatus, cidr string) bool {
+	return m != nil && m.Phase == operator.EncapsulationMigrationEnablingTunnels && slices.Contains(m.Pools, cidr)
+}
+
+// advanceEncapsulationMigration moves the migration in the Installation status, or the planned one if none is in
+// progress, as far along as it can and returns its new status. It returns nil once the migration is complete.
+func advanceEncapsulationMigration(ctx context.Context, c client.Client, installation *operator.Installation, planned *operator.EncapsulationMigrationStatus) (*operator.EncapsulationMigrationStatus, error) {
+	m := installation.Status.EncapsulationMigration
+	if m == nil {
+		if planned == nil {
+			return nil, nil
+		}
+		m = planned
+	} else {
+		m = m.DeepCopy()
+	}
+
+	switch m.Phase {
+	case operator.EncapsulationMigrationEnablingTunnels:
+		if planned == nil {
+			// The pools were set back to their old encapsulation before they were changed.
+			return nil, resetTransitionalFelixSettings(ctx, c, m)
+		}
+		if err := enableTransitionalFelixSettings(ctx, c, m); err != nil {
+			return m, err
+		}
+		waiting, err := calicoNodeRollingOut(ctx, c, installation)
+		if err != nil {
+			return m, err
+		}
+		if waiting != "" {
+			m.Message = fmt.Sprintf("Waiting for %s before changing the encapsulation of the IP pools", waiting)
+			return m, nil
+		}
+		m.Phase = operator.EncapsulationMigrationUpdatingPools
+		m.Message = "Changing the encapsulation of the IP pools"
+		return m, nil
+
+	case operator.EncapsulationMigrationUpdatingPools:
+		if planned != nil {
+			// The pools have been sent to the API server, but the change hasn't been observed yet.
+			return m, nil
+		}
+		waiting, err := calicoNodeRollingOut(ctx, c, installation)
+		if err == nil && waiting == "" {
+			waiting, err = nodeWithoutTunnelAddress(ctx, c, installation, m)
+		}
+		if err != nil {
+			return m, err
+		}
+		if waiting != "" {
+			m.Message = fmt.Sprintf("Waiting for %s", waiting)
+			return m, nil
+		}
+		return nil, resetTransitionalFelixSettings(ctx, c, m)
+	}
+	return m, nil
+}
+
+// felixTunnelSetting returns the FelixConfiguration field that enables the tunnel, and its name, or nil if the
+// encapsulation doesn't use a tunnel.
+func felixTunnelSetting(fc *crdv1.FelixConfiguration, tunnel operator.EncapsulationType) (**bool, string) {
+	switch tunnel {
+	case operator.EncapsulationIPIP:
+		return &fc.Spec.IPIPEnabled, felixIPIPEnabledSettingName
+	case operator.EncapsulationVXLAN:
+		return &fc.Spec.VXLANEnabled, felixVXLANEnabledSettingName
+	}
+	return nil, ""
+}
+
+// enableTransitionalFelixSettings enables both tunnels of the migration in the default FelixConfiguration. Only the
+// settings that weren't set are enabled and recorded in the status, so that settings made by the user are left alone.
+func enableTransitionalFelixSettings(ctx context.Context, c client.Client, m *operator.EncapsulationMigrationStatus) error {
+	_, err := utils.PatchFelixConfiguration(ctx, c, func(fc *crdv1.FelixConfiguration) (bool, error) {
+		changed := false
+		for _, tunnel := range []operator.EncapsulationType{m.From, m.To} {
+			setting, name := felixTunnelSetting(fc, tunnel)
+			if setting == nil || *setting != nil {
+				continue
+			}
+			*setting = ptr.BoolToPtr(true)
+			if !slices.Contains(m.TransitionalFelixSettings, name) {
+				m.TransitionalFelixSettings = append(m.TransitionalFelixSettings, name)
+			}
+			changed = true
+		}
+		return changed, nil
+	})
+	return err
+}
+
+// resetTransitionalFelixSettings unsets the FelixConfiguration settings that the migration enabled.
+func resetTransitionalFelixSettings(ctx context.Context, c client.Client, m *operator.EncapsulationMigrationStatus) error {
+	if len(m.TransitionalFelixSettings) == 0 {
+		return nil
+	}
+	_, err := utils.PatchFelixConfiguration(ctx, c, func(fc *crdv1.FelixConfiguration) (bool, error) {
+		changed := false
+		for _, tunnel := range []operator.EncapsulationType{operator.EncapsulationIPIP, operator.EncapsulationVXLAN} {
+			setting, name := felixTunnelSetting(fc, tunnel)
+			if slices.Contains(m.TransitionalFelixSettings, name) && *setting != nil {
+				*setting = nil
+				changed = true
+			}
+		}
+		return changed, nil
+	})
+	return err
+}
+
+// calicoNodeRollingOut returns what the migration waits for while calico-node is rolling out, or an empty string if
+// every calico-node pod runs the current spec.
+func calicoNodeRollingOut(ctx context.Context, c client.Client, installation *operator.Installation) (string, error) {
+	if noderollout.InProgress(installation.Status.NodeRollout) {
+		return "the coordinated rollout of calico-node", nil
+	}
+	ds := &appsv1.DaemonSet{}
+	if err := c.Get(ctx, types.NamespacedName{Name: common.NodeDaemonSetName, Namespace: common.CalicoNamespace}, ds); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "calico-node to be created", nil
+		}
+		return "", err
+	}
+	if !upgrade.DaemonSetRolledOut(ds) {
+		return "calico-node to roll out", nil
+	}
+	return "", nil
+}
+
+// nodeWithoutTunnelAddress returns the first node that doesn't have an address for the new tunnel yet, or an empty
+// string if they all do. calico-node allocates the addresses once the pools use the tunnel. Only pools that select
+// all nodes are checked, since the other pools don't give every node an address.
+func nodeWithoutTunnelAddress(ctx context.Context, c client.Client, installation *operator.Installation, m *operator.EncapsulationMigrationStatus) (string, error) {
+	var annotations []string
+	for _, p := range installation.Spec.CalicoNetwork.IPPools {
+		if !slices.Contains(m.Pools, p.CIDR) || p.NodeSelector != "all()" {
+			continue
+		}
+		var annotation string
+		switch {
+		case m.To == operator.EncapsulationIPIP:
+			annotation = ipipTunnelAddrAnnotation
+		case m.To == operator.EncapsulationVXLAN && strings.Contains(p.CIDR, ":"):
+			annotation = vxlanV6TunnelAddrAnnotation
+		case m.To == operator.EncapsulationVXLAN:
+			annotation = vxlanTunnelAddrAnnotation
+		default:
+			continue
+		}
+		if !slices.Contains(annotations, annotation) {
+			annotations = append(annotations, annotation)
+		}
+	}
+	if len(annotations) == 0 {
+		return "", nil
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		return "", err
+	}
+	sort.Slice(nodes.Items, func(i, j int) bool { return nodes.Items[i].Name < nodes.Items[j].Name })
+	for _, node := range nodes.Items {
+		for _, annotation := range annotations {
+			if node.Annotations[annotation] == "" {
+				return fmt.Sprintf("node %s to allocate a %s tunnel address", node.Name, m.To), nil
+			}
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,259 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ippool
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operator "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/status"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+var _ = Describe("IP pool encapsulation migration tests", func() {
+	var (
+		c            client.Client
+		ctx          context.Context
+		installation *operator.Installation
+		ourPools     map[string]crdv1.IPPool
+	)
+
+	clusterPool := func(name, cidr string, encap operator.EncapsulationType) crdv1.IPPool {
+		p := operator.IPPool{Name: name, CIDR: cidr, Encapsulation: encap, NATOutgoing: operator.NATOutgoingEnabled, NodeSelector: "all()"}
+		v1p, err := p.ToProjectCalicoV1()
+		Expect(err).NotTo(HaveOccurred())
+		v1p.Labels[managedByLabel] = managedByValue
+		return *v1p
+	}
+
+	felixConfiguration := func() *crdv1.FelixConfiguration {
+		fc := &crdv1.FelixConfiguration{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: "default"}, fc)).NotTo(HaveOccurred())
+		return fc
+	}
+
+	setCalicoNodeRolledOut := func(rolledOut bool) {
+		ds := &appsv1.DaemonSet{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: common.NodeDaemonSetName, Namespace: common.CalicoNamespace}, ds)).NotTo(HaveOccurred())
+		ds.Status.DesiredNumberScheduled = 2
+		ds.Status.NumberAvailable = 2
+		ds.Status.UpdatedNumberScheduled = 1
+		if rolledOut {
+			ds.Status.UpdatedNumberScheduled = 2
+		}
+		Expect(c.Status().Update(ctx, ds)).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(appsv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(corev1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).WithStatusSubresource(&appsv1.DaemonSet{}).Build()
+		ctx = context.Background()
+
+		installation = &operator.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: operator.InstallationSpec{
+				CalicoNetwork: &operator.CalicoNetworkSpec{
+					IPPools: []operator.IPPool{
+						{Name: "pool-a", CIDR: "192.168.0.0/16", Encapsulation: operator.EncapsulationVXLAN, NodeSelector: "all()"},
+						{Name: "pool-b", CIDR: "172.16.0.0/16", Encapsulation: operator.EncapsulationVXLANCrossSubnet, NodeSelector: "all()"},
+					},
+				},
+			},
+		}
+		ourPools = map[string]crdv1.IPPool{
+			"192.168.0.0/16": clusterPool("pool-a", "192.168.0.0/16", operator.EncapsulationIPIP),
+			"172.16.0.0/16":  clusterPool("pool-b", "172.16.0.0/16", operator.EncapsulationIPIPCrossSubnet),
+		}
+
+		Expect(c.Create(ctx, &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: common.NodeDaemonSetName, Namespace: common.CalicoNamespace}})).NotTo(HaveOccurred())
+		for _, name := range []string{"node-a", "node-b"} {
+			Expect(c.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})).NotTo(HaveOccurred())
+		}
+	})
+
+	It("should plan a migration for pools that move to a different tunnel", func() {
+		planned, err := planEncapsulationMigration(installation, ourPools)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(planned.From).To(Equal(operator.EncapsulationIPIP))
+		Expect(planned.To).To(Equal(operator.EncapsulationVXLAN))
+		Expect(planned.Pools).To(Equal([]string{"172.16.0.0/16", "192.168.0.0/16"}))
+		Expect(planned.Phase).To(Equal(operator.EncapsulationMigrationEnablingTunnels))
+
+		// Moving to the cross-subnet mode of the same tunnel is not a migration.
+		ourPools["192.168.0.0/16"] = clusterPool("pool-a", "192.168.0.0/16", operator.EncapsulationVXLANCrossSubnet)
+		ourPools["172.16.0.0/16"] = clusterPool("pool-b", "172.16.0.0/16", operator.EncapsulationVXLAN)
+		Expect(planEncapsulationMigration(installation, ourPools)).To(BeNil())
+	})
+
+	It("should reject pools that move in different directions", func() {
+		installation.Spec.CalicoNetwork.IPPools[1].Encapsulation = operator.EncapsulationIPIP
+		ourPools["172.16.0.0/16"] = clusterPool("pool-b", "172.16.0.0/16", operator.EncapsulationVXLAN)
+		_, err := planEncapsulationMigration(installation, ourPools)
+		Expect(err).To(MatchError(ContainSubstring("migrate the pools in one direction at a time")))
+	})
+
+	It("should reject a migration that can't be carried out", func() {
+		planned, err := planEncapsulationMigration(installation, ourPools)
+		Expect(err).NotTo(HaveOccurred())
+
+		installation.Status.Upgrade = &operator.UpgradeStatus{
+			ToVersion: "v3.28.0",
+			Stages:    []operator.UpgradeStageStatus{{Stage: operator.UpgradeStageNode, State: operator.UpgradeStageRollingOut}},
+		}
+		Expect(validateEncapsulationMigration(ctx, c, installation, planned)).To(MatchError(ContainSubstring("wait for the upgrade to complete")))
+		installation.Status.Upgrade = nil
+
+		Expect(c.Create(ctx, &crdv1.FelixConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec:       crdv1.FelixConfigurationSpec{VXLANEnabled: ptr.BoolToPtr(false)},
+		})).NotTo(HaveOccurred())
+		Expect(validateEncapsulationMigration(ctx, c, installation, planned)).To(MatchError(ContainSubstring("sets vxlanEnabled to false")))
+
+		fc := felixConfiguration()
+		fc.Spec.VXLANEnabled = nil
+		Expect(c.Update(ctx, fc)).NotTo(HaveOccurred())
+		Expect(validateEncapsulationMigration(ctx, c, installation, planned)).NotTo(HaveOccurred())
+
+		// A different migration can't start while one is in progress, but the one in progress can be cancelled.
+		installation.Status.EncapsulationMigration = &operator.EncapsulationMigrationStatus{
+			From:  operator.EncapsulationIPIP,
+			To:    operator.EncapsulationNone,
+			Pools: planned.Pools,
+			Phase: operator.EncapsulationMigrationEnablingTunnels,
+		}
+		Expect(validateEncapsulationMigration(ctx, c, installation, planned)).To(MatchError(ContainSubstring("or set it back to IPIP to cancel the migration")))
+	})
+
+	It("should enable both tunnels until the nodes have switched over", func() {
+		Expect(c.Create(ctx, &crdv1.FelixConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec:       crdv1.FelixConfigurationSpec{IPIPEnabled: ptr.BoolToPtr(true)},
+		})).NotTo(HaveOccurred())
+		setCalicoNodeRolledOut(false)
+
+		planned, err := planEncapsulationMigration(installation, ourPools)
+		Expect(err).NotTo(HaveOccurred())
+		m, err := advanceEncapsulationMigration(ctx, c, installation, planned)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.Phase).To(Equal(operator.EncapsulationMigrationEnablingTunnels))
+		Expect(m.Message).To(ContainSubstring("calico-node to roll out"))
+		Expect(holdsPool(m, "192.168.0.0/16")).To(BeTrue())
+
+		// Only the setting that the migration enabled is recorded, so that it's the only one that is unset later.
+		Expect(m.TransitionalFelixSettings).To(Equal([]string{"vxlanEnabled"}))
+		Expect(*felixConfiguration().Spec.VXLANEnabled).To(BeTrue())
+
+		installation.Status.EncapsulationMigration = m
+		setCalicoNodeRolledOut(true)
+		m, err = advanceEncapsulationMigration(ctx, c, installation, planned)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.Phase).To(Equal(operator.EncapsulationMigrationUpdatingPools))
+		Expect(holdsPool(m, "192.168.0.0/16")).To(BeFalse())
+
+		// Once the pools are updated, the migration waits for every node to have a VXLAN tunnel address.
+		installation.Status.EncapsulationMigration = m
+		m, err = advanceEncapsulationMigration(ctx, c, installation, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.Message).To(Equal("Waiting for node node-a to allocate a VXLAN tunnel address"))
+
+		for _, name := range []string{"node-a", "node-b"} {
+			node := &corev1.Node{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: name}, node)).NotTo(HaveOccurred())
+			node.Annotations = map[string]string{vxlanTunnelAddrAnnotation: "192.168.0.1"}
+			Expect(c.Update(ctx, node)).NotTo(HaveOccurred())
+		}
+		m, err = advanceEncapsulationMigration(ctx, c, installation, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m).To(BeNil())
+		Expect(felixConfiguration().Spec.VXLANEnabled).To(BeNil())
+		Expect(*felixConfiguration().Spec.IPIPEnabled).To(BeTrue())
+	})
+
+	It("should unset the transitional settings when the migration is cancelled", func() {
+		setCalicoNodeRolledOut(false)
+		planned, err := planEncapsulationMigration(installation, ourPools)
+		Expect(err).NotTo(HaveOccurred())
+		m, err := advanceEncapsulationMigration(ctx, c, installation, planned)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.TransitionalFelixSettings).To(ConsistOf("ipipEnabled", "vxlanEnabled"))
+
+		installation.Status.EncapsulationMigration = m
+		m, err = advanceEncapsulationMigration(ctx, c, installation, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m).To(BeNil())
+		Expect(felixConfiguration().Spec.IPIPEnabled).To(BeNil())
+		Expect(felixConfiguration().Spec.VXLANEnabled).To(BeNil())
+	})
+
+	It("should hold the pools while the reconciler enables both tunnels", func() {
+		mockStatus := &status.MockStatus{}
+		mockStatus.On("OnCRFound")
+		mockStatus.On("SetMetaData", mock.Anything)
+		mockStatus.On("IsAvailable").Return(true)
+		mockStatus.On("ReadyToMonitor")
+		mockStatus.On("ClearDegraded")
+		r := Reconciler{client: c, scheme: c.Scheme(), autoDetectedProvider: operator.ProviderNone, status: mockStatus}
+
+		bgp := operator.BGPEnabled
+		installation.Finalizers = []string{"tigera.io/operator-cleanup"}
+		installation.Spec.CNI = &operator.CNISpec{Type: operator.PluginCalico, IPAM: &operator.IPAMSpec{Type: operator.IPAMPluginCalico}}
+		installation.Spec.CalicoNetwork.BGP = &bgp
+		Expect(c.Create(ctx, installation)).NotTo(HaveOccurred())
+		for _, p := range ourPools {
+			Expect(c.Create(ctx, &p)).NotTo(HaveOccurred())
+		}
+		apiserver := &operator.APIServer{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+		Expect(c.Create(ctx, apiserver)).NotTo(HaveOccurred())
+		apiserver.Status.State = operator.TigeraStatusReady
+		Expect(c.Status().Update(ctx, apiserver)).NotTo(HaveOccurred())
+		setCalicoNodeRolledOut(false)
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).NotTo(BeZero())
+		mockStatus.AssertExpectations(GinkgoT())
+
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(installation), installation)).NotTo(HaveOccurred())
+		Expect(installation.Status.EncapsulationMigration).NotTo(BeNil())
+		Expect(installation.Status.EncapsulationMigration.Phase).To(Equal(operator.EncapsulationMigrationEnablingTunnels))
+
+		// The pools keep IPIP until calico-node has rolled out with both tunnels enabled.
+		pools := &crdv1.IPPoolList{}
+		Expect(c.List(ctx, pools)).NotTo(HaveOccurred())
+		for _, p := range pools.Items {
+			Expect(p.Spec.IPIPMode).NotTo(Equal(crdv1.IPIPModeNever))
+			Expect(p.Spec.VXLANMode).To(Equal(crdv1.VXLANModeNever))
+		}
+	})
+})
@@ -0,0 +1,182 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ippool
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operator "github.com/tigera/operator/api/v1"
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+)
+
+// maxIPPools is the most IP pools that the Installation API accepts in spec.calicoNetwork.ipPools.
+const maxIPPools = 25
+
+// expandPodNetwork adds an IP pool to the Installation for each pod network expansion that doesn't have one yet. The
+// pool copies the settings of its template pool, so it's expected to run after the other pools have been defaulted.
+// The rest of the configuration that depends on the pools, such as the IPAM configuration of the CNI plugin and node
+// address autodetection, is derived from the pools in the cluster by the core controller once the pool is created.
+func expandPodNetwork(ctx context.Context, c client.Client, instance *operator.Installation, currentPools *crdv1.IPPoolList) error {
+	if instance.Spec.CalicoNetwork == nil || len(instance.Spec.CalicoNetwork.PodNetworkExpansions) == 0 {
+		return nil
+	}
+	if instance.Spec.CNI == nil || instance.Spec.CNI.IPAM == nil || instance.Spec.CNI.IPAM.Type != operator.IPAMPluginCalico {
+		return fmt.Errorf("pod network expansions require Calico IPAM; with other IPAM plugins the pod CIDRs come from the nodes' podCIDR")
+	}
+
+	var platformCIDRs []string
+	platformCIDRsRead := false
+	for _, expansion := range instance.Spec.CalicoNetwork.PodNetworkExpansions {
+		_, cidr, err := net.ParseCIDR(expansion.CIDR)
+		if err != nil {
+			return fmt.Errorf("pod network expansion CIDR (%s) is invalid: %s", expansion.CIDR, err)
+		}
+		if cidr.String() != expansion.CIDR {
+			return fmt.Errorf("pod network expansion CIDR (%s) has host bits set; use %s", expansion.CIDR, cidr)
+		}
+		if poolWithCIDR(instance.Spec.CalicoNetwork.IPPools, expansion.CIDR) != nil {
+			// The pool for this expansion has already been added.
+			continue
+		}
+
+		template, err := expansionTemplate(instance.Spec.CalicoNetwork.IPPools, expansion)
+		if err != nil {
+			return err
+		}
+		if err := checkExpansionOverlap(instance, currentPools, expansion.CIDR); err != nil {
+			return err
+		}
+		if instance.Spec.KubernetesProvider.IsOpenShift() {
+			// OpenShift only routes pod traffic within its cluster network, so the CIDR needs to be added there first.
+			if !platformCIDRsRead {
+				if platformCIDRs, err = openShiftClusterNetwork(ctx, c); err != nil {
+					return err
+				}
+				platformCIDRsRead = true
+			}
+			within := false
+			for _, platformCIDR := range platformCIDRs {
+				within = within || cidrWithinCidr(platformCIDR, expansion.CIDR)
+			}
+			if !within {
+				return fmt.Errorf("pod network expansion CIDR (%s) is not within the OpenShift cluster network %v; add it to "+
+					"spec.clusterNetwork of networks.config.openshift.io cluster first", expansion.CIDR, platformCIDRs)
+			}
+		}
+		if len(instance.Spec.CalicoNetwork.IPPools) >= maxIPPools {
+			return fmt.Errorf("cannot add an IP pool for pod network expansion CIDR (%s): the Installation already has the "+
+				"maximum of %d IP pools", expansion.CIDR, maxIPPools)
+		}
+
+		pool := template.DeepCopy()
+		pool.CIDR = expansion.CIDR
+		if pool.Name, err = cidrToName(expansion.CIDR); err != nil {
+			return err
+		}
+		log.Info("Adding IP pool for pod network expansion", "cidr", pool.CIDR, "name", pool.Name, "template", template.Name)
+		instance.Spec.CalicoNetwork.IPPools = append(instance.Spec.CalicoNetwork.IPPools, *pool)
+	}
+	return nil
+}
+
+// expansionTemplate returns the pool whose settings the expansion copies.
+func expansionTemplate(pools []operator.IPPool, expansion operator.PodNetworkExpansion) (*operator.IPPool, error) {
+	if expansion.Template != "" {
+		for i := range pools {
+			if pools[i].Name == expansion.Template {
+				if isIPv6(pools[i].CIDR) != isIPv6(expansion.CIDR) {
+					return nil, fmt.Errorf("pod network expansion CIDR (%s) uses a different IP family than its template IP pool %s",
+						expansion.CIDR, expansion.Template)
+				}
+				return &pools[i], nil
+			}
+		}
+		return nil, fmt.Errorf("template IP pool %s of pod network expansion CIDR (%s) is not in ipPools", expansion.Template, expansion.CIDR)
+	}
+	for i := range pools {
+		if isIPv6(pools[i].CIDR) == isIPv6(expansion.CIDR) {
+			return &pools[i], nil
+		}
+	}
+	return nil, fmt.Errorf("there is no IP pool of the same IP family as pod network expansion CIDR (%s) to copy the settings "+
+		"of; add a pool to ipPools instead", expansion.CIDR)
+}
+
+// checkExpansionOverlap returns an error if the CIDR overlaps with an IP pool in the Installation or the cluster, or
+// with a service CIDR.
+func checkExpansionOverlap(instance *operator.Installation, currentPools *crdv1.IPPoolList, cidr string) error {
+	for _, pool := range instance.Spec.CalicoNetwork.IPPools {
+		if cidrsOverlap(cidr, pool.CIDR) {
+			return fmt.Errorf("pod network expansion CIDR (%s) overlaps with IP pool %s (%s)", cidr, pool.Name, pool.CIDR)
+		}
+	}
+	for _, pool := range currentPools.Items {
+		if cidrsOverlap(cidr, pool.Spec.CIDR) {
+			return fmt.Errorf("pod network expansion CIDR (%s) overlaps with IP pool %s (%s) in the cluster", cidr, pool.Name, pool.Spec.CIDR)
+		}
+	}
+	for _, serviceCIDR := range instance.Spec.ServiceCIDRs {
+		if cidrsOverlap(cidr, serviceCIDR) {
+			return fmt.Errorf("pod network expansion CIDR (%s) overlaps with service CIDR %s", cidr, serviceCIDR)
+		}
+	}
+	return nil
+}
+
+func openShiftClusterNetwork(ctx context.Context, c client.Client) ([]string, error) {
+	o := &configv1.Network{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "cluster"}, o); err != nil {
+		return nil, fmt.Errorf("Unable to read openshift network configuration: %s", err.Error())
+	}
+	var cidrs []string
+	for _, n := range o.Spec.ClusterNetwork {
+		cidrs = append(cidrs, n.CIDR)
+	}
+	return cidrs, nil
+}
+
+func poolWithCIDR(pools []operator.IPPool, cidr string) *operator.IPPool {
+	for i := range pools {
+		if pools[i].CIDR == cidr {
+			return &pools[i]
+		}
+	}
+	return nil
+}
+
+// cidrsOverlap returns true if the two CIDRs share any address. Either one contains the other or they are disjoint,
+// so it's enough to check whether either contains the network address of the other.
+func cidrsOverlap(a, b string) bool {
+	_, aNet, err := net.ParseCIDR(a)
+	if err != nil {
+		return false
+	}
+	_, bNet, err := net.ParseCIDR(b)
+	if err != nil {
+		return false
+	}
+	return aNet.Contains(bNet.IP) || bNet.Contains(aNet.IP)
+}
+
+func isIPv6(cidr string) bool {
+	addr, _, err := net.ParseCIDR(cidr)
+	return err == nil && addr.To4() == nil
+}
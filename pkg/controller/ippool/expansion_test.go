@@ -0,0 +1,125 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ippool
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	configv1 "github.com/openshift/api/config/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operator "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+)
+
+var _ = Describe("Pod network expansion tests", func() {
+	var (
+		c            client.Client
+		ctx          context.Context
+		instance     *operator.Installation
+		currentPools *crdv1.IPPoolList
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(configv1.AddToScheme(scheme)).NotTo(HaveOccurred())
+		c = fake.NewClientBuilder().WithScheme(scheme).Build()
+		ctx = context.Background()
+
+		blockSize := int32(26)
+		instance = &operator.Installation{
+			Spec: operator.InstallationSpec{
+				CNI: &operator.CNISpec{Type: operator.PluginCalico, IPAM: &operator.IPAMSpec{Type: operator.IPAMPluginCalico}},
+				CalicoNetwork: &operator.CalicoNetworkSpec{
+					IPPools: []operator.IPPool{{
+						Name:          "default-ipv4-ippool",
+						CIDR:          "192.168.0.0/16",
+						Encapsulation: operator.EncapsulationVXLAN,
+						NATOutgoing:   operator.NATOutgoingEnabled,
+						NodeSelector:  "all()",
+						BlockSize:     &blockSize,
+					}},
+				},
+				ServiceCIDRs: []string{"10.96.0.0/12"},
+			},
+		}
+		currentPools = &crdv1.IPPoolList{Items: []crdv1.IPPool{
+			{ObjectMeta: metav1.ObjectMeta{Name: "default-ipv4-ippool"}, Spec: crdv1.IPPoolSpec{CIDR: "192.168.0.0/16"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged"}, Spec: crdv1.IPPoolSpec{CIDR: "172.20.0.0/16"}},
+		}}
+	})
+
+	It("should add a pool with the settings of the template pool", func() {
+		instance.Spec.CalicoNetwork.PodNetworkExpansions = []operator.PodNetworkExpansion{{CIDR: "10.244.0.0/16"}}
+		Expect(expandPodNetwork(ctx, c, instance, currentPools)).NotTo(HaveOccurred())
+
+		pools := instance.Spec.CalicoNetwork.IPPools
+		Expect(pools).To(HaveLen(2))
+		Expect(pools[1].Name).To(Equal("10.244.0.0-16"))
+		Expect(pools[1].CIDR).To(Equal("10.244.0.0/16"))
+		Expect(pools[1].Encapsulation).To(Equal(operator.EncapsulationVXLAN))
+		Expect(pools[1].NATOutgoing).To(Equal(operator.NATOutgoingEnabled))
+		Expect(*pools[1].BlockSize).To(Equal(int32(26)))
+		Expect(ValidatePools(instance)).NotTo(HaveOccurred())
+
+		// The pool is only added once.
+		Expect(expandPodNetwork(ctx, c, instance, currentPools)).NotTo(HaveOccurred())
+		Expect(instance.Spec.CalicoNetwork.IPPools).To(HaveLen(2))
+	})
+
+	table.DescribeTable("should reject expansions that can't be added",
+		func(expansion operator.PodNetworkExpansion, message string) {
+			instance.Spec.CalicoNetwork.PodNetworkExpansions = []operator.PodNetworkExpansion{expansion}
+			Expect(expandPodNetwork(ctx, c, instance, currentPools)).To(MatchError(ContainSubstring(message)))
+			Expect(instance.Spec.CalicoNetwork.IPPools).To(HaveLen(1))
+		},
+		table.Entry("invalid CIDR", operator.PodNetworkExpansion{CIDR: "10.244.0.0"}, "is invalid"),
+		table.Entry("host bits set", operator.PodNetworkExpansion{CIDR: "10.244.0.1/16"}, "use 10.244.0.0/16"),
+		table.Entry("overlaps with a pool in the Installation", operator.PodNetworkExpansion{CIDR: "192.168.128.0/17"}, "overlaps with IP pool default-ipv4-ippool"),
+		table.Entry("overlaps with a pool in the cluster", operator.PodNetworkExpansion{CIDR: "172.16.0.0/12"}, "overlaps with IP pool unmanaged (172.20.0.0/16) in the cluster"),
+		table.Entry("overlaps with the services", operator.PodNetworkExpansion{CIDR: "10.100.0.0/16"}, "overlaps with service CIDR 10.96.0.0/12"),
+		table.Entry("no pool of the IP family", operator.PodNetworkExpansion{CIDR: "fd00::/64"}, "no IP pool of the same IP family"),
+		table.Entry("unknown template", operator.PodNetworkExpansion{CIDR: "10.244.0.0/16", Template: "other"}, "template IP pool other"),
+	)
+
+	It("should require Calico IPAM", func() {
+		instance.Spec.CNI.IPAM.Type = operator.IPAMPluginHostLocal
+		instance.Spec.CalicoNetwork.PodNetworkExpansions = []operator.PodNetworkExpansion{{CIDR: "10.244.0.0/16"}}
+		Expect(expandPodNetwork(ctx, c, instance, currentPools)).To(MatchError(ContainSubstring("require Calico IPAM")))
+	})
+
+	It("should require the CIDR to be in the OpenShift cluster network", func() {
+		Expect(c.Create(ctx, &configv1.Network{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+			Spec:       configv1.NetworkSpec{ClusterNetwork: []configv1.ClusterNetworkEntry{{CIDR: "10.128.0.0/14"}}},
+		})).NotTo(HaveOccurred())
+		instance.Spec.KubernetesProvider = operator.ProviderOpenShift
+		instance.Spec.CalicoNetwork.PodNetworkExpansions = []operator.PodNetworkExpansion{{CIDR: "10.244.0.0/16"}}
+		Expect(expandPodNetwork(ctx, c, instance, currentPools)).To(MatchError(ContainSubstring("not within the OpenShift cluster network")))
+
+		instance.Spec.CalicoNetwork.PodNetworkExpansions = []operator.PodNetworkExpansion{{CIDR: "10.130.0.0/16"}}
+		Expect(expandPodNetwork(ctx, c, instance, currentPools)).NotTo(HaveOccurred())
+		Expect(instance.Spec.CalicoNetwork.IPPools).To(HaveLen(2))
+	})
+})
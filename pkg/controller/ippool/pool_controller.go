@@ -31,6 +31,7 @@ import (
 	"github.com/tigera/operator/pkg/controller/status"
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -142,7 +143,7 @@ func hasOwnerLabel(pool *crdv1.IPPool) bool {
 // - Query existing IP pools owned by this controller
 // - Reconcile the differences
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.V(1).Info("Reconciling IP pools")
 
 	// Get the Installation object - this is the source of truth for IP pools managed by
@@ -205,6 +206,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		r.status.SetDegraded(operator.ResourceReadError, "error filling IP pool defaults", err, reqLogger)
 		return reconcile.Result{}, err
 	}
+	if err = expandPodNetwork(ctx, r.client, installation, currentPools); err != nil {
+		r.status.SetDegraded(operator.InvalidConfigurationError, "error expanding the pod network", err, reqLogger)
+		return reconcile.Result{}, err
+	}
 	if err = ValidatePools(installation); err != nil {
 		r.status.SetDegraded(operator.InvalidConfigurationError, "error validating IP pool configuration", err, reqLogger)
 		return reconcile.Result{}, err
@@ -271,6 +276,31 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	}
 	reqLogger.V(1).Info("Found IP pools owned by us", "count", len(ourPools))
 
+	// Pools that move to a different tunnel are migrated in steps, which need the v3 API to update the pools.
+	planned, err := planEncapsulationMigration(installation, ourPools)
+	if err == nil {
+		err = validateEncapsulationMigration(ctx, r.client, installation, planned)
+	}
+	if err != nil {
+		r.status.SetDegraded(operator.InvalidConfigurationError, "Invalid IP pool encapsulation change", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	migration := installation.Status.EncapsulationMigration
+	if apiAvailable {
+		migration, err = advanceEncapsulationMigration(ctx, r.client, installation, planned)
+		if err != nil {
+			r.status.SetDegraded(operator.ResourceUpdateError, "Error migrating IP pool encapsulation", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		if !reflect.DeepEqual(migration, installation.Status.EncapsulationMigration) {
+			installation.Status.EncapsulationMigration = migration
+			if err := r.client.Status().Update(ctx, installation); err != nil {
+				r.status.SetDegraded(operator.ResourceUpdateError, "Error updating the encapsulation migration status", err, reqLogger)
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
 	// For each pool that is desired, but doesn't exist, create it.
 	// We will install pools at start-of-day using the CRD API, but otherwise
 	// we require the v3 API to be running. This is so that we properly leverage the v3 API's validation.
@@ -292,6 +322,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 			continue
 		}
 
+		// The pool keeps its current encapsulation until both tunnels are enabled on every node.
+		if holdsPool(migration, p.CIDR) {
+			continue
+		}
+
 		// Consider sending an update if:
 		//
 		// - The API server is up and running.
@@ -365,7 +400,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	// will remain even though all other Calico resources will be deleted. This is intentional - deleting IP pools requires the Calico API server to be
 	// running, and we don't want to block the deletion of the Installation on the API server being available, as it introduces too many ways for
 	// things to go wrong upon deleting the Installation API. Users can manually delete the IP pools if they are no longer needed.
-	handler := utils.NewComponentHandler(log, r.client, r.scheme, nil)
+	handler := utils.NewComponentHandler(logging.ForReconcile(ctx, log), r.client, r.scheme, nil)
 
 	passThru := render.NewPassthroughWithLog(log, toCreateOrUpdate...)
 	if err := handler.CreateOrUpdateOrDelete(ctx, passThru, nil); err != nil {
@@ -390,6 +425,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
 	}
 
+	if migration != nil {
+		// The nodes aren't watched, so check on the migration again in a little while.
+		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
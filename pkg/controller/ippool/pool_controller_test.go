@@ -773,6 +773,39 @@ var _ = Describe("fillDefaults()", func() {
 			Expect(ValidatePools(instance)).NotTo(HaveOccurred())
 		})
 	})
+	// Tests for Calico Networking on AKS (BYOCNI) should go in this context.
+	Context("with Calico Networking on AKS", func() {
+		BeforeEach(func() {
+			instance = &operator.Installation{
+				Spec: operator.InstallationSpec{
+					KubernetesProvider: operator.ProviderAKS,
+					CNI: &operator.CNISpec{
+						Type: operator.PluginCalico,
+						IPAM: &operator.IPAMSpec{
+							Type: operator.IPAMPluginCalico,
+						},
+					},
+				},
+			}
+		})
+
+		It("should default to VXLAN", func() {
+			err := fillDefaults(ctx, cli, instance, currentPools)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Spec.CalicoNetwork.IPPools[0].Encapsulation).To(Equal(operator.EncapsulationVXLAN))
+			Expect(instance.Spec.CalicoNetwork.IPPools[0].CIDR).To(Equal("192.168.0.0/16"))
+			Expect(ValidatePools(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should default the encapsulation of specified pools to VXLAN", func() {
+			instance.Spec.CalicoNetwork = &operator.CalicoNetworkSpec{
+				IPPools: []operator.IPPool{{Name: "pool", CIDR: "10.244.0.0/16"}},
+			}
+			err := fillDefaults(ctx, cli, instance, currentPools)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Spec.CalicoNetwork.IPPools[0].Encapsulation).To(Equal(operator.EncapsulationVXLAN))
+		})
+	})
 })
 
 var _ = Describe("validate()", func() {
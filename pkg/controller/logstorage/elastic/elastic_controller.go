@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"time"
 
 	cmnv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/common/v1"
 	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
@@ -39,18 +40,19 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
 	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
 	logstoragecommon "github.com/tigera/operator/pkg/controller/logstorage/common"
 	"github.com/tigera/operator/pkg/controller/logstorage/initializer"
+	"github.com/tigera/operator/pkg/controller/maintenance"
 	"github.com/tigera/operator/pkg/controller/options"
 	"github.com/tigera/operator/pkg/controller/status"
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/controller/utils/imageset"
 	"github.com/tigera/operator/pkg/ctrlruntime"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render"
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
@@ -73,6 +75,7 @@ const (
 // responsible for managing the Elasticsearch service used by Calico.
 type ElasticSubController struct {
 	client         client.Client
+	reader         client.Reader
 	scheme         *runtime.Scheme
 	status         status.StatusManager
 	provider       operatorv1.Provider
@@ -95,6 +98,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	// Create the reconciler
 	r := &ElasticSubController{
 		client:         mgr.GetClient(),
+		reader:         utils.NewUncachedReader(mgr.GetAPIReader()),
 		scheme:         mgr.GetScheme(),
 		esCliCreator:   utils.NewElasticClient,
 		tierWatchReady: &utils.ReadyFlag{},
@@ -117,7 +121,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	}
 
 	// Configure watches for operator.tigera.io APIs this controller cares about.
-	if err = c.WatchObject(&operatorv1.LogStorage{}, &handler.EnqueueRequestForObject{}); err != nil {
+	if err = c.WatchObject(&operatorv1.LogStorage{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("log-storage-elastic-controller failed to watch LogStorage resource: %w", err)
 	}
 	if err = utils.AddInstallationWatch(c); err != nil {
@@ -126,22 +130,22 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	if err = imageset.AddImageSetWatch(c); err != nil {
 		return fmt.Errorf("log-storage-elastic-controller failed to watch ImageSet: %w", err)
 	}
-	if err = c.WatchObject(&operatorv1.ManagementCluster{}, &handler.EnqueueRequestForObject{}); err != nil {
+	if err = c.WatchObject(&operatorv1.ManagementCluster{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("log-storage-elastic-controller failed to watch ManagementCluster resource: %w", err)
 	}
-	if err = c.WatchObject(&operatorv1.ManagementClusterConnection{}, &handler.EnqueueRequestForObject{}); err != nil {
+	if err = c.WatchObject(&operatorv1.ManagementClusterConnection{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("log-storage-elastic-controller failed to watch ManagementClusterConnection resource: %w", err)
 	}
 	if err = utils.AddTigeraStatusWatch(c, initializer.TigeraStatusLogStorageElastic); err != nil {
 		return fmt.Errorf("logstorage-controller failed to watch logstorage Tigerastatus: %w", err)
 	}
-	if err = c.WatchObject(&operatorv1.Authentication{}, &handler.EnqueueRequestForObject{}); err != nil {
+	if err = c.WatchObject(&operatorv1.Authentication{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("log-storage-elastic-controller failed to watch Authentication resource: %w", err)
 	}
 
 	// Start goroutines to establish watches against projectcalico.org/v3 resources.
-	go utils.WaitToAddTierWatch(networkpolicy.TigeraComponentTierName, c, k8sClient, log, r.tierWatchReady)
-	go utils.WaitToAddNetworkPolicyWatches(c, k8sClient, log, []types.NamespacedName{
+	go utils.WaitToAddTierWatch(opts.ShutdownContext, networkpolicy.TigeraComponentTierName, c, k8sClient, log, r.tierWatchReady)
+	go utils.WaitToAddNetworkPolicyWatches(opts.ShutdownContext, c, k8sClient, log, []types.NamespacedName{
 		{Name: render.ElasticsearchPolicyName, Namespace: render.ElasticsearchNamespace},
 		{Name: kibana.PolicyName, Namespace: kibana.Namespace},
 		{Name: eck.OperatorPolicyName, Namespace: eck.OperatorNamespace},
@@ -252,7 +256,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 }
 
 func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling LogStorage - Elasticsearch")
 
 	// Get LogStorage resource.
@@ -300,21 +304,11 @@ func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, nil
 	}
 
-	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
-	if !r.tierWatchReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", nil, reqLogger)
-		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
-	}
-
-	// Ensure the allow-tigera tier exists, before rendering any network policies within it.
-	if err := r.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
-		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", err, reqLogger)
-			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
-		} else {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying allow-tigera tier", err, reqLogger)
-			return reconcile.Result{}, err
-		}
+	if _, result, err := (utils.Prerequisites{
+		UpgradeStage: operatorv1.UpgradeStageComponents,
+		TierWatch:    r.tierWatchReady,
+	}).Check(ctx, r.client, r.status, reqLogger); result != nil {
+		return *result, err
 	}
 
 	managementCluster, err := utils.GetManagementCluster(ctx, r.client)
@@ -347,7 +341,7 @@ func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.
 	}
 
 	// Get the keypairs we need for rendering components. These are created separately by the ES secrets controller.
-	cm, err := certificatemanager.Create(r.client, install, r.clusterDomain, common.OperatorNamespace())
+	cm, err := certificatemanager.Create(r.client, install, r.clusterDomain, common.OperatorNamespace(), certificatemanager.WithLogger(reqLogger))
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
 		return reconcile.Result{}, err
@@ -568,8 +562,16 @@ func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.
 		}
 	}
 
+	// Changes that cause a rolling restart of Elasticsearch wait for a maintenance window.
+	maintenanceOpen, nextWindow, err := maintenance.Open(ls.Spec.MaintenanceWindows, time.Now())
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Error parsing maintenance windows", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	deferrer := maintenance.NewDeferrer(ctx, r.client, maintenanceOpen)
+
 	for _, component := range components {
-		if err := hdler.CreateOrUpdateOrDelete(ctx, component, r.status); err != nil {
+		if err := hdler.CreateOrUpdateOrDelete(ctx, deferrer.Wrap(component), r.status); err != nil {
 			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error creating / updating resource", err, reqLogger)
 			return reconcile.Result{}, err
 		}
@@ -608,6 +610,10 @@ func (r *ElasticSubController) Reconcile(ctx context.Context, request reconcile.
 
 	r.status.ReadyToMonitor()
 	r.status.ClearDegraded()
+	if deferred := deferrer.Deferred(); len(deferred) > 0 && nextWindow > 0 {
+		reqLogger.Info("Deferring disruptive changes until the next maintenance window", "workloads", deferred, "opensIn", nextWindow)
+		return reconcile.Result{RequeueAfter: nextWindow}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
@@ -680,7 +686,7 @@ func (r *ElasticSubController) checkOIDCUsersEsResource(ctx context.Context) err
 
 func (r *ElasticSubController) applyILMPolicies(ls *operatorv1.LogStorage, reqLogger logr.Logger, ctx context.Context) error {
 	// ES should be in ready phase when execution reaches here, apply ILM polices
-	esClient, err := r.esCliCreator(r.client, ctx, relasticsearch.ECKElasticEndpoint(), false)
+	esClient, err := r.esCliCreator(r.reader, ctx, relasticsearch.ECKElasticEndpoint(), false)
 	if err != nil {
 		return err
 	}
@@ -95,6 +95,7 @@ func NewReconcilerWithShims(
 
 	r := &ElasticSubController{
 		client:         cli,
+		reader:         cli,
 		scheme:         scheme,
 		esCliCreator:   esCliCreator,
 		tierWatchReady: tierWatchReady,
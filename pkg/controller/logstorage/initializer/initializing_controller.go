@@ -32,10 +32,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/maintenance"
 	"github.com/tigera/operator/pkg/controller/options"
 	"github.com/tigera/operator/pkg/controller/status"
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render"
 	"github.com/tigera/operator/pkg/render/logstorage/kibana"
 )
@@ -78,10 +80,10 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	}
 
 	// Configure watches for operator.tigera.io APIs this controller cares about.
-	if err = c.WatchObject(&operatorv1.LogStorage{}, &handler.EnqueueRequestForObject{}); err != nil {
+	if err = c.WatchObject(&operatorv1.LogStorage{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("log-storage-initializing-controller failed to watch LogStorage resource: %w", err)
 	}
-	if err = c.WatchObject(&operatorv1.Installation{}, &handler.EnqueueRequestForObject{}); err != nil {
+	if err = c.WatchObject(&operatorv1.Installation{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("log-storage-initializing-controller failed to watch Installation resource: %w", err)
 	}
 
@@ -183,7 +185,7 @@ func validateComponentResources(spec *operatorv1.LogStorageSpec) error {
 }
 
 func (r *LogStorageInitializer) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling LogStorage")
 
 	ls := &operatorv1.LogStorage{}
@@ -240,6 +242,11 @@ func (r *LogStorageInitializer) Reconcile(ctx context.Context, request reconcile
 		r.status.SetDegraded(operatorv1.ResourceValidationError, "An error occurred while validating LogStorage", err, reqLogger)
 		return reconcile.Result{}, err
 	}
+	if err = maintenance.Validate(ls.Spec.MaintenanceWindows); err != nil {
+		r.setConditionDegraded(ctx, ls, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "An error occurred while validating LogStorage spec.maintenanceWindows", err, reqLogger)
+		return reconcile.Result{}, err
+	}
 
 	// Before we can create secrets, we need to ensure the tigera-elasticsearch namespace exists.
 	hdler := utils.NewComponentHandler(reqLogger, r.client, r.scheme, ls)
@@ -247,6 +247,41 @@ var _ = Describe("LogStorage ES kube-controllers controller", func() {
 		Expect(test.GetResource(cli, &dep)).To(BeNil())
 	})
 
+	It("should wait for an upgrade to reach the components before rolling them out", func() {
+		install.Status.Upgrade = &operatorv1.UpgradeStatus{
+			FromVersion: "v1.0.0",
+			ToVersion:   "v1.1.0",
+			Stages: []operatorv1.UpgradeStageStatus{
+				{Stage: operatorv1.UpgradeStageNode, State: operatorv1.UpgradeStageRollingOut},
+				{Stage: operatorv1.UpgradeStageComponents, State: operatorv1.UpgradeStagePending},
+			},
+		}
+		Expect(cli.Status().Update(ctx, install)).ShouldNot(HaveOccurred())
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result).Should(Equal(reconcile.Result{RequeueAfter: utils.StandardRetry}))
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceNotReady, "Waiting for the upgrade to reach the Components stage", mock.Anything, mock.Anything)
+
+		dep := appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      esgateway.DeploymentName,
+				Namespace: render.ElasticsearchNamespace,
+			},
+		}
+		Expect(test.GetResource(cli, &dep)).NotTo(BeNil())
+
+		// Once the pre hooks of the components have run, they are rolled out.
+		install.Status.Upgrade.Stages[1].State = operatorv1.UpgradeStageRollingOut
+		Expect(cli.Status().Update(ctx, install)).ShouldNot(HaveOccurred())
+
+		result, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result).Should(Equal(successResult))
+		Expect(test.GetResource(cli, &dep)).To(BeNil())
+	})
+
 	It("should use images from ImageSet", func() {
 		Expect(cli.Create(ctx, &operatorv1.ImageSet{
 			ObjectMeta: metav1.ObjectMeta{Name: "enterprise-" + components.EnterpriseRelease},
@@ -41,6 +41,7 @@ func (r *ESKubeControllersController) createESGateway(
 	hdler utils.ComponentHandler,
 	reqLogger logr.Logger,
 	trustedBundle certificatemanagement.TrustedBundleRO,
+	logStorage *operatorv1.LogStorage,
 ) error {
 	// Get the ES admin user secret. For internal ES, this is provisioned by the ECK operator as part of installing Elasticsearch,
 	// and so may not be immediately available.
@@ -72,7 +73,7 @@ func (r *ESKubeControllersController) createESGateway(
 	}
 
 	// Collect the certificates we need to provision ESGW. These will have been provisioned already by the ES secrets controller.
-	cm, err := certificatemanager.Create(r.client, install, r.clusterDomain, helper.TruthNamespace())
+	cm, err := certificatemanager.Create(r.client, install, r.clusterDomain, helper.TruthNamespace(), certificatemanager.WithLogger(reqLogger))
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
 		return err
@@ -108,6 +109,7 @@ func (r *ESKubeControllersController) createESGateway(
 		ESGatewayKeyPair:           gatewayKeyPair,
 		Namespace:                  helper.InstallNamespace(),
 		TruthNamespace:             helper.TruthNamespace(),
+		LogStorage:                 logStorage,
 	}
 
 	esGatewayComponent := esgateway.EsGateway(cfg)
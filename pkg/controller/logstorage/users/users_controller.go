@@ -25,6 +25,7 @@ import (
 	"github.com/go-logr/logr"
 	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render/logstorage/dashboards"
 	corev1 "k8s.io/api/core/v1"
 
@@ -54,6 +55,7 @@ const (
 
 type UserController struct {
 	client          client.Client
+	reader          client.Reader
 	scheme          *runtime.Scheme
 	status          status.StatusManager
 	esClientFn      utils.ElasticsearchClientCreator
@@ -63,6 +65,7 @@ type UserController struct {
 
 type UsersCleanupController struct {
 	client          client.Client
+	reader          client.Reader
 	scheme          *runtime.Scheme
 	esClientFn      utils.ElasticsearchClientCreator
 	elasticExternal bool
@@ -81,6 +84,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	// Create the reconciler
 	r := &UserController{
 		client:          mgr.GetClient(),
+		reader:          utils.NewUncachedReader(mgr.GetAPIReader()),
 		scheme:          mgr.GetScheme(),
 		multiTenant:     opts.MultiTenant,
 		status:          status.New(mgr.GetClient(), initializer.TigeraStatusLogStorageUsers, opts.KubernetesVersion),
@@ -90,7 +94,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	r.status.Run(opts.ShutdownContext)
 
 	// Create a controller using the reconciler and register it with the manager to receive reconcile calls.
-	c, err := ctrlruntime.NewController("log-storage-user-controller", mgr, controller.Options{Reconciler: r})
+	c, err := ctrlruntime.NewController("log-storage-user-controller", mgr, utils.ControllerOptions("log-storage-user-controller", r, opts))
 	if err != nil {
 		return err
 	}
@@ -103,20 +107,20 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	}
 
 	// Configure watches for operator.tigera.io APIs this controller cares about.
-	if err = c.WatchObject(&operatorv1.LogStorage{}, eventHandler); err != nil {
+	if err = c.WatchObject(&operatorv1.LogStorage{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("log-storage-user-controller failed to watch LogStorage resource: %w", err)
 	}
-	if err = c.WatchObject(&operatorv1.ManagementCluster{}, eventHandler); err != nil {
+	if err = c.WatchObject(&operatorv1.ManagementCluster{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("log-storage-user-controller failed to watch ManagementCluster resource: %w", err)
 	}
-	if err = c.WatchObject(&operatorv1.ManagementClusterConnection{}, eventHandler); err != nil {
+	if err = c.WatchObject(&operatorv1.ManagementClusterConnection{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("log-storage-user-controller failed to watch ManagementClusterConnection resource: %w", err)
 	}
 	if err = utils.AddTigeraStatusWatch(c, initializer.TigeraStatusLogStorageUsers); err != nil {
 		return fmt.Errorf("logstorage-controller failed to watch logstorage Tigerastatus: %w", err)
 	}
 	if opts.MultiTenant {
-		if err = c.WatchObject(&operatorv1.Tenant{}, &handler.EnqueueRequestForObject{}); err != nil {
+		if err = c.WatchObject(&operatorv1.Tenant{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
 			return fmt.Errorf("log-storage-user-controller failed to watch Tenant resource: %w", err)
 		}
 	}
@@ -136,6 +140,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	// Now that the users controller is set up, we can also set up the controller that cleans up stale users
 	usersCleanupReconciler := &UsersCleanupController{
 		client:          mgr.GetClient(),
+		reader:          utils.NewUncachedReader(mgr.GetAPIReader()),
 		scheme:          mgr.GetScheme(),
 		esClientFn:      utils.NewElasticClient,
 		elasticExternal: opts.ElasticExternal,
@@ -147,7 +152,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		return err
 	}
 
-	if err = usersCleanupController.WatchObject(&operatorv1.Tenant{}, &handler.EnqueueRequestForObject{}); err != nil {
+	if err = usersCleanupController.WatchObject(&operatorv1.Tenant{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("log-storage-cleanup-controller failed to watch Tenant resource: %w", err)
 	}
 
@@ -161,7 +166,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 
 func (r *UserController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	helper := utils.NewNamespaceHelper(r.multiTenant, render.ElasticsearchNamespace, request.Namespace)
-	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name, "installNS", helper.InstallNamespace(), "truthNS", helper.TruthNamespace())
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name, "installNS", helper.InstallNamespace(), "truthNS", helper.TruthNamespace())
 	reqLogger.Info("Reconciling LogStorage - Users")
 
 	// We skip requests without a namespace specified in multi-tenant setups.
@@ -326,7 +331,7 @@ func (r *UserController) Reconcile(ctx context.Context, request reconcile.Reques
 }
 
 func (r *UserController) createUserLogin(ctx context.Context, elasticEndpoint string, secret *corev1.Secret, user *utils.User, reqLogger logr.Logger) error {
-	esClient, err := r.esClientFn(r.client, ctx, elasticEndpoint, r.elasticExternal)
+	esClient, err := r.esClientFn(r.reader, ctx, elasticEndpoint, r.elasticExternal)
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceCreateError, "Failed to connect to Elasticsearch - failed to create the Elasticsearch client", err, reqLogger)
 		return err
@@ -353,7 +358,7 @@ func (r *UserController) createUserLogin(ctx context.Context, elasticEndpoint st
 
 func (r *UsersCleanupController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	helper := utils.NewNamespaceHelper(true, render.ElasticsearchNamespace, request.Namespace)
-	reqLogger := logf.Log.WithName("controller_logstorage_users_cleanup").WithValues("Request.Namespace",
+	reqLogger := logging.ForReconcile(ctx, logf.Log.WithName("controller_logstorage_users_cleanup")).WithValues("Request.Namespace",
 		request.Namespace, "Request.Name", request.Name, "installNS", helper.InstallNamespace(), "truthNS", helper.TruthNamespace())
 	reqLogger.Info("Reconciling LogStorage - Cleanup")
 
@@ -406,7 +411,7 @@ func (r *UsersCleanupController) cleanupStaleUsers(ctx context.Context, logger l
 		}
 
 		// This tenant is terminating - clean up its Linseed user, if it exists.
-		esClient, err := r.esClientFn(r.client, ctx, t.Spec.Elastic.URL, r.elasticExternal)
+		esClient, err := r.esClientFn(r.reader, ctx, t.Spec.Elastic.URL, r.elasticExternal)
 		if err != nil {
 			return fmt.Errorf("failed to connect to Elasticsearch - failed to create the Elasticsearch client")
 		}
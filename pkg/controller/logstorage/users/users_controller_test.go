@@ -50,6 +50,7 @@ var _ = Describe("LogStorage cleanup controller", func() {
 		t := &testing.T{}
 		ctrl := UsersCleanupController{
 			client:     cli,
+			reader:     cli,
 			esClientFn: tigeraelastic.MockESCLICreator,
 		}
 		testESClient := tigeraelastic.MockESClient{}
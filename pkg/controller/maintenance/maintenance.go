@@ -0,0 +1,194 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package maintenance defers disruptive changes to the workloads that the operator manages until a maintenance window
+// is open. A change is disruptive if it restarts pods that carry traffic or data: a change to the pod template of a
+// DaemonSet, or a change to the spec of an Elasticsearch cluster, which causes a rolling restart. Outside of a window,
+// the live version of these parts of the objects is kept, and everything else is applied as usual.
+package maintenance
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/render"
+)
+
+const (
+	// HashAnnotation is set on the objects with disruptive changes, with a hash of the disruptive part of the object
+	// as the operator last applied it. It tells whether the live object has a deferred change.
+	HashAnnotation = "operator.tigera.io/maintenance-hash"
+
+	maxDuration = 7 * 24 * time.Hour
+
+	// lookahead is how far ahead to search for the next window, which covers monthly schedules.
+	lookahead = 32 * 24 * time.Hour
+)
+
+// Validate checks that the windows have valid schedules and durations.
+func Validate(windows []operatorv1.MaintenanceWindow) error {
+	for _, w := range windows {
+		if _, err := parseSchedule(w.Schedule); err != nil {
+			return err
+		}
+		if w.Duration.Duration < time.Minute || w.Duration.Duration > maxDuration {
+			return fmt.Errorf("maintenance window %q has duration %s, which must be between 1m and %s", w.Schedule, w.Duration.Duration, maxDuration)
+		}
+	}
+	return nil
+}
+
+// Open returns true if disruptive changes may be rolled out at the given time, which is the case if no windows are
+// configured or one of them is open. Otherwise, it also returns how long it is until the next window opens, or zero if
+// none opens within the next month.
+func Open(windows []operatorv1.MaintenanceWindow, now time.Time) (bool, time.Duration, error) {
+	if len(windows) == 0 {
+		return true, 0, nil
+	}
+	now = now.UTC()
+	minute := now.Truncate(time.Minute)
+
+	var schedules []*schedule
+	for _, w := range windows {
+		s, err := parseSchedule(w.Schedule)
+		if err != nil {
+			return false, 0, err
+		}
+		schedules = append(schedules, s)
+
+		// The window is open if it opened at a minute within its duration before now.
+		for t := minute; now.Sub(t) < w.Duration.Duration; t = t.Add(-time.Minute) {
+			if s.matches(t) {
+				return true, 0, nil
+			}
+		}
+	}
+
+	for t := minute.Add(time.Minute); t.Sub(now) <= lookahead; t = t.Add(time.Minute) {
+		for _, s := range schedules {
+			if s.matches(t) {
+				return false, t.Sub(now), nil
+			}
+		}
+	}
+	return false, 0, nil
+}
+
+// Deferrer wraps the components of a single reconcile, so that their disruptive changes are deferred when no
+// maintenance window is open.
+type Deferrer struct {
+	ctx      context.Context
+	client   client.Client
+	open     bool
+	deferred []string
+}
+
+// NewDeferrer returns a Deferrer that defers disruptive changes unless open is true.
+func NewDeferrer(ctx context.Context, c client.Client, open bool) *Deferrer {
+	return &Deferrer{ctx: ctx, client: c, open: open}
+}
+
+// Wrap returns a component that renders the objects of the given one, keeping the live version of their disruptive
+// parts if they changed and no window is open.
+func (d *Deferrer) Wrap(component render.Component) render.Component {
+	return &deferredComponent{Component: component, deferrer: d}
+}
+
+// Deferred returns the names of the objects whose disruptive changes were deferred.
+func (d *Deferrer) Deferred() []string {
+	return d.deferred
+}
+
+// deferChanges replaces the disruptive part of the rendered object with the live one if it changed and no window is open.
+func (d *Deferrer) deferChanges(obj client.Object) {
+	var live client.Object
+	var disruptive func(client.Object) any
+	var keep func(rendered, live client.Object)
+	switch obj.(type) {
+	case *appsv1.DaemonSet:
+		live = &appsv1.DaemonSet{}
+		disruptive = func(o client.Object) any { return o.(*appsv1.DaemonSet).Spec.Template }
+		keep = func(r, l client.Object) { r.(*appsv1.DaemonSet).Spec.Template = l.(*appsv1.DaemonSet).Spec.Template }
+	case *esv1.Elasticsearch:
+		live = &esv1.Elasticsearch{}
+		disruptive = func(o client.Object) any { return o.(*esv1.Elasticsearch).Spec }
+		keep = func(r, l client.Object) { r.(*esv1.Elasticsearch).Spec = l.(*esv1.Elasticsearch).Spec }
+	default:
+		return
+	}
+
+	hash := hashOf(disruptive(obj))
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[HashAnnotation] = hash
+	obj.SetAnnotations(annotations)
+	if d.open {
+		return
+	}
+
+	// If the object doesn't exist there is nothing to disrupt yet. If it can't be read, the component handler will
+	// fail to read it too and report the error.
+	if err := d.client.Get(d.ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+		return
+	}
+	if live.GetAnnotations()[HashAnnotation] == hash {
+		return
+	}
+	keep(obj, live)
+	if liveHash, ok := live.GetAnnotations()[HashAnnotation]; ok {
+		annotations[HashAnnotation] = liveHash
+	} else {
+		delete(annotations, HashAnnotation)
+	}
+	obj.SetAnnotations(annotations)
+	d.deferred = append(d.deferred, obj.GetName())
+}
+
+func hashOf(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+// deferredComponent is a component whose disruptive changes are deferred by a Deferrer.
+type deferredComponent struct {
+	render.Component
+	deferrer *Deferrer
+}
+
+func (c *deferredComponent) Unwrap() render.Component {
+	return c.Component
+}
+
+func (c *deferredComponent) Objects() ([]client.Object, []client.Object) {
+	objsToCreate, objsToDelete := c.Component.Objects()
+	for _, obj := range objsToCreate {
+		c.deferrer.deferChanges(obj)
+	}
+	return objsToCreate, objsToDelete
+}
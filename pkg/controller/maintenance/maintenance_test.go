@@ -0,0 +1,144 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/maintenance"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render"
+)
+
+var _ = Describe("Maintenance window tests", func() {
+	// Saturday 2024-06-01 02:30 UTC.
+	saturday := time.Date(2024, time.June, 1, 2, 30, 0, 0, time.UTC)
+	window := func(schedule string, d time.Duration) []operatorv1.MaintenanceWindow {
+		return []operatorv1.MaintenanceWindow{{Schedule: schedule, Duration: metav1.Duration{Duration: d}}}
+	}
+
+	table.DescribeTable("should validate windows",
+		func(schedule string, d time.Duration, valid bool) {
+			err := maintenance.Validate(window(schedule, d))
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		table.Entry("weekly", "0 2 * * 6", 4*time.Hour, true),
+		table.Entry("lists, ranges and steps", "*/15 1-4,22 1,15 */2 1-5", time.Hour, true),
+		table.Entry("Sunday as 7", "0 0 * * 7", time.Hour, true),
+		table.Entry("too few fields", "0 2 * *", time.Hour, false),
+		table.Entry("hour out of range", "0 24 * * *", time.Hour, false),
+		table.Entry("inverted range", "0 5-1 * * *", time.Hour, false),
+		table.Entry("zero step", "*/0 * * * *", time.Hour, false),
+		table.Entry("too short", "0 2 * * 6", time.Second, false),
+		table.Entry("too long", "0 2 * * 6", 8*24*time.Hour, false),
+	)
+
+	It("should always be open without windows", func() {
+		open, _, err := maintenance.Open(nil, saturday)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(open).To(BeTrue())
+	})
+
+	It("should be open during a window and report when the next one opens", func() {
+		open, _, err := maintenance.Open(window("0 2 * * 6", time.Hour), saturday)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(open).To(BeTrue())
+
+		open, next, err := maintenance.Open(window("0 2 * * 6", time.Hour), saturday.Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(open).To(BeFalse())
+		Expect(next).To(Equal(7*24*time.Hour - 90*time.Minute))
+
+		// Either the day of month or the day of week matches when both are restricted.
+		open, _, err = maintenance.Open(window("30 2 15 * 6", time.Minute), saturday)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(open).To(BeTrue())
+	})
+
+	Context("deferring changes", func() {
+		var (
+			c   client.Client
+			ctx context.Context
+		)
+
+		daemonSet := func(image string) *appsv1.DaemonSet {
+			return &appsv1.DaemonSet{
+				TypeMeta:   metav1.TypeMeta{Kind: "DaemonSet", APIVersion: "apps/v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: common.NodeDaemonSetName, Namespace: common.CalicoNamespace},
+				Spec: appsv1.DaemonSetSpec{
+					MinReadySeconds: 5,
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "calico-node", Image: image}}},
+					},
+				},
+			}
+		}
+
+		apply := func(open bool, ds *appsv1.DaemonSet) (*appsv1.DaemonSet, []string) {
+			d := maintenance.NewDeferrer(ctx, c, open)
+			objs, _ := d.Wrap(render.NewPassthrough(ds)).Objects()
+			return objs[0].(*appsv1.DaemonSet), d.Deferred()
+		}
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+			Expect(appsv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+			c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+			ctx = context.Background()
+		})
+
+		It("should keep the live pod template outside of a window", func() {
+			// Creating the DaemonSet isn't disruptive.
+			ds, deferred := apply(false, daemonSet("calico/node:v1"))
+			Expect(deferred).To(BeEmpty())
+			Expect(ds.Annotations).To(HaveKey(maintenance.HashAnnotation))
+			Expect(c.Create(ctx, ds)).NotTo(HaveOccurred())
+
+			// An unchanged template isn't deferred.
+			_, deferred = apply(false, daemonSet("calico/node:v1"))
+			Expect(deferred).To(BeEmpty())
+
+			changed := daemonSet("calico/node:v2")
+			changed.Spec.MinReadySeconds = 10
+			ds, deferred = apply(false, changed)
+			Expect(deferred).To(ConsistOf(common.NodeDaemonSetName))
+			Expect(ds.Spec.Template.Spec.Containers[0].Image).To(Equal("calico/node:v1"))
+			Expect(ds.Spec.MinReadySeconds).To(Equal(int32(10)))
+
+			ds, deferred = apply(true, daemonSet("calico/node:v2"))
+			Expect(deferred).To(BeEmpty())
+			Expect(ds.Spec.Template.Spec.Containers[0].Image).To(Equal("calico/node:v2"))
+		})
+	})
+})
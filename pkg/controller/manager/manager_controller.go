@@ -24,33 +24,24 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
-
 	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/common"
-	"github.com/tigera/operator/pkg/controller/certificatemanager"
-	"github.com/tigera/operator/pkg/controller/compliance"
 	"github.com/tigera/operator/pkg/controller/options"
 	"github.com/tigera/operator/pkg/controller/status"
 	"github.com/tigera/operator/pkg/controller/utils"
-	"github.com/tigera/operator/pkg/controller/utils/imageset"
 	"github.com/tigera/operator/pkg/ctrlruntime"
-	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render"
-	rcertificatemanagement "github.com/tigera/operator/pkg/render/certificatemanagement"
 	tigerakvc "github.com/tigera/operator/pkg/render/common/authentication/tigera/key_validator_config"
 	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/logstorage/eck"
 	rmanager "github.com/tigera/operator/pkg/render/manager"
-	"github.com/tigera/operator/pkg/render/monitor"
-	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 )
 
 const ResourceName = "manager"
@@ -72,7 +63,7 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	reconciler := newReconciler(mgr, opts, licenseAPIReady, tierWatchReady)
 
 	// Create a new controller
-	c, err := ctrlruntime.NewController("manager-controller", mgr, controller.Options{Reconciler: reconciler})
+	c, err := ctrlruntime.NewController("manager-controller", mgr, utils.ControllerOptions("manager-controller", reconciler, opts))
 	if err != nil {
 		return fmt.Errorf("failed to create manager-controller: %w", err)
 	}
@@ -84,10 +75,11 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	}
 
 	// Determine how to handle watch events for cluster-scoped resources. For multi-tenant clusters,
-	// we should update all tenants whenever one changes. For single-tenant clusters, we can just queue the object.
+	// we should update the Managers of all tenants whenever one changes. For single-tenant clusters, we can just queue
+	// the object.
 	var eventHandler handler.EventHandler = &handler.EnqueueRequestForObject{}
 	if opts.MultiTenant {
-		eventHandler = utils.EnqueueAllTenants(mgr.GetClient())
+		eventHandler = utils.EnqueueTenantInstances(mgr.GetClient(), &operatorv1.ManagerList{})
 	}
 
 	// Make a helper for determining which namespaces to use based on tenancy mode.
@@ -97,80 +89,71 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		return err
 	}
 
-	go utils.WaitToAddLicenseKeyWatch(c, k8sClient, log, licenseAPIReady)
-	go utils.WaitToAddTierWatch(networkpolicy.TigeraComponentTierName, c, k8sClient, log, tierWatchReady)
-	go utils.WaitToAddNetworkPolicyWatches(c, k8sClient, log, []types.NamespacedName{
+	go utils.WaitToAddLicenseKeyWatchWithHandler(opts.ShutdownContext, c, k8sClient, log, licenseAPIReady, eventHandler)
+	go utils.WaitToAddTierWatch(opts.ShutdownContext, networkpolicy.TigeraComponentTierName, c, k8sClient, log, tierWatchReady)
+	go utils.WaitToAddNetworkPolicyWatches(opts.ShutdownContext, c, k8sClient, log, []types.NamespacedName{
 		{Name: render.ManagerPolicyName, Namespace: helper.InstallNamespace()},
 		{Name: networkpolicy.TigeraComponentDefaultDenyPolicyName, Namespace: helper.InstallNamespace()},
 	})
 
 	// Watch for changes to primary resource Manager
-	err = c.WatchObject(&operatorv1.Manager{}, &handler.EnqueueRequestForObject{})
+	err = c.WatchObject(&operatorv1.Manager{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate)
 	if err != nil {
 		return fmt.Errorf("manager-controller failed to watch primary resource: %w", err)
 	}
 
-	err = c.WatchObject(&operatorv1.TLSTerminatedRoute{}, &handler.EnqueueRequestForObject{})
+	err = c.WatchObject(&operatorv1.TLSTerminatedRoute{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate)
 	if err != nil {
 		return fmt.Errorf("manager-controller failed to watch TLSTerminatedRoutes: %w", err)
 	}
 
-	err = c.WatchObject(&operatorv1.TLSPassThroughRoute{}, &handler.EnqueueRequestForObject{})
+	err = c.WatchObject(&operatorv1.TLSPassThroughRoute{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate)
 	if err != nil {
 		return fmt.Errorf("manager-controller failed to watch TLSPassThroughRoutes: %w", err)
 	}
 
 	// Watch for other operator.tigera.io resources.
-	if err = c.WatchObject(&operatorv1.Installation{}, eventHandler); err != nil {
+	if err = c.WatchObject(&operatorv1.Installation{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("manager-controller failed to watch Installation resource: %w", err)
 	}
-	if err = c.WatchObject(&operatorv1.APIServer{}, eventHandler); err != nil {
+	if err = c.WatchObject(&operatorv1.APIServer{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("manager-controller failed to watch APIServer resource: %w", err)
 	}
-	if err = c.WatchObject(&operatorv1.Compliance{}, eventHandler); err != nil {
+	if err = c.WatchObject(&operatorv1.Compliance{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("manager-controller failed to watch APIServer resource: %w", err)
 	}
-	if err = c.WatchObject(&operatorv1.ManagementCluster{}, eventHandler); err != nil {
+	if err = c.WatchObject(&operatorv1.ManagementCluster{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("manager-controller failed to watch primary resource: %w", err)
 	}
-	if err = c.WatchObject(&operatorv1.ManagementClusterConnection{}, eventHandler); err != nil {
+	if err = c.WatchObject(&operatorv1.ManagementClusterConnection{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("manager-controller failed to watch primary resource: %w", err)
 	}
-	if err = c.WatchObject(&operatorv1.Authentication{}, eventHandler); err != nil {
+	if err = c.WatchObject(&operatorv1.Authentication{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("manager-controller failed to watch resource: %w", err)
 	}
 	if err = utils.AddTigeraStatusWatch(c, ResourceName); err != nil {
 		return fmt.Errorf("manager-controller failed to watch manager Tigerastatus: %w", err)
 	}
-	if err = c.WatchObject(&operatorv1.ImageSet{}, eventHandler); err != nil {
+	if err = c.WatchObject(&operatorv1.ImageSet{}, eventHandler, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("manager-controller failed to watch ImageSet: %w", err)
 	}
 	if opts.MultiTenant {
-		if err = c.WatchObject(&operatorv1.Tenant{}, &handler.EnqueueRequestForObject{}); err != nil {
+		if err = c.WatchObject(&operatorv1.Tenant{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
 			return fmt.Errorf("manager-controller failed to watch Tenant resource: %w", err)
 		}
 	}
 
-	// Watch any secrets that this controller depends upon.
-	namespacesToWatch := []string{helper.TruthNamespace(), helper.InstallNamespace()}
-	if helper.TruthNamespace() == helper.InstallNamespace() {
-		namespacesToWatch = []string{helper.InstallNamespace()}
-	}
-	for _, namespace := range namespacesToWatch {
-		for _, secretName := range []string{
-			// We need to watch for es-gateway certificate because es-proxy still creates a
-			// client to talk to elastic via es-gateway
-			render.ManagerTLSSecretName, relasticsearch.PublicCertSecret,
-			render.VoltronTunnelSecretName, render.ComplianceServerCertSecret, render.PacketCaptureServerCert,
-			render.ManagerInternalTLSSecretName, monitor.PrometheusServerTLSSecretName, certificatemanagement.CASecretName,
-		} {
-			if err = utils.AddSecretsWatch(c, secretName, namespace); err != nil {
-				return fmt.Errorf("manager-controller failed to watch the secret '%s' in '%s' namespace: %w", secretName, namespace, err)
-			}
-		}
+	// Watch the secrets and config maps that the reconciler reads.
+	if err = reconciler.dependencyTracker.Start(c); err != nil {
+		return fmt.Errorf("manager-controller failed to watch its dependencies: %w", err)
 	}
 
-	if err = utils.AddConfigMapWatch(c, tigerakvc.StaticWellKnownJWKSConfigMapName, common.OperatorNamespace(), &handler.EnqueueRequestForObject{}); err != nil {
+	// Watch the sources of the secrets copied into the manager namespace, so that the copies are kept in sync.
+	if err = utils.AddReplicatedSecretsWatch(c, mgr); err != nil {
+		return fmt.Errorf("manager-controller failed to watch replicated secrets: %w", err)
+	}
+
+	if err = utils.AddConfigMapWatch(c, tigerakvc.StaticWellKnownJWKSConfigMapName, common.OperatorNamespace(), eventHandler); err != nil {
 		return fmt.Errorf("manager-controller failed to watch ConfigMap resource %s: %w", tigerakvc.StaticWellKnownJWKSConfigMapName, err)
 	}
 
@@ -192,17 +175,27 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager, opts options.AddOptions, licenseAPIReady *utils.ReadyFlag, tierWatchReady *utils.ReadyFlag) reconcile.Reconciler {
+func newReconciler(mgr manager.Manager, opts options.AddOptions, licenseAPIReady *utils.ReadyFlag, tierWatchReady *utils.ReadyFlag) *ReconcileManager {
+	// In multi-tenant clusters, the secrets and config maps read from the operator's namespace are shared by all
+	// tenants, so changes to them need to reach every tenant's Manager.
+	var trackerHandler handler.EventHandler = &handler.EnqueueRequestForObject{}
+	if opts.MultiTenant {
+		trackerHandler = utils.EnqueueTenantInstances(mgr.GetClient(), &operatorv1.ManagerList{})
+	}
+	helper := utils.NewNamespaceHelper(opts.MultiTenant, render.ManagerNamespace, "")
+	tracker := utils.NewDependencyTracker(trackerHandler, helper.TruthNamespace(), helper.InstallNamespace())
 	c := &ReconcileManager{
-		client:          mgr.GetClient(),
-		scheme:          mgr.GetScheme(),
-		provider:        opts.DetectedProvider,
-		status:          status.New(mgr.GetClient(), "manager", opts.KubernetesVersion),
-		clusterDomain:   opts.ClusterDomain,
-		licenseAPIReady: licenseAPIReady,
-		tierWatchReady:  tierWatchReady,
-		multiTenant:     opts.MultiTenant,
-		elasticExternal: opts.ElasticExternal,
+		client:            tracker.Client(mgr.GetClient()),
+		dependencyTracker: tracker,
+		scheme:            mgr.GetScheme(),
+		provider:          opts.DetectedProvider,
+		status:            status.New(mgr.GetClient(), "manager", opts.KubernetesVersion),
+		clusterDomain:     opts.ClusterDomain,
+		licenseAPIReady:   licenseAPIReady,
+		tierWatchReady:    tierWatchReady,
+		multiTenant:       opts.MultiTenant,
+		elasticExternal:   opts.ElasticExternal,
+		dependencies:      utils.NewDependencyChecker(mgr.GetAPIReader()),
 	}
 	c.status.Run(opts.ShutdownContext)
 	return c
@@ -225,6 +218,12 @@ type ReconcileManager struct {
 	// Whether or not the operator is running in multi-tenant mode.
 	multiTenant     bool
 	elasticExternal bool
+
+	// dependencies checks that the components the manager queries are serving.
+	dependencies *utils.DependencyChecker
+
+	// dependencyTracker watches the secrets and config maps read through the client.
+	dependencyTracker *utils.DependencyTracker
 }
 
 // GetManager returns the default manager instance with defaults populated.
@@ -251,10 +250,11 @@ func GetManager(ctx context.Context, cli client.Client, mt bool, ns string) (*op
 func (r *ReconcileManager) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	// Perform any common preparation that needs to be done for single-tenant and multi-tenant scenarios.
 	helper := utils.NewNamespaceHelper(r.multiTenant, render.ManagerNamespace, request.Namespace)
-	logc := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name, "installNS", helper.InstallNamespace(), "truthNS", helper.TruthNamespace(), "multi-tenant", r.multiTenant)
+	logc := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name, "installNS", helper.InstallNamespace(), "truthNS", helper.TruthNamespace(), "multi-tenant", r.multiTenant)
 	logc.Info("Reconciling Manager")
 
-	// We skip requests without a namespace specified in multi-tenant setups.
+	// We skip requests without a namespace specified in multi-tenant setups. The watches of cluster-scoped and shared
+	// resources queue a request for each tenant's Manager instead.
 	if r.multiTenant && request.Namespace == "" {
 		return reconcile.Result{}, nil
 	}
@@ -294,430 +294,22 @@ func (r *ReconcileManager) Reconcile(ctx context.Context, request reconcile.Requ
 			return reconcile.Result{}, err
 		}
 		instance.Status.Conditions = status.UpdateStatusCondition(instance.Status.Conditions, ts.Status.Conditions)
+		instance.Status.ObservedGeneration = status.ObservedGeneration(ts.Status.Conditions)
+		instance.Status.Rollout = r.status.RolloutStatus()
 		if err := r.client.Status().Update(ctx, instance); err != nil {
 			log.WithValues("reason", err).Info("Failed to create Manager status conditions.")
 			return reconcile.Result{}, err
 		}
 	}
 
-	if !utils.IsAPIServerReady(r.client, logc) {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tigera API server to be ready", nil, logc)
-		return reconcile.Result{}, nil
-	}
-
-	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
-	if !r.tierWatchReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", nil, logc)
-		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
-	}
-
-	// Ensure the allow-tigera tier exists, before rendering any network policies within it.
-	if err := r.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
-		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", err, logc)
-			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
-		} else {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying allow-tigera tier", err, logc)
-			return reconcile.Result{}, err
-		}
-	}
-
-	if !r.licenseAPIReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for LicenseKeyAPI to be ready", nil, logc)
-		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
-	}
-
-	// TODO: Do we need a license per-tenant in the management cluster?
-	license, err := utils.FetchLicenseKey(ctx, r.client)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "License not found", err, logc)
-			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
-		}
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying license", err, logc)
-		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
-	}
-
-	// Fetch the Installation instance. We need this for a few reasons.
-	// - We need to make sure it has successfully completed installation.
-	// - We need to get the registry information from its spec.
-	variant, installation, err := utils.GetInstallation(ctx, r.client)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", err, logc)
-			return reconcile.Result{}, err
-		}
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying installation", err, logc)
-		return reconcile.Result{}, err
-	}
-
-	// When creating the certificate manager, pass in the logger and tenant (if one exists).
-	opts := []certificatemanager.Option{
-		certificatemanager.WithLogger(logc),
-		certificatemanager.WithTenant(tenant),
-	}
-	certificateManager, err := certificatemanager.Create(r.client, installation, r.clusterDomain, helper.TruthNamespace(), opts...)
-	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, logc)
-		return reconcile.Result{}, err
-	}
-
-	// Get or create a certificate for clients of the manager pod es-proxy container.
-	tlsSecret, err := certificateManager.GetOrCreateKeyPair(
-		r.client,
-		render.ManagerTLSSecretName,
-		helper.TruthNamespace(),
-		[]string{"localhost"})
-	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error getting or creating manager TLS certificate", err, logc)
-		return reconcile.Result{}, err
-	}
-
-	// Get or create a certificate for the manager pod to use within the cluster.
-	dnsNames := dns.GetServiceDNSNames(render.ManagerServiceName, helper.InstallNamespace(), r.clusterDomain)
-	internalTrafficSecret, err := certificateManager.GetOrCreateKeyPair(
-		r.client,
-		render.ManagerInternalTLSSecretName,
-		helper.TruthNamespace(),
-		dnsNames)
-	if err != nil {
-		r.status.SetDegraded(operatorv1.CertificateError, fmt.Sprintf("Error ensuring internal manager TLS certificate %q exists and has valid DNS names", render.ManagerInternalTLSSecretName), err, logc)
-		return reconcile.Result{}, err
-	}
-
-	// Determine if compliance is enabled.
-	complianceLicenseFeatureActive := utils.IsFeatureActive(license, common.ComplianceFeature)
-	complianceCR, err := compliance.GetCompliance(ctx, r.client, r.multiTenant, request.Namespace)
-	if err != nil && !errors.IsNotFound(err) {
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying compliance: ", err, logc)
-		return reconcile.Result{}, err
-	}
-
-	// Build a trusted bundle containing all of the certificates of components that communicate with the manager pod.
-	// This bundle contains the root CA used to sign all operator-generated certificates, as well as the explicitly named
-	// certificates, in case the user has provided their own cert in lieu of the default certificate.
-
-	var trustedSecretNames []string
-	if !r.multiTenant {
-		// For multi-tenant systems, we don't support user-provided certs for all components. So, we don't need to include these,
-		// and the bundle will simply use the root CA for the tenant. For single-tenant systems, we need to include these in case
-		// any of them haven't been signed by the root CA.
-		trustedSecretNames = []string{
-			render.ProjectCalicoAPIServerTLSSecretName(installation.Variant),
-			render.TigeraLinseedSecret,
-		}
-
-		packetcaptureapi, err := utils.GetPacketCaptureAPI(ctx, r.client)
-		if err != nil && !errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying PacketCapture CR", err, logc)
-			return reconcile.Result{}, err
-		}
-		if packetcaptureapi != nil {
-			trustedSecretNames = append(trustedSecretNames, render.PacketCaptureServerCert)
-		}
-
-		// This is necessary because prior to v3.13 secrets were not signed by a single CA, so we need to include each individually
-		// in the trusted bundle
-		esgwCertificate, err := certificateManager.GetCertificate(r.client, relasticsearch.PublicCertSecret, common.OperatorNamespace())
-		if err != nil {
-			r.status.SetDegraded(operatorv1.ResourceReadError, fmt.Sprintf("Failed to retrieve / validate  %s", relasticsearch.PublicCertSecret), err, logc)
-			return reconcile.Result{}, err
-		}
-		if esgwCertificate != nil {
-			trustedSecretNames = append(trustedSecretNames, relasticsearch.PublicCertSecret)
-		}
-
-		// If external prometheus is enabled, the secret will be signed by the Calico CA and no secret will be created. We can skip
-		// adding it to the bundle, as trusting the CA will suffice.
-		monitorCR := &operatorv1.Monitor{}
-		if err := r.client.Get(ctx, utils.DefaultTSEEInstanceKey, monitorCR); err != nil {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying required Monitor resource: ", err, logc)
-			return reconcile.Result{}, err
-		}
-		if monitorCR.Spec.ExternalPrometheus == nil {
-			trustedSecretNames = append(trustedSecretNames, monitor.PrometheusServerTLSSecretName)
-		}
-
-		if complianceLicenseFeatureActive && complianceCR != nil {
-			// Check that compliance is running.
-			if complianceCR.Status.State != operatorv1.TigeraStatusReady {
-				r.status.SetDegraded(operatorv1.ResourceNotReady, "Compliance is not ready", nil, logc)
-				return reconcile.Result{}, nil
-			}
-			trustedSecretNames = append(trustedSecretNames, render.ComplianceServerCertSecret)
-		}
-	}
-
-	var authenticationCR *operatorv1.Authentication
-	// Fetch the Authentication spec. If present, we use to configure user authentication.
-	authenticationCR, err = utils.GetAuthentication(ctx, r.client)
-	if err != nil && !errors.IsNotFound(err) {
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error while fetching Authentication", err, logc)
-		return reconcile.Result{}, err
-	}
-	if authenticationCR != nil && authenticationCR.Status.State != operatorv1.TigeraStatusReady {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, fmt.Sprintf("Authentication is not ready authenticationCR status: %s", authenticationCR.Status.State), nil, logc)
-		return reconcile.Result{}, nil
-	} else if authenticationCR != nil && !utils.IsDexDisabled(authenticationCR) {
-		// Do not include DEX TLS Secret Name is authentication CR does not have type Dex
-		trustedSecretNames = append(trustedSecretNames, render.DexTLSSecretName)
-	}
-
-	bundleMaker := certificateManager.CreateTrustedBundle()
-	for _, secret := range trustedSecretNames {
-		certificate, err := certificateManager.GetCertificate(r.client, secret, helper.TruthNamespace())
-		if err != nil {
-			r.status.SetDegraded(operatorv1.CertificateError, fmt.Sprintf("Failed to retrieve %s", secret), err, logc)
-			return reconcile.Result{}, err
-		} else if certificate == nil {
-			logc.Info(fmt.Sprintf("Waiting for secret '%s' to become available", secret))
-			r.status.SetDegraded(operatorv1.ResourceNotReady, fmt.Sprintf("Waiting for secret '%s' to become available", secret), nil, logc)
-			return reconcile.Result{}, nil
-		}
-		bundleMaker.AddCertificates(certificate)
-	}
-	certificateManager.AddToStatusManager(r.status, helper.InstallNamespace())
-
-	// Check that Prometheus is running
-	// TODO: We'll need to run an instance of Prometheus per-tenant? Or do we use labels to delimit metrics?
-	//       Probably the former.
-	ns := &corev1.Namespace{}
-	if err = r.client.Get(ctx, client.ObjectKey{Name: common.TigeraPrometheusNamespace}, ns); err != nil {
-		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotFound, "tigera-prometheus namespace does not exist Dependency on tigera-prometheus not satisfied", nil, logc)
-		} else {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying prometheus", err, logc)
-		}
-		return reconcile.Result{}, err
-	}
-
-	pullSecrets, err := utils.GetNetworkingPullSecrets(installation, r.client)
-	if err != nil {
-		log.Error(err, "Error with Pull secrets")
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", err, logc)
-		return reconcile.Result{}, err
-	}
-
-	managementCluster, err := utils.GetManagementCluster(ctx, r.client)
-	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error reading ManagementCluster", err, logc)
-		return reconcile.Result{}, err
-	}
-
-	managementClusterConnection, err := utils.GetManagementClusterConnection(ctx, r.client)
-	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error reading ManagementClusterConnection", err, logc)
-		return reconcile.Result{}, err
-	}
-
-	if managementClusterConnection != nil && managementCluster != nil {
-		err = fmt.Errorf("having both a ManagementCluster and a ManagementClusterConnection is not supported")
-		r.status.SetDegraded(operatorv1.ResourceValidationError, "", err, logc)
-		return reconcile.Result{}, err
-	}
-
-	// Es-proxy needs to trust Voltron for cross-cluster requests.
-	bundleMaker.AddCertificates(internalTrafficSecret)
-
-	var linseedVoltronServerCert certificatemanagement.KeyPairInterface
-	var tunnelServerCert certificatemanagement.KeyPairInterface
-	var tunnelSecretPassthrough render.Component
-
-	if managementCluster != nil {
-		preDefaultPatchFrom := client.MergeFrom(managementCluster.DeepCopy())
-		fillDefaults(managementCluster)
-
-		// Write the discovered configuration back to the API. This is essentially a poor-man's defaulting, and
-		// ensures that we don't surprise anyone by changing defaults in a future version of the operator.
-		if err := r.client.Patch(ctx, managementCluster, preDefaultPatchFrom); err != nil {
-			r.status.SetDegraded(operatorv1.ResourceUpdateError, "", err, logc)
-			return reconcile.Result{}, err
-		}
-
-		// Create a certificate for Voltron to use when serving TLS connections from managed clusters destined
-		// to Linseed. This certificate is used only for connections received over Voltron's mTLS tunnel targeting tigera-linseed.
-		// The public cert from this keypair is sent by es-kube-controllers to managed clusters so that linseed clients in those clusters
-		// can authenticate the certificate presented by Voltron.
-		linseedDNSNames := dns.GetServiceDNSNames(render.LinseedServiceName, render.ElasticsearchNamespace, r.clusterDomain)
-		linseedVoltronServerCert, err = certificateManager.GetOrCreateKeyPair(
-			r.client,
-			render.VoltronLinseedTLS,
-			helper.TruthNamespace(),
-			linseedDNSNames)
-		if err != nil {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "Error getting or creating Voltron Linseed TLS certificate", err, logc)
-			return reconcile.Result{}, err
-		}
-
-		// Query the tunnel server certificate used by Voltron to serve mTLS connections from managed clusters.
-		tunnelSecretName := managementCluster.Spec.TLS.SecretName
-		// For multi-tenant clusters, ensure that we have a CA that can be used to sign the tunnel server cert within this tenant's namespace.
-		// For single-tenant cluster, ensure that we have a CA that can be used to sign the tunnel server cert in operator namespace.
-		// This certificate will also be presented by Voltron to prove its identity to managed clusters.
-		tunnelCASecret, err := utils.GetSecret(ctx, r.client, tunnelSecretName, helper.TruthNamespace())
-		if err != nil {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "Unable to fetch the tunnel secret", err, logc)
-			return reconcile.Result{}, err
-		}
-
-		// Single tenant MCM clusters will use "voltron" as a server name to establish mTLS connection
-		serverName := "voltron"
-		if r.multiTenant {
-			// Multi-tenant MCM clusters will use the tenat ID as a server name to establish mTLS connection
-			serverName = tenant.Spec.ID
-		}
-
-		if tunnelCASecret == nil {
-			tunnelCASecret, err = certificatemanagement.CreateSelfSignedSecret(tunnelSecretName, helper.TruthNamespace(), "tigera-voltron", []string{serverName})
-			if err != nil {
-				r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the tunnel secret", err, logc)
-				return reconcile.Result{}, err
-			}
-		} else {
-			// Check controller references and remove any old APIServer ownership, since ownership of this resource has moved
-			// to the manager controller instead. Without this, we will hit an error when trying to update the secret as it will
-			// have two controllers set.
-			for i := 0; i < len(tunnelCASecret.OwnerReferences); i++ {
-				ref := tunnelCASecret.OwnerReferences[i]
-				if ref.Kind == "APIServer" && ref.Controller != nil && *ref.Controller {
-					tunnelCASecret.OwnerReferences = append(tunnelCASecret.OwnerReferences[:i], tunnelCASecret.OwnerReferences[i+1:]...)
-					i--
-				}
-			}
-		}
-
-		// We use the CA as the server cert.
-		tunnelServerCert = certificatemanagement.NewKeyPair(tunnelCASecret, nil, "")
-		tunnelSecretPassthrough = render.NewPassthrough(tunnelCASecret)
-	}
-
-	keyValidatorConfig, err := utils.GetKeyValidatorConfig(ctx, r.client, authenticationCR, r.clusterDomain)
-	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceValidationError, "Failed to process the authentication CR.", err, logc)
-		return reconcile.Result{}, err
+	s := &reconcileState{
+		request:  request,
+		helper:   helper,
+		logc:     logc,
+		tenant:   tenant,
+		instance: instance,
 	}
-
-	elasticLicenseType := render.ElasticsearchLicenseTypeBasic
-	if !r.elasticExternal && managementClusterConnection == nil {
-		if elasticLicenseType, err = utils.GetElasticLicenseType(ctx, r.client, logc); err != nil {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to get Elasticsearch license", err, logc)
-			return reconcile.Result{}, err
-		}
-	}
-
-	// Create a component handler to manage the rendered component.
-	componentHandler := utils.NewComponentHandler(log, r.client, r.scheme, instance)
-
-	// Set replicas to 1 for management or managed clusters.
-	// TODO Remove after MCM tigera-manager HA deployment is supported.
-	var replicas *int32 = installation.ControlPlaneReplicas
-	if managementCluster != nil || managementClusterConnection != nil {
-		var mcmReplicas int32 = 1
-		replicas = &mcmReplicas
-	}
-
-	trustedBundle := bundleMaker.(certificatemanagement.TrustedBundleRO)
-	if r.multiTenant {
-		// For multi-tenant systems, we load the pre-created bundle for this tenant instead of using the one we built here.
-		// Multi-tenant managers need the bundle variant that includes system root certificates, in order to verify external auth providers.
-		trustedBundle, err = certificateManager.LoadMultiTenantTrustedBundleWithRootCertificates(ctx, r.client, helper.InstallNamespace())
-		if err != nil {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "Error getting trusted bundle", err, logc)
-			return reconcile.Result{}, err
-		}
-		bundleMaker = nil
-	}
-
-	// Determine the namespaces to which we must bind the cluster role.
-	namespaces, err := helper.TenantNamespaces(r.client)
-	if err != nil {
-		return reconcile.Result{}, err
-	}
-
-	routeConfig, err := getVoltronRouteConfig(ctx, r.client, helper.InstallNamespace())
-	if err != nil {
-		r.status.SetDegraded(operatorv1.InternalServerError, "Failed to create Voltron Route Configuration", err, logc)
-		return reconcile.Result{}, err
-	}
-
-	managerCfg := &render.ManagerConfiguration{
-		VoltronRouteConfig:      routeConfig,
-		KeyValidatorConfig:      keyValidatorConfig,
-		TrustedCertBundle:       trustedBundle,
-		TLSKeyPair:              tlsSecret,
-		VoltronLinseedKeyPair:   linseedVoltronServerCert,
-		PullSecrets:             pullSecrets,
-		OpenShift:               r.provider.IsOpenShift(),
-		Installation:            installation,
-		ManagementCluster:       managementCluster,
-		TunnelServerCert:        tunnelServerCert,
-		InternalTLSKeyPair:      internalTrafficSecret,
-		ClusterDomain:           r.clusterDomain,
-		ESLicenseType:           elasticLicenseType,
-		Replicas:                replicas,
-		Compliance:              complianceCR,
-		ComplianceLicenseActive: complianceLicenseFeatureActive,
-		ComplianceNamespace:     utils.NewNamespaceHelper(r.multiTenant, render.ComplianceNamespace, request.Namespace).InstallNamespace(),
-		Namespace:               helper.InstallNamespace(),
-		TruthNamespace:          helper.TruthNamespace(),
-		Tenant:                  tenant,
-		ExternalElastic:         r.elasticExternal,
-		BindingNamespaces:       namespaces,
-		Manager:                 instance,
-	}
-
-	// Render the desired objects from the CRD and create or update them.
-	component, err := render.Manager(managerCfg)
-	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceRenderingError, "Error rendering Manager", err, logc)
-		return reconcile.Result{}, err
-	}
-
-	if err = imageset.ApplyImageSet(ctx, r.client, variant, component); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, logc)
-		return reconcile.Result{}, err
-	}
-
-	components := []render.Component{
-		// Install manager components.
-		component,
-
-		// Installs KeyPairs and trusted bundle (if not pre-installed)
-		rcertificatemanagement.CertificateManagement(&rcertificatemanagement.Config{
-			Namespace:       helper.InstallNamespace(),
-			TruthNamespace:  helper.TruthNamespace(),
-			ServiceAccounts: []string{render.ManagerServiceAccount},
-			KeyPairOptions: []rcertificatemanagement.KeyPairOption{
-				rcertificatemanagement.NewKeyPairOption(tlsSecret, true, true),
-				rcertificatemanagement.NewKeyPairOption(linseedVoltronServerCert, true, true),
-				rcertificatemanagement.NewKeyPairOption(internalTrafficSecret, true, true),
-				rcertificatemanagement.NewKeyPairOption(tunnelServerCert, false, true),
-			},
-			TrustedBundle: bundleMaker,
-		}),
-	}
-
-	if tunnelSecretPassthrough != nil {
-		components = append(components, tunnelSecretPassthrough)
-	}
-
-	for _, component := range components {
-		if err := componentHandler.CreateOrUpdateOrDelete(ctx, component, r.status); err != nil {
-			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error creating / updating resource", err, logc)
-			return reconcile.Result{}, err
-		}
-	}
-
-	// Clear the degraded bit if we've reached this far.
-	r.status.ClearDegraded()
-	instance.Status.State = operatorv1.TigeraStatusReady
-	if r.status.IsAvailable() {
-		if err = r.client.Status().Update(ctx, instance); err != nil {
-			return reconcile.Result{}, err
-		}
-	}
-
-	return reconcile.Result{}, nil
+	return r.runPhases(ctx, s, phases()...)
 }
 
 func fillDefaults(mc *operatorv1.ManagementCluster) {
@@ -31,6 +31,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -54,6 +55,7 @@ import (
 	"github.com/tigera/operator/pkg/render/common/secret"
 	rsecret "github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/render/logstorage/eck"
+	"github.com/tigera/operator/pkg/render/logstorage/esgateway"
 	"github.com/tigera/operator/pkg/render/monitor"
 	tigeratls "github.com/tigera/operator/pkg/tls"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
@@ -73,6 +75,7 @@ var _ = Describe("Manager controller tests", func() {
 		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
 		Expect(appsv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
 		Expect(rbacv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(networkingv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
 		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
 		ctx = context.Background()
 		replicas = 2
@@ -132,7 +135,6 @@ var _ = Describe("Manager controller tests", func() {
 
 		clusterDomain := "some.domain"
 		expectedDNSNames := dns.GetServiceDNSNames(render.ManagerServiceName, render.ManagerNamespace, clusterDomain)
-		expectedDNSNames = append(expectedDNSNames, "localhost")
 
 		BeforeEach(func() {
 			// Create an object we can use throughout the test to do the compliance reconcile loops.
@@ -162,6 +164,7 @@ var _ = Describe("Manager controller tests", func() {
 				clusterDomain:   clusterDomain,
 				licenseAPIReady: &utils.ReadyFlag{},
 				tierWatchReady:  &utils.ReadyFlag{},
+				dependencies:    utils.NewDependencyChecker(c),
 			}
 
 			Expect(c.Create(ctx, &operatorv1.APIServer{
@@ -205,6 +208,7 @@ var _ = Describe("Manager controller tests", func() {
 			Expect(c.Create(ctx, &corev1.Namespace{
 				ObjectMeta: metav1.ObjectMeta{Name: common.TigeraPrometheusNamespace},
 			})).NotTo(HaveOccurred())
+			createReadyDependencies(ctx, c)
 
 			Expect(c.Create(ctx, relasticsearch.NewClusterConfig("cluster", 1, 1, 1).ConfigMap())).NotTo(HaveOccurred())
 
@@ -265,7 +269,6 @@ var _ = Describe("Manager controller tests", func() {
 				},
 			}
 			dnsNames := dns.GetServiceDNSNames(render.ManagerServiceName, render.ManagerNamespace, clusterDomain)
-			dnsNames = append(dnsNames, "localhost")
 			Expect(test.GetResource(c, internalManagerTLSSecret)).To(BeNil())
 			test.VerifyCert(internalManagerTLSSecret, dnsNames...)
 		})
@@ -288,7 +291,6 @@ var _ = Describe("Manager controller tests", func() {
 			}
 
 			dnsNames := dns.GetServiceDNSNames(render.ManagerServiceName, render.ManagerNamespace, clusterDomain)
-			dnsNames = append(dnsNames, "localhost")
 			Expect(test.GetResource(c, internalManagerTLSSecret)).To(BeNil())
 			test.VerifyCert(internalManagerTLSSecret, dnsNames...)
 		})
@@ -441,6 +443,7 @@ var _ = Describe("Manager controller tests", func() {
 				status:          mockStatus,
 				licenseAPIReady: &utils.ReadyFlag{},
 				tierWatchReady:  &utils.ReadyFlag{},
+				dependencies:    utils.NewDependencyChecker(c),
 			}
 
 			Expect(c.Create(ctx, &operatorv1.APIServer{
@@ -485,6 +488,7 @@ var _ = Describe("Manager controller tests", func() {
 			Expect(c.Create(ctx, &corev1.Namespace{
 				ObjectMeta: metav1.ObjectMeta{Name: common.TigeraPrometheusNamespace},
 			})).NotTo(HaveOccurred())
+			createReadyDependencies(ctx, c)
 
 			Expect(c.Create(ctx, &corev1.ConfigMap{
 				ObjectMeta: metav1.ObjectMeta{
@@ -639,6 +643,50 @@ var _ = Describe("Manager controller tests", func() {
 				})
 			})
 
+			Context("ingress reconciliation", func() {
+				setIngress := func(ingress *operatorv1.ManagerIngress) {
+					manager := &operatorv1.Manager{}
+					Expect(c.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, manager)).NotTo(HaveOccurred())
+					manager.Spec.Ingress = ingress
+					Expect(c.Update(ctx, manager)).NotTo(HaveOccurred())
+				}
+
+				It("should render the Ingress of the Manager UI and delete it once it's no longer asked for", func() {
+					setIngress(&operatorv1.ManagerIngress{Host: "manager.example.com"})
+					_, err := r.Reconcile(ctx, reconcile.Request{})
+					Expect(err).ShouldNot(HaveOccurred())
+
+					ingress := &networkingv1.Ingress{}
+					Expect(c.Get(ctx, client.ObjectKey{Name: render.ManagerIngressName, Namespace: render.ManagerNamespace}, ingress)).NotTo(HaveOccurred())
+					Expect(ingress.Spec.Rules).To(HaveLen(1))
+					Expect(ingress.Spec.Rules[0].Host).To(Equal("manager.example.com"))
+
+					setIngress(nil)
+					_, err = r.Reconcile(ctx, reconcile.Request{})
+					Expect(err).ShouldNot(HaveOccurred())
+					err = c.Get(ctx, client.ObjectKey{Name: render.ManagerIngressName, Namespace: render.ManagerNamespace}, ingress)
+					Expect(kerror.IsNotFound(err)).To(BeTrue())
+				})
+
+				It("should degrade if the ingress is not valid", func() {
+					mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Manager spec.ingress is not valid", mock.Anything, mock.Anything).Return()
+					setIngress(&operatorv1.ManagerIngress{Host: "*.example.com"})
+					_, err := r.Reconcile(ctx, reconcile.Request{})
+					Expect(err).Should(HaveOccurred())
+					mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Manager spec.ingress is not valid", mock.Anything, mock.Anything)
+				})
+
+				It("should wait for the TLS secret of the Route on OpenShift", func() {
+					r.provider = operatorv1.ProviderOpenShift
+					mockStatus.On("SetDegraded", operatorv1.ResourceNotFound, "Secret tigera-manager/manager-ingress-tls named by Manager spec.ingress.tlsSecretName not found", mock.Anything, mock.Anything).Return()
+					setIngress(&operatorv1.ManagerIngress{Host: "manager.apps.example.com", TLSSecretName: "manager-ingress-tls"})
+					result, err := r.Reconcile(ctx, reconcile.Request{})
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(result.RequeueAfter).To(Equal(utils.StandardRetry))
+					mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceNotFound, "Secret tigera-manager/manager-ingress-tls named by Manager spec.ingress.tlsSecretName not found", mock.Anything, mock.Anything)
+				})
+			})
+
 			Context("allow-tigera reconciliation", func() {
 				var readyFlag *utils.ReadyFlag
 				BeforeEach(func() {
@@ -655,6 +703,7 @@ var _ = Describe("Manager controller tests", func() {
 						status:          mockStatus,
 						licenseAPIReady: readyFlag,
 						tierWatchReady:  readyFlag,
+						dependencies:    utils.NewDependencyChecker(c),
 					}
 				})
 
@@ -698,6 +747,25 @@ var _ = Describe("Manager controller tests", func() {
 					mockStatus.AssertExpectations(GinkgoT())
 				})
 
+				It("should wait for the compliance server to be serving", func() {
+					Expect(c.Delete(ctx, &corev1.Endpoints{
+						ObjectMeta: metav1.ObjectMeta{Name: render.ComplianceServiceName, Namespace: render.ComplianceNamespace},
+					})).NotTo(HaveOccurred())
+					// Dependencies are checked before any certificates are provisioned.
+					mockStatus = &status.MockStatus{}
+					mockStatus.On("OnCRFound").Return()
+					mockStatus.On("SetDegraded", operatorv1.DependencyNotReady, "Waiting for dependencies to be ready",
+						"Compliance server service tigera-compliance/compliance has no endpoints", mock.Anything).Return()
+					mockStatus.On("SetMetaData", mock.Anything).Return()
+					r.status = mockStatus
+
+					result, err := r.Reconcile(ctx, reconcile.Request{})
+
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.RequeueAfter).To(Equal(utils.StandardRetry))
+					mockStatus.AssertExpectations(GinkgoT())
+				})
+
 				DescribeTable("should not degrade when compliance CR or compliance license feature is not present/active", func(crPresent, licenseFeatureActive bool) {
 					mockStatus = &status.MockStatus{}
 					mockStatus.On("IsAvailable").Return(true)
@@ -734,6 +802,7 @@ var _ = Describe("Manager controller tests", func() {
 				generation := int64(2)
 				It("should reconcile with creating new status condition with one item", func() {
 					mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
+					mockStatus.On("RolloutStatus").Return(&operatorv1.RolloutStatus{DesiredReplicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1})
 					ts := &operatorv1.TigeraStatus{
 						ObjectMeta: metav1.ObjectMeta{Name: "manager"},
 						Spec:       operatorv1.TigeraStatusSpec{},
@@ -765,9 +834,12 @@ var _ = Describe("Manager controller tests", func() {
 					Expect(instance.Status.Conditions[0].Reason).To(Equal(string(operatorv1.AllObjectsAvailable)))
 					Expect(instance.Status.Conditions[0].Message).To(Equal("All Objects are available"))
 					Expect(instance.Status.Conditions[0].ObservedGeneration).To(Equal(generation))
+					Expect(instance.Status.ObservedGeneration).To(Equal(generation))
+					Expect(instance.Status.Rollout).To(Equal(&operatorv1.RolloutStatus{DesiredReplicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1}))
 				})
 				It("should reconcile with empty tigerastatus conditions ", func() {
 					mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
+					mockStatus.On("RolloutStatus").Return(nil)
 					ts := &operatorv1.TigeraStatus{
 						ObjectMeta: metav1.ObjectMeta{Name: "manager"},
 						Spec:       operatorv1.TigeraStatusSpec{},
@@ -788,6 +860,7 @@ var _ = Describe("Manager controller tests", func() {
 
 				It("should reconcile with creating new status condition  with multiple conditions as true", func() {
 					mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
+					mockStatus.On("RolloutStatus").Return(nil)
 					ts := &operatorv1.TigeraStatus{
 						ObjectMeta: metav1.ObjectMeta{Name: "manager"},
 						Spec:       operatorv1.TigeraStatusSpec{},
@@ -849,6 +922,7 @@ var _ = Describe("Manager controller tests", func() {
 
 				It("should reconcile with creating new status condition and toggle Available to true & others to false", func() {
 					mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
+					mockStatus.On("RolloutStatus").Return(nil)
 					ts := &operatorv1.TigeraStatus{
 						ObjectMeta: metav1.ObjectMeta{Name: "manager"},
 						Spec:       operatorv1.TigeraStatusSpec{},
@@ -1305,3 +1379,23 @@ func assertSANs(secret *corev1.Secret, expectedSAN string) {
 
 	Expect(cert.DNSNames).To(Equal([]string{expectedSAN}))
 }
+
+// createReadyDependencies creates the deployments and endpoints of the components that the manager waits for.
+func createReadyDependencies(ctx context.Context, c client.Client) {
+	for _, dep := range []struct{ deployment, service, namespace string }{
+		{render.ComplianceServerName, render.ComplianceServiceName, render.ComplianceNamespace},
+		{esgateway.DeploymentName, esgateway.ServiceName, render.ElasticsearchNamespace},
+	} {
+		Expect(c.Create(ctx, &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: dep.deployment, Namespace: dep.namespace},
+			Status:     appsv1.DeploymentStatus{UpdatedReplicas: 1, AvailableReplicas: 1},
+		})).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: dep.service, Namespace: dep.namespace},
+			Subsets: []corev1.EndpointSubset{{
+				Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}},
+				Ports:     []corev1.EndpointPort{{Port: 443}},
+			}},
+		})).NotTo(HaveOccurred())
+	}
+}
@@ -0,0 +1,637 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/common/validation"
+	"github.com/tigera/operator/pkg/controller/certificatemanager"
+	"github.com/tigera/operator/pkg/controller/compliance"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/controller/utils/imageset"
+	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/logging"
+	"github.com/tigera/operator/pkg/render"
+	rcertificatemanagement "github.com/tigera/operator/pkg/render/certificatemanagement"
+	rauth "github.com/tigera/operator/pkg/render/common/authentication"
+	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
+	"github.com/tigera/operator/pkg/render/logstorage/esgateway"
+	"github.com/tigera/operator/pkg/render/monitor"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
+)
+
+// phase is one step of a Manager reconcile. A phase reads what earlier phases stored in the reconcileState, and stores
+// what later phases need. A phase that can't complete reports why on the status manager and returns the result to
+// end the reconcile with.
+type phase interface {
+	name() string
+	run(ctx context.Context, r *ReconcileManager, s *reconcileState) (*reconcile.Result, error)
+}
+
+// phases returns the phases of a Manager reconcile, in the order they run.
+func phases() []phase {
+	return []phase{
+		prereqsPhase{},
+		certificatesPhase{},
+		dataStorePhase{},
+		renderPhase{},
+		applyPhase{},
+		statusPhase{},
+	}
+}
+
+// reconcileState is what the phases of a single Manager reconcile share.
+type reconcileState struct {
+	request  reconcile.Request
+	helper   utils.NamespaceHelper
+	logc     logr.Logger
+	tenant   *operatorv1.Tenant
+	instance *operatorv1.Manager
+
+	// Set by the prereqs phase.
+	variant                        operatorv1.ProductVariant
+	installation                   *operatorv1.InstallationSpec
+	complianceLicenseFeatureActive bool
+	complianceCR                   *operatorv1.Compliance
+	authenticationCR               *operatorv1.Authentication
+	keyValidatorConfig             rauth.KeyValidatorConfig
+	pullSecrets                    []*corev1.Secret
+	ingressTLSSecret               *corev1.Secret
+	managementCluster              *operatorv1.ManagementCluster
+	managementClusterConnection    *operatorv1.ManagementClusterConnection
+
+	// Set by the certificates phase.
+	certificateManager       certificatemanager.CertificateManager
+	tlsSecret                certificatemanagement.KeyPairInterface
+	internalTrafficSecret    certificatemanagement.KeyPairInterface
+	linseedVoltronServerCert certificatemanagement.KeyPairInterface
+	tunnelServerCert         certificatemanagement.KeyPairInterface
+	tunnelSecretPassthrough  render.Component
+	// bundleMaker is nil when the trusted bundle is pre-created, as it is in multi-tenant mode.
+	bundleMaker   certificatemanagement.TrustedBundle
+	trustedBundle certificatemanagement.TrustedBundleRO
+
+	// Set by the data store phase.
+	elasticLicenseType render.ElasticsearchLicenseType
+
+	// Set by the render phase.
+	components []render.Component
+}
+
+// runPhases runs the phases in order, stopping at the first one that doesn't complete.
+func (r *ReconcileManager) runPhases(ctx context.Context, s *reconcileState, phases ...phase) (reconcile.Result, error) {
+	for _, p := range phases {
+		s.logc.V(2).Info("Running phase", "phase", p.name())
+		result, err := p.run(ctx, r, s)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if result != nil {
+			return *result, nil
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+// stop ends the reconcile with the given result.
+func stop(result reconcile.Result) *reconcile.Result {
+	return &result
+}
+
+// prereqsPhase checks that everything the manager depends on is in place, and reads the resources that configure it.
+type prereqsPhase struct{}
+
+func (prereqsPhase) name() string { return "Prereqs" }
+
+func (prereqsPhase) run(ctx context.Context, r *ReconcileManager, s *reconcileState) (*reconcile.Result, error) {
+	logc := s.logc
+	if err := validation.ValidateServiceOptions(s.instance.Spec.ManagerService); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Manager spec.managerService is not valid", err, logc)
+		return nil, err
+	}
+	if err := validation.ValidateManagerIngress(s.instance.Spec.Ingress); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Manager spec.ingress is not valid", err, logc)
+		return nil, err
+	}
+
+	prereqs, result, err := utils.Prerequisites{
+		UpgradeStage: operatorv1.UpgradeStageComponents,
+		APIServer:    true,
+		TierWatch:    r.tierWatchReady,
+		LicenseAPI:   r.licenseAPIReady,
+		License:      true,
+	}.Check(ctx, r.client, r.status, logc)
+	if result != nil {
+		return result, err
+	}
+	// TODO: Do we need a license per-tenant in the management cluster?
+	license := prereqs.License
+
+	// Fetch the Installation instance. We need this for a few reasons.
+	// - We need to make sure it has successfully completed installation.
+	// - We need to get the registry information from its spec.
+	s.variant, s.installation, err = utils.GetInstallation(ctx, r.client)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "Installation not found", err, logc)
+			return nil, err
+		}
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying installation", err, logc)
+		return nil, err
+	}
+
+	// Determine if compliance is enabled.
+	s.complianceLicenseFeatureActive = utils.IsFeatureActive(license, common.ComplianceFeature)
+	s.complianceCR, err = compliance.GetCompliance(ctx, r.client, r.multiTenant, s.request.Namespace)
+	if err != nil && !errors.IsNotFound(err) {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying compliance: ", err, logc)
+		return nil, err
+	}
+
+	// Fetch the Authentication spec. If present, we use to configure user authentication.
+	s.authenticationCR, err = utils.GetAuthentication(ctx, r.client)
+	if err != nil && !errors.IsNotFound(err) {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error while fetching Authentication", err, logc)
+		return nil, err
+	}
+	if s.authenticationCR != nil && s.authenticationCR.Status.State != operatorv1.TigeraStatusReady {
+		r.status.SetDegraded(operatorv1.ResourceNotReady, fmt.Sprintf("Authentication is not ready authenticationCR status: %s", s.authenticationCR.Status.State), nil, logc)
+		return stop(reconcile.Result{}), nil
+	}
+
+	// Check that Prometheus is running. In multi-tenant mode, the monitor controller also runs a Prometheus instance
+	// in the namespace of each tenant that only scrapes that namespace.
+	ns := &corev1.Namespace{}
+	if err = r.client.Get(ctx, client.ObjectKey{Name: common.TigeraPrometheusNamespace}, ns); err != nil {
+		if errors.IsNotFound(err) {
+			r.status.SetDegraded(operatorv1.ResourceNotFound, "tigera-prometheus namespace does not exist Dependency on tigera-prometheus not satisfied", nil, logc)
+		} else {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying prometheus", err, logc)
+		}
+		return nil, err
+	}
+
+	s.pullSecrets, err = utils.GetNetworkingPullSecrets(s.installation, r.client)
+	if err != nil {
+		log.Error(err, "Error with Pull secrets")
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", err, logc)
+		return nil, err
+	}
+
+	// On OpenShift, the certificate presented for the Manager UI is copied into the Route, so read it here. Ingress
+	// controllers read the secret themselves.
+	if ingress := s.instance.Spec.Ingress; ingress != nil && ingress.TLSSecretName != "" && r.provider.IsOpenShift() {
+		s.ingressTLSSecret = &corev1.Secret{}
+		key := client.ObjectKey{Name: ingress.TLSSecretName, Namespace: s.helper.InstallNamespace()}
+		if err = r.client.Get(ctx, key, s.ingressTLSSecret); err != nil {
+			if errors.IsNotFound(err) {
+				r.status.SetDegraded(operatorv1.ResourceNotFound, fmt.Sprintf("Secret %s named by Manager spec.ingress.tlsSecretName not found", key), err, logc)
+				return stop(reconcile.Result{RequeueAfter: utils.StandardRetry}), nil
+			}
+			r.status.SetDegraded(operatorv1.ResourceReadError, fmt.Sprintf("Error reading secret %s", key), err, logc)
+			return nil, err
+		}
+		if len(s.ingressTLSSecret.Data[corev1.TLSCertKey]) == 0 || len(s.ingressTLSSecret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+			err = fmt.Errorf("secret %s must have %s and %s", key, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+			r.status.SetDegraded(operatorv1.ResourceValidationError, "Manager spec.ingress.tlsSecretName is not valid", err, logc)
+			return nil, err
+		}
+	}
+
+	s.managementCluster, err = utils.GetManagementCluster(ctx, r.client)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error reading ManagementCluster", err, logc)
+		return nil, err
+	}
+
+	s.managementClusterConnection, err = utils.GetManagementClusterConnection(ctx, r.client)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error reading ManagementClusterConnection", err, logc)
+		return nil, err
+	}
+
+	if s.managementClusterConnection != nil && s.managementCluster != nil {
+		err = fmt.Errorf("having both a ManagementCluster and a ManagementClusterConnection is not supported")
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "", err, logc)
+		return nil, err
+	}
+
+	if s.managementCluster != nil && s.managementCluster.Spec.TunnelService != nil {
+		if r.multiTenant {
+			err = fmt.Errorf("ManagementCluster spec.tunnelService is not supported in multi-tenant management clusters")
+			r.status.SetDegraded(operatorv1.ResourceValidationError, "", err, logc)
+			return nil, err
+		}
+		if err = validation.ValidateTunnelServiceOptions(s.managementCluster.Spec.TunnelService); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, "ManagementCluster spec.tunnelService is not valid", err, logc)
+			return nil, err
+		}
+	}
+
+	// Wait for the components that the manager queries to be serving before rendering it, so that users don't get
+	// errors in the UI. The state of their CRs only tells us that they became available at some point.
+	if !r.multiTenant {
+		var dependencies []utils.Dependency
+		if s.complianceLicenseFeatureActive && s.complianceCR != nil {
+			dependencies = append(dependencies, utils.Dependency{
+				Name:       "Compliance server",
+				Deployment: types.NamespacedName{Name: render.ComplianceServerName, Namespace: render.ComplianceNamespace},
+				Service:    &types.NamespacedName{Name: render.ComplianceServiceName, Namespace: render.ComplianceNamespace},
+			})
+		}
+		if !r.elasticExternal && s.managementClusterConnection == nil {
+			dependencies = append(dependencies, utils.Dependency{
+				Name:       "Elasticsearch gateway",
+				Deployment: types.NamespacedName{Name: esgateway.DeploymentName, Namespace: render.ElasticsearchNamespace},
+				Service:    &types.NamespacedName{Name: esgateway.ServiceName, Namespace: render.ElasticsearchNamespace},
+			})
+		}
+		if err := r.dependencies.Check(ctx, dependencies...); err != nil {
+			r.status.SetDegraded(operatorv1.DependencyNotReady, "Waiting for dependencies to be ready", err, logc)
+			return stop(reconcile.Result{RequeueAfter: utils.StandardRetry}), nil
+		}
+	}
+
+	if s.managementCluster != nil {
+		preDefaultPatchFrom := client.MergeFrom(s.managementCluster.DeepCopy())
+		fillDefaults(s.managementCluster)
+
+		// Write the discovered configuration back to the API. This is essentially a poor-man's defaulting, and
+		// ensures that we don't surprise anyone by changing defaults in a future version of the operator.
+		if err := r.client.Patch(ctx, s.managementCluster, preDefaultPatchFrom); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, "", err, logc)
+			return nil, err
+		}
+	}
+
+	s.keyValidatorConfig, err = utils.GetKeyValidatorConfig(ctx, r.client, s.authenticationCR, r.clusterDomain)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Failed to process the authentication CR.", err, logc)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// certificatesPhase provisions the keypairs of the manager and builds the bundle of certificates it trusts.
+type certificatesPhase struct{}
+
+func (certificatesPhase) name() string { return "Certificates" }
+
+func (certificatesPhase) run(ctx context.Context, r *ReconcileManager, s *reconcileState) (*reconcile.Result, error) {
+	logc, helper := s.logc, s.helper
+
+	// When creating the certificate manager, pass in the logger and tenant (if one exists).
+	opts := []certificatemanager.Option{
+		certificatemanager.WithLogger(logc),
+		certificatemanager.WithTenant(s.tenant),
+	}
+	certificateManager, err := certificatemanager.Create(r.client, s.installation, r.clusterDomain, helper.TruthNamespace(), opts...)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, logc)
+		return nil, err
+	}
+	s.certificateManager = certificateManager
+
+	// Get or create a certificate for clients of the manager pod es-proxy container.
+	s.tlsSecret, err = certificateManager.GetOrCreateKeyPair(
+		r.client,
+		render.ManagerTLSSecretName,
+		helper.TruthNamespace(),
+		[]string{"localhost"})
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error getting or creating manager TLS certificate", err, logc)
+		return nil, err
+	}
+
+	// Get or create a certificate for the manager pod to use within the cluster.
+	dnsNames := dns.GetServiceDNSNames(render.ManagerServiceName, helper.InstallNamespace(), r.clusterDomain)
+	s.internalTrafficSecret, err = certificateManager.GetOrCreateKeyPair(
+		r.client,
+		render.ManagerInternalTLSSecretName,
+		helper.TruthNamespace(),
+		dnsNames)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.CertificateError, fmt.Sprintf("Error ensuring internal manager TLS certificate %q exists and has valid DNS names", render.ManagerInternalTLSSecretName), err, logc)
+		return nil, err
+	}
+
+	// Build a trusted bundle containing all of the certificates of components that communicate with the manager pod.
+	// This bundle contains the root CA used to sign all operator-generated certificates, as well as the explicitly named
+	// certificates, in case the user has provided their own cert in lieu of the default certificate.
+
+	var trustedSecretNames []string
+	if !r.multiTenant {
+		// For multi-tenant systems, we don't support user-provided certs for all components. So, we don't need to include these,
+		// and the bundle will simply use the root CA for the tenant. For single-tenant systems, we need to include these in case
+		// any of them haven't been signed by the root CA.
+		trustedSecretNames = []string{
+			render.ProjectCalicoAPIServerTLSSecretName(s.installation.Variant),
+			render.TigeraLinseedSecret,
+		}
+
+		packetcaptureapi, err := utils.GetPacketCaptureAPI(ctx, r.client)
+		if err != nil && !errors.IsNotFound(err) {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying PacketCapture CR", err, logc)
+			return nil, err
+		}
+		if packetcaptureapi != nil {
+			trustedSecretNames = append(trustedSecretNames, render.PacketCaptureServerCert)
+		}
+
+		// This is necessary because prior to v3.13 secrets were not signed by a single CA, so we need to include each individually
+		// in the trusted bundle
+		esgwCertificate, err := certificateManager.GetCertificate(r.client, relasticsearch.PublicCertSecret, common.OperatorNamespace())
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, fmt.Sprintf("Failed to retrieve / validate  %s", relasticsearch.PublicCertSecret), err, logc)
+			return nil, err
+		}
+		if esgwCertificate != nil {
+			trustedSecretNames = append(trustedSecretNames, relasticsearch.PublicCertSecret)
+		}
+
+		// If external prometheus is enabled, the secret will be signed by the Calico CA and no secret will be created. We can skip
+		// adding it to the bundle, as trusting the CA will suffice.
+		monitorCR := &operatorv1.Monitor{}
+		if err := r.client.Get(ctx, utils.DefaultTSEEInstanceKey, monitorCR); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying required Monitor resource: ", err, logc)
+			return nil, err
+		}
+		if monitorCR.Spec.ExternalPrometheus == nil {
+			trustedSecretNames = append(trustedSecretNames, monitor.PrometheusServerTLSSecretName)
+		}
+
+		if s.complianceLicenseFeatureActive && s.complianceCR != nil {
+			// Check that compliance is running.
+			if s.complianceCR.Status.State != operatorv1.TigeraStatusReady {
+				r.status.SetDegraded(operatorv1.ResourceNotReady, "Compliance is not ready", nil, logc)
+				return stop(reconcile.Result{}), nil
+			}
+			trustedSecretNames = append(trustedSecretNames, render.ComplianceServerCertSecret)
+		}
+	}
+
+	if s.authenticationCR != nil && !utils.IsDexDisabled(s.authenticationCR) {
+		// Do not include DEX TLS Secret Name is authentication CR does not have type Dex
+		trustedSecretNames = append(trustedSecretNames, render.DexTLSSecretName)
+	}
+
+	bundleMaker := certificateManager.CreateTrustedBundle()
+	for _, secret := range trustedSecretNames {
+		certificate, err := certificateManager.GetCertificate(r.client, secret, helper.TruthNamespace())
+		if err != nil {
+			r.status.SetDegraded(operatorv1.CertificateError, fmt.Sprintf("Failed to retrieve %s", secret), err, logc)
+			return nil, err
+		} else if certificate == nil {
+			logc.Info(fmt.Sprintf("Waiting for secret '%s' to become available", secret))
+			r.status.SetDegraded(operatorv1.ResourceNotReady, fmt.Sprintf("Waiting for secret '%s' to become available", secret), nil, logc)
+			return stop(reconcile.Result{}), nil
+		}
+		bundleMaker.AddCertificates(certificate)
+	}
+	certificateManager.AddToStatusManager(r.status, helper.InstallNamespace())
+
+	// Es-proxy needs to trust Voltron for cross-cluster requests.
+	bundleMaker.AddCertificates(s.internalTrafficSecret)
+
+	if s.managementCluster != nil {
+		// Create a certificate for Voltron to use when serving TLS connections from managed clusters destined
+		// to Linseed. This certificate is used only for connections received over Voltron's mTLS tunnel targeting tigera-linseed.
+		// The public cert from this keypair is sent by es-kube-controllers to managed clusters so that linseed clients in those clusters
+		// can authenticate the certificate presented by Voltron.
+		linseedDNSNames := dns.GetServiceDNSNames(render.LinseedServiceName, render.ElasticsearchNamespace, r.clusterDomain)
+		s.linseedVoltronServerCert, err = certificateManager.GetOrCreateKeyPair(
+			r.client,
+			render.VoltronLinseedTLS,
+			helper.TruthNamespace(),
+			linseedDNSNames)
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error getting or creating Voltron Linseed TLS certificate", err, logc)
+			return nil, err
+		}
+
+		// Query the tunnel server certificate used by Voltron to serve mTLS connections from managed clusters.
+		tunnelSecretName := s.managementCluster.Spec.TLS.SecretName
+		// For multi-tenant clusters, ensure that we have a CA that can be used to sign the tunnel server cert within this tenant's namespace.
+		// For single-tenant cluster, ensure that we have a CA that can be used to sign the tunnel server cert in operator namespace.
+		// This certificate will also be presented by Voltron to prove its identity to managed clusters.
+		tunnelCASecret, err := utils.GetSecret(ctx, r.client, tunnelSecretName, helper.TruthNamespace())
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Unable to fetch the tunnel secret", err, logc)
+			return nil, err
+		}
+
+		// Single tenant MCM clusters will use "voltron" as a server name to establish mTLS connection
+		serverName := "voltron"
+		if r.multiTenant {
+			// Multi-tenant MCM clusters will use the tenat ID as a server name to establish mTLS connection
+			serverName = s.tenant.Spec.ID
+		}
+
+		if tunnelCASecret == nil {
+			tunnelCASecret, err = certificatemanagement.CreateSelfSignedSecret(tunnelSecretName, helper.TruthNamespace(), "tigera-voltron", []string{serverName})
+			if err != nil {
+				r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the tunnel secret", err, logc)
+				return nil, err
+			}
+		} else {
+			// Check controller references and remove any old APIServer ownership, since ownership of this resource has moved
+			// to the manager controller instead. Without this, we will hit an error when trying to update the secret as it will
+			// have two controllers set.
+			for i := 0; i < len(tunnelCASecret.OwnerReferences); i++ {
+				ref := tunnelCASecret.OwnerReferences[i]
+				if ref.Kind == "APIServer" && ref.Controller != nil && *ref.Controller {
+					tunnelCASecret.OwnerReferences = append(tunnelCASecret.OwnerReferences[:i], tunnelCASecret.OwnerReferences[i+1:]...)
+					i--
+				}
+			}
+		}
+
+		// We use the CA as the server cert.
+		s.tunnelServerCert = certificatemanagement.NewKeyPair(tunnelCASecret, nil, "")
+		s.tunnelSecretPassthrough = render.NewPassthrough(tunnelCASecret)
+	}
+
+	s.bundleMaker = bundleMaker
+	s.trustedBundle = bundleMaker
+	if r.multiTenant {
+		// For multi-tenant systems, we load the pre-created bundle for this tenant instead of using the one we built here.
+		// Multi-tenant managers need the bundle variant that includes system root certificates, in order to verify external auth providers.
+		s.trustedBundle, err = certificateManager.LoadMultiTenantTrustedBundleWithRootCertificates(ctx, r.client, helper.InstallNamespace())
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error getting trusted bundle", err, logc)
+			return nil, err
+		}
+		s.bundleMaker = nil
+	}
+	return nil, nil
+}
+
+// dataStorePhase reads the configuration of the Elasticsearch cluster that the manager queries.
+type dataStorePhase struct{}
+
+func (dataStorePhase) name() string { return "DataStore" }
+
+func (dataStorePhase) run(ctx context.Context, r *ReconcileManager, s *reconcileState) (*reconcile.Result, error) {
+	s.elasticLicenseType = render.ElasticsearchLicenseTypeBasic
+	if !r.elasticExternal && s.managementClusterConnection == nil {
+		var err error
+		if s.elasticLicenseType, err = utils.GetElasticLicenseType(ctx, r.client, s.logc); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to get Elasticsearch license", err, s.logc)
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// renderPhase renders the components of the manager.
+type renderPhase struct{}
+
+func (renderPhase) name() string { return "Render" }
+
+func (renderPhase) run(ctx context.Context, r *ReconcileManager, s *reconcileState) (*reconcile.Result, error) {
+	logc, helper := s.logc, s.helper
+
+	// Set replicas to 1 for management or managed clusters. Each guardian holds a single tunnel to one Voltron pod,
+	// and Voltron has no way to forward requests to a tunnel held by another replica, nor to elect a single active
+	// replica. Until Voltron supports one of these, running more than one replica would send requests for a managed
+	// cluster to pods that can't reach it.
+	// TODO Remove after MCM tigera-manager HA deployment is supported.
+	var replicas *int32 = s.installation.ControlPlaneReplicas
+	if s.managementCluster != nil || s.managementClusterConnection != nil {
+		var mcmReplicas int32 = 1
+		replicas = &mcmReplicas
+	}
+
+	// Determine the namespaces to which we must bind the cluster role.
+	namespaces, err := helper.TenantNamespaces(r.client)
+	if err != nil {
+		return nil, err
+	}
+
+	routeConfig, err := getVoltronRouteConfig(ctx, r.client, helper.InstallNamespace())
+	if err != nil {
+		r.status.SetDegraded(operatorv1.InternalServerError, "Failed to create Voltron Route Configuration", err, logc)
+		return nil, err
+	}
+
+	managerCfg := &render.ManagerConfiguration{
+		VoltronRouteConfig:      routeConfig,
+		KeyValidatorConfig:      s.keyValidatorConfig,
+		TrustedCertBundle:       s.trustedBundle,
+		IngressTLSSecret:        s.ingressTLSSecret,
+		Authentication:          s.authenticationCR,
+		TLSKeyPair:              s.tlsSecret,
+		VoltronLinseedKeyPair:   s.linseedVoltronServerCert,
+		PullSecrets:             s.pullSecrets,
+		OpenShift:               r.provider.IsOpenShift(),
+		Installation:            s.installation,
+		ManagementCluster:       s.managementCluster,
+		TunnelServerCert:        s.tunnelServerCert,
+		InternalTLSKeyPair:      s.internalTrafficSecret,
+		ClusterDomain:           r.clusterDomain,
+		ESLicenseType:           s.elasticLicenseType,
+		Replicas:                replicas,
+		Compliance:              s.complianceCR,
+		ComplianceLicenseActive: s.complianceLicenseFeatureActive,
+		ComplianceNamespace:     utils.NewNamespaceHelper(r.multiTenant, render.ComplianceNamespace, s.request.Namespace).InstallNamespace(),
+		Namespace:               helper.InstallNamespace(),
+		TruthNamespace:          helper.TruthNamespace(),
+		Tenant:                  s.tenant,
+		ExternalElastic:         r.elasticExternal,
+		BindingNamespaces:       namespaces,
+		Manager:                 s.instance,
+	}
+
+	// Render the desired objects from the CRD and create or update them.
+	component, err := render.Manager(managerCfg)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceRenderingError, "Error rendering Manager", err, logc)
+		return nil, err
+	}
+
+	if err = imageset.ApplyImageSet(ctx, r.client, s.variant, component); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, logc)
+		return nil, err
+	}
+
+	s.components = []render.Component{
+		// Install manager components.
+		component,
+
+		// Installs KeyPairs and trusted bundle (if not pre-installed)
+		rcertificatemanagement.CertificateManagement(&rcertificatemanagement.Config{
+			Namespace:       helper.InstallNamespace(),
+			TruthNamespace:  helper.TruthNamespace(),
+			ServiceAccounts: []string{render.ManagerServiceAccount},
+			KeyPairOptions: []rcertificatemanagement.KeyPairOption{
+				rcertificatemanagement.NewKeyPairOption(s.tlsSecret, true, true),
+				rcertificatemanagement.NewKeyPairOption(s.linseedVoltronServerCert, true, true),
+				rcertificatemanagement.NewKeyPairOption(s.internalTrafficSecret, true, true),
+				rcertificatemanagement.NewKeyPairOption(s.tunnelServerCert, false, true),
+			},
+			TrustedBundle: s.bundleMaker,
+		}),
+	}
+
+	if s.tunnelSecretPassthrough != nil {
+		s.components = append(s.components, s.tunnelSecretPassthrough)
+	}
+	return nil, nil
+}
+
+// applyPhase creates, updates and deletes the objects of the rendered components.
+type applyPhase struct{}
+
+func (applyPhase) name() string { return "Apply" }
+
+func (applyPhase) run(ctx context.Context, r *ReconcileManager, s *reconcileState) (*reconcile.Result, error) {
+	// Create a component handler to manage the rendered component.
+	componentHandler := utils.NewComponentHandler(logging.ForReconcile(ctx, log), r.client, r.scheme, s.instance)
+	for _, component := range s.components {
+		if err := componentHandler.CreateOrUpdateOrDelete(ctx, component, r.status); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error creating / updating resource", err, s.logc)
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// statusPhase reports that the reconcile succeeded.
+type statusPhase struct{}
+
+func (statusPhase) name() string { return "Status" }
+
+func (statusPhase) run(ctx context.Context, r *ReconcileManager, s *reconcileState) (*reconcile.Result, error) {
+	// Clear the degraded bit if we've reached this far.
+	r.status.ClearDegraded()
+	s.instance.Status.State = operatorv1.TigeraStatusReady
+	if r.status.IsAvailable() {
+		if err := r.client.Status().Update(ctx, s.instance); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
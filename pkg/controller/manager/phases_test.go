@@ -0,0 +1,109 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/controller/status"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render"
+)
+
+// fakePhase records that it ran and returns the given result.
+type fakePhase struct {
+	ran    *[]string
+	id     string
+	result *reconcile.Result
+	err    error
+}
+
+func (p fakePhase) name() string { return p.id }
+
+func (p fakePhase) run(context.Context, *ReconcileManager, *reconcileState) (*reconcile.Result, error) {
+	*p.ran = append(*p.ran, p.id)
+	return p.result, p.err
+}
+
+var _ = Describe("Manager reconcile phases", func() {
+	var (
+		ctx context.Context
+		r   *ReconcileManager
+		s   *reconcileState
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		r = &ReconcileManager{
+			client: ctrlrfake.DefaultFakeClientBuilder(scheme).Build(),
+			scheme: scheme,
+			status: &status.MockStatus{},
+		}
+		s = &reconcileState{logc: log, instance: &operatorv1.Manager{}}
+	})
+
+	It("should run the phases in order and stop at the first that doesn't complete", func() {
+		var ran []string
+		result, err := r.runPhases(ctx, s,
+			fakePhase{ran: &ran, id: "a"},
+			fakePhase{ran: &ran, id: "b", result: stop(reconcile.Result{RequeueAfter: 5})},
+			fakePhase{ran: &ran, id: "c"},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeEquivalentTo(5))
+		Expect(ran).To(Equal([]string{"a", "b"}))
+
+		ran = nil
+		_, err = r.runPhases(ctx, s,
+			fakePhase{ran: &ran, id: "a", err: fmt.Errorf("failed")},
+			fakePhase{ran: &ran, id: "b"},
+		)
+		Expect(err).To(MatchError("failed"))
+		Expect(ran).To(Equal([]string{"a"}))
+	})
+
+	It("should not query Elasticsearch in a managed cluster", func() {
+		s.managementClusterConnection = &operatorv1.ManagementClusterConnection{}
+		result, err := dataStorePhase{}.run(ctx, r, s)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(s.elasticLicenseType).To(Equal(render.ElasticsearchLicenseTypeBasic))
+	})
+
+	It("should degrade and stop when a component can't be applied", func() {
+		mockStatus := &status.MockStatus{}
+		mockStatus.On("SetDegraded", operatorv1.ResourceUpdateError, "Error creating / updating resource", mock.Anything, mock.Anything).Return()
+		r.status = mockStatus
+
+		// An object without a name is rejected.
+		s.components = []render.Component{render.NewPassthrough(&operatorv1.ManagementCluster{})}
+		_, err := r.runPhases(ctx, s, applyPhase{}, statusPhase{})
+		Expect(err).To(HaveOccurred())
+		mockStatus.AssertExpectations(GinkgoT())
+		mockStatus.AssertNotCalled(GinkgoT(), "ClearDegraded")
+	})
+})
@@ -52,6 +52,7 @@ func (s *patches) Data(obj client.Object) ([]byte, error) {
 // to the assumed units to be attached to the value.
 var unitlessEnvVars = map[string]string{
 	"FELIX_IPTABLESREFRESHINTERVAL": "s",
+	"FELIX_FLOWLOGSFLUSHINTERVAL":   "s",
 }
 
 // handleFelixVars handles unexpected felix env vars (i.e. vars that start with FELIX_*) on the calico-node container
@@ -28,6 +28,7 @@ import (
 	"github.com/tigera/api/pkg/lib/numorstring"
 	"github.com/tigera/operator/pkg/apis"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
 
 	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
 )
@@ -204,6 +205,24 @@ var _ = Describe("felix env parser", func() {
 			Expect(f.Spec.IptablesRefreshInterval).To(Equal(&metav1.Duration{Duration: 20 * time.Second}))
 		})
 
+		It("sets flow log settings", func() {
+			c.node.Spec.Template.Spec.Containers[0].Env = []v1.EnvVar{
+				{Name: "FELIX_FLOWLOGSFLUSHINTERVAL", Value: "15"},
+				{Name: "FELIX_FLOWLOGSFILEENABLED", Value: "true"},
+				{Name: "FELIX_FLOWLOGSFILEAGGREGATIONKINDFORALLOWED", Value: "1"},
+				{Name: "FELIX_FLOWLOGSFILEINCLUDEPOLICIES", Value: "true"},
+			}
+
+			Expect(handleFelixVars(&c)).ToNot(HaveOccurred())
+
+			f := crdv1.FelixConfiguration{}
+			Expect(c.client.Get(ctx, types.NamespacedName{Name: "default"}, &f)).ToNot(HaveOccurred())
+			Expect(f.Spec.FlowLogsFlushInterval).To(Equal(&metav1.Duration{Duration: 15 * time.Second}))
+			Expect(f.Spec.FlowLogsFileEnabled).To(Equal(ptr.BoolToPtr(true)))
+			Expect(f.Spec.FlowLogsFileAggregationKindForAllowed).To(Equal(ptr.ToPtr(1)))
+			Expect(f.Spec.FlowLogsFileIncludePolicies).To(Equal(ptr.BoolToPtr(true)))
+		})
+
 		It("sets iptablesbackend", func() {
 			c.node.Spec.Template.Spec.Containers[0].Env = []v1.EnvVar{{
 				Name:  "FELIX_IPTABLESBACKEND",
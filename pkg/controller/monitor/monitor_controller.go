@@ -44,6 +44,7 @@ import (
 	"github.com/tigera/operator/pkg/controller/utils/imageset"
 	"github.com/tigera/operator/pkg/ctrlruntime"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render"
 	rcertificatemanagement "github.com/tigera/operator/pkg/render/certificatemanagement"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
@@ -91,13 +92,13 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	}
 
 	// Watch for changes to Tier, as its status is used as input to determine whether network policy should be reconciled by this controller.
-	go utils.WaitToAddTierWatch(networkpolicy.TigeraComponentTierName, c, k8sClient, log, tierWatchReady)
+	go utils.WaitToAddTierWatch(opts.ShutdownContext, networkpolicy.TigeraComponentTierName, c, k8sClient, log, tierWatchReady)
 
-	go utils.WaitToAddNetworkPolicyWatches(c, k8sClient, log, policyNames)
+	go utils.WaitToAddNetworkPolicyWatches(opts.ShutdownContext, c, k8sClient, log, policyNames)
 
-	go waitToAddPrometheusWatch(c, k8sClient, log, prometheusReady)
+	go waitToAddPrometheusWatch(opts.ShutdownContext, c, k8sClient, log, prometheusReady)
 
-	return add(mgr, c)
+	return add(mgr, c, opts)
 }
 
 func newReconciler(mgr manager.Manager, opts options.AddOptions, prometheusReady *utils.ReadyFlag, tierWatchReady *utils.ReadyFlag) reconcile.Reconciler {
@@ -121,11 +122,11 @@ func newReconciler(mgr manager.Manager, opts options.AddOptions, prometheusReady
 	return r
 }
 
-func add(_ manager.Manager, c ctrlruntime.Controller) error {
+func add(_ manager.Manager, c ctrlruntime.Controller, opts options.AddOptions) error {
 	var err error
 
 	// watch for primary resource changes
-	if err = c.WatchObject(&operatorv1.Monitor{}, &handler.EnqueueRequestForObject{}); err != nil {
+	if err = c.WatchObject(&operatorv1.Monitor{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("monitor-controller failed to watch primary resource: %w", err)
 	}
 
@@ -138,7 +139,7 @@ func add(_ manager.Manager, c ctrlruntime.Controller) error {
 	}
 
 	// ManagementClusterConnection (in addition to Installation/Network) is used as input to determine whether network policy should be reconciled.
-	err = c.WatchObject(&operatorv1.ManagementClusterConnection{}, &handler.EnqueueRequestForObject{})
+	err = c.WatchObject(&operatorv1.ManagementClusterConnection{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate)
 	if err != nil {
 		return fmt.Errorf("monitor-controller failed to watch ManagementClusterConnection resource: %w", err)
 	}
@@ -163,7 +164,7 @@ func add(_ manager.Manager, c ctrlruntime.Controller) error {
 		return fmt.Errorf("monitor-controller failed to watch resource: %w", err)
 	}
 
-	err = c.WatchObject(&operatorv1.Authentication{}, &handler.EnqueueRequestForObject{})
+	err = c.WatchObject(&operatorv1.Authentication{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate)
 	if err != nil {
 		return fmt.Errorf("monitor-controller failed to watch resource: %w", err)
 	}
@@ -173,6 +174,13 @@ func add(_ manager.Manager, c ctrlruntime.Controller) error {
 		return fmt.Errorf("monitor-controller failed to watch monitor Tigerastatus: %w", err)
 	}
 
+	if opts.MultiTenant {
+		// Each tenant gets its own Prometheus instance.
+		if err = c.WatchObject(&operatorv1.Tenant{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
+			return fmt.Errorf("monitor-controller failed to watch Tenant resource: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -201,7 +209,7 @@ func (r *ReconcileMonitor) getMonitor(ctx context.Context) (*operatorv1.Monitor,
 }
 
 func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling Monitor")
 
 	instance, err := r.getMonitor(ctx)
@@ -238,6 +246,10 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Failed to write defaults", err, reqLogger)
 		return reconcile.Result{}, err
 	}
+	if err = monitor.ValidateMetrics(instance.Spec.Metrics); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Invalid Monitor metrics", err, reqLogger)
+		return reconcile.Result{}, nil
+	}
 	if instance.Spec.ExternalPrometheus != nil {
 		if err = r.client.Get(ctx, client.ObjectKey{Name: instance.Spec.ExternalPrometheus.Namespace}, &corev1.Namespace{}); err != nil {
 			r.status.SetDegraded(operatorv1.ResourceReadError, fmt.Sprintf("Failed to get external prometheus namespace %s",
@@ -256,6 +268,10 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 		return reconcile.Result{}, err
 	}
 
+	if _, result, err := (utils.Prerequisites{UpgradeStage: operatorv1.UpgradeStageComponents}).Check(ctx, r.client, r.status, reqLogger); result != nil {
+		return *result, err
+	}
+
 	pullSecrets, err := utils.GetNetworkingPullSecrets(install, r.client)
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", err, reqLogger)
@@ -268,7 +284,7 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 		return reconcile.Result{}, err
 	}
 
-	certificateManager, err := certificatemanager.Create(r.client, install, r.clusterDomain, common.OperatorNamespace())
+	certificateManager, err := certificatemanager.Create(r.client, install, r.clusterDomain, common.OperatorNamespace(), certificatemanager.WithLogger(reqLogger))
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
 		return reconcile.Result{}, err
@@ -333,7 +349,7 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 
 	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
 	if !r.tierWatchReady.IsReady() {
-		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", nil, reqLogger)
+		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", r.tierWatchReady.Err(), reqLogger)
 		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
 	}
 
@@ -352,7 +368,7 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 	}
 
 	// Create a component handler to manage the rendered component.
-	hdler := utils.NewComponentHandler(log, r.client, r.scheme, instance)
+	hdler := utils.NewComponentHandler(logging.ForReconcile(ctx, log), r.client, r.scheme, instance)
 
 	alertmanagerConfigSecret, createInOperatorNamespace, err := r.readAlertmanagerConfigSecret(ctx)
 	if err != nil {
@@ -406,7 +422,31 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 		components = append(components, monitor.MonitorPolicy(monitorCfg))
 	}
 
-	if err = imageset.ApplyImageSet(ctx, r.client, variant, components...); err != nil {
+	// In multi-tenant mode, each tenant gets a Prometheus instance in its own namespace, so that tenants can't query
+	// each other's metrics.
+	var tenants []*operatorv1.Tenant
+	var tenantComponents []render.Component
+	if r.multiTenant {
+		tenantList := &operatorv1.TenantList{}
+		if err = r.client.List(ctx, tenantList); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying tenants", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		for i := range tenantList.Items {
+			tenant := &tenantList.Items[i]
+			if tenant.DeletionTimestamp != nil {
+				continue
+			}
+			tenants = append(tenants, tenant)
+			tenantComponents = append(tenantComponents, monitor.TenantPrometheus(&monitor.TenantPrometheusConfig{
+				Installation: install,
+				Tenant:       tenant,
+				PullSecrets:  pullSecrets,
+			}))
+		}
+	}
+
+	if err = imageset.ApplyImageSet(ctx, r.client, variant, append(tenantComponents, components...)...); err != nil {
 		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
 		return reconcile.Result{}, err
 	}
@@ -418,6 +458,14 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 		}
 	}
 
+	// The objects of a tenant are owned by the Tenant, so that they are removed along with it.
+	for i, component := range tenantComponents {
+		if err = utils.NewComponentHandler(logging.ForReconcile(ctx, log), r.client, r.scheme, tenants[i]).CreateOrUpdateOrDelete(ctx, component, r.status); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, fmt.Sprintf("Error creating / updating Prometheus for tenant %s", tenants[i].Name), err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
 	// Tell the status manager that we're ready to monitor the resources we've told it about and receive statuses.
 	r.status.ReadyToMonitor()
 
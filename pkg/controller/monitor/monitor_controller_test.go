@@ -158,6 +158,28 @@ var _ = Describe("Monitor controller tests", func() {
 			Expect(cli.Get(ctx, client.ObjectKey{Name: monitor.FluentdMetrics, Namespace: common.TigeraPrometheusNamespace}, sm)).NotTo(HaveOccurred())
 		})
 
+		It("should create a Prometheus instance for each tenant in multi-tenant mode", func() {
+			r.multiTenant = true
+			tenant := &operatorv1.Tenant{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "tenant-a"},
+				Spec:       operatorv1.TenantSpec{ID: "tenant-a"},
+			}
+			Expect(cli.Create(ctx, tenant)).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			// The cluster's own Prometheus is still rendered.
+			Expect(cli.Get(ctx, client.ObjectKey{Name: monitor.CalicoNodePrometheus, Namespace: common.TigeraPrometheusNamespace}, p)).NotTo(HaveOccurred())
+
+			// The tenant's Prometheus is owned by the Tenant.
+			tp := &monitoringv1.Prometheus{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: monitor.CalicoNodePrometheus, Namespace: "tenant-a"}, tp)).NotTo(HaveOccurred())
+			Expect(tp.OwnerReferences).To(HaveLen(1))
+			Expect(tp.OwnerReferences[0].Kind).To(Equal("Tenant"))
+			Expect(tp.Spec.IgnoreNamespaceSelectors).To(BeTrue())
+		})
+
 		It("should render allow-tigera policy when tier and policy watch are ready", func() {
 			_, err := r.Reconcile(ctx, reconcile.Request{})
 			Expect(err).ShouldNot(HaveOccurred())
@@ -199,6 +221,20 @@ var _ = Describe("Monitor controller tests", func() {
 			Expect(policies.Items).To(HaveLen(0))
 		})
 
+		It("should degrade and not render anything when a metric relabeling is invalid", func() {
+			mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Invalid Monitor metrics", mock.Anything, mock.Anything).Return()
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, monitorCR)).NotTo(HaveOccurred())
+			monitorCR.Spec.Metrics = &operatorv1.MonitorMetrics{
+				Relabelings: []operatorv1.MetricRelabeling{{Action: operatorv1.MetricRelabelingDrop, Regex: "felix_("}},
+			}
+			Expect(cli.Update(ctx, monitorCR)).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Invalid Monitor metrics", mock.Anything, mock.Anything)
+			Expect(cli.Get(ctx, client.ObjectKey{Name: monitor.CalicoNodeMonitor, Namespace: common.TigeraPrometheusNamespace}, sm)).To(HaveOccurred())
+		})
+
 		Context("controller reconciliation with external monitoring configuration", func() {
 			It("should create Prometheus related resources", func() {
 				Expect(r.client.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "external-prometheus"}})).NotTo(HaveOccurred())
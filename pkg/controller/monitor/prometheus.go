@@ -15,6 +15,7 @@
 package monitor
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -153,7 +154,7 @@ func requiresPrometheusResources(client kubernetes.Interface) error {
 	return nil
 }
 
-func waitToAddPrometheusWatch(c ctrlruntime.Controller, client kubernetes.Interface, log logr.Logger, readyFlag *utils.ReadyFlag) {
+func waitToAddPrometheusWatch(ctx context.Context, c ctrlruntime.Controller, client kubernetes.Interface, log logr.Logger, readyFlag *utils.ReadyFlag) {
 	const (
 		initBackoff = 30 * time.Second
 		maxBackoff  = 8 * time.Minute
@@ -162,7 +163,16 @@ func waitToAddPrometheusWatch(c ctrlruntime.Controller, client kubernetes.Interf
 	duration := initBackoff
 	ticker := time.NewTicker(duration)
 	defer ticker.Stop()
-	for range ticker.C {
+	apisChanged := utils.APIsChanged()
+	for {
+		// Retry straight away when an API becomes available, e.g., when the Prometheus operator is installed.
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-apisChanged:
+		}
+		apisChanged = utils.APIsChanged()
 		duration = duration * 2
 		if duration >= maxBackoff {
 			duration = maxBackoff
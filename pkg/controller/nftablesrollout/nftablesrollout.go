@@ -0,0 +1,338 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nftablesrollout rolls out a change of nftablesMode in the default FelixConfiguration to calico-node. The
+// mode is set in the environment of calico-node, which takes precedence over the FelixConfiguration, so a change
+// only reaches a node once its calico-node pod is replaced:
+//
+//   - The DaemonSet is switched to the OnDelete update strategy, and the pods of a batch of canary nodes are
+//     replaced. The rollout waits for them to become ready with the new mode.
+//   - The DaemonSet goes back to its own update strategy, which rolls the new mode out to the remaining nodes.
+//
+// If a pod that runs the new mode isn't ready within the health check timeout, calico-node is rolled back to the
+// previous mode. Since the DaemonSet then goes back to a template that it ran before, only the pods that were replaced
+// with the new mode are replaced again.
+package nftablesrollout
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"github.com/tigera/operator/pkg/common"
+)
+
+const (
+	// CanaryLabel marks the nodes that are picked as canaries before any other node.
+	CanaryLabel = "operator.tigera.io/nftables-canary"
+
+	// EnvVar is the variable of the calico-node container that sets the nftables mode of Felix.
+	EnvVar = "FELIX_NFTABLESMODE"
+
+	defaultCanaryNodes        = 1
+	defaultHealthCheckTimeout = 5 * time.Minute
+)
+
+// DesiredMode returns the nftables mode that the default FelixConfiguration asks for, or the default of Felix if it
+// doesn't set one.
+func DesiredMode(fc *crdv1.FelixConfiguration) string {
+	if fc == nil || fc.Spec.NFTablesMode == nil {
+		return string(crdv1.NFTablesModeDisabled)
+	}
+	return string(*fc.Spec.NFTablesMode)
+}
+
+// InProgress returns true if the status records a rollout that hasn't finished or been rolled back.
+func InProgress(status *operatorv1.NFTablesModeRolloutStatus) bool {
+	return status != nil && (status.Phase == operatorv1.NFTablesModeRolloutCanary || status.Phase == operatorv1.NFTablesModeRolloutRolling)
+}
+
+// Canary returns true while the canary pods are replaced. The DaemonSet must use the OnDelete update strategy until
+// then, so that it leaves the pods of the other nodes alone.
+func Canary(status *operatorv1.NFTablesModeRolloutStatus) bool {
+	return status != nil && status.Phase == operatorv1.NFTablesModeRolloutCanary
+}
+
+// RolledBack returns true if the status records a rollout that was rolled back.
+func RolledBack(status *operatorv1.NFTablesModeRolloutStatus) bool {
+	return status != nil && status.Phase == operatorv1.NFTablesModeRolloutRolledBack
+}
+
+// RenderedMode returns the nftables mode that calico-node is rendered with, or an empty string if there is no status
+// to take it from.
+func RenderedMode(status *operatorv1.NFTablesModeRolloutStatus) string {
+	if status == nil {
+		return ""
+	}
+	if status.Phase == operatorv1.NFTablesModeRolloutRolledBack {
+		return status.PreviousMode
+	}
+	return status.Mode
+}
+
+// Advance moves the rollout of the desired nftables mode forward and returns its status. A change of the desired mode
+// starts a new rollout. Pods and nodes aren't watched, so Advance must be called periodically while the rollout is in
+// progress.
+func Advance(ctx context.Context, c client.Client, cfg *operatorv1.NFTablesModeRollout, desired string, previous *operatorv1.NFTablesModeRolloutStatus, now time.Time) (*operatorv1.NFTablesModeRolloutStatus, error) {
+	ds := &appsv1.DaemonSet{}
+	if err := c.Get(ctx, types.NamespacedName{Name: common.NodeDaemonSetName, Namespace: common.CalicoNamespace}, ds); err != nil {
+		if apierrors.IsNotFound(err) {
+			// calico-node isn't running yet, so it can start with the desired mode.
+			return complete(desired), nil
+		}
+		return previous, err
+	}
+	if previous == nil {
+		// calico-node was rendered without the mode, which leaves Felix to take it from the FelixConfiguration.
+		return complete(desired), nil
+	}
+	if desired != previous.Mode {
+		return start(ctx, c, cfg, desired, previous)
+	}
+
+	status := previous.DeepCopy()
+	switch status.Phase {
+	case operatorv1.NFTablesModeRolloutCanary:
+		return status, advanceCanary(ctx, c, cfg, ds, status, now)
+	case operatorv1.NFTablesModeRolloutRolling:
+		return status, advanceRolling(ctx, c, cfg, ds, status, now)
+	}
+	return status, nil
+}
+
+func complete(mode string) *operatorv1.NFTablesModeRolloutStatus {
+	return &operatorv1.NFTablesModeRolloutStatus{Mode: mode, Phase: operatorv1.NFTablesModeRolloutComplete}
+}
+
+// start returns the status of a rollout of the desired mode, which starts with the canary nodes. A rollout that
+// returns to the mode that calico-node ran with before is not a change, and the DaemonSet rolls it out by itself.
+func start(ctx context.Context, c client.Client, cfg *operatorv1.NFTablesModeRollout, desired string, previous *operatorv1.NFTablesModeRolloutStatus) (*operatorv1.NFTablesModeRolloutStatus, error) {
+	from := RenderedMode(previous)
+	if InProgress(previous) {
+		from = previous.PreviousMode
+	}
+	if desired == from {
+		return complete(desired), nil
+	}
+
+	canaries, err := pickCanaries(ctx, c, cfg)
+	if err != nil {
+		return previous, err
+	}
+	return &operatorv1.NFTablesModeRolloutStatus{
+		Mode:         desired,
+		PreviousMode: from,
+		Phase:        operatorv1.NFTablesModeRolloutCanary,
+		CanaryNodes:  canaries,
+		Message:      "Waiting for the calico-node DaemonSet to be updated",
+	}, nil
+}
+
+// pickCanaries returns the nodes that run calico-node and that the new mode is rolled out to first. Labeled nodes are
+// picked first, and the others in order of their names.
+func pickCanaries(ctx context.Context, c client.Client, cfg *operatorv1.NFTablesModeRollout) ([]string, error) {
+	count := defaultCanaryNodes
+	if cfg != nil && cfg.CanaryNodes != nil {
+		count = int(*cfg.CanaryNodes)
+	}
+
+	pods, err := listPods(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	labeled := &corev1.NodeList{}
+	if err := c.List(ctx, labeled, client.MatchingLabels{CanaryLabel: "true"}); err != nil {
+		return nil, err
+	}
+	preferred := map[string]bool{}
+	for _, n := range labeled.Items {
+		preferred[n.Name] = true
+	}
+
+	var nodes []string
+	for _, pod := range pods {
+		nodes = append(nodes, pod.Spec.NodeName)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if preferred[nodes[i]] != preferred[nodes[j]] {
+			return preferred[nodes[i]]
+		}
+		return nodes[i] < nodes[j]
+	})
+	if len(nodes) > count {
+		nodes = nodes[:count]
+	}
+	return nodes, nil
+}
+
+// advanceCanary replaces the pods of the canary nodes once the DaemonSet has the new mode, and moves on to the other
+// nodes once they are all ready.
+func advanceCanary(ctx context.Context, c client.Client, cfg *operatorv1.NFTablesModeRollout, ds *appsv1.DaemonSet, status *operatorv1.NFTablesModeRolloutStatus, now time.Time) error {
+	if modeOf(&ds.Spec.Template.Spec) != status.Mode || ds.Spec.UpdateStrategy.Type != appsv1.OnDeleteDaemonSetStrategyType {
+		status.Message = "Waiting for the calico-node DaemonSet to be updated"
+		return nil
+	}
+	pods, err := listPods(ctx, c)
+	if err != nil {
+		return err
+	}
+	podOnNode := map[string]*corev1.Pod{}
+	for _, pod := range pods {
+		podOnNode[pod.Spec.NodeName] = pod
+	}
+
+	var waiting []string
+	for _, name := range status.CanaryNodes {
+		pod := podOnNode[name]
+		if pod == nil {
+			// The pod is being replaced, unless the node is gone.
+			err := c.Get(ctx, types.NamespacedName{Name: name}, &corev1.Node{})
+			if apierrors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			waiting = append(waiting, name)
+			continue
+		}
+		if modeOf(&pod.Spec) != status.Mode {
+			if err := deletePod(ctx, c, pod); err != nil {
+				return err
+			}
+			waiting = append(waiting, name)
+			continue
+		}
+		if failed(pod, cfg, now) {
+			rollBack(status, pod, cfg)
+			return nil
+		}
+		if !podReady(pod) {
+			waiting = append(waiting, name)
+		}
+	}
+	if len(waiting) > 0 {
+		status.Message = fmt.Sprintf("Waiting for calico-node to be ready with nftables mode %s on canary nodes %s", status.Mode, strings.Join(waiting, ", "))
+		return nil
+	}
+
+	status.Phase = operatorv1.NFTablesModeRolloutRolling
+	status.Message = fmt.Sprintf("Rolling out nftables mode %s to the remaining nodes", status.Mode)
+	return nil
+}
+
+// advanceRolling waits for every calico-node pod to be ready with the new mode, which the DaemonSet rolls out.
+func advanceRolling(ctx context.Context, c client.Client, cfg *operatorv1.NFTablesModeRollout, ds *appsv1.DaemonSet, status *operatorv1.NFTablesModeRolloutStatus, now time.Time) error {
+	pods, err := listPods(ctx, c)
+	if err != nil {
+		return err
+	}
+	var updated int
+	for _, pod := range pods {
+		if modeOf(&pod.Spec) != status.Mode {
+			continue
+		}
+		if failed(pod, cfg, now) {
+			rollBack(status, pod, cfg)
+			return nil
+		}
+		if podReady(pod) {
+			updated++
+		}
+	}
+	if updated < len(pods) || modeOf(&ds.Spec.Template.Spec) != status.Mode {
+		status.Message = fmt.Sprintf("Rolling out nftables mode %s (%d out of %d nodes ready)", status.Mode, updated, len(pods))
+		return nil
+	}
+
+	status.Phase = operatorv1.NFTablesModeRolloutComplete
+	status.Message = ""
+	return nil
+}
+
+func rollBack(status *operatorv1.NFTablesModeRolloutStatus, pod *corev1.Pod, cfg *operatorv1.NFTablesModeRollout) {
+	status.Phase = operatorv1.NFTablesModeRolloutRolledBack
+	status.Message = fmt.Sprintf("Rolled back to nftables mode %s: calico-node on node %s was not ready with nftables mode %s within %s",
+		status.PreviousMode, pod.Spec.NodeName, status.Mode, healthCheckTimeout(cfg))
+}
+
+// failed returns true if the pod hasn't become ready within the health check timeout.
+func failed(pod *corev1.Pod, cfg *operatorv1.NFTablesModeRollout, now time.Time) bool {
+	return pod.DeletionTimestamp == nil && !podReady(pod) && now.Sub(pod.CreationTimestamp.Time) > healthCheckTimeout(cfg)
+}
+
+func healthCheckTimeout(cfg *operatorv1.NFTablesModeRollout) time.Duration {
+	if cfg != nil && cfg.HealthCheckTimeout != nil {
+		return cfg.HealthCheckTimeout.Duration
+	}
+	return defaultHealthCheckTimeout
+}
+
+// modeOf returns the nftables mode that the calico-node container of the pod spec is set to, if any.
+func modeOf(spec *corev1.PodSpec) string {
+	for _, container := range spec.Containers {
+		if container.Name != common.NodeDaemonSetName {
+			continue
+		}
+		for _, env := range container.Env {
+			if env.Name == EnvVar {
+				return env.Value
+			}
+		}
+	}
+	return ""
+}
+
+// listPods returns the calico-node pods that are scheduled to a node.
+func listPods(ctx context.Context, c client.Client) ([]*corev1.Pod, error) {
+	list := &corev1.PodList{}
+	if err := c.List(ctx, list, client.InNamespace(common.CalicoNamespace), client.MatchingLabels{"k8s-app": common.NodeDaemonSetName}); err != nil {
+		return nil, err
+	}
+	var pods []*corev1.Pod
+	for i := range list.Items {
+		if list.Items[i].Spec.NodeName != "" {
+			pods = append(pods, &list.Items[i])
+		}
+	}
+	return pods, nil
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func deletePod(ctx context.Context, c client.Client, pod *corev1.Pod) error {
+	if pod.DeletionTimestamp != nil {
+		return nil
+	}
+	if err := c.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete calico-node pod %s: %w", pod.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nftablesrollout_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/nftablesrollout"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+var _ = Describe("nftables mode rollout tests", func() {
+	var (
+		c   client.Client
+		ctx context.Context
+		cfg *operatorv1.NFTablesModeRollout
+		now time.Time
+	)
+
+	nodeSpec := func(mode string) corev1.PodSpec {
+		return corev1.PodSpec{Containers: []corev1.Container{{
+			Name: common.NodeDaemonSetName,
+			Env:  []corev1.EnvVar{{Name: nftablesrollout.EnvVar, Value: mode}},
+		}}}
+	}
+
+	createPod := func(node, mode string, ready bool) {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		spec := nodeSpec(mode)
+		spec.NodeName = node
+		Expect(c.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "calico-node-" + node,
+				Namespace:         common.CalicoNamespace,
+				Labels:            map[string]string{"k8s-app": common.NodeDaemonSetName},
+				CreationTimestamp: metav1.NewTime(now),
+			},
+			Spec:   spec,
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}},
+		})).NotTo(HaveOccurred())
+	}
+
+	replacePod := func(node, mode string, ready bool) {
+		_ = c.Delete(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "calico-node-" + node, Namespace: common.CalicoNamespace}})
+		createPod(node, mode, ready)
+	}
+
+	podExists := func(node string) bool {
+		err := c.Get(ctx, types.NamespacedName{Name: "calico-node-" + node, Namespace: common.CalicoNamespace}, &corev1.Pod{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return true
+	}
+
+	// renderDaemonSet updates the DaemonSet as the core controller renders it for the status.
+	renderDaemonSet := func(status *operatorv1.NFTablesModeRolloutStatus) {
+		ds := &appsv1.DaemonSet{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: common.NodeDaemonSetName, Namespace: common.CalicoNamespace}, ds)).NotTo(HaveOccurred())
+		ds.Spec.Template.Spec = nodeSpec(nftablesrollout.RenderedMode(status))
+		ds.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{Type: appsv1.RollingUpdateDaemonSetStrategyType}
+		if nftablesrollout.Canary(status) {
+			ds.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType}
+		}
+		Expect(c.Update(ctx, ds)).NotTo(HaveOccurred())
+	}
+
+	complete := &operatorv1.NFTablesModeRolloutStatus{Mode: "Disabled", Phase: operatorv1.NFTablesModeRolloutComplete}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(appsv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(corev1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+		cfg = nil
+		now = time.Now()
+
+		Expect(c.Create(ctx, &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: common.NodeDaemonSetName, Namespace: common.CalicoNamespace},
+			Spec:       appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{Spec: nodeSpec("Disabled")}},
+		})).NotTo(HaveOccurred())
+		for _, name := range []string{"node-a", "node-b", "node-c"} {
+			Expect(c.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})).NotTo(HaveOccurred())
+			createPod(name, "Disabled", true)
+		}
+	})
+
+	It("should default to the mode of Felix", func() {
+		Expect(nftablesrollout.DesiredMode(&crdv1.FelixConfiguration{})).To(Equal("Disabled"))
+		enabled := crdv1.NFTablesModeEnabled
+		Expect(nftablesrollout.DesiredMode(&crdv1.FelixConfiguration{Spec: crdv1.FelixConfigurationSpec{NFTablesMode: &enabled}})).To(Equal("Enabled"))
+	})
+
+	It("should take the mode from the FelixConfiguration when there is no rollout to continue", func() {
+		status, err := nftablesrollout.Advance(ctx, c, cfg, "Enabled", nil, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(&operatorv1.NFTablesModeRolloutStatus{Mode: "Enabled", Phase: operatorv1.NFTablesModeRolloutComplete}))
+
+		Expect(c.Delete(ctx, &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: common.NodeDaemonSetName, Namespace: common.CalicoNamespace}})).NotTo(HaveOccurred())
+		status, err = nftablesrollout.Advance(ctx, c, cfg, "Enabled", complete, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(&operatorv1.NFTablesModeRolloutStatus{Mode: "Enabled", Phase: operatorv1.NFTablesModeRolloutComplete}))
+	})
+
+	It("should roll out a new mode to the canary nodes first", func() {
+		status, err := nftablesrollout.Advance(ctx, c, cfg, "Enabled", complete, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Phase).To(Equal(operatorv1.NFTablesModeRolloutCanary))
+		Expect(status.PreviousMode).To(Equal("Disabled"))
+		Expect(status.CanaryNodes).To(Equal([]string{"node-a"}))
+		Expect(nftablesrollout.InProgress(status)).To(BeTrue())
+		Expect(nftablesrollout.RenderedMode(status)).To(Equal("Enabled"))
+
+		// No pod is replaced until the DaemonSet has the new mode.
+		status, err = nftablesrollout.Advance(ctx, c, cfg, "Enabled", status, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Message).To(Equal("Waiting for the calico-node DaemonSet to be updated"))
+		Expect(podExists("node-a")).To(BeTrue())
+
+		renderDaemonSet(status)
+		status, err = nftablesrollout.Advance(ctx, c, cfg, "Enabled", status, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(podExists("node-a")).To(BeFalse())
+		Expect(podExists("node-b")).To(BeTrue())
+		Expect(status.Message).To(ContainSubstring("on canary nodes node-a"))
+
+		createPod("node-a", "Enabled", false)
+		status, err = nftablesrollout.Advance(ctx, c, cfg, "Enabled", status, now.Add(time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Phase).To(Equal(operatorv1.NFTablesModeRolloutCanary))
+
+		replacePod("node-a", "Enabled", true)
+		status, err = nftablesrollout.Advance(ctx, c, cfg, "Enabled", status, now.Add(time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Phase).To(Equal(operatorv1.NFTablesModeRolloutRolling))
+		Expect(nftablesrollout.Canary(status)).To(BeFalse())
+
+		renderDaemonSet(status)
+		replacePod("node-b", "Enabled", true)
+		status, err = nftablesrollout.Advance(ctx, c, cfg, "Enabled", status, now.Add(time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Phase).To(Equal(operatorv1.NFTablesModeRolloutRolling))
+		Expect(status.Message).To(ContainSubstring("2 out of 3 nodes ready"))
+
+		replacePod("node-c", "Enabled", true)
+		status, err = nftablesrollout.Advance(ctx, c, cfg, "Enabled", status, now.Add(time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Phase).To(Equal(operatorv1.NFTablesModeRolloutComplete))
+		Expect(nftablesrollout.InProgress(status)).To(BeFalse())
+	})
+
+	It("should pick labeled nodes as canaries first", func() {
+		two := int32(2)
+		cfg = &operatorv1.NFTablesModeRollout{CanaryNodes: &two}
+		node := &corev1.Node{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: "node-c"}, node)).NotTo(HaveOccurred())
+		node.Labels = map[string]string{nftablesrollout.CanaryLabel: "true"}
+		Expect(c.Update(ctx, node)).NotTo(HaveOccurred())
+
+		status, err := nftablesrollout.Advance(ctx, c, cfg, "Enabled", complete, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.CanaryNodes).To(Equal([]string{"node-c", "node-a"}))
+	})
+
+	It("should roll back when a canary isn't ready in time", func() {
+		cfg = &operatorv1.NFTablesModeRollout{HealthCheckTimeout: &metav1.Duration{Duration: 2 * time.Minute}}
+		status, err := nftablesrollout.Advance(ctx, c, cfg, "Enabled", complete, now)
+		Expect(err).NotTo(HaveOccurred())
+		renderDaemonSet(status)
+		status, err = nftablesrollout.Advance(ctx, c, cfg, "Enabled", status, now)
+		Expect(err).NotTo(HaveOccurred())
+		createPod("node-a", "Enabled", false)
+
+		status, err = nftablesrollout.Advance(ctx, c, cfg, "Enabled", status, now.Add(3*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Phase).To(Equal(operatorv1.NFTablesModeRolloutRolledBack))
+		Expect(status.Message).To(Equal("Rolled back to nftables mode Disabled: calico-node on node node-a was not ready with nftables mode Enabled within 2m0s"))
+		Expect(nftablesrollout.RolledBack(status)).To(BeTrue())
+		Expect(nftablesrollout.RenderedMode(status)).To(Equal("Disabled"))
+
+		// It stays rolled back until the mode is changed again.
+		status, err = nftablesrollout.Advance(ctx, c, cfg, "Enabled", status, now.Add(4*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Phase).To(Equal(operatorv1.NFTablesModeRolloutRolledBack))
+
+		status, err = nftablesrollout.Advance(ctx, c, cfg, "Disabled", status, now.Add(4*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(complete))
+	})
+
+	It("should roll back when a node isn't ready in time after the canaries", func() {
+		status := &operatorv1.NFTablesModeRolloutStatus{Mode: "Enabled", PreviousMode: "Disabled", Phase: operatorv1.NFTablesModeRolloutRolling, CanaryNodes: []string{"node-a"}}
+		renderDaemonSet(status)
+		replacePod("node-a", "Enabled", true)
+		replacePod("node-b", "Enabled", false)
+
+		status, err := nftablesrollout.Advance(ctx, c, cfg, "Enabled", status, now.Add(6*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Phase).To(Equal(operatorv1.NFTablesModeRolloutRolledBack))
+		Expect(status.Message).To(ContainSubstring("calico-node on node node-b"))
+	})
+
+	It("should go back to the previous mode when the change is reverted during the rollout", func() {
+		status, err := nftablesrollout.Advance(ctx, c, cfg, "Enabled", complete, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nftablesrollout.InProgress(status)).To(BeTrue())
+
+		status, err = nftablesrollout.Advance(ctx, c, cfg, "Disabled", status, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(complete))
+	})
+})
@@ -0,0 +1,229 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package noderollout rolls out the calico-node DaemonSet one node at a time. The DaemonSet uses the OnDelete update
+// strategy, and the operator cordons a node, deletes its outdated calico-node pod and waits for the replacement to be
+// ready, and for the node to report the configured conditions, before it uncordons the node and moves on.
+package noderollout
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+)
+
+const (
+	// CordonedAnnotation is set on a node that the operator cordoned for the rollout, so that only nodes that the
+	// operator cordoned are uncordoned again.
+	CordonedAnnotation = "operator.tigera.io/node-rollout-cordoned"
+
+	// templateGenerationAnnotation is set by the API server on DaemonSets, with a generation that only goes up when the
+	// pod template changes. The generation of the DaemonSet itself also goes up when the rest of its spec changes, such
+	// as its update strategy.
+	templateGenerationAnnotation = "deprecated.daemonset.template.generation"
+
+	// podTemplateGenerationLabel is set by the DaemonSet controller on its pods, with the template generation of the
+	// DaemonSet that they were created from.
+	podTemplateGenerationLabel = "pod-template-generation"
+)
+
+// InProgress returns true if the status records a rollout that hasn't reached every node.
+func InProgress(status *operatorv1.NodeRolloutStatus) bool {
+	return status != nil && (status.CurrentNode != "" || status.UpdatedNodes < status.TotalNodes)
+}
+
+// Advance moves the rollout of the calico-node DaemonSet forward and returns its status. It finishes with the node
+// recorded as current in the previous status before it starts on the next one. If cfg is nil there is no coordinated
+// rollout, and the node that was current is uncordoned.
+func Advance(ctx context.Context, c client.Client, cfg *operatorv1.CoordinatedNodeRollout, previous *operatorv1.NodeRolloutStatus) (*operatorv1.NodeRolloutStatus, error) {
+	current := ""
+	if previous != nil {
+		current = previous.CurrentNode
+	}
+	if cfg == nil {
+		if current != "" {
+			if err := uncordon(ctx, c, current); err != nil {
+				return previous, err
+			}
+		}
+		return nil, nil
+	}
+
+	ds := &appsv1.DaemonSet{}
+	if err := c.Get(ctx, types.NamespacedName{Name: common.NodeDaemonSetName, Namespace: common.CalicoNamespace}, ds); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return previous, err
+	}
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(common.CalicoNamespace), client.MatchingLabels{"k8s-app": common.NodeDaemonSetName}); err != nil {
+		return previous, err
+	}
+
+	generation := ds.Annotations[templateGenerationAnnotation]
+	templateGeneration, err := strconv.ParseInt(generation, 10, 64)
+	if err != nil {
+		return previous, fmt.Errorf("calico-node DaemonSet has no valid %s annotation: %w", templateGenerationAnnotation, err)
+	}
+	status := &operatorv1.NodeRolloutStatus{Generation: templateGeneration}
+	podOnNode := map[string]*corev1.Pod{}
+	var outdated []string
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		podOnNode[pod.Spec.NodeName] = pod
+		status.TotalNodes++
+		if pod.Labels[podTemplateGenerationLabel] == generation {
+			status.UpdatedNodes++
+		} else {
+			outdated = append(outdated, pod.Spec.NodeName)
+		}
+	}
+	sort.Strings(outdated)
+
+	if current != "" {
+		node := &corev1.Node{}
+		err := c.Get(ctx, types.NamespacedName{Name: current}, node)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return previous, err
+		}
+		if err == nil {
+			status.CurrentNode = current
+			pod := podOnNode[current]
+			if pod == nil || pod.Labels[podTemplateGenerationLabel] != generation {
+				if err := deletePod(ctx, c, pod); err != nil {
+					return status, err
+				}
+				status.Message = fmt.Sprintf("Replacing the calico-node pod on node %s", current)
+				return status, nil
+			}
+			if waiting := notReady(node, pod, cfg); waiting != "" {
+				status.Message = fmt.Sprintf("Waiting for node %s: %s", current, waiting)
+				return status, nil
+			}
+			if err := uncordon(ctx, c, current); err != nil {
+				return status, err
+			}
+			status.CurrentNode = ""
+		}
+	}
+
+	if len(outdated) == 0 {
+		return status, nil
+	}
+	next := outdated[0]
+	if err := cordon(ctx, c, next); err != nil {
+		return status, err
+	}
+	status.CurrentNode = next
+	if err := deletePod(ctx, c, podOnNode[next]); err != nil {
+		return status, err
+	}
+	status.Message = fmt.Sprintf("Replacing the calico-node pod on node %s", next)
+	return status, nil
+}
+
+// notReady returns what the node is waiting for before the rollout can move on, or an empty string if it's ready.
+func notReady(node *corev1.Node, pod *corev1.Pod, cfg *operatorv1.CoordinatedNodeRollout) string {
+	if !conditionTrue(pod.Status.Conditions, corev1.PodReady) {
+		return "the calico-node pod is not ready"
+	}
+	for _, t := range cfg.ReadinessConditions {
+		ready := false
+		for _, cond := range node.Status.Conditions {
+			if cond.Type == t && cond.Status == corev1.ConditionTrue {
+				ready = true
+			}
+		}
+		if !ready {
+			return fmt.Sprintf("condition %s is not True", t)
+		}
+	}
+	return ""
+}
+
+func conditionTrue(conditions []corev1.PodCondition, t corev1.PodConditionType) bool {
+	for _, cond := range conditions {
+		if cond.Type == t {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func deletePod(ctx context.Context, c client.Client, pod *corev1.Pod) error {
+	if pod == nil || pod.DeletionTimestamp != nil {
+		return nil
+	}
+	if err := c.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete calico-node pod %s: %w", pod.Name, err)
+	}
+	return nil
+}
+
+// cordon marks the node unschedulable. A node that is already unschedulable is left as it is, so that it isn't
+// uncordoned when the rollout moves on.
+func cordon(ctx context.Context, c client.Client, name string) error {
+	node := &corev1.Node{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, node); err != nil {
+		return err
+	}
+	if node.Spec.Unschedulable {
+		return nil
+	}
+	patchFrom := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = true
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+	node.Annotations[CordonedAnnotation] = "true"
+	if err := c.Patch(ctx, node, patchFrom); err != nil {
+		return fmt.Errorf("failed to cordon node %s: %w", name, err)
+	}
+	return nil
+}
+
+// uncordon marks the node schedulable again, if the operator cordoned it.
+func uncordon(ctx context.Context, c client.Client, name string) error {
+	node := &corev1.Node{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if _, ok := node.Annotations[CordonedAnnotation]; !ok {
+		return nil
+	}
+	patchFrom := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = false
+	delete(node.Annotations, CordonedAnnotation)
+	if err := c.Patch(ctx, node, patchFrom); err != nil {
+		return fmt.Errorf("failed to uncordon node %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noderollout_test
+
+import (
+	"context"
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/noderollout"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+var _ = Describe("Coordinated node rollout tests", func() {
+	var (
+		c   client.Client
+		ctx context.Context
+		cfg *operatorv1.CoordinatedNodeRollout
+	)
+
+	const networkReady = corev1.NodeConditionType("NetworkVerified")
+
+	createPod := func(node string, generation int64, ready bool) {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		Expect(c.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "calico-node-" + node,
+				Namespace: common.CalicoNamespace,
+				Labels:    map[string]string{"k8s-app": common.NodeDaemonSetName, "pod-template-generation": strconv.FormatInt(generation, 10)},
+			},
+			Spec:   corev1.PodSpec{NodeName: node},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}},
+		})).NotTo(HaveOccurred())
+	}
+
+	getNode := func(name string) *corev1.Node {
+		node := &corev1.Node{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: name}, node)).NotTo(HaveOccurred())
+		return node
+	}
+
+	podExists := func(node string) bool {
+		err := c.Get(ctx, types.NamespacedName{Name: "calico-node-" + node, Namespace: common.CalicoNamespace}, &corev1.Pod{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return true
+	}
+
+	setNetworkReady := func(name string) {
+		node := getNode(name)
+		node.Status.Conditions = []corev1.NodeCondition{{Type: networkReady, Status: corev1.ConditionTrue}}
+		Expect(c.Status().Update(ctx, node)).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(appsv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(corev1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+		cfg = &operatorv1.CoordinatedNodeRollout{ReadinessConditions: []corev1.NodeConditionType{networkReady}}
+
+		// The generation of the DaemonSet is ahead of its template generation, as it is once its update strategy has
+		// changed, and the pods are labeled with the template generation that they were created from.
+		ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+			Name:        common.NodeDaemonSetName,
+			Namespace:   common.CalicoNamespace,
+			Annotations: map[string]string{"deprecated.daemonset.template.generation": "2"},
+		}}
+		Expect(c.Create(ctx, ds)).NotTo(HaveOccurred())
+		ds.Generation = 5
+		Expect(c.Update(ctx, ds)).NotTo(HaveOccurred())
+
+		for _, name := range []string{"node-a", "node-b"} {
+			Expect(c.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})).NotTo(HaveOccurred())
+			createPod(name, 1, true)
+		}
+	})
+
+	It("should replace the pods one node at a time", func() {
+		const generation = 2
+
+		status, err := noderollout.Advance(ctx, c, cfg, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.CurrentNode).To(Equal("node-a"))
+		Expect(status.TotalNodes).To(Equal(int32(2)))
+		Expect(status.UpdatedNodes).To(Equal(int32(0)))
+		Expect(getNode("node-a").Spec.Unschedulable).To(BeTrue())
+		Expect(podExists("node-a")).To(BeFalse())
+		Expect(podExists("node-b")).To(BeTrue())
+		Expect(noderollout.InProgress(status)).To(BeTrue())
+
+		// The node is held until the replacement pod is ready and the node reports the readiness condition.
+		createPod("node-a", generation, false)
+		status, err = noderollout.Advance(ctx, c, cfg, status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.CurrentNode).To(Equal("node-a"))
+		Expect(status.Message).To(ContainSubstring("the calico-node pod is not ready"))
+
+		Expect(c.Delete(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "calico-node-node-a", Namespace: common.CalicoNamespace}})).NotTo(HaveOccurred())
+		createPod("node-a", generation, true)
+		status, err = noderollout.Advance(ctx, c, cfg, status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Message).To(ContainSubstring("condition NetworkVerified is not True"))
+		Expect(podExists("node-b")).To(BeTrue())
+
+		setNetworkReady("node-a")
+		status, err = noderollout.Advance(ctx, c, cfg, status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(getNode("node-a").Spec.Unschedulable).To(BeFalse())
+		Expect(getNode("node-a").Annotations).NotTo(HaveKey(noderollout.CordonedAnnotation))
+		Expect(status.CurrentNode).To(Equal("node-b"))
+		Expect(status.UpdatedNodes).To(Equal(int32(1)))
+		Expect(getNode("node-b").Spec.Unschedulable).To(BeTrue())
+		Expect(podExists("node-b")).To(BeFalse())
+
+		createPod("node-b", generation, true)
+		setNetworkReady("node-b")
+		status, err = noderollout.Advance(ctx, c, cfg, status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.CurrentNode).To(BeEmpty())
+		Expect(status.UpdatedNodes).To(Equal(int32(2)))
+		Expect(getNode("node-b").Spec.Unschedulable).To(BeFalse())
+		Expect(noderollout.InProgress(status)).To(BeFalse())
+	})
+
+	It("should leave the pods alone when only the rest of the spec of the DaemonSet changed", func() {
+		for _, name := range []string{"node-a", "node-b"} {
+			Expect(c.Delete(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "calico-node-" + name, Namespace: common.CalicoNamespace}})).NotTo(HaveOccurred())
+			createPod(name, 2, true)
+		}
+
+		status, err := noderollout.Advance(ctx, c, cfg, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Generation).To(Equal(int64(2)))
+		Expect(status.CurrentNode).To(BeEmpty())
+		Expect(status.UpdatedNodes).To(Equal(int32(2)))
+		Expect(getNode("node-a").Spec.Unschedulable).To(BeFalse())
+		Expect(podExists("node-a")).To(BeTrue())
+		Expect(noderollout.InProgress(status)).To(BeFalse())
+	})
+
+	It("should leave nodes that were already cordoned cordoned", func() {
+		node := getNode("node-a")
+		node.Spec.Unschedulable = true
+		Expect(c.Update(ctx, node)).NotTo(HaveOccurred())
+
+		status, err := noderollout.Advance(ctx, c, cfg, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.CurrentNode).To(Equal("node-a"))
+		Expect(getNode("node-a").Annotations).NotTo(HaveKey(noderollout.CordonedAnnotation))
+
+		// Turning off the coordinated rollout doesn't uncordon it either.
+		status, err = noderollout.Advance(ctx, c, nil, status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(BeNil())
+		Expect(getNode("node-a").Spec.Unschedulable).To(BeTrue())
+	})
+
+	It("should uncordon the current node when the coordinated rollout is turned off", func() {
+		status, err := noderollout.Advance(ctx, c, cfg, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(getNode("node-a").Spec.Unschedulable).To(BeTrue())
+
+		status, err = noderollout.Advance(ctx, c, nil, status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(BeNil())
+		Expect(getNode("node-a").Spec.Unschedulable).To(BeFalse())
+	})
+})
@@ -0,0 +1,109 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operatorconfig
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/logging"
+	"github.com/tigera/operator/pkg/tracing"
+)
+
+const ControllerName = "operatorconfig-controller"
+
+var log = logf.Log.WithName("controller_operatorconfig")
+
+// Add creates a controller that configures the operator from the OperatorConfig, and adds it to the Manager.
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	r := &ReconcileOperatorConfig{
+		client:                  mgr.GetClient(),
+		maxConcurrentReconciles: opts.MaxConcurrentReconciles,
+		restart:                 func() { os.Exit(0) },
+	}
+
+	c, err := ctrlruntime.NewController(ControllerName, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", ControllerName, err)
+	}
+
+	isDefault := predicate.NewPredicateFuncs(func(o client.Object) bool { return o.GetName() == utils.DefaultInstanceKey.Name })
+	if err = c.WatchObject(&operatorv1.OperatorConfig{}, &handler.EnqueueRequestForObject{}, isDefault); err != nil {
+		return fmt.Errorf("%s failed to watch OperatorConfig resource: %w", ControllerName, err)
+	}
+	return nil
+}
+
+// blank assignment to verify that ReconcileOperatorConfig implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileOperatorConfig{}
+
+// ReconcileOperatorConfig applies the configuration of the operator itself. The components that are configured by
+// the OperatorConfig are reconciled by their own controllers.
+type ReconcileOperatorConfig struct {
+	client client.Client
+
+	// maxConcurrentReconciles is the concurrency that the controllers were created with. The operator is restarted
+	// when the OperatorConfig changes it, because the concurrency of a controller is fixed once it is created.
+	maxConcurrentReconciles map[string]int
+	restart                 func()
+}
+
+func (r *ReconcileOperatorConfig) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Name", request.Name)
+	reqLogger.Info("Reconciling OperatorConfig")
+
+	var tracingCfg *operatorv1.OperatorTracing
+	oc, err := utils.GetOperatorConfig(ctx, r.client)
+	if err != nil && !errors.IsNotFound(err) {
+		reqLogger.Error(err, "Error querying OperatorConfig")
+		return reconcile.Result{}, err
+	} else if err == nil {
+		tracingCfg = oc.Spec.Tracing
+	} else {
+		oc = nil
+	}
+
+	if concurrency := utils.MaxConcurrentReconciles(oc); !reflect.DeepEqual(concurrency, r.maxConcurrentReconciles) {
+		reqLogger.Info("detected controller concurrency change. rebooting")
+		r.restart()
+		return reconcile.Result{}, nil
+	}
+
+	if err = tracing.Validate(tracingCfg); err != nil {
+		// Retrying won't help, the OperatorConfig has to change first.
+		reqLogger.Error(err, "Invalid OperatorConfig, leaving tracing as it is")
+		return reconcile.Result{}, nil
+	}
+	if err = tracing.Configure(ctx, tracingCfg); err != nil {
+		reqLogger.Error(err, "Error configuring tracing")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operatorconfig
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
+	"github.com/tigera/operator/pkg/tracing"
+)
+
+var _ = Describe("OperatorConfig controller tests", func() {
+	var (
+		cli      client.Client
+		ctx      context.Context
+		r        ReconcileOperatorConfig
+		restarts int
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		ctx = context.Background()
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		restarts = 0
+		r = ReconcileOperatorConfig{client: cli, restart: func() { restarts++ }}
+	})
+
+	AfterEach(func() {
+		Expect(tracing.Shutdown(ctx)).NotTo(HaveOccurred())
+	})
+
+	// tracingEnabled reports whether the spans that are started are recorded for export. The span isn't ended, so that it
+	// isn't exported.
+	tracingEnabled := func() bool {
+		_, span := tracing.Start(ctx, "test")
+		return span.IsRecording()
+	}
+
+	create := func(endpoint string) {
+		Expect(cli.Create(ctx, &operatorv1.OperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: operatorv1.OperatorConfigSpec{
+				Tracing: &operatorv1.OperatorTracing{Endpoint: endpoint},
+			},
+		})).NotTo(HaveOccurred())
+	}
+
+	It("leaves tracing disabled without an OperatorConfig", func() {
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(tracingEnabled()).To(BeFalse())
+	})
+
+	It("enables tracing as configured, and disables it when the OperatorConfig is deleted", func() {
+		create("http://collector.observability:4318")
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(tracingEnabled()).To(BeTrue())
+
+		Expect(cli.Delete(ctx, &operatorv1.OperatorConfig{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).NotTo(HaveOccurred())
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(tracingEnabled()).To(BeFalse())
+	})
+
+	It("doesn't retry an invalid OperatorConfig", func() {
+		create("collector:4318")
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(tracingEnabled()).To(BeFalse())
+	})
+
+	It("restarts the operator when the controller concurrency changes", func() {
+		Expect(cli.Create(ctx, &operatorv1.OperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: operatorv1.OperatorConfigSpec{
+				Controllers: []operatorv1.OperatorControllerConfig{
+					{Name: "manager-controller", MaxConcurrentReconciles: ptr.Int32ToPtr(4)},
+				},
+			},
+		})).NotTo(HaveOccurred())
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(restarts).To(Equal(1))
+
+		// Nothing changed since the operator started with the same concurrency.
+		r.maxConcurrentReconciles = map[string]int{"manager-controller": 4}
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(restarts).To(Equal(1))
+	})
+})
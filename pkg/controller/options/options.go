@@ -42,4 +42,8 @@ type AddOptions struct {
 	// use external elasticsearch. When set, the operator will not install Elasticsearch
 	// and instead will configure the cluster to use an external Elasticsearch.
 	ElasticExternal bool
+
+	// MaxConcurrentReconciles is the maximum number of concurrent reconciles, by controller name, as configured
+	// in the OperatorConfig when the operator started. Controllers that are not listed reconcile one request at a time.
+	MaxConcurrentReconciles map[string]int
 }
@@ -0,0 +1,267 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package preflight checks the node prerequisites of the features configured in the Installation. The checks run in
+// the short-lived calico-preflight DaemonSet, whose pods report their failures in the termination message of their
+// init container. The results of every node are gathered into the Installation status, and the DaemonSet is removed
+// once every node has reported.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/render"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+)
+
+// RecheckInterval is how long after a run with failures the checks are run again, to pick up fixes on the nodes.
+const RecheckInterval = 10 * time.Minute
+
+// Checks returns the checks for the features configured in the Installation. The ports that calico-node and Typha
+// listen on are only checked in Enforce mode before calico-node is first installed, since calico-node holds them
+// back until the checks have passed then. Otherwise calico-node would be listening on them by the time they're checked.
+func Checks(installation *operatorv1.InstallationSpec, nodeInstalled bool, felixHealthPort int) []string {
+	checks := []string{render.PreflightCheckWireGuard, render.PreflightCheckRPFilter}
+	if installation.BPFEnabled() {
+		checks = append(checks, render.PreflightCheckBPFKernel)
+	}
+	if enforced(installation.Preflight) && !nodeInstalled {
+		typhaPort := render.TyphaPort
+		if installation.TyphaPort != nil {
+			typhaPort = *installation.TyphaPort
+		}
+		checks = append(checks,
+			render.PreflightPortCheck(int32(felixHealthPort), "tcp"),
+			render.PreflightPortCheck(typhaPort, "tcp"),
+		)
+		if installation.CalicoNetwork != nil && installation.CalicoNetwork.BGP != nil && *installation.CalicoNetwork.BGP == operatorv1.BGPEnabled {
+			checks = append(checks, render.PreflightPortCheck(179, "tcp"))
+		}
+	}
+	sort.Strings(checks)
+	return checks
+}
+
+// Reconcile returns the preflight status of the Installation and the configuration of the DaemonSet that runs the
+// checks. The checks are run again when they no longer cover the checks that the Installation needs, or when the
+// previous run had failures and completed more than RecheckInterval ago. Checks that are no longer needed, such as
+// those of the ports once calico-node is installed, don't cause another run.
+func Reconcile(ctx context.Context, c client.Client, installation *operatorv1.Installation, felixHealthPort int, now time.Time) (*operatorv1.PreflightStatus, *render.PreflightConfiguration, error) {
+	cfg := &render.PreflightConfiguration{Installation: &installation.Spec, Done: true}
+	if installation.Spec.Preflight == nil {
+		return nil, cfg, nil
+	}
+
+	nodeInstalled := true
+	if err := c.Get(ctx, types.NamespacedName{Name: common.NodeDaemonSetName, Namespace: common.CalicoNamespace}, &appsv1.DaemonSet{}); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return installation.Status.Preflight, nil, err
+		}
+		nodeInstalled = false
+	}
+
+	checks := Checks(&installation.Spec, nodeInstalled, felixHealthPort)
+	status := installation.Status.Preflight.DeepCopy()
+	if status == nil || !covers(status.Checks, checks) || recheckDue(status, now) {
+		// The hash includes the start of the run, so that pods of a previous run with the same checks are ignored.
+		status = &operatorv1.PreflightStatus{Checks: checks, ChecksHash: rmeta.AnnotationHash([]string{strings.Join(checks, " "), now.String()})}
+	}
+	cfg.Checks = status.Checks
+	cfg.ChecksHash = status.ChecksHash
+	if status.Complete {
+		return status, cfg, nil
+	}
+
+	complete, nodes, err := results(ctx, c, status.ChecksHash)
+	if err != nil {
+		return installation.Status.Preflight, nil, err
+	}
+	status.Nodes = nodes
+	if complete {
+		status.Complete = true
+		status.CompletionTime = &metav1.Time{Time: now}
+	}
+	cfg.Done = complete
+	return status, cfg, nil
+}
+
+// RequeueAfter returns how long until the preflight status needs another look: soon while the checks are running,
+// and after RecheckInterval if they had failures. It returns 0 if nothing is pending.
+func RequeueAfter(status *operatorv1.PreflightStatus, now time.Time) time.Duration {
+	switch {
+	case status == nil:
+		return 0
+	case !status.Complete:
+		return 10 * time.Second
+	case failed(status) && status.CompletionTime != nil:
+		if d := status.CompletionTime.Add(RecheckInterval).Sub(now); d > 0 {
+			return d
+		}
+		return time.Second
+	}
+	return 0
+}
+
+// Summary describes the failures in the status, for the degraded message of the Installation in Enforce mode.
+func Summary(status *operatorv1.PreflightStatus) string {
+	if status == nil || !status.Complete {
+		return "preflight checks are still running"
+	}
+	var failures []string
+	for _, node := range status.Nodes {
+		for _, failure := range node.Failures {
+			failures = append(failures, fmt.Sprintf("%s on node %s", failure.Check, node.Node))
+		}
+	}
+	return fmt.Sprintf("preflight checks failed: %s", strings.Join(failures, ", "))
+}
+
+// Allowed returns true if the feature that the check is for can be enabled. That's always the case in Report mode.
+// In Enforce mode, every node must have reported and passed the check.
+func Allowed(installation *operatorv1.Installation, check string) bool {
+	return allowed(installation, func(c string) bool { return c == check })
+}
+
+// PortsAllowed returns true if calico-node and Typha can be installed. In Enforce mode, that's once every node has
+// reported and passed the checks of the ports, if they are checked.
+func PortsAllowed(installation *operatorv1.Installation) bool {
+	isPort := func(c string) bool { return strings.HasPrefix(c, render.PreflightCheckPortPrefix) }
+	if status := installation.Status.Preflight; status != nil {
+		checked := false
+		for _, c := range status.Checks {
+			checked = checked || isPort(c)
+		}
+		if !checked {
+			return true
+		}
+	}
+	return allowed(installation, isPort)
+}
+
+func allowed(installation *operatorv1.Installation, match func(check string) bool) bool {
+	if !enforced(installation.Spec.Preflight) {
+		return true
+	}
+	status := installation.Status.Preflight
+	if status == nil || !status.Complete {
+		return false
+	}
+	for _, node := range status.Nodes {
+		for _, failure := range node.Failures {
+			if match(failure.Check) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// results gathers the results that the preflight pods with the checks hash have reported. They're complete once
+// every node that the DaemonSet schedules a pod on has reported.
+func results(ctx context.Context, c client.Client, hash string) (bool, []operatorv1.NodePreflightResult, error) {
+	ds := &appsv1.DaemonSet{}
+	if err := c.Get(ctx, types.NamespacedName{Name: render.PreflightName, Namespace: common.CalicoNamespace}, ds); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The DaemonSet is created after this reconcile.
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(common.CalicoNamespace), client.MatchingLabels{"k8s-app": render.PreflightName}); err != nil {
+		return false, nil, err
+	}
+
+	var nodes []operatorv1.NodePreflightResult
+	for _, pod := range pods.Items {
+		if pod.Annotations[render.PreflightChecksHashAnnotation] != hash || pod.Spec.NodeName == "" {
+			continue
+		}
+		failures, ok := podResult(&pod)
+		if !ok {
+			continue
+		}
+		nodes = append(nodes, operatorv1.NodePreflightResult{Node: pod.Spec.NodeName, Failures: failures})
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Node < nodes[j].Node })
+
+	desired := int(ds.Status.DesiredNumberScheduled)
+	return desired > 0 && len(nodes) >= desired, nodes, nil
+}
+
+// podResult returns the failures that the pod reported, and false if it hasn't finished its checks.
+func podResult(pod *corev1.Pod) ([]operatorv1.PreflightFailure, bool) {
+	for _, cs := range pod.Status.InitContainerStatuses {
+		if cs.Name != render.PreflightChecksContainer || cs.State.Terminated == nil {
+			continue
+		}
+		var failures []operatorv1.PreflightFailure
+		done := false
+		for _, line := range strings.Split(cs.State.Terminated.Message, "\n") {
+			fields := strings.SplitN(strings.TrimSpace(line), " ", 3)
+			switch {
+			case fields[0] == "DONE":
+				done = true
+			case fields[0] == "FAIL" && len(fields) == 3:
+				failures = append(failures, operatorv1.PreflightFailure{Check: fields[1], Message: fields[2]})
+			}
+		}
+		return failures, done
+	}
+	return nil, false
+}
+
+func recheckDue(status *operatorv1.PreflightStatus, now time.Time) bool {
+	return status.Complete && failed(status) && status.CompletionTime != nil && !now.Before(status.CompletionTime.Add(RecheckInterval))
+}
+
+func failed(status *operatorv1.PreflightStatus) bool {
+	for _, node := range status.Nodes {
+		if len(node.Failures) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// covers returns true if every check in want is in have.
+func covers(have, want []string) bool {
+	for _, w := range want {
+		found := false
+		for _, h := range have {
+			found = found || h == w
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func enforced(spec *operatorv1.PreflightSpec) bool {
+	return spec != nil && spec.Mode != nil && *spec.Mode == operatorv1.PreflightModeEnforce
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preflight_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/preflight"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
+	"github.com/tigera/operator/pkg/render"
+)
+
+var _ = Describe("Preflight tests", func() {
+	var (
+		c            client.Client
+		ctx          context.Context
+		installation *operatorv1.Installation
+		now          time.Time
+		enforce      = operatorv1.PreflightModeEnforce
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(appsv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(corev1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+		now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		installation = &operatorv1.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec:       operatorv1.InstallationSpec{Preflight: &operatorv1.PreflightSpec{}},
+		}
+	})
+
+	createDaemonSet := func(name string, desired int32) {
+		Expect(c.Create(ctx, &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: common.CalicoNamespace},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: desired},
+		})).NotTo(HaveOccurred())
+	}
+
+	createPod := func(node, hash, message string) {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "calico-preflight-" + node,
+				Namespace:   common.CalicoNamespace,
+				Labels:      map[string]string{"k8s-app": render.PreflightName},
+				Annotations: map[string]string{render.PreflightChecksHashAnnotation: hash},
+			},
+			Spec: corev1.PodSpec{NodeName: node},
+		}
+		if message != "" {
+			pod.Status.InitContainerStatuses = []corev1.ContainerStatus{{
+				Name:  render.PreflightChecksContainer,
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: message}},
+			}}
+		}
+		Expect(c.Create(ctx, pod)).NotTo(HaveOccurred())
+	}
+
+	It("should only check the ports in Enforce mode before calico-node is installed", func() {
+		spec := &operatorv1.InstallationSpec{
+			Preflight:     &operatorv1.PreflightSpec{Mode: &enforce},
+			CalicoNetwork: &operatorv1.CalicoNetworkSpec{LinuxDataplane: ptr.ToPtr(operatorv1.LinuxDataplaneBPF), BGP: ptr.ToPtr(operatorv1.BGPEnabled)},
+		}
+		Expect(preflight.Checks(spec, false, 9099)).To(Equal([]string{"bpf-kernel", "port:179/tcp", "port:5473/tcp", "port:9099/tcp", "rp-filter", "wireguard"}))
+		Expect(preflight.Checks(spec, true, 9099)).To(Equal([]string{"bpf-kernel", "rp-filter", "wireguard"}))
+
+		spec.Preflight.Mode = nil
+		Expect(preflight.Checks(spec, false, 9099)).To(Equal([]string{"bpf-kernel", "rp-filter", "wireguard"}))
+	})
+
+	It("should remove the DaemonSet if the preflight isn't configured", func() {
+		installation.Spec.Preflight = nil
+		installation.Status.Preflight = &operatorv1.PreflightStatus{ChecksHash: "old"}
+		status, cfg, err := preflight.Reconcile(ctx, c, installation, 9099, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(BeNil())
+		Expect(cfg.Done).To(BeTrue())
+	})
+
+	It("should gather the results of every node", func() {
+		createDaemonSet(common.NodeDaemonSetName, 2)
+		status, cfg, err := preflight.Reconcile(ctx, c, installation, 9099, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Checks).To(Equal([]string{"rp-filter", "wireguard"}))
+		Expect(status.Complete).To(BeFalse())
+		Expect(cfg.Done).To(BeFalse())
+		Expect(cfg.ChecksHash).To(Equal(status.ChecksHash))
+		installation.Status.Preflight = status
+
+		createDaemonSet(render.PreflightName, 2)
+		createPod("node-a", status.ChecksHash, "FAIL wireguard the wireguard kernel module is neither loaded nor installed\nDONE\n")
+		createPod("node-b", status.ChecksHash, "")
+		createPod("node-c", "other", "DONE\n")
+		status, cfg, err = preflight.Reconcile(ctx, c, installation, 9099, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Complete).To(BeFalse())
+		Expect(status.Nodes).To(Equal([]operatorv1.NodePreflightResult{{
+			Node:     "node-a",
+			Failures: []operatorv1.PreflightFailure{{Check: "wireguard", Message: "the wireguard kernel module is neither loaded nor installed"}},
+		}}))
+		Expect(cfg.Done).To(BeFalse())
+		installation.Status.Preflight = status
+
+		pod := &corev1.Pod{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "calico-preflight-node-b", Namespace: common.CalicoNamespace}, pod)).NotTo(HaveOccurred())
+		pod.Status.InitContainerStatuses = []corev1.ContainerStatus{{
+			Name:  render.PreflightChecksContainer,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: "DONE\n"}},
+		}}
+		Expect(c.Status().Update(ctx, pod)).NotTo(HaveOccurred())
+		status, cfg, err = preflight.Reconcile(ctx, c, installation, 9099, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Complete).To(BeTrue())
+		Expect(status.CompletionTime.Time).To(Equal(now))
+		Expect(status.Nodes).To(HaveLen(2))
+		Expect(status.Nodes[1]).To(Equal(operatorv1.NodePreflightResult{Node: "node-b"}))
+		Expect(cfg.Done).To(BeTrue())
+		installation.Status.Preflight = status
+
+		// Failed checks are run again later.
+		Expect(preflight.RequeueAfter(status, now)).To(Equal(preflight.RecheckInterval))
+		later := now.Add(preflight.RecheckInterval)
+		rerun, cfg, err := preflight.Reconcile(ctx, c, installation, 9099, later)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rerun.Complete).To(BeFalse())
+		Expect(rerun.ChecksHash).NotTo(Equal(status.ChecksHash))
+		Expect(cfg.Done).To(BeFalse())
+	})
+
+	It("should run the checks again when the features that they're for change", func() {
+		createDaemonSet(common.NodeDaemonSetName, 1)
+		installation.Status.Preflight = &operatorv1.PreflightStatus{ChecksHash: "old", Checks: []string{"rp-filter", "wireguard"}, Complete: true}
+		status, _, err := preflight.Reconcile(ctx, c, installation, 9099, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(installation.Status.Preflight))
+
+		installation.Spec.CalicoNetwork = &operatorv1.CalicoNetworkSpec{LinuxDataplane: ptr.ToPtr(operatorv1.LinuxDataplaneBPF)}
+		status, cfg, err := preflight.Reconcile(ctx, c, installation, 9099, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Complete).To(BeFalse())
+		Expect(status.Checks).To(ContainElement("bpf-kernel"))
+		Expect(cfg.Checks).To(Equal(status.Checks))
+	})
+
+	It("should hold back features whose checks haven't passed in Enforce mode", func() {
+		Expect(preflight.Allowed(installation, render.PreflightCheckBPFKernel)).To(BeTrue())
+
+		installation.Spec.Preflight.Mode = &enforce
+		installation.Status.Preflight = &operatorv1.PreflightStatus{Checks: []string{"bpf-kernel", "port:179/tcp"}}
+		Expect(preflight.Allowed(installation, render.PreflightCheckBPFKernel)).To(BeFalse())
+		Expect(preflight.PortsAllowed(installation)).To(BeFalse())
+
+		installation.Status.Preflight.Complete = true
+		installation.Status.Preflight.Nodes = []operatorv1.NodePreflightResult{
+			{Node: "node-a", Failures: []operatorv1.PreflightFailure{{Check: "bpf-kernel", Message: "too old"}}},
+			{Node: "node-b"},
+		}
+		Expect(preflight.Allowed(installation, render.PreflightCheckBPFKernel)).To(BeFalse())
+		Expect(preflight.PortsAllowed(installation)).To(BeTrue())
+		Expect(preflight.Summary(installation.Status.Preflight)).To(Equal("preflight checks failed: bpf-kernel on node node-a"))
+
+		// Once calico-node is installed, the ports are no longer checked.
+		installation.Status.Preflight = &operatorv1.PreflightStatus{Checks: []string{"rp-filter"}}
+		Expect(preflight.PortsAllowed(installation)).To(BeTrue())
+	})
+})
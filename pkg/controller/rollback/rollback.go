@@ -0,0 +1,295 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rollback keeps the last known-good render of the workloads that a controller manages, so that a workload
+// that is degraded by an image bump or a configuration change can be rolled back without editing the spec.
+//
+// A render is known-good once its workload has fully rolled out. The known-good renders are stored in a ConfigMap in
+// the operator namespace, keyed by the name of the workload's Deployment or DaemonSet. Listing workload names in the
+// operator.tigera.io/rollback annotation of the owning resource makes the controller apply the known-good render of
+// those workloads instead of the current one. Only the Deployments and DaemonSets themselves are rolled back; the
+// other objects of their components keep following the spec.
+package rollback
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/upgrade"
+	"github.com/tigera/operator/pkg/render"
+)
+
+const (
+	// Annotation lists the comma separated names of the workloads to roll back to their last known-good render.
+	Annotation = "operator.tigera.io/rollback"
+
+	// KnownGoodConfigMapName is the name of the ConfigMap that stores the known-good renders.
+	KnownGoodConfigMapName = "tigera-known-good-render"
+)
+
+// knownGood is a known-good render of a workload, as it is stored in the ConfigMap.
+type knownGood struct {
+	Generation int64           `json:"generation"`
+	Kind       string          `json:"kind"`
+	Object     json.RawMessage `json:"object"`
+}
+
+// Tracker records the workloads that components render, and replaces them with their known-good renders for the
+// workloads that are rolled back. A Tracker is used for a single reconcile.
+type Tracker struct {
+	client    client.Client
+	scheme    *runtime.Scheme
+	owner     client.Object
+	requested map[string]bool
+	knownGood map[string]knownGood
+	rendered  map[string]client.Object
+}
+
+// NewTracker returns a Tracker for the workloads of the owner, loading the known-good renders that were stored for it.
+func NewTracker(ctx context.Context, c client.Client, scheme *runtime.Scheme, owner client.Object) (*Tracker, error) {
+	t := &Tracker{
+		client:    c,
+		scheme:    scheme,
+		owner:     owner,
+		requested: map[string]bool{},
+		knownGood: map[string]knownGood{},
+		rendered:  map[string]client.Object{},
+	}
+	for _, name := range strings.Split(owner.GetAnnotations()[Annotation], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			t.requested[name] = true
+		}
+	}
+
+	cm := &corev1.ConfigMap{}
+	err := c.Get(ctx, types.NamespacedName{Name: KnownGoodConfigMapName, Namespace: common.OperatorNamespace()}, cm)
+	if apierrors.IsNotFound(err) {
+		return t, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read the known-good renders: %w", err)
+	}
+	for name, data := range cm.Data {
+		kg := knownGood{}
+		if err := json.Unmarshal([]byte(data), &kg); err != nil {
+			return nil, fmt.Errorf("failed to parse the known-good render of %s: %w", name, err)
+		}
+		t.knownGood[name] = kg
+	}
+	return t, nil
+}
+
+// Wrap returns a component that renders the same objects as the given one, except for the workloads that are rolled
+// back, which are replaced by their known-good render.
+func (t *Tracker) Wrap(component render.Component) render.Component {
+	return &trackedComponent{Component: component, tracker: t}
+}
+
+// RolledBack returns the status of the workloads that are requested to be rolled back.
+func (t *Tracker) RolledBack() []operatorv1.RolledBackComponent {
+	var status []operatorv1.RolledBackComponent
+	for name := range t.requested {
+		kg, ok := t.knownGood[name]
+		if !ok {
+			status = append(status, operatorv1.RolledBackComponent{
+				Name:    name,
+				Message: fmt.Sprintf("There is no known-good render to roll back to; the current spec is applied. Remove %s from the %s annotation.", name, Annotation),
+			})
+			continue
+		}
+		status = append(status, operatorv1.RolledBackComponent{
+			Name:                name,
+			KnownGoodGeneration: kg.Generation,
+			Message:             fmt.Sprintf("Rolled back to the render of generation %d; the current spec is not applied. Remove %s from the %s annotation to apply it.", kg.Generation, name, Annotation),
+		})
+	}
+	sort.Slice(status, func(i, j int) bool { return status[i].Name < status[j].Name })
+	return status
+}
+
+// Record stores the current render of every workload that has fully rolled out as its known-good render. Workloads
+// that are rolled back are left as they are.
+func (t *Tracker) Record(ctx context.Context) error {
+	changed := false
+	for name, obj := range t.rendered {
+		if t.requested[name] {
+			continue
+		}
+		ok, err := t.rolledOut(ctx, obj)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		kg := knownGood{Generation: t.owner.GetGeneration(), Kind: kindOf(obj), Object: data}
+		if cur, ok := t.knownGood[name]; ok && cur.Kind == kg.Kind && string(cur.Object) == string(kg.Object) {
+			continue
+		}
+		t.knownGood[name] = kg
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: KnownGoodConfigMapName, Namespace: common.OperatorNamespace()},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, t.client, cm, func() error {
+		cm.Data = map[string]string{}
+		for name, kg := range t.knownGood {
+			data, err := json.Marshal(kg)
+			if err != nil {
+				return err
+			}
+			cm.Data[name] = string(data)
+		}
+		return controllerutil.SetControllerReference(t.owner, cm, t.scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store the known-good renders: %w", err)
+	}
+	return nil
+}
+
+// rolledOut returns true if the live workload runs the containers of the render and every replica is up to date and
+// available.
+func (t *Tracker) rolledOut(ctx context.Context, obj client.Object) (bool, error) {
+	var live client.Object
+	switch obj.(type) {
+	case *appsv1.Deployment:
+		live = &appsv1.Deployment{}
+	case *appsv1.DaemonSet:
+		live = &appsv1.DaemonSet{}
+	default:
+		return false, nil
+	}
+	if err := t.client.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	switch l := live.(type) {
+	case *appsv1.Deployment:
+		return upgrade.DeploymentRolledOut(l) && sameContainers(obj.(*appsv1.Deployment).Spec.Template.Spec, l.Spec.Template.Spec), nil
+	case *appsv1.DaemonSet:
+		return upgrade.DaemonSetRolledOut(l) && sameContainers(obj.(*appsv1.DaemonSet).Spec.Template.Spec, l.Spec.Template.Spec), nil
+	}
+	return false, nil
+}
+
+// sameContainers returns true if the live pod spec runs the images, commands and environment of the rendered one. It
+// guards against recording a render before the cache has caught up with it being applied.
+func sameContainers(rendered, live corev1.PodSpec) bool {
+	liveContainers := map[string]corev1.Container{}
+	for _, c := range append(live.InitContainers, live.Containers...) {
+		liveContainers[c.Name] = c
+	}
+	for _, c := range append(rendered.InitContainers, rendered.Containers...) {
+		l, ok := liveContainers[c.Name]
+		if !ok || l.Image != c.Image || !equalOrEmpty(l.Command, c.Command) || !equalOrEmpty(l.Args, c.Args) || !equalOrEmpty(l.Env, c.Env) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalOrEmpty returns true if the slices are deeply equal, treating nil and empty slices as equal.
+func equalOrEmpty[T any](a, b []T) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// trackedComponent is a component whose workloads are recorded by, and may be rolled back by, a Tracker.
+type trackedComponent struct {
+	render.Component
+	tracker *Tracker
+}
+
+func (c *trackedComponent) Unwrap() render.Component {
+	return c.Component
+}
+
+func (c *trackedComponent) Objects() ([]client.Object, []client.Object) {
+	objsToCreate, objsToDelete := c.Component.Objects()
+	for i, obj := range objsToCreate {
+		switch obj.(type) {
+		case *appsv1.Deployment, *appsv1.DaemonSet:
+		default:
+			continue
+		}
+		name := obj.GetName()
+		c.tracker.rendered[name] = obj.DeepCopyObject().(client.Object)
+		if !c.tracker.requested[name] {
+			continue
+		}
+		if kg, ok := c.tracker.knownGood[name]; ok {
+			knownGoodObj, err := decode(kg)
+			if err != nil || knownGoodObj.GetNamespace() != obj.GetNamespace() {
+				continue
+			}
+			objsToCreate[i] = knownGoodObj
+		}
+	}
+	return objsToCreate, objsToDelete
+}
+
+func kindOf(obj client.Object) string {
+	switch obj.(type) {
+	case *appsv1.Deployment:
+		return "Deployment"
+	case *appsv1.DaemonSet:
+		return "DaemonSet"
+	}
+	return ""
+}
+
+func decode(kg knownGood) (client.Object, error) {
+	var obj client.Object
+	switch kg.Kind {
+	case "Deployment":
+		obj = &appsv1.Deployment{}
+	case "DaemonSet":
+		obj = &appsv1.DaemonSet{}
+	default:
+		return nil, fmt.Errorf("unexpected kind %s", kg.Kind)
+	}
+	if err := json.Unmarshal(kg.Object, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
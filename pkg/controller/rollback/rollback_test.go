@@ -0,0 +1,143 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollback_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/rollback"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render"
+)
+
+var _ = Describe("Rollback tests", func() {
+	var (
+		c      client.Client
+		ctx    context.Context
+		scheme *runtime.Scheme
+		owner  *operatorv1.Installation
+	)
+
+	deployment := func(image string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "calico-kube-controllers", Namespace: common.CalicoNamespace},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "calico-kube-controllers", Image: image}}},
+				},
+			},
+		}
+	}
+
+	// apply renders the deployment through a tracker, as a controller would, and marks it as rolled out.
+	apply := func(image string) *rollback.Tracker {
+		t, err := rollback.NewTracker(ctx, c, scheme, owner)
+		Expect(err).NotTo(HaveOccurred())
+		objs, _ := t.Wrap(render.NewPassthrough(deployment(image))).Objects()
+		Expect(objs).To(HaveLen(1))
+
+		d := objs[0].(*appsv1.Deployment).DeepCopy()
+		Expect(c.Create(ctx, d)).NotTo(HaveOccurred())
+		d.Status = appsv1.DeploymentStatus{ObservedGeneration: d.Generation, Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1}
+		Expect(c.Status().Update(ctx, d)).NotTo(HaveOccurred())
+		return t
+	}
+
+	appliedImage := func(t *rollback.Tracker) string {
+		objs, _ := t.Wrap(render.NewPassthrough(deployment("calico/kube-controllers:v3"))).Objects()
+		return objs[0].(*appsv1.Deployment).Spec.Template.Spec.Containers[0].Image
+	}
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(appsv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(corev1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+		owner = &operatorv1.Installation{ObjectMeta: metav1.ObjectMeta{Name: "default", UID: "installation-uid", Generation: 1}}
+	})
+
+	It("should record rolled out workloads as known-good and roll back to them", func() {
+		Expect(apply("calico/kube-controllers:v1").Record(ctx)).NotTo(HaveOccurred())
+
+		cm := &corev1.ConfigMap{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: rollback.KnownGoodConfigMapName, Namespace: common.OperatorNamespace()}, cm)).NotTo(HaveOccurred())
+		Expect(cm.Data).To(HaveKey("calico-kube-controllers"))
+		Expect(cm.OwnerReferences).To(HaveLen(1))
+
+		// Without the annotation the current render is applied.
+		owner.Generation = 2
+		t, err := rollback.NewTracker(ctx, c, scheme, owner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(appliedImage(t)).To(Equal("calico/kube-controllers:v3"))
+		Expect(t.RolledBack()).To(BeEmpty())
+
+		owner.Annotations = map[string]string{rollback.Annotation: "calico-kube-controllers, calico-node"}
+		t, err = rollback.NewTracker(ctx, c, scheme, owner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(appliedImage(t)).To(Equal("calico/kube-controllers:v1"))
+
+		status := t.RolledBack()
+		Expect(status).To(HaveLen(2))
+		Expect(status[0].Name).To(Equal("calico-kube-controllers"))
+		Expect(status[0].KnownGoodGeneration).To(Equal(int64(1)))
+		Expect(status[0].Message).To(ContainSubstring("the current spec is not applied"))
+		Expect(status[1].Name).To(Equal("calico-node"))
+		Expect(status[1].Message).To(ContainSubstring("no known-good render"))
+	})
+
+	It("should not record a render that hasn't rolled out", func() {
+		t := apply("calico/kube-controllers:v1")
+		d := &appsv1.Deployment{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: "calico-kube-controllers", Namespace: common.CalicoNamespace}, d)).NotTo(HaveOccurred())
+		d.Status.AvailableReplicas = 0
+		Expect(c.Status().Update(ctx, d)).NotTo(HaveOccurred())
+		Expect(t.Record(ctx)).NotTo(HaveOccurred())
+
+		cm := &corev1.ConfigMap{}
+		err := c.Get(ctx, types.NamespacedName{Name: rollback.KnownGoodConfigMapName, Namespace: common.OperatorNamespace()}, cm)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not replace the known-good render while the workload is rolled back", func() {
+		Expect(apply("calico/kube-controllers:v1").Record(ctx)).NotTo(HaveOccurred())
+
+		owner.Generation = 2
+		owner.Annotations = map[string]string{rollback.Annotation: "calico-kube-controllers"}
+		t, err := rollback.NewTracker(ctx, c, scheme, owner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(appliedImage(t)).To(Equal("calico/kube-controllers:v1"))
+		Expect(t.Record(ctx)).NotTo(HaveOccurred())
+
+		t, err = rollback.NewTracker(ctx, c, scheme, owner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.RolledBack()[0].KnownGoodGeneration).To(Equal(int64(1)))
+	})
+})
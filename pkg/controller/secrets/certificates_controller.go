@@ -0,0 +1,175 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/certificatemanager"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/logging"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// CertificatesController reports the validity of the certificates that the operator manages in the "certificates"
+// TigeraStatus. These are the certificates in the operator namespace, and in the tenant namespaces in multi-tenant
+// mode. The certificates that the operator issued are renewed by the controllers that use them, once they are due.
+type CertificatesController struct {
+	client      client.Client
+	reader      client.Reader
+	status      status.StatusManager
+	multiTenant bool
+	log         logr.Logger
+}
+
+func AddCertificatesController(mgr manager.Manager, opts options.AddOptions) error {
+	r := &CertificatesController{
+		client:      mgr.GetClient(),
+		reader:      utils.NewUncachedReader(mgr.GetAPIReader()),
+		multiTenant: opts.MultiTenant,
+		status:      status.New(mgr.GetClient(), "certificates", opts.KubernetesVersion),
+		log:         logf.Log.WithName("controller_certificates"),
+	}
+	r.status.Run(opts.ShutdownContext)
+
+	c, err := ctrlruntime.NewController("certificates-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	if err = c.WatchObject(&operatorv1.Installation{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
+		return fmt.Errorf("certificates-controller failed to watch Installation resource: %w", err)
+	}
+	if err = utils.AddSecretsWatch(c, certificatemanagement.CASecretName, common.OperatorNamespace()); err != nil {
+		return fmt.Errorf("certificates-controller failed to watch CA secret: %w", err)
+	}
+
+	// Certificates approach their expiry without any event, so check them periodically.
+	if err = utils.AddPeriodicReconcile(c, utils.PeriodicReconcileTime, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("certificates-controller failed to create periodic reconcile watch: %w", err)
+	}
+	return nil
+}
+
+func (r *CertificatesController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logc := logging.ForReconcile(ctx, r.log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+	if err := r.client.Get(ctx, utils.DefaultInstanceKey, &operatorv1.Installation{}); err != nil {
+		if errors.IsNotFound(err) {
+			r.status.OnCRNotFound()
+			return reconcile.Result{}, nil
+		}
+		r.status.SetDegraded(operatorv1.ResourceReadError, "An error occurred while querying Installation", err, logc)
+		return reconcile.Result{}, err
+	}
+	r.status.OnCRFound()
+
+	namespaces := []string{common.OperatorNamespace()}
+	if r.multiTenant {
+		tenantNamespaces, err := utils.TenantNamespaces(ctx, r.client)
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying tenant namespaces", err, logc)
+			return reconcile.Result{}, err
+		}
+		namespaces = append(namespaces, tenantNamespaces...)
+	}
+
+	var certificates []operatorv1.CertificateStatus
+	for _, ns := range namespaces {
+		// List the secrets a page at a time from the API server, so that the secrets of large namespaces aren't all
+		// copied out of the cache at once.
+		secrets := &corev1.SecretList{}
+		err := utils.ListPages(ctx, r.reader, secrets, func() (bool, error) {
+			for i := range secrets.Items {
+				if cs := certificateStatus(&secrets.Items[i]); cs != nil {
+					certificates = append(certificates, *cs)
+				}
+			}
+			return false, nil
+		}, client.InNamespace(ns))
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying secrets", err, logc)
+			return reconcile.Result{}, err
+		}
+	}
+	sort.Slice(certificates, func(i, j int) bool {
+		if certificates[i].Namespace != certificates[j].Namespace {
+			return certificates[i].Namespace < certificates[j].Namespace
+		}
+		return certificates[i].Name < certificates[j].Name
+	})
+	r.status.SetCertificates(certificates)
+	r.status.ReadyToMonitor()
+
+	var expired []string
+	for _, c := range certificates {
+		if c.NotAfter.Time.Before(time.Now()) {
+			expired = append(expired, fmt.Sprintf("%s/%s", c.Namespace, c.Name))
+		}
+	}
+	if len(expired) > 0 {
+		r.status.SetDegraded(operatorv1.CertificateError, "Certificates have expired", fmt.Errorf("%s", strings.Join(expired, ", ")), logc)
+		return reconcile.Result{}, nil
+	}
+	r.status.ClearDegraded()
+	return reconcile.Result{}, nil
+}
+
+// certificateStatus returns the status of the certificate in the secret, or nil if the secret doesn't hold one.
+func certificateStatus(s *corev1.Secret) *operatorv1.CertificateStatus {
+	_, certPEM := certificatemanagement.GetKeyCertPEM(s)
+	if len(certPEM) == 0 {
+		return nil
+	}
+	cert, err := certificatemanagement.ParseCertificate(certPEM)
+	if err != nil {
+		return nil
+	}
+
+	cs := &operatorv1.CertificateStatus{
+		Name:      s.Name,
+		Namespace: s.Namespace,
+		Issuer:    cert.Issuer.CommonName,
+		NotAfter:  metav1.NewTime(cert.NotAfter),
+	}
+	// The operator renews the certificates that its current CA issued, but not the CA itself.
+	if !cert.IsCA && cert.Issuer.CommonName == rmeta.TigeraOperatorCAIssuerPrefix {
+		renewAfter := metav1.NewTime(certificatemanager.RenewalTime(cert))
+		cs.RenewAfter = &renewAfter
+	}
+	return cs
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/library-go/pkg/crypto"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/certificatemanager"
+	"github.com/tigera/operator/pkg/controller/status"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/render/common/secret"
+	"github.com/tigera/operator/pkg/tls"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
+)
+
+var _ = Describe("Certificates controller", func() {
+	var (
+		cli        client.Client
+		ctx        context.Context
+		mockStatus *status.MockStatus
+		r          *CertificatesController
+		ca         *crypto.CA
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(corev1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		ctx = context.Background()
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+
+		install := &operatorv1.Installation{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+		Expect(cli.Create(ctx, install)).ShouldNot(HaveOccurred())
+
+		cm, err := certificatemanager.Create(cli, &install.Spec, dns.DefaultClusterDomain, common.OperatorNamespace(), certificatemanager.AllowCACreation())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cli.Create(ctx, cm.KeyPair().Secret(common.OperatorNamespace()))).ShouldNot(HaveOccurred())
+		ca, err = crypto.GetCAFromBytes(cm.KeyPair().GetCertificatePEM(), cm.KeyPair().Secret("").Data[corev1.TLSPrivateKeyKey])
+		Expect(err).ShouldNot(HaveOccurred())
+
+		// A secret that doesn't hold a certificate, which should be ignored.
+		Expect(cli.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: common.OperatorNamespace()}})).ShouldNot(HaveOccurred())
+
+		mockStatus = &status.MockStatus{}
+		mockStatus.On("OnCRFound").Return()
+		mockStatus.On("ReadyToMonitor")
+		r = &CertificatesController{
+			client: cli,
+			reader: cli,
+			status: mockStatus,
+			log:    logf.Log.WithName("controller_certificates"),
+		}
+	})
+
+	reportedCertificates := func() []operatorv1.CertificateStatus {
+		for _, call := range mockStatus.Calls {
+			if call.Method == "SetCertificates" {
+				return call.Arguments.Get(0).([]operatorv1.CertificateStatus)
+			}
+		}
+		return nil
+	}
+
+	It("should report the certificates and when the operator renews them", func() {
+		byoCA, err := tls.MakeCA("byo-ca")
+		Expect(err).ShouldNot(HaveOccurred())
+		byo, err := secret.CreateTLSSecret(byoCA, "byo-secret", common.OperatorNamespace(), corev1.TLSPrivateKeyKey, corev1.TLSCertKey, time.Hour, nil, "byo")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cli.Create(ctx, byo)).ShouldNot(HaveOccurred())
+		issued, err := secret.CreateTLSSecret(ca, "issued-secret", common.OperatorNamespace(), corev1.TLSPrivateKeyKey, corev1.TLSCertKey, time.Hour, nil, "issued")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cli.Create(ctx, issued)).ShouldNot(HaveOccurred())
+
+		mockStatus.On("SetCertificates", mock.Anything).Return()
+		mockStatus.On("ClearDegraded")
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+		Expect(err).ShouldNot(HaveOccurred())
+		mockStatus.AssertExpectations(GinkgoT())
+
+		certs := reportedCertificates()
+		Expect(certs).To(HaveLen(3))
+		Expect(certs[0].Name).To(Equal("byo-secret"))
+		Expect(certs[0].Issuer).To(Equal("byo-ca"))
+		Expect(certs[0].RenewAfter).To(BeNil())
+		Expect(certs[1].Name).To(Equal("issued-secret"))
+		Expect(certs[1].RenewAfter).NotTo(BeNil())
+		Expect(certs[1].RenewAfter.Time).To(BeTemporally("<", certs[1].NotAfter.Time))
+		// The CA is not renewed by the operator.
+		Expect(certs[2].Name).To(Equal(certificatemanagement.CASecretName))
+		Expect(certs[2].RenewAfter).To(BeNil())
+	})
+
+	It("should degrade when a certificate has expired", func() {
+		expired, err := secret.CreateTLSSecret(ca, "expired-secret", common.OperatorNamespace(), corev1.TLSPrivateKeyKey, corev1.TLSCertKey, -time.Hour, nil, "expired")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cli.Create(ctx, expired)).ShouldNot(HaveOccurred())
+
+		mockStatus.On("SetCertificates", mock.Anything).Return()
+		mockStatus.On("SetDegraded", operatorv1.CertificateError, "Certificates have expired", mock.Anything, mock.Anything).Return()
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+		Expect(err).ShouldNot(HaveOccurred())
+		mockStatus.AssertExpectations(GinkgoT())
+		Expect(mockStatus.Calls[len(mockStatus.Calls)-1].Arguments.Get(2)).To(Equal(common.OperatorNamespace() + "/expired-secret"))
+	})
+})
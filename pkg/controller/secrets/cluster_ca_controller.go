@@ -26,6 +26,7 @@ import (
 	"github.com/tigera/operator/pkg/controller/options"
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/logging"
 	rcertificatemanagement "github.com/tigera/operator/pkg/render/certificatemanagement"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 
@@ -61,7 +62,7 @@ func AddClusterCAController(mgr manager.Manager, opts options.AddOptions) error
 	}
 
 	// Watch for triggers.
-	if err = c.WatchObject(&operatorv1.Installation{}, &handler.EnqueueRequestForObject{}); err != nil {
+	if err = c.WatchObject(&operatorv1.Installation{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("cluster-ca-controller failed to watch primary resource: %w", err)
 	}
 	if err = utils.AddSecretsWatch(c, certificatemanagement.CASecretName, common.OperatorNamespace()); err != nil {
@@ -79,7 +80,7 @@ func AddClusterCAController(mgr manager.Manager, opts options.AddOptions) error
 }
 
 func (r *ClusterCAController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	logc := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	logc := logging.ForReconcile(ctx, r.log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 
 	// Get Installation resource.
 	instance := &operatorv1.Installation{}
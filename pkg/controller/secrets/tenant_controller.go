@@ -16,7 +16,10 @@ package secrets
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"sort"
 
 	"github.com/go-logr/logr"
 	operatorv1 "github.com/tigera/operator/api/v1"
@@ -27,16 +30,18 @@ import (
 	"github.com/tigera/operator/pkg/controller/status"
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/logging"
 	"github.com/tigera/operator/pkg/render"
 	rcertificatemanagement "github.com/tigera/operator/pkg/render/certificatemanagement"
 	"github.com/tigera/operator/pkg/render/logstorage"
 	"github.com/tigera/operator/pkg/render/logstorage/linseed"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -44,7 +49,8 @@ import (
 )
 
 // TenantControllers runs in multi-tenant mode and provisions a CA per-tenant, as well as generating
-// a trusted bundle to place in each tenant's namespace.
+// a trusted bundle and copying the image pull secrets to place in each tenant's namespace. The component
+// controllers of a tenant only consume these, they never create them.
 type TenantController struct {
 	client          client.Client
 	scheme          *runtime.Scheme
@@ -70,16 +76,16 @@ func AddTenantController(mgr manager.Manager, opts options.AddOptions) error {
 	r.status.Run(opts.ShutdownContext)
 
 	// Create a controller using the reconciler and register it with the manager to receive reconcile calls.
-	c, err := ctrlruntime.NewController("tenant-secrets-controller", mgr, controller.Options{Reconciler: r})
+	c, err := ctrlruntime.NewController("tenant-secrets-controller", mgr, utils.ControllerOptions("tenant-secrets-controller", r, opts))
 	if err != nil {
 		return err
 	}
 
-	if err = c.WatchObject(&operatorv1.Tenant{}, &handler.EnqueueRequestForObject{}); err != nil {
+	if err = c.WatchObject(&operatorv1.Tenant{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("tenant-secrets-controller failed to watch Tenant resource: %w", err)
 	}
 
-	if err = c.WatchObject(&operatorv1.Installation{}, &handler.EnqueueRequestForObject{}); err != nil {
+	if err = c.WatchObject(&operatorv1.Installation{}, &handler.EnqueueRequestForObject{}, utils.ObjectChangedPredicate); err != nil {
 		return fmt.Errorf("tenant-controller failed to watch Installation resource: %w", err)
 	}
 	if err = utils.AddSecretsWatch(c, certificatemanagement.CASecretName, common.OperatorNamespace()); err != nil {
@@ -96,12 +102,20 @@ func AddTenantController(mgr manager.Manager, opts options.AddOptions) error {
 	if err = utils.AddConfigMapWatch(c, certificatemanagement.TrustedCertConfigMapNamePublic, "", &handler.EnqueueRequestForObject{}); err != nil {
 		return fmt.Errorf("tenant-controller failed to watch ConfigMap resource: %w", err)
 	}
+	if err = utils.AddConfigMapWatch(c, certificatemanagement.UserTrustedCertConfigMapName, "", &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("tenant-controller failed to watch ConfigMap resource: %w", err)
+	}
+
+	// Watch the pull secrets so that the copies in the tenant namespaces are kept up to date.
+	if err = utils.AddReplicatedSecretsWatch(c, mgr); err != nil {
+		return fmt.Errorf("tenant-controller failed to watch pull secrets: %w", err)
+	}
 
 	return nil
 }
 
 func (r *TenantController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	logc := r.log.WithValues("Request.Namespace", request.Namespace)
+	logc := logging.ForReconcile(ctx, r.log).WithValues("Request.Namespace", request.Namespace)
 	if request.Namespace == "" {
 		// Tenant resources are always within a namespace.
 		return reconcile.Result{}, nil
@@ -147,6 +161,12 @@ func (r *TenantController) Reconcile(ctx context.Context, request reconcile.Requ
 		return reconcile.Result{}, err
 	}
 
+	pullSecrets, err := utils.GetNetworkingPullSecrets(installation, r.client)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving pull secrets", err, logc)
+		return reconcile.Result{}, err
+	}
+
 	// Create a certificate manager for this tenant. This certificate manager will load the CA for this tenant, creating it if needed,
 	// and can be used to sign any certificates needed for this tenant's components.
 	opts := []certificatemanager.Option{
@@ -173,11 +193,20 @@ func (r *TenantController) Reconcile(ctx context.Context, request reconcile.Requ
 		return reconcile.Result{}, err
 	}
 
+	// Get the CAs that the user wants this tenant to trust in addition to the ones managed by the operator.
+	userCerts, err := r.userCertificates(ctx, tenant.Namespace)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("Invalid ConfigMap %s", certificatemanagement.UserTrustedCertConfigMapName), err, logc)
+		return reconcile.Result{}, nil
+	}
+	certs = append(certs, userCerts...)
+
 	// Create a trusted bundle for this tenant. This bundle is provided to the tenant pods so that they can verify
 	// each other's certificates. Each tenant needs to trust:
 	// - Certificates signed by its own CA
 	// - Certificates signed by the cluster-scoped Tigera CA
 	// - Certificates for external ES and Kibana, if configured.
+	// - Certificates that the user added to the tigera-ca-bundle-user ConfigMap, if present.
 	trustedBundle := cm.CreateTrustedBundle()
 	trustedBundle.AddCertificates(certs...)
 
@@ -216,6 +245,10 @@ func (r *TenantController) Reconcile(ctx context.Context, request reconcile.Requ
 		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error creating / updating trusted bundle with public CAs", err, logc)
 		return reconcile.Result{}, err
 	}
+	if err = hdler.CreateOrUpdateOrDelete(ctx, render.TenantPullSecrets(tenant.Namespace, pullSecrets), r.status); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error copying pull secrets", err, logc)
+		return reconcile.Result{}, err
+	}
 
 	return reconcile.Result{}, nil
 }
@@ -245,3 +278,50 @@ func (r *TenantController) upstreamCertificates(cm certificatemanager.Certificat
 	}
 	return certs, nil
 }
+
+// userCertificates returns the certificates in the user's trusted CA ConfigMap within the given namespace, one for each
+// of its keys. The ConfigMap is optional, and is never modified by the operator.
+func (r *TenantController) userCertificates(ctx context.Context, namespace string) ([]certificatemanagement.CertificateInterface, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: certificatemanagement.UserTrustedCertConfigMapName, Namespace: namespace}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(cm.Data))
+	for key := range cm.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	certs := []certificatemanagement.CertificateInterface{}
+	for _, key := range keys {
+		pemData := []byte(cm.Data[key])
+		if err := validateCertificatesPEM(pemData); err != nil {
+			return nil, fmt.Errorf("key %s: %w", key, err)
+		}
+		name := fmt.Sprintf("%s.%s", certificatemanagement.UserTrustedCertConfigMapName, key)
+		certs = append(certs, certificatemanagement.NewCertificate(name, namespace, pemData, nil))
+	}
+	return certs, nil
+}
+
+// validateCertificatesPEM checks that the given PEM holds at least one certificate, and nothing but certificates.
+func validateCertificatesPEM(pemData []byte) error {
+	var found bool
+	for block, rest := pem.Decode(pemData); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("unexpected PEM block of type %s", block.Type)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return err
+		}
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("no PEM encoded certificates found")
+	}
+	return nil
+}
@@ -182,4 +182,78 @@ var _ = Describe("Tenant controller", func() {
 		// A trusted bundle ConfigMap with system roots should also have been created.
 		Expect(cli.Get(ctx, types.NamespacedName{Name: certificatemanagement.TrustedCertConfigMapNamePublic, Namespace: tenantNS}, trustedBundle)).ShouldNot(HaveOccurred())
 	})
+
+	It("should merge the user's CAs into the tenant's trusted bundles", func() {
+		userCA := rtest.CreateCertSecret("idp-ca", tenantNS, "idp.example.com")
+		Expect(cli.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: certificatemanagement.UserTrustedCertConfigMapName, Namespace: tenantNS},
+			Data:       map[string]string{"idp-ca.crt": string(userCA.Data[corev1.TLSCertKey])},
+		})).ShouldNot(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "default", Namespace: tenantNS}})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		userCert := types.NamespacedName{Name: certificatemanagement.UserTrustedCertConfigMapName + ".idp-ca.crt", Namespace: tenantNS}
+		for _, name := range []string{certificatemanagement.TrustedCertConfigMapName, certificatemanagement.TrustedCertConfigMapNamePublic} {
+			trustedBundle := &corev1.ConfigMap{}
+			Expect(cli.Get(ctx, types.NamespacedName{Name: name, Namespace: tenantNS}, trustedBundle)).ShouldNot(HaveOccurred())
+			rtest.ExpectBundleContents(
+				trustedBundle,
+				types.NamespacedName{Name: certificatemanagement.CASecretName, Namespace: common.OperatorNamespace()},
+				types.NamespacedName{Name: certificatemanagement.TenantCASecretName, Namespace: tenantNS},
+				types.NamespacedName{Name: logstorage.ExternalESPublicCertName, Namespace: common.OperatorNamespace()},
+				types.NamespacedName{Name: logstorage.ExternalKBPublicCertName, Namespace: common.OperatorNamespace()},
+				userCert,
+			)
+		}
+
+		// The user's ConfigMap is left as it is.
+		userBundle := &corev1.ConfigMap{}
+		Expect(cli.Get(ctx, types.NamespacedName{Name: certificatemanagement.UserTrustedCertConfigMapName, Namespace: tenantNS}, userBundle)).ShouldNot(HaveOccurred())
+		Expect(userBundle.Data).To(HaveLen(1))
+		Expect(userBundle.OwnerReferences).To(BeEmpty())
+	})
+
+	It("should degrade when the user's CA ConfigMap does not hold certificates", func() {
+		Expect(cli.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: certificatemanagement.UserTrustedCertConfigMapName, Namespace: tenantNS},
+			Data:       map[string]string{"idp-ca.crt": "not a certificate"},
+		})).ShouldNot(HaveOccurred())
+		mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Invalid ConfigMap tigera-ca-bundle-user", mock.Anything, mock.Anything).Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "default", Namespace: tenantNS}})
+		Expect(err).ShouldNot(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Invalid ConfigMap tigera-ca-bundle-user", mock.Anything, mock.Anything)
+
+		trustedBundle := &corev1.ConfigMap{}
+		Expect(cli.Get(ctx, types.NamespacedName{Name: certificatemanagement.TrustedCertConfigMapName, Namespace: tenantNS}, trustedBundle)).To(MatchError(ContainSubstring("not found")))
+	})
+
+	It("should copy the pull secrets into the tenant's namespace", func() {
+		pullSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: common.OperatorNamespace()},
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")},
+			Type:       corev1.SecretTypeDockerConfigJson,
+		}
+		Expect(cli.Create(ctx, pullSecret)).ShouldNot(HaveOccurred())
+		install.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: pullSecret.Name}}
+		Expect(cli.Update(ctx, install)).ShouldNot(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "default", Namespace: tenantNS}})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		// The copy should be owned by the Tenant.
+		tenantPullSecret := &corev1.Secret{}
+		Expect(cli.Get(ctx, types.NamespacedName{Name: pullSecret.Name, Namespace: tenantNS}, tenantPullSecret)).ShouldNot(HaveOccurred())
+		Expect(tenantPullSecret.Data).To(Equal(pullSecret.Data))
+		Expect(tenantPullSecret.OwnerReferences).To(HaveLen(1))
+		Expect(tenantPullSecret.OwnerReferences[0].Kind).To(Equal("Tenant"))
+
+		// The copy should be removed once the Installation no longer references the pull secret.
+		install.Spec.ImagePullSecrets = nil
+		Expect(cli.Update(ctx, install)).ShouldNot(HaveOccurred())
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "default", Namespace: tenantNS}})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cli.Get(ctx, types.NamespacedName{Name: pullSecret.Name, Namespace: tenantNS}, tenantPullSecret)).To(MatchError(ContainSubstring("not found")))
+	})
 })
@@ -126,3 +126,19 @@ func (m *MockStatus) WasCalled(method string, arguments ...interface{}) bool {
 func (m *MockStatus) SetMetaData(meta *metav1.ObjectMeta) {
 	m.Called(meta)
 }
+
+func (m *MockStatus) RolloutStatus() *operator.RolloutStatus {
+	ret := m.Called()
+	if r, ok := ret.Get(0).(*operator.RolloutStatus); ok {
+		return r
+	}
+	return nil
+}
+
+// SetAppliedRevision is called by the component handler for every component it applies, so unlike the other methods it
+// doesn't need to be expected by tests.
+func (m *MockStatus) SetAppliedRevision(ctx context.Context, component, revision string) {}
+
+func (m *MockStatus) SetCertificates(certificates []operator.CertificateStatus) {
+	m.Called(certificates)
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -32,16 +33,23 @@ import (
 	certV1beta1 "k8s.io/api/certificates/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 var log = logf.Log.WithName("status_manager")
 
+// reconcileIDFromContext returns the ID of the reconcile that ctx belongs to. It's a variable so that tests can
+// make up reconciles.
+var reconcileIDFromContext = controller.ReconcileIDFromContext
+
 // StatusManager manages the status for a single controller and component, and reports the status via
 // a TigeraStatus API object. The status manager uses the following conditions/states to represent the
 // component's current status:
@@ -77,6 +85,9 @@ type StatusManager interface {
 	IsDegraded() bool
 	ReadyToMonitor()
 	SetMetaData(meta *metav1.ObjectMeta)
+	RolloutStatus() *operator.RolloutStatus
+	SetAppliedRevision(ctx context.Context, component, revision string)
+	SetCertificates(certificates []operator.CertificateStatus)
 }
 
 type statusManager struct {
@@ -99,6 +110,20 @@ type statusManager struct {
 	// Keep track of currently calculated status.
 	progressing []string
 	failing     []string
+	rollout     *operator.RolloutStatus
+	versions    []operator.ComponentVersion
+
+	// The revisions that the component handler last applied, keyed by the name of the part of the component, and the
+	// reconciles that applied them.
+	appliedRevisions   map[string]operator.AppliedRevision
+	appliedRevisionsBy map[string]types.UID
+
+	// The reconcile that last applied a revision of each tenant, or of the cluster for parts that aren't rendered per
+	// tenant.
+	revisionReconciles map[string]types.UID
+
+	// The certificates reported by the certificates controller.
+	certificates []operator.CertificateStatus
 
 	// readyToMonitor tells the status manager that it's ready to monitor the resources that it's been told to monitor,
 	// if there are any, and report statuses based on the state of those resources.
@@ -133,6 +158,9 @@ func New(client client.Client, component string, kubernetesVersion *common.Versi
 		statefulsets:              make(map[string]types.NamespacedName),
 		cronjobs:                  make(map[string]types.NamespacedName),
 		certificatestatusrequests: make(map[string]map[string]string),
+		appliedRevisions:          make(map[string]operator.AppliedRevision),
+		appliedRevisionsBy:        make(map[string]types.UID),
+		revisionReconciles:        make(map[string]types.UID),
 		kubernetesVersion:         kubernetesVersion,
 		crExists:                  crExists,
 	}
@@ -151,6 +179,7 @@ func (m *statusManager) updateStatus() {
 		return
 	}
 	// This status manager is enabled. Perform a sync.
+	m.pruneDeletedTenants()
 
 	// Unless we've been given an explicit degraded reason we are not ready to start reporting statuses until
 	// ReadyToMonitor has been called by the owner of the status manager. This means there's no point in syncing
@@ -259,10 +288,109 @@ func (m *statusManager) OnCRNotFound() {
 	m.enabled = &f
 	m.progressing = []string{}
 	m.failing = []string{}
+	m.rollout = nil
 	m.daemonsets = make(map[string]types.NamespacedName)
 	m.deployments = make(map[string]types.NamespacedName)
 	m.statefulsets = make(map[string]types.NamespacedName)
 	m.cronjobs = make(map[string]types.NamespacedName)
+	m.appliedRevisions = make(map[string]operator.AppliedRevision)
+	m.appliedRevisionsBy = make(map[string]types.UID)
+	m.revisionReconciles = make(map[string]types.UID)
+	m.certificates = nil
+}
+
+// SetAppliedRevision tells the status manager the revision of the desired state that was just applied for a part of
+// the component by the reconcile that ctx belongs to. The time it was applied is only updated when the revision
+// changes.
+//
+// Once a reconcile starts applying the parts of a tenant, or of the cluster, the parts that the reconcile before it
+// didn't apply are dropped, so that parts that are no longer rendered and tenants that were deleted aren't reported
+// forever.
+func (m *statusManager) SetAppliedRevision(ctx context.Context, component, revision string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if id := reconcileIDFromContext(ctx); id != "" {
+		m.pruneAppliedRevisions(revisionScope(component), id)
+		m.appliedRevisionsBy[component] = id
+	}
+	if cur, ok := m.appliedRevisions[component]; ok && cur.Revision == revision {
+		return
+	}
+	// The API server stores times to the second, so truncate it here or it would never match what was written.
+	m.appliedRevisions[component] = operator.AppliedRevision{
+		Component: component,
+		Revision:  revision,
+		AppliedAt: metav1.Now().Rfc3339Copy(),
+	}
+}
+
+// SetCertificates sets the certificates that are reported in the status of the component.
+func (m *statusManager) SetCertificates(certificates []operator.CertificateStatus) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.certificates = certificates
+}
+
+// pruneAppliedRevisions drops the revisions of the scope that the previous reconcile of the scope didn't apply, when
+// the given reconcile is the first to apply a revision of the scope since. The caller must hold the lock.
+func (m *statusManager) pruneAppliedRevisions(scope string, id types.UID) {
+	previous, ok := m.revisionReconciles[scope]
+	if previous == id {
+		return
+	}
+	m.revisionReconciles[scope] = id
+	if !ok {
+		return
+	}
+	for component, by := range m.appliedRevisionsBy {
+		if revisionScope(component) == scope && by != previous {
+			delete(m.appliedRevisions, component)
+			delete(m.appliedRevisionsBy, component)
+		}
+	}
+}
+
+// pruneDeletedTenants drops the revisions of the tenants that have been deleted. Their parts are no longer
+// reconciled, so the revisions wouldn't be dropped otherwise.
+func (m *statusManager) pruneDeletedTenants() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for scope := range m.revisionReconciles {
+		if scope == "" {
+			continue
+		}
+		err := m.client.Get(context.TODO(), types.NamespacedName{Name: "default", Namespace: scope}, &operator.Tenant{})
+		if !errors.IsNotFound(err) {
+			continue
+		}
+		for component := range m.appliedRevisions {
+			if revisionScope(component) == scope {
+				delete(m.appliedRevisions, component)
+				delete(m.appliedRevisionsBy, component)
+			}
+		}
+		delete(m.revisionReconciles, scope)
+	}
+}
+
+// revisionScope returns the tenant namespace that the part of the component was rendered for, or an empty string for
+// parts that aren't rendered per tenant.
+func revisionScope(component string) string {
+	if i := strings.LastIndex(component, "/"); i >= 0 {
+		return component[i+1:]
+	}
+	return ""
+}
+
+// sortedAppliedRevisions returns the applied revisions ordered by the name of the part of the component. The caller
+// must hold the lock.
+func (m *statusManager) sortedAppliedRevisions() []operator.AppliedRevision {
+	var revisions []operator.AppliedRevision
+	for _, r := range m.appliedRevisions {
+		revisions = append(revisions, r)
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Component < revisions[j].Component })
+	return revisions
 }
 
 // AddDaemonsets tells the status manager to monitor the health of the given daemonsets.
@@ -435,6 +563,11 @@ func (m *statusManager) syncState() {
 	defer m.lock.Unlock()
 	progressing := []string{}
 	failing := []string{}
+	var versions []operator.ComponentVersion
+	var rollout *operator.RolloutStatus
+	if len(m.daemonsets) != 0 || len(m.deployments) != 0 {
+		rollout = &operator.RolloutStatus{}
+	}
 
 	// For each daemonset, check its rollout status.
 	for _, dsnn := range m.daemonsets {
@@ -444,6 +577,10 @@ func (m *statusManager) syncState() {
 			log.WithValues("reason", err).Info("Failed to query daemonset")
 			continue
 		}
+		versions = append(versions, m.workloadVersions("DaemonSet", dsnn, ds.Spec.Template, ds.Spec.Selector)...)
+		rollout.DesiredReplicas += ds.Status.DesiredNumberScheduled
+		rollout.UpdatedReplicas += ds.Status.UpdatedNumberScheduled
+		rollout.AvailableReplicas += ds.Status.NumberAvailable
 		if ds.Status.UpdatedNumberScheduled < ds.Status.DesiredNumberScheduled {
 			progressing = append(progressing, fmt.Sprintf("DaemonSet %q update is rolling out (%d out of %d updated)", dsnn.String(), ds.Status.UpdatedNumberScheduled, ds.Status.DesiredNumberScheduled))
 		} else if ds.Status.NumberUnavailable > 0 {
@@ -482,6 +619,7 @@ func (m *statusManager) syncState() {
 			log.WithValues("reason", err).Info("Failed to query deployment")
 			continue
 		}
+		versions = append(versions, m.workloadVersions("Deployment", depnn, dep.Spec.Template, dep.Spec.Selector)...)
 		if dep.Status.UnavailableReplicas > 0 {
 			progressing = append(progressing, fmt.Sprintf("Deployment %q is not available (awaiting %d replicas)", depnn.String(), dep.Status.UnavailableReplicas))
 		} else if dep.Status.AvailableReplicas == 0 {
@@ -494,6 +632,9 @@ func (m *statusManager) syncState() {
 		if dep.Spec.Replicas != nil {
 			replicas = *dep.Spec.Replicas
 		}
+		rollout.DesiredReplicas += replicas
+		rollout.UpdatedReplicas += dep.Status.UpdatedReplicas
+		rollout.AvailableReplicas += dep.Status.AvailableReplicas
 		// There could be old pods in the Errored, Terminated, or Completed state
 		// but if the following are true then we don't need to worry about those
 		// failed pods so continue.
@@ -522,6 +663,7 @@ func (m *statusManager) syncState() {
 			log.WithValues("reason", err).Info("Failed to query statefulset")
 			continue
 		}
+		versions = append(versions, m.workloadVersions("StatefulSet", depnn, ss.Spec.Template, ss.Spec.Selector)...)
 		if *ss.Spec.Replicas != ss.Status.CurrentReplicas {
 			progressing = append(progressing, fmt.Sprintf("Statefulset %q is not available (awaiting %d replicas)", depnn.String(), ss.Status.CurrentReplicas-*ss.Spec.Replicas))
 		} else if ss.Status.ObservedGeneration < ss.Generation {
@@ -587,11 +729,56 @@ func (m *statusManager) syncState() {
 		}
 	}
 
+	sort.Slice(versions, func(i, j int) bool {
+		if versions[i].Workload != versions[j].Workload {
+			return versions[i].Workload < versions[j].Workload
+		}
+		return versions[i].Container < versions[j].Container
+	})
+
 	m.progressing = progressing
 	m.failing = failing
+	m.rollout = rollout
+	m.versions = versions
 	m.hasSynced = true
 }
 
+// workloadVersions returns the images that the containers of the workload run. The image ID of a container is only
+// reported if every pod of the workload reports the same one.
+func (m *statusManager) workloadVersions(kind string, nn types.NamespacedName, template corev1.PodTemplateSpec, selector *metav1.LabelSelector) []operator.ComponentVersion {
+	imageIDs := map[string]map[string]bool{}
+	if s, err := metav1.LabelSelectorAsMap(selector); err == nil {
+		pods := corev1.PodList{}
+		if err := m.client.List(context.TODO(), &pods, client.MatchingLabels(s), client.InNamespace(nn.Namespace)); err != nil {
+			log.WithValues("reason", err, "workload", nn).V(1).Info("Failed to list pods for image versions")
+		}
+		for _, p := range pods.Items {
+			for _, c := range p.Status.ContainerStatuses {
+				if imageIDs[c.Name] == nil {
+					imageIDs[c.Name] = map[string]bool{}
+				}
+				imageIDs[c.Name][c.ImageID] = true
+			}
+		}
+	}
+
+	var versions []operator.ComponentVersion
+	for _, c := range template.Spec.Containers {
+		v := operator.ComponentVersion{
+			Workload:  fmt.Sprintf("%s/%s/%s", kind, nn.Namespace, nn.Name),
+			Container: c.Name,
+			Image:     c.Image,
+		}
+		if ids := imageIDs[c.Name]; len(ids) == 1 {
+			for id := range ids {
+				v.ImageID = id
+			}
+		}
+		versions = append(versions, v)
+	}
+	return versions
+}
+
 // isInitialized returns true if corresponding CR has been queried
 func (m *statusManager) isInitialized() bool {
 	m.lock.Lock()
@@ -714,8 +901,18 @@ func (m *statusManager) set(retry bool, conditions ...operator.TigeraStatusCondi
 		}
 	}
 
-	// If nothing has changed, we don't need to update in the API.
-	if reflect.DeepEqual(ts.Status.Conditions, old.Status.Conditions) {
+	if m.hasSynced {
+		ts.Status.Versions = m.versions
+	}
+	ts.Status.AppliedRevisions = m.sortedAppliedRevisions()
+	ts.Status.Certificates = m.certificates
+
+	// If nothing has changed, we don't need to update in the API. Timestamps read back from the API are in the local
+	// time zone, so those are compared semantically.
+	if reflect.DeepEqual(ts.Status.Conditions, old.Status.Conditions) &&
+		reflect.DeepEqual(ts.Status.Versions, old.Status.Versions) &&
+		equality.Semantic.DeepEqual(ts.Status.AppliedRevisions, old.Status.AppliedRevisions) &&
+		equality.Semantic.DeepEqual(ts.Status.Certificates, old.Status.Certificates) {
 		return
 	}
 
@@ -860,6 +1057,14 @@ func (m *statusManager) SetMetaData(meta *metav1.ObjectMeta) {
 	m.observedGeneration = meta.Generation
 }
 
+// RolloutStatus returns a summary of the rollout of the monitored deployments and daemonsets as of the last time their
+// state was synced, or nil if there are none.
+func (m *statusManager) RolloutStatus() *operator.RolloutStatus {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.rollout.DeepCopy()
+}
+
 func hasPendingCSR(ctx context.Context, m *statusManager, labelMap map[string]string) (bool, error) {
 	if m.kubernetesVersion.ProvidesCertV1API() {
 		return hasPendingCSRUsingCertV1(ctx, m.client, labelMap)
@@ -928,6 +1133,17 @@ func hasPendingCSRUsingCertV1beta1(ctx context.Context, cli client.Client, label
 }
 
 // UpdateStatusCondition updates CR's status conditions from tigerastatus conditions.
+// ObservedGeneration returns the generation of the CR that the given TigeraStatus conditions were last reported for.
+func ObservedGeneration(conditions []operator.TigeraStatusCondition) int64 {
+	var generation int64
+	for _, c := range conditions {
+		if c.ObservedGeneration > generation {
+			generation = c.ObservedGeneration
+		}
+	}
+	return generation
+}
+
 func UpdateStatusCondition(statuscondition []metav1.Condition, conditions []operator.TigeraStatusCondition) []metav1.Condition {
 	if statuscondition == nil {
 		statuscondition = []metav1.Condition{}
@@ -979,3 +1195,44 @@ func UpdateStatusCondition(statuscondition []metav1.Condition, conditions []oper
 	}
 	return statuscondition
 }
+
+// crConditionType returns the type used for a condition in a CR's status. CR statuses use the standard Ready type
+// where TigeraStatus uses Available.
+func crConditionType(ctype operator.StatusConditionType) string {
+	if ctype == operator.ComponentAvailable {
+		return string(operator.ComponentReady)
+	}
+	return string(ctype)
+}
+
+// SetStatusCondition sets a condition in a CR's status conditions, for controllers that report the status of their CR
+// directly rather than through TigeraStatus. The last transition time only changes when the status of the condition
+// does. Conditions with the legacy Available type are replaced by the equivalent Ready condition.
+func SetStatusCondition(conditions *[]metav1.Condition, ctype operator.StatusConditionType, status metav1.ConditionStatus, reason, msg string, generation int64) {
+	if reason == "" {
+		reason = string(operator.Unknown)
+	}
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               crConditionType(ctype),
+		Status:             status,
+		Reason:             reason,
+		Message:            msg,
+		ObservedGeneration: generation,
+	})
+	if ctype == operator.ComponentAvailable || ctype == operator.ComponentReady {
+		meta.RemoveStatusCondition(conditions, string(operator.ComponentAvailable))
+	}
+}
+
+// FindStatusCondition returns the condition of the given type from a CR's status conditions, or nil if there is none.
+// Ready and Available are treated as the same type, so that conditions written by older versions of the operator are
+// still found.
+func FindStatusCondition(conditions []metav1.Condition, ctype operator.StatusConditionType) *metav1.Condition {
+	if c := meta.FindStatusCondition(conditions, crConditionType(ctype)); c != nil {
+		return c
+	}
+	if ctype == operator.ComponentAvailable || ctype == operator.ComponentReady {
+		return meta.FindStatusCondition(conditions, string(operator.ComponentAvailable))
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ package status
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -32,6 +33,7 @@ import (
 
 	controllerRuntimeClient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	operator "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/apis"
@@ -117,6 +119,96 @@ var _ = Describe("Status reporting tests", func() {
 			sm.hasSynced = true
 		})
 
+		It("should report the applied revisions and only update when they were applied if they change", func() {
+			sm.SetAppliedRevision(ctx, "render.b", "rev1")
+			sm.SetAppliedRevision(ctx, "render.a", "rev1")
+			sm.updateStatus()
+
+			ts := &operator.TigeraStatus{}
+			Expect(client.Get(ctx, types.NamespacedName{Name: "test-component"}, ts)).NotTo(HaveOccurred())
+			Expect(ts.Status.AppliedRevisions).To(HaveLen(2))
+			Expect(ts.Status.AppliedRevisions[0].Component).To(Equal("render.a"))
+			Expect(ts.Status.AppliedRevisions[1].Component).To(Equal("render.b"))
+			appliedAt := ts.Status.AppliedRevisions[0].AppliedAt
+			Expect(appliedAt.IsZero()).To(BeFalse())
+
+			// Setting the same revision again keeps the time it was applied.
+			sm.appliedRevisions["render.a"] = operator.AppliedRevision{Component: "render.a", Revision: "rev1", AppliedAt: metav1.NewTime(appliedAt.Add(-time.Hour))}
+			sm.SetAppliedRevision(ctx, "render.a", "rev1")
+			Expect(sm.appliedRevisions["render.a"].AppliedAt.Time).To(Equal(appliedAt.Add(-time.Hour)))
+
+			sm.SetAppliedRevision(ctx, "render.a", "rev2")
+			sm.updateStatus()
+			Expect(client.Get(ctx, types.NamespacedName{Name: "test-component"}, ts)).NotTo(HaveOccurred())
+			Expect(ts.Status.AppliedRevisions[0].Revision).To(Equal("rev2"))
+			Expect(ts.Status.AppliedRevisions[0].AppliedAt.Time).NotTo(BeTemporally("<", appliedAt.Time))
+		})
+
+		Context("across reconciles", func() {
+			type reconcileKey struct{}
+			reconcile := func(id string) context.Context {
+				return context.WithValue(ctx, reconcileKey{}, types.UID(id))
+			}
+
+			BeforeEach(func() {
+				reconcileIDFromContext = func(ctx context.Context) types.UID {
+					id, _ := ctx.Value(reconcileKey{}).(types.UID)
+					return id
+				}
+			})
+
+			AfterEach(func() {
+				reconcileIDFromContext = controller.ReconcileIDFromContext
+			})
+
+			components := func() []string {
+				var names []string
+				for _, r := range sm.sortedAppliedRevisions() {
+					names = append(names, r.Component)
+				}
+				return names
+			}
+
+			It("should drop the revisions of parts that a reconcile no longer applies", func() {
+				sm.SetAppliedRevision(reconcile("1"), "render.a", "rev1")
+				sm.SetAppliedRevision(reconcile("1"), "render.b", "rev1")
+				sm.SetAppliedRevision(reconcile("1"), "render.a/tenant-a", "rev1")
+
+				// The second reconcile stops rendering render.b, which is only dropped once another reconcile starts.
+				sm.SetAppliedRevision(reconcile("2"), "render.a", "rev1")
+				Expect(components()).To(Equal([]string{"render.a", "render.a/tenant-a", "render.b"}))
+
+				sm.SetAppliedRevision(reconcile("3"), "render.a", "rev1")
+				Expect(components()).To(Equal([]string{"render.a", "render.a/tenant-a"}))
+			})
+
+			It("should drop the revisions of deleted tenants", func() {
+				Expect(client.Create(ctx, &operator.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "tenant-a"}})).NotTo(HaveOccurred())
+				sm.SetAppliedRevision(reconcile("1"), "render.a/tenant-a", "rev1")
+				sm.SetAppliedRevision(reconcile("2"), "render.a/tenant-b", "rev1")
+
+				sm.pruneDeletedTenants()
+				Expect(components()).To(Equal([]string{"render.a/tenant-a"}))
+			})
+		})
+
+		It("should report the certificates", func() {
+			notAfter := metav1.NewTime(time.Now().Add(time.Hour).Truncate(time.Second))
+			sm.SetCertificates([]operator.CertificateStatus{{Name: "a", Namespace: "ns", Issuer: "issuer", NotAfter: notAfter}})
+			sm.updateStatus()
+
+			ts := &operator.TigeraStatus{}
+			Expect(client.Get(ctx, types.NamespacedName{Name: "test-component"}, ts)).NotTo(HaveOccurred())
+			Expect(ts.Status.Certificates).To(HaveLen(1))
+			Expect(ts.Status.Certificates[0].Name).To(Equal("a"))
+			Expect(ts.Status.Certificates[0].NotAfter.Equal(&notAfter)).To(BeTrue())
+
+			sm.SetCertificates(nil)
+			sm.updateStatus()
+			Expect(client.Get(ctx, types.NamespacedName{Name: "test-component"}, ts)).NotTo(HaveOccurred())
+			Expect(ts.Status.Certificates).To(BeEmpty())
+		})
+
 		Context("ReadyToMonitor not called", func() {
 			When("it is not progressing or failing", func() {
 				It("should not be available, progressing, or degraded", func() {
@@ -337,6 +429,85 @@ var _ = Describe("Status reporting tests", func() {
 				sm.updateStatus()
 				Expect(sm.IsDegraded()).To(BeTrue())
 			})
+			It("should summarize the rollout of deployments and daemonsets", func() {
+				Expect(sm.RolloutStatus()).To(BeNil())
+				sm.AddDeployments([]types.NamespacedName{{Namespace: "NS1", Name: "DP1"}})
+				sm.AddDaemonsets([]types.NamespacedName{{Namespace: "NS1", Name: "DS1"}})
+				replicas := int32(2)
+
+				Expect(client.Create(ctx, &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "NS1", Name: "DP1", Generation: gen},
+					Spec: appsv1.DeploymentSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"dp1Key": "dp1Value"}},
+						Replicas: &replicas,
+					},
+					Status: appsv1.DeploymentStatus{
+						ObservedGeneration: gen,
+						UpdatedReplicas:    1,
+						AvailableReplicas:  2,
+						ReadyReplicas:      2,
+					},
+				})).NotTo(HaveOccurred())
+				Expect(client.Create(ctx, &appsv1.DaemonSet{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "NS1", Name: "DS1", Generation: gen},
+					Spec: appsv1.DaemonSetSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"ds1Key": "ds1Value"}},
+					},
+					Status: appsv1.DaemonSetStatus{
+						ObservedGeneration:     gen,
+						DesiredNumberScheduled: 3,
+						UpdatedNumberScheduled: 3,
+						NumberAvailable:        1,
+					},
+				})).NotTo(HaveOccurred())
+				sm.updateStatus()
+				Expect(sm.RolloutStatus()).To(Equal(&operator.RolloutStatus{
+					DesiredReplicas:   5,
+					UpdatedReplicas:   4,
+					AvailableReplicas: 3,
+				}))
+			})
+			It("should report the images of the workloads' containers", func() {
+				sm.AddDeployments([]types.NamespacedName{{Namespace: "NS1", Name: "DP2"}})
+				sm.AddDaemonsets([]types.NamespacedName{{Namespace: "NS1", Name: "DS2"}})
+				template := func(image string) corev1.PodTemplateSpec {
+					return corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: image}}}}
+				}
+				pod := func(name, key, imageID string) *corev1.Pod {
+					return &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "NS1", Name: name, Labels: map[string]string{key: "v"}},
+						Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "main", ImageID: imageID}}},
+					}
+				}
+
+				Expect(client.Create(ctx, &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "NS1", Name: "DP2", Generation: gen},
+					Spec: appsv1.DeploymentSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"dp2Key": "v"}},
+						Template: template("example.com/kube-controllers:v1"),
+					},
+				})).NotTo(HaveOccurred())
+				Expect(client.Create(ctx, &appsv1.DaemonSet{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "NS1", Name: "DS2", Generation: gen},
+					Spec: appsv1.DaemonSetSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"ds2Key": "v"}},
+						Template: template("example.com/node:v2"),
+					},
+				})).NotTo(HaveOccurred())
+				Expect(client.Create(ctx, pod("dp2-a", "dp2Key", "example.com/kube-controllers@sha256:aaa"))).NotTo(HaveOccurred())
+				Expect(client.Create(ctx, pod("dp2-b", "dp2Key", "example.com/kube-controllers@sha256:aaa"))).NotTo(HaveOccurred())
+				// The daemonset is rolling out, so its pods run different images.
+				Expect(client.Create(ctx, pod("ds2-a", "ds2Key", "example.com/node@sha256:bbb"))).NotTo(HaveOccurred())
+				Expect(client.Create(ctx, pod("ds2-b", "ds2Key", "example.com/node@sha256:ccc"))).NotTo(HaveOccurred())
+				sm.updateStatus()
+
+				ts := &operator.TigeraStatus{}
+				Expect(client.Get(ctx, types.NamespacedName{Name: "test-component"}, ts)).NotTo(HaveOccurred())
+				Expect(ts.Status.Versions).To(Equal([]operator.ComponentVersion{
+					{Workload: "DaemonSet/NS1/DS2", Container: "main", Image: "example.com/node:v2"},
+					{Workload: "Deployment/NS1/DP2", Container: "main", Image: "example.com/kube-controllers:v1", ImageID: "example.com/kube-controllers@sha256:aaa"},
+				}))
+			})
 			It("should not degrade when statefulset has the proper pod counts", func() {
 				sm.AddStatefulSets([]types.NamespacedName{{Namespace: "NS1", Name: "SS1"}})
 				replicas := int32(1)
@@ -645,3 +816,49 @@ var _ = Describe("Status reporting tests", func() {
 		)
 	})
 })
+
+var _ = Describe("CR status condition helpers", func() {
+	It("should report the latest generation that conditions were reported for", func() {
+		Expect(ObservedGeneration(nil)).To(Equal(int64(0)))
+		Expect(ObservedGeneration([]operator.TigeraStatusCondition{
+			{Type: operator.ComponentAvailable, ObservedGeneration: 2},
+			{Type: operator.ComponentDegraded, ObservedGeneration: 3},
+		})).To(Equal(int64(3)))
+	})
+
+	It("should report availability with the Ready type", func() {
+		var conditions []metav1.Condition
+		SetStatusCondition(&conditions, operator.ComponentAvailable, metav1.ConditionTrue, string(operator.AllObjectsAvailable), "All good", 3)
+
+		Expect(conditions).To(HaveLen(1))
+		Expect(conditions[0].Type).To(Equal(string(operator.ComponentReady)))
+		Expect(conditions[0].Status).To(Equal(metav1.ConditionTrue))
+		Expect(conditions[0].ObservedGeneration).To(Equal(int64(3)))
+		Expect(FindStatusCondition(conditions, operator.ComponentAvailable)).To(Equal(&conditions[0]))
+	})
+
+	It("should replace legacy Available conditions", func() {
+		conditions := []metav1.Condition{{Type: string(operator.ComponentAvailable), Status: metav1.ConditionFalse, Reason: "Old"}}
+		Expect(FindStatusCondition(conditions, operator.ComponentReady).Reason).To(Equal("Old"))
+
+		SetStatusCondition(&conditions, operator.ComponentReady, metav1.ConditionTrue, "", "", 1)
+		Expect(conditions).To(HaveLen(1))
+		Expect(conditions[0].Type).To(Equal(string(operator.ComponentReady)))
+		Expect(conditions[0].Reason).To(Equal(string(operator.Unknown)))
+	})
+
+	It("should only change the transition time when the status changes", func() {
+		var conditions []metav1.Condition
+		SetStatusCondition(&conditions, operator.ComponentDegraded, metav1.ConditionTrue, "Error", "broken", 1)
+		conditions[0].LastTransitionTime = metav1.NewTime(conditions[0].LastTransitionTime.Add(-time.Hour))
+		transitioned := conditions[0].LastTransitionTime
+
+		SetStatusCondition(&conditions, operator.ComponentDegraded, metav1.ConditionTrue, "Error", "still broken", 2)
+		Expect(conditions[0].LastTransitionTime).To(Equal(transitioned))
+		Expect(conditions[0].Message).To(Equal("still broken"))
+		Expect(conditions[0].ObservedGeneration).To(Equal(int64(2)))
+
+		SetStatusCondition(&conditions, operator.ComponentDegraded, metav1.ConditionFalse, "", "", 2)
+		Expect(conditions[0].LastTransitionTime).NotTo(Equal(transitioned))
+	})
+})
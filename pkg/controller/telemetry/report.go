@@ -0,0 +1,116 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ParseEndpoint parses and validates the URL that telemetry reports are posted to.
+func ParseEndpoint(endpoint string) (*url.URL, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("an endpoint must be provided when telemetry reporting is enabled")
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("endpoint %q must use https", endpoint)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("endpoint %q has no host", endpoint)
+	}
+	return u, nil
+}
+
+// Report is the anonymized information about an installation that is sent when telemetry is enabled. It must not
+// contain names, addresses or anything else that identifies the cluster or its workloads.
+type Report struct {
+	// InstallID is a hash of the UID of the kube-system namespace. It lets reports from the same cluster be
+	// correlated without identifying the cluster.
+	InstallID string `json:"installID"`
+
+	OperatorVersion string `json:"operatorVersion"`
+	Variant         string `json:"variant"`
+	Provider        string `json:"provider"`
+
+	// Components lists the optional components that are enabled, by the kind of their custom resource.
+	Components []string `json:"components"`
+
+	// Nodes is the bucket that the number of nodes in the cluster falls in, e.g., "11-50".
+	Nodes string `json:"nodes"`
+}
+
+// nodeBuckets are the upper bounds of the buckets that the node count is reported in.
+var nodeBuckets = []int{5, 10, 50, 100, 250, 500, 1000}
+
+// nodeCountBucket returns the bucket that the given number of nodes falls in.
+func nodeCountBucket(n int) string {
+	lower := 1
+	for _, upper := range nodeBuckets {
+		if n <= upper {
+			return fmt.Sprintf("%d-%d", lower, upper)
+		}
+		lower = upper + 1
+	}
+	return fmt.Sprintf("%d+", lower)
+}
+
+// installID returns the anonymized identifier of the cluster with the given kube-system namespace UID.
+func installID(uid string) string {
+	sum := sha256.Sum256([]byte(uid))
+	return hex.EncodeToString(sum[:])
+}
+
+// Sender sends a report to the given endpoint.
+type Sender func(ctx context.Context, endpoint *url.URL, report *Report) error
+
+// sendTimeout bounds how long sending a report may take, so that an unreachable endpoint doesn't hold up the
+// controller.
+const sendTimeout = 10 * time.Second
+
+// HTTPSender is a Sender that posts the report as JSON.
+func HTTPSender(ctx context.Context, endpoint *url.URL, report *Report) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("telemetry endpoint returned %s", resp.Status)
+	}
+	return nil
+}
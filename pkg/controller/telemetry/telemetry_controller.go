@@ -0,0 +1,220 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/utils"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	"github.com/tigera/operator/pkg/logging"
+	"github.com/tigera/operator/version"
+)
+
+// The telemetry controller sends a periodic report of anonymized information about the installation when reporting
+// is enabled in the Installation. The operator runs on the host network, so reaching the telemetry endpoint is up to
+// the host endpoint policy of the cluster, if there is any.
+
+const (
+	ControllerName = "telemetry-controller"
+
+	// ReportInterval is how often a report is sent.
+	ReportInterval = 24 * time.Hour
+
+	// retryInterval is how long to wait before retrying a report that failed to send.
+	retryInterval = time.Hour
+)
+
+var log = logf.Log.WithName("controller_telemetry")
+
+// Add creates a new telemetry Controller and adds it to the Manager.
+// The Manager will set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager, opts options.AddOptions) error {
+	r := newReconciler(mgr, opts)
+
+	c, err := ctrlruntime.NewController(ControllerName, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", ControllerName, err)
+	}
+
+	if err = utils.AddInstallationWatch(c); err != nil {
+		return fmt.Errorf("%s failed to watch Installation resource: %w", ControllerName, err)
+	}
+
+	return nil
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, opts options.AddOptions) *ReconcileTelemetry {
+	return &ReconcileTelemetry{
+		client:              mgr.GetClient(),
+		reader:              mgr.GetAPIReader(),
+		provider:            opts.DetectedProvider,
+		enterpriseCRDsExist: opts.EnterpriseCRDExists,
+		send:                HTTPSender,
+	}
+}
+
+var _ reconcile.Reconciler = &ReconcileTelemetry{}
+
+type ReconcileTelemetry struct {
+	client client.Client
+
+	// reader is used for the reads that are only needed when a report is sent, so that the operator doesn't cache
+	// every node in the cluster for the sake of counting them once a day.
+	reader client.Reader
+
+	provider            operatorv1.Provider
+	enterpriseCRDsExist bool
+	send                Sender
+
+	// lastReport is when a report was last sent, so that changes to the Installation don't trigger extra reports.
+	lastReport time.Time
+}
+
+func (r *ReconcileTelemetry) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := logging.ForReconcile(ctx, log).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.V(1).Info("Reconciling telemetry")
+
+	variant, installation, err := utils.GetInstallation(ctx, r.client)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			reqLogger.V(1).Info("Installation not found")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	var endpoint *url.URL
+	if installation.TelemetryEnabled() {
+		if endpoint, err = ParseEndpoint(installation.Telemetry.Endpoint); err != nil {
+			// The Installation controller reports the invalid endpoint in its status.
+			reqLogger.Info("Not sending telemetry", "reason", err.Error())
+			return reconcile.Result{}, nil
+		}
+	}
+
+	if endpoint == nil {
+		r.lastReport = time.Time{}
+		return reconcile.Result{}, nil
+	}
+	if wait := ReportInterval - time.Since(r.lastReport); wait > 0 {
+		return reconcile.Result{RequeueAfter: wait}, nil
+	}
+
+	if variant == "" {
+		variant = installation.Variant
+	}
+	provider := installation.KubernetesProvider
+	if provider == operatorv1.ProviderNone {
+		provider = r.provider
+	}
+	report, err := r.buildReport(ctx, variant, provider)
+	if err != nil {
+		reqLogger.Info("Failed to build telemetry report", "error", err.Error())
+		return reconcile.Result{RequeueAfter: retryInterval}, nil
+	}
+	if err = r.send(ctx, endpoint, report); err != nil {
+		reqLogger.Info("Failed to send telemetry report", "endpoint", endpoint.Host, "error", err.Error())
+		return reconcile.Result{RequeueAfter: retryInterval}, nil
+	}
+	r.lastReport = time.Now()
+	reqLogger.V(1).Info("Sent telemetry report", "endpoint", endpoint.Host)
+	return reconcile.Result{RequeueAfter: ReportInterval}, nil
+}
+
+// component is an optional component that is reported as enabled when its custom resource exists.
+type component struct {
+	kind       string
+	key        client.ObjectKey
+	obj        func() client.Object
+	enterprise bool
+}
+
+var components = []component{
+	{kind: "APIServer", key: utils.DefaultInstanceKey, obj: func() client.Object { return &operatorv1.APIServer{} }},
+	{kind: "APIServer", key: utils.DefaultTSEEInstanceKey, obj: func() client.Object { return &operatorv1.APIServer{} }},
+	{kind: "ApplicationLayer", key: utils.DefaultTSEEInstanceKey, obj: func() client.Object { return &operatorv1.ApplicationLayer{} }, enterprise: true},
+	{kind: "Authentication", key: utils.DefaultTSEEInstanceKey, obj: func() client.Object { return &operatorv1.Authentication{} }, enterprise: true},
+	{kind: "CloudConnector", key: utils.DefaultTSEEInstanceKey, obj: func() client.Object { return &operatorv1.CloudConnector{} }, enterprise: true},
+	{kind: "Compliance", key: utils.DefaultTSEEInstanceKey, obj: func() client.Object { return &operatorv1.Compliance{} }, enterprise: true},
+	{kind: "IntrusionDetection", key: utils.DefaultTSEEInstanceKey, obj: func() client.Object { return &operatorv1.IntrusionDetection{} }, enterprise: true},
+	{kind: "LogCollector", key: utils.DefaultTSEEInstanceKey, obj: func() client.Object { return &operatorv1.LogCollector{} }, enterprise: true},
+	{kind: "LogStorage", key: utils.DefaultTSEEInstanceKey, obj: func() client.Object { return &operatorv1.LogStorage{} }, enterprise: true},
+	{kind: "ManagementCluster", key: utils.DefaultTSEEInstanceKey, obj: func() client.Object { return &operatorv1.ManagementCluster{} }, enterprise: true},
+	{kind: "ManagementClusterConnection", key: utils.DefaultTSEEInstanceKey, obj: func() client.Object { return &operatorv1.ManagementClusterConnection{} }, enterprise: true},
+	{kind: "Manager", key: utils.DefaultTSEEInstanceKey, obj: func() client.Object { return &operatorv1.Manager{} }, enterprise: true},
+	{kind: "Monitor", key: utils.DefaultTSEEInstanceKey, obj: func() client.Object { return &operatorv1.Monitor{} }, enterprise: true},
+	{kind: "PacketCaptureAPI", key: utils.DefaultTSEEInstanceKey, obj: func() client.Object { return &operatorv1.PacketCaptureAPI{} }, enterprise: true},
+	{kind: "PolicyRecommendation", key: utils.DefaultTSEEInstanceKey, obj: func() client.Object { return &operatorv1.PolicyRecommendation{} }, enterprise: true},
+}
+
+// buildReport collects the anonymized information about the installation.
+func (r *ReconcileTelemetry) buildReport(ctx context.Context, variant operatorv1.ProductVariant, provider operatorv1.Provider) (*Report, error) {
+	ns := &metav1.PartialObjectMetadata{}
+	ns.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Namespace"))
+	if err := r.reader.Get(ctx, client.ObjectKey{Name: metav1.NamespaceSystem}, ns); err != nil {
+		return nil, fmt.Errorf("failed to read the %s namespace: %w", metav1.NamespaceSystem, err)
+	}
+
+	nodes := &metav1.PartialObjectMetadataList{}
+	nodes.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("NodeList"))
+	if err := r.reader.List(ctx, nodes); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	report := &Report{
+		InstallID:       installID(string(ns.UID)),
+		OperatorVersion: version.VERSION,
+		Variant:         string(variant),
+		Provider:        string(provider),
+		Components:      []string{},
+		Nodes:           nodeCountBucket(len(nodes.Items)),
+	}
+	if report.Provider == "" {
+		report.Provider = "Unknown"
+	}
+
+	for _, c := range components {
+		if c.enterprise && !r.enterpriseCRDsExist {
+			continue
+		}
+		if len(report.Components) > 0 && report.Components[len(report.Components)-1] == c.kind {
+			continue
+		}
+		if err := r.client.Get(ctx, c.key, c.obj()); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", c.kind, err)
+		}
+		report.Components = append(report.Components, c.kind)
+	}
+	return report, nil
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+var _ = Describe("telemetry controller tests", func() {
+	var (
+		r            *ReconcileTelemetry
+		c            client.Client
+		ctx          context.Context
+		installation *operatorv1.Installation
+		reports      []*Report
+		endpoints    []string
+		sendErr      error
+	)
+
+	enabled := operatorv1.TelemetryReportingEnabled
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(corev1.AddToScheme(scheme)).NotTo(HaveOccurred())
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+
+		reports, endpoints, sendErr = nil, nil, nil
+		r = &ReconcileTelemetry{
+			client:              c,
+			reader:              c,
+			provider:            operatorv1.ProviderEKS,
+			enterpriseCRDsExist: true,
+			send: func(ctx context.Context, endpoint *url.URL, report *Report) error {
+				endpoints = append(endpoints, endpoint.String())
+				reports = append(reports, report)
+				return sendErr
+			},
+		}
+
+		Expect(c.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-system", UID: "kube-system-uid"},
+		})).NotTo(HaveOccurred())
+		for i := 0; i < 12; i++ {
+			Expect(c.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("node-%d", i)}})).NotTo(HaveOccurred())
+		}
+		Expect(c.Create(ctx, &operatorv1.APIServer{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &operatorv1.Manager{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}})).NotTo(HaveOccurred())
+
+		installation = &operatorv1.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: operatorv1.InstallationSpec{
+				Variant: operatorv1.TigeraSecureEnterprise,
+				Telemetry: &operatorv1.Telemetry{
+					Reporting: &enabled,
+					Endpoint:  "https://telemetry.example.com:8443/report",
+				},
+			},
+			Status: operatorv1.InstallationStatus{Variant: operatorv1.TigeraSecureEnterprise},
+		}
+	})
+
+	It("doesn't report anything unless reporting is enabled", func() {
+		installation.Spec.Telemetry = nil
+		Expect(c.Create(ctx, installation)).NotTo(HaveOccurred())
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(reports).To(BeEmpty())
+	})
+
+	It("sends an anonymized report once per interval", func() {
+		Expect(c.Create(ctx, installation)).NotTo(HaveOccurred())
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(ReportInterval))
+		Expect(endpoints).To(Equal([]string{"https://telemetry.example.com:8443/report"}))
+		Expect(reports).To(HaveLen(1))
+		Expect(*reports[0]).To(Equal(Report{
+			InstallID:       installID("kube-system-uid"),
+			OperatorVersion: reports[0].OperatorVersion,
+			Variant:         "TigeraSecureEnterprise",
+			Provider:        "EKS",
+			Components:      []string{"APIServer", "Manager"},
+			Nodes:           "11-50",
+		}))
+		Expect(reports[0].InstallID).NotTo(ContainSubstring("kube-system-uid"))
+
+		// A reconcile triggered by a change to the Installation doesn't send another report.
+		result, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", ReportInterval-time.Minute))
+		Expect(reports).To(HaveLen(1))
+	})
+
+	It("retries a report that fails to send", func() {
+		Expect(c.Create(ctx, installation)).NotTo(HaveOccurred())
+		sendErr = fmt.Errorf("connection refused")
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(retryInterval))
+
+		sendErr = nil
+		_, err = r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reports).To(HaveLen(2))
+	})
+
+	DescribeTable("node count buckets", func(nodes int, expected string) {
+		Expect(nodeCountBucket(nodes)).To(Equal(expected))
+	},
+		Entry("one node", 1, "1-5"),
+		Entry("upper bound", 10, "6-10"),
+		Entry("lower bound", 11, "11-50"),
+		Entry("largest bucket", 1000, "501-1000"),
+		Entry("more than the largest bucket", 5000, "1001+"),
+	)
+
+	DescribeTable("rejects invalid endpoints", func(endpoint, msg string) {
+		_, err := ParseEndpoint(endpoint)
+		Expect(err).To(MatchError(ContainSubstring(msg)))
+	},
+		Entry("missing", "", "an endpoint must be provided"),
+		Entry("plain http", "http://telemetry.example.com/report", "must use https"),
+		Entry("no host", "https:///report", "has no host"),
+	)
+})
@@ -0,0 +1,202 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package typhahealth summarizes the connections from Felix to Typha in the Installation status, so that problems at
+// node scale, such as Typha being saturated and dropping its clients, are visible without a dashboard. The counts are
+// scraped from the Prometheus metrics of each Typha pod, which are only served if spec.typhaMetricsPort is set.
+package typhahealth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/render"
+)
+
+const (
+	// ScrapeInterval is how often the metrics of Typha are scraped.
+	ScrapeInterval = 5 * time.Minute
+
+	// scrapeTimeout is how long scraping the metrics of a Typha pod may take.
+	scrapeTimeout = 5 * time.Second
+
+	// The metrics of Typha that the connection counts are read from. Every connection that was accepted and is no
+	// longer active was closed, either by Typha or by Felix.
+	metricConnectionsAccepted = "typha_connections_accepted"
+	metricConnectionsActive   = "typha_connections_active"
+)
+
+// MetricsReader returns the Prometheus metrics served at the given URL, in the text exposition format.
+type MetricsReader func(ctx context.Context, url string) ([]byte, error)
+
+// HTTPMetricsReader is a MetricsReader that fetches the metrics over HTTP.
+func HTTPMetricsReader(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, scrapeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Collect returns the Typha health status of the Installation. The metrics of the running Typha pods are scraped at
+// most once every ScrapeInterval; until then, the current status is returned. The disconnect rate is worked out from
+// the counts of the previous scrape, which is why it's only set from the second scrape on.
+func Collect(ctx context.Context, c client.Client, installation *operatorv1.Installation, read MetricsReader, now time.Time) (*operatorv1.TyphaHealthStatus, error) {
+	port := installation.Spec.TyphaMetricsPort
+	if port == nil {
+		return nil, nil
+	}
+	previous := installation.Status.TyphaHealth
+	if previous != nil && now.Sub(previous.LastScrapeTime.Time) < ScrapeInterval {
+		return previous, nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(common.CalicoNamespace), client.MatchingLabels{"k8s-app": render.TyphaK8sAppName}); err != nil {
+		return previous, err
+	}
+
+	status := &operatorv1.TyphaHealthStatus{LastScrapeTime: metav1.NewTime(now)}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+		instance := operatorv1.TyphaInstanceHealth{Pod: pod.Name, Node: pod.Spec.NodeName}
+		url := fmt.Sprintf("http://%s/metrics", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(*port))))
+		if metrics, err := read(ctx, url); err != nil {
+			instance.Error = err.Error()
+		} else if err = parse(metrics, &instance); err != nil {
+			instance.Error = err.Error()
+		}
+		status.Connections += instance.Connections
+		status.Instances = append(status.Instances, instance)
+	}
+	sort.Slice(status.Instances, func(i, j int) bool { return status.Instances[i].Pod < status.Instances[j].Pod })
+
+	if previous != nil {
+		status.DisconnectsPerHour = disconnectsPerHour(previous, status)
+	}
+	return status, nil
+}
+
+// RequeueAfter returns how long until the metrics of Typha should be scraped again, or 0 if they aren't scraped.
+func RequeueAfter(status *operatorv1.TyphaHealthStatus, now time.Time) time.Duration {
+	if status == nil {
+		return 0
+	}
+	if d := ScrapeInterval - now.Sub(status.LastScrapeTime.Time); d > 0 {
+		return d
+	}
+	return time.Second
+}
+
+// parse reads the connection counts of a Typha instance from its metrics.
+func parse(metrics []byte, instance *operatorv1.TyphaInstanceHealth) error {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(metrics))
+	if err != nil {
+		return fmt.Errorf("failed to parse the metrics: %w", err)
+	}
+	accepted, err := value(families, metricConnectionsAccepted)
+	if err != nil {
+		return err
+	}
+	active, err := value(families, metricConnectionsActive)
+	if err != nil {
+		return err
+	}
+	instance.Connections = int32(active)
+	instance.Disconnects = int64(math.Max(accepted-active, 0))
+	return nil
+}
+
+// value returns the value of the metric with the given name.
+func value(families map[string]*dto.MetricFamily, name string) (float64, error) {
+	family, ok := families[name]
+	if !ok || len(family.Metric) == 0 {
+		return 0, fmt.Errorf("metric %s is missing", name)
+	}
+	m := family.Metric[0]
+	switch {
+	case m.Counter != nil:
+		return m.Counter.GetValue(), nil
+	case m.Gauge != nil:
+		return m.Gauge.GetValue(), nil
+	case m.Untyped != nil:
+		return m.Untyped.GetValue(), nil
+	}
+	return 0, fmt.Errorf("metric %s has an unexpected type", name)
+}
+
+// disconnectsPerHour returns the rate at which connections were closed between two scrapes, counting the instances
+// that were scraped both times. An instance whose count went down has restarted, so all its disconnects are new.
+func disconnectsPerHour(previous, current *operatorv1.TyphaHealthStatus) *int32 {
+	elapsed := current.LastScrapeTime.Sub(previous.LastScrapeTime.Time)
+	if elapsed <= 0 {
+		return nil
+	}
+	before := map[string]int64{}
+	for _, instance := range previous.Instances {
+		if instance.Error == "" {
+			before[instance.Pod] = instance.Disconnects
+		}
+	}
+
+	var disconnects int64
+	compared := false
+	for _, instance := range current.Instances {
+		count, ok := before[instance.Pod]
+		if !ok || instance.Error != "" {
+			continue
+		}
+		compared = true
+		if instance.Disconnects >= count {
+			disconnects += instance.Disconnects - count
+		} else {
+			disconnects += instance.Disconnects
+		}
+	}
+	if !compared {
+		return nil
+	}
+	rate := int32(math.Round(float64(disconnects) / elapsed.Hours()))
+	return &rate
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typhahealth_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/typhahealth"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+func typhaMetrics(accepted, active int) []byte {
+	return []byte(fmt.Sprintf(`# HELP typha_connections_accepted Total number of connections accepted over time.
+# TYPE typha_connections_accepted counter
+typha_connections_accepted %d
+# HELP typha_connections_active Number of open client connections.
+# TYPE typha_connections_active gauge
+typha_connections_active %d
+`, accepted, active))
+}
+
+var _ = Describe("Typha health tests", func() {
+	var (
+		c            client.Client
+		ctx          context.Context
+		installation *operatorv1.Installation
+		now          time.Time
+		metrics      map[string][]byte
+		read         typhahealth.MetricsReader
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(corev1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+		now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+		installation = &operatorv1.Installation{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec:       operatorv1.InstallationSpec{TyphaMetricsPort: ptr.Int32ToPtr(9093)},
+		}
+		metrics = map[string][]byte{}
+		read = func(ctx context.Context, url string) ([]byte, error) {
+			m, ok := metrics[url]
+			if !ok {
+				return nil, fmt.Errorf("connection refused")
+			}
+			return m, nil
+		}
+
+		for i, node := range []string{"node-a", "node-b"} {
+			Expect(c.Create(ctx, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "calico-typha-" + node,
+					Namespace: common.CalicoNamespace,
+					Labels:    map[string]string{"k8s-app": "calico-typha"},
+				},
+				Spec:   corev1.PodSpec{NodeName: node},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: fmt.Sprintf("10.0.0.%d", i+1)},
+			})).NotTo(HaveOccurred())
+		}
+	})
+
+	It("should not scrape Typha if it doesn't serve metrics", func() {
+		installation.Spec.TyphaMetricsPort = nil
+		status, err := typhahealth.Collect(ctx, c, installation, read, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(BeNil())
+		Expect(typhahealth.RequeueAfter(status, now)).To(BeZero())
+	})
+
+	It("should report the connections of each Typha instance", func() {
+		metrics["http://10.0.0.1:9093/metrics"] = typhaMetrics(120, 100)
+		metrics["http://10.0.0.2:9093/metrics"] = typhaMetrics(60, 50)
+
+		status, err := typhahealth.Collect(ctx, c, installation, read, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(&operatorv1.TyphaHealthStatus{
+			LastScrapeTime: metav1.NewTime(now),
+			Connections:    150,
+			Instances: []operatorv1.TyphaInstanceHealth{
+				{Pod: "calico-typha-node-a", Node: "node-a", Connections: 100, Disconnects: 20},
+				{Pod: "calico-typha-node-b", Node: "node-b", Connections: 50, Disconnects: 10},
+			},
+		}))
+		Expect(typhahealth.RequeueAfter(status, now)).To(Equal(typhahealth.ScrapeInterval))
+	})
+
+	It("should report the instances that couldn't be scraped", func() {
+		metrics["http://10.0.0.1:9093/metrics"] = typhaMetrics(120, 100)
+		metrics["http://10.0.0.2:9093/metrics"] = []byte("typha_connections_active 50\n")
+
+		status, err := typhahealth.Collect(ctx, c, installation, read, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Connections).To(Equal(int32(100)))
+		Expect(status.Instances[1].Error).To(Equal("metric typha_connections_accepted is missing"))
+	})
+
+	It("should only scrape Typha once every scrape interval", func() {
+		installation.Status.TyphaHealth = &operatorv1.TyphaHealthStatus{
+			LastScrapeTime: metav1.NewTime(now.Add(-time.Minute)),
+			Connections:    10,
+		}
+		status, err := typhahealth.Collect(ctx, c, installation, read, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(installation.Status.TyphaHealth))
+		Expect(typhahealth.RequeueAfter(status, now)).To(Equal(typhahealth.ScrapeInterval - time.Minute))
+	})
+
+	It("should work out the disconnect rate from the previous scrape", func() {
+		installation.Status.TyphaHealth = &operatorv1.TyphaHealthStatus{
+			LastScrapeTime: metav1.NewTime(now.Add(-30 * time.Minute)),
+			Connections:    150,
+			Instances: []operatorv1.TyphaInstanceHealth{
+				{Pod: "calico-typha-node-a", Node: "node-a", Connections: 100, Disconnects: 20},
+				// This instance has restarted since, so all of its disconnects are new.
+				{Pod: "calico-typha-node-b", Node: "node-b", Connections: 50, Disconnects: 40},
+			},
+		}
+		metrics["http://10.0.0.1:9093/metrics"] = typhaMetrics(150, 100)
+		metrics["http://10.0.0.2:9093/metrics"] = typhaMetrics(55, 50)
+
+		status, err := typhahealth.Collect(ctx, c, installation, read, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.DisconnectsPerHour).To(Equal(ptr.Int32ToPtr(70)))
+	})
+
+	It("should not work out a disconnect rate without a previous scrape of the same instances", func() {
+		installation.Status.TyphaHealth = &operatorv1.TyphaHealthStatus{
+			LastScrapeTime: metav1.NewTime(now.Add(-30 * time.Minute)),
+			Instances: []operatorv1.TyphaInstanceHealth{
+				{Pod: "calico-typha-old", Node: "node-c", Connections: 100, Disconnects: 20},
+			},
+		}
+		metrics["http://10.0.0.1:9093/metrics"] = typhaMetrics(150, 100)
+		metrics["http://10.0.0.2:9093/metrics"] = typhaMetrics(55, 50)
+
+		status, err := typhahealth.Collect(ctx, c, installation, read, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.DisconnectsPerHour).To(BeNil())
+	})
+})
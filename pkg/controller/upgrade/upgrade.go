@@ -0,0 +1,284 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package upgrade sequences the rollout of a new version across the components that the operator manages. An upgrade
+// is made up of stages, which are rolled out one at a time in a fixed order. Each stage runs its pre hooks, rolls out
+// the new version, waits for it to be ready and then runs its post hooks before the next stage starts.
+package upgrade
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+// Stages are the stages of an upgrade, in the order that they're rolled out.
+var Stages = []operatorv1.UpgradeStage{
+	operatorv1.UpgradeStageCRDs,
+	operatorv1.UpgradeStageAPIServer,
+	operatorv1.UpgradeStageTypha,
+	operatorv1.UpgradeStageNode,
+	operatorv1.UpgradeStageComponents,
+}
+
+const (
+	// HookLabel is set on the Jobs of upgrade hooks, with the name of the hook as the value.
+	HookLabel = "operator.tigera.io/upgrade-hook"
+
+	// hookJobTTL is how long a finished hook Job is kept, so that its logs can be inspected.
+	hookJobTTL = 7 * 24 * 60 * 60
+)
+
+// Start returns the status to track for an upgrade from the installed version to the target version. A new upgrade is
+// started when the versions differ and the current status isn't already tracking an upgrade to the target. Otherwise,
+// the current status is returned. A fresh installation, with no installed version, isn't an upgrade.
+func Start(current *operatorv1.UpgradeStatus, installed, target string) *operatorv1.UpgradeStatus {
+	if installed == "" || installed == target {
+		return current
+	}
+	if current != nil && current.ToVersion == target {
+		return current
+	}
+	status := &operatorv1.UpgradeStatus{FromVersion: installed, ToVersion: target}
+	for _, stage := range Stages {
+		status.Stages = append(status.Stages, operatorv1.UpgradeStageStatus{
+			Stage:              stage,
+			State:              operatorv1.UpgradeStagePending,
+			LastTransitionTime: metav1.Now(),
+		})
+	}
+	return status
+}
+
+// InProgress returns true if the status records an upgrade that has stages that aren't complete.
+func InProgress(status *operatorv1.UpgradeStatus) bool {
+	if status == nil {
+		return false
+	}
+	for _, s := range status.Stages {
+		if s.State != operatorv1.UpgradeStageComplete {
+			return true
+		}
+	}
+	return false
+}
+
+// Allowed returns true if the new version of the given stage may be applied, i.e. there is no upgrade in progress or
+// the stage's pre hooks have succeeded.
+func Allowed(status *operatorv1.UpgradeStatus, stage operatorv1.UpgradeStage) bool {
+	if !InProgress(status) {
+		return true
+	}
+	for _, s := range status.Stages {
+		if s.Stage == stage {
+			return s.State != operatorv1.UpgradeStagePending && s.State != operatorv1.UpgradeStagePreHooks
+		}
+	}
+	return true
+}
+
+// RolloutCheck returns true once the new version of the given stage has rolled out and is ready.
+type RolloutCheck func(ctx context.Context, stage operatorv1.UpgradeStage) (bool, error)
+
+// Coordinator moves an upgrade through its stages.
+type Coordinator struct {
+	client      client.Client
+	scheme      *runtime.Scheme
+	owner       metav1.Object
+	hooks       []operatorv1.UpgradeHook
+	pullSecrets []corev1.LocalObjectReference
+	rolledOut   RolloutCheck
+}
+
+// NewCoordinator returns a Coordinator that runs the given hooks, owned by the owner, and uses rolledOut to check
+// whether a stage has finished rolling out.
+func NewCoordinator(c client.Client, scheme *runtime.Scheme, owner metav1.Object, hooks []operatorv1.UpgradeHook, pullSecrets []corev1.LocalObjectReference, rolledOut RolloutCheck) *Coordinator {
+	return &Coordinator{
+		client:      c,
+		scheme:      scheme,
+		owner:       owner,
+		hooks:       hooks,
+		pullSecrets: pullSecrets,
+		rolledOut:   rolledOut,
+	}
+}
+
+// Advance moves the upgrade recorded in the status as far along as it can, updating the status in place. It stops at
+// the first stage that is waiting for a hook or for its rollout. A stage that has just become allowed to roll out isn't
+// checked until the next call, so that the caller has a chance to apply it first. An error is returned if a hook has
+// failed.
+func (c *Coordinator) Advance(ctx context.Context, status *operatorv1.UpgradeStatus) error {
+	if !InProgress(status) {
+		return nil
+	}
+	for i := range status.Stages {
+		s := &status.Stages[i]
+		switch s.State {
+		case operatorv1.UpgradeStageComplete:
+			continue
+		case operatorv1.UpgradeStagePending, operatorv1.UpgradeStagePreHooks:
+			done, err := c.runHooks(ctx, status.ToVersion, s.Stage, operatorv1.UpgradeHookPhasePre)
+			if err != nil {
+				setState(s, operatorv1.UpgradeStagePreHooks, err.Error())
+				return err
+			}
+			if !done {
+				setState(s, operatorv1.UpgradeStagePreHooks, "Waiting for pre-upgrade hooks to complete")
+				return nil
+			}
+			setState(s, operatorv1.UpgradeStageRollingOut, "")
+			return nil
+		case operatorv1.UpgradeStageRollingOut:
+			done, err := c.rolledOut(ctx, s.Stage)
+			if err != nil {
+				return err
+			}
+			if !done {
+				s.Message = "Waiting for the new version to roll out"
+				return nil
+			}
+			setState(s, operatorv1.UpgradeStagePostHooks, "")
+			fallthrough
+		case operatorv1.UpgradeStagePostHooks:
+			done, err := c.runHooks(ctx, status.ToVersion, s.Stage, operatorv1.UpgradeHookPhasePost)
+			if err != nil {
+				s.Message = err.Error()
+				return err
+			}
+			if !done {
+				s.Message = "Waiting for post-upgrade hooks to complete"
+				return nil
+			}
+			setState(s, operatorv1.UpgradeStageComplete, "")
+		}
+	}
+	return nil
+}
+
+// runHooks creates the Jobs of the hooks for the given stage and phase, and returns true once they have all succeeded.
+func (c *Coordinator) runHooks(ctx context.Context, version string, stage operatorv1.UpgradeStage, phase operatorv1.UpgradeHookPhase) (bool, error) {
+	done := true
+	for _, hook := range c.hooks {
+		if hook.Stage != stage || hook.Phase != phase {
+			continue
+		}
+
+		name := HookJobName(hook, version)
+		job := &batchv1.Job{}
+		err := c.client.Get(ctx, types.NamespacedName{Name: name, Namespace: common.OperatorNamespace()}, job)
+		if apierrors.IsNotFound(err) {
+			job = c.hookJob(hook, name)
+			if err := controllerutil.SetControllerReference(c.owner, job, c.scheme); err != nil {
+				return false, err
+			}
+			if err := c.client.Create(ctx, job); err != nil {
+				return false, fmt.Errorf("failed to create Job for upgrade hook %s: %w", hook.Name, err)
+			}
+			done = false
+			continue
+		} else if err != nil {
+			return false, err
+		}
+
+		for _, cond := range job.Status.Conditions {
+			if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
+				return false, fmt.Errorf("upgrade hook %s failed: %s; delete Job %s/%s to run it again", hook.Name, cond.Message, job.Namespace, job.Name)
+			}
+		}
+		if job.Status.Succeeded == 0 {
+			done = false
+		}
+	}
+	return done, nil
+}
+
+func (c *Coordinator) hookJob(hook operatorv1.UpgradeHook, name string) *batchv1.Job {
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{Kind: "Job", APIVersion: "batch/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: common.OperatorNamespace(),
+			Labels:    map[string]string{HookLabel: hook.Name},
+		},
+		Spec: batchv1.JobSpec{
+			TTLSecondsAfterFinished: ptr.Int32ToPtr(hookJobTTL),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{HookLabel: hook.Name},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: hook.ServiceAccountName,
+					ImagePullSecrets:   c.pullSecrets,
+					Containers: []corev1.Container{{
+						Name:    "hook",
+						Image:   hook.Image,
+						Command: hook.Command,
+						Args:    hook.Args,
+						Env:     hook.Env,
+					}},
+				},
+			},
+		},
+	}
+}
+
+// HookJobName returns the name of the Job that runs the hook when upgrading to the given version. The name includes a
+// hash of the version so that the hook runs once for each upgrade.
+func HookJobName(hook operatorv1.UpgradeHook, version string) string {
+	h := sha256.Sum256([]byte(version))
+	return fmt.Sprintf("tigera-upgrade-%s-%s", hook.Name, hex.EncodeToString(h[:])[:7])
+}
+
+// DeploymentRolledOut returns true if every replica of the deployment is running its current spec and is available.
+func DeploymentRolledOut(d *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	return d.Status.ObservedGeneration >= d.Generation &&
+		d.Status.UpdatedReplicas == replicas &&
+		d.Status.Replicas == replicas &&
+		d.Status.AvailableReplicas == replicas
+}
+
+// DaemonSetRolledOut returns true if every pod of the daemonset is running its current spec and is available.
+func DaemonSetRolledOut(ds *appsv1.DaemonSet) bool {
+	return ds.Status.ObservedGeneration >= ds.Generation &&
+		ds.Status.UpdatedNumberScheduled == ds.Status.DesiredNumberScheduled &&
+		ds.Status.NumberAvailable == ds.Status.DesiredNumberScheduled
+}
+
+func setState(s *operatorv1.UpgradeStageStatus, state operatorv1.UpgradeStageState, message string) {
+	if s.State != state {
+		s.State = state
+		s.LastTransitionTime = metav1.Now()
+	}
+	s.Message = message
+}
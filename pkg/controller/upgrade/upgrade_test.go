@@ -0,0 +1,183 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/upgrade"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+var _ = Describe("Upgrade tests", func() {
+	It("should only start an upgrade when the installed version differs from the target", func() {
+		Expect(upgrade.Start(nil, "", "v3.28.0")).To(BeNil())
+		Expect(upgrade.Start(nil, "v3.28.0", "v3.28.0")).To(BeNil())
+
+		status := upgrade.Start(nil, "v3.27.0", "v3.28.0")
+		Expect(status.FromVersion).To(Equal("v3.27.0"))
+		Expect(status.ToVersion).To(Equal("v3.28.0"))
+		Expect(status.Stages).To(HaveLen(len(upgrade.Stages)))
+		for _, s := range status.Stages {
+			Expect(s.State).To(Equal(operatorv1.UpgradeStagePending))
+		}
+		Expect(upgrade.InProgress(status)).To(BeTrue())
+
+		// An upgrade that is already tracked is kept, and one to a different version replaces it.
+		Expect(upgrade.Start(status, "v3.27.0", "v3.28.0")).To(BeIdenticalTo(status))
+		Expect(upgrade.Start(status, "v3.27.0", "v3.29.0").ToVersion).To(Equal("v3.29.0"))
+	})
+
+	It("should only allow stages whose pre hooks have completed", func() {
+		Expect(upgrade.Allowed(nil, operatorv1.UpgradeStageNode)).To(BeTrue())
+
+		status := upgrade.Start(nil, "v3.27.0", "v3.28.0")
+		status.Stages[0].State = operatorv1.UpgradeStageComplete
+		status.Stages[1].State = operatorv1.UpgradeStageRollingOut
+		status.Stages[2].State = operatorv1.UpgradeStagePreHooks
+		Expect(upgrade.Allowed(status, operatorv1.UpgradeStageCRDs)).To(BeTrue())
+		Expect(upgrade.Allowed(status, operatorv1.UpgradeStageAPIServer)).To(BeTrue())
+		Expect(upgrade.Allowed(status, operatorv1.UpgradeStageTypha)).To(BeFalse())
+		Expect(upgrade.Allowed(status, operatorv1.UpgradeStageNode)).To(BeFalse())
+	})
+
+	It("should name hook Jobs by hook and version", func() {
+		hook := operatorv1.UpgradeHook{Name: "backup"}
+		Expect(upgrade.HookJobName(hook, "v3.28.0")).To(MatchRegexp(`^tigera-upgrade-backup-[0-9a-f]{7}$`))
+		Expect(upgrade.HookJobName(hook, "v3.28.0")).To(Equal(upgrade.HookJobName(hook, "v3.28.0")))
+		Expect(upgrade.HookJobName(hook, "v3.28.0")).NotTo(Equal(upgrade.HookJobName(hook, "v3.29.0")))
+	})
+
+	Context("Advance", func() {
+		var (
+			c          client.Client
+			ctx        context.Context
+			owner      *operatorv1.Installation
+			rolledOut  map[operatorv1.UpgradeStage]bool
+			hooks      []operatorv1.UpgradeHook
+			status     *operatorv1.UpgradeStatus
+			newAdvance func() error
+		)
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+			Expect(batchv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+			c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+			ctx = context.Background()
+
+			owner = &operatorv1.Installation{ObjectMeta: metav1.ObjectMeta{Name: "default", UID: "installation-uid"}}
+			rolledOut = map[operatorv1.UpgradeStage]bool{}
+			hooks = []operatorv1.UpgradeHook{
+				{Name: "backup", Stage: operatorv1.UpgradeStageTypha, Phase: operatorv1.UpgradeHookPhasePre, Image: "example.com/backup:v1"},
+			}
+			status = upgrade.Start(nil, "v3.27.0", "v3.28.0")
+			newAdvance = func() error {
+				check := func(_ context.Context, stage operatorv1.UpgradeStage) (bool, error) {
+					return rolledOut[stage], nil
+				}
+				return upgrade.NewCoordinator(c, scheme, owner, hooks, nil, check).Advance(ctx, status)
+			}
+		})
+
+		stateOf := func(stage operatorv1.UpgradeStage) operatorv1.UpgradeStageState {
+			for _, s := range status.Stages {
+				if s.Stage == stage {
+					return s.State
+				}
+			}
+			return ""
+		}
+
+		getJob := func() *batchv1.Job {
+			job := &batchv1.Job{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: upgrade.HookJobName(hooks[0], "v3.28.0"), Namespace: common.OperatorNamespace()}, job)).NotTo(HaveOccurred())
+			return job
+		}
+
+		It("should roll out one stage at a time and run hooks before their stage", func() {
+			Expect(newAdvance()).NotTo(HaveOccurred())
+			Expect(stateOf(operatorv1.UpgradeStageCRDs)).To(Equal(operatorv1.UpgradeStageRollingOut))
+			Expect(stateOf(operatorv1.UpgradeStageAPIServer)).To(Equal(operatorv1.UpgradeStagePending))
+
+			// Nothing moves until the rollout of the CRDs has completed.
+			Expect(newAdvance()).NotTo(HaveOccurred())
+			Expect(stateOf(operatorv1.UpgradeStageCRDs)).To(Equal(operatorv1.UpgradeStageRollingOut))
+
+			rolledOut[operatorv1.UpgradeStageCRDs] = true
+			Expect(newAdvance()).NotTo(HaveOccurred())
+			Expect(stateOf(operatorv1.UpgradeStageCRDs)).To(Equal(operatorv1.UpgradeStageComplete))
+			Expect(stateOf(operatorv1.UpgradeStageAPIServer)).To(Equal(operatorv1.UpgradeStageRollingOut))
+
+			// The pre hook of the typha stage is started once the API server has rolled out.
+			rolledOut[operatorv1.UpgradeStageAPIServer] = true
+			Expect(newAdvance()).NotTo(HaveOccurred())
+			Expect(stateOf(operatorv1.UpgradeStageTypha)).To(Equal(operatorv1.UpgradeStagePreHooks))
+			job := getJob()
+			Expect(job.Labels).To(HaveKeyWithValue(upgrade.HookLabel, "backup"))
+			Expect(job.OwnerReferences).To(HaveLen(1))
+			Expect(job.Spec.Template.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+			Expect(job.Spec.Template.Spec.Containers[0].Image).To(Equal("example.com/backup:v1"))
+			Expect(upgrade.Allowed(status, operatorv1.UpgradeStageTypha)).To(BeFalse())
+
+			job.Status.Succeeded = 1
+			Expect(c.Status().Update(ctx, job)).NotTo(HaveOccurred())
+			Expect(newAdvance()).NotTo(HaveOccurred())
+			Expect(stateOf(operatorv1.UpgradeStageTypha)).To(Equal(operatorv1.UpgradeStageRollingOut))
+			Expect(upgrade.Allowed(status, operatorv1.UpgradeStageTypha)).To(BeTrue())
+
+			rolledOut[operatorv1.UpgradeStageTypha] = true
+			rolledOut[operatorv1.UpgradeStageNode] = true
+			rolledOut[operatorv1.UpgradeStageComponents] = true
+			for i := 0; i < 3; i++ {
+				Expect(newAdvance()).NotTo(HaveOccurred())
+			}
+			Expect(upgrade.InProgress(status)).To(BeFalse())
+		})
+
+		It("should block the upgrade when a hook fails", func() {
+			rolledOut[operatorv1.UpgradeStageCRDs] = true
+			rolledOut[operatorv1.UpgradeStageAPIServer] = true
+			for i := 0; i < 3; i++ {
+				Expect(newAdvance()).NotTo(HaveOccurred())
+			}
+			job := getJob()
+			job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+			Expect(c.Status().Update(ctx, job)).NotTo(HaveOccurred())
+
+			err := newAdvance()
+			Expect(err).To(MatchError(ContainSubstring("upgrade hook backup failed: BackoffLimitExceeded")))
+			Expect(stateOf(operatorv1.UpgradeStageTypha)).To(Equal(operatorv1.UpgradeStagePreHooks))
+
+			// Deleting the failed Job runs the hook again.
+			Expect(c.Delete(ctx, job)).NotTo(HaveOccurred())
+			Expect(newAdvance()).NotTo(HaveOccurred())
+			Expect(getJob().Status.Conditions).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,40 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import "sync"
+
+var (
+	apiChangesLock sync.Mutex
+	// apiChanges is closed, and replaced, each time the APIs served by the cluster change, so that everything waiting
+	// on it is woken up at once.
+	apiChanges = make(chan struct{})
+)
+
+// APIsChanged returns a channel that is closed the next time NotifyAPIsChanged is called. The goroutines that wait for
+// an API to become available before watching it use it to retry straight away, rather than at their next backoff.
+func APIsChanged() <-chan struct{} {
+	apiChangesLock.Lock()
+	defer apiChangesLock.Unlock()
+	return apiChanges
+}
+
+// NotifyAPIsChanged wakes up everything waiting on APIsChanged. It is called when an API becomes available.
+func NotifyAPIsChanged() {
+	apiChangesLock.Lock()
+	defer apiChangesLock.Unlock()
+	close(apiChanges)
+	apiChanges = make(chan struct{})
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/tigera/operator/pkg/render"
+)
+
+const (
+	updateResultApplied = "applied"
+	updateResultSkipped = "skipped"
+)
+
+// componentObjectUpdates counts the updates of existing objects made by the component handler, by whether the update
+// was sent to the API server or skipped because nothing had changed.
+var componentObjectUpdates = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "tigera_operator_component_object_updates_total",
+	Help: "Number of updates of existing objects by the component handler, partitioned by whether they were applied or skipped.",
+}, []string{"result"})
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(componentObjectUpdates)
+}
+
+// appliedObjects remembers, for each object the component handler has written, the resourceVersion the write
+// resulted in and a hash of the desired state that was written. If an object still has that resourceVersion and the
+// desired state hashes the same, then neither the object nor what we want it to be has changed, and the update can be
+// skipped. Any change to the object, including by a user, changes its resourceVersion so drift is still corrected.
+var appliedObjects = &appliedObjectCache{objects: map[appliedObjectKey]appliedObject{}}
+
+// appliedObjectKey identifies an object by its type and name, so that it can be forgotten when it is deleted through
+// its rendered form, which has no UID.
+type appliedObjectKey struct {
+	kind string
+	key  types.NamespacedName
+}
+
+type appliedObject struct {
+	uid             types.UID
+	resourceVersion string
+	hash            string
+}
+
+type appliedObjectCache struct {
+	sync.Mutex
+	objects map[appliedObjectKey]appliedObject
+}
+
+func newAppliedObjectKey(obj client.Object) appliedObjectKey {
+	return appliedObjectKey{kind: fmt.Sprintf("%T", obj), key: client.ObjectKeyFromObject(obj)}
+}
+
+// unchanged returns true if cur is the object last written by the handler for the desired state with the given hash.
+func (a *appliedObjectCache) unchanged(cur client.Object, hash string) bool {
+	applied, ok := a.get(cur, hash)
+	return ok && applied.resourceVersion == cur.GetResourceVersion()
+}
+
+// semanticallyUnchanged returns true if the handler last wrote cur for the desired state with the given hash, and
+// cur still has every field of the merged object mobj set to the same value. This covers objects whose
+// resourceVersion changed for reasons that don't matter to the handler, such as a status update of a Deployment or
+// fields defaulted by the API server, which would otherwise be updated on every reconcile.
+//
+// Fields that are unset in mobj are not compared, so the check is only made if the desired state is the one last
+// written: a field that the desired state stopped setting is then still cleared by an update.
+func (a *appliedObjectCache) semanticallyUnchanged(mobj, cur client.Object, hash string) bool {
+	if _, ok := a.get(cur, hash); !ok {
+		return false
+	}
+	return equality.Semantic.DeepDerivative(mobj, cur)
+}
+
+// get returns what the handler last wrote for cur, if it was written for the desired state with the given hash.
+func (a *appliedObjectCache) get(cur client.Object, hash string) (appliedObject, bool) {
+	if cur.GetUID() == "" || hash == "" {
+		return appliedObject{}, false
+	}
+	a.Lock()
+	defer a.Unlock()
+	applied, ok := a.objects[newAppliedObjectKey(cur)]
+	return applied, ok && applied.uid == cur.GetUID() && applied.hash == hash
+}
+
+// record stores the resourceVersion of an object that was just written for the desired state with the given hash.
+func (a *appliedObjectCache) record(obj client.Object, hash string) {
+	if obj.GetUID() == "" || hash == "" {
+		return
+	}
+	a.Lock()
+	defer a.Unlock()
+	a.objects[newAppliedObjectKey(obj)] = appliedObject{uid: obj.GetUID(), resourceVersion: obj.GetResourceVersion(), hash: hash}
+}
+
+// forget removes an object from the cache, so that it is written the next time it is applied. It is called when the
+// object is deleted, or is about to be written in a way that the cache can't track, such as being recreated.
+func (a *appliedObjectCache) forget(obj client.Object) {
+	a.Lock()
+	defer a.Unlock()
+	delete(a.objects, newAppliedObjectKey(obj))
+}
+
+// desiredStateHash returns a hash of the desired state of an object. Maps are serialized with sorted keys, so the
+// hash doesn't depend on their iteration order. An empty string is returned if the object can't be serialized.
+func desiredStateHash(obj client.Object) string {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return ""
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+// componentRevision returns a hash of everything a component rendered: the desired state of the objects it creates and
+// the keys of the objects it deletes.
+func componentRevision(objsToCreate, objsToDelete []client.Object) string {
+	h := sha256.New()
+	for _, obj := range objsToCreate {
+		h.Write([]byte(desiredStateHash(obj)))
+	}
+	for _, obj := range objsToDelete {
+		h.Write([]byte(fmt.Sprintf("-%T/%s", obj, client.ObjectKeyFromObject(obj))))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// componentName returns the name of the type of a component, which its revision and metrics are reported under.
+// Components that wrap another one are reported under the name of the wrapped component.
+func componentName(component render.Component) string {
+	return strings.TrimPrefix(reflect.TypeOf(render.UnwrapComponent(component)).String(), "*")
+}
+
+// componentRevisionName returns the name that the revision of a component is reported under. Components rendered for a
+// namespaced CR, like those of a tenant, are told apart by the namespace of the CR.
+func componentRevisionName(component render.Component, cr metav1.Object) string {
+	name := componentName(component)
+	if cr != nil && cr.GetNamespace() != "" {
+		name = fmt.Sprintf("%s/%s", name, cr.GetNamespace())
+	}
+	return name
+}
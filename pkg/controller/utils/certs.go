@@ -23,7 +23,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func GetSecret(ctx context.Context, client client.Client, name string, ns string) (*corev1.Secret, error) {
+func GetSecret(ctx context.Context, client client.Reader, name string, ns string) (*corev1.Secret, error) {
 	secret := &corev1.Secret{}
 	if err := client.Get(ctx, types.NamespacedName{Name: name, Namespace: ns}, secret); err != nil {
 		if !kerrors.IsNotFound(err) {
@@ -18,7 +18,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"sync"
+	"time"
 
 	rbacv1 "k8s.io/api/rbac/v1"
 
@@ -26,6 +28,7 @@ import (
 	kbv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/kibana/v1"
 	"github.com/go-logr/logr"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"go.opentelemetry.io/otel/attribute"
 	apps "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
@@ -37,10 +40,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/controller/status"
 	"github.com/tigera/operator/pkg/render"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/tracing"
 )
 
 type ComponentHandler interface {
@@ -51,7 +56,7 @@ type ComponentHandler interface {
 // this is useful for CRD management so that they are not removed automatically.
 func NewComponentHandler(log logr.Logger, client client.Client, scheme *runtime.Scheme, cr metav1.Object) ComponentHandler {
 	return &componentHandler{
-		client: client,
+		client: untrackedClient(client),
 		scheme: scheme,
 		cr:     cr,
 		log:    log,
@@ -65,10 +70,12 @@ type componentHandler struct {
 	log    logr.Logger
 }
 
-func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.Object, osType rmeta.OSType) error {
+// prepareObject makes the changes to obj that the handler makes to every object it applies: owner references, OS
+// scheduling restrictions, defaults and standard labels. It returns whether the object has multiple owners.
+func (c componentHandler) prepareObject(obj client.Object, osType rmeta.OSType, ipFamilies *serviceIPFamilies, opts applyOptions) (bool, error) {
 	om, ok := obj.(metav1.ObjectMetaAccessor)
 	if !ok {
-		return fmt.Errorf("object is not ObjectMetaAccessor")
+		return false, fmt.Errorf("object is not ObjectMetaAccessor")
 	}
 
 	multipleOwners := checkIfMultipleOwnersLabel(om.GetObjectMeta())
@@ -77,22 +84,21 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 	case *v3.UISettings:
 		// Never add controller ref for UISettings since these are always GCd through the UISettingsGroup.
 	default:
-		if c.cr != nil && !skipAddingOwnerReference(c.cr, om.GetObjectMeta()) {
+		if c.cr != nil && opts.ownership == render.OwnershipLabels && needsOwnerLabels(c.cr, obj) {
+			c.setOwnerLabels(obj)
+		} else if c.cr != nil && !skipAddingOwnerReference(c.cr, om.GetObjectMeta()) {
 			if multipleOwners {
 				if err := controllerutil.SetOwnerReference(c.cr, om.GetObjectMeta(), c.scheme); err != nil {
-					return err
+					return false, err
 				}
 			} else {
 				if err := controllerutil.SetControllerReference(c.cr, om.GetObjectMeta(), c.scheme); err != nil {
-					return err
+					return false, err
 				}
 			}
 		}
 	}
 
-	logCtx := ContextLoggerForResource(c.log, obj)
-	key := client.ObjectKeyFromObject(obj)
-
 	// Ensure that if the object is something the creates a pod that it is scheduled on nodes running the operating
 	// system as specified by the osType.
 	ensureOSSchedulingRestrictions(obj, osType)
@@ -106,13 +112,55 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 	// Make sure we have our standard selector and pod labels
 	setStandardSelectorAndLabels(obj)
 
+	// Make sure services use the IP families of the cluster, unless the render chose them explicitly.
+	setServiceIPFamilies(obj, ipFamilies)
+
+	if opts.provenance {
+		setWorkloadProvenance(obj)
+	}
+
+	if len(opts.tracingEnv) > 0 {
+		modifyPodSpec(obj, func(podSpec *v1.PodSpec) { setTracingEnv(podSpec, opts.tracingContainers, opts.tracingEnv) })
+	}
+	return multipleOwners, nil
+}
+
+func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.Object, osType rmeta.OSType, ipFamilies *serviceIPFamilies, opts applyOptions, metrics objectMetrics) (err error) {
+	ctx, span := tracing.Start(ctx, "apply",
+		attribute.String("object.kind", c.gvkForObject(obj).Kind),
+		attribute.String("object.namespace", obj.GetNamespace()),
+		attribute.String("object.name", obj.GetName()),
+	)
+	start := time.Now()
+	defer func() {
+		metrics.observeApply(start)
+		if errors.IsConflict(err) {
+			metrics.conflict()
+		}
+		tracing.End(span, err)
+	}()
+
+	multipleOwners, err := c.prepareObject(obj, osType, ipFamilies, opts)
+	if err != nil {
+		return err
+	}
+	if err := validateDataSize(obj); err != nil {
+		return err
+	}
+	logCtx := ContextLoggerForResource(c.log, obj)
+	key := client.ObjectKeyFromObject(obj)
+
+	// Hash the desired state before it is merged with the current one, so we can tell if it changed since it was
+	// last applied.
+	hash := desiredStateHash(obj)
+
 	cur, ok := obj.DeepCopyObject().(client.Object)
 	if !ok {
 		logCtx.V(2).Info("Failed converting object", "obj", obj)
 		return fmt.Errorf("failed converting object %+v", obj)
 	}
 	// Check to see if the object exists or not.
-	err := c.client.Get(ctx, key, cur)
+	err = c.client.Get(ctx, key, cur)
 	if err != nil {
 		if !errors.IsNotFound(err) {
 			// Anything other than "Not found" we should retry.
@@ -122,15 +170,15 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 		// Otherwise, if it was not found, we should create it and move on.
 		logCtx.V(2).Info("Object does not exist, creating it", "error", err)
 		if multipleOwners {
-			labels := om.GetObjectMeta().GetLabels()
-			delete(labels, common.MultipleOwnersLabel)
-			om.GetObjectMeta().SetLabels(labels)
+			removeMultipleOwnersLabel(obj)
 		}
 		err = c.client.Create(ctx, obj)
 		if err != nil {
 			logCtx.WithValues("key", key).Error(err, "Failed to create object.")
 			return err
 		}
+		appliedObjects.record(obj, hash)
+		metrics.applied(applyOperationCreate)
 		return nil
 	}
 
@@ -143,6 +191,19 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 
 	// if mergeState returns nil we don't want to update the object
 	if mobj := mergeState(obj, cur); mobj != nil {
+		if appliedObjects.unchanged(cur, hash) {
+			logCtx.V(3).Info("Object is unchanged since it was last applied, skipping update")
+			componentObjectUpdates.WithLabelValues(updateResultSkipped).Inc()
+			return nil
+		}
+		if appliedObjects.semanticallyUnchanged(mobj, cur, hash) {
+			logCtx.V(3).Info("Object is semantically unchanged since it was last applied, skipping update")
+			appliedObjects.record(cur, hash)
+			componentObjectUpdates.WithLabelValues(updateResultSkipped).Inc()
+			return nil
+		}
+		appliedObjects.forget(cur)
+
 		switch obj.(type) {
 		case *batchv1.Job:
 			// Jobs can't be updated, they can only be deleted then created
@@ -157,6 +218,7 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 				logCtx.WithValues("key", key).Error(err, "Failed to create Job.")
 				return err
 			}
+			metrics.applied(applyOperationRecreate)
 			return nil
 		case *v1.Secret:
 			objSecret := obj.(*v1.Secret)
@@ -176,15 +238,16 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 					logCtx.WithValues("key", key).Error(err, "Failed to create Secret.")
 					return err
 				}
+				metrics.applied(applyOperationRecreate)
 				return nil
 			}
 		case *v1.Service:
 			objService := obj.(*v1.Service)
 			curService := cur.(*v1.Service)
-			if objService.Spec.ClusterIP == "None" && curService.Spec.ClusterIP != "None" {
-				// We don't want this service to have a cluster IP, but it has got one already.  Need to recreate
-				// the service to remove it.
-				logCtx.WithValues("key", key).Info("Service already exists and has unwanted ClusterIP, recreating service.")
+			if (objService.Spec.ClusterIP == "None") != (curService.Spec.ClusterIP == "None") {
+				// We don't want this service to have a cluster IP, but it has got one already, or the other way
+				// around. The cluster IP is immutable so we need to recreate the service to change it.
+				logCtx.WithValues("key", key).Info("Service already exists and has an unwanted ClusterIP, recreating service.")
 				if err := c.client.Delete(ctx, obj); err != nil {
 					logCtx.WithValues("key", key).Error(err, "Failed to delete Service for recreation.")
 					return err
@@ -196,6 +259,7 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 					logCtx.WithValues("key", key).Error(err, "Failed to recreate service.", "obj", obj)
 					return err
 				}
+				metrics.applied(applyOperationRecreate)
 				return nil
 			}
 		case *rbacv1.RoleBinding:
@@ -214,6 +278,7 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 					logCtx.WithValues("key", key).Error(err, "Failed to recreate RoleBinding")
 					return err
 				}
+				metrics.applied(applyOperationRecreate)
 				return nil
 			}
 		case *rbacv1.ClusterRoleBinding:
@@ -232,6 +297,7 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 					logCtx.WithValues("key", key).Error(err, "Failed to recreate ClusterRoleBinding")
 					return err
 				}
+				metrics.applied(applyOperationRecreate)
 				return nil
 			}
 		}
@@ -239,10 +305,49 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 			logCtx.WithValues("key", key).Info("Failed to update object.")
 			return err
 		}
+		appliedObjects.record(mobj, hash)
+		componentObjectUpdates.WithLabelValues(updateResultApplied).Inc()
+		metrics.applied(applyOperationUpdate)
+	}
+	return nil
+}
+
+// validateDataSize returns an error if the data of a ConfigMap or Secret is over the 1MiB limit of Kubernetes, so that
+// the error gives the size of the data instead of being an opaque rejection by the API server.
+func validateDataSize(obj client.Object) error {
+	var size int
+	switch o := obj.(type) {
+	case *v1.ConfigMap:
+		for _, v := range o.Data {
+			size += len(v)
+		}
+		for _, v := range o.BinaryData {
+			size += len(v)
+		}
+	case *v1.Secret:
+		for _, v := range o.Data {
+			size += len(v)
+		}
+		for _, v := range o.StringData {
+			size += len(v)
+		}
+	default:
+		return nil
+	}
+	if size > v1.MaxSecretSize {
+		return fmt.Errorf("its data is %d bytes, which is over the Kubernetes limit of %d bytes", size, v1.MaxSecretSize)
 	}
 	return nil
 }
 
+// removeMultipleOwnersLabel removes the label that marks an object as having multiple owners, which is only used to
+// tell the handler how to set the owner references of the object.
+func removeMultipleOwnersLabel(obj client.Object) {
+	labels := obj.GetLabels()
+	delete(labels, common.MultipleOwnersLabel)
+	obj.SetLabels(labels)
+}
+
 func resetMetadataForCreate(obj client.Object) {
 	obj.SetResourceVersion("")
 	obj.SetUID("")
@@ -250,9 +355,19 @@ func resetMetadataForCreate(obj client.Object) {
 }
 
 func (c componentHandler) CreateOrUpdateOrDelete(ctx context.Context, component render.Component, status status.StatusManager) error {
+	name := componentName(component)
+	ctx, span := tracing.Start(ctx, "component", attribute.String("component", name))
+	start := time.Now()
+	err := c.createOrUpdateOrDelete(ctx, component, status)
+	componentApplyDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+	tracing.End(span, err)
+	return err
+}
+
+func (c componentHandler) createOrUpdateOrDelete(ctx context.Context, component render.Component, status status.StatusManager) error {
 	// Before creating the component, make sure that it is ready. This provides a hook to do
 	// dependency checking for the component.
-	cmpLog := c.log.WithValues("component", reflect.TypeOf(component))
+	cmpLog := c.log.WithValues("component", componentName(component))
 	cmpLog.V(2).Info("Checking if component is ready")
 	if !component.Ready() {
 		cmpLog.Info("Component is not ready, skipping")
@@ -270,22 +385,93 @@ func (c componentHandler) CreateOrUpdateOrDelete(ctx context.Context, component
 	objsToCreate, objsToDelete := component.Objects()
 	osType := component.SupportedOSType()
 
+	// Hash what was rendered before any of it is modified while being applied.
+	revision := componentRevision(objsToCreate, objsToDelete)
+
+	// Only look up the IP families of the cluster if there are services to render.
+	var ipFamilies *serviceIPFamilies
+	for _, obj := range objsToCreate {
+		if _, ok := obj.(*v1.Service); ok {
+			ipFamilies = c.clusterIPFamilies(ctx)
+			break
+		}
+	}
+
+	// Only look up how the Installation wants objects to be applied if there are objects to apply.
+	var opts applyOptions
+	if len(objsToCreate) > 0 {
+		opts = c.applyOptions(ctx)
+	}
+	if oc, ok := component.(render.OwnershipComponent); ok {
+		opts.ownership = oc.OwnershipStrategy()
+	}
+	if tc, ok := component.(render.TracingComponent); ok && len(objsToCreate) > 0 {
+		opts.tracingEnv = c.tracingEnv(ctx)
+		opts.tracingContainers = tc.TracingContainers()
+	}
+
+	// Make sure the CR can't go away before the objects labeled as owned by it are deleted.
+	if opts.ownership == render.OwnershipLabels && c.cr != nil {
+		for _, obj := range objsToCreate {
+			if needsOwnerLabels(c.cr, obj) {
+				if err := c.addOwnedObjectsFinalizer(ctx); err != nil {
+					cmpLog.Error(err, "Failed to add finalizer for owned objects")
+					return err
+				}
+				break
+			}
+		}
+	}
+
+	// Label the secrets that the component copies from other namespaces so that the copies it stops rendering can be
+	// found and deleted below. Without an owner there is nothing to scope the clean up to, so it is skipped.
+	var replicationKey string
+	if rc, ok := component.(render.SecretReplicatingComponent); ok && c.cr != nil {
+		replicationKey = rc.ReplicatedSecretsKey()
+		labelReplicatedSecrets(objsToCreate, replicationKey)
+	}
+
+	// If asked to, have the API server validate all of the objects before applying any of them, so that a rejected
+	// object doesn't leave the component partially applied.
+	if opts.validate {
+		if valErr := c.validateObjects(ctx, objsToCreate, osType, ipFamilies, opts); valErr != nil {
+			cmpLog.Error(valErr, "Rendered objects failed validation, not applying them")
+			for _, objErr := range valErr.Errors {
+				c.recordObjectError(objErr)
+			}
+			return valErr
+		}
+	}
+
+	// A failure to apply one object doesn't stop the others from being applied, except for the objects in a namespace
+	// that failed to be applied, so that as little as possible is left stale. The failures are returned together.
+	var objErrs ObjectErrors
+	failedNamespaces := map[string]bool{}
 	for _, obj := range objsToCreate {
 		key := client.ObjectKeyFromObject(obj)
+		if failedNamespaces[key.Namespace] {
+			cmpLog.V(1).Info("Skipping object in a namespace that failed to be applied", "key", key)
+			continue
+		}
 
 		// Pass in a DeepCopy so any modifications made by createOrUpdateObject won't be included
 		// if we need to retry the function
-		err := c.createOrUpdateObject(ctx, obj.DeepCopyObject().(client.Object), osType)
+		metrics := c.objectMetrics(componentName(component), obj)
+		err := c.createOrUpdateObject(ctx, obj.DeepCopyObject().(client.Object), osType, ipFamilies, opts, metrics)
 		if err != nil && errors.IsConflict(err) {
 			// If the error is a resource Conflict, try the update again
 			cmpLog.WithValues("key", key, "conflict_message", err).Info("Failed to update object, retrying.")
-			err = c.createOrUpdateObject(ctx, obj, osType)
-			if err != nil {
-				return err
+			err = c.createOrUpdateObject(ctx, obj, osType, ipFamilies, opts, metrics)
+		}
+		if err != nil {
+			objErr := c.newObjectError(objectOpApply, obj, err)
+			cmpLog.Error(err, "Failed to create or update object", "key", key, "kind", objErr.GVK.Kind, "reason", objErr.Reason)
+			c.recordObjectError(objErr)
+			objErrs = append(objErrs, objErr)
+			if _, ok := obj.(*v1.Namespace); ok {
+				failedNamespaces[key.Name] = true
 			}
-		} else if err != nil {
-			cmpLog.Error(err, "Failed to create or update object", "key", key)
-			return err
+			continue
 		}
 
 		// Keep track of some objects so we can report on their status.
@@ -303,6 +489,14 @@ func (c componentHandler) CreateOrUpdateOrDelete(ctx context.Context, component
 		continue
 	}
 
+	// The stale copies are only known once all of the current ones are applied.
+	if replicationKey != "" && len(objErrs) == 0 {
+		if err := c.pruneReplicatedSecrets(ctx, replicationKey, objsToCreate); err != nil {
+			cmpLog.Error(err, "Failed to delete stale secret copies")
+			return err
+		}
+	}
+
 	if status != nil {
 		// Add the objects to the status manager so we can report on their status.
 		if len(daemonSets) > 0 {
@@ -322,9 +516,16 @@ func (c componentHandler) CreateOrUpdateOrDelete(ctx context.Context, component
 	for _, obj := range objsToDelete {
 		err := c.client.Delete(ctx, obj)
 		if err != nil && !errors.IsNotFound(err) {
+			objErr := c.newObjectError(objectOpDelete, obj, err)
 			logCtx := ContextLoggerForResource(c.log, obj)
-			logCtx.Error(err, fmt.Sprintf("Error deleting object %v", obj))
-			return err
+			logCtx.Error(err, "Error deleting object", "reason", objErr.Reason)
+			c.recordObjectError(objErr)
+			objErrs = append(objErrs, objErr)
+			continue
+		}
+		appliedObjects.forget(obj)
+		if err == nil {
+			c.objectMetrics(componentName(component), obj).deleted()
 		}
 
 		key := client.ObjectKeyFromObject(obj)
@@ -342,23 +543,117 @@ func (c componentHandler) CreateOrUpdateOrDelete(ctx context.Context, component
 		}
 	}
 
+	if err := objErrs.Err(); err != nil {
+		return err
+	}
+
 	cmpLog.V(1).Info("Done reconciling component")
 	// TODO Get each controller to explicitly call ReadyToMonitor on the status manager instead of doing it here.
 	if status != nil {
+		status.SetAppliedRevision(ctx, componentRevisionName(component, c.cr), revision)
 		status.ReadyToMonitor()
 	}
 	return nil
 }
 
-// skipAddingOwnerReference returns true if owner is a namespaced resource and
-// controlled object is a cluster scoped resource.
+// applyOptions holds the settings of the Installation that affect how the objects of all components are applied.
+type applyOptions struct {
+	// provenance adds provenance metadata to the pods of workloads.
+	provenance bool
+
+	// validate dry-runs all of the objects of a component before applying any of them.
+	validate bool
+
+	// ownership is the strategy of the component for tying its objects to the CR.
+	ownership render.OwnershipStrategy
+
+	// tracingEnv is added to the tracingContainers of the component, to have them export their traces.
+	tracingEnv        []v1.EnvVar
+	tracingContainers []string
+}
+
+// applyOptions reads the applyOptions from the Installation. It returns the defaults if the Installation can't be
+// read.
+func (c componentHandler) applyOptions(ctx context.Context) applyOptions {
+	_, installation, err := GetInstallation(ctx, c.client)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			c.log.V(2).Info("Unable to query Installation for apply options, using the defaults", "error", err)
+		}
+		return applyOptions{}
+	}
+	return applyOptions{
+		provenance: installation.WorkloadProvenance != nil && *installation.WorkloadProvenance == operatorv1.WorkloadProvenanceEnabled,
+		validate:   installation.ApplyValidation != nil && *installation.ApplyValidation == operatorv1.ApplyValidationDryRun,
+	}
+}
+
+// tracingEnv returns the variables that configure components to export their traces, as set in the OperatorConfig.
+// It returns nothing if the OperatorConfig can't be read.
+func (c componentHandler) tracingEnv(ctx context.Context) []v1.EnvVar {
+	oc, err := GetOperatorConfig(ctx, c.client)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			c.log.V(2).Info("Unable to query OperatorConfig for tracing, not configuring it", "error", err)
+		}
+		return nil
+	}
+	return tracing.ComponentEnv(oc.Spec.Tracing)
+}
+
+// serviceIPFamilies holds the IP family settings to apply to rendered services.
+type serviceIPFamilies struct {
+	policy   v1.IPFamilyPolicy
+	families []v1.IPFamily
+}
+
+// clusterIPFamilies determines the IP families that services should use based on the IP pools configured in the
+// Installation. It returns nil if the cluster defaults should be used, which is the case for IPv4-only clusters or
+// when the Installation can't be read.
+func (c componentHandler) clusterIPFamilies(ctx context.Context) *serviceIPFamilies {
+	_, installation, err := GetInstallation(ctx, c.client)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			c.log.V(2).Info("Unable to query Installation for IP families, using cluster defaults", "error", err)
+		}
+		return nil
+	}
+	if installation.CalicoNetwork == nil {
+		return nil
+	}
+
+	v4pool := render.GetIPv4Pool(installation.CalicoNetwork.IPPools)
+	v6pool := render.GetIPv6Pool(installation.CalicoNetwork.IPPools)
+	switch {
+	case v4pool != nil && v6pool != nil:
+		// Leave the families unset so that the API server keeps the primary family of the cluster.
+		return &serviceIPFamilies{policy: v1.IPFamilyPolicyPreferDualStack}
+	case v6pool != nil:
+		return &serviceIPFamilies{policy: v1.IPFamilyPolicySingleStack, families: []v1.IPFamily{v1.IPv6Protocol}}
+	}
+	return nil
+}
+
+// setServiceIPFamilies sets the IP family policy and families of a service, if the object is a service that
+// doesn't have them set already.
+func setServiceIPFamilies(obj client.Object, ipFamilies *serviceIPFamilies) {
+	svc, ok := obj.(*v1.Service)
+	if !ok || ipFamilies == nil || svc.Spec.Type == v1.ServiceTypeExternalName {
+		return
+	}
+	if svc.Spec.IPFamilyPolicy != nil || len(svc.Spec.IPFamilies) > 0 {
+		return
+	}
+	policy := ipFamilies.policy
+	svc.Spec.IPFamilyPolicy = &policy
+	svc.Spec.IPFamilies = append([]v1.IPFamily(nil), ipFamilies.families...)
+}
+
+// skipAddingOwnerReference returns true if controlled can't have an owner reference to owner: a namespaced owner can
+// only own objects in its own namespace. The garbage collector would otherwise delete the object.
 func skipAddingOwnerReference(owner, controlled metav1.Object) bool {
 	ownerNs := owner.GetNamespace()
-	controlledNs := controlled.GetNamespace()
-	if ownerNs != "" && controlledNs == "" {
-		return true
-	}
-	return false
+	return ownerNs != "" && controlled.GetNamespace() != ownerNs
 }
 
 func checkIfMultipleOwnersLabel(controlled metav1.Object) bool {
@@ -417,7 +712,7 @@ func mergeState(desired client.Object, current runtime.Object) client.Object {
 		// and we need to maintain them on updates.
 		cs := current.(*v1.Service)
 		ds := desired.(*v1.Service)
-		if ds.Spec.ClusterIP != "None" {
+		if ds.Spec.ClusterIP != "None" && cs.Spec.ClusterIP != "None" {
 			// We want this service to keep its cluster IP.
 			ds.Spec.ClusterIP = cs.Spec.ClusterIP
 		}
@@ -589,6 +884,21 @@ func setImagePullPolicy(podSpec *v1.PodSpec) {
 	}
 }
 
+// setTracingEnv adds the tracing variables to the named containers, unless the component set them itself.
+func setTracingEnv(podSpec *v1.PodSpec, containers []string, env []v1.EnvVar) {
+	for i := range podSpec.Containers {
+		c := &podSpec.Containers[i]
+		if !slices.Contains(containers, c.Name) {
+			continue
+		}
+		for _, e := range env {
+			if !slices.ContainsFunc(c.Env, func(set v1.EnvVar) bool { return set.Name == e.Name }) {
+				c.Env = append(c.Env, e)
+			}
+		}
+	}
+}
+
 // ensureOSSchedulingRestrictions ensures that if obj is a type that creates pods and if osType is not OSTypeAny that a
 // node selector is set on the pod template for the "kubernetes.io/os" label to ensure that the pod is scheduled
 // on a node running an operating system as specified by osType.
@@ -640,11 +950,13 @@ func ensureOSSchedulingRestrictions(obj client.Object, osType rmeta.OSType) {
 // from service after 1.5 minute (3 default failure threshold).
 func setProbeTimeouts(obj client.Object) {
 	const (
-		failureThreshold            = 3
-		livenessProbePeriodSeconds  = 60
-		readinessProbePeriodSeconds = 30
-		successThreshold            = 1
-		timeoutSeconds              = 5
+		failureThreshold             = 3
+		livenessProbePeriodSeconds   = 60
+		readinessProbePeriodSeconds  = 30
+		startupProbeFailureThreshold = 30
+		startupProbePeriodSeconds    = 10
+		successThreshold             = 1
+		timeoutSeconds               = 5
 	)
 
 	var containers []v1.Container
@@ -697,6 +1009,22 @@ func setProbeTimeouts(obj client.Object) {
 				rp.TimeoutSeconds = timeoutSeconds
 			}
 		}
+
+		if container.StartupProbe != nil {
+			sp := container.StartupProbe
+			if sp.FailureThreshold == 0 {
+				sp.FailureThreshold = startupProbeFailureThreshold
+			}
+			if sp.PeriodSeconds == 0 {
+				sp.PeriodSeconds = startupProbePeriodSeconds
+			}
+			if sp.SuccessThreshold == 0 {
+				sp.SuccessThreshold = successThreshold
+			}
+			if sp.TimeoutSeconds == 0 {
+				sp.TimeoutSeconds = timeoutSeconds
+			}
+		}
 	}
 }
 
@@ -752,6 +1080,7 @@ func setStandardSelectorAndLabels(obj client.Object) {
 type ReadyFlag struct {
 	mu      sync.RWMutex
 	isReady bool
+	err     error
 }
 
 // IsReady returns true if was marked as ready
@@ -766,4 +1095,19 @@ func (r *ReadyFlag) MarkAsReady() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.isReady = true
+	r.err = nil
+}
+
+// Err returns the reason the flag is not ready yet, if one was recorded.
+func (r *ReadyFlag) Err() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.err
+}
+
+// setErr records the reason the flag is not ready yet.
+func (r *ReadyFlag) setErr(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.err = err
 }
@@ -0,0 +1,101 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	applyOperationCreate   = "create"
+	applyOperationUpdate   = "update"
+	applyOperationRecreate = "recreate"
+)
+
+var componentObjectLabels = []string{"component", "group", "version", "kind"}
+
+var (
+	// componentObjectsApplied counts the writes of the objects of components, by whether the object was created,
+	// updated, or deleted and created again because a field that can't be updated changed.
+	componentObjectsApplied = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "tigera_operator_component_objects_applied_total",
+		Help: "Number of objects created or updated by the component handler, partitioned by component, group, version, kind and operation.",
+	}, []string{"component", "group", "version", "kind", "operation"})
+
+	// componentObjectsDeleted counts the objects that components stopped rendering and that were deleted.
+	componentObjectsDeleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "tigera_operator_component_objects_deleted_total",
+		Help: "Number of objects deleted by the component handler, partitioned by component, group, version and kind.",
+	}, componentObjectLabels)
+
+	// componentObjectConflicts counts the writes that failed because the object changed since it was read. The handler
+	// retries them once, so a count that keeps growing means that something else keeps changing the objects.
+	componentObjectConflicts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "tigera_operator_component_object_conflicts_total",
+		Help: "Number of conflicts when applying objects by the component handler, partitioned by component, group, version and kind.",
+	}, componentObjectLabels)
+
+	componentObjectApplyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "tigera_operator_component_object_apply_duration_seconds",
+		Help:    "Duration of the attempts of the component handler to apply an object, partitioned by component, group, version and kind.",
+		Buckets: prometheus.DefBuckets,
+	}, componentObjectLabels)
+
+	componentApplyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "tigera_operator_component_apply_duration_seconds",
+		Help:    "Duration of applying all of the objects of a component by the component handler, partitioned by component.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"component"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		componentObjectsApplied,
+		componentObjectsDeleted,
+		componentObjectConflicts,
+		componentObjectApplyDuration,
+		componentApplyDuration,
+	)
+}
+
+// objectMetrics records the metrics of an object of a component.
+type objectMetrics struct {
+	component, group, version, kind string
+}
+
+func (c componentHandler) objectMetrics(component string, obj client.Object) objectMetrics {
+	gvk := c.gvkForObject(obj)
+	return objectMetrics{component: component, group: gvk.Group, version: gvk.Version, kind: gvk.Kind}
+}
+
+func (m objectMetrics) applied(operation string) {
+	componentObjectsApplied.WithLabelValues(m.component, m.group, m.version, m.kind, operation).Inc()
+}
+
+func (m objectMetrics) deleted() {
+	componentObjectsDeleted.WithLabelValues(m.component, m.group, m.version, m.kind).Inc()
+}
+
+func (m objectMetrics) conflict() {
+	componentObjectConflicts.WithLabelValues(m.component, m.group, m.version, m.kind).Inc()
+}
+
+func (m objectMetrics) observeApply(start time.Time) {
+	componentObjectApplyDuration.WithLabelValues(m.component, m.group, m.version, m.kind).Observe(time.Since(start).Seconds())
+}
@@ -16,6 +16,8 @@ package utils
 
 import (
 	"context"
+	"encoding/json"
+	goerrors "errors"
 	"fmt"
 
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -28,6 +30,7 @@ import (
 	kbv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/kibana/v1"
 	ocsv1 "github.com/openshift/api/security/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	apps "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -36,18 +39,26 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
 
 	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/apis"
 	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/maintenance"
+	"github.com/tigera/operator/pkg/controller/rollback"
 	"github.com/tigera/operator/pkg/controller/status"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	rsecret "github.com/tigera/operator/pkg/render/common/secret"
 )
 
 const (
@@ -1099,6 +1110,799 @@ var _ = Describe("Component handler tests", func() {
 			"Expected update to rev ResourceVersion")
 	})
 
+	It("recreates a service if it is no longer headless", func() {
+		headless := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-service"},
+			Spec:       corev1.ServiceSpec{ClusterIP: "None"},
+		}
+		fc := &fakeComponent{
+			supportedOSType: rmeta.OSTypeLinux,
+			objs:            []client.Object{headless},
+		}
+		err := handler.CreateOrUpdateOrDelete(ctx, fc, sm)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Get(ctx, client.ObjectKey{Name: "my-service"}, headless)).NotTo(HaveOccurred())
+		Expect(headless.Spec.ClusterIP).To(Equal("None"))
+
+		// Render the service without asking for it to be headless. The fake client doesn't allocate a
+		// cluster IP, so check that the service was recreated rather than updated.
+		svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-service"}}
+		fc = &fakeComponent{
+			supportedOSType: rmeta.OSTypeLinux,
+			objs:            []client.Object{svc},
+		}
+		err = handler.CreateOrUpdateOrDelete(ctx, fc, sm)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Get(ctx, client.ObjectKey{Name: "my-service"}, svc)).NotTo(HaveOccurred())
+		Expect(svc.Spec.ClusterIP).NotTo(Equal("None"))
+		Expect(svc.ObjectMeta.ResourceVersion).To(Equal("1"),
+			"Expected recreation of Service to reset resourceVersion to 1")
+	})
+
+	It("reports the revision of what the component rendered to the status manager", func() {
+		rs := &revisionRecordingStatus{revisions: map[string]string{}}
+		rs.On("ReadyToMonitor")
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cm", Namespace: "my-namespace"},
+			Data:       map[string]string{"a": "1"},
+		}
+		fc := &fakeComponent{supportedOSType: rmeta.OSTypeLinux, objs: []client.Object{cm}}
+
+		Expect(handler.CreateOrUpdateOrDelete(ctx, fc, rs)).NotTo(HaveOccurred())
+		Expect(rs.revisions).To(HaveKey("utils.fakeComponent"))
+		revision := rs.revisions["utils.fakeComponent"]
+		Expect(revision).NotTo(BeEmpty())
+
+		// Applying the same objects again gives the same revision.
+		fc.objs = []client.Object{cm.DeepCopy()}
+		Expect(handler.CreateOrUpdateOrDelete(ctx, fc, rs)).NotTo(HaveOccurred())
+		Expect(rs.revisions["utils.fakeComponent"]).To(Equal(revision))
+
+		cm = cm.DeepCopy()
+		cm.Data["a"] = "2"
+		fc.objs = []client.Object{cm}
+		Expect(handler.CreateOrUpdateOrDelete(ctx, fc, rs)).NotTo(HaveOccurred())
+		Expect(rs.revisions["utils.fakeComponent"]).NotTo(Equal(revision))
+
+		// The revisions of components rendered for a tenant are reported separately.
+		tenantCR := instance.DeepCopy()
+		tenantCR.Namespace = "tenant-a"
+		handler = NewComponentHandler(logf.Log.WithName("test_utils_logger"), c, scheme, tenantCR)
+		fc.objs = []client.Object{&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "my-cm", Namespace: "tenant-a"}}}
+		Expect(handler.CreateOrUpdateOrDelete(ctx, fc, rs)).NotTo(HaveOccurred())
+		Expect(rs.revisions).To(HaveKey("utils.fakeComponent/tenant-a"))
+	})
+
+	Context("object failures", func() {
+		var recorder *record.FakeRecorder
+
+		BeforeEach(func() {
+			// Fail to create config maps, the way the API server would if the operator wasn't allowed to.
+			c = ctrlrfake.DefaultFakeClientBuilder(scheme).WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if _, ok := obj.(*corev1.ConfigMap); ok {
+						return errors.NewForbidden(schema.GroupResource{Resource: "configmaps"}, obj.GetName(), fmt.Errorf("not allowed"))
+					}
+					return cl.Create(ctx, obj, opts...)
+				},
+			}).Build()
+			handler = NewComponentHandler(logf.Log.WithName("test_utils_logger"), c, scheme, instance)
+			recorder = record.NewFakeRecorder(10)
+			SetEventRecorder(recorder)
+		})
+
+		AfterEach(func() {
+			SetEventRecorder(nil)
+		})
+
+		It("identifies the object that failed and records an event against the CR", func() {
+			fc := &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objs:            []client.Object{&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "my-config", Namespace: "my-namespace"}}},
+			}
+			err := handler.CreateOrUpdateOrDelete(ctx, fc, sm)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.IsForbidden(err)).To(BeTrue())
+
+			objErr := &ObjectError{}
+			Expect(goerrors.As(err, &objErr)).To(BeTrue())
+			Expect(objErr.GVK).To(Equal(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}))
+			Expect(objErr.Key).To(Equal(types.NamespacedName{Name: "my-config", Namespace: "my-namespace"}))
+			Expect(objErr.Reason).To(Equal(metav1.StatusReasonForbidden))
+			Expect(err.Error()).To(HavePrefix("failed to apply ConfigMap my-namespace/my-config (v1): Forbidden: "))
+
+			Expect(recorder.Events).To(HaveLen(1))
+			Expect(<-recorder.Events).To(Equal(fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, ObjectFailedEventReason, err.Error())))
+		})
+
+		It("applies the other objects and reports all of the failures", func() {
+			fc := &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objs: []client.Object{
+					&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "my-namespace"}},
+					&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "my-sa", Namespace: "my-namespace"}},
+					&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "my-namespace"}},
+				},
+			}
+			err := handler.CreateOrUpdateOrDelete(ctx, fc, sm)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(HavePrefix("2 objects failed: failed to apply ConfigMap my-namespace/first (v1): Forbidden: "))
+			Expect(err.Error()).To(ContainSubstring("; failed to apply ConfigMap my-namespace/second (v1): Forbidden: "))
+
+			objErrs := ObjectErrors{}
+			Expect(goerrors.As(err, &objErrs)).To(BeTrue())
+			Expect(objErrs).To(HaveLen(2))
+			Expect(c.Get(ctx, types.NamespacedName{Name: "my-sa", Namespace: "my-namespace"}, &corev1.ServiceAccount{})).NotTo(HaveOccurred())
+
+			Expect(recorder.Events).To(HaveLen(2))
+		})
+
+		It("skips the objects in a namespace that failed to be applied", func() {
+			c = ctrlrfake.DefaultFakeClientBuilder(scheme).WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if _, ok := obj.(*corev1.Namespace); ok {
+						return errors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, obj.GetName(), fmt.Errorf("not allowed"))
+					}
+					return cl.Create(ctx, obj, opts...)
+				},
+			}).Build()
+			handler = NewComponentHandler(logf.Log.WithName("test_utils_logger"), c, scheme, instance)
+
+			fc := &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objs: []client.Object{
+					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-namespace"}},
+					&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "my-sa", Namespace: "my-namespace"}},
+					&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "my-sa", Namespace: "other-namespace"}},
+				},
+			}
+			err := handler.CreateOrUpdateOrDelete(ctx, fc, sm)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("failed to apply Namespace my-namespace (v1): Forbidden: "))
+
+			err = c.Get(ctx, types.NamespacedName{Name: "my-sa", Namespace: "my-namespace"}, &corev1.ServiceAccount{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(c.Get(ctx, types.NamespacedName{Name: "my-sa", Namespace: "other-namespace"}, &corev1.ServiceAccount{})).NotTo(HaveOccurred())
+		})
+
+		It("rejects ConfigMaps and Secrets with too much data before applying them", func() {
+			fc := &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objs: []client.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "my-namespace"},
+						Data: map[string][]byte{
+							"a": make([]byte, corev1.MaxSecretSize/2),
+							"b": make([]byte, corev1.MaxSecretSize/2+1),
+						},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Name: "small-secret", Namespace: "my-namespace"},
+						Data:       map[string][]byte{"a": make([]byte, corev1.MaxSecretSize)},
+					},
+				},
+			}
+			err := handler.CreateOrUpdateOrDelete(ctx, fc, sm)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(fmt.Sprintf("failed to apply Secret my-namespace/my-secret (v1): its data is %d bytes, which is over the Kubernetes limit of %d bytes", corev1.MaxSecretSize+1, corev1.MaxSecretSize)))
+			Expect(c.Get(ctx, types.NamespacedName{Name: "small-secret", Namespace: "my-namespace"}, &corev1.Secret{})).NotTo(HaveOccurred())
+		})
+
+		It("identifies objects that failed to be deleted", func() {
+			c = ctrlrfake.DefaultFakeClientBuilder(scheme).WithInterceptorFuncs(interceptor.Funcs{
+				Delete: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					return errors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, obj.GetName(), fmt.Errorf("not allowed"))
+				},
+			}).Build()
+			handler = NewComponentHandler(logf.Log.WithName("test_utils_logger"), c, scheme, nil)
+
+			fc := &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objsToDelete:    []client.Object{&apps.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "my-deployment", Namespace: "my-namespace"}}},
+			}
+			err := handler.CreateOrUpdateOrDelete(ctx, fc, sm)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("failed to delete Deployment my-namespace/my-deployment (apps/v1): Forbidden: "))
+
+			// Without a CR there's nothing to record the event against.
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+
+	Context("unchanged objects", func() {
+		var cm *corev1.ConfigMap
+
+		BeforeEach(func() {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-config", Namespace: "my-namespace"},
+				Data:       map[string]string{"key": "value"},
+			}
+
+			// The fake client doesn't assign UIDs, so create the object with one up front.
+			existing := cm.DeepCopy()
+			existing.UID = "my-config-uid"
+			Expect(c.Create(ctx, existing)).NotTo(HaveOccurred())
+			appliedObjects.forget(existing)
+		})
+
+		renderConfigMap := func() *corev1.ConfigMap {
+			fc := &fakeComponent{supportedOSType: rmeta.OSTypeLinux, objs: []client.Object{cm}}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			actual := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(cm), actual)).NotTo(HaveOccurred())
+			return actual
+		}
+
+		It("skips the update if neither the object nor its desired state changed", func() {
+			applied := testutil.ToFloat64(componentObjectUpdates.WithLabelValues(updateResultApplied))
+			skipped := testutil.ToFloat64(componentObjectUpdates.WithLabelValues(updateResultSkipped))
+
+			first := renderConfigMap()
+			second := renderConfigMap()
+			Expect(second.ResourceVersion).To(Equal(first.ResourceVersion))
+			Expect(testutil.ToFloat64(componentObjectUpdates.WithLabelValues(updateResultApplied))).To(Equal(applied + 1))
+			Expect(testutil.ToFloat64(componentObjectUpdates.WithLabelValues(updateResultSkipped))).To(Equal(skipped + 1))
+		})
+
+		It("re-applies an object that was modified since it was last applied", func() {
+			renderConfigMap()
+
+			cur := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(cm), cur)).NotTo(HaveOccurred())
+			cur.Data["key"] = "modified"
+			Expect(c.Update(ctx, cur)).NotTo(HaveOccurred())
+
+			Expect(renderConfigMap().Data).To(HaveKeyWithValue("key", "value"))
+		})
+
+		It("applies a change to the desired state", func() {
+			renderConfigMap()
+			cm.Data["key"] = "new-value"
+			Expect(renderConfigMap().Data).To(HaveKeyWithValue("key", "new-value"))
+		})
+
+		It("skips the update of an object that only changed in fields that are not rendered", func() {
+			d := &apps.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-deployment", Namespace: "my-namespace", UID: "my-deployment-uid"},
+				Spec: apps.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "my-container", Image: "my-image"}}},
+					},
+				},
+			}
+			Expect(c.Create(ctx, d.DeepCopy())).NotTo(HaveOccurred())
+			appliedObjects.forget(d)
+			fc := &fakeComponent{supportedOSType: rmeta.OSTypeLinux, objs: []client.Object{d}}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			// Default fields the way the API server does, which changes the resourceVersion.
+			cur := &apps.Deployment{}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(d), cur)).NotTo(HaveOccurred())
+			cur.Spec.RevisionHistoryLimit = ptr.Int32ToPtr(10)
+			cur.Spec.Template.Spec.Containers[0].TerminationMessagePath = corev1.TerminationMessagePathDefault
+			Expect(c.Update(ctx, cur)).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(d), cur)).NotTo(HaveOccurred())
+
+			skipped := testutil.ToFloat64(componentObjectUpdates.WithLabelValues(updateResultSkipped))
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(testutil.ToFloat64(componentObjectUpdates.WithLabelValues(updateResultSkipped))).To(Equal(skipped + 1))
+
+			actual := &apps.Deployment{}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(d), actual)).NotTo(HaveOccurred())
+			Expect(actual.ResourceVersion).To(Equal(cur.ResourceVersion))
+			Expect(actual.Spec.RevisionHistoryLimit).To(Equal(ptr.Int32ToPtr(10)))
+
+			// A change to a rendered field is still corrected.
+			actual.Spec.Template.Spec.Containers[0].Image = "other-image"
+			Expect(c.Update(ctx, actual)).NotTo(HaveOccurred())
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(d), actual)).NotTo(HaveOccurred())
+			Expect(actual.Spec.Template.Spec.Containers[0].Image).To(Equal("my-image"))
+		})
+
+		It("forgets objects that are deleted", func() {
+			renderConfigMap()
+			_, ok := appliedObjects.get(cm, desiredStateHash(cm))
+			Expect(ok).To(BeFalse(), "the rendered object has no UID")
+			Expect(appliedObjects.objects).To(HaveKey(newAppliedObjectKey(cm)))
+
+			fc := &fakeComponent{supportedOSType: rmeta.OSTypeLinux, objsToDelete: []client.Object{cm}}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(appliedObjects.objects).NotTo(HaveKey(newAppliedObjectKey(cm)))
+		})
+	})
+
+	Context("metrics", func() {
+		applied := func(operation string) float64 {
+			return testutil.ToFloat64(componentObjectsApplied.WithLabelValues("utils.fakeComponent", "", "v1", "ConfigMap", operation))
+		}
+
+		It("counts the objects that are created, updated and deleted by component and kind", func() {
+			created, updated := applied(applyOperationCreate), applied(applyOperationUpdate)
+			deleted := testutil.ToFloat64(componentObjectsDeleted.WithLabelValues("utils.fakeComponent", "", "v1", "ConfigMap"))
+
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-config", Namespace: "my-namespace"},
+				Data:       map[string]string{"key": "value"},
+			}
+			fc := &fakeComponent{supportedOSType: rmeta.OSTypeLinux, objs: []client.Object{cm}}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(applied(applyOperationCreate)).To(Equal(created + 1))
+
+			cm.Data["key"] = "new-value"
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(applied(applyOperationUpdate)).To(Equal(updated + 1))
+
+			fc = &fakeComponent{supportedOSType: rmeta.OSTypeLinux, objsToDelete: []client.Object{cm}}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(testutil.ToFloat64(componentObjectsDeleted.WithLabelValues("utils.fakeComponent", "", "v1", "ConfigMap"))).To(Equal(deleted + 1))
+
+			// Deleting an object that is already gone isn't counted.
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			Expect(testutil.ToFloat64(componentObjectsDeleted.WithLabelValues("utils.fakeComponent", "", "v1", "ConfigMap"))).To(Equal(deleted + 1))
+		})
+
+		It("reports a component that is wrapped like the core controller does under its own name", func() {
+			created := applied(applyOperationCreate)
+
+			tracker, err := rollback.NewTracker(ctx, c, scheme, instance)
+			Expect(err).NotTo(HaveOccurred())
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "wrapped-config", Namespace: "my-namespace"}}
+			fc := &fakeComponent{supportedOSType: rmeta.OSTypeLinux, objs: []client.Object{cm}}
+			wrapped := tracker.Wrap(maintenance.NewDeferrer(ctx, c, true).Wrap(fc))
+
+			Expect(componentName(wrapped)).To(Equal("utils.fakeComponent"))
+			Expect(handler.CreateOrUpdateOrDelete(ctx, wrapped, sm)).NotTo(HaveOccurred())
+			Expect(applied(applyOperationCreate)).To(Equal(created + 1))
+		})
+
+		It("labels the metrics of an object with its component and GVK", func() {
+			h := handler.(*componentHandler)
+			Expect(h.objectMetrics("utils.fakeComponent", &corev1.ConfigMap{})).To(Equal(
+				objectMetrics{component: "utils.fakeComponent", version: "v1", kind: "ConfigMap"},
+			))
+			Expect(h.objectMetrics("utils.fakeComponent", &apps.DaemonSet{})).To(Equal(
+				objectMetrics{component: "utils.fakeComponent", group: "apps", version: "v1", kind: "DaemonSet"},
+			))
+		})
+	})
+
+	Context("secret replication", func() {
+		var source *corev1.Secret
+
+		BeforeEach(func() {
+			source = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: common.OperatorNamespace()},
+				Data:       map[string][]byte{"key": []byte("value")},
+			}
+		})
+
+		renderCopies := func(objs ...client.Object) {
+			fc := &fakeReplicatingComponent{fakeComponent{supportedOSType: rmeta.OSTypeLinux, objs: objs}}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+		}
+
+		It("labels copied secrets", func() {
+			renderCopies(rsecret.CopyToNamespace("my-namespace", source)[0])
+
+			actual := &corev1.Secret{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "my-secret", Namespace: "my-namespace"}, actual)).NotTo(HaveOccurred())
+			Expect(actual.Labels).To(HaveKeyWithValue(rsecret.ReplicatedByLabel, "fake-component"))
+			Expect(actual.Annotations).To(HaveKeyWithValue(rsecret.ReplicatedFromAnnotation, "tigera-operator/my-secret"))
+			Expect(actual.Data).To(Equal(source.Data))
+		})
+
+		It("does not label secrets that were not copied", func() {
+			renderCopies(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "my-namespace"}})
+
+			actual := &corev1.Secret{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "my-secret", Namespace: "my-namespace"}, actual)).NotTo(HaveOccurred())
+			Expect(actual.Labels).NotTo(HaveKey(rsecret.ReplicatedByLabel))
+		})
+
+		It("deletes copies that are no longer rendered", func() {
+			renderCopies(rsecret.CopyToNamespace("my-namespace", source)[0])
+			renderCopies()
+
+			err := c.Get(ctx, types.NamespacedName{Name: "my-secret", Namespace: "my-namespace"}, &corev1.Secret{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("does not delete copies owned by another custom resource", func() {
+			renderCopies(rsecret.CopyToNamespace("my-namespace", source)[0])
+
+			other := &operatorv1.Manager{
+				TypeMeta:   metav1.TypeMeta{Kind: "Manager", APIVersion: "operator.tigera.io/v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure", Namespace: "other-namespace", UID: "other-uid"},
+			}
+			otherHandler := NewComponentHandler(logf.Log.WithName("test_utils_logger"), c, scheme, other)
+			fc := &fakeReplicatingComponent{fakeComponent{supportedOSType: rmeta.OSTypeLinux}}
+			Expect(otherHandler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			Expect(c.Get(ctx, types.NamespacedName{Name: "my-secret", Namespace: "my-namespace"}, &corev1.Secret{})).NotTo(HaveOccurred())
+		})
+
+		It("maps a secret to the requests of its copies through the index", func() {
+			copy1 := rsecret.CopyToNamespace("ns-1", source)[0]
+			copy2 := rsecret.CopyToNamespace("ns-2", source)[0]
+			otherSource := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other-secret", Namespace: common.OperatorNamespace()}}
+			other := rsecret.CopyToNamespace("ns-1", otherSource)[0]
+			cl := ctrlrfake.DefaultFakeClientBuilder(scheme).
+				WithIndex(&corev1.Secret{}, replicatedFromIndex, replicatedFromIndexValues).
+				WithObjects(source, copy1, copy2, otherSource, other).
+				Build()
+
+			requests := replicatedSecretRequests(cl)(ctx, source)
+			Expect(requests).To(ConsistOf(
+				reconcile.Request{NamespacedName: types.NamespacedName{Name: "my-secret", Namespace: "ns-1"}},
+				reconcile.Request{NamespacedName: types.NamespacedName{Name: "my-secret", Namespace: "ns-2"}},
+			))
+			Expect(replicatedSecretRequests(cl)(ctx, copy1)).To(BeEmpty())
+		})
+	})
+
+	Context("service IP families", func() {
+		createInstallation := func(cidrs ...string) {
+			installation := &operatorv1.Installation{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec:       operatorv1.InstallationSpec{CalicoNetwork: &operatorv1.CalicoNetworkSpec{}},
+			}
+			for _, cidr := range cidrs {
+				installation.Spec.CalicoNetwork.IPPools = append(installation.Spec.CalicoNetwork.IPPools, operatorv1.IPPool{CIDR: cidr})
+			}
+			Expect(c.Create(ctx, installation)).NotTo(HaveOccurred())
+		}
+
+		renderService := func(svc *corev1.Service) *corev1.Service {
+			fc := &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objs:            []client.Object{svc},
+			}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			actual := &corev1.Service{}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(svc), actual)).NotTo(HaveOccurred())
+			return actual
+		}
+
+		It("leaves IP families unset when there is no Installation", func() {
+			svc := renderService(&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-service"}})
+			Expect(svc.Spec.IPFamilyPolicy).To(BeNil())
+			Expect(svc.Spec.IPFamilies).To(BeEmpty())
+		})
+
+		It("leaves IP families unset in an IPv4 cluster", func() {
+			createInstallation("192.168.0.0/16")
+			svc := renderService(&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-service"}})
+			Expect(svc.Spec.IPFamilyPolicy).To(BeNil())
+			Expect(svc.Spec.IPFamilies).To(BeEmpty())
+		})
+
+		It("renders single stack IPv6 services in an IPv6 cluster", func() {
+			createInstallation("fd00::/48")
+			svc := renderService(&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-service"}})
+			Expect(svc.Spec.IPFamilyPolicy).To(Equal(ptr.ToPtr(corev1.IPFamilyPolicySingleStack)))
+			Expect(svc.Spec.IPFamilies).To(ConsistOf(corev1.IPv6Protocol))
+		})
+
+		It("prefers dual stack services in a dual stack cluster", func() {
+			createInstallation("192.168.0.0/16", "fd00::/48")
+			svc := renderService(&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-service"}})
+			Expect(svc.Spec.IPFamilyPolicy).To(Equal(ptr.ToPtr(corev1.IPFamilyPolicyPreferDualStack)))
+			Expect(svc.Spec.IPFamilies).To(BeEmpty())
+		})
+
+		It("does not override IP families chosen by the render", func() {
+			createInstallation("fd00::/48")
+			svc := renderService(&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-service"},
+				Spec:       corev1.ServiceSpec{IPFamilyPolicy: ptr.ToPtr(corev1.IPFamilyPolicyRequireDualStack)},
+			})
+			Expect(svc.Spec.IPFamilyPolicy).To(Equal(ptr.ToPtr(corev1.IPFamilyPolicyRequireDualStack)))
+			Expect(svc.Spec.IPFamilies).To(BeEmpty())
+		})
+
+		It("does not set IP families on ExternalName services", func() {
+			createInstallation("fd00::/48")
+			svc := renderService(&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-service"},
+				Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "example.com"},
+			})
+			Expect(svc.Spec.IPFamilyPolicy).To(BeNil())
+			Expect(svc.Spec.IPFamilies).To(BeEmpty())
+		})
+	})
+
+	Context("apply validation", func() {
+		var fc *fakeComponent
+		var recorder *record.FakeRecorder
+
+		BeforeEach(func() {
+			// Reject config maps with an invalid data key, the way the API server would when validating them.
+			invalid := func(obj client.Object) error {
+				if cm, ok := obj.(*corev1.ConfigMap); ok {
+					if _, ok := cm.Data["bad key"]; ok {
+						return errors.NewInvalid(schema.GroupKind{Kind: "ConfigMap"}, cm.Name, nil)
+					}
+				}
+				return nil
+			}
+			c = ctrlrfake.DefaultFakeClientBuilder(scheme).WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if err := invalid(obj); err != nil {
+						return err
+					}
+					return cl.Create(ctx, obj, opts...)
+				},
+				Update: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					if err := invalid(obj); err != nil {
+						return err
+					}
+					return cl.Update(ctx, obj, opts...)
+				},
+			}).Build()
+			handler = NewComponentHandler(logf.Log.WithName("test_utils_logger"), c, scheme, instance)
+			recorder = record.NewFakeRecorder(10)
+			SetEventRecorder(recorder)
+
+			Expect(c.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "my-namespace"},
+				Data:       map[string]string{"key": "value"},
+			})).NotTo(HaveOccurred())
+			fc = &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objs: []client.Object{
+					&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "my-sa", Namespace: "my-namespace"}},
+					&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "my-namespace"}, Data: map[string]string{"bad key": "value"}},
+					&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "my-namespace"}, Data: map[string]string{"bad key": "value"}},
+				},
+			}
+		})
+
+		AfterEach(func() {
+			SetEventRecorder(nil)
+		})
+
+		createInstallation := func(validation *operatorv1.ApplyValidationType) {
+			Expect(c.Create(ctx, &operatorv1.Installation{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec:       operatorv1.InstallationSpec{ApplyValidation: validation},
+			})).NotTo(HaveOccurred())
+		}
+
+		It("applies the valid objects when it's not enabled", func() {
+			createInstallation(nil)
+			err := handler.CreateOrUpdateOrDelete(ctx, fc, sm)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("2 objects failed: failed to apply ConfigMap my-namespace/new (v1): Invalid: "))
+			Expect(c.Get(ctx, types.NamespacedName{Name: "my-sa", Namespace: "my-namespace"}, &corev1.ServiceAccount{})).NotTo(HaveOccurred())
+		})
+
+		It("reports all of the invalid objects and applies none of them", func() {
+			createInstallation(ptr.ToPtr(operatorv1.ApplyValidationDryRun))
+			err := handler.CreateOrUpdateOrDelete(ctx, fc, sm)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.IsInvalid(err)).To(BeTrue())
+
+			valErr := &ValidationError{}
+			Expect(goerrors.As(err, &valErr)).To(BeTrue())
+			Expect(valErr.Errors).To(HaveLen(2))
+			Expect(valErr.Errors[0].Op).To(Equal("validate"))
+			Expect(valErr.Errors[0].Key).To(Equal(types.NamespacedName{Name: "new", Namespace: "my-namespace"}))
+			Expect(valErr.Errors[1].Key).To(Equal(types.NamespacedName{Name: "existing", Namespace: "my-namespace"}))
+			Expect(err.Error()).To(HavePrefix("2 of the rendered objects failed validation, none were applied: failed to validate ConfigMap my-namespace/new (v1): Invalid: "))
+
+			err = c.Get(ctx, types.NamespacedName{Name: "my-sa", Namespace: "my-namespace"}, &corev1.ServiceAccount{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			cm := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "existing", Namespace: "my-namespace"}, cm)).NotTo(HaveOccurred())
+			Expect(cm.Data).To(Equal(map[string]string{"key": "value"}))
+
+			Expect(recorder.Events).To(HaveLen(2))
+		})
+
+		It("applies the objects once they are valid", func() {
+			createInstallation(ptr.ToPtr(operatorv1.ApplyValidationDryRun))
+			fc.objs[1].(*corev1.ConfigMap).Data = map[string]string{"key": "new"}
+			fc.objs[2].(*corev1.ConfigMap).Data = map[string]string{"key": "updated"}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			Expect(c.Get(ctx, types.NamespacedName{Name: "my-sa", Namespace: "my-namespace"}, &corev1.ServiceAccount{})).NotTo(HaveOccurred())
+			cm := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "existing", Namespace: "my-namespace"}, cm)).NotTo(HaveOccurred())
+			Expect(cm.Data).To(Equal(map[string]string{"key": "updated"}))
+		})
+	})
+
+	Context("ownership", func() {
+		var owner *operatorv1.Manager
+		var objs []client.Object
+
+		BeforeEach(func() {
+			owner = &operatorv1.Manager{
+				TypeMeta:   metav1.TypeMeta{Kind: "Manager", APIVersion: "operator.tigera.io/v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure", Namespace: "tenant-a", UID: "1234"},
+			}
+			Expect(c.Create(ctx, owner)).NotTo(HaveOccurred())
+			handler = NewComponentHandler(logf.Log.WithName("test_utils_logger"), c, scheme, owner)
+			objs = []client.Object{
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "same-namespace", Namespace: "tenant-a"}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "tenant-b"}},
+				&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "cluster-scoped"}},
+				&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "shared", Labels: map[string]string{common.MultipleOwnersLabel: "true"}}},
+			}
+		})
+
+		get := func(obj client.Object) client.Object {
+			actual := obj.DeepCopyObject().(client.Object)
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(obj), actual)).NotTo(HaveOccurred())
+			return actual
+		}
+
+		It("only sets owner references that Kubernetes allows by default", func() {
+			fc := &fakeComponent{supportedOSType: rmeta.OSTypeLinux, objs: objs}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			Expect(get(objs[0]).GetOwnerReferences()).To(HaveLen(1))
+			for _, obj := range objs[1:] {
+				actual := get(obj)
+				Expect(actual.GetOwnerReferences()).To(BeEmpty())
+				Expect(actual.GetLabels()).NotTo(HaveKey(OwnerUIDLabel))
+			}
+			Expect(get(owner).GetFinalizers()).To(BeEmpty())
+		})
+
+		It("labels the objects that can't have an owner reference and cleans them up", func() {
+			fc := &fakeOwnershipComponent{fakeComponent{supportedOSType: rmeta.OSTypeLinux, objs: objs}, render.OwnershipLabels}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			sameNS := get(objs[0])
+			Expect(sameNS.GetOwnerReferences()).To(HaveLen(1))
+			Expect(sameNS.GetLabels()).NotTo(HaveKey(OwnerUIDLabel))
+			for _, obj := range objs[1:3] {
+				actual := get(obj)
+				Expect(actual.GetOwnerReferences()).To(BeEmpty())
+				Expect(actual.GetLabels()).To(HaveKeyWithValue(OwnerUIDLabel, "1234"))
+				Expect(actual.GetAnnotations()).To(HaveKeyWithValue(OwnerAnnotation, "Manager tenant-a/tigera-secure"))
+			}
+			Expect(get(objs[3]).GetLabels()).NotTo(HaveKey(OwnerUIDLabel))
+			Expect(get(owner).GetFinalizers()).To(ConsistOf(OwnedObjectsFinalizer))
+
+			Expect(DeleteLabelOwnedObjects(ctx, c, owner, &corev1.ConfigMapList{}, &rbacv1.ClusterRoleList{})).NotTo(HaveOccurred())
+			for _, obj := range objs[1:3] {
+				err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object))
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			}
+			get(objs[0])
+			get(objs[3])
+			Expect(get(owner).GetFinalizers()).To(BeEmpty())
+		})
+	})
+
+	Context("workload provenance", func() {
+		digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+		renderDeployment := func(provenance *operatorv1.WorkloadProvenanceType) *apps.Deployment {
+			Expect(c.Create(ctx, &operatorv1.Installation{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec:       operatorv1.InstallationSpec{WorkloadProvenance: provenance},
+			})).NotTo(HaveOccurred())
+			d := &apps.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-deployment", Namespace: "my-namespace"},
+				Spec: apps.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							InitContainers: []corev1.Container{{Name: "init", Image: "quay.io/tigera/init@" + digest}},
+							Containers:     []corev1.Container{{Name: "main", Image: "quay.io/tigera/main:v1.0.0"}},
+						},
+					},
+				},
+			}
+			fc := &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objs:            []client.Object{d},
+			}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+			actual := &apps.Deployment{}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(d), actual)).NotTo(HaveOccurred())
+			return actual
+		}
+
+		It("leaves the pods alone when it's not enabled", func() {
+			d := renderDeployment(nil)
+			Expect(d.Spec.Template.Labels).NotTo(HaveKey(OperatorVersionLabel))
+			Expect(d.Spec.Template.Labels).NotTo(HaveKey(OperatorGitSHALabel))
+			Expect(d.Spec.Template.Annotations).NotTo(HaveKey(ImageProvenanceAnnotation))
+		})
+
+		It("labels and annotates the pods with their provenance", func() {
+			d := renderDeployment(ptr.ToPtr(operatorv1.WorkloadProvenanceEnabled))
+			Expect(d.Spec.Template.Labels).To(HaveKeyWithValue(OperatorGitSHALabel, "unknown"))
+			Expect(d.Spec.Template.Labels).To(HaveKey(OperatorVersionLabel))
+
+			var images []ImageProvenance
+			Expect(json.Unmarshal([]byte(d.Spec.Template.Annotations[ImageProvenanceAnnotation]), &images)).NotTo(HaveOccurred())
+			Expect(images).To(Equal([]ImageProvenance{
+				{
+					Container: "init",
+					Image:     "quay.io/tigera/init@" + digest,
+					Digest:    digest,
+					SBOM:      "quay.io/tigera/init:sha256-0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef.sbom",
+				},
+				{Container: "main", Image: "quay.io/tigera/main:v1.0.0"},
+			}))
+		})
+	})
+
+	Context("component tracing", func() {
+		renderDeployment := func(component func(*fakeComponent) render.Component) *apps.Deployment {
+			d := &apps.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-deployment", Namespace: "my-namespace"},
+				Spec: apps.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{Name: "traced", Env: []corev1.EnvVar{{Name: "OTEL_TRACES_SAMPLER", Value: "always_on"}}},
+								{Name: "sidecar"},
+							},
+						},
+					},
+				},
+			}
+			fc := &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objs:            []client.Object{d},
+			}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, component(fc), sm)).NotTo(HaveOccurred())
+			actual := &apps.Deployment{}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(d), actual)).NotTo(HaveOccurred())
+			return actual
+		}
+		tracingComponent := func(fc *fakeComponent) render.Component {
+			return &fakeTracingComponent{fakeComponent: *fc, containers: []string{"traced"}}
+		}
+
+		BeforeEach(func() {
+			Expect(c.Create(ctx, &operatorv1.OperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec: operatorv1.OperatorConfigSpec{
+					Tracing: &operatorv1.OperatorTracing{
+						Endpoint:           "http://collector.observability:4318",
+						SamplingPercentage: ptr.Int32ToPtr(25),
+						Components:         ptr.ToPtr(operatorv1.ComponentTracingEnabled),
+					},
+				},
+			})).NotTo(HaveOccurred())
+		})
+
+		It("configures the tracing containers of tracing components", func() {
+			d := renderDeployment(tracingComponent)
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ConsistOf(
+				corev1.EnvVar{Name: "OTEL_TRACES_SAMPLER", Value: "always_on"},
+				corev1.EnvVar{Name: "OTEL_TRACES_EXPORTER", Value: "otlp"},
+				corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: "http/protobuf"},
+				corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://collector.observability:4318"},
+				corev1.EnvVar{Name: "OTEL_TRACES_SAMPLER_ARG", Value: "0.25"},
+			))
+			Expect(d.Spec.Template.Spec.Containers[1].Env).To(BeEmpty())
+		})
+
+		It("leaves the containers of other components alone", func() {
+			d := renderDeployment(func(fc *fakeComponent) render.Component { return fc })
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(HaveLen(1))
+		})
+
+		It("leaves the containers alone when component tracing is disabled", func() {
+			oc := &operatorv1.OperatorConfig{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "default"}, oc)).NotTo(HaveOccurred())
+			oc.Spec.Tracing.Components = ptr.ToPtr(operatorv1.ComponentTracingDisabled)
+			Expect(c.Update(ctx, oc)).NotTo(HaveOccurred())
+
+			d := renderDeployment(tracingComponent)
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(HaveLen(1))
+		})
+	})
+
 	It("allows you to replace a secret if the types change", func() {
 		// Please note that a fake client does not behave exactly as it would on K8s:
 		// - A secret without a type in a real cluster automatically becomes type Opaque
@@ -1491,6 +2295,47 @@ var _ = Describe("Component handler tests", func() {
 			Expect(containers).To(HaveLen(1))
 			Expect(containers[0].LivenessProbe).To(BeNil())
 			Expect(containers[0].ReadinessProbe).To(BeNil())
+			Expect(containers[0].StartupProbe).To(BeNil())
+		})
+
+		It("should set startup probe default values", func() {
+			fc := &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objs: []client.Object{
+					&apps.Deployment{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "test-deployment",
+							Namespace: "test-namespace",
+						},
+						Spec: apps.DeploymentSpec{
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{
+										{
+											Name:         "test-deployment-container",
+											StartupProbe: &corev1.Probe{FailureThreshold: 60},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			err := handler.CreateOrUpdateOrDelete(ctx, fc, sm)
+			Expect(err).NotTo(HaveOccurred())
+
+			var deploy apps.Deployment
+			err = c.Get(ctx, client.ObjectKey{Name: "test-deployment", Namespace: "test-namespace"}, &deploy)
+			Expect(err).NotTo(HaveOccurred())
+			containers := deploy.Spec.Template.Spec.Containers
+
+			Expect(containers).To(HaveLen(1))
+			Expect(containers[0].StartupProbe.FailureThreshold).To(BeEquivalentTo(60))
+			Expect(containers[0].StartupProbe.PeriodSeconds).To(BeEquivalentTo(10))
+			Expect(containers[0].StartupProbe.SuccessThreshold).To(BeEquivalentTo(1))
+			Expect(containers[0].StartupProbe.TimeoutSeconds).To(BeEquivalentTo(5))
 		})
 	})
 
@@ -1688,6 +2533,12 @@ var _ = Describe("Mocked client Component handler tests", func() {
 		ctx = context.Background()
 
 		handler = NewComponentHandler(log, c, runtime.NewScheme(), nil)
+
+		// The Installation is read first, to see how the objects should be applied.
+		mc.Info = append(mc.Info, mockReturn{
+			Method: "Get",
+			Return: errors.NewNotFound(schema.GroupResource{}, "default"),
+		})
 	})
 
 	Context("Resource conflicts", func() {
@@ -1736,10 +2587,13 @@ var _ = Describe("Mocked client Component handler tests", func() {
 				InputMutator: setToDS,
 			})
 
+			conflicts := testutil.ToFloat64(componentObjectConflicts.WithLabelValues("utils.fakeComponent", "", "", "DaemonSet"))
+
 			err := handler.CreateOrUpdateOrDelete(ctx, fc, nil)
 			Expect(err).To(BeNil())
 
-			Expect(mc.Index).To(Equal(4))
+			Expect(mc.Index).To(Equal(5))
+			Expect(testutil.ToFloat64(componentObjectConflicts.WithLabelValues("utils.fakeComponent", "", "", "DaemonSet"))).To(Equal(conflicts + 1))
 		})
 
 		It("if Updating a resource conflicts try the update again", func() {
@@ -1762,10 +2616,13 @@ var _ = Describe("Mocked client Component handler tests", func() {
 				Return: errors.NewConflict(schema.GroupResource{}, "error name", fmt.Errorf("test error message")),
 			})
 
+			conflicts := testutil.ToFloat64(componentObjectConflicts.WithLabelValues("utils.fakeComponent", "", "", "DaemonSet"))
+
 			err := handler.CreateOrUpdateOrDelete(ctx, fc, nil)
 			Expect(err).NotTo(BeNil())
 
-			Expect(mc.Index).To(Equal(4))
+			Expect(mc.Index).To(Equal(5))
+			Expect(testutil.ToFloat64(componentObjectConflicts.WithLabelValues("utils.fakeComponent", "", "", "DaemonSet"))).To(Equal(conflicts + 2))
 		})
 	})
 
@@ -1808,7 +2665,7 @@ var _ = Describe("Mocked client Component handler tests", func() {
 
 			err := handler.CreateOrUpdateOrDelete(ctx, fc, nil)
 			Expect(err).To(BeNil())
-			Expect(mc.Index).To(Equal(1))
+			Expect(mc.Index).To(Equal(2))
 		})
 
 		It("NetworkPolicy updates are applied if there is a change", func() {
@@ -1833,7 +2690,7 @@ var _ = Describe("Mocked client Component handler tests", func() {
 
 			err := handler.CreateOrUpdateOrDelete(ctx, fc, nil)
 			Expect(err).To(BeNil())
-			Expect(mc.Index).To(Equal(2))
+			Expect(mc.Index).To(Equal(3))
 		})
 	})
 
@@ -1862,7 +2719,7 @@ var _ = Describe("Mocked client Component handler tests", func() {
 
 			err := handler.CreateOrUpdateOrDelete(ctx, fc, nil)
 			Expect(err).To(BeNil())
-			Expect(mc.Index).To(Equal(1))
+			Expect(mc.Index).To(Equal(2))
 		})
 
 		It("Tier updates are applied if there is a change", func() {
@@ -1888,7 +2745,7 @@ var _ = Describe("Mocked client Component handler tests", func() {
 
 			err := handler.CreateOrUpdateOrDelete(ctx, fc, nil)
 			Expect(err).To(BeNil())
-			Expect(mc.Index).To(Equal(2))
+			Expect(mc.Index).To(Equal(3))
 		})
 	})
 })
@@ -1896,6 +2753,7 @@ var _ = Describe("Mocked client Component handler tests", func() {
 // A fake component that only returns ready and always creates the "test-namespace" Namespace.
 type fakeComponent struct {
 	objs            []client.Object
+	objsToDelete    []client.Object
 	supportedOSType rmeta.OSType
 }
 
@@ -1908,13 +2766,49 @@ func (c *fakeComponent) ResolveImages(is *operatorv1.ImageSet) error {
 }
 
 func (c *fakeComponent) Objects() ([]client.Object, []client.Object) {
-	return c.objs, nil
+	return c.objs, c.objsToDelete
 }
 
 func (c *fakeComponent) SupportedOSType() rmeta.OSType {
 	return c.supportedOSType
 }
 
+// revisionRecordingStatus is a mock status manager that records the applied revisions.
+type revisionRecordingStatus struct {
+	status.MockStatus
+	revisions map[string]string
+}
+
+func (s *revisionRecordingStatus) SetAppliedRevision(_ context.Context, component, revision string) {
+	s.revisions[component] = revision
+}
+
+type fakeReplicatingComponent struct {
+	fakeComponent
+}
+
+func (c *fakeReplicatingComponent) ReplicatedSecretsKey() string {
+	return "fake-component"
+}
+
+type fakeOwnershipComponent struct {
+	fakeComponent
+	strategy render.OwnershipStrategy
+}
+
+func (c *fakeOwnershipComponent) OwnershipStrategy() render.OwnershipStrategy {
+	return c.strategy
+}
+
+type fakeTracingComponent struct {
+	fakeComponent
+	containers []string
+}
+
+func (c *fakeTracingComponent) TracingContainers() []string {
+	return c.containers
+}
+
 type mockReturn struct {
 	Method       string
 	Return       interface{}
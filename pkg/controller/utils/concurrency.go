@@ -0,0 +1,89 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/options"
+)
+
+// MaxConcurrentReconciles returns the maximum number of concurrent reconciles for each controller, as configured in
+// the OperatorConfig. Controllers that are not configured are left out.
+func MaxConcurrentReconciles(oc *operatorv1.OperatorConfig) map[string]int {
+	if oc == nil {
+		return nil
+	}
+	var concurrency map[string]int
+	for _, c := range oc.Spec.Controllers {
+		if c.MaxConcurrentReconciles == nil {
+			continue
+		}
+		if concurrency == nil {
+			concurrency = map[string]int{}
+		}
+		concurrency[c.Name] = int(*c.MaxConcurrentReconciles)
+	}
+	return concurrency
+}
+
+// ControllerOptions returns the options for creating the named controller with the given reconciler.
+//
+// Controllers reconcile one request at a time, unless a higher concurrency is configured for them and the operator
+// runs in multi-tenant mode. Requests for the same tenant may still have different keys (e.g., the names of different
+// secrets in the tenant's namespace), so in that case the requests of each tenant are serialized and only the
+// requests of different tenants are reconciled in parallel.
+func ControllerOptions(name string, r reconcile.Reconciler, opts options.AddOptions) controller.Options {
+	n := opts.MaxConcurrentReconciles[name]
+	if !opts.MultiTenant || n <= 1 {
+		return controller.Options{Reconciler: r}
+	}
+	return controller.Options{
+		Reconciler:              &perTenantReconciler{reconciler: r, locks: map[string]*sync.Mutex{}},
+		MaxConcurrentReconciles: n,
+	}
+}
+
+// perTenantReconciler wraps a reconciler so that requests for the same tenant, identified by the namespace of the
+// request, are never reconciled at the same time.
+type perTenantReconciler struct {
+	reconciler reconcile.Reconciler
+
+	lock  sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func (r *perTenantReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	l := r.tenantLock(request.Namespace)
+	l.Lock()
+	defer l.Unlock()
+	return r.reconciler.Reconcile(ctx, request)
+}
+
+func (r *perTenantReconciler) tenantLock(namespace string) *sync.Mutex {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	l, ok := r.locks[namespace]
+	if !ok {
+		l = &sync.Mutex{}
+		r.locks[namespace] = l
+	}
+	return l
+}
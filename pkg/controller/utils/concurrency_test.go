@@ -0,0 +1,128 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+// blockingReconciler records the requests it is reconciling and blocks until released.
+type blockingReconciler struct {
+	lock    sync.Mutex
+	active  map[string]int
+	release chan struct{}
+}
+
+func (r *blockingReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	r.lock.Lock()
+	r.active[request.Namespace]++
+	r.lock.Unlock()
+
+	<-r.release
+
+	r.lock.Lock()
+	r.active[request.Namespace]--
+	r.lock.Unlock()
+	return reconcile.Result{}, nil
+}
+
+func (r *blockingReconciler) activeRequests(namespace string) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.active[namespace]
+}
+
+var _ = Describe("ControllerOptions", func() {
+	var r *blockingReconciler
+
+	BeforeEach(func() {
+		r = &blockingReconciler{active: map[string]int{}, release: make(chan struct{})}
+	})
+
+	It("should reconcile one request at a time by default", func() {
+		opts := ControllerOptions("manager-controller", r, options.AddOptions{MultiTenant: true})
+		Expect(opts.MaxConcurrentReconciles).To(BeZero())
+		Expect(opts.Reconciler).To(Equal(r))
+	})
+
+	It("should ignore the configured concurrency outside of multi-tenant mode", func() {
+		opts := ControllerOptions("manager-controller", r, options.AddOptions{
+			MaxConcurrentReconciles: map[string]int{"manager-controller": 4},
+		})
+		Expect(opts.MaxConcurrentReconciles).To(BeZero())
+		Expect(opts.Reconciler).To(Equal(r))
+	})
+
+	It("should only reconcile requests for different tenants concurrently", func() {
+		opts := ControllerOptions("manager-controller", r, options.AddOptions{
+			MultiTenant:             true,
+			MaxConcurrentReconciles: map[string]int{"manager-controller": 4},
+		})
+		Expect(opts.MaxConcurrentReconciles).To(Equal(4))
+
+		var wg sync.WaitGroup
+		for _, key := range []types.NamespacedName{
+			{Namespace: "tenant-a", Name: "secret-1"},
+			{Namespace: "tenant-a", Name: "secret-2"},
+			{Namespace: "tenant-b", Name: "secret-1"},
+		} {
+			wg.Add(1)
+			go func(key types.NamespacedName) {
+				defer wg.Done()
+				_, _ = opts.Reconciler.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+			}(key)
+		}
+
+		Eventually(func() int { return r.activeRequests("tenant-b") }).Should(Equal(1))
+		Eventually(func() int { return r.activeRequests("tenant-a") }).Should(Equal(1))
+		Consistently(func() int { return r.activeRequests("tenant-a") }, 100*time.Millisecond).Should(Equal(1))
+
+		close(r.release)
+		wg.Wait()
+	})
+})
+
+var _ = Describe("controller concurrency configuration", func() {
+	It("should default to no concurrency settings", func() {
+		Expect(MaxConcurrentReconciles(nil)).To(BeNil())
+		Expect(MaxConcurrentReconciles(&operatorv1.OperatorConfig{})).To(BeNil())
+	})
+
+	It("should read the concurrency of each configured controller", func() {
+		oc := &operatorv1.OperatorConfig{Spec: operatorv1.OperatorConfigSpec{
+			Controllers: []operatorv1.OperatorControllerConfig{
+				{Name: "manager-controller", MaxConcurrentReconciles: ptr.Int32ToPtr(4)},
+				{Name: "compliance-controller", MaxConcurrentReconciles: ptr.Int32ToPtr(2)},
+				{Name: "intrusiondetection-controller"},
+			},
+		}}
+		Expect(MaxConcurrentReconciles(oc)).To(Equal(map[string]int{
+			"manager-controller":    4,
+			"compliance-controller": 2,
+		}))
+	})
+})
@@ -0,0 +1,111 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Dependency is a component that must be serving before a component that depends on it is rendered.
+type Dependency struct {
+	// Name identifies the dependency in status messages, e.g., "Compliance server".
+	Name string
+
+	// Deployment is the deployment of the dependency. It must have rolled out and have all of its replicas available.
+	Deployment types.NamespacedName
+
+	// Service is the service of the dependency. If set, it must have a ready endpoint.
+	Service *types.NamespacedName
+}
+
+// DependencyChecker checks the live readiness of the dependencies of a component. Unlike the state in the status of a
+// dependency's CR, which only says that its objects were rendered and became available at some point, it looks at the
+// current state of the dependency's deployment and endpoints.
+//
+// The operator doesn't connect to the dependencies itself: it runs on the host network, and the network policies of
+// the dependencies only admit the components that use them.
+type DependencyChecker struct {
+	reader client.Reader
+}
+
+// NewDependencyChecker returns a DependencyChecker that reads deployments and endpoints with the given reader. This
+// should be a reader that doesn't use the cache (e.g., the API reader of the manager), so that the operator doesn't
+// have to cache every Endpoints resource in the cluster.
+func NewDependencyChecker(reader client.Reader) *DependencyChecker {
+	return &DependencyChecker{reader: reader}
+}
+
+// Check returns nil if all the given dependencies are ready. Otherwise, it returns an error that describes what the
+// first dependency that isn't ready is waiting on, which can be used as the message of the status condition.
+func (d *DependencyChecker) Check(ctx context.Context, deps ...Dependency) error {
+	for _, dep := range deps {
+		if err := d.checkDeployment(ctx, dep); err != nil {
+			return err
+		}
+		if dep.Service != nil {
+			if err := d.checkService(ctx, dep); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *DependencyChecker) checkDeployment(ctx context.Context, dep Dependency) error {
+	deploy := &appsv1.Deployment{}
+	if err := d.reader.Get(ctx, dep.Deployment, deploy); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("%s deployment %s does not exist", dep.Name, dep.Deployment)
+		}
+		return fmt.Errorf("failed to query %s deployment %s: %w", dep.Name, dep.Deployment, err)
+	}
+
+	replicas := int32(1)
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+	if deploy.Status.ObservedGeneration < deploy.Generation || deploy.Status.UpdatedReplicas < replicas {
+		return fmt.Errorf("%s deployment %s is rolling out (%d of %d replicas updated)", dep.Name, dep.Deployment, deploy.Status.UpdatedReplicas, replicas)
+	}
+	if deploy.Status.AvailableReplicas < replicas {
+		return fmt.Errorf("%s deployment %s is not available (%d of %d replicas available)", dep.Name, dep.Deployment, deploy.Status.AvailableReplicas, replicas)
+	}
+	return nil
+}
+
+func (d *DependencyChecker) checkService(ctx context.Context, dep Dependency) error {
+	endpoints := &corev1.Endpoints{}
+	if err := d.reader.Get(ctx, *dep.Service, endpoints); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("%s service %s has no endpoints", dep.Name, dep.Service)
+		}
+		return fmt.Errorf("failed to query %s endpoints %s: %w", dep.Name, dep.Service, err)
+	}
+
+	// The addresses of a subset are the ready endpoints, the others are listed under its NotReadyAddresses.
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s service %s has no ready endpoints", dep.Name, dep.Service)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+var _ = Describe("DependencyChecker", func() {
+	var (
+		ctx     context.Context
+		cli     client.Client
+		checker *DependencyChecker
+		dep     Dependency
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(appsv1.AddToScheme(scheme)).NotTo(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+
+		checker = NewDependencyChecker(cli)
+		dep = Dependency{
+			Name:       "Test server",
+			Deployment: types.NamespacedName{Name: "test-server", Namespace: "test-ns"},
+			Service:    &types.NamespacedName{Name: "test", Namespace: "test-ns"},
+		}
+	})
+
+	createDeployment := func(updated, available int32) {
+		Expect(cli.Create(ctx, &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "test-ns"},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.Int32ToPtr(2)},
+			Status:     appsv1.DeploymentStatus{UpdatedReplicas: updated, AvailableReplicas: available},
+		})).NotTo(HaveOccurred())
+	}
+
+	createEndpoints := func(ready, notReady []string) {
+		subset := corev1.EndpointSubset{Ports: []corev1.EndpointPort{{Port: 5443}}}
+		for _, ip := range ready {
+			subset.Addresses = append(subset.Addresses, corev1.EndpointAddress{IP: ip})
+		}
+		for _, ip := range notReady {
+			subset.NotReadyAddresses = append(subset.NotReadyAddresses, corev1.EndpointAddress{IP: ip})
+		}
+		Expect(cli.Create(ctx, &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns"},
+			Subsets:    []corev1.EndpointSubset{subset},
+		})).NotTo(HaveOccurred())
+	}
+
+	It("is ready with no dependencies", func() {
+		Expect(checker.Check(ctx)).NotTo(HaveOccurred())
+	})
+
+	It("waits for the deployment to exist", func() {
+		Expect(checker.Check(ctx, dep)).To(MatchError("Test server deployment test-ns/test-server does not exist"))
+	})
+
+	It("waits for the deployment to roll out", func() {
+		createDeployment(1, 2)
+		Expect(checker.Check(ctx, dep)).To(MatchError("Test server deployment test-ns/test-server is rolling out (1 of 2 replicas updated)"))
+	})
+
+	It("waits for the deployment to be available", func() {
+		createDeployment(2, 1)
+		Expect(checker.Check(ctx, dep)).To(MatchError("Test server deployment test-ns/test-server is not available (1 of 2 replicas available)"))
+	})
+
+	It("waits for a ready endpoint", func() {
+		createDeployment(2, 2)
+		createEndpoints(nil, []string{"10.0.0.1"})
+		Expect(checker.Check(ctx, dep)).To(MatchError("Test server service test-ns/test has no ready endpoints"))
+	})
+
+	It("is ready once the deployment is available and an endpoint is ready", func() {
+		createDeployment(2, 2)
+		createEndpoints([]string{"10.0.0.2"}, []string{"10.0.0.1"})
+		Expect(checker.Check(ctx, dep)).NotTo(HaveOccurred())
+	})
+
+	It("doesn't look up the endpoints of dependencies without a service", func() {
+		createDeployment(2, 2)
+		dep.Service = nil
+		Expect(checker.Check(ctx, dep)).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,170 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/tigera/operator/pkg/ctrlruntime"
+)
+
+var dlog = logf.Log.WithName("dependency_tracker")
+
+// dependencyKind is the kind of a resource that the DependencyTracker watches.
+type dependencyKind string
+
+const (
+	secretDependency    dependencyKind = "Secret"
+	configMapDependency dependencyKind = "ConfigMap"
+)
+
+type dependencyKey struct {
+	kind dependencyKind
+	key  types.NamespacedName
+}
+
+// DependencyTracker watches the secrets and config maps that a controller reads, so that a change to any of them
+// triggers a reconcile. Reads are recorded by the client returned from Client, which replaces hand-written lists of
+// watches that drift from what the controller actually reads. Only Get is tracked; resources that are listed still
+// need to be watched explicitly. Watches can't be removed from a controller, so a resource stays watched once it has
+// been read.
+//
+// Only reads in the namespaces the tracker is created with are tracked, which bounds the watches it adds to the
+// names the controller reads in them. Resources read in other namespaces need to be watched explicitly.
+type DependencyTracker struct {
+	lock       sync.Mutex
+	controller ctrlruntime.Controller
+	handler    handler.EventHandler
+	namespaces map[string]bool
+	watched    map[dependencyKey]bool
+	pending    []dependencyKey
+}
+
+// NewDependencyTracker returns a DependencyTracker that queues reconcile requests with the given handler for changes to
+// the resources read in the given namespaces. An empty namespace stands for all of them, as it does for watches: reads
+// in any namespace are then tracked by a single watch across all namespaces for each name, so that reads in the
+// namespaces of tenants don't add a watch each.
+func NewDependencyTracker(h handler.EventHandler, namespaces ...string) *DependencyTracker {
+	t := &DependencyTracker{handler: h, namespaces: map[string]bool{}, watched: map[dependencyKey]bool{}}
+	for _, ns := range namespaces {
+		t.namespaces[ns] = true
+	}
+	return t
+}
+
+// Start makes the tracker add watches to the given controller, including for any resources that were read before the
+// controller was created.
+func (t *DependencyTracker) Start(c ctrlruntime.Controller) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.controller = c
+	pending := t.pending
+	t.pending = nil
+	for _, dep := range pending {
+		if err := t.watch(dep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Client returns a client that records the secrets and config maps read through it as dependencies.
+func (t *DependencyTracker) Client(cli client.Client) client.Client {
+	return &trackingClient{Client: cli, tracker: t}
+}
+
+// track records a read of the given object. Reads of objects that don't exist are recorded too, so that the
+// controller is triggered when they are created.
+func (t *DependencyTracker) track(obj client.Object, key types.NamespacedName) {
+	var kind dependencyKind
+	switch obj.(type) {
+	case *corev1.Secret:
+		kind = secretDependency
+	case *corev1.ConfigMap:
+		kind = configMapDependency
+	default:
+		return
+	}
+	switch {
+	case t.namespaces[key.Namespace]:
+	case t.namespaces[""]:
+		key.Namespace = ""
+	default:
+		return
+	}
+	dep := dependencyKey{kind: kind, key: key}
+
+	// The watch is added without holding the lock, since it waits for the cache. The dependency is marked as watched
+	// first so that concurrent reads of it don't add the watch again.
+	t.lock.Lock()
+	if t.watched[dep] {
+		t.lock.Unlock()
+		return
+	}
+	t.watched[dep] = true
+	started := t.controller != nil
+	if !started {
+		t.pending = append(t.pending, dep)
+	}
+	t.lock.Unlock()
+	if !started {
+		return
+	}
+
+	if err := t.watch(dep); err != nil {
+		dlog.Error(err, "Failed to watch dependency", "kind", kind, "key", key)
+		t.lock.Lock()
+		delete(t.watched, dep)
+		t.lock.Unlock()
+	}
+}
+
+// watch adds a watch for the given dependency to the controller.
+func (t *DependencyTracker) watch(dep dependencyKey) error {
+	switch dep.kind {
+	case secretDependency:
+		return AddSecretsWatchWithHandler(t.controller, dep.key.Name, dep.key.Namespace, t.handler)
+	case configMapDependency:
+		return AddConfigMapWatch(t.controller, dep.key.Name, dep.key.Namespace, t.handler)
+	}
+	return nil
+}
+
+// trackingClient is a client that reports the objects read through it to a DependencyTracker.
+type trackingClient struct {
+	client.Client
+	tracker *DependencyTracker
+}
+
+func (c *trackingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	c.tracker.track(obj, key)
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+// untrackedClient returns the client wrapped by a tracking client. The component handler reads the objects that it
+// writes, which aren't dependencies of the controller, so it uses the wrapped client.
+func untrackedClient(cli client.Client) client.Client {
+	if tc, ok := cli.(*trackingClient); ok {
+		return tc.Client
+	}
+	return cli
+}
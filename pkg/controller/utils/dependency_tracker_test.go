@@ -0,0 +1,110 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+// watchRecorder is a controller that records the objects it is asked to watch.
+type watchRecorder struct {
+	controller.Controller
+	watched []types.NamespacedName
+}
+
+func (w *watchRecorder) WatchObject(object client.Object, _ handler.EventHandler, _ ...predicate.Predicate) error {
+	w.watched = append(w.watched, client.ObjectKeyFromObject(object))
+	return nil
+}
+
+var _ = Describe("DependencyTracker", func() {
+	var (
+		ctx     context.Context
+		tracker *DependencyTracker
+		cli     client.Client
+		ctrl    *watchRecorder
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).NotTo(HaveOccurred())
+		tracker = NewDependencyTracker(&handler.EnqueueRequestForObject{}, "ns")
+		cli = tracker.Client(ctrlrfake.DefaultFakeClientBuilder(scheme).Build())
+		ctrl = &watchRecorder{}
+	})
+
+	It("watches the secrets and config maps that are read, once each", func() {
+		Expect(tracker.Start(ctrl)).NotTo(HaveOccurred())
+
+		// Reads of missing objects are tracked too, so the controller hears about their creation.
+		_ = cli.Get(ctx, types.NamespacedName{Name: "my-secret", Namespace: "ns"}, &corev1.Secret{})
+		_ = cli.Get(ctx, types.NamespacedName{Name: "my-secret", Namespace: "ns"}, &corev1.Secret{})
+		_ = cli.Get(ctx, types.NamespacedName{Name: "my-config", Namespace: "ns"}, &corev1.ConfigMap{})
+		_ = cli.Get(ctx, types.NamespacedName{Name: "my-service", Namespace: "ns"}, &corev1.Service{})
+
+		Expect(ctrl.watched).To(ConsistOf(
+			types.NamespacedName{Name: "my-secret", Namespace: "ns"},
+			types.NamespacedName{Name: "my-config", Namespace: "ns"},
+		))
+	})
+
+	It("watches the objects read before it was started", func() {
+		_ = cli.Get(ctx, types.NamespacedName{Name: "early-secret", Namespace: "ns"}, &corev1.Secret{})
+		Expect(ctrl.watched).To(BeEmpty())
+
+		Expect(tracker.Start(ctrl)).NotTo(HaveOccurred())
+		Expect(ctrl.watched).To(ConsistOf(types.NamespacedName{Name: "early-secret", Namespace: "ns"}))
+	})
+
+	It("doesn't track reads outside of its namespaces", func() {
+		Expect(tracker.Start(ctrl)).NotTo(HaveOccurred())
+
+		_ = cli.Get(ctx, types.NamespacedName{Name: "my-secret", Namespace: "other-ns"}, &corev1.Secret{})
+		Expect(ctrl.watched).To(BeEmpty())
+	})
+
+	It("watches the objects read in any namespace once for each name when tracking all namespaces", func() {
+		tracker = NewDependencyTracker(&handler.EnqueueRequestForObject{}, "")
+		cli = tracker.Client(untrackedClient(cli))
+		Expect(tracker.Start(ctrl)).NotTo(HaveOccurred())
+
+		_ = cli.Get(ctx, types.NamespacedName{Name: "my-secret", Namespace: "tenant-a"}, &corev1.Secret{})
+		_ = cli.Get(ctx, types.NamespacedName{Name: "my-secret", Namespace: "tenant-b"}, &corev1.Secret{})
+
+		Expect(ctrl.watched).To(ConsistOf(types.NamespacedName{Name: "my-secret"}))
+	})
+
+	It("doesn't track reads made by the component handler", func() {
+		Expect(tracker.Start(ctrl)).NotTo(HaveOccurred())
+		Expect(untrackedClient(cli)).NotTo(BeIdenticalTo(cli))
+
+		_ = untrackedClient(cli).Get(ctx, types.NamespacedName{Name: "rendered", Namespace: "ns"}, &corev1.Secret{})
+		Expect(ctrl.watched).To(BeEmpty())
+	})
+})
@@ -147,18 +147,25 @@ func autodetectFromGroup(c kubernetes.Interface) (operatorv1.Provider, error) {
 // Docker EE doesn't have any provider-specific API groups, so we need to use a different approach than
 // we use for other platforms in autodetectFromGroup.
 func isDockerEE(ctx context.Context, c kubernetes.Interface) (bool, error) {
-	masterNodes, err := c.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: "node-role.kubernetes.io/master"})
-	if err != nil {
-		return false, err
-	}
-	for _, n := range masterNodes.Items {
-		for l := range n.Labels {
-			if strings.HasPrefix(l, "com.docker.ucp") {
-				return true, nil
+	// Large clusters can have many master nodes, so list them a page at a time and stop as soon as one matches.
+	opts := metav1.ListOptions{LabelSelector: "node-role.kubernetes.io/master", Limit: ListPageSize}
+	for {
+		masterNodes, err := c.CoreV1().Nodes().List(ctx, opts)
+		if err != nil {
+			return false, err
+		}
+		for _, n := range masterNodes.Items {
+			for l := range n.Labels {
+				if strings.HasPrefix(l, "com.docker.ucp") {
+					return true, nil
+				}
 			}
 		}
+		if masterNodes.Continue == "" {
+			return false, nil
+		}
+		opts.Continue = masterNodes.Continue
 	}
-	return false, nil
 }
 
 // isEKS returns true if running on an EKS cluster, and false otherwise.
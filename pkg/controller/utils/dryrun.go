@@ -0,0 +1,99 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+)
+
+// validateObjects dry-runs the create or update of each of the given objects, as createOrUpdateObject would apply it.
+// It returns a ValidationError listing every object that the API server rejected, or nil if none were.
+func (c componentHandler) validateObjects(ctx context.Context, objs []client.Object, osType rmeta.OSType, ipFamilies *serviceIPFamilies, opts applyOptions) *ValidationError {
+	var objErrs ObjectErrors
+	for _, obj := range objs {
+		if err := c.dryRunObject(ctx, obj.DeepCopyObject().(client.Object), osType, ipFamilies, opts); err != nil {
+			objErrs = append(objErrs, c.newObjectError(objectOpValidate, obj, err))
+		}
+	}
+	if len(objErrs) > 0 {
+		return &ValidationError{Errors: objErrs}
+	}
+	return nil
+}
+
+func (c componentHandler) dryRunObject(ctx context.Context, obj client.Object, osType rmeta.OSType, ipFamilies *serviceIPFamilies, opts applyOptions) error {
+	multipleOwners, err := c.prepareObject(obj, osType, ipFamilies, opts)
+	if err != nil {
+		return err
+	}
+	logCtx := ContextLoggerForResource(c.log, obj)
+
+	cur, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("failed converting object %+v", obj)
+	}
+	if err := c.client.Get(ctx, client.ObjectKeyFromObject(obj), cur); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if multipleOwners {
+			removeMultipleOwnersLabel(obj)
+		}
+		return c.client.Create(ctx, obj, client.DryRunAll)
+	}
+
+	if IgnoreObject(cur) {
+		return nil
+	}
+	mobj := mergeState(obj, cur)
+	if mobj == nil {
+		return nil
+	}
+	if recreatedOnUpdate(obj, cur) {
+		// The object is deleted and created again when it's applied, which can't be dry-run while it exists.
+		logCtx.V(2).Info("Skipping validation of object that is recreated on update")
+		return nil
+	}
+	return c.client.Update(ctx, mobj, client.DryRunAll)
+}
+
+// recreatedOnUpdate returns true if createOrUpdateObject deletes and creates cur again to apply obj, because the
+// fields that differ between them are immutable.
+func recreatedOnUpdate(obj, cur client.Object) bool {
+	switch o := obj.(type) {
+	case *batchv1.Job:
+		return true
+	case *v1.Secret:
+		c := cur.(*v1.Secret)
+		return o.Type != c.Type && !(len(o.Type) == 0 && c.Type == v1.SecretTypeOpaque)
+	case *v1.Service:
+		c := cur.(*v1.Service)
+		return (o.Spec.ClusterIP == "None") != (c.Spec.ClusterIP == "None")
+	case *rbacv1.RoleBinding:
+		return o.RoleRef.Name != cur.(*rbacv1.RoleBinding).RoleRef.Name
+	case *rbacv1.ClusterRoleBinding:
+		return o.RoleRef.Name != cur.(*rbacv1.ClusterRoleBinding).RoleRef.Name
+	}
+	return false
+}
@@ -110,7 +110,7 @@ func GetElasticsearchClusterConfig(ctx context.Context, cli client.Client) (*rel
 	return relasticsearch.NewClusterConfigFromConfigMap(configMap)
 }
 
-type ElasticsearchClientCreator func(client client.Client, ctx context.Context, elasticHTTPSEndpoint string, external bool) (ElasticClient, error)
+type ElasticsearchClientCreator func(client client.Reader, ctx context.Context, elasticHTTPSEndpoint string, external bool) (ElasticClient, error)
 
 type ElasticClient interface {
 	SetILMPolicies(context.Context, *operatorv1.LogStorage) error
@@ -123,7 +123,7 @@ type esClient struct {
 	client *elastic.Client
 }
 
-func NewElasticClient(client client.Client, ctx context.Context, elasticHTTPSEndpoint string, external bool) (ElasticClient, error) {
+func NewElasticClient(client client.Reader, ctx context.Context, elasticHTTPSEndpoint string, external bool) (ElasticClient, error) {
 	user, password, root, err := getClientCredentials(client, ctx)
 	if err != nil {
 		return nil, err
@@ -577,7 +577,7 @@ func calculateRolloverAge(retention int) string {
 
 // getClientCredentials gets the client credentials used by the operator to talk to Elasticsearch. The operator
 // uses the ES admin credentials in order to provision users and ILM policies.
-func getClientCredentials(client client.Client, ctx context.Context) (string, string, *x509.CertPool, error) {
+func getClientCredentials(client client.Reader, ctx context.Context) (string, string, *x509.CertPool, error) {
 	esSecret := &corev1.Secret{}
 	if err := client.Get(ctx, types.NamespacedName{Name: render.ElasticsearchAdminUserSecret, Namespace: common.OperatorNamespace()}, esSecret); err != nil {
 		return "", "", nil, err
@@ -597,7 +597,7 @@ func getClientCredentials(client client.Client, ctx context.Context) (string, st
 }
 
 // getESRoots returns the root certificates used to validate the Elasticsearch server certificate.
-func getESRoots(ctx context.Context, client client.Client, secretName string) (*x509.CertPool, error) {
+func getESRoots(ctx context.Context, client client.Reader, secretName string) (*x509.CertPool, error) {
 	instance := &operator.Installation{}
 	if err := client.Get(ctx, DefaultInstanceKey, instance); err != nil {
 		return nil, err
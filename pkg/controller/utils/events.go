@@ -0,0 +1,42 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// EventRecorderName is the name that the operator records its Events under.
+const EventRecorderName = "tigera-operator"
+
+// eventRecorder records the Events of the operator. No Events are recorded until it is set.
+var eventRecorder record.EventRecorder
+
+// SetEventRecorder sets the recorder that the Events of the operator are recorded with. It's the recorder of the
+// manager, which aggregates repeated Events and rate limits them, so that a failure that recurs on every reconcile
+// doesn't create an Event each time.
+func SetEventRecorder(r record.EventRecorder) {
+	eventRecorder = r
+}
+
+// recordWarning records a warning Event against obj, which must be in the scheme of the manager.
+func recordWarning(obj runtime.Object, reason, msg string) {
+	if eventRecorder == nil {
+		return
+	}
+	eventRecorder.Event(obj, corev1.EventTypeWarning, reason, msg)
+}
@@ -16,7 +16,11 @@ package utils
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -24,6 +28,21 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// FelixConfigurationManagedFieldsAnnotation records the fields of the spec of the default FelixConfiguration that the
+	// operator has set, with the values it set them to. That's how user edits of those fields are told apart from the
+	// operator's own changes.
+	FelixConfigurationManagedFieldsAnnotation = "operator.tigera.io/managed-fields"
+
+	// FelixConfigurationOverriddenEventReason is the reason of the Event recorded against the default FelixConfiguration
+	// when the operator reverts user edits of fields that it manages.
+	FelixConfigurationOverriddenEventReason = "FieldsOverridden"
+)
+
+// PatchFelixConfiguration reads the default FelixConfiguration, passes it to patchFn, and writes it back if patchFn
+// reports that it changed it. The fields that patchFn changes are recorded as managed by the operator. If patchFn
+// changes a managed field that a user had edited, an Event is recorded against the FelixConfiguration, since the user
+// edit is lost.
 func PatchFelixConfiguration(ctx context.Context, c client.Client, patchFn func(fc *crdv1.FelixConfiguration) (bool, error)) (*crdv1.FelixConfiguration, error) {
 	// Fetch any existing default FelixConfiguration object.
 	fc := &crdv1.FelixConfiguration{}
@@ -34,6 +53,10 @@ func PatchFelixConfiguration(ctx context.Context, c client.Client, patchFn func(
 
 	// Create a base state for the upcoming patch operation.
 	patchFrom := client.MergeFrom(fc.DeepCopy())
+	before, err := felixConfigurationSpecFields(fc)
+	if err != nil {
+		return nil, err
+	}
 
 	// Apply desired changes to the FelixConfiguration.
 	updated, err := patchFn(fc)
@@ -41,6 +64,11 @@ func PatchFelixConfiguration(ctx context.Context, c client.Client, patchFn func(
 		return nil, err
 	}
 	if updated {
+		overridden, err := recordManagedFields(fc, before)
+		if err != nil {
+			return nil, err
+		}
+
 		// Apply the patch.
 		if fc.ResourceVersion == "" {
 			fc.ObjectMeta.Name = "default"
@@ -52,7 +80,117 @@ func PatchFelixConfiguration(ctx context.Context, c client.Client, patchFn func(
 				return nil, err
 			}
 		}
+
+		if len(overridden) > 0 {
+			recordWarning(fc, FelixConfigurationOverriddenEventReason,
+				fmt.Sprintf("The operator reverted edits of fields that it manages: %s", strings.Join(overridden, ", ")))
+		}
 	}
 
 	return fc, nil
 }
+
+// FelixConfigurationConflicts returns the fields of the spec of the default FelixConfiguration that the operator
+// manages, and those of them that a user has edited since the operator last set them. The operator didn't revert
+// those edits, since it hasn't needed to change the fields since.
+func FelixConfigurationConflicts(fc *crdv1.FelixConfiguration) (managed, edited []string, err error) {
+	fields, err := managedFields(fc)
+	if err != nil || fields == nil {
+		return nil, nil, err
+	}
+	current, err := felixConfigurationSpecFields(fc)
+	if err != nil {
+		return nil, nil, err
+	}
+	for field, value := range fields {
+		managed = append(managed, field)
+		if !reflect.DeepEqual(current[field], value) {
+			edited = append(edited, field)
+		}
+	}
+	sort.Strings(managed)
+	sort.Strings(edited)
+	return managed, edited, nil
+}
+
+// recordManagedFields records the fields that were changed from their values in before as managed by the operator,
+// and returns those of them that held a user edit. User edits can only be told apart once the operator has recorded
+// the fields it manages, so none are returned before then.
+func recordManagedFields(fc *crdv1.FelixConfiguration, before map[string]interface{}) ([]string, error) {
+	after, err := felixConfigurationSpecFields(fc)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := managedFields(fc)
+	if err != nil {
+		return nil, err
+	}
+	tracked := fields != nil
+	if fields == nil {
+		fields = map[string]interface{}{}
+	}
+
+	var overridden []string
+	for _, field := range changedFields(before, after) {
+		previous, wasSet := before[field]
+		if tracked && wasSet && !reflect.DeepEqual(fields[field], previous) {
+			overridden = append(overridden, field)
+		}
+		// A field that the operator removed is recorded with a null value.
+		fields[field] = after[field]
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	if fc.Annotations == nil {
+		fc.Annotations = map[string]string{}
+	}
+	fc.Annotations[FelixConfigurationManagedFieldsAnnotation] = string(data)
+	sort.Strings(overridden)
+	return overridden, nil
+}
+
+// managedFields returns the fields recorded in the managed fields annotation, or nil if there is none.
+func managedFields(fc *crdv1.FelixConfiguration) (map[string]interface{}, error) {
+	data, ok := fc.Annotations[FelixConfigurationManagedFieldsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(data), &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse the %s annotation of the FelixConfiguration: %w", FelixConfigurationManagedFieldsAnnotation, err)
+	}
+	return fields, nil
+}
+
+// felixConfigurationSpecFields returns the top level fields of the spec of the FelixConfiguration, as they're
+// serialized.
+func felixConfigurationSpecFields(fc *crdv1.FelixConfiguration) (map[string]interface{}, error) {
+	data, err := json.Marshal(fc.Spec)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// changedFields returns the fields whose values differ between before and after.
+func changedFields(before, after map[string]interface{}) []string {
+	var changed []string
+	for field, value := range after {
+		if !reflect.DeepEqual(before[field], value) {
+			changed = append(changed, field)
+		}
+	}
+	for field := range before {
+		if _, ok := after[field]; !ok {
+			changed = append(changed, field)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/tigera/api/pkg/lib/numorstring"
+
+	"github.com/tigera/operator/pkg/apis"
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+var _ = Describe("FelixConfiguration patching", func() {
+	var (
+		ctx      context.Context
+		cli      client.Client
+		recorder *record.FakeRecorder
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(corev1.AddToScheme(scheme)).NotTo(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		recorder = record.NewFakeRecorder(10)
+		utils.SetEventRecorder(recorder)
+	})
+
+	AfterEach(func() {
+		utils.SetEventRecorder(nil)
+	})
+
+	setHealthPort := func(port int) func(fc *crdv1.FelixConfiguration) (bool, error) {
+		return func(fc *crdv1.FelixConfiguration) (bool, error) {
+			if fc.Spec.HealthPort != nil && *fc.Spec.HealthPort == port {
+				return false, nil
+			}
+			fc.Spec.HealthPort = &port
+			return true, nil
+		}
+	}
+
+	getFelixConfiguration := func() *crdv1.FelixConfiguration {
+		fc := &crdv1.FelixConfiguration{}
+		Expect(cli.Get(ctx, types.NamespacedName{Name: "default"}, fc)).NotTo(HaveOccurred())
+		return fc
+	}
+
+	editFelixConfiguration := func(edit func(fc *crdv1.FelixConfiguration)) {
+		fc := getFelixConfiguration()
+		edit(fc)
+		Expect(cli.Update(ctx, fc)).NotTo(HaveOccurred())
+	}
+
+	overriddenEvents := func() []string {
+		var overridden []string
+		for len(recorder.Events) > 0 {
+			e := <-recorder.Events
+			if strings.HasPrefix(e, fmt.Sprintf("%s %s ", corev1.EventTypeWarning, utils.FelixConfigurationOverriddenEventReason)) {
+				overridden = append(overridden, e)
+			}
+		}
+		return overridden
+	}
+
+	It("should record the fields that the operator sets", func() {
+		_, err := utils.PatchFelixConfiguration(ctx, cli, setHealthPort(9099))
+		Expect(err).NotTo(HaveOccurred())
+
+		fc := getFelixConfiguration()
+		Expect(fc.Annotations).To(HaveKeyWithValue(utils.FelixConfigurationManagedFieldsAnnotation, `{"healthPort":9099}`))
+		managed, edited, err := utils.FelixConfigurationConflicts(fc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(managed).To(ConsistOf("healthPort"))
+		Expect(edited).To(BeEmpty())
+	})
+
+	It("should report user edits of managed fields that the operator left in place", func() {
+		_, err := utils.PatchFelixConfiguration(ctx, cli, setHealthPort(9099))
+		Expect(err).NotTo(HaveOccurred())
+		editFelixConfiguration(func(fc *crdv1.FelixConfiguration) {
+			fc.Spec.HealthPort = ptr.ToPtr(9199)
+			fc.Spec.LogSeverityScreen = "Debug"
+		})
+
+		_, edited, err := utils.FelixConfigurationConflicts(getFelixConfiguration())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(edited).To(ConsistOf("healthPort"))
+		Expect(overriddenEvents()).To(BeEmpty())
+	})
+
+	It("should record an event when the operator reverts a user edit", func() {
+		_, err := utils.PatchFelixConfiguration(ctx, cli, setHealthPort(9099))
+		Expect(err).NotTo(HaveOccurred())
+		editFelixConfiguration(func(fc *crdv1.FelixConfiguration) { fc.Spec.HealthPort = ptr.ToPtr(9199) })
+
+		_, err = utils.PatchFelixConfiguration(ctx, cli, setHealthPort(9099))
+		Expect(err).NotTo(HaveOccurred())
+
+		events := overriddenEvents()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0]).To(ContainSubstring("healthPort"))
+
+		_, edited, err := utils.FelixConfigurationConflicts(getFelixConfiguration())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(edited).To(BeEmpty())
+	})
+
+	It("should keep the BPF tuning that users set", func() {
+		_, err := utils.PatchFelixConfiguration(ctx, cli, setHealthPort(9099))
+		Expect(err).NotTo(HaveOccurred())
+		psnatPorts, err := numorstring.PortFromRange(30000, 39999)
+		Expect(err).NotTo(HaveOccurred())
+		editFelixConfiguration(func(fc *crdv1.FelixConfiguration) {
+			fc.Spec.BPFMapSizeConntrack = ptr.ToPtr(1024000)
+			fc.Spec.BPFMapSizeNATFrontend = ptr.ToPtr(131072)
+			fc.Spec.BPFMapSizeIPSets = ptr.ToPtr(2097152)
+			fc.Spec.BPFPSNATPorts = &psnatPorts
+		})
+
+		_, err = utils.PatchFelixConfiguration(ctx, cli, setHealthPort(9199))
+		Expect(err).NotTo(HaveOccurred())
+
+		fc := getFelixConfiguration()
+		Expect(fc.Spec.HealthPort).To(Equal(ptr.ToPtr(9199)))
+		Expect(fc.Spec.BPFMapSizeConntrack).To(Equal(ptr.ToPtr(1024000)))
+		Expect(fc.Spec.BPFMapSizeNATFrontend).To(Equal(ptr.ToPtr(131072)))
+		Expect(fc.Spec.BPFMapSizeIPSets).To(Equal(ptr.ToPtr(2097152)))
+		Expect(fc.Spec.BPFPSNATPorts.String()).To(Equal("30000:39999"))
+		managed, _, err := utils.FelixConfigurationConflicts(fc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(managed).To(ConsistOf("healthPort"))
+	})
+
+	It("should not report edits before the operator has recorded the fields it manages", func() {
+		Expect(cli.Create(ctx, &crdv1.FelixConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec:       crdv1.FelixConfigurationSpec{HealthPort: ptr.ToPtr(9199)},
+		})).NotTo(HaveOccurred())
+
+		_, err := utils.PatchFelixConfiguration(ctx, cli, setHealthPort(9099))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(overriddenEvents()).To(BeEmpty())
+	})
+})
@@ -93,6 +93,11 @@ func OverrideInstallationSpec(cfg, override operatorv1.InstallationSpec) operato
 		copy(inst.ControlPlaneTolerations, override.ControlPlaneTolerations)
 	}
 
+	switch compareFields(inst.ControlPlaneTopology, override.ControlPlaneTopology) {
+	case BOnlySet, Different:
+		inst.ControlPlaneTopology = override.ControlPlaneTopology
+	}
+
 	switch compareFields(inst.ControlPlaneReplicas, override.ControlPlaneReplicas) {
 	case BOnlySet, Different:
 		inst.ControlPlaneReplicas = override.ControlPlaneReplicas
@@ -108,6 +113,11 @@ func OverrideInstallationSpec(cfg, override operatorv1.InstallationSpec) operato
 		inst.TyphaMetricsPort = override.TyphaMetricsPort
 	}
 
+	switch compareFields(inst.TyphaPort, override.TyphaPort) {
+	case BOnlySet, Different:
+		inst.TyphaPort = override.TyphaPort
+	}
+
 	switch compareFields(inst.FlexVolumePath, override.FlexVolumePath) {
 	case BOnlySet, Different:
 		inst.FlexVolumePath = override.FlexVolumePath
@@ -141,6 +151,11 @@ func OverrideInstallationSpec(cfg, override operatorv1.InstallationSpec) operato
 		override.CertificateManagement.DeepCopyInto(inst.CertificateManagement)
 	}
 
+	switch compareFields(inst.CertificateKeyAlgorithm, override.CertificateKeyAlgorithm) {
+	case BOnlySet, Different:
+		inst.CertificateKeyAlgorithm = override.CertificateKeyAlgorithm
+	}
+
 	switch compareFields(inst.NonPrivileged, override.NonPrivileged) {
 	case BOnlySet, Different:
 		inst.NonPrivileged = override.NonPrivileged
@@ -209,6 +224,56 @@ func OverrideInstallationSpec(cfg, override operatorv1.InstallationSpec) operato
 		inst.ServiceCIDRs = override.ServiceCIDRs
 	}
 
+	switch compareFields(inst.Telemetry, override.Telemetry) {
+	case BOnlySet, Different:
+		inst.Telemetry = override.Telemetry.DeepCopy()
+	}
+
+	switch compareFields(inst.UpgradeHooks, override.UpgradeHooks) {
+	case BOnlySet, Different:
+		inst.UpgradeHooks = override.UpgradeHooks
+	}
+
+	switch compareFields(inst.MaintenanceWindows, override.MaintenanceWindows) {
+	case BOnlySet, Different:
+		inst.MaintenanceWindows = override.MaintenanceWindows
+	}
+
+	switch compareFields(inst.FelixProfiles, override.FelixProfiles) {
+	case BOnlySet, Different:
+		inst.FelixProfiles = override.FelixProfiles
+	}
+
+	switch compareFields(inst.CoordinatedNodeRollout, override.CoordinatedNodeRollout) {
+	case BOnlySet, Different:
+		inst.CoordinatedNodeRollout = override.CoordinatedNodeRollout
+	}
+
+	switch compareFields(inst.NFTablesModeRollout, override.NFTablesModeRollout) {
+	case BOnlySet, Different:
+		inst.NFTablesModeRollout = override.NFTablesModeRollout
+	}
+
+	switch compareFields(inst.Preflight, override.Preflight) {
+	case BOnlySet, Different:
+		inst.Preflight = override.Preflight
+	}
+
+	switch compareFields(inst.Diagnostics, override.Diagnostics) {
+	case BOnlySet, Different:
+		inst.Diagnostics = override.Diagnostics
+	}
+
+	switch compareFields(inst.WorkloadProvenance, override.WorkloadProvenance) {
+	case BOnlySet, Different:
+		inst.WorkloadProvenance = override.WorkloadProvenance
+	}
+
+	switch compareFields(inst.ApplyValidation, override.ApplyValidation) {
+	case BOnlySet, Different:
+		inst.ApplyValidation = override.ApplyValidation
+	}
+
 	return inst
 }
 
@@ -263,6 +328,12 @@ func mergeCalicoNetwork(cfg, override *operatorv1.CalicoNetworkSpec) *operatorv1
 		}
 	}
 
+	switch compareFields(out.PodNetworkExpansions, override.PodNetworkExpansions) {
+	case BOnlySet, Different:
+		out.PodNetworkExpansions = make([]operatorv1.PodNetworkExpansion, len(override.PodNetworkExpansions))
+		copy(out.PodNetworkExpansions, override.PodNetworkExpansions)
+	}
+
 	switch compareFields(out.MTU, override.MTU) {
 	case BOnlySet, Different:
 		out.MTU = override.MTU
@@ -298,11 +369,26 @@ func mergeCalicoNetwork(cfg, override *operatorv1.CalicoNetworkSpec) *operatorv1
 		out.HostPorts = override.HostPorts
 	}
 
+	switch compareFields(out.BandwidthPlugin, override.BandwidthPlugin) {
+	case BOnlySet, Different:
+		out.BandwidthPlugin = override.BandwidthPlugin
+	}
+
 	switch compareFields(out.MultiInterfaceMode, override.MultiInterfaceMode) {
 	case BOnlySet, Different:
 		out.MultiInterfaceMode = override.MultiInterfaceMode
 	}
 
+	switch compareFields(out.MultusCompatibility, override.MultusCompatibility) {
+	case BOnlySet, Different:
+		out.MultusCompatibility = override.MultusCompatibility.DeepCopy()
+	}
+
+	switch compareFields(out.WireGuard, override.WireGuard) {
+	case BOnlySet, Different:
+		out.WireGuard = override.WireGuard
+	}
+
 	switch compareFields(out.ContainerIPForwarding, override.ContainerIPForwarding) {
 	case BOnlySet, Different:
 		out.ContainerIPForwarding = override.ContainerIPForwarding
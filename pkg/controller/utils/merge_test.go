@@ -541,6 +541,29 @@ var _ = Describe("Installation merge tests", func() {
 		Entry("Both set not matching", intPtr(1460), intPtr(8981), intPtr(8981)),
 	)
 
+	DescribeTable("merge TyphaPort", func(main, second, expect *int32) {
+		m := opv1.InstallationSpec{}
+		s := opv1.InstallationSpec{}
+		if main != nil {
+			m.TyphaPort = main
+		}
+		if second != nil {
+			s.TyphaPort = second
+		}
+		inst := OverrideInstallationSpec(m, s)
+		if expect == nil {
+			Expect(inst.TyphaPort).To(BeNil())
+		} else {
+			Expect(*inst.TyphaPort).To(Equal(*expect))
+		}
+	},
+		Entry("Both unset", nil, nil, nil),
+		Entry("Main only set", intPtr(5473), nil, intPtr(5473)),
+		Entry("Second only set", nil, intPtr(5474), intPtr(5474)),
+		Entry("Both set equal", intPtr(5475), intPtr(5475), intPtr(5475)),
+		Entry("Both set not matching", intPtr(5475), intPtr(5476), intPtr(5476)),
+	)
+
 	DescribeTable("merge FlexVolumePath", func(main, second, expect string) {
 		m := opv1.InstallationSpec{}
 		s := opv1.InstallationSpec{}
@@ -0,0 +1,153 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	objectOpApply    = "apply"
+	objectOpDelete   = "delete"
+	objectOpValidate = "validate"
+
+	// ObjectFailedEventReason is the reason of the Event recorded against a CR when one of the objects rendered for it
+	// can't be applied or deleted.
+	ObjectFailedEventReason = "ObjectFailed"
+)
+
+// ObjectError is returned by the component handler when it fails to apply or delete an object. It identifies the
+// object and the reason the API server gave for the failure, so that this ends up in the degraded condition of the
+// TigeraStatus rather than only in the operator logs. The error it wraps can be inspected with the functions of the
+// k8s.io/apimachinery/pkg/api/errors package as before.
+type ObjectError struct {
+	Op     string
+	GVK    schema.GroupVersionKind
+	Key    types.NamespacedName
+	Reason metav1.StatusReason
+	Err    error
+}
+
+func (e *ObjectError) Error() string {
+	name := e.Key.Name
+	if e.Key.Namespace != "" {
+		name = e.Key.String()
+	}
+	msg := fmt.Sprintf("failed to %s %s %s", e.Op, e.GVK.Kind, name)
+	if e.GVK.Version != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, e.GVK.GroupVersion().String())
+	}
+	if e.Reason != metav1.StatusReasonUnknown {
+		msg = fmt.Sprintf("%s: %s", msg, e.Reason)
+	}
+	return fmt.Sprintf("%s: %v", msg, e.Err)
+}
+
+func (e *ObjectError) Unwrap() error {
+	return e.Err
+}
+
+// ObjectErrors holds the failures to apply or delete several of the objects of a component.
+type ObjectErrors []*ObjectError
+
+// Err returns nil if there are no failures, the failure if there's only one, and the ObjectErrors otherwise.
+func (e ObjectErrors) Err() error {
+	switch len(e) {
+	case 0:
+		return nil
+	case 1:
+		return e[0]
+	}
+	return e
+}
+
+func (e ObjectErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%d objects failed: %s", len(e), strings.Join(msgs, "; "))
+}
+
+func (e ObjectErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// ValidationError is returned by the component handler when the API server rejects some of the objects of a component
+// in the dry-run that precedes applying them. None of the objects of the component are applied in that case.
+type ValidationError struct {
+	Errors ObjectErrors
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%d of the rendered objects failed validation, none were applied: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+func (e *ValidationError) Unwrap() []error {
+	return e.Errors.Unwrap()
+}
+
+// newObjectError wraps err, which occurred while performing op on obj, in an ObjectError.
+func (c componentHandler) newObjectError(op string, obj client.Object, err error) *ObjectError {
+	return &ObjectError{
+		Op:     op,
+		GVK:    c.gvkForObject(obj),
+		Key:    client.ObjectKeyFromObject(obj),
+		Reason: errors.ReasonForError(err),
+		Err:    err,
+	}
+}
+
+// gvkForObject returns the GroupVersionKind of the given object, falling back on its TypeMeta and then its Go type
+// for objects that aren't in the handler's scheme.
+func (c componentHandler) gvkForObject(obj runtime.Object) schema.GroupVersionKind {
+	if c.scheme != nil {
+		if gvk, err := apiutil.GVKForObject(obj, c.scheme); err == nil {
+			return gvk
+		}
+	}
+	if gvk := obj.GetObjectKind().GroupVersionKind(); gvk.Kind != "" {
+		return gvk
+	}
+	return schema.GroupVersionKind{Kind: reflect.Indirect(reflect.ValueOf(obj)).Type().Name()}
+}
+
+// recordObjectError records an Event against the handler's CR for the given failure, so that it shows up when the CR
+// is described.
+func (c componentHandler) recordObjectError(objErr *ObjectError) {
+	cr, ok := c.cr.(runtime.Object)
+	if !ok {
+		return
+	}
+	recordWarning(cr, ObjectFailedEventReason, objErr.Error())
+}
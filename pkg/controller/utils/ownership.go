@@ -0,0 +1,114 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+)
+
+const (
+	// OwnerUIDLabel is set, by components that use render.OwnershipLabels, on the objects that can't have an owner
+	// reference to the custom resource they are rendered for. Its value is the UID of the custom resource.
+	OwnerUIDLabel = "operator.tigera.io/owner-uid"
+
+	// OwnerAnnotation identifies the owner of objects with the OwnerUIDLabel for humans, as "<kind> <namespace>/<name>".
+	OwnerAnnotation = "operator.tigera.io/owner"
+
+	// OwnedObjectsFinalizer is added to custom resources that own objects through the OwnerUIDLabel.
+	OwnedObjectsFinalizer = "operator.tigera.io/owned-objects"
+)
+
+// needsOwnerLabels returns true if obj can't have an owner reference to owner, and isn't shared with other owners.
+func needsOwnerLabels(owner metav1.Object, obj client.Object) bool {
+	if _, ok := obj.(*v3.UISettings); ok {
+		return false
+	}
+	return skipAddingOwnerReference(owner, obj) && !checkIfMultipleOwnersLabel(obj)
+}
+
+// setOwnerLabels labels and annotates obj as owned by the handler's CR.
+func (c componentHandler) setOwnerLabels(obj client.Object) {
+	owner := c.cr
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[OwnerUIDLabel] = string(owner.GetUID())
+	obj.SetLabels(labels)
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	key := types.NamespacedName{Name: owner.GetName(), Namespace: owner.GetNamespace()}
+	kind := ""
+	if ro, ok := owner.(runtime.Object); ok {
+		kind = c.gvkForObject(ro).Kind
+	}
+	annotations[OwnerAnnotation] = fmt.Sprintf("%s %s", kind, key)
+	obj.SetAnnotations(annotations)
+}
+
+// addOwnedObjectsFinalizer adds the OwnedObjectsFinalizer to the handler's CR, if it doesn't have it yet.
+func (c componentHandler) addOwnedObjectsFinalizer(ctx context.Context) error {
+	cr, ok := c.cr.(client.Object)
+	if !ok || controllerutil.ContainsFinalizer(cr, OwnedObjectsFinalizer) {
+		return nil
+	}
+	patchFrom := client.MergeFrom(cr.DeepCopyObject().(client.Object))
+	controllerutil.AddFinalizer(cr, OwnedObjectsFinalizer)
+	return c.client.Patch(ctx, cr, patchFrom)
+}
+
+// DeleteLabelOwnedObjects deletes the objects of the kinds of the given lists that are labeled as owned by owner, and
+// then removes the OwnedObjectsFinalizer from owner. Controllers of components that use render.OwnershipLabels call it
+// once their custom resource is being deleted.
+func DeleteLabelOwnedObjects(ctx context.Context, cli client.Client, owner client.Object, lists ...client.ObjectList) error {
+	if !controllerutil.ContainsFinalizer(owner, OwnedObjectsFinalizer) {
+		return nil
+	}
+	for _, list := range lists {
+		if err := cli.List(ctx, list, client.MatchingLabels{OwnerUIDLabel: string(owner.GetUID())}); err != nil {
+			return err
+		}
+		objs, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, o := range objs {
+			obj, ok := o.(client.Object)
+			if !ok {
+				continue
+			}
+			if err := cli.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	patchFrom := client.MergeFrom(owner.DeepCopyObject().(client.Object))
+	controllerutil.RemoveFinalizer(owner, OwnedObjectsFinalizer)
+	return cli.Patch(ctx, owner, patchFrom)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ListPageSize is the maximum number of objects requested at once when listing objects directly from the API server,
+// so that lists of potentially many objects (e.g., nodes or secrets) don't have to be held in memory all at once.
+const ListPageSize = 500
+
+// ListPages lists the objects matching opts a page of ListPageSize objects at a time, and calls fn after each page is
+// read into list. The list is reused for every page, so fn should process the items of the page and not keep
+// references to them. Listing stops early, without an error, once fn returns true.
+//
+// The reader must read from the API server, such as one returned by NewUncachedReader. The informer cache truncates a
+// list at the limit without returning a continue token, so listing from it a page at a time would miss objects.
+func ListPages(ctx context.Context, r client.Reader, list client.ObjectList, fn func() (bool, error), opts ...client.ListOption) error {
+	opts = append(opts[:len(opts):len(opts)], client.Limit(ListPageSize))
+	var cont string
+	for {
+		if err := r.List(ctx, list, append(opts, client.Continue(cont))...); err != nil {
+			return err
+		}
+		if stop, err := fn(); err != nil || stop {
+			return err
+		}
+		if cont = list.GetContinue(); cont == "" {
+			return nil
+		}
+	}
+}
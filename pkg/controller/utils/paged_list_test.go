@@ -0,0 +1,126 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+var _ = Describe("Paged lists", func() {
+	const pageSize = 2
+
+	var (
+		ctx    context.Context
+		cli    client.Client
+		limits []int64
+		conts  []string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		limits, conts = nil, nil
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).NotTo(HaveOccurred())
+
+		var objs []client.Object
+		for i := 0; i < 5; i++ {
+			objs = append(objs, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("secret-%d", i), Namespace: "ns"}})
+		}
+		objs = append(objs, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret-other", Namespace: "other-ns"}})
+
+		// The fake client doesn't page lists, so serve them a few objects at a time the way the API server does, with
+		// the index of the next object as the continue token.
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).WithObjects(objs...).WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				listOpts := &client.ListOptions{}
+				listOpts.ApplyOptions(opts)
+				limits = append(limits, listOpts.Limit)
+				conts = append(conts, listOpts.Continue)
+
+				if err := c.List(ctx, list, client.InNamespace(listOpts.Namespace)); err != nil {
+					return err
+				}
+				secrets := list.(*corev1.SecretList)
+				sort.Slice(secrets.Items, func(i, j int) bool { return secrets.Items[i].Name < secrets.Items[j].Name })
+				start := 0
+				if listOpts.Continue != "" {
+					var err error
+					if start, err = strconv.Atoi(listOpts.Continue); err != nil {
+						return err
+					}
+				}
+				end := start + pageSize
+				secrets.Continue = strconv.Itoa(end)
+				if end >= len(secrets.Items) {
+					end = len(secrets.Items)
+					secrets.Continue = ""
+				}
+				secrets.Items = secrets.Items[start:end]
+				return nil
+			},
+		}).Build()
+	})
+
+	It("should list every page by following the continue token", func() {
+		secrets := &corev1.SecretList{}
+		var names []string
+		err := ListPages(ctx, cli, secrets, func() (bool, error) {
+			for _, s := range secrets.Items {
+				names = append(names, s.Name)
+			}
+			return false, nil
+		}, client.InNamespace("ns"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(names).To(Equal([]string{"secret-0", "secret-1", "secret-2", "secret-3", "secret-4"}))
+		Expect(conts).To(Equal([]string{"", "2", "4"}))
+		Expect(limits).To(HaveEach(BeEquivalentTo(ListPageSize)))
+	})
+
+	It("should stop listing once the page function is done", func() {
+		secrets := &corev1.SecretList{}
+		pages := 0
+		err := ListPages(ctx, cli, secrets, func() (bool, error) {
+			pages++
+			return true, nil
+		}, client.InNamespace("ns"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pages).To(Equal(1))
+		Expect(conts).To(Equal([]string{""}))
+	})
+
+	It("should return the error of the page function", func() {
+		secrets := &corev1.SecretList{}
+		err := ListPages(ctx, cli, secrets, func() (bool, error) {
+			return false, fmt.Errorf("page error")
+		}, client.InNamespace("ns"))
+		Expect(err).To(MatchError("page error"))
+		Expect(conts).To(HaveLen(1))
+	})
+})
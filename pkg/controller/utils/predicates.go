@@ -0,0 +1,101 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+)
+
+// ObjectChangedPredicate filters out update events that don't change anything the controllers act on, such as
+// status-only updates, resyncs and updates to the resourceVersion or managedFields alone.
+var ObjectChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return objectChanged(e.ObjectOld, e.ObjectNew)
+	},
+}
+
+// objectChanged returns true if the update from oldObj to newObj is relevant to the controllers. Objects that track
+// a generation are considered changed when their generation, labels, annotations, owner references or deletion
+// timestamp change, or when a status field that other controllers wait on changes. Objects that don't track a
+// generation (e.g., secrets and config maps) are considered changed unless they only differ in their bookkeeping
+// metadata.
+func objectChanged(oldObj, newObj client.Object) bool {
+	if oldObj == nil || newObj == nil {
+		return true
+	}
+
+	if oldObj.GetGeneration() != 0 {
+		return oldObj.GetGeneration() != newObj.GetGeneration() ||
+			!reflect.DeepEqual(oldObj.GetLabels(), newObj.GetLabels()) ||
+			!reflect.DeepEqual(oldObj.GetAnnotations(), newObj.GetAnnotations()) ||
+			!reflect.DeepEqual(oldObj.GetOwnerReferences(), newObj.GetOwnerReferences()) ||
+			!oldObj.GetDeletionTimestamp().Equal(newObj.GetDeletionTimestamp()) ||
+			readinessStatusChanged(oldObj, newObj)
+	}
+
+	oldCopy := oldObj.DeepCopyObject().(client.Object)
+	newCopy := newObj.DeepCopyObject().(client.Object)
+	for _, obj := range []client.Object{oldCopy, newCopy} {
+		obj.SetResourceVersion("")
+		obj.SetManagedFields(nil)
+	}
+	return !equality.Semantic.DeepEqual(oldCopy, newCopy)
+}
+
+// readinessStatusChanged returns true if the status fields that controllers read from custom resources other than
+// their own have changed, e.g., the variant of the Installation, the stages of its upgrade or the state of the
+// APIServer.
+func readinessStatusChanged(oldObj, newObj client.Object) bool {
+	switch o := oldObj.(type) {
+	case *operatorv1.Installation:
+		n, ok := newObj.(*operatorv1.Installation)
+		return !ok || o.Status.Variant != n.Status.Variant || o.Status.CalicoVersion != n.Status.CalicoVersion ||
+			upgradeStagesChanged(o.Status.Upgrade, n.Status.Upgrade)
+	case *operatorv1.APIServer:
+		n, ok := newObj.(*operatorv1.APIServer)
+		return !ok || o.Status.State != n.Status.State
+	case *operatorv1.LogStorage:
+		n, ok := newObj.(*operatorv1.LogStorage)
+		return !ok || o.Status.State != n.Status.State
+	case *operatorv1.Authentication:
+		n, ok := newObj.(*operatorv1.Authentication)
+		return !ok || o.Status.State != n.Status.State
+	}
+	return false
+}
+
+// upgradeStagesChanged returns true if an upgrade started or finished, or one of its stages changed state. Changes
+// to only the messages of the stages are ignored.
+func upgradeStagesChanged(oldUpgrade, newUpgrade *operatorv1.UpgradeStatus) bool {
+	if oldUpgrade == nil || newUpgrade == nil {
+		return oldUpgrade != newUpgrade
+	}
+	if oldUpgrade.ToVersion != newUpgrade.ToVersion || len(oldUpgrade.Stages) != len(newUpgrade.Stages) {
+		return true
+	}
+	for i := range oldUpgrade.Stages {
+		if oldUpgrade.Stages[i].Stage != newUpgrade.Stages[i].Stage || oldUpgrade.Stages[i].State != newUpgrade.Stages[i].State {
+			return true
+		}
+	}
+	return false
+}
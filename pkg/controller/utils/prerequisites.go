@@ -0,0 +1,158 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	esv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/elasticsearch/v1"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/upgrade"
+	relasticsearch "github.com/tigera/operator/pkg/render/common/elasticsearch"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+)
+
+// Prerequisites declares what a controller needs to be in place before it can render its components. The checks run in
+// a fixed order, so every controller reports the same degraded reason and message for the same missing prerequisite,
+// and requeues in the same way:
+//   - A prerequisite that isn't ready yet sets the component degraded with ResourceNotReady or ResourceNotFound and
+//     requeues after StandardRetry.
+//   - An error reading a prerequisite sets the component degraded with ResourceReadError and returns the error, so
+//     the request is retried with backoff.
+type Prerequisites struct {
+	// UpgradeStage, if set, requires an upgrade that is in progress to have reached the given stage, so that the new
+	// version of the components isn't rolled out before the stages that they depend on.
+	UpgradeStage operatorv1.UpgradeStage
+
+	// APIServer requires the APIServer to be ready.
+	APIServer bool
+
+	// Elasticsearch requires the Elasticsearch cluster to be operational.
+	Elasticsearch bool
+
+	// ElasticsearchConfig requires the Elasticsearch cluster configuration written by the log storage controller.
+	ElasticsearchConfig bool
+
+	// TierWatch, if set, requires the watch on the allow-tigera tier to be established and the tier to exist.
+	TierWatch *ReadyFlag
+
+	// LicenseAPI, if set, requires the LicenseKey API to be available.
+	LicenseAPI *ReadyFlag
+
+	// License requires a license to exist.
+	License bool
+}
+
+// PrerequisiteState holds the resources that were read while checking the prerequisites, so that controllers don't
+// need to query them again.
+type PrerequisiteState struct {
+	// License is the license of the cluster. Only set if the License prerequisite was requested.
+	License v3.LicenseKey
+
+	// ElasticsearchConfig is the Elasticsearch cluster configuration. Only set if the ElasticsearchConfig prerequisite
+	// was requested.
+	ElasticsearchConfig *relasticsearch.ClusterConfig
+}
+
+// Check verifies the prerequisites. If they are all met, it returns the resources it read and a nil result. Otherwise,
+// it sets the component degraded and returns the result and error that the reconcile should return.
+func (p Prerequisites) Check(ctx context.Context, cli client.Client, status status.StatusManager, log logr.Logger) (*PrerequisiteState, *reconcile.Result, error) {
+	waiting := func(reason operatorv1.TigeraStatusReason, msg string, err error) (*PrerequisiteState, *reconcile.Result, error) {
+		status.SetDegraded(reason, msg, err, log)
+		return nil, &reconcile.Result{RequeueAfter: StandardRetry}, nil
+	}
+	failed := func(msg string, err error) (*PrerequisiteState, *reconcile.Result, error) {
+		status.SetDegraded(operatorv1.ResourceReadError, msg, err, log)
+		return nil, &reconcile.Result{}, err
+	}
+
+	state := &PrerequisiteState{}
+
+	if p.UpgradeStage != "" {
+		installStatus, err := GetInstallationStatus(ctx, cli)
+		if err != nil && !errors.IsNotFound(err) {
+			return failed("Error querying installation status", err)
+		}
+		if err == nil && !upgrade.Allowed(installStatus.Upgrade, p.UpgradeStage) {
+			return waiting(operatorv1.ResourceNotReady, fmt.Sprintf("Waiting for the upgrade to reach the %s stage", p.UpgradeStage), nil)
+		}
+	}
+
+	if p.APIServer && !IsAPIServerReady(cli, log) {
+		return waiting(operatorv1.ResourceNotReady, "Waiting for Tigera API server to be ready", nil)
+	}
+
+	if p.Elasticsearch {
+		elasticsearch, err := GetElasticsearch(ctx, cli)
+		if err != nil {
+			return failed("An error occurred trying to retrieve Elasticsearch", err)
+		}
+		if elasticsearch == nil || elasticsearch.Status.Phase != esv1.ElasticsearchReadyPhase {
+			return waiting(operatorv1.ResourceNotReady, "Waiting for Elasticsearch cluster to be operational", nil)
+		}
+	}
+
+	if p.ElasticsearchConfig {
+		config, err := GetElasticsearchClusterConfig(ctx, cli)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return waiting(operatorv1.ResourceNotReady, "Elasticsearch cluster configuration is not available, waiting for it to become available", err)
+			}
+			return failed("Failed to get the elasticsearch cluster configuration", err)
+		}
+		state.ElasticsearchConfig = config
+	}
+
+	if p.TierWatch != nil {
+		// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
+		if !p.TierWatch.IsReady() {
+			return waiting(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", p.TierWatch.Err())
+		}
+
+		// Ensure the allow-tigera tier exists, before rendering any network policies within it.
+		if err := cli.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
+			if errors.IsNotFound(err) {
+				return waiting(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", err)
+			}
+			return failed("Error querying allow-tigera tier", err)
+		}
+	}
+
+	if p.LicenseAPI != nil && !p.LicenseAPI.IsReady() {
+		return waiting(operatorv1.ResourceNotReady, "Waiting for LicenseKeyAPI to be ready", p.LicenseAPI.Err())
+	}
+
+	if p.License {
+		license, err := FetchLicenseKey(ctx, cli)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return waiting(operatorv1.ResourceNotFound, "License not found", err)
+			}
+			return failed("Error querying license", err)
+		}
+		state.License = license
+	}
+
+	return state, nil, nil
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/stretchr/testify/mock"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+)
+
+var _ = Describe("Prerequisites", func() {
+	var (
+		ctx        context.Context
+		cli        client.Client
+		mockStatus *status.MockStatus
+		tierWatch  *utils.ReadyFlag
+		licenseAPI *utils.ReadyFlag
+		prereqs    utils.Prerequisites
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+
+		mockStatus = &status.MockStatus{}
+		tierWatch = &utils.ReadyFlag{}
+		licenseAPI = &utils.ReadyFlag{}
+		prereqs = utils.Prerequisites{
+			APIServer:  true,
+			TierWatch:  tierWatch,
+			LicenseAPI: licenseAPI,
+			License:    true,
+		}
+	})
+
+	createAPIServer := func() {
+		Expect(cli.Create(ctx, &operatorv1.APIServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Status:     operatorv1.APIServerStatus{State: operatorv1.TigeraStatusReady},
+		})).NotTo(HaveOccurred())
+	}
+
+	createTier := func() {
+		Expect(cli.Create(ctx, &v3.Tier{
+			ObjectMeta: metav1.ObjectMeta{Name: networkpolicy.TigeraComponentTierName},
+		})).NotTo(HaveOccurred())
+	}
+
+	expectWaiting := func(reason operatorv1.TigeraStatusReason, msg string) {
+		mockStatus.On("SetDegraded", reason, msg, mock.Anything, mock.Anything).Return()
+		state, result, err := prereqs.Check(ctx, cli, mockStatus, logf.Log)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(BeNil())
+		Expect(result).To(Equal(&reconcile.Result{RequeueAfter: utils.StandardRetry}))
+		mockStatus.AssertExpectations(GinkgoT())
+	}
+
+	It("should wait for the API server to be ready", func() {
+		expectWaiting(operatorv1.ResourceNotReady, "Waiting for Tigera API server to be ready")
+	})
+
+	It("should wait for the tier watch to be established", func() {
+		createAPIServer()
+		expectWaiting(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established")
+	})
+
+	It("should wait for the allow-tigera tier to be created", func() {
+		createAPIServer()
+		tierWatch.MarkAsReady()
+		expectWaiting(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information")
+	})
+
+	It("should wait for the LicenseKey API to be ready", func() {
+		createAPIServer()
+		createTier()
+		tierWatch.MarkAsReady()
+		expectWaiting(operatorv1.ResourceNotReady, "Waiting for LicenseKeyAPI to be ready")
+	})
+
+	It("should wait for the license to be created", func() {
+		createAPIServer()
+		createTier()
+		tierWatch.MarkAsReady()
+		licenseAPI.MarkAsReady()
+		expectWaiting(operatorv1.ResourceNotFound, "License not found")
+	})
+
+	It("should return the license once all prerequisites are met", func() {
+		createAPIServer()
+		createTier()
+		tierWatch.MarkAsReady()
+		licenseAPI.MarkAsReady()
+		Expect(cli.Create(ctx, &v3.LicenseKey{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Status:     v3.LicenseKeyStatus{Features: []string{"tiers"}},
+		})).NotTo(HaveOccurred())
+
+		state, result, err := prereqs.Check(ctx, cli, mockStatus, logf.Log)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(state.License.Status.Features).To(ConsistOf("tiers"))
+	})
+
+	It("should only check the requested prerequisites", func() {
+		state, result, err := utils.Prerequisites{}.Check(ctx, cli, mockStatus, logf.Log)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(state).NotTo(BeNil())
+	})
+
+	Context("during an upgrade", func() {
+		createInstallation := func(state operatorv1.UpgradeStageState) {
+			Expect(cli.Create(ctx, &operatorv1.Installation{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Status: operatorv1.InstallationStatus{
+					Upgrade: &operatorv1.UpgradeStatus{
+						FromVersion: "v1.0.0",
+						ToVersion:   "v1.1.0",
+						Stages: []operatorv1.UpgradeStageStatus{
+							{Stage: operatorv1.UpgradeStageNode, State: operatorv1.UpgradeStageComplete},
+							{Stage: operatorv1.UpgradeStageComponents, State: state},
+						},
+					},
+				},
+			})).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			prereqs = utils.Prerequisites{UpgradeStage: operatorv1.UpgradeStageComponents}
+		})
+
+		It("should wait for the upgrade to reach the stage", func() {
+			createInstallation(operatorv1.UpgradeStagePreHooks)
+			expectWaiting(operatorv1.ResourceNotReady, "Waiting for the upgrade to reach the Components stage")
+		})
+
+		It("should be met once the stage is rolling out", func() {
+			createInstallation(operatorv1.UpgradeStageRollingOut)
+			_, result, err := prereqs.Check(ctx, cli, mockStatus, logf.Log)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+
+		It("should be met without an Installation", func() {
+			_, result, err := prereqs.Check(ctx, cli, mockStatus, logf.Log)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+	})
+})
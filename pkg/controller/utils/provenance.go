@@ -0,0 +1,110 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"encoding/json"
+	"strings"
+
+	apps "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/tigera/operator/version"
+)
+
+const (
+	// OperatorVersionLabel and OperatorGitSHALabel are set on the pods of rendered workloads, when workload provenance
+	// is enabled, with the version and git commit of the operator that rendered them.
+	OperatorVersionLabel = "operator.tigera.io/operator-version"
+	OperatorGitSHALabel  = "operator.tigera.io/operator-git-sha"
+
+	// ImageProvenanceAnnotation is set on the pods of rendered workloads, when workload provenance is enabled, with a
+	// JSON list of the image, digest and SBOM reference of each container.
+	ImageProvenanceAnnotation = "operator.tigera.io/image-provenance"
+)
+
+// ImageProvenance describes the image of a container. The digest and SBOM reference are only known for images that
+// are referenced by digest. The SBOM reference follows the cosign convention of attaching the SBOM of an image as the
+// tag sha256-<digest>.sbom of its repository.
+type ImageProvenance struct {
+	Container string `json:"container"`
+	Image     string `json:"image"`
+	Digest    string `json:"digest,omitempty"`
+	SBOM      string `json:"sbom,omitempty"`
+}
+
+// setWorkloadProvenance labels and annotates the pod template of the object with its provenance, if the object is a
+// workload.
+func setWorkloadProvenance(obj client.Object) {
+	template := podTemplate(obj)
+	if template == nil {
+		return
+	}
+
+	if template.Labels == nil {
+		template.Labels = map[string]string{}
+	}
+	for label, value := range map[string]string{OperatorVersionLabel: version.VERSION, OperatorGitSHALabel: version.GitSHA} {
+		// A dirty development build may have a version that isn't a valid label value.
+		if len(validation.IsValidLabelValue(value)) == 0 {
+			template.Labels[label] = value
+		}
+	}
+
+	var images []ImageProvenance
+	for _, containers := range [][]v1.Container{template.Spec.InitContainers, template.Spec.Containers} {
+		for _, container := range containers {
+			images = append(images, imageProvenance(container))
+		}
+	}
+	b, err := json.Marshal(images)
+	if err != nil {
+		return
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[ImageProvenanceAnnotation] = string(b)
+}
+
+func imageProvenance(container v1.Container) ImageProvenance {
+	p := ImageProvenance{Container: container.Name, Image: container.Image}
+	repo, digest, found := strings.Cut(container.Image, "@")
+	if found && strings.HasPrefix(digest, "sha256:") {
+		p.Digest = digest
+		p.SBOM = repo + ":" + strings.Replace(digest, ":", "-", 1) + ".sbom"
+	}
+	return p
+}
+
+// podTemplate returns the pod template of the workload, or nil if the object isn't a workload.
+func podTemplate(obj client.Object) *v1.PodTemplateSpec {
+	switch x := obj.(type) {
+	case *apps.Deployment:
+		return &x.Spec.Template
+	case *apps.DaemonSet:
+		return &x.Spec.Template
+	case *apps.StatefulSet:
+		return &x.Spec.Template
+	case *batchv1.CronJob:
+		return &x.Spec.JobTemplate.Spec.Template
+	case *batchv1.Job:
+		return &x.Spec.Template
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/ctrlruntime"
+	rsecret "github.com/tigera/operator/pkg/render/common/secret"
+)
+
+var rlog = logf.Log.WithName("secret_replication")
+
+// labelReplicatedSecrets labels the secrets in objs that were copied from another namespace with the given key.
+func labelReplicatedSecrets(objs []client.Object, key string) {
+	for _, obj := range objs {
+		s, ok := obj.(*corev1.Secret)
+		if !ok {
+			continue
+		}
+		if _, ok := s.Annotations[rsecret.ReplicatedFromAnnotation]; !ok {
+			continue
+		}
+		s.Labels = common.MapExistsOrInitialize(s.Labels)
+		s.Labels[rsecret.ReplicatedByLabel] = key
+	}
+}
+
+// pruneReplicatedSecrets deletes the secret copies labelled with the given key that are not in objs. Only copies
+// controlled by the handler's custom resource are deleted, so that copies made on behalf of another instance of the
+// same component (e.g., for another tenant) are left alone.
+func (c componentHandler) pruneReplicatedSecrets(ctx context.Context, key string, objs []client.Object) error {
+	rendered := map[types.NamespacedName]bool{}
+	for _, obj := range objs {
+		if s, ok := obj.(*corev1.Secret); ok && s.Labels[rsecret.ReplicatedByLabel] == key {
+			rendered[client.ObjectKeyFromObject(s)] = true
+		}
+	}
+
+	copies := &corev1.SecretList{}
+	if err := c.client.List(ctx, copies, client.MatchingLabels{rsecret.ReplicatedByLabel: key}); err != nil {
+		return err
+	}
+	for i := range copies.Items {
+		s := &copies.Items[i]
+		if rendered[client.ObjectKeyFromObject(s)] || !metav1.IsControlledBy(s, c.cr) {
+			continue
+		}
+		ContextLoggerForResource(c.log, s).Info("Deleting secret copy that is no longer rendered",
+			"source", s.Annotations[rsecret.ReplicatedFromAnnotation])
+		if err := c.client.Delete(ctx, s); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// replicatedFromIndex is the name of the index of the secret copies by the secret that they were copied from, in the
+// "<namespace>/<name>" format of the rsecret.ReplicatedFromAnnotation.
+const replicatedFromIndex = "replicatedFrom"
+
+var (
+	replicatedSecretsIndexLock sync.Mutex
+	replicatedSecretsIndexed   = map[manager.Manager]bool{}
+)
+
+// replicatedFromIndexValues returns the value of obj in the replicatedFromIndex. Only secret copies are indexed.
+func replicatedFromIndexValues(obj client.Object) []string {
+	if source, ok := obj.GetAnnotations()[rsecret.ReplicatedFromAnnotation]; ok {
+		return []string{source}
+	}
+	return nil
+}
+
+// indexReplicatedSecrets adds the replicatedFromIndex to the cache of the manager. The index can only be added once,
+// while every controller that replicates secrets watches them, so it is added by the first one.
+func indexReplicatedSecrets(mgr manager.Manager) error {
+	replicatedSecretsIndexLock.Lock()
+	defer replicatedSecretsIndexLock.Unlock()
+	if replicatedSecretsIndexed[mgr] {
+		return nil
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Secret{}, replicatedFromIndex, replicatedFromIndexValues); err != nil {
+		return err
+	}
+	replicatedSecretsIndexed[mgr] = true
+	return nil
+}
+
+// AddReplicatedSecretsWatch adds a watch on secrets to the given controller that triggers a reconcile for each copy
+// of a secret when the secret changes, so that the copies made by secret.CopyToNamespace are kept up to date and are
+// removed once their source is deleted. The copies of a secret are looked up in an index of the manager's cache, so
+// that a change to a secret costs a lookup rather than a list of all copies.
+func AddReplicatedSecretsWatch(c ctrlruntime.Controller, mgr manager.Manager) error {
+	if err := indexReplicatedSecrets(mgr); err != nil {
+		return err
+	}
+	return c.WatchObject(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(replicatedSecretRequests(mgr.GetClient())), ObjectChangedPredicate)
+}
+
+// replicatedSecretRequests returns a function that maps a secret to a request for each of its copies.
+func replicatedSecretRequests(cl client.Client) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		source := fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
+		copies := &corev1.SecretList{}
+		if err := cl.List(ctx, copies, client.MatchingFields{replicatedFromIndex: source}); err != nil {
+			rlog.Error(err, "Failed to list secret copies", "namespace", obj.GetNamespace(), "name", obj.GetName())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for i := range copies.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&copies.Items[i])})
+		}
+		return requests
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/render/siemexport"
+)
+
+// ValidateSIEMExport returns an error if the SIEM export is invalid or isn't supported by the cluster. The export reads
+// from the Linseed of the cluster it runs in, so it isn't supported in managed or multi-tenant clusters.
+func ValidateSIEMExport(export *operatorv1.SIEMExport, multiTenant bool, managementClusterConnection *operatorv1.ManagementClusterConnection) error {
+	if multiTenant {
+		return fmt.Errorf("siemExport is not supported in multi-tenant management clusters")
+	}
+	if managementClusterConnection != nil {
+		return fmt.Errorf("siemExport is not supported in managed clusters")
+	}
+	return siemexport.ValidateExport(export)
+}
+
+// GetSIEMExportCredentials reads the secret in the operator namespace that holds the credentials of the bucket that the
+// SIEM export writes to.
+func GetSIEMExportCredentials(ctx context.Context, cli client.Client, export *operatorv1.SIEMExport) (*corev1.Secret, error) {
+	s := &corev1.Secret{}
+	key := client.ObjectKey{Name: export.ObjectStorage.CredentialsSecretName, Namespace: common.OperatorNamespace()}
+	if err := cli.Get(ctx, key, s); err != nil {
+		return nil, err
+	}
+	for _, k := range []string{siemexport.AccessKeyIDKey, siemexport.SecretAccessKeyKey} {
+		if len(s.Data[k]) == 0 {
+			return nil, fmt.Errorf("Secret %s/%s has no %s key", s.Namespace, s.Name, k)
+		}
+	}
+	return s, nil
+}
@@ -19,6 +19,8 @@ import (
 	"time"
 
 	operatorv1 "github.com/tigera/operator/api/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,7 +30,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-var elog = logf.Log.WithName("eventhandler").WithName("EnqueueAllTenants")
+var (
+	elog = logf.Log.WithName("eventhandler").WithName("EnqueueAllTenants")
+	ilog = logf.Log.WithName("eventhandler").WithName("EnqueueTenantInstances")
+)
 
 func EnqueueAllTenants(c client.Client) handler.EventHandler {
 	return &enqueueAllTenants{client: c}
@@ -86,3 +91,49 @@ func (h *enqueueAllTenants) getAllTenants() []types.NamespacedName {
 	}
 	return names
 }
+
+// EnqueueTenantInstances returns an event handler that queues a request for each instance of a per-tenant CR that an
+// object affects, where list is an empty list of that CR's kind. An object in the namespace of a tenant only affects
+// that tenant's instances. Any other object, either cluster-scoped or in a namespace shared by all tenants such as the
+// operator's, affects the instances of every tenant.
+func EnqueueTenantInstances(c client.Client, list client.ObjectList) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return tenantInstanceRequests(ctx, c, list, obj)
+	})
+}
+
+func tenantInstanceRequests(ctx context.Context, c client.Client, list client.ObjectList, obj client.Object) []reconcile.Request {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var opts []client.ListOption
+	if ns := obj.GetNamespace(); ns != "" {
+		tenants := operatorv1.TenantList{}
+		if err := c.List(ctx, &tenants, client.InNamespace(ns)); err != nil {
+			ilog.Error(err, "Error querying tenants, cannot trigger Reconcile", "namespace", ns)
+			return nil
+		}
+		if len(tenants.Items) > 0 {
+			opts = append(opts, client.InNamespace(ns))
+		}
+	}
+
+	instances := list.DeepCopyObject().(client.ObjectList)
+	if err := c.List(ctx, instances, opts...); err != nil {
+		ilog.Error(err, "Error querying tenant instances, cannot trigger Reconcile")
+		return nil
+	}
+	var requests []reconcile.Request
+	err := apimeta.EachListItem(instances, func(o runtime.Object) error {
+		if instance, ok := o.(client.Object); ok {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(instance)})
+		}
+		return nil
+	})
+	if err != nil {
+		ilog.Error(err, "Error reading tenant instances, cannot trigger Reconcile")
+		return nil
+	}
+	ilog.V(2).Info("Event triggered reconciliation for tenant instances", "namespace", obj.GetNamespace(), "name", obj.GetName(), "requests", len(requests))
+	return requests
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+var _ = Describe("EnqueueTenantInstances", func() {
+	var ctx context.Context
+	var cli client.Client
+
+	managerRequest := func(ns string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Name: "tigera-secure", Namespace: ns}}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(corev1.AddToScheme(scheme)).NotTo(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+
+		// Tenants a and b have a Manager, tenant c doesn't.
+		for _, ns := range []string{"tenant-a", "tenant-b", "tenant-c"} {
+			Expect(cli.Create(ctx, &operatorv1.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: ns}})).NotTo(HaveOccurred())
+		}
+		for _, ns := range []string{"tenant-a", "tenant-b"} {
+			Expect(cli.Create(ctx, &operatorv1.Manager{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure", Namespace: ns}})).NotTo(HaveOccurred())
+		}
+	})
+
+	It("should queue the Manager of the tenant whose namespace the object is in", func() {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "tenant-a"}}
+		Expect(tenantInstanceRequests(ctx, cli, &operatorv1.ManagerList{}, secret)).To(ConsistOf(managerRequest("tenant-a")))
+	})
+
+	It("should queue nothing for an object in the namespace of a tenant without a Manager", func() {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "tenant-c"}}
+		Expect(tenantInstanceRequests(ctx, cli, &operatorv1.ManagerList{}, secret)).To(BeEmpty())
+	})
+
+	It("should queue the Managers of all tenants for an object in a shared namespace", func() {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "my-cm", Namespace: common.OperatorNamespace()}}
+		Expect(tenantInstanceRequests(ctx, cli, &operatorv1.ManagerList{}, cm)).To(ConsistOf(managerRequest("tenant-a"), managerRequest("tenant-b")))
+	})
+
+	It("should queue the Managers of all tenants for a cluster-scoped object", func() {
+		license := &v3.LicenseKey{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+		Expect(tenantInstanceRequests(ctx, cli, &operatorv1.ManagerList{}, license)).To(ConsistOf(managerRequest("tenant-a"), managerRequest("tenant-b")))
+	})
+})
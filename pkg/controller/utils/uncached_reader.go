@@ -0,0 +1,90 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	readResultSuccess  = "success"
+	readResultNotFound = "not_found"
+	readResultError    = "error"
+)
+
+var (
+	// uncachedReads counts the reads that bypass the informer cache, by the kind of object read and the result.
+	uncachedReads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "tigera_operator_uncached_reads_total",
+		Help: "Number of reads sent directly to the API server rather than served from the cache, partitioned by kind and result.",
+	}, []string{"kind", "result"})
+
+	// uncachedReadDuration tracks how long the reads that bypass the informer cache take.
+	uncachedReadDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "tigera_operator_uncached_read_duration_seconds",
+		Help:    "Duration of reads sent directly to the API server rather than served from the cache, partitioned by kind.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"kind"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(uncachedReads, uncachedReadDuration)
+}
+
+// uncachedReader is a client.Reader that records metrics for reads made with the manager's API reader.
+type uncachedReader struct {
+	reader client.Reader
+}
+
+// NewUncachedReader returns a client.Reader for reads that should not be served from, or populate, the informer
+// cache, such as large secrets that are only needed occasionally. The given reader should be the manager's API
+// reader. Every read is a request to the API server, so this should only be used for reads that are not on a hot
+// path.
+func NewUncachedReader(reader client.Reader) client.Reader {
+	return &uncachedReader{reader: reader}
+}
+
+func (r *uncachedReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	start := time.Now()
+	err := r.reader.Get(ctx, key, obj, opts...)
+	r.observe(obj, start, err)
+	return err
+}
+
+func (r *uncachedReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	start := time.Now()
+	err := r.reader.List(ctx, list, opts...)
+	r.observe(list, start, err)
+	return err
+}
+
+func (r *uncachedReader) observe(obj any, start time.Time, err error) {
+	kind := reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+	result := readResultSuccess
+	if kerrors.IsNotFound(err) {
+		result = readResultNotFound
+	} else if err != nil {
+		result = readResultError
+	}
+	uncachedReads.WithLabelValues(kind, result).Inc()
+	uncachedReadDuration.WithLabelValues(kind).Observe(time.Since(start).Seconds())
+}
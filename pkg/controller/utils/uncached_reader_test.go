@@ -0,0 +1,56 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+var _ = Describe("Uncached reader", func() {
+	It("should read through and record the result of each read", func() {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).NotTo(HaveOccurred())
+		cli := ctrlrfake.DefaultFakeClientBuilder(scheme).WithObjects(
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "large-secret", Namespace: "ns"}},
+		).Build()
+		reader := NewUncachedReader(cli)
+		ctx := context.Background()
+
+		success := testutil.ToFloat64(uncachedReads.WithLabelValues("Secret", readResultSuccess))
+		notFound := testutil.ToFloat64(uncachedReads.WithLabelValues("Secret", readResultNotFound))
+		listed := testutil.ToFloat64(uncachedReads.WithLabelValues("SecretList", readResultSuccess))
+
+		Expect(reader.Get(ctx, client.ObjectKey{Name: "large-secret", Namespace: "ns"}, &corev1.Secret{})).NotTo(HaveOccurred())
+		Expect(reader.Get(ctx, client.ObjectKey{Name: "missing", Namespace: "ns"}, &corev1.Secret{})).To(HaveOccurred())
+		secrets := &corev1.SecretList{}
+		Expect(reader.List(ctx, secrets, client.InNamespace("ns"))).NotTo(HaveOccurred())
+		Expect(secrets.Items).To(HaveLen(1))
+
+		Expect(testutil.ToFloat64(uncachedReads.WithLabelValues("Secret", readResultSuccess))).To(Equal(success + 1))
+		Expect(testutil.ToFloat64(uncachedReads.WithLabelValues("Secret", readResultNotFound))).To(Equal(notFound + 1))
+		Expect(testutil.ToFloat64(uncachedReads.WithLabelValues("SecretList", readResultSuccess))).To(Equal(listed + 1))
+	})
+})
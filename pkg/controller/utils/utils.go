@@ -37,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
@@ -103,15 +104,20 @@ func IgnoreObject(obj runtime.Object) bool {
 }
 
 func AddInstallationWatch(c ctrlruntime.Controller) error {
-	return c.WatchObject(&operatorv1.Installation{}, &handler.EnqueueRequestForObject{})
+	return c.WatchObject(&operatorv1.Installation{}, &handler.EnqueueRequestForObject{}, ObjectChangedPredicate)
 }
 
 func AddAPIServerWatch(c ctrlruntime.Controller) error {
-	return c.WatchObject(&operatorv1.APIServer{}, &handler.EnqueueRequestForObject{})
+	return c.WatchObject(&operatorv1.APIServer{}, &handler.EnqueueRequestForObject{}, ObjectChangedPredicate)
 }
 
 func AddComplianceWatch(c ctrlruntime.Controller) error {
-	return c.WatchObject(&operatorv1.Compliance{}, &handler.EnqueueRequestForObject{})
+	return c.WatchObject(&operatorv1.Compliance{}, &handler.EnqueueRequestForObject{}, ObjectChangedPredicate)
+}
+
+// AddOperatorConfigWatch watches the OperatorConfig, for the controllers of components that are configured by it.
+func AddOperatorConfigWatch(c ctrlruntime.Controller) error {
+	return c.WatchObject(&operatorv1.OperatorConfig{}, &handler.EnqueueRequestForObject{}, ObjectChangedPredicate)
 }
 
 func AddNamespaceWatch(c ctrlruntime.Controller, name string) error {
@@ -121,7 +127,7 @@ func AddNamespaceWatch(c ctrlruntime.Controller, name string) error {
 		},
 	}
 
-	return c.WatchObject(ns, &handler.EnqueueRequestForObject{})
+	return c.WatchObject(ns, &handler.EnqueueRequestForObject{}, ObjectChangedPredicate)
 }
 
 type MetaMatch func(metav1.ObjectMeta) bool
@@ -178,7 +184,7 @@ func AddSecretWatchWithLabel(c ctrlruntime.Controller, ns, label string) error {
 		},
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			_, hasLabel := e.ObjectNew.GetLabels()[label]
-			return (ns == "" || e.ObjectNew.GetNamespace() == ns) && hasLabel
+			return (ns == "" || e.ObjectNew.GetNamespace() == ns) && hasLabel && objectChanged(e.ObjectOld, e.ObjectNew)
 		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
 			_, hasLabel := e.Object.GetLabels()[label]
@@ -221,15 +227,20 @@ func createPeriodicReconcileChannel(period time.Duration) chan event.GenericEven
 	return periodicReconcileEvents
 }
 
-func WaitToAddLicenseKeyWatch(controller ctrlruntime.Controller, c kubernetes.Interface, log logr.Logger, flag *ReadyFlag) {
-	WaitToAddResourceWatch(controller, c, log, flag, []client.Object{&v3.LicenseKey{TypeMeta: metav1.TypeMeta{Kind: v3.KindLicenseKey}}})
+func WaitToAddLicenseKeyWatch(ctx context.Context, controller ctrlruntime.Controller, c kubernetes.Interface, log logr.Logger, flag *ReadyFlag) {
+	WaitToAddLicenseKeyWatchWithHandler(ctx, controller, c, log, flag, &handler.EnqueueRequestForObject{})
+}
+
+// WaitToAddLicenseKeyWatchWithHandler is WaitToAddLicenseKeyWatch with the given handler for the license key's events.
+func WaitToAddLicenseKeyWatchWithHandler(ctx context.Context, controller ctrlruntime.Controller, c kubernetes.Interface, log logr.Logger, flag *ReadyFlag, h handler.EventHandler) {
+	waitToAddResourceWatch(ctx, controller, c, log, flag, []client.Object{&v3.LicenseKey{TypeMeta: metav1.TypeMeta{Kind: v3.KindLicenseKey}}}, h)
 }
 
-func WaitToAddPolicyRecommendationScopeWatch(controller ctrlruntime.Controller, c kubernetes.Interface, log logr.Logger, flag *ReadyFlag) {
-	WaitToAddResourceWatch(controller, c, log, flag, []client.Object{&v3.PolicyRecommendationScope{TypeMeta: metav1.TypeMeta{Kind: v3.KindPolicyRecommendationScope}}})
+func WaitToAddPolicyRecommendationScopeWatch(ctx context.Context, controller ctrlruntime.Controller, c kubernetes.Interface, log logr.Logger, flag *ReadyFlag) {
+	WaitToAddResourceWatch(ctx, controller, c, log, flag, []client.Object{&v3.PolicyRecommendationScope{TypeMeta: metav1.TypeMeta{Kind: v3.KindPolicyRecommendationScope}}})
 }
 
-func WaitToAddNetworkPolicyWatches(controller ctrlruntime.Controller, c kubernetes.Interface, log logr.Logger, policies []types.NamespacedName) {
+func WaitToAddNetworkPolicyWatches(ctx context.Context, controller ctrlruntime.Controller, c kubernetes.Interface, log logr.Logger, policies []types.NamespacedName) {
 	objs := []client.Object{}
 	for _, policy := range policies {
 		objs = append(objs, &v3.NetworkPolicy{
@@ -240,22 +251,22 @@ func WaitToAddNetworkPolicyWatches(controller ctrlruntime.Controller, c kubernet
 
 	// The success of a NetworkPolicy watch is not a dependency for resources to be installed or function correctly.
 	// Therefore, no ready flag is accepted or created for the watch.
-	WaitToAddResourceWatch(controller, c, log, nil, objs)
+	WaitToAddResourceWatch(ctx, controller, c, log, nil, objs)
 }
 
-func WaitToAddTierWatch(tierName string, controller ctrlruntime.Controller, c kubernetes.Interface, log logr.Logger, flag *ReadyFlag) {
+func WaitToAddTierWatch(ctx context.Context, tierName string, controller ctrlruntime.Controller, c kubernetes.Interface, log logr.Logger, flag *ReadyFlag) {
 	obj := &v3.Tier{
 		TypeMeta:   metav1.TypeMeta{Kind: "Tier", APIVersion: "projectcalico.org/v3"},
 		ObjectMeta: metav1.ObjectMeta{Name: tierName},
 	}
 
 	// The success of a Tier watch can be used as a signal that Tier queries will be resolved using the cache.
-	WaitToAddResourceWatch(controller, c, log, flag, []client.Object{obj})
+	WaitToAddResourceWatch(ctx, controller, c, log, flag, []client.Object{obj})
 }
 
 // AddNamespacedWatch creates a watch on the given object. If a name and namespace are provided, then it will
 // use predicates to only return matching objects. If they are not, then all events of the provided kind
-// will be generated. Updates that do not modify the object's generation (e.g., status and metadata) will be ignored.
+// will be generated. Updates that only modify the object's status or bookkeeping metadata will be ignored.
 func AddNamespacedWatch(c ctrlruntime.Controller, obj client.Object, h handler.EventHandler, metaMatches ...MetaMatch) error {
 	objMeta := obj.(metav1.ObjectMetaAccessor).GetObjectMeta()
 	pred := createPredicateForObject(objMeta)
@@ -560,6 +571,28 @@ func GetPacketCaptureAPI(ctx context.Context, cli client.Client) (*operatorv1.Pa
 	return pc, nil
 }
 
+// GetCloudConnector finds the CloudConnector CR in your cluster.
+func GetCloudConnector(ctx context.Context, cli client.Client) (*operatorv1.CloudConnector, error) {
+	cc := &operatorv1.CloudConnector{}
+	err := cli.Get(ctx, DefaultTSEEInstanceKey, cc)
+	if err != nil {
+		return nil, err
+	}
+
+	return cc, nil
+}
+
+// GetOperatorConfig finds the OperatorConfig CR in your cluster.
+func GetOperatorConfig(ctx context.Context, cli client.Client) (*operatorv1.OperatorConfig, error) {
+	oc := &operatorv1.OperatorConfig{}
+	err := cli.Get(ctx, DefaultInstanceKey, oc)
+	if err != nil {
+		return nil, err
+	}
+
+	return oc, nil
+}
+
 // GetElasticLicenseType returns the license type from elastic-licensing ConfigMap that ECK operator keeps updated.
 func GetElasticLicenseType(ctx context.Context, cli client.Client, logger logr.Logger) (render.ElasticsearchLicenseType, error) {
 	cm := &corev1.ConfigMap{}
@@ -591,9 +624,22 @@ type resourceWatchContext struct {
 	logger    logr.Logger
 }
 
+// The delays between the attempts to add the watches of WaitToAddResourceWatch. The delay doubles after each failed
+// attempt, up to the maximum, and is jittered so that the controllers that wait for the same API don't all retry at once.
+var (
+	watchRetryInitialDelay = 1 * time.Second
+	watchRetryMaxDelay     = 30 * time.Second
+)
+
 // WaitToAddResourceWatch will check if projectcalico.org APIs are available and if so, it will add a watch for resource
-// The completion of this operation will be signaled on a ready channel
-func WaitToAddResourceWatch(controller ctrlruntime.Controller, c kubernetes.Interface, log logr.Logger, flag *ReadyFlag, objs []client.Object) {
+// The completion of this operation will be signaled on a ready channel. Until then, the reason the watches couldn't be
+// added is recorded on the flag so that the controller can report it. It stops retrying once ctx is done, so that the
+// goroutine it is run in doesn't outlive the controller, and retries straight away when the APIs change.
+func WaitToAddResourceWatch(ctx context.Context, controller ctrlruntime.Controller, c kubernetes.Interface, log logr.Logger, flag *ReadyFlag, objs []client.Object) {
+	waitToAddResourceWatch(ctx, controller, c, log, flag, objs, &handler.EnqueueRequestForObject{})
+}
+
+func waitToAddResourceWatch(ctx context.Context, controller ctrlruntime.Controller, c kubernetes.Interface, log logr.Logger, flag *ReadyFlag, objs []client.Object, h handler.EventHandler) {
 	// Track resources left to watch and establish their watch context.
 	resourcesToWatch := map[client.Object]resourceWatchContext{}
 	for _, obj := range objs {
@@ -603,31 +649,48 @@ func WaitToAddResourceWatch(controller ctrlruntime.Controller, c kubernetes.Inte
 		}
 	}
 
-	maxDuration := 30 * time.Second
-	duration := 1 * time.Second
-	ticker := time.NewTicker(duration)
-	defer ticker.Stop()
-	for range ticker.C {
-		duration = duration * 2
-		if duration >= maxDuration {
-			duration = maxDuration
+	delay := watchRetryInitialDelay
+	timer := time.NewTimer(wait.Jitter(delay, 0.1))
+	defer timer.Stop()
+	apisChanged := APIsChanged()
+	for {
+		select {
+		case <-ctx.Done():
+			log.V(2).Info("Stopped waiting to add watches", "remaining", len(resourcesToWatch))
+			return
+		case <-timer.C:
+			delay = delay * 2
+			if delay >= watchRetryMaxDelay {
+				delay = watchRetryMaxDelay
+			}
+		case <-apisChanged:
+			// An API has become available, so retry now and start the backoff over.
+			delay = watchRetryInitialDelay
+			if !timer.Stop() {
+				<-timer.C
+			}
 		}
-		ticker.Reset(duration)
+		apisChanged = APIsChanged()
 
+		var watchErr error
 		for obj := range resourcesToWatch {
 			objLog := resourcesToWatch[obj].logger
 			predicateFn := resourcesToWatch[obj].predicate
-			if ok, err := isCalicoResourceReady(c, obj.GetObjectKind().GroupVersionKind().Kind); err != nil {
+			kind := obj.GetObjectKind().GroupVersionKind().Kind
+			if ok, err := isCalicoResourceReady(c, kind); err != nil {
 				msg := "Failed to check if resource is ready - will retry"
 				if errors.IsNotFound(err) {
 					objLog.WithValues("Error", err).V(2).Info(msg)
 				} else {
 					objLog.WithValues("Error", err).Info(msg)
 				}
+				watchErr = fmt.Errorf("failed to check if the %s API is available: %w", kind, err)
 			} else if !ok {
 				objLog.Info("Waiting for resource to be ready to watch - will retry watch attempt")
-			} else if err := controller.WatchObject(obj, &handler.EnqueueRequestForObject{}, predicateFn); err != nil {
+				watchErr = fmt.Errorf("the %s API is not available", kind)
+			} else if err := controller.WatchObject(obj, h, predicateFn); err != nil {
 				objLog.WithValues("Error", err).Info("Failed to watch resource - will retry")
+				watchErr = fmt.Errorf("failed to watch %s: %w", kind, err)
 			} else {
 				objLog.V(2).Info("Successfully watching resource")
 				delete(resourcesToWatch, obj)
@@ -640,6 +703,11 @@ func WaitToAddResourceWatch(controller ctrlruntime.Controller, c kubernetes.Inte
 			}
 			return
 		}
+		if flag != nil {
+			flag.setErr(watchErr)
+		}
+
+		timer.Reset(wait.Jitter(delay, 0.1))
 	}
 }
 
@@ -679,13 +747,12 @@ func createPredicateForObject(objMeta metav1.Object) predicate.Predicate {
 			return e.Object.GetNamespace() == objMeta.GetNamespace() || objMeta.GetNamespace() == ""
 		},
 		UpdateFunc: func(e event.UpdateEvent) bool {
-			// Not all objects use/have a generation, so we can't always rely on that to determine if the
-			// object has changed. The generation will be 0 if it's not set.
-			generationChanged := e.ObjectOld.GetGeneration() == 0 || e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+			// Not all objects use/have a generation, so objectChanged compares the contents of those that don't.
+			changed := objectChanged(e.ObjectOld, e.ObjectNew)
 
 			if objMeta.GetName() == "" && objMeta.GetNamespace() == "" {
-				// No name or namespace match was specified. Match everything, assuming the generation has changed.
-				return generationChanged
+				// No name or namespace match was specified. Match everything, assuming the object has changed.
+				return changed
 			}
 
 			if objMeta.GetName() != "" && e.ObjectNew.GetName() != objMeta.GetName() {
@@ -693,9 +760,9 @@ func createPredicateForObject(objMeta metav1.Object) predicate.Predicate {
 				return false
 			}
 			// A name match was specified and the name matches, or this is just a namespace match.
-			// Assuming the generation has changed, return a match if the namespaces also match,
+			// Assuming the object has changed, return a match if the namespaces also match,
 			// or if no namespace was given to match against.
-			return generationChanged && (e.ObjectNew.GetNamespace() == objMeta.GetNamespace() || objMeta.GetNamespace() == "")
+			return changed && (e.ObjectNew.GetNamespace() == objMeta.GetNamespace() || objMeta.GetNamespace() == "")
 		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
 			if objMeta.GetName() == "" && objMeta.GetNamespace() == "" {
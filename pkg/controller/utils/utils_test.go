@@ -37,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -345,7 +346,7 @@ var _ = Describe("CreatePredicateForObject", func() {
 		It("should match everything", func() {
 			p := createPredicateForObject(objMeta)
 			Expect(p.Create(event.CreateEvent{})).To(BeTrue())
-			Expect(p.Update(event.UpdateEvent{ObjectOld: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "", Namespace: "", Generation: 0}}, ObjectNew: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "", Namespace: "", Generation: 0}}})).To(BeTrue())
+			Expect(p.Update(event.UpdateEvent{ObjectOld: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "", Namespace: "", Generation: 0}}, ObjectNew: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "", Namespace: "", Generation: 0, Labels: map[string]string{"changed": "true"}}}})).To(BeTrue())
 			Expect(p.Update(event.UpdateEvent{ObjectOld: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "", Namespace: "", Generation: 1}}, ObjectNew: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "", Namespace: "", Generation: 2}}})).To(BeTrue())
 			Expect(p.Delete(event.DeleteEvent{})).To(BeTrue())
 		})
@@ -362,7 +363,7 @@ var _ = Describe("CreatePredicateForObject", func() {
 		It("should match if the object name matches", func() {
 			p := createPredicateForObject(objMeta)
 			Expect(p.Create(event.CreateEvent{Object: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-object", Namespace: ""}}})).To(BeTrue())
-			Expect(p.Update(event.UpdateEvent{ObjectOld: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-object", Namespace: "", Generation: 0}}, ObjectNew: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-object", Namespace: "", Generation: 0}}})).To(BeTrue()) // Generation was not specified.
+			Expect(p.Update(event.UpdateEvent{ObjectOld: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-object", Namespace: "", Generation: 0}}, ObjectNew: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-object", Namespace: "", Generation: 0, Labels: map[string]string{"changed": "true"}}}})).To(BeTrue()) // Generation was not specified.
 			Expect(p.Update(event.UpdateEvent{ObjectOld: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-object", Namespace: "", Generation: 2}}, ObjectNew: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-object", Namespace: "", Generation: 3}}})).To(BeTrue())
 			Expect(p.Delete(event.DeleteEvent{Object: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-object", Namespace: ""}}})).To(BeTrue())
 		})
@@ -388,7 +389,7 @@ var _ = Describe("CreatePredicateForObject", func() {
 		It("should match if the object namespace matches", func() {
 			p := createPredicateForObject(objMeta)
 			Expect(p.Create(event.CreateEvent{Object: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "", Namespace: "test-namespace"}}})).To(BeTrue())
-			Expect(p.Update(event.UpdateEvent{ObjectOld: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "", Namespace: "test-namespace", Generation: 0}}, ObjectNew: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "", Namespace: "test-namespace", Generation: 0}}})).To(BeTrue()) // Generation was not specified.
+			Expect(p.Update(event.UpdateEvent{ObjectOld: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "", Namespace: "test-namespace", Generation: 0}}, ObjectNew: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "", Namespace: "test-namespace", Generation: 0, Labels: map[string]string{"changed": "true"}}}})).To(BeTrue()) // Generation was not specified.
 			Expect(p.Update(event.UpdateEvent{ObjectOld: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "", Namespace: "test-namespace", Generation: 2}}, ObjectNew: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "", Namespace: "test-namespace", Generation: 3}}})).To(BeTrue())
 			Expect(p.Delete(event.DeleteEvent{Object: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "", Namespace: "test-namespace"}}})).To(BeTrue())
 		})
@@ -414,7 +415,7 @@ var _ = Describe("CreatePredicateForObject", func() {
 		It("should match if the object name and namespace match", func() {
 			p := createPredicateForObject(objMeta)
 			Expect(p.Create(event.CreateEvent{Object: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-object", Namespace: "test-namespace"}}})).To(BeTrue())
-			Expect(p.Update(event.UpdateEvent{ObjectOld: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-object", Namespace: "test-namespace", Generation: 0}}, ObjectNew: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-object", Namespace: "test-namespace", Generation: 0}}})).To(BeTrue()) // Generation was not specified.
+			Expect(p.Update(event.UpdateEvent{ObjectOld: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-object", Namespace: "test-namespace", Generation: 0}}, ObjectNew: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-object", Namespace: "test-namespace", Generation: 0, Labels: map[string]string{"changed": "true"}}}})).To(BeTrue()) // Generation was not specified.
 			Expect(p.Update(event.UpdateEvent{ObjectOld: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-object", Namespace: "test-namespace", Generation: 2}}, ObjectNew: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-object", Namespace: "test-namespace", Generation: 3}}})).To(BeTrue())
 			Expect(p.Delete(event.DeleteEvent{Object: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-object", Namespace: "test-namespace"}}})).To(BeTrue())
 		})
@@ -434,3 +435,134 @@ var _ = Describe("CreatePredicateForObject", func() {
 		})
 	})
 })
+
+var _ = Describe("ObjectChangedPredicate", func() {
+	update := func(oldObj, newObj client.Object) bool {
+		return ObjectChangedPredicate.Update(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj})
+	}
+
+	It("should ignore updates to objects without a generation that only change bookkeeping metadata", func() {
+		oldSecret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "my-namespace", ResourceVersion: "1"},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		newSecret := oldSecret.DeepCopy()
+		Expect(update(oldSecret, newSecret)).To(BeFalse())
+
+		newSecret.ResourceVersion = "2"
+		newSecret.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}
+		Expect(update(oldSecret, newSecret)).To(BeFalse())
+
+		newSecret.Data["key"] = []byte("other-value")
+		Expect(update(oldSecret, newSecret)).To(BeTrue())
+	})
+
+	It("should ignore status-only updates to objects with a generation", func() {
+		oldMgr := &opv1.Manager{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure", Generation: 1, ResourceVersion: "1"}}
+		newMgr := oldMgr.DeepCopy()
+		newMgr.ResourceVersion = "2"
+		newMgr.Status.State = opv1.TigeraStatusReady
+		Expect(update(oldMgr, newMgr)).To(BeFalse())
+
+		newMgr.Labels = map[string]string{"foo": "bar"}
+		Expect(update(oldMgr, newMgr)).To(BeTrue())
+
+		newMgr = oldMgr.DeepCopy()
+		newMgr.Generation = 2
+		Expect(update(oldMgr, newMgr)).To(BeTrue())
+	})
+
+	It("should pass status updates that other controllers wait on", func() {
+		oldAPIServer := &opv1.APIServer{ObjectMeta: metav1.ObjectMeta{Name: "default", Generation: 1}}
+		newAPIServer := oldAPIServer.DeepCopy()
+		newAPIServer.Status.Conditions = []metav1.Condition{{Type: "Ready"}}
+		Expect(update(oldAPIServer, newAPIServer)).To(BeFalse())
+		newAPIServer.Status.State = opv1.TigeraStatusReady
+		Expect(update(oldAPIServer, newAPIServer)).To(BeTrue())
+
+		oldInstallation := &opv1.Installation{ObjectMeta: metav1.ObjectMeta{Name: "default", Generation: 1}}
+		newInstallation := oldInstallation.DeepCopy()
+		newInstallation.Status.Variant = opv1.TigeraSecureEnterprise
+		Expect(update(oldInstallation, newInstallation)).To(BeTrue())
+
+		oldInstallation.Status.Upgrade = &opv1.UpgradeStatus{ToVersion: "v3.30.0", Stages: []opv1.UpgradeStageStatus{
+			{Stage: opv1.UpgradeStageComponents, State: opv1.UpgradeStagePending},
+		}}
+		newInstallation = oldInstallation.DeepCopy()
+		newInstallation.Status.Upgrade.Stages[0].Message = "Waiting for calico-node"
+		Expect(update(oldInstallation, newInstallation)).To(BeFalse())
+		newInstallation.Status.Upgrade.Stages[0].State = opv1.UpgradeStageRollingOut
+		Expect(update(oldInstallation, newInstallation)).To(BeTrue())
+	})
+})
+
+var _ = Describe("WaitToAddResourceWatch", func() {
+	var (
+		k8sClient *k8sfake.Clientset
+		ctrl      *watchRecorder
+		flag      *ReadyFlag
+		tier      *v3.Tier
+	)
+
+	BeforeEach(func() {
+		watchRetryInitialDelay, watchRetryMaxDelay = time.Millisecond, 5*time.Millisecond
+		k8sClient = k8sfake.NewSimpleClientset()
+		ctrl = &watchRecorder{}
+		flag = &ReadyFlag{}
+		tier = &v3.Tier{TypeMeta: metav1.TypeMeta{Kind: "Tier"}, ObjectMeta: metav1.ObjectMeta{Name: "allow-tigera"}}
+	})
+
+	AfterEach(func() {
+		watchRetryInitialDelay, watchRetryMaxDelay = 1*time.Second, 30*time.Second
+	})
+
+	It("should add the watch once the API is available and mark the flag as ready", func() {
+		k8sClient.Resources = []*metav1.APIResourceList{{
+			GroupVersion: v3.GroupVersionCurrent,
+			APIResources: []metav1.APIResource{{Kind: "Tier"}},
+		}}
+		WaitToAddResourceWatch(context.Background(), ctrl, k8sClient, logf.Log, flag, []client.Object{tier})
+
+		Expect(ctrl.watched).To(ConsistOf(client.ObjectKeyFromObject(tier)))
+		Expect(flag.IsReady()).To(BeTrue())
+		Expect(flag.Err()).NotTo(HaveOccurred())
+	})
+
+	It("should record why the watch can't be added and stop retrying once the context is done", func() {
+		k8sClient.Resources = []*metav1.APIResourceList{{
+			GroupVersion: v3.GroupVersionCurrent,
+			APIResources: []metav1.APIResource{{Kind: "LicenseKey"}},
+		}}
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			WaitToAddResourceWatch(ctx, ctrl, k8sClient, logf.Log, flag, []client.Object{tier})
+		}()
+
+		Eventually(flag.Err).Should(MatchError("the Tier API is not available"))
+		Expect(flag.IsReady()).To(BeFalse())
+
+		cancel()
+		Eventually(done).Should(BeClosed())
+		Expect(ctrl.watched).To(BeEmpty())
+	})
+
+	It("should retry straight away when the APIs change", func() {
+		watchRetryInitialDelay, watchRetryMaxDelay = time.Hour, time.Hour
+		k8sClient.Resources = []*metav1.APIResourceList{{
+			GroupVersion: v3.GroupVersionCurrent,
+			APIResources: []metav1.APIResource{{Kind: "Tier"}},
+		}}
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			WaitToAddResourceWatch(context.Background(), ctrl, k8sClient, logf.Log, flag, []client.Object{tier})
+		}()
+
+		Consistently(done, "100ms").ShouldNot(BeClosed())
+		NotifyAPIsChanged()
+		Eventually(done).Should(BeClosed())
+		Expect(flag.IsReady()).To(BeTrue())
+	})
+})
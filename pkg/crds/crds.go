@@ -50,7 +50,7 @@ var (
 func init() {
 	yamlDelimRe = regexp.MustCompile(`\n---`)
 
-	calicoCRDNames := []string{"installation", "apiserver", "imageset", "tigerastatus"}
+	calicoCRDNames := []string{"installation", "apiserver", "imageset", "tigerastatus", "operatorconfig", "felixconfigurationoverlay"}
 	calicoOprtrCRDsRe = regexp.MustCompile(fmt.Sprintf("(%s)", strings.Join(calicoCRDNames, "|")))
 }
 
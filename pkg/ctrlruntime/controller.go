@@ -22,13 +22,19 @@
 package ctrlruntime
 
 import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/tigera/operator/pkg/tracing"
 )
 
 // Controller implements and extends the controller.Controller interface. Implementations should store the cache from the
@@ -55,6 +61,9 @@ type controler struct {
 }
 
 func NewController(name string, mgr manager.Manager, options controller.Options) (Controller, error) {
+	if options.Reconciler != nil {
+		options.Reconciler = &tracedReconciler{name: name, Reconciler: options.Reconciler}
+	}
 	c, err := controller.New(name, mgr, options)
 	if err != nil {
 		return nil, err
@@ -66,3 +75,22 @@ func NewController(name string, mgr manager.Manager, options controller.Options)
 func (c *controler) WatchObject(object client.Object, eventhandler handler.EventHandler, predicates ...predicate.Predicate) error {
 	return c.Watch(source.Kind(c.cach, object), eventhandler, predicates...)
 }
+
+// tracedReconciler starts a span for each reconcile, which the spans of the work done by the reconcile are children
+// of. The span carries the ID of the reconcile, which its log lines carry too.
+type tracedReconciler struct {
+	reconcile.Reconciler
+	name string
+}
+
+func (r *tracedReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	ctx, span := tracing.Start(ctx, r.name+"/reconcile",
+		attribute.String("request.namespace", request.Namespace),
+		attribute.String("request.name", request.Name),
+		attribute.String("reconcile.id", string(controller.ReconcileIDFromContext(ctx))),
+	)
+	result, err := r.Reconciler.Reconcile(ctx, request)
+	span.SetAttributes(attribute.Bool("result.requeue", result.Requeue || result.RequeueAfter > 0))
+	tracing.End(span, err)
+	return result, err
+}
@@ -16,9 +16,17 @@ package dns
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -27,8 +35,66 @@ const (
 
 	// Default cluster domain value for k8s clusters.
 	DefaultClusterDomain = "cluster.local"
+
+	// kubeletConfigMap is the ConfigMap that kubeadm keeps the configuration of the kubelets in.
+	kubeletConfigMap          = "kubelet-config"
+	kubeletConfigMapNamespace = "kube-system"
+	kubeletConfigMapKey       = "kubelet"
+)
+
+// ClusterDomainSource is where the cluster domain was found.
+type ClusterDomainSource string
+
+const (
+	ClusterDomainSourceOverride   ClusterDomainSource = "override"
+	ClusterDomainSourceResolvConf ClusterDomainSource = "resolv.conf"
+	ClusterDomainSourceKubelet    ClusterDomainSource = "kubelet-config"
+	ClusterDomainSourceDefault    ClusterDomainSource = "default"
 )
 
+// DetectClusterDomain returns the cluster domain and where it was found. The override is used if it's set. Otherwise
+// the domain is looked up in the resolv.conf at resolvConfPath, and then in the kubelet configuration that kubeadm
+// stores in the cluster. If neither has it, DefaultClusterDomain is returned along with the reasons why the lookups
+// failed.
+func DetectClusterDomain(ctx context.Context, cs kubernetes.Interface, override, resolvConfPath string) (string, ClusterDomainSource, error) {
+	if override != "" {
+		if errs := validation.IsDNS1123Subdomain(override); len(errs) > 0 {
+			return "", "", fmt.Errorf("invalid cluster domain %q: %s", override, strings.Join(errs, ", "))
+		}
+		return override, ClusterDomainSourceOverride, nil
+	}
+
+	domain, resolvErr := GetClusterDomain(resolvConfPath)
+	if resolvErr == nil {
+		return domain, ClusterDomainSourceResolvConf, nil
+	}
+
+	domain, kubeletErr := getKubeletClusterDomain(ctx, cs)
+	if kubeletErr == nil {
+		return domain, ClusterDomainSourceKubelet, nil
+	}
+
+	return DefaultClusterDomain, ClusterDomainSourceDefault, errors.Join(resolvErr, kubeletErr)
+}
+
+// getKubeletClusterDomain reads the cluster domain from the kubelet configuration that kubeadm stores in the cluster.
+func getKubeletClusterDomain(ctx context.Context, cs kubernetes.Interface) (string, error) {
+	cm, err := cs.CoreV1().ConfigMaps(kubeletConfigMapNamespace).Get(ctx, kubeletConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to read the kubelet configuration: %w", err)
+	}
+	var cfg struct {
+		ClusterDomain string `json:"clusterDomain"`
+	}
+	if err := yaml.Unmarshal([]byte(cm.Data[kubeletConfigMapKey]), &cfg); err != nil {
+		return "", fmt.Errorf("failed to parse the kubelet configuration: %w", err)
+	}
+	if cfg.ClusterDomain == "" {
+		return "", fmt.Errorf("failed to find cluster domain in the kubelet configuration")
+	}
+	return strings.TrimSuffix(cfg.ClusterDomain, "."), nil
+}
+
 // GetClusterDomain parses the path to resolv.conf to find the cluster domain.
 func GetClusterDomain(resolvConfPath string) (string, error) {
 	var clusterDomain string
@@ -15,12 +15,17 @@
 package dns_test
 
 import (
+	"context"
 	"os"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
 	"github.com/tigera/operator/pkg/dns"
 )
 
@@ -44,6 +49,60 @@ var _ = Describe("Common Tests", func() {
 		})
 	})
 
+	Context("Detect cluster domain", func() {
+		var resolvConfPath string
+		kubeletConfig := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubelet-config", Namespace: "kube-system"},
+			Data: map[string]string{
+				"kubelet": "apiVersion: kubelet.config.k8s.io/v1beta1\nkind: KubeletConfiguration\nclusterDomain: example.org\n",
+			},
+		}
+
+		BeforeEach(func() {
+			dir, err := os.Getwd()
+			Expect(err).ToNot(HaveOccurred())
+			resolvConfPath = dir + "/testdata/resolv.conf"
+		})
+
+		It("Should use the override when it is set", func() {
+			cs := fake.NewSimpleClientset(kubeletConfig)
+			domain, source, err := dns.DetectClusterDomain(context.Background(), cs, "override.local", resolvConfPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(domain).To(Equal("override.local"))
+			Expect(source).To(Equal(dns.ClusterDomainSourceOverride))
+		})
+
+		It("Should reject an invalid override", func() {
+			cs := fake.NewSimpleClientset()
+			_, _, err := dns.DetectClusterDomain(context.Background(), cs, "Not_A_Domain", resolvConfPath)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should prefer resolv.conf over the kubelet configuration", func() {
+			cs := fake.NewSimpleClientset(kubeletConfig)
+			domain, source, err := dns.DetectClusterDomain(context.Background(), cs, "", resolvConfPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(domain).To(Equal("othername.local"))
+			Expect(source).To(Equal(dns.ClusterDomainSourceResolvConf))
+		})
+
+		It("Should fall back to the kubelet configuration", func() {
+			cs := fake.NewSimpleClientset(kubeletConfig)
+			domain, source, err := dns.DetectClusterDomain(context.Background(), cs, "", "does-not.exist")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(domain).To(Equal("example.org"))
+			Expect(source).To(Equal(dns.ClusterDomainSourceKubelet))
+		})
+
+		It("Should return the default and an error when nothing has the domain", func() {
+			cs := fake.NewSimpleClientset()
+			domain, source, err := dns.DetectClusterDomain(context.Background(), cs, "", "does-not.exist")
+			Expect(err).To(HaveOccurred())
+			Expect(domain).To(Equal(dns.DefaultClusterDomain))
+			Expect(source).To(Equal(dns.ClusterDomainSourceDefault))
+		})
+	})
+
 	Context("Get all DNS names for a service", func() {
 		DescribeTable("Should return the correct services names", func(service, namespace, clusterDomain string, expectedDNSNames []string) {
 			names := dns.GetServiceDNSNames(service, namespace, clusterDomain)
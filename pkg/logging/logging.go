@@ -0,0 +1,88 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging configures the format of the operator's logs, and ties the log lines that a reconcile writes
+// together with the ID of the reconcile, so that a single reconcile can be followed in a log aggregator.
+package logging
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+const (
+	// FormatText logs human readable lines.
+	FormatText = "text"
+
+	// FormatJSON logs a JSON object per line, for log aggregators.
+	FormatJSON = "json"
+
+	// ReconcileIDKey is the key of the reconcile ID in the log lines of a reconcile. It's the key that controller-runtime
+	// logs the ID under too, so that its own log lines of the reconcile match the operator's.
+	ReconcileIDKey = "reconcileID"
+)
+
+// SetFormat configures opts to log in the given format. An empty format leaves the encoder that the zap flags select.
+func SetFormat(opts *zap.Options, format string) error {
+	switch format {
+	case "":
+		return nil
+	case FormatText:
+		opts.Encoder = nil
+		opts.NewEncoder = newTextEncoder
+		return nil
+	case FormatJSON:
+		opts.Encoder = nil
+		opts.NewEncoder = newJSONEncoder
+		return nil
+	}
+	return fmt.Errorf("unknown log format %q, must be one of %q or %q", format, FormatText, FormatJSON)
+}
+
+// newTextEncoder encodes log lines as tab separated fields, followed by the key and value pairs as JSON.
+func newTextEncoder(opts ...zap.EncoderConfigOption) zapcore.Encoder {
+	cfg := uberzap.NewDevelopmentEncoderConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return zapcore.NewConsoleEncoder(cfg)
+}
+
+// newJSONEncoder encodes log lines as JSON objects. The timestamp is encoded as RFC3339 unless the
+// --zap-time-encoding flag says otherwise, so that aggregators can parse it without configuration.
+func newJSONEncoder(opts ...zap.EncoderConfigOption) zapcore.Encoder {
+	cfg := uberzap.NewProductionEncoderConfig()
+	cfg.TimeKey = "time"
+	cfg.EncodeTime = zapcore.RFC3339TimeEncoder
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return zapcore.NewJSONEncoder(cfg)
+}
+
+// ForReconcile returns log with the ID of the reconcile that ctx belongs to. Loggers that are handed to the
+// component handler and the certificate manager should come from it, so that their log lines carry the ID too. log
+// is returned as is outside of a reconcile.
+func ForReconcile(ctx context.Context, log logr.Logger) logr.Logger {
+	if id := controller.ReconcileIDFromContext(ctx); id != "" {
+		return log.WithValues(ReconcileIDKey, string(id))
+	}
+	return log
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+var _ = Describe("logging", func() {
+	var buf *bytes.Buffer
+
+	BeforeEach(func() {
+		buf = &bytes.Buffer{}
+	})
+
+	newLogger := func(format string) error {
+		opts := zap.Options{}
+		if err := SetFormat(&opts, format); err != nil {
+			return err
+		}
+		opts.DestWriter = buf
+		zap.New(zap.UseFlagOptions(&opts)).WithName("controller_test").Info("Reconciling", ReconcileIDKey, "abc", "Request.Name", "default")
+		return nil
+	}
+
+	It("logs a JSON object per line in the json format", func() {
+		Expect(newLogger(FormatJSON)).NotTo(HaveOccurred())
+
+		line := map[string]interface{}{}
+		Expect(json.Unmarshal(buf.Bytes(), &line)).NotTo(HaveOccurred())
+		Expect(line).To(HaveKeyWithValue("msg", "Reconciling"))
+		Expect(line).To(HaveKeyWithValue("logger", "controller_test"))
+		Expect(line).To(HaveKeyWithValue(ReconcileIDKey, "abc"))
+		Expect(line).To(HaveKeyWithValue("Request.Name", "default"))
+		Expect(line).To(HaveKey("time"))
+	})
+
+	It("logs human readable lines in the text format", func() {
+		Expect(newLogger(FormatText)).NotTo(HaveOccurred())
+
+		Expect(json.Valid(buf.Bytes())).To(BeFalse())
+		Expect(buf.String()).To(ContainSubstring("controller_test\tReconciling\t"))
+		Expect(buf.String()).To(ContainSubstring(`"reconcileID": "abc"`))
+	})
+
+	It("leaves the encoder of the zap flags when no format is set", func() {
+		opts := zap.Options{Development: true}
+		Expect(SetFormat(&opts, "")).NotTo(HaveOccurred())
+		Expect(opts.NewEncoder).To(BeNil())
+	})
+
+	It("rejects an unknown format", func() {
+		Expect(newLogger("yaml")).To(MatchError(`unknown log format "yaml", must be one of "text" or "json"`))
+	})
+
+	It("returns the logger as is outside of a reconcile", func() {
+		log := zap.New(zap.WriteTo(buf))
+		Expect(ForReconcile(context.Background(), log)).To(Equal(log))
+	})
+})
@@ -34,6 +34,7 @@ import (
 	"github.com/tigera/operator/pkg/components"
 	"github.com/tigera/operator/pkg/controller/k8sapi"
 	rcomp "github.com/tigera/operator/pkg/render/common/components"
+	"github.com/tigera/operator/pkg/render/common/configmap"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/common/podaffinity"
@@ -128,6 +129,20 @@ type apiServerComponent struct {
 	queryServerImage string
 }
 
+// APIServerImage returns the image of the API server container for the given installation.
+func APIServerImage(installation *operatorv1.InstallationSpec, is *operatorv1.ImageSet) (string, error) {
+	reg := installation.Registry
+	path := installation.ImagePath
+	prefix := installation.ImagePrefix
+	if installation.Variant == operatorv1.TigeraSecureEnterprise {
+		return components.GetReference(components.ComponentAPIServer, reg, path, prefix, is)
+	}
+	if operatorv1.IsFIPSModeEnabled(installation.FIPSMode) {
+		return components.GetReference(components.ComponentCalicoAPIServerFIPS, reg, path, prefix, is)
+	}
+	return components.GetReference(components.ComponentCalicoAPIServer, reg, path, prefix, is)
+}
+
 func (c *apiServerComponent) ResolveImages(is *operatorv1.ImageSet) error {
 	reg := c.cfg.Installation.Registry
 	path := c.cfg.Installation.ImagePath
@@ -135,27 +150,15 @@ func (c *apiServerComponent) ResolveImages(is *operatorv1.ImageSet) error {
 	var err error
 	errMsgs := []string{}
 
+	c.apiServerImage, err = APIServerImage(c.cfg.Installation, is)
+	if err != nil {
+		errMsgs = append(errMsgs, err.Error())
+	}
 	if c.cfg.Installation.Variant == operatorv1.TigeraSecureEnterprise {
-		c.apiServerImage, err = components.GetReference(components.ComponentAPIServer, reg, path, prefix, is)
-		if err != nil {
-			errMsgs = append(errMsgs, err.Error())
-		}
 		c.queryServerImage, err = components.GetReference(components.ComponentQueryServer, reg, path, prefix, is)
 		if err != nil {
 			errMsgs = append(errMsgs, err.Error())
 		}
-	} else {
-		if operatorv1.IsFIPSModeEnabled(c.cfg.Installation.FIPSMode) {
-			c.apiServerImage, err = components.GetReference(components.ComponentCalicoAPIServerFIPS, reg, path, prefix, is)
-			if err != nil {
-				errMsgs = append(errMsgs, err.Error())
-			}
-		} else {
-			c.apiServerImage, err = components.GetReference(components.ComponentCalicoAPIServer, reg, path, prefix, is)
-			if err != nil {
-				errMsgs = append(errMsgs, err.Error())
-			}
-		}
 	}
 
 	if len(errMsgs) != 0 {
@@ -262,7 +265,7 @@ func (c *apiServerComponent) Objects() ([]client.Object, []client.Object) {
 		c.auditPolicyConfigMap(),
 	}
 	if c.cfg.TrustedBundle != nil {
-		namespacedEnterpriseObjects = append(namespacedEnterpriseObjects, c.cfg.TrustedBundle.ConfigMap(QueryserverNamespace))
+		namespacedEnterpriseObjects = append(namespacedEnterpriseObjects, configmap.ToRuntimeObjects(c.cfg.TrustedBundle.ConfigMaps(QueryserverNamespace)...)...)
 	}
 
 	// Global OSS-only objects.
@@ -927,6 +930,7 @@ func (c *apiServerComponent) apiServerDeployment() *appsv1.Deployment {
 	annotations := map[string]string{
 		c.cfg.TLSKeyPair.HashAnnotationKey(): c.cfg.TLSKeyPair.HashAnnotationValue(),
 	}
+	rmeta.AddNetworkAttachments(annotations, c.cfg.Installation, operatorv1.MultusWorkloadAPIServer)
 
 	d := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
@@ -939,9 +943,7 @@ func (c *apiServerComponent) apiServerDeployment() *appsv1.Deployment {
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: c.cfg.Installation.ControlPlaneReplicas,
-			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RecreateDeploymentStrategyType,
-			},
+			Strategy: c.deploymentStrategy(),
 			Selector: c.deploymentSelector(),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
@@ -991,6 +993,24 @@ func (c *apiServerComponent) apiServerDeployment() *appsv1.Deployment {
 	return d
 }
 
+// deploymentStrategy rolls the API server one pod at a time so that it keeps serving during upgrades. A replacement pod
+// is started before an old one is stopped, except on the host network where the two would compete for the same ports.
+func (c *apiServerComponent) deploymentStrategy() appsv1.DeploymentStrategy {
+	maxUnavailable := intstr.FromInt(0)
+	maxSurge := intstr.FromInt(1)
+	if c.hostNetwork() {
+		maxUnavailable = intstr.FromInt(1)
+		maxSurge = intstr.FromInt(0)
+	}
+	return appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxUnavailable: &maxUnavailable,
+			MaxSurge:       &maxSurge,
+		},
+	}
+}
+
 func (c *apiServerComponent) hostNetwork() bool {
 	hostNetwork := c.cfg.ForceHostNetwork
 	if (c.cfg.Installation.KubernetesProvider.IsEKS() || c.cfg.Installation.KubernetesProvider.IsTKG()) &&
@@ -1184,7 +1204,7 @@ func (c *apiServerComponent) tolerations() []corev1.Toleration {
 	if c.hostNetwork() {
 		return rmeta.TolerateAll
 	}
-	return append(c.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateControlPlane...)
+	return rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.TolerateControlPlane...)
 }
 
 // networkPolicy returns a NP to allow traffic to the API server. This prevents it from
@@ -15,6 +15,8 @@
 package render
 
 import (
+	"fmt"
+
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -110,6 +112,10 @@ func (c *awsSGSetupComponent) setupJob() *batchv1.Job {
 								Name:  "KUBELET_KUBECONFIG",
 								Value: "/etc/kubernetes/kubeconfig",
 							},
+							{
+								Name:  "TYPHA_PORT",
+								Value: fmt.Sprintf("%d", typhaPort(c.cfg.Installation)),
+							},
 						},
 						SecurityContext: securitycontext.NewNonRootContext(),
 					}},
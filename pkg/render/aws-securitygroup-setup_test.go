@@ -22,6 +22,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/ptr"
 	rtest "github.com/tigera/operator/pkg/render/common/test"
 )
 
@@ -90,5 +91,18 @@ var _ = Describe("AWS SecurityGroup Setup rendering tests", func() {
 			&corev1.SeccompProfile{
 				Type: corev1.SeccompProfileTypeRuntimeDefault,
 			}))
+		Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "TYPHA_PORT", Value: "5473"}))
+	})
+
+	It("should pass a custom Typha port to the Setup Job", func() {
+		cfg.Installation.TyphaPort = ptr.Int32ToPtr(5474)
+		component, err := AWSSecurityGroupSetup(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+
+		job, ok := rtest.GetResource(toCreate, "aws-security-group-setup-1", "tigera-operator", "batch", "v1", "Job").(*batchv1.Job)
+		Expect(ok).To(BeTrue())
+		Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "TYPHA_PORT", Value: "5474"}))
 	})
 })
@@ -18,6 +18,7 @@ import (
 	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/render"
+	"github.com/tigera/operator/pkg/render/common/configmap"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -45,6 +46,10 @@ type Config struct {
 	Namespace       string
 	TruthNamespace  string
 	TrustedBundle   certificatemanagement.TrustedBundle
+
+	// ComponentTrustedBundles are bundles of the certificates that individual components need, which they mount
+	// instead of the TrustedBundle of the namespace.
+	ComponentTrustedBundles []certificatemanagement.TrustedBundle
 }
 
 func NewKeyPairOption(keyPair certificatemanagement.KeyPairInterface, renderInTruthNS, renderInAppNS bool) KeyPairOption {
@@ -76,7 +81,10 @@ func (c component) ResolveImages(*operatorv1.ImageSet) error {
 func (c component) Objects() (objsToCreate, objsToDelete []client.Object) {
 	if c.cfg.TrustedBundle != nil {
 		// Create the trusted bundle in the namespace that we're installing into.
-		objsToCreate = append(objsToCreate, c.cfg.TrustedBundle.ConfigMap(c.cfg.Namespace))
+		objsToCreate = append(objsToCreate, configmap.ToRuntimeObjects(c.cfg.TrustedBundle.ConfigMaps(c.cfg.Namespace)...)...)
+	}
+	for _, bundle := range c.cfg.ComponentTrustedBundles {
+		objsToCreate = append(objsToCreate, configmap.ToRuntimeObjects(bundle.ConfigMaps(c.cfg.Namespace)...)...)
 	}
 
 	// Iterate each KeyPair and create it where needed. A KeyPair may need to be installed one or more of:
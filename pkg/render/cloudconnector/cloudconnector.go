@@ -0,0 +1,289 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cloudconnector renders the agent that attaches the cluster to Calico Cloud.
+package cloudconnector
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/components"
+	"github.com/tigera/operator/pkg/render"
+	rcomponents "github.com/tigera/operator/pkg/render/common/components"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/secret"
+	"github.com/tigera/operator/pkg/render/common/securitycontext"
+	"github.com/tigera/operator/pkg/render/common/securitycontextconstraints"
+)
+
+const (
+	Name               = "tigera-cloud-connector"
+	Namespace          = Name
+	ServiceAccountName = Name
+	ClusterRoleName    = Name
+	DeploymentName     = Name
+	ContainerName      = Name
+
+	// CredentialsSecretName is the name of the copy of the user's credentials secret in the connector's namespace.
+	CredentialsSecretName = "tigera-cloud-connector-credentials"
+
+	credentialsVolumeName = "credentials"
+	credentialsMountPath  = "/etc/tigera/cloud-connector"
+)
+
+// ValidateCloudConnector returns an error if the CloudConnector configuration is invalid.
+func ValidateCloudConnector(cc *operatorv1.CloudConnector) error {
+	if cc.Spec.CredentialsSecretName == "" {
+		return fmt.Errorf("spec.credentialsSecretName must be specified")
+	}
+	u, err := url.Parse(cc.Spec.Endpoint)
+	if err != nil {
+		return fmt.Errorf("spec.endpoint %q is invalid: %w", cc.Spec.Endpoint, err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("spec.endpoint %q must be an https URL", cc.Spec.Endpoint)
+	}
+	if cc.Spec.Proxy != nil {
+		u, err := url.Parse(cc.Spec.Proxy.HTTPSProxy)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("spec.proxy.httpsProxy %q must be a URL", cc.Spec.Proxy.HTTPSProxy)
+		}
+	}
+	return nil
+}
+
+// Config contains the information needed to render the cloud connector.
+type Config struct {
+	PullSecrets  []*corev1.Secret
+	OpenShift    bool
+	Installation *operatorv1.InstallationSpec
+
+	// CloudConnector is the custom resource that the connector is rendered for.
+	CloudConnector *operatorv1.CloudConnector
+
+	// CredentialsSecret is the secret named by the custom resource, in the operator namespace.
+	CredentialsSecret *corev1.Secret
+}
+
+// CloudConnector returns the component that renders the cloud connector Deployment and the resources it needs.
+func CloudConnector(cfg *Config) render.Component {
+	return &component{cfg: cfg}
+}
+
+type component struct {
+	cfg   *Config
+	image string
+}
+
+func (c *component) ResolveImages(is *operatorv1.ImageSet) error {
+	reg := c.cfg.Installation.Registry
+	path := c.cfg.Installation.ImagePath
+	prefix := c.cfg.Installation.ImagePrefix
+	var err error
+	c.image, err = components.GetReference(components.ComponentCloudConnector, reg, path, prefix, is)
+	return err
+}
+
+func (c *component) SupportedOSType() rmeta.OSType {
+	return rmeta.OSTypeLinux
+}
+
+func (c *component) ReplicatedSecretsKey() string {
+	return "cloud-connector"
+}
+
+func (c *component) TracingContainers() []string {
+	return []string{ContainerName}
+}
+
+func (c *component) Ready() bool {
+	return true
+}
+
+func (c *component) Objects() ([]client.Object, []client.Object) {
+	objs := []client.Object{
+		render.CreateNamespace(Namespace, c.cfg.Installation.KubernetesProvider, render.PSSRestricted),
+	}
+	objs = append(objs, secret.ToRuntimeObjects(secret.CopyToNamespace(Namespace, c.cfg.PullSecrets...)...)...)
+	objs = append(objs,
+		c.credentialsSecret(),
+		c.serviceAccount(),
+		c.clusterRole(),
+		c.clusterRoleBinding(),
+		c.deployment(),
+	)
+	return objs, nil
+}
+
+// credentialsSecret copies the user's credentials into the connector's namespace under a fixed name, so that the
+// Deployment doesn't depend on the name the user chose.
+func (c *component) credentialsSecret() *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: CredentialsSecretName, Namespace: Namespace},
+		Data:       c.cfg.CredentialsSecret.Data,
+	}
+}
+
+func (c *component) serviceAccount() *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		TypeMeta:   metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: ServiceAccountName, Namespace: Namespace},
+	}
+}
+
+func (c *component) clusterRole() *rbacv1.ClusterRole {
+	rules := []rbacv1.PolicyRule{
+		{
+			// The connector reports the size and layout of the cluster.
+			APIGroups: []string{""},
+			Resources: []string{"nodes", "namespaces"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+		{
+			// The connector reports the installed components and their health.
+			APIGroups: []string{"operator.tigera.io"},
+			Resources: []string{"installations", "tigerastatuses"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+	}
+
+	if c.cfg.OpenShift {
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups:     []string{"security.openshift.io"},
+			Resources:     []string{"securitycontextconstraints"},
+			Verbs:         []string{"use"},
+			ResourceNames: []string{securitycontextconstraints.NonRootV2},
+		})
+	}
+
+	return &rbacv1.ClusterRole{
+		TypeMeta:   metav1.TypeMeta{Kind: "ClusterRole", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: ClusterRoleName},
+		Rules:      rules,
+	}
+}
+
+func (c *component) clusterRoleBinding() *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		TypeMeta:   metav1.TypeMeta{Kind: "ClusterRoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: ClusterRoleName},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     ClusterRoleName,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      ServiceAccountName,
+				Namespace: Namespace,
+			},
+		},
+	}
+}
+
+func (c *component) deployment() *appsv1.Deployment {
+	var replicas int32 = 1
+
+	d := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DeploymentName,
+			Namespace: Namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      DeploymentName,
+					Namespace: Namespace,
+					Annotations: map[string]string{
+						"hash.operator.tigera.io/cloud-connector-credentials": rmeta.AnnotationHash(c.cfg.CredentialsSecret.Data),
+					},
+				},
+				Spec: corev1.PodSpec{
+					NodeSelector:       c.cfg.Installation.ControlPlaneNodeSelector,
+					ServiceAccountName: ServiceAccountName,
+					Tolerations:        rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.TolerateCriticalAddonsAndControlPlane...),
+					ImagePullSecrets:   secret.GetReferenceList(c.cfg.PullSecrets),
+					Containers:         []corev1.Container{c.container()},
+					Volumes: []corev1.Volume{
+						{
+							Name: credentialsVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{SecretName: CredentialsSecretName},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if overrides := c.cfg.CloudConnector.Spec.CloudConnectorDeployment; overrides != nil {
+		rcomponents.ApplyDeploymentOverrides(d, overrides)
+	}
+	return d
+}
+
+func (c *component) container() corev1.Container {
+	env := []corev1.EnvVar{
+		{Name: "CLOUD_CONNECTOR_ENDPOINT", Value: c.cfg.CloudConnector.Spec.Endpoint},
+		{Name: "CLOUD_CONNECTOR_CREDENTIALS_PATH", Value: credentialsMountPath},
+		{Name: "CLOUD_CONNECTOR_FIPS_MODE_ENABLED", Value: operatorv1.IsFIPSModeEnabledString(c.cfg.Installation.FIPSMode)},
+	}
+	env = append(env, c.proxyEnv()...)
+
+	return corev1.Container{
+		Name:            ContainerName,
+		Image:           c.image,
+		ImagePullPolicy: render.ImagePullPolicy(),
+		Env:             env,
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: credentialsVolumeName, MountPath: credentialsMountPath, ReadOnly: true},
+		},
+		SecurityContext: securitycontext.NewNonRootContext(),
+	}
+}
+
+// proxyEnv returns the variables that send the connector's outbound connections through the configured proxy. The
+// in-cluster address of the Kubernetes API server, which the kubelet provides to every pod, is always excluded.
+func (c *component) proxyEnv() []corev1.EnvVar {
+	proxy := c.cfg.CloudConnector.Spec.Proxy
+	if proxy == nil {
+		return nil
+	}
+
+	noProxy := []string{"$(KUBERNETES_SERVICE_HOST)"}
+	if proxy.NoProxy != "" {
+		noProxy = append(noProxy, proxy.NoProxy)
+	}
+	return []corev1.EnvVar{
+		{Name: "HTTPS_PROXY", Value: proxy.HTTPSProxy},
+		{Name: "NO_PROXY", Value: strings.Join(noProxy, ",")},
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudconnector
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	rtest "github.com/tigera/operator/pkg/render/common/test"
+)
+
+var _ = Describe("Cloud connector rendering tests", func() {
+	var cfg *Config
+
+	BeforeEach(func() {
+		cfg = &Config{
+			Installation: &operatorv1.InstallationSpec{Registry: "testregistry.com/"},
+			CloudConnector: &operatorv1.CloudConnector{
+				ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+				Spec: operatorv1.CloudConnectorSpec{
+					Endpoint:              "https://connect.calicocloud.io",
+					CredentialsSecretName: "calico-cloud-credentials",
+				},
+			},
+			CredentialsSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "calico-cloud-credentials", Namespace: common.OperatorNamespace()},
+				Data:       map[string][]byte{"token": []byte("t0ken")},
+			},
+		}
+	})
+
+	expectedResources := []struct {
+		name    string
+		ns      string
+		group   string
+		version string
+		kind    string
+	}{
+		{Namespace, "", "", "v1", "Namespace"},
+		{CredentialsSecretName, Namespace, "", "v1", "Secret"},
+		{ServiceAccountName, Namespace, "", "v1", "ServiceAccount"},
+		{ClusterRoleName, "", "rbac.authorization.k8s.io", "v1", "ClusterRole"},
+		{ClusterRoleName, "", "rbac.authorization.k8s.io", "v1", "ClusterRoleBinding"},
+		{DeploymentName, Namespace, "apps", "v1", "Deployment"},
+	}
+
+	It("should render the connector Deployment", func() {
+		component := CloudConnector(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		resources, toDelete := component.Objects()
+		Expect(toDelete).To(BeEmpty())
+		Expect(resources).To(HaveLen(len(expectedResources)))
+		for i, expectedRes := range expectedResources {
+			rtest.ExpectResourceTypeAndObjectMetadata(resources[i], expectedRes.name, expectedRes.ns, expectedRes.group, expectedRes.version, expectedRes.kind)
+		}
+
+		secret := rtest.GetResource(resources, CredentialsSecretName, Namespace, "", "v1", "Secret").(*corev1.Secret)
+		Expect(secret.Data).To(Equal(map[string][]byte{"token": []byte("t0ken")}))
+
+		role := rtest.GetResource(resources, ClusterRoleName, "", "rbac.authorization.k8s.io", "v1", "ClusterRole").(*rbacv1.ClusterRole)
+		for _, rule := range role.Rules {
+			Expect(rule.Verbs).To(Equal([]string{"get", "list", "watch"}))
+		}
+
+		d := rtest.GetResource(resources, DeploymentName, Namespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		pod := d.Spec.Template
+		Expect(pod.Spec.ServiceAccountName).To(Equal(ServiceAccountName))
+		Expect(pod.Annotations).To(HaveKey("hash.operator.tigera.io/cloud-connector-credentials"))
+		Expect(pod.Spec.Containers).To(HaveLen(1))
+		container := pod.Spec.Containers[0]
+		Expect(container.Image).To(Equal("testregistry.com/tigera/cloud-connector:master"))
+		Expect(*container.SecurityContext.RunAsNonRoot).To(BeTrue())
+		rtest.ExpectEnv(container.Env, "CLOUD_CONNECTOR_ENDPOINT", "https://connect.calicocloud.io")
+		rtest.ExpectEnv(container.Env, "CLOUD_CONNECTOR_CREDENTIALS_PATH", "/etc/tigera/cloud-connector")
+		for _, env := range container.Env {
+			Expect(env.Name).NotTo(Equal("HTTPS_PROXY"))
+		}
+		Expect(pod.Spec.Volumes).To(ConsistOf(corev1.Volume{
+			Name: "credentials",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: CredentialsSecretName},
+			},
+		}))
+	})
+
+	It("should send outbound connections through the proxy, except to the API server", func() {
+		cfg.CloudConnector.Spec.Proxy = &operatorv1.CloudConnectorProxy{
+			HTTPSProxy: "http://proxy.example.com:3128",
+			NoProxy:    ".example.com,10.0.0.0/8",
+		}
+		resources, _ := CloudConnector(cfg).Objects()
+		d := rtest.GetResource(resources, DeploymentName, Namespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		env := d.Spec.Template.Spec.Containers[0].Env
+		rtest.ExpectEnv(env, "HTTPS_PROXY", "http://proxy.example.com:3128")
+		rtest.ExpectEnv(env, "NO_PROXY", "$(KUBERNETES_SERVICE_HOST),.example.com,10.0.0.0/8")
+	})
+
+	It("should allow the use of the nonroot-v2 SCC on OpenShift", func() {
+		cfg.OpenShift = true
+		resources, _ := CloudConnector(cfg).Objects()
+		role := rtest.GetResource(resources, ClusterRoleName, "", "rbac.authorization.k8s.io", "v1", "ClusterRole").(*rbacv1.ClusterRole)
+		Expect(role.Rules).To(ContainElement(rbacv1.PolicyRule{
+			APIGroups:     []string{"security.openshift.io"},
+			Resources:     []string{"securitycontextconstraints"},
+			Verbs:         []string{"use"},
+			ResourceNames: []string{"nonroot-v2"},
+		}))
+	})
+
+	It("should apply resource overrides", func() {
+		rr := corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("250m"),
+				corev1.ResourceMemory: resource.MustParse("256Mi"),
+			},
+		}
+		cfg.CloudConnector.Spec.CloudConnectorDeployment = &operatorv1.CloudConnectorDeployment{
+			Spec: &operatorv1.CloudConnectorDeploymentSpec{
+				Template: &operatorv1.CloudConnectorDeploymentPodTemplateSpec{
+					Spec: &operatorv1.CloudConnectorDeploymentPodSpec{
+						Containers: []operatorv1.CloudConnectorDeploymentContainer{
+							{Name: ContainerName, Resources: &rr},
+						},
+					},
+				},
+			},
+		}
+		resources, _ := CloudConnector(cfg).Objects()
+		d := rtest.GetResource(resources, DeploymentName, Namespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Resources).To(Equal(rr))
+	})
+
+	DescribeTable("validating the CloudConnector",
+		func(spec operatorv1.CloudConnectorSpec, expectedErr string) {
+			err := ValidateCloudConnector(&operatorv1.CloudConnector{Spec: spec})
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			}
+		},
+		Entry("valid", operatorv1.CloudConnectorSpec{Endpoint: "https://cc.example.com", CredentialsSecretName: "c"}, ""),
+		Entry("valid proxy", operatorv1.CloudConnectorSpec{
+			Endpoint:              "https://cc.example.com",
+			CredentialsSecretName: "c",
+			Proxy:                 &operatorv1.CloudConnectorProxy{HTTPSProxy: "http://proxy:3128"},
+		}, ""),
+		Entry("no credentials", operatorv1.CloudConnectorSpec{Endpoint: "https://cc.example.com"}, "credentialsSecretName must be specified"),
+		Entry("http endpoint", operatorv1.CloudConnectorSpec{Endpoint: "http://cc.example.com", CredentialsSecretName: "c"}, "must be an https URL"),
+		Entry("no endpoint", operatorv1.CloudConnectorSpec{CredentialsSecretName: "c"}, "must be an https URL"),
+		Entry("invalid proxy", operatorv1.CloudConnectorSpec{
+			Endpoint:              "https://cc.example.com",
+			CredentialsSecretName: "c",
+			Proxy:                 &operatorv1.CloudConnectorProxy{HTTPSProxy: "proxy"},
+		}, "httpsProxy \"proxy\" must be a URL"),
+	)
+})
@@ -0,0 +1,82 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudnetworkset
+
+import (
+	"strings"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/render"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+)
+
+const (
+	// NameLabel is set on the GlobalNetworkSet to the name of its CloudNetworkSet, and ProviderLabel to the lower
+	// case cloud provider, so that policy can select the network sets by label.
+	NameLabel     = "operator.tigera.io/cloud-network-set"
+	ProviderLabel = "operator.tigera.io/cloud-provider"
+)
+
+// Config contains the information needed to render the GlobalNetworkSet of a CloudNetworkSet.
+type Config struct {
+	CloudNetworkSet *operatorv1.CloudNetworkSet
+
+	// Nets are the IP ranges of the network set.
+	Nets []string
+}
+
+// CloudNetworkSet returns a component that renders the GlobalNetworkSet with the IP ranges of a CloudNetworkSet.
+func CloudNetworkSet(cfg *Config) render.Component {
+	return &component{cfg: cfg}
+}
+
+type component struct {
+	cfg *Config
+}
+
+func (c *component) ResolveImages(is *operatorv1.ImageSet) error {
+	return nil
+}
+
+func (c *component) SupportedOSType() rmeta.OSType {
+	return rmeta.OSTypeAny
+}
+
+func (c *component) Ready() bool {
+	return true
+}
+
+func (c *component) Objects() ([]client.Object, []client.Object) {
+	return []client.Object{c.globalNetworkSet()}, nil
+}
+
+func (c *component) globalNetworkSet() *v3.GlobalNetworkSet {
+	cns := c.cfg.CloudNetworkSet
+	return &v3.GlobalNetworkSet{
+		TypeMeta: metav1.TypeMeta{Kind: "GlobalNetworkSet", APIVersion: "projectcalico.org/v3"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: cns.Name,
+			Labels: map[string]string{
+				NameLabel:     cns.Name,
+				ProviderLabel: strings.ToLower(string(cns.Spec.Provider)),
+			},
+		},
+		Spec: v3.GlobalNetworkSetSpec{Nets: c.cfg.Nets},
+	}
+}
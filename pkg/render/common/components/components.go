@@ -41,6 +41,7 @@ type replicatedPodResource struct {
 	minReadySeconds    *int32
 	podTemplateSpec    *corev1.PodTemplateSpec
 	deploymentStrategy *appsv1.DeploymentStrategy // Deployments only
+	podSelector        map[string]string          // Deployments only
 }
 
 // applyReplicatedPodResourceOverrides takes the given replicated pod resource data and applies the overrides.
@@ -77,6 +78,9 @@ func applyReplicatedPodResourceOverrides(r *replicatedPodResource, overrides com
 	if initContainers := overrides.GetInitContainers(); initContainers != nil {
 		mergeContainers(r.podTemplateSpec.Spec.InitContainers, initContainers)
 	}
+	if d, ok := overrides.(components.InitContainerDisablingOverrides); ok {
+		r.podTemplateSpec.Spec.InitContainers = removeContainers(r.podTemplateSpec.Spec.InitContainers, d.GetDisabledInitContainers())
+	}
 	if containers := overrides.GetContainers(); containers != nil {
 		mergeContainers(r.podTemplateSpec.Spec.Containers, containers)
 	}
@@ -88,7 +92,7 @@ func applyReplicatedPodResourceOverrides(r *replicatedPodResource, overrides com
 		common.MergeMaps(nodeSelector, r.podTemplateSpec.Spec.NodeSelector)
 	}
 	if constraints := overrides.GetTopologySpreadConstraints(); constraints != nil {
-		r.podTemplateSpec.Spec.TopologySpreadConstraints = constraints
+		r.podTemplateSpec.Spec.TopologySpreadConstraints = topologySpreadConstraints(constraints, r.podSelector)
 	}
 	if tolerations := overrides.GetTolerations(); tolerations != nil {
 		r.podTemplateSpec.Spec.Tolerations = tolerations
@@ -96,10 +100,33 @@ func applyReplicatedPodResourceOverrides(r *replicatedPodResource, overrides com
 	if priorityClassName := overrides.GetPriorityClassName(); priorityClassName != "" {
 		r.podTemplateSpec.Spec.PriorityClassName = priorityClassName
 	}
+	if dnsPolicy := overrides.GetDNSPolicy(); dnsPolicy != "" {
+		r.podTemplateSpec.Spec.DNSPolicy = dnsPolicy
+	}
+	if dnsConfig := overrides.GetDNSConfig(); dnsConfig != nil {
+		r.podTemplateSpec.Spec.DNSConfig = dnsConfig
+	}
 
 	return r
 }
 
+// topologySpreadConstraints returns the constraints with the selector of the resource's own pods as the label selector
+// of any constraint that has none. Without a label selector no pods are counted, so the constraint wouldn't spread them.
+func topologySpreadConstraints(constraints []corev1.TopologySpreadConstraint, podSelector map[string]string) []corev1.TopologySpreadConstraint {
+	var result []corev1.TopologySpreadConstraint
+	for _, c := range constraints {
+		c = *c.DeepCopy()
+		if c.LabelSelector == nil && len(podSelector) > 0 {
+			c.LabelSelector = &metav1.LabelSelector{MatchLabels: map[string]string{}}
+			for k, v := range podSelector {
+				c.LabelSelector.MatchLabels[k] = v
+			}
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
 // ApplyDaemonSetOverrides applies the overrides to the given DaemonSet.
 // Note: overrides must not be nil pointer.
 func ApplyDaemonSetOverrides(ds *appsv1.DaemonSet, overrides components.ReplicatedPodResourceOverrides) {
@@ -140,6 +167,7 @@ func ApplyDeploymentOverrides(d *appsv1.Deployment, overrides components.Replica
 		minReadySeconds:    &d.Spec.MinReadySeconds,
 		podTemplateSpec:    &d.Spec.Template,
 		deploymentStrategy: &d.Spec.Strategy,
+		podSelector:        deploymentPodSelector(d),
 	}
 	// Apply the overrides.
 	applyReplicatedPodResourceOverrides(r, overrides)
@@ -152,6 +180,18 @@ func ApplyDeploymentOverrides(d *appsv1.Deployment, overrides components.Replica
 	d.Spec.Strategy = *r.deploymentStrategy
 }
 
+// deploymentPodSelector returns the labels that select the pods of the deployment. If the deployment has no selector
+// yet, it is the one the component handler gives it.
+func deploymentPodSelector(d *appsv1.Deployment) map[string]string {
+	if d.Spec.Selector != nil && len(d.Spec.Selector.MatchLabels) > 0 {
+		return d.Spec.Selector.MatchLabels
+	}
+	if d.Name == "" {
+		return nil
+	}
+	return map[string]string{"k8s-app": d.Name}
+}
+
 // ApplyJobOverrides applies the overrides to the given Job.
 func ApplyJobOverrides(job *batchv1.Job, overrides components.ReplicatedPodResourceOverrides) {
 	// Catch if caller passes in an explicit nil.
@@ -240,6 +280,37 @@ func ApplyKibanaOverrides(k *kbv1.Kibana, overrides components.ReplicatedPodReso
 	k.Spec.PodTemplate = *r.podTemplateSpec
 }
 
+// ApplyServiceOptions applies the given options to the Service.
+func ApplyServiceOptions(s *corev1.Service, opts *operator.ServiceOptions) {
+	// Catch if caller passes in an explicit nil.
+	if opts == nil {
+		return
+	}
+
+	if opts.Type != nil {
+		s.Spec.Type = *opts.Type
+	}
+	if opts.SessionAffinity != nil {
+		s.Spec.SessionAffinity = *opts.SessionAffinity
+	}
+	if opts.ExternalTrafficPolicy != nil {
+		s.Spec.ExternalTrafficPolicy = *opts.ExternalTrafficPolicy
+	}
+	if opts.Headless != nil && *opts.Headless {
+		s.Spec.ClusterIP = corev1.ClusterIPNone
+	}
+	if metadata := opts.Metadata; metadata != nil {
+		if len(metadata.Labels) > 0 {
+			s.Labels = common.MapExistsOrInitialize(s.Labels)
+			common.MergeMaps(metadata.Labels, s.Labels)
+		}
+		if len(metadata.Annotations) > 0 {
+			s.Annotations = common.MapExistsOrInitialize(s.Annotations)
+			common.MergeMaps(metadata.Annotations, s.Annotations)
+		}
+	}
+}
+
 // ApplyPrometheusOverrides applies the overrides to the given Prometheus.
 // Note: overrides must not be nil pointer.
 func ApplyPrometheusOverrides(prom *monitoringv1.Prometheus, overrides *operator.Prometheus) {
@@ -263,8 +334,8 @@ func ApplyPrometheusOverrides(prom *monitoringv1.Prometheus, overrides *operator
 	prom.Spec.CommonPrometheusFields = *prometheusFields
 }
 
-// mergeContainers copies the ResourceRequirements from the provided containers
-// to the current corev1.Containers.
+// mergeContainers copies the ResourceRequirements, environment variables and probe timings from the provided
+// containers to the current corev1.Containers.
 func mergeContainers(current []corev1.Container, provided []corev1.Container) {
 	providedMap := make(map[string]corev1.Container)
 	for _, c := range provided {
@@ -273,13 +344,89 @@ func mergeContainers(current []corev1.Container, provided []corev1.Container) {
 
 	for i, c := range current {
 		if override, ok := providedMap[c.Name]; ok {
-			current[i].Resources = override.Resources
+			if overridesResources(override) {
+				current[i].Resources = override.Resources
+			}
+			current[i].Env = mergeEnv(current[i].Env, override.Env)
+			if override.Image != "" {
+				current[i].Image = override.Image
+			}
+			mergeProbeTimings(current[i].StartupProbe, override.StartupProbe)
+			mergeProbeTimings(current[i].LivenessProbe, override.LivenessProbe)
+			mergeProbeTimings(current[i].ReadinessProbe, override.ReadinessProbe)
 		} else {
 			log.V(1).Info(fmt.Sprintf("WARNING: the container %q was provided for an override and passed CRD validation but the container does not currently exist", c.Name))
 		}
 	}
 }
 
+// removeContainers returns the containers without the named ones.
+func removeContainers(current []corev1.Container, names []string) []corev1.Container {
+	if len(names) == 0 {
+		return current
+	}
+	removed := make(map[string]bool)
+	for _, name := range names {
+		removed[name] = true
+	}
+	var result []corev1.Container
+	for _, c := range current {
+		if !removed[c.Name] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// overridesResources returns true if the resources of the override should be applied. An override that only
+// sets other fields, such as env, leaves the rendered resources untouched.
+func overridesResources(c corev1.Container) bool {
+	r := c.Resources
+	if len(r.Limits) > 0 || len(r.Requests) > 0 || len(r.Claims) > 0 {
+		return true
+	}
+	return c.Env == nil && c.Image == "" && c.StartupProbe == nil && c.LivenessProbe == nil && c.ReadinessProbe == nil
+}
+
+// mergeProbeTimings copies the non-zero timings of the provided probe to the current probe. The current probe's
+// handler is never changed, and nothing is done if the current container does not render the probe.
+func mergeProbeTimings(current, provided *corev1.Probe) {
+	if current == nil || provided == nil {
+		return
+	}
+	if provided.InitialDelaySeconds != 0 {
+		current.InitialDelaySeconds = provided.InitialDelaySeconds
+	}
+	if provided.PeriodSeconds != 0 {
+		current.PeriodSeconds = provided.PeriodSeconds
+	}
+	if provided.TimeoutSeconds != 0 {
+		current.TimeoutSeconds = provided.TimeoutSeconds
+	}
+	if provided.FailureThreshold != 0 {
+		current.FailureThreshold = provided.FailureThreshold
+	}
+}
+
+// mergeEnv returns current with the provided env vars merged in. A provided var replaces the current var
+// with the same name, otherwise it is appended.
+func mergeEnv(current []corev1.EnvVar, provided []corev1.EnvVar) []corev1.EnvVar {
+	for _, p := range provided {
+		replaced := false
+		for i, c := range current {
+			if c.Name == p.Name {
+				current[i] = p
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			current = append(current, p)
+		}
+	}
+	return current
+}
+
 // ClusterRoleBinding returns a cluster role binding with the given name, that binds the given cluster role
 // to the service account in each of the provided namespaces.
 func ClusterRoleBinding(name, clusterRole, sa string, namespaces []string) *rbacv1.ClusterRoleBinding {
@@ -23,6 +23,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/tigera/operator/test"
 )
@@ -437,6 +438,97 @@ var _ = Describe("Common components render tests", func() {
 				Expect(result.Spec.Template.Spec.InitContainers).To(ContainElements(expected.Spec.Template.Spec.InitContainers))
 				Expect(result).To(Equal(expected))
 			}),
+		Entry("init container env",
+			defaultedDaemonSet,
+			func() *v1.CalicoNodeDaemonSet {
+				existing := defaultedDaemonSet().Spec.Template.Spec.InitContainers[0].Env[0].Name
+				return &v1.CalicoNodeDaemonSet{
+					Spec: &v1.CalicoNodeDaemonSetSpec{
+						Template: &v1.CalicoNodeDaemonSetPodTemplateSpec{
+							Spec: &v1.CalicoNodeDaemonSetPodSpec{
+								InitContainers: []v1.CalicoNodeDaemonSetInitContainer{
+									{
+										Name: "not-zero1",
+										Env: []corev1.EnvVar{
+											{Name: existing, Value: "overridden"},
+											{Name: "NEW_VAR", Value: "new"},
+										},
+									},
+									{
+										Name:      "not-zero2",
+										Resources: &resources2,
+										Env:       []corev1.EnvVar{{Name: "OTHER_VAR", Value: "other"}},
+									},
+								},
+							},
+						},
+					},
+				}
+			},
+			func(result appsv1.DaemonSet) {
+				expected := defaultedDaemonSet()
+				Expect(expected.Spec.Template.Spec.InitContainers).To(HaveLen(2))
+
+				// Setting only env must leave the default resources in place.
+				env := expected.Spec.Template.Spec.InitContainers[0].Env
+				env[0] = corev1.EnvVar{Name: env[0].Name, Value: "overridden"}
+				expected.Spec.Template.Spec.InitContainers[0].Env = append(env, corev1.EnvVar{Name: "NEW_VAR", Value: "new"})
+				expected.Spec.Template.Spec.InitContainers[1].Resources = resources2
+				expected.Spec.Template.Spec.InitContainers[1].Env = append(expected.Spec.Template.Spec.InitContainers[1].Env, corev1.EnvVar{Name: "OTHER_VAR", Value: "other"})
+				Expect(result).To(Equal(expected))
+			}),
+		Entry("init container image",
+			defaultedDaemonSet,
+			func() *v1.CalicoNodeDaemonSet {
+				return &v1.CalicoNodeDaemonSet{
+					Spec: &v1.CalicoNodeDaemonSetSpec{
+						Template: &v1.CalicoNodeDaemonSetPodTemplateSpec{
+							Spec: &v1.CalicoNodeDaemonSetPodSpec{
+								InitContainers: []v1.CalicoNodeDaemonSetInitContainer{
+									{
+										Name:  "not-zero1",
+										Image: "registry.example.com/init:patched",
+									},
+								},
+							},
+						},
+					},
+				}
+			},
+			func(result appsv1.DaemonSet) {
+				expected := defaultedDaemonSet()
+				// Setting only the image must leave the default resources and env in place.
+				expected.Spec.Template.Spec.InitContainers[0].Image = "registry.example.com/init:patched"
+				Expect(result).To(Equal(expected))
+			}),
+		Entry("disabled init container",
+			defaultedDaemonSet,
+			func() *v1.CalicoNodeDaemonSet {
+				return &v1.CalicoNodeDaemonSet{
+					Spec: &v1.CalicoNodeDaemonSetSpec{
+						Template: &v1.CalicoNodeDaemonSetPodTemplateSpec{
+							Spec: &v1.CalicoNodeDaemonSetPodSpec{
+								InitContainers: []v1.CalicoNodeDaemonSetInitContainer{
+									{
+										Name:     "not-zero1",
+										Disabled: ptr.BoolToPtr(true),
+									},
+									{
+										Name:     "not-zero2",
+										Disabled: ptr.BoolToPtr(false),
+									},
+								},
+							},
+						},
+					},
+				}
+			},
+			func(result appsv1.DaemonSet) {
+				expected := defaultedDaemonSet()
+				expected.Spec.Template.Spec.InitContainers = expected.Spec.Template.Spec.InitContainers[1:]
+				Expect(result.Spec.Template.Spec.InitContainers).To(HaveLen(1))
+				Expect(result).To(Equal(expected))
+			}),
 		Entry("containers",
 			defaultedDaemonSet,
 			func() *v1.CalicoNodeDaemonSet {
@@ -554,6 +646,35 @@ var _ = Describe("Common components render tests", func() {
 				Expect(result.Spec.Template.Spec.Tolerations).To(Equal(expected.Spec.Template.Spec.Tolerations))
 				Expect(result).To(Equal(expected))
 			}),
+
+		Entry("dnsPolicy and dnsConfig",
+			defaultedDaemonSet,
+			func() *v1.CalicoNodeDaemonSet {
+				return &v1.CalicoNodeDaemonSet{
+					Spec: &v1.CalicoNodeDaemonSetSpec{
+						Template: &v1.CalicoNodeDaemonSetPodTemplateSpec{
+							Spec: &v1.CalicoNodeDaemonSetPodSpec{
+								DNSPolicy: corev1.DNSNone,
+								DNSConfig: &corev1.PodDNSConfig{
+									Nameservers: []string{"169.254.20.10"},
+									Searches:    []string{"svc.cluster.local"},
+									Options:     []corev1.PodDNSConfigOption{{Name: "ndots", Value: ptr.ToPtr("2")}},
+								},
+							},
+						},
+					},
+				}
+			},
+			func(result appsv1.DaemonSet) {
+				expected := defaultedDaemonSet()
+				expected.Spec.Template.Spec.DNSPolicy = corev1.DNSNone
+				expected.Spec.Template.Spec.DNSConfig = &corev1.PodDNSConfig{
+					Nameservers: []string{"169.254.20.10"},
+					Searches:    []string{"svc.cluster.local"},
+					Options:     []corev1.PodDNSConfigOption{{Name: "ndots", Value: ptr.ToPtr("2")}},
+				}
+				Expect(result).To(Equal(expected))
+			}),
 	)
 
 	DescribeTable("test ApplyDeploymentOverrides",
@@ -1035,6 +1156,51 @@ var _ = Describe("Common components render tests", func() {
 				Expect(*result.Spec.Template.Spec.TerminationGracePeriodSeconds).To(Equal(int64(3)))
 			}),
 
+		Entry("topologySpreadConstraints",
+			defaultedDeployment,
+			func() *v1.TyphaDeployment {
+				return &v1.TyphaDeployment{
+					Spec: &v1.TyphaDeploymentSpec{
+						Template: &v1.TyphaDeploymentPodTemplateSpec{
+							Spec: &v1.TyphaDeploymentPodSpec{
+								TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+									{
+										MaxSkew:           1,
+										TopologyKey:       "topology.kubernetes.io/zone",
+										WhenUnsatisfiable: corev1.DoNotSchedule,
+									},
+									{
+										MaxSkew:           1,
+										TopologyKey:       "kubernetes.io/hostname",
+										WhenUnsatisfiable: corev1.ScheduleAnyway,
+										LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"custom": "label"}},
+									},
+								},
+							},
+						},
+					},
+				}
+			},
+			func(result appsv1.Deployment) {
+				// The constraint without a label selector selects the pods of the deployment.
+				expected := defaultedDeployment()
+				Expect(expected.Spec.Selector.MatchLabels).NotTo(BeEmpty())
+				Expect(result.Spec.Template.Spec.TopologySpreadConstraints).To(Equal([]corev1.TopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						TopologyKey:       "topology.kubernetes.io/zone",
+						WhenUnsatisfiable: corev1.DoNotSchedule,
+						LabelSelector:     &metav1.LabelSelector{MatchLabels: expected.Spec.Selector.MatchLabels},
+					},
+					{
+						MaxSkew:           1,
+						TopologyKey:       "kubernetes.io/hostname",
+						WhenUnsatisfiable: corev1.ScheduleAnyway,
+						LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"custom": "label"}},
+					},
+				}))
+			}),
+
 		Entry("strategy",
 			defaultedDeployment,
 			func() *v1.TyphaDeployment {
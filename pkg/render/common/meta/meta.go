@@ -37,6 +37,9 @@ const (
 	// NOTE: Do not change this field since we use this value to identify
 	// certificates managed by this operator.
 	TigeraOperatorCAIssuerPrefix = "tigera-operator-signer"
+
+	// MultusNetworksAnnotation is the pod annotation that requests secondary network attachments from Multus.
+	MultusNetworksAnnotation = "k8s.v1.cni.cncf.io/networks"
 )
 
 var (
@@ -75,6 +78,29 @@ var (
 	}
 )
 
+// ControlPlaneTolerations returns the tolerations of a control plane component of the given installation: the user's
+// ControlPlaneTolerations followed by the component's defaults. Defaults for the taints of control plane nodes are
+// dropped when the control plane is hosted outside of the cluster, since there are no such nodes to run on.
+func ControlPlaneTolerations(installation *operatorv1.InstallationSpec, defaults ...corev1.Toleration) []corev1.Toleration {
+	tolerations := append([]corev1.Toleration{}, installation.ControlPlaneTolerations...)
+	for _, t := range defaults {
+		if installation.HostedControlPlane() && isControlPlaneToleration(t) {
+			continue
+		}
+		tolerations = append(tolerations, t)
+	}
+	return tolerations
+}
+
+func isControlPlaneToleration(t corev1.Toleration) bool {
+	for _, cp := range TolerateControlPlane {
+		if t == cp {
+			return true
+		}
+	}
+	return false
+}
+
 func DefaultOperatorCASignerName() string {
 	return fmt.Sprintf("%s@%d", TigeraOperatorCAIssuerPrefix, time.Now().Unix())
 }
@@ -122,3 +148,16 @@ func GetResourceRequirements(i *operatorv1.InstallationSpec, name operatorv1.Com
 	}
 	return corev1.ResourceRequirements{}
 }
+
+// AddNetworkAttachments sets the Multus network attachment annotation configured for the given workload, if any, on the
+// pod annotations.
+func AddNetworkAttachments(annotations map[string]string, installation *operatorv1.InstallationSpec, workload operatorv1.MultusWorkload) {
+	if installation.CalicoNetwork == nil || installation.CalicoNetwork.MultusCompatibility == nil {
+		return
+	}
+	for _, na := range installation.CalicoNetwork.MultusCompatibility.NetworkAttachments {
+		if na.Workload == workload {
+			annotations[MultusNetworksAnnotation] = na.Networks
+		}
+	}
+}
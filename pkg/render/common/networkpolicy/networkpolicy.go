@@ -16,12 +16,16 @@ package networkpolicy
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
 	"github.com/tigera/api/pkg/lib/numorstring"
+
+	"github.com/tigera/operator/pkg/render/common/portregistry"
 )
 
 const (
@@ -30,6 +34,8 @@ const (
 	TigeraComponentDefaultDenyPolicyName = TigeraComponentPolicyPrefix + "default-deny"
 )
 
+const guardianName = "tigera-guardian"
+
 var (
 	TCPProtocol               = numorstring.ProtocolFromString(numorstring.ProtocolTCP)
 	UDPProtocol               = numorstring.ProtocolFromString(numorstring.ProtocolUDP)
@@ -160,6 +166,17 @@ func Ports(ports ...uint16) []numorstring.Port {
 	return nsPorts
 }
 
+// ObjectStoragePort returns the port of an object storage endpoint, which is 443 unless the endpoint specifies one.
+// The endpoint must have been validated.
+func ObjectStoragePort(endpoint string) uint16 {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Port() == "" {
+		return 443
+	}
+	port, _ := strconv.ParseUint(u.Port(), 10, 16)
+	return uint16(port)
+}
+
 func AllowTigeraDefaultDeny(namespace string) *v3.NetworkPolicy {
 	return &v3.NetworkPolicy{
 		TypeMeta: metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "projectcalico.org/v3"},
@@ -223,7 +240,7 @@ func (h *NetworkPolicyHelper) namespace(def string) string {
 
 // ESGatewayEntityRule returns an entity rule that selects es-gateway pods in the given namespace.
 func (h *NetworkPolicyHelper) ESGatewayEntityRule() v3.EntityRule {
-	return CreateEntityRule(h.namespace("tigera-elasticsearch"), "tigera-secure-es-gateway", 5554)
+	return CreateEntityRule(h.namespace("tigera-elasticsearch"), "tigera-secure-es-gateway", uint16(portregistry.ESGateway))
 }
 
 func (h *NetworkPolicyHelper) ESGatewaySourceEntityRule() v3.EntityRule {
@@ -235,13 +252,63 @@ func (h *NetworkPolicyHelper) ESGatewayServiceSelectorEntityRule() v3.EntityRule
 }
 
 func (h *NetworkPolicyHelper) LinseedEntityRule() v3.EntityRule {
-	return CreateEntityRule(h.namespace("tigera-elasticsearch"), "tigera-linseed", 8444)
+	return CreateEntityRule(h.namespace("tigera-elasticsearch"), "tigera-linseed", uint16(portregistry.Linseed))
 }
 
 func (h *NetworkPolicyHelper) LinseedSourceEntityRule() v3.EntityRule {
 	return CreateSourceEntityRule(h.namespace("tigera-elasticsearch"), "tigera-linseed")
 }
 
+// DataStoreConfig describes how a component reaches the log datastore.
+type DataStoreConfig struct {
+	// ManagedCluster is true if the component runs in a managed cluster, where it reaches the datastore of the
+	// management cluster through guardian.
+	ManagedCluster bool
+
+	// ESGateway is true if the component talks to the Elasticsearch gateway in addition to Linseed. It has no effect
+	// in managed clusters.
+	ESGateway bool
+}
+
+// DataStoreEgressRules returns the rules that allow egress to the datastore endpoints that the given config uses.
+func (h *NetworkPolicyHelper) DataStoreEgressRules(cfg DataStoreConfig) []v3.Rule {
+	var rules []v3.Rule
+	for _, dest := range h.dataStoreEndpoints(cfg) {
+		rules = append(rules, v3.Rule{
+			Action:      v3.Allow,
+			Protocol:    &TCPProtocol,
+			Destination: dest,
+		})
+	}
+	return rules
+}
+
+// DataStoreDenyOtherPortsRules returns the rules that deny egress to the datastore endpoints that the given config
+// uses on any port but the one they serve on. It is meant for policies that go on to allow all other egress.
+func (h *NetworkPolicyHelper) DataStoreDenyOtherPortsRules(cfg DataStoreConfig) []v3.Rule {
+	var rules []v3.Rule
+	for _, dest := range h.dataStoreEndpoints(cfg) {
+		dest.NotPorts, dest.Ports = dest.Ports, nil
+		rules = append(rules, v3.Rule{
+			Action:      v3.Deny,
+			Protocol:    &TCPProtocol,
+			Destination: dest,
+		})
+	}
+	return rules
+}
+
+func (h *NetworkPolicyHelper) dataStoreEndpoints(cfg DataStoreConfig) []v3.EntityRule {
+	if cfg.ManagedCluster {
+		return []v3.EntityRule{CreateEntityRule(guardianName, guardianName, uint16(portregistry.Guardian))}
+	}
+	var endpoints []v3.EntityRule
+	if cfg.ESGateway {
+		endpoints = append(endpoints, h.ESGatewayEntityRule())
+	}
+	return append(endpoints, h.LinseedEntityRule())
+}
+
 func (h *NetworkPolicyHelper) DashboardInstallerEntityRule() v3.EntityRule {
 	return CreateEntityRule(h.namespace("tigera-elasticsearch"), "dashboards-installer")
 }
@@ -255,7 +322,7 @@ func (h *NetworkPolicyHelper) LinseedServiceSelectorEntityRule() v3.EntityRule {
 }
 
 func (h *NetworkPolicyHelper) ManagerEntityRule() v3.EntityRule {
-	return CreateEntityRule(h.namespace("tigera-manager"), "tigera-manager", 9443)
+	return CreateEntityRule(h.namespace("tigera-manager"), "tigera-manager", uint16(portregistry.Manager))
 }
 
 func (h *NetworkPolicyHelper) ManagerSourceEntityRule() v3.EntityRule {
@@ -267,7 +334,7 @@ func (h *NetworkPolicyHelper) PolicyRecommendationSourceEntityRule() v3.EntityRu
 }
 
 func (h *NetworkPolicyHelper) ComplianceServerEntityRule() v3.EntityRule {
-	return CreateEntityRule(h.namespace("tigera-compliance"), "compliance-server", 5443)
+	return CreateEntityRule(h.namespace("tigera-compliance"), "compliance-server", uint16(portregistry.ComplianceServer))
 }
 
 func (h *NetworkPolicyHelper) ComplianceServerSourceEntityRule() v3.EntityRule {
@@ -294,6 +361,14 @@ func (h *NetworkPolicyHelper) IntrusionDetectionSourceEntityRule() v3.EntityRule
 	return CreateSourceEntityRule(h.namespace("tigera-intrusion-detection"), "intrusion-detection-controller")
 }
 
+func (h *NetworkPolicyHelper) ComplianceSIEMExportSourceEntityRule() v3.EntityRule {
+	return CreateSourceEntityRule(h.namespace("tigera-compliance"), "tigera-siem-export")
+}
+
+func (h *NetworkPolicyHelper) IntrusionDetectionSIEMExportSourceEntityRule() v3.EntityRule {
+	return CreateSourceEntityRule(h.namespace("tigera-intrusion-detection"), "tigera-siem-export")
+}
+
 const PrometheusSelector = "k8s-app == 'tigera-prometheus'"
 
 var PrometheusEntityRule = v3.EntityRule{
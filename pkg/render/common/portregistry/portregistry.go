@@ -0,0 +1,57 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package portregistry holds the ports that the components serve on. The Services, container probes and network
+// policies of a component read its ports from here, so that changing a port is a single edit.
+package portregistry
+
+import (
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Port is a TCP port that a component serves on.
+type Port uint16
+
+// The ports that the pods of the components listen on.
+const (
+	ComplianceServer Port = 5443
+	ComplianceHealth Port = 9099
+	ESGateway        Port = 5554
+	Linseed          Port = 8444
+	Guardian         Port = 8080
+	Manager          Port = 9443
+)
+
+// The ports of the Services in front of the components, where they differ from the ports of their pods.
+const (
+	ComplianceServerService Port = 443
+	LinseedService          Port = 443
+)
+
+// Int32 returns the port as used by Service and container ports.
+func (p Port) Int32() int32 {
+	return int32(p)
+}
+
+// IntOrString returns the port as used by Service target ports and probes.
+func (p Port) IntOrString() intstr.IntOrString {
+	return intstr.FromInt(int(p))
+}
+
+// String returns the port as used in env vars and URLs.
+func (p Port) String() string {
+	return strconv.Itoa(int(p))
+}
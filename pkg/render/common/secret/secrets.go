@@ -30,6 +30,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// ReplicatedFromAnnotation is set on secrets created by CopyToNamespace and records the namespace and name of the
+	// secret that the copy was made from, in the form <namespace>/<name>.
+	ReplicatedFromAnnotation = "operator.tigera.io/replicated-from"
+
+	// ReplicatedByLabel is set by the component handler on copied secrets and identifies the component that renders
+	// them, so that copies that are no longer rendered can be garbage collected.
+	ReplicatedByLabel = "operator.tigera.io/replicated-by"
+)
+
 // CreateTLSSecret Creates a new TLS secret with the information passed
 //
 //	ca: The ca to use for creating the Cert/Key pair. This is required.
@@ -101,12 +111,15 @@ func getSecretFromTLSConfig(
 }
 
 // CopyToNamespace returns a new list of secrets generated from the ones given but with the namespace changed to the
-// given one.
+// given one. Copies of secrets from another namespace are annotated with the secret they were copied from.
 func CopyToNamespace(ns string, oSecrets ...*corev1.Secret) []*corev1.Secret {
 	var secrets []*corev1.Secret
 	for _, s := range oSecrets {
 		x := s.DeepCopy()
 		x.ObjectMeta = metav1.ObjectMeta{Name: s.Name, Namespace: ns}
+		if s.Namespace != "" && s.Namespace != ns {
+			x.Annotations = map[string]string{ReplicatedFromAnnotation: fmt.Sprintf("%s/%s", s.Namespace, s.Name)}
+		}
 
 		secrets = append(secrets, x)
 	}
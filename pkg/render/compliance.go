@@ -17,15 +17,20 @@ package render
 import (
 	"crypto/x509"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apiserver/pkg/authentication/serviceaccount"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
 
@@ -36,6 +41,8 @@ import (
 	"github.com/tigera/operator/pkg/render/common/configmap"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/common/podaffinity"
+	"github.com/tigera/operator/pkg/render/common/portregistry"
 	"github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/render/common/securitycontext"
 	"github.com/tigera/operator/pkg/render/common/securitycontextconstraints"
@@ -71,6 +78,35 @@ const (
 	ComplianceBenchmarkerSecret = "tigera-compliance-benchmarker-tls"
 	ComplianceControllerSecret  = "tigera-compliance-controller-tls"
 	ComplianceReporterSecret    = "tigera-compliance-reporter-tls"
+
+	// ComplianceBenchmarkerTrustedBundle is the ConfigMap of the trusted bundle that only the benchmarker mounts.
+	ComplianceBenchmarkerTrustedBundle = "tigera-ca-bundle-compliance-benchmarker"
+
+	// ComplianceReportSigningKeySecret holds the private key that the compliance server signs downloaded reports with,
+	// under ComplianceReportSigningKeyKey. The operator generates it in the operator namespace, unless the Compliance
+	// references a secret with a user provided key, and copies it into the compliance namespace.
+	ComplianceReportSigningKeySecret = "tigera-compliance-report-signing-key"
+	ComplianceReportSigningKeyKey    = "signing.key"
+
+	complianceReportSigningKeyMountPath      = "/etc/compliance/signing"
+	complianceReportSigningKeyHashAnnotation = "hash.operator.tigera.io/report-signing-key"
+
+	// ComplianceReportArchiveCredentialsSecret is the copy, in the compliance namespace, of the credentials of the bucket
+	// that reports are archived to. It holds the ComplianceReportArchiveAccessKeyIDKey and
+	// ComplianceReportArchiveSecretKeyKey keys for S3, and the ComplianceReportArchiveGCSKeyKey key for GCS.
+	ComplianceReportArchiveCredentialsSecret = "tigera-compliance-report-archive-credentials"
+	ComplianceReportArchiveAccessKeyIDKey    = "access-key-id"
+	ComplianceReportArchiveSecretKeyKey      = "secret-access-key"
+	ComplianceReportArchiveGCSKeyKey         = "key.json"
+	ComplianceReportArchivePolicyName        = networkpolicy.TigeraComponentPolicyPrefix + "compliance-report-archive"
+
+	complianceReportArchiveCredentialsMountPath      = "/etc/compliance/archive"
+	complianceReportArchiveCredentialsHashAnnotation = "hash.operator.tigera.io/report-archive-credentials"
+
+	// ComplianceReportTemplatesConfigMap is the ConfigMap in the operator namespace that users can create to change the
+	// templates of the GlobalReportTypes and to add their own types. Each key is the name of a GlobalReportType and its
+	// value is the YAML of a ReportTypeSpec. The operator only reads it, so edits to it are kept.
+	ComplianceReportTemplatesConfigMap = "tigera-compliance-report-templates"
 )
 
 // Register secret/certs that need Server and Client Key usage
@@ -82,9 +118,47 @@ func init() {
 }
 
 func Compliance(cfg *ComplianceConfiguration) (Component, error) {
-	return &complianceComponent{
+	c := &complianceComponent{
 		cfg: cfg,
-	}, nil
+	}
+	if cfg.Compliance != nil && cfg.Compliance.Spec.SnapshotScope != nil {
+		var err error
+		if c.snapshotEnv, err = ComplianceSnapshotScopeEnv(cfg.Compliance.Spec.SnapshotScope); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// ComplianceSnapshotScopeEnv returns the env vars that limit the resources that the snapshotter records to the given
+// scope.
+func ComplianceSnapshotScopeEnv(scope *operatorv1.SnapshotScope) ([]corev1.EnvVar, error) {
+	var env []corev1.EnvVar
+	selectors := []struct {
+		name     string
+		selector *metav1.LabelSelector
+	}{
+		{"TIGERA_COMPLIANCE_SNAPSHOT_NAMESPACE_SELECTOR", scope.NamespaceSelector},
+		{"TIGERA_COMPLIANCE_SNAPSHOT_EXCLUDE_NAMESPACE_SELECTOR", scope.ExcludeNamespaceSelector},
+	}
+	for _, s := range selectors {
+		if s.selector == nil {
+			continue
+		}
+		sel, err := metav1.LabelSelectorAsSelector(s.selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid snapshot namespace selector: %w", err)
+		}
+		env = append(env, corev1.EnvVar{Name: s.name, Value: sel.String()})
+	}
+	if len(scope.Resources) > 0 {
+		resources := make([]string, len(scope.Resources))
+		for i, r := range scope.Resources {
+			resources[i] = string(r)
+		}
+		env = append(env, corev1.EnvVar{Name: "TIGERA_COMPLIANCE_SNAPSHOT_RESOURCES", Value: strings.Join(resources, ",")})
+	}
+	return env, nil
 }
 
 // ComplianceConfiguration contains all the config information needed to render the component.
@@ -101,6 +175,11 @@ type ComplianceConfiguration struct {
 	// Trusted certificate bundle for all compliance pods.
 	TrustedBundle certificatemanagement.TrustedBundleRO
 
+	// BenchmarkerTrustedBundle is the bundle that the benchmarker mounts instead of the TrustedBundle, with only the
+	// certificates that it needs to reach Linseed. The benchmarker runs on every node, so it shouldn't be restarted for
+	// changes to certificates that it doesn't use.
+	BenchmarkerTrustedBundle certificatemanagement.TrustedBundleRO
+
 	// Key pairs used for mTLS.
 	ServerKeyPair      certificatemanagement.KeyPairInterface
 	BenchmarkerKeyPair certificatemanagement.KeyPairInterface
@@ -114,6 +193,17 @@ type ComplianceConfiguration struct {
 	Tenant          *operatorv1.Tenant
 	ExternalElastic bool
 	Compliance      *operatorv1.Compliance
+
+	// ReportSigningKey is the secret that holds the key that the compliance server signs downloaded reports with, or
+	// nil if report signing isn't enabled.
+	ReportSigningKey *corev1.Secret
+
+	// ReportTemplates are the report types read from the ComplianceReportTemplatesConfigMap, by name.
+	ReportTemplates map[string]v3.ReportTypeSpec
+
+	// ReportArchiveCredentials is the secret that holds the credentials of the bucket that reports are archived to, or
+	// nil if reports aren't archived.
+	ReportArchiveCredentials *corev1.Secret
 }
 
 type complianceComponent struct {
@@ -123,6 +213,9 @@ type complianceComponent struct {
 	serverImage      string
 	controllerImage  string
 	reporterImage    string
+
+	// snapshotEnv holds the env vars that limit the scope of the snapshots.
+	snapshotEnv []corev1.EnvVar
 }
 
 func (c *complianceComponent) ResolveImages(is *operatorv1.ImageSet) error {
@@ -167,6 +260,10 @@ func (c *complianceComponent) SupportedOSType() rmeta.OSType {
 	return rmeta.OSTypeLinux
 }
 
+func (c *complianceComponent) ReplicatedSecretsKey() string {
+	return "compliance"
+}
+
 func (c *complianceComponent) Objects() ([]client.Object, []client.Object) {
 	var complianceObjs []client.Object
 	if c.cfg.Tenant.MultiTenant() {
@@ -222,12 +319,9 @@ func (c *complianceComponent) Objects() ([]client.Object, []client.Object) {
 			c.complianceBenchmarkerClusterRole(),
 			c.complianceBenchmarkerClusterRoleBinding(),
 			c.complianceBenchmarkerDaemonSet(),
-
-			c.complianceGlobalReportInventory(),
-			c.complianceGlobalReportNetworkAccess(),
-			c.complianceGlobalReportPolicyAudit(),
-			c.complianceGlobalReportCISBenchmark(),
-
+		)
+		complianceObjs = append(complianceObjs, c.complianceGlobalReportTypes()...)
+		complianceObjs = append(complianceObjs,
 			// We always need a sa and crb, whether a deployment of compliance-server is present or not.
 			// These two are used for rbac checks for managed clusters.
 			c.complianceServerServiceAccount(),
@@ -247,10 +341,39 @@ func (c *complianceComponent) Objects() ([]client.Object, []client.Object) {
 			c.complianceServerClusterRole(),
 			c.complianceServerService(),
 			c.complianceServerDeployment(),
+			c.complianceServerPodDisruptionBudget(),
 		)
+		if c.cfg.ReportSigningKey != nil {
+			complianceObjs = append(complianceObjs, c.complianceReportSigningKeySecret())
+		} else {
+			objsToDelete = append(objsToDelete, &corev1.Secret{
+				TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: ComplianceReportSigningKeySecret, Namespace: c.cfg.Namespace},
+			})
+		}
+		if c.cfg.ReportArchiveCredentials != nil {
+			complianceObjs = append(complianceObjs,
+				c.complianceReportArchiveCredentialsSecret(),
+				c.complianceReportArchiveAllowTigeraNetworkPolicy(),
+			)
+		} else {
+			objsToDelete = append(objsToDelete,
+				&corev1.Secret{
+					TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+					ObjectMeta: metav1.ObjectMeta{Name: ComplianceReportArchiveCredentialsSecret, Namespace: c.cfg.Namespace},
+				},
+				&v3.NetworkPolicy{
+					TypeMeta:   metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "projectcalico.org/v3"},
+					ObjectMeta: metav1.ObjectMeta{Name: ComplianceReportArchivePolicyName, Namespace: c.cfg.Namespace},
+				},
+			)
+		}
 	} else {
 		// Compliance server is only for Standalone or Management clusters
-		objsToDelete = append(objsToDelete, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: ComplianceServerName, Namespace: c.cfg.Namespace}})
+		objsToDelete = append(objsToDelete,
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: ComplianceServerName, Namespace: c.cfg.Namespace}},
+			&policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: ComplianceServerName, Namespace: c.cfg.Namespace}},
+		)
 		complianceObjs = append(complianceObjs,
 			c.complianceServerManagedClusterRole(),
 			c.externalLinseedRoleBinding(),
@@ -276,9 +399,125 @@ func (c *complianceComponent) Ready() bool {
 
 var (
 	complianceReplicas int32 = 1
+
+	// ComplianceBenchmarkControlPlaneSections are the sections of the CIS benchmark that cover the control plane
+	// components, etcd and the control plane configuration.
+	ComplianceBenchmarkControlPlaneSections = []string{"1", "2", "3"}
 )
 
-const complianceServerPort = 5443
+const complianceServerReadinessPath = "/compliance/readiness"
+
+// The variants of the CIS benchmark for the managed Kubernetes services, which cover the nodes of the service with
+// its own host paths and configuration.
+const (
+	ComplianceBenchmarkVersionAKS = "aks-1.0"
+	ComplianceBenchmarkVersionEKS = "eks-1.2.0"
+	ComplianceBenchmarkVersionGKE = "gke-1.4.0"
+)
+
+// priorityClassName returns the PriorityClass of the compliance pods, if one is configured.
+func (c *complianceComponent) priorityClassName() string {
+	if c.cfg.Compliance == nil {
+		return ""
+	}
+	return c.cfg.Compliance.Spec.PriorityClassName
+}
+
+// logging returns the logging configuration of the compliance components, which is empty if none is configured.
+func (c *complianceComponent) logging() *operatorv1.ComplianceLogging {
+	if c.cfg.Compliance == nil || c.cfg.Compliance.Spec.Logging == nil {
+		return &operatorv1.ComplianceLogging{}
+	}
+	return c.cfg.Compliance.Spec.Logging
+}
+
+// logLevel returns the LOG_LEVEL of a compliance component, given the level configured for it: that level if it is
+// set, otherwise the level of all components, otherwise info.
+func (c *complianceComponent) logLevel(level *operatorv1.LogLevel) string {
+	if level == nil {
+		level = c.logging().LogSeverity
+	}
+	if level == nil {
+		return "info"
+	}
+	return strings.ToLower(string(*level))
+}
+
+// timeZoneEnv returns the TZ env var of the components that schedule and generate reports, so that the snapshot
+// hour and the report schedules are interpreted in the configured time zone.
+func (c *complianceComponent) timeZoneEnv() []corev1.EnvVar {
+	if c.cfg.Compliance == nil || c.cfg.Compliance.Spec.TimeZone == "" {
+		return nil
+	}
+	return []corev1.EnvVar{{Name: "TZ", Value: c.cfg.Compliance.Spec.TimeZone}}
+}
+
+// reportRetention returns the retention of reports, if one is configured.
+func (c *complianceComponent) reportRetention() *operatorv1.ReportRetention {
+	if c.cfg.Compliance == nil {
+		return nil
+	}
+	return c.cfg.Compliance.Spec.ReportRetention
+}
+
+// reportRetentionEnv returns the env vars, volume mounts and volumes that configure the reporter and the server with
+// the retention of reports and the bucket that they are archived to.
+func (c *complianceComponent) reportRetentionEnv() ([]corev1.EnvVar, []corev1.VolumeMount, []corev1.Volume) {
+	retention := c.reportRetention()
+	if retention == nil {
+		return nil, nil, nil
+	}
+	var env []corev1.EnvVar
+	if retention.Duration != nil {
+		env = append(env, corev1.EnvVar{Name: "TIGERA_COMPLIANCE_REPORT_RETENTION", Value: retention.Duration.Duration.String()})
+	}
+	archive := retention.Archive
+	if archive == nil || c.cfg.ReportArchiveCredentials == nil {
+		return env, nil, nil
+	}
+	env = append(env,
+		corev1.EnvVar{Name: "TIGERA_COMPLIANCE_REPORT_ARCHIVE_PROVIDER", Value: string(archive.Provider)},
+		corev1.EnvVar{Name: "TIGERA_COMPLIANCE_REPORT_ARCHIVE_BUCKET", Value: archive.Bucket},
+		corev1.EnvVar{Name: "TIGERA_COMPLIANCE_REPORT_ARCHIVE_PREFIX", Value: archive.Prefix},
+	)
+
+	if archive.Provider == operatorv1.ReportArchiveProviderGCS {
+		// The GCS client reads the key of the service account from a file.
+		env = append(env, corev1.EnvVar{
+			Name:  "GOOGLE_APPLICATION_CREDENTIALS",
+			Value: fmt.Sprintf("%s/%s", complianceReportArchiveCredentialsMountPath, ComplianceReportArchiveGCSKeyKey),
+		})
+		mounts := []corev1.VolumeMount{{
+			Name:      ComplianceReportArchiveCredentialsSecret,
+			MountPath: complianceReportArchiveCredentialsMountPath,
+			ReadOnly:  true,
+		}}
+		volumes := []corev1.Volume{{
+			Name: ComplianceReportArchiveCredentialsSecret,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: ComplianceReportArchiveCredentialsSecret,
+					Items:      []corev1.KeyToPath{{Key: ComplianceReportArchiveGCSKeyKey, Path: ComplianceReportArchiveGCSKeyKey}},
+				},
+			},
+		}}
+		return env, mounts, volumes
+	}
+
+	credentialRef := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: ComplianceReportArchiveCredentialsSecret},
+			Key:                  key,
+		}}
+	}
+	env = append(env,
+		corev1.EnvVar{Name: "TIGERA_COMPLIANCE_REPORT_ARCHIVE_REGION", Value: archive.Region},
+		corev1.EnvVar{Name: "TIGERA_COMPLIANCE_REPORT_ARCHIVE_ENDPOINT", Value: archive.Endpoint},
+		corev1.EnvVar{Name: "AWS_ACCESS_KEY_ID", ValueFrom: credentialRef(ComplianceReportArchiveAccessKeyIDKey)},
+		corev1.EnvVar{Name: "AWS_SECRET_ACCESS_KEY", ValueFrom: credentialRef(ComplianceReportArchiveSecretKeyKey)},
+	)
+	return env, nil, nil
+}
 
 func (c *complianceComponent) complianceControllerServiceAccount() *corev1.ServiceAccount {
 	return &corev1.ServiceAccount{
@@ -445,7 +684,7 @@ func (c *complianceComponent) complianceControllerDeployment() *appsv1.Deploymen
 	}
 
 	envVars := []corev1.EnvVar{
-		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "LOG_LEVEL", Value: c.logLevel(c.logging().Controller)},
 		{Name: "TIGERA_COMPLIANCE_JOB_NAMESPACE", Value: c.cfg.Namespace},
 		{Name: "TIGERA_COMPLIANCE_MAX_FAILED_JOBS_HISTORY", Value: "3"},
 		{Name: "TIGERA_COMPLIANCE_MAX_JOB_RETRIES", Value: "6"},
@@ -453,6 +692,7 @@ func (c *complianceComponent) complianceControllerDeployment() *appsv1.Deploymen
 		{Name: "LINSEED_CLIENT_KEY", Value: keyPath},
 		{Name: "LINSEED_TOKEN", Value: GetLinseedTokenPath(c.cfg.ManagementClusterConnection != nil)},
 	}
+	envVars = append(envVars, c.timeZoneEnv()...)
 	if c.cfg.Tenant != nil {
 		// Configure the tenant id in order to read /write linseed data using the correct tenant ID
 		// Multi-tenant and single tenant with external elastic needs this variable set
@@ -472,7 +712,8 @@ func (c *complianceComponent) complianceControllerDeployment() *appsv1.Deploymen
 		},
 		Spec: corev1.PodSpec{
 			ServiceAccountName: ComplianceControllerServiceAccount,
-			Tolerations:        append(c.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateControlPlane...),
+			PriorityClassName:  c.priorityClassName(),
+			Tolerations:        rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.TolerateControlPlane...),
 			NodeSelector:       c.cfg.Installation.ControlPlaneNodeSelector,
 			ImagePullSecrets:   secret.GetReferenceList(c.cfg.PullSecrets),
 			InitContainers:     initContainers,
@@ -486,7 +727,7 @@ func (c *complianceComponent) complianceControllerDeployment() *appsv1.Deploymen
 						ProbeHandler: corev1.ProbeHandler{
 							HTTPGet: &corev1.HTTPGetAction{
 								Path: "/liveness",
-								Port: intstr.FromInt(9099),
+								Port: portregistry.ComplianceHealth.IntOrString(),
 							},
 						},
 					},
@@ -612,12 +853,15 @@ func (c *complianceComponent) complianceReporterPodTemplate() *corev1.PodTemplat
 	dirOrCreate := corev1.HostPathDirectoryOrCreate
 
 	envVars := []corev1.EnvVar{
-		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "LOG_LEVEL", Value: c.logLevel(c.logging().Reporter)},
 		{Name: "TIGERA_COMPLIANCE_JOB_NAMESPACE", Value: c.cfg.Namespace},
 		{Name: "LINSEED_CLIENT_CERT", Value: certPath},
 		{Name: "LINSEED_CLIENT_KEY", Value: keyPath},
 		{Name: "LINSEED_TOKEN", Value: GetLinseedTokenPath(c.cfg.ManagementClusterConnection != nil)},
 	}
+	envVars = append(envVars, c.timeZoneEnv()...)
+	retentionEnv, retentionMounts, retentionVolumes := c.reportRetentionEnv()
+	envVars = append(envVars, retentionEnv...)
 	if c.cfg.Tenant != nil {
 		// Configure the tenant id in order to read /write linseed data using the correct tenant ID
 		// Multi-tenant and single tenant with external elastic needs this variable set
@@ -644,6 +888,8 @@ func (c *complianceComponent) complianceReporterPodTemplate() *corev1.PodTemplat
 		c.cfg.ReporterKeyPair.VolumeMount(c.SupportedOSType()),
 		corev1.VolumeMount{MountPath: "/var/log/calico", Name: "var-log-calico"},
 	)
+	volumes = append(volumes, retentionVolumes...)
+	volumeMounts = append(volumeMounts, retentionMounts...)
 
 	if c.cfg.ManagementClusterConnection != nil {
 		// For managed clusters, we need to mount the token for Linseed access.
@@ -687,7 +933,8 @@ func (c *complianceComponent) complianceReporterPodTemplate() *corev1.PodTemplat
 			},
 			Spec: corev1.PodSpec{
 				ServiceAccountName: ComplianceReporterServiceAccount,
-				Tolerations:        append(c.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateControlPlane...),
+				PriorityClassName:  c.priorityClassName(),
+				Tolerations:        rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.TolerateControlPlane...),
 				NodeSelector:       c.cfg.Installation.ControlPlaneNodeSelector,
 				ImagePullSecrets:   secret.GetReferenceList(c.cfg.PullSecrets),
 				InitContainers:     initContainers,
@@ -701,7 +948,7 @@ func (c *complianceComponent) complianceReporterPodTemplate() *corev1.PodTemplat
 							ProbeHandler: corev1.ProbeHandler{
 								HTTPGet: &corev1.HTTPGetAction{
 									Path: "/liveness",
-									Port: intstr.FromInt(9099),
+									Port: portregistry.ComplianceHealth.IntOrString(),
 								},
 							},
 							PeriodSeconds:  300,
@@ -862,21 +1109,27 @@ func (c *complianceComponent) complianceServerClusterRoleBinding() *rbacv1.Clust
 }
 
 func (c *complianceComponent) complianceServerService() *corev1.Service {
-	return &corev1.Service{
+	s := &corev1.Service{
 		TypeMeta:   metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
 		ObjectMeta: metav1.ObjectMeta{Name: "compliance", Namespace: c.cfg.Namespace},
 		Spec: corev1.ServiceSpec{
 			Ports: []corev1.ServicePort{
 				{
 					Name:       "compliance-api",
-					Port:       443,
+					Port:       portregistry.ComplianceServerService.Int32(),
 					Protocol:   corev1.ProtocolTCP,
-					TargetPort: intstr.FromInt(complianceServerPort),
+					TargetPort: portregistry.ComplianceServer.IntOrString(),
 				},
 			},
 			Selector: map[string]string{"k8s-app": ComplianceServerName},
 		},
 	}
+
+	if c.cfg.Compliance != nil {
+		rcomponents.ApplyServiceOptions(s, c.cfg.Compliance.Spec.ComplianceServerService)
+	}
+
+	return s
 }
 
 func (c *complianceComponent) complianceServerDeployment() *appsv1.Deployment {
@@ -887,7 +1140,7 @@ func (c *complianceComponent) complianceServerDeployment() *appsv1.Deployment {
 	}
 
 	envVars := []corev1.EnvVar{
-		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "LOG_LEVEL", Value: c.logLevel(c.logging().Server)},
 		{Name: "TIGERA_COMPLIANCE_JOB_NAMESPACE", Value: c.cfg.Namespace},
 		{Name: "MULTI_CLUSTER_FORWARDING_CA", Value: certificatemanagement.TrustedCertBundleMountPath},
 		{Name: "FIPS_MODE_ENABLED", Value: operatorv1.IsFIPSModeEnabledString(c.cfg.Installation.FIPSMode)},
@@ -895,6 +1148,18 @@ func (c *complianceComponent) complianceServerDeployment() *appsv1.Deployment {
 		{Name: "LINSEED_CLIENT_KEY", Value: keyPath},
 		{Name: "LINSEED_TOKEN", Value: GetLinseedTokenPath(c.cfg.ManagementClusterConnection != nil)},
 	}
+	if c.cfg.Compliance != nil && c.cfg.Compliance.Spec.ComplianceServerQueryLimits != nil {
+		limits := c.cfg.Compliance.Spec.ComplianceServerQueryLimits
+		if limits.PageSize != nil {
+			envVars = append(envVars, corev1.EnvVar{Name: "TIGERA_COMPLIANCE_REPORT_PAGE_SIZE", Value: strconv.Itoa(int(*limits.PageSize))})
+		}
+		if limits.QueryTimeout != nil {
+			envVars = append(envVars, corev1.EnvVar{Name: "TIGERA_COMPLIANCE_QUERY_TIMEOUT", Value: limits.QueryTimeout.Duration.String()})
+		}
+		if limits.MaxConcurrentDownloads != nil {
+			envVars = append(envVars, corev1.EnvVar{Name: "TIGERA_COMPLIANCE_MAX_CONCURRENT_DOWNLOADS", Value: strconv.Itoa(int(*limits.MaxConcurrentDownloads))})
+		}
+	}
 	if c.cfg.Tenant != nil {
 		// Configure the tenant id in order to read /write linseed data using the correct tenant ID
 		// Multi-tenant and single tenant with external elastic needs this variable set
@@ -916,15 +1181,51 @@ func (c *complianceComponent) complianceServerDeployment() *appsv1.Deployment {
 		initContainers = append(initContainers, c.cfg.ServerKeyPair.InitContainer(c.cfg.Namespace))
 	}
 
+	volumeMounts := append(
+		c.cfg.TrustedBundle.VolumeMounts(c.SupportedOSType()),
+		c.cfg.ServerKeyPair.VolumeMount(c.SupportedOSType()),
+	)
+	volumes := []corev1.Volume{
+		c.cfg.ServerKeyPair.Volume(),
+		c.cfg.TrustedBundle.Volume(),
+	}
+	annotations := complianceAnnotations(c)
+	retentionEnv, retentionMounts, retentionVolumes := c.reportRetentionEnv()
+	envVars = append(envVars, retentionEnv...)
+	volumeMounts = append(volumeMounts, retentionMounts...)
+	volumes = append(volumes, retentionVolumes...)
+	if c.cfg.ReportArchiveCredentials != nil {
+		annotations[complianceReportArchiveCredentialsHashAnnotation] = rmeta.AnnotationHash(c.cfg.ReportArchiveCredentials.Data)
+	}
+	if c.cfg.ReportSigningKey != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "TIGERA_COMPLIANCE_REPORT_SIGNING_KEY",
+			Value: fmt.Sprintf("%s/%s", complianceReportSigningKeyMountPath, ComplianceReportSigningKeyKey),
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      ComplianceReportSigningKeySecret,
+			MountPath: complianceReportSigningKeyMountPath,
+			ReadOnly:  true,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: ComplianceReportSigningKeySecret,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: ComplianceReportSigningKeySecret},
+			},
+		})
+		annotations[complianceReportSigningKeyHashAnnotation] = rmeta.AnnotationHash(c.cfg.ReportSigningKey.Data)
+	}
+
 	podTemplate := &corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        ComplianceServerName,
 			Namespace:   c.cfg.Namespace,
-			Annotations: complianceAnnotations(c),
+			Annotations: annotations,
 		},
 		Spec: corev1.PodSpec{
 			ServiceAccountName: ComplianceServerServiceAccount,
-			Tolerations:        append(c.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateControlPlane...),
+			PriorityClassName:  c.priorityClassName(),
+			Tolerations:        rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.TolerateControlPlane...),
 			NodeSelector:       c.cfg.Installation.ControlPlaneNodeSelector,
 			ImagePullSecrets:   secret.GetReferenceList(c.cfg.PullSecrets),
 			InitContainers:     initContainers,
@@ -938,42 +1239,50 @@ func (c *complianceComponent) complianceServerDeployment() *appsv1.Deployment {
 						ProbeHandler: corev1.ProbeHandler{
 							HTTPGet: &corev1.HTTPGetAction{
 								Path:   "/compliance/version",
-								Port:   intstr.FromInt(complianceServerPort),
+								Port:   portregistry.ComplianceServer.IntOrString(),
 								Scheme: corev1.URISchemeHTTPS,
 							},
 						},
 						FailureThreshold:    5,
 						InitialDelaySeconds: 5,
 					},
+					// The readiness endpoint checks that the server can reach its datastore, so that no requests are
+					// routed to a server that can't answer them.
 					ReadinessProbe: &corev1.Probe{
 						ProbeHandler: corev1.ProbeHandler{
 							HTTPGet: &corev1.HTTPGetAction{
-								Path:   "/compliance/version",
-								Port:   intstr.FromInt(complianceServerPort),
+								Path:   complianceServerReadinessPath,
+								Port:   portregistry.ComplianceServer.IntOrString(),
 								Scheme: corev1.URISchemeHTTPS,
 							},
 						},
 						FailureThreshold:    5,
 						InitialDelaySeconds: 5,
+						TimeoutSeconds:      5,
 					},
+					StartupProbe: StartupProbe(&corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{
+								Path:   "/compliance/version",
+								Port:   portregistry.ComplianceServer.IntOrString(),
+								Scheme: corev1.URISchemeHTTPS,
+							},
+						},
+					}),
 					Args: []string{
 						fmt.Sprintf("-certpath=%s", c.cfg.ServerKeyPair.VolumeMountCertificateFilePath()),
 						fmt.Sprintf("-keypath=%s", c.cfg.ServerKeyPair.VolumeMountKeyFilePath()),
 					},
 					SecurityContext: securitycontext.NewNonRootContext(),
-					VolumeMounts: append(
-						c.cfg.TrustedBundle.VolumeMounts(c.SupportedOSType()),
-						c.cfg.ServerKeyPair.VolumeMount(c.SupportedOSType()),
-					),
+					VolumeMounts:    volumeMounts,
 				},
 			},
-			Volumes: []corev1.Volume{
-				c.cfg.ServerKeyPair.Volume(),
-				c.cfg.TrustedBundle.Volume(),
-			},
+			Volumes: volumes,
 		},
 	}
 
+	maxUnavailable := intstr.FromInt(0)
+	maxSurge := intstr.FromInt(1)
 	d := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
 		ObjectMeta: metav1.ObjectMeta{
@@ -981,14 +1290,23 @@ func (c *complianceComponent) complianceServerDeployment() *appsv1.Deployment {
 			Namespace: c.cfg.Namespace,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: &complianceReplicas,
+			Replicas: c.cfg.Installation.ControlPlaneReplicas,
+			// Bring up a new pod before taking an old one down, so that the compliance API stays available.
 			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RecreateDeploymentStrategyType,
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxUnavailable: &maxUnavailable,
+					MaxSurge:       &maxSurge,
+				},
 			},
 			Template: *podTemplate,
 		},
 	}
 
+	if c.cfg.Installation.ControlPlaneReplicas != nil && *c.cfg.Installation.ControlPlaneReplicas > 1 {
+		d.Spec.Template.Spec.Affinity = podaffinity.NewPodAntiAffinity(ComplianceServerName, c.cfg.Namespace)
+	}
+
 	if c.cfg.Compliance != nil {
 		if overrides := c.cfg.Compliance.Spec.ComplianceServerDeployment; overrides != nil {
 			rcomponents.ApplyDeploymentOverrides(d, overrides)
@@ -997,6 +1315,52 @@ func (c *complianceComponent) complianceServerDeployment() *appsv1.Deployment {
 	return d
 }
 
+// complianceServerPodDisruptionBudget keeps at least one compliance server running during node drains when there are
+// several replicas.
+func (c *complianceComponent) complianceServerPodDisruptionBudget() *policyv1.PodDisruptionBudget {
+	maxUnavailable := intstr.FromInt(1)
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{Kind: "PodDisruptionBudget", APIVersion: "policy/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ComplianceServerName,
+			Namespace: c.cfg.Namespace,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"k8s-app": ComplianceServerName},
+			},
+		},
+	}
+}
+
+// complianceReportSigningKeySecret returns the copy of the report signing key in the compliance namespace.
+func (c *complianceComponent) complianceReportSigningKeySecret() *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: ComplianceReportSigningKeySecret, Namespace: c.cfg.Namespace},
+		Data: map[string][]byte{
+			ComplianceReportSigningKeyKey: c.cfg.ReportSigningKey.Data[ComplianceReportSigningKeyKey],
+		},
+	}
+}
+
+// complianceReportArchiveCredentialsSecret copies the keys of the credentials of the archive bucket into the compliance
+// namespace.
+func (c *complianceComponent) complianceReportArchiveCredentialsSecret() *corev1.Secret {
+	data := map[string][]byte{}
+	for _, k := range []string{ComplianceReportArchiveAccessKeyIDKey, ComplianceReportArchiveSecretKeyKey, ComplianceReportArchiveGCSKeyKey} {
+		if v, ok := c.cfg.ReportArchiveCredentials.Data[k]; ok {
+			data[k] = v
+		}
+	}
+	return &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: ComplianceReportArchiveCredentialsSecret, Namespace: c.cfg.Namespace},
+		Data:       data,
+	}
+}
+
 func complianceAnnotations(c *complianceComponent) map[string]string {
 	annotations := c.cfg.TrustedBundle.HashAnnotations()
 	if c.cfg.ServerKeyPair != nil {
@@ -1090,15 +1454,22 @@ func (c *complianceComponent) complianceSnapshotterDeployment() *appsv1.Deployme
 		keyPath, certPath = c.cfg.SnapshotterKeyPair.VolumeMountKeyFilePath(), c.cfg.SnapshotterKeyPair.VolumeMountCertificateFilePath()
 	}
 
+	var snapshotHour int32
+	if c.cfg.Compliance != nil && c.cfg.Compliance.Spec.SnapshotHour != nil {
+		snapshotHour = *c.cfg.Compliance.Spec.SnapshotHour
+	}
+
 	envVars := []corev1.EnvVar{
-		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "LOG_LEVEL", Value: c.logLevel(c.logging().Snapshotter)},
 		{Name: "TIGERA_COMPLIANCE_JOB_NAMESPACE", Value: c.cfg.Namespace},
 		{Name: "TIGERA_COMPLIANCE_MAX_FAILED_JOBS_HISTORY", Value: "3"},
-		{Name: "TIGERA_COMPLIANCE_SNAPSHOT_HOUR", Value: "0"},
+		{Name: "TIGERA_COMPLIANCE_SNAPSHOT_HOUR", Value: strconv.Itoa(int(snapshotHour))},
 		{Name: "LINSEED_CLIENT_CERT", Value: certPath},
 		{Name: "LINSEED_CLIENT_KEY", Value: keyPath},
 		{Name: "LINSEED_TOKEN", Value: GetLinseedTokenPath(c.cfg.ManagementClusterConnection != nil)},
 	}
+	envVars = append(envVars, c.timeZoneEnv()...)
+	envVars = append(envVars, c.snapshotEnv...)
 	if c.cfg.Tenant != nil {
 		// Configure the tenant id in order to read /write linseed data using the correct tenant ID
 		// Multi-tenant and single tenant with external elastic needs this variable set
@@ -1142,7 +1513,8 @@ func (c *complianceComponent) complianceSnapshotterDeployment() *appsv1.Deployme
 		},
 		Spec: corev1.PodSpec{
 			ServiceAccountName: ComplianceSnapshotterServiceAccount,
-			Tolerations:        append(c.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateControlPlane...),
+			PriorityClassName:  c.priorityClassName(),
+			Tolerations:        rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.TolerateControlPlane...),
 			NodeSelector:       c.cfg.Installation.ControlPlaneNodeSelector,
 			ImagePullSecrets:   secret.GetReferenceList(c.cfg.PullSecrets),
 			InitContainers:     initContainers,
@@ -1156,7 +1528,7 @@ func (c *complianceComponent) complianceSnapshotterDeployment() *appsv1.Deployme
 						ProbeHandler: corev1.ProbeHandler{
 							HTTPGet: &corev1.HTTPGetAction{
 								Path: "/liveness",
-								Port: intstr.FromInt(9099),
+								Port: portregistry.ComplianceHealth.IntOrString(),
 							},
 						},
 					},
@@ -1260,6 +1632,52 @@ func (c *complianceComponent) complianceBenchmarkerClusterRoleBinding() *rbacv1.
 	}
 }
 
+// benchmarkSkipChecks returns the sections and checks of the CIS benchmark that the benchmarker skips.
+func (c *complianceComponent) benchmarkSkipChecks() []string {
+	mode := operatorv1.ControlPlaneChecksAuto
+	var skip []string
+	if c.cfg.Compliance != nil && c.cfg.Compliance.Spec.CISBenchmark != nil {
+		if c.cfg.Compliance.Spec.CISBenchmark.ControlPlaneChecks != nil {
+			mode = *c.cfg.Compliance.Spec.CISBenchmark.ControlPlaneChecks
+		}
+		skip = c.cfg.Compliance.Spec.CISBenchmark.SkipChecks
+	}
+
+	skipControlPlane := mode == operatorv1.ControlPlaneChecksDisabled
+	if mode == operatorv1.ControlPlaneChecksAuto {
+		// The control plane of a managed service isn't reachable from the nodes, so its checks can only fail.
+		skipControlPlane = c.managedKubernetesService() || c.cfg.Installation.HostedControlPlane()
+	}
+	if skipControlPlane {
+		skip = append(append([]string{}, ComplianceBenchmarkControlPlaneSections...), skip...)
+	}
+	return skip
+}
+
+// managedKubernetesService returns whether the cluster runs on a managed Kubernetes service, whose control plane and
+// etcd don't run on the nodes of the cluster.
+func (c *complianceComponent) managedKubernetesService() bool {
+	p := c.cfg.Installation.KubernetesProvider
+	return p.IsAKS() || p.IsEKS() || p.IsGKE()
+}
+
+// benchmarkVersion returns the variant and version of the CIS benchmark that the benchmarker runs. An empty version
+// lets the benchmarker pick the upstream benchmark for the Kubernetes version of the cluster.
+func (c *complianceComponent) benchmarkVersion() string {
+	if c.cfg.Compliance != nil && c.cfg.Compliance.Spec.CISBenchmark != nil && c.cfg.Compliance.Spec.CISBenchmark.BenchmarkVersion != "" {
+		return c.cfg.Compliance.Spec.CISBenchmark.BenchmarkVersion
+	}
+	switch p := c.cfg.Installation.KubernetesProvider; {
+	case p.IsAKS():
+		return ComplianceBenchmarkVersionAKS
+	case p.IsEKS():
+		return ComplianceBenchmarkVersionEKS
+	case p.IsGKE():
+		return ComplianceBenchmarkVersionGKE
+	}
+	return ""
+}
+
 func (c *complianceComponent) complianceBenchmarkerDaemonSet() *appsv1.DaemonSet {
 	var keyPath, certPath string
 	if c.cfg.BenchmarkerKeyPair != nil {
@@ -1268,12 +1686,18 @@ func (c *complianceComponent) complianceBenchmarkerDaemonSet() *appsv1.DaemonSet
 	}
 
 	envVars := []corev1.EnvVar{
-		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "LOG_LEVEL", Value: c.logLevel(c.logging().Benchmarker)},
 		{Name: "NODENAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}}},
 		{Name: "LINSEED_CLIENT_CERT", Value: certPath},
 		{Name: "LINSEED_CLIENT_KEY", Value: keyPath},
 		{Name: "LINSEED_TOKEN", Value: GetLinseedTokenPath(c.cfg.ManagementClusterConnection != nil)},
 	}
+	if skip := c.benchmarkSkipChecks(); len(skip) > 0 {
+		envVars = append(envVars, corev1.EnvVar{Name: "TIGERA_COMPLIANCE_BENCHMARK_SKIP_CHECKS", Value: strings.Join(skip, ",")})
+	}
+	if version := c.benchmarkVersion(); version != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: "TIGERA_COMPLIANCE_BENCHMARK_VERSION", Value: version})
+	}
 
 	if c.cfg.Tenant != nil {
 		// Configure the tenant id in order to read /write linseed data using the correct tenant ID
@@ -1284,20 +1708,19 @@ func (c *complianceComponent) complianceBenchmarkerDaemonSet() *appsv1.DaemonSet
 	}
 
 	volMounts := []corev1.VolumeMount{
-		{Name: "var-lib-etcd", MountPath: "/var/lib/etcd", ReadOnly: true},
 		{Name: "var-lib-kubelet", MountPath: "/var/lib/kubelet", ReadOnly: true},
 		{Name: "etc-systemd", MountPath: "/etc/systemd", ReadOnly: true},
 		{Name: "etc-kubernetes", MountPath: "/etc/kubernetes", ReadOnly: true},
 		{Name: "usr-bin", MountPath: "/usr/local/bin", ReadOnly: true},
 	}
-	volMounts = append(volMounts, c.cfg.TrustedBundle.VolumeMounts(c.SupportedOSType())...)
+	trustedBundle := c.cfg.TrustedBundle
+	if c.cfg.BenchmarkerTrustedBundle != nil {
+		trustedBundle = c.cfg.BenchmarkerTrustedBundle
+	}
+	volMounts = append(volMounts, trustedBundle.VolumeMounts(c.SupportedOSType())...)
 	volMounts = append(volMounts, c.cfg.BenchmarkerKeyPair.VolumeMount(c.SupportedOSType()))
 
 	vols := []corev1.Volume{
-		{
-			Name:         "var-lib-etcd",
-			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/lib/etcd"}},
-		},
 		{
 			Name:         "var-lib-kubelet",
 			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/lib/kubelet"}},
@@ -1314,10 +1737,20 @@ func (c *complianceComponent) complianceBenchmarkerDaemonSet() *appsv1.DaemonSet
 			Name:         "usr-bin",
 			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/usr/bin"}},
 		},
-		c.cfg.TrustedBundle.Volume(),
+		trustedBundle.Volume(),
 		c.cfg.BenchmarkerKeyPair.Volume(),
 	}
 
+	// etcd runs on the control plane nodes of the cluster, so there is nothing to benchmark when the control plane is
+	// hosted elsewhere or run by a managed service.
+	if !c.cfg.Installation.HostedControlPlane() && !c.managedKubernetesService() {
+		volMounts = append([]corev1.VolumeMount{{Name: "var-lib-etcd", MountPath: "/var/lib/etcd", ReadOnly: true}}, volMounts...)
+		vols = append([]corev1.Volume{{
+			Name:         "var-lib-etcd",
+			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/lib/etcd"}},
+		}}, vols...)
+	}
+
 	// benchmarker needs an extra host path volume mount for GKE for CIS benchmarks
 	if c.cfg.Installation.KubernetesProvider.IsGKE() {
 		volMounts = append(volMounts, corev1.VolumeMount{Name: "home-kubernetes", MountPath: "/home/kubernetes", ReadOnly: true})
@@ -1328,6 +1761,16 @@ func (c *complianceComponent) complianceBenchmarkerDaemonSet() *appsv1.DaemonSet
 		})
 	}
 
+	// AKS nodes keep the flags of the kubelet in /etc/default/kubelet.
+	if c.cfg.Installation.KubernetesProvider.IsAKS() {
+		volMounts = append(volMounts, corev1.VolumeMount{Name: "etc-default", MountPath: "/etc/default", ReadOnly: true})
+
+		vols = append(vols, corev1.Volume{
+			Name:         "etc-default",
+			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/etc/default"}},
+		})
+	}
+
 	if c.cfg.ManagementClusterConnection != nil {
 		// For managed clusters, we need to mount the token for Linseed access.
 		vols = append(vols,
@@ -1358,6 +1801,7 @@ func (c *complianceComponent) complianceBenchmarkerDaemonSet() *appsv1.DaemonSet
 		},
 		Spec: corev1.PodSpec{
 			ServiceAccountName: ComplianceBenchmarkerServiceAccount,
+			PriorityClassName:  c.priorityClassName(),
 			HostPID:            true,
 			Tolerations:        rmeta.TolerateAll,
 			ImagePullSecrets:   secret.GetReferenceList(c.cfg.PullSecrets),
@@ -1374,7 +1818,7 @@ func (c *complianceComponent) complianceBenchmarkerDaemonSet() *appsv1.DaemonSet
 						ProbeHandler: corev1.ProbeHandler{
 							HTTPGet: &corev1.HTTPGetAction{
 								Path: "/liveness",
-								Port: intstr.FromInt(9099),
+								Port: portregistry.ComplianceHealth.IntOrString(),
 							},
 						},
 						PeriodSeconds:  300,
@@ -1406,6 +1850,101 @@ func (c *complianceComponent) complianceBenchmarkerDaemonSet() *appsv1.DaemonSet
 	return ds
 }
 
+// complianceGlobalReportTypes returns the built-in GlobalReportTypes, with the templates of the ReportTemplates merged
+// in, followed by the types that only the ReportTemplates define.
+func (c *complianceComponent) complianceGlobalReportTypes() []client.Object {
+	builtIn := []*v3.GlobalReportType{
+		c.complianceGlobalReportInventory(),
+		c.complianceGlobalReportNetworkAccess(),
+		c.complianceGlobalReportPolicyAudit(),
+		c.complianceGlobalReportCISBenchmark(),
+	}
+	var objs []client.Object
+	for _, rt := range builtIn {
+		if spec, ok := c.cfg.ReportTemplates[rt.Name]; ok {
+			mergeReportTemplates(&rt.Spec, spec)
+		}
+		objs = append(objs, rt)
+	}
+
+	var names []string
+	for name := range c.cfg.ReportTemplates {
+		if !complianceBuiltInReportTypes[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		objs = append(objs, &v3.GlobalReportType{
+			TypeMeta: metav1.TypeMeta{Kind: "GlobalReportType", APIVersion: "projectcalico.org/v3"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"global-report-type": name},
+			},
+			Spec: c.cfg.ReportTemplates[name],
+		})
+	}
+	return objs
+}
+
+// complianceBuiltInReportTypes are the names of the GlobalReportTypes that the operator always renders.
+var complianceBuiltInReportTypes = map[string]bool{
+	"inventory":      true,
+	"network-access": true,
+	"policy-audit":   true,
+	"cis-benchmark":  true,
+}
+
+// mergeReportTemplates replaces the UI summary template of spec, if templates has one, and the download templates of
+// spec that have the same name as one in templates. The other download templates of templates are added.
+func mergeReportTemplates(spec *v3.ReportTypeSpec, templates v3.ReportTypeSpec) {
+	if templates.UISummaryTemplate.Template != "" {
+		spec.UISummaryTemplate = templates.UISummaryTemplate
+	}
+	for _, t := range templates.DownloadTemplates {
+		replaced := false
+		for i := range spec.DownloadTemplates {
+			if spec.DownloadTemplates[i].Name == t.Name {
+				spec.DownloadTemplates[i] = t
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			spec.DownloadTemplates = append(spec.DownloadTemplates, t)
+		}
+	}
+}
+
+// ParseComplianceReportTemplates reads the report types from the data of the ComplianceReportTemplatesConfigMap. For
+// the built-in types only the templates are used. Other types must have a UI summary template.
+func ParseComplianceReportTemplates(cm *corev1.ConfigMap) (map[string]v3.ReportTypeSpec, error) {
+	templates := map[string]v3.ReportTypeSpec{}
+	for name, data := range cm.Data {
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid report type name %q: %s", name, strings.Join(errs, ", "))
+		}
+		var spec v3.ReportTypeSpec
+		if err := yaml.UnmarshalStrict([]byte(data), &spec); err != nil {
+			return nil, fmt.Errorf("invalid report type %q: %w", name, err)
+		}
+		if !complianceBuiltInReportTypes[name] && spec.UISummaryTemplate.Template == "" {
+			return nil, fmt.Errorf("report type %q must have a uiSummaryTemplate", name)
+		}
+		all := spec.DownloadTemplates
+		if spec.UISummaryTemplate.Template != "" {
+			all = append([]v3.ReportTemplate{spec.UISummaryTemplate}, all...)
+		}
+		for _, t := range all {
+			if t.Name == "" || t.Template == "" {
+				return nil, fmt.Errorf("the templates of report type %q must have a name and a template", name)
+			}
+		}
+		templates[name] = spec
+	}
+	return templates, nil
+}
+
 func (c *complianceComponent) complianceGlobalReportInventory() *v3.GlobalReportType {
 	return &v3.GlobalReportType{
 		TypeMeta: metav1.TypeMeta{Kind: "GlobalReportType", APIVersion: "projectcalico.org/v3"},
@@ -1696,19 +2235,9 @@ func (c *complianceComponent) complianceAccessAllowTigeraNetworkPolicy() *v3.Net
 
 	egressRules = networkpolicy.AppendDNSEgressRules(egressRules, c.cfg.OpenShift)
 
-	if c.cfg.ManagementClusterConnection == nil {
-		egressRules = append(egressRules, v3.Rule{
-			Action:      v3.Allow,
-			Protocol:    &networkpolicy.TCPProtocol,
-			Destination: networkpolicy.DefaultHelper().LinseedEntityRule(),
-		})
-	} else {
-		egressRules = append(egressRules, v3.Rule{
-			Action:      v3.Allow,
-			Protocol:    &networkpolicy.TCPProtocol,
-			Destination: GuardianEntityRule,
-		})
-	}
+	egressRules = append(egressRules, networkpolicy.DefaultHelper().DataStoreEgressRules(networkpolicy.DataStoreConfig{
+		ManagedCluster: c.cfg.ManagementClusterConnection != nil,
+	})...)
 
 	return &v3.NetworkPolicy{
 		TypeMeta: metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "projectcalico.org/v3"},
@@ -1735,12 +2264,8 @@ func (c *complianceComponent) complianceServerAllowTigeraNetworkPolicy() *v3.Net
 			Protocol:    &networkpolicy.TCPProtocol,
 			Destination: networkpolicy.KubeAPIServerServiceSelectorEntityRule,
 		},
-		{
-			Action:      v3.Allow,
-			Protocol:    &networkpolicy.TCPProtocol,
-			Destination: networkpolicyHelper.LinseedEntityRule(),
-		},
 	}
+	egressRules = append(egressRules, networkpolicyHelper.DataStoreEgressRules(networkpolicy.DataStoreConfig{})...)
 
 	egressRules = networkpolicy.AppendDNSEgressRules(egressRules, c.cfg.OpenShift)
 
@@ -1764,7 +2289,7 @@ func (c *complianceComponent) complianceServerAllowTigeraNetworkPolicy() *v3.Net
 			Protocol: &networkpolicy.TCPProtocol,
 			Source:   networkpolicyHelper.ManagerSourceEntityRule(),
 			Destination: v3.EntityRule{
-				Ports: networkpolicy.Ports(complianceServerPort),
+				Ports: networkpolicy.Ports(uint16(portregistry.ComplianceServer)),
 			},
 		},
 	}
@@ -1786,6 +2311,50 @@ func (c *complianceComponent) complianceServerAllowTigeraNetworkPolicy() *v3.Net
 	}
 }
 
+// complianceReportArchiveAllowTigeraNetworkPolicy allows the reporter and the server to reach the bucket that reports
+// are archived to.
+func (c *complianceComponent) complianceReportArchiveAllowTigeraNetworkPolicy() *v3.NetworkPolicy {
+	port := uint16(443)
+	if r := c.reportRetention(); r != nil && r.Archive != nil && r.Archive.Provider == operatorv1.ReportArchiveProviderS3 {
+		port = networkpolicy.ObjectStoragePort(r.Archive.Endpoint)
+	}
+	egressRules := []v3.Rule{
+		// Block any link local IPs, e.g. cloud metadata, which are often targets of server-side request forgery (SSRF) attacks
+		{
+			Action:      v3.Deny,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Destination: v3.EntityRule{Nets: []string{"169.254.0.0/16"}},
+		},
+		{
+			Action:      v3.Deny,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Destination: v3.EntityRule{Nets: []string{"fe80::/10"}},
+		},
+	}
+	egressRules = networkpolicy.AppendDNSEgressRules(egressRules, c.cfg.OpenShift)
+	egressRules = append(egressRules, v3.Rule{
+		// The object storage is outside the cluster, at an address that isn't known.
+		Action:      v3.Allow,
+		Protocol:    &networkpolicy.TCPProtocol,
+		Destination: v3.EntityRule{Ports: networkpolicy.Ports(port)},
+	})
+
+	return &v3.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "projectcalico.org/v3"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ComplianceReportArchivePolicyName,
+			Namespace: c.cfg.Namespace,
+		},
+		Spec: v3.NetworkPolicySpec{
+			Order:    &networkpolicy.HighPrecedenceOrder,
+			Tier:     networkpolicy.TigeraComponentTierName,
+			Selector: networkpolicy.KubernetesAppSelector(ComplianceReporterName, ComplianceServerName),
+			Types:    []v3.PolicyType{v3.PolicyTypeEgress},
+			Egress:   egressRules,
+		},
+	}
+}
+
 func (c *complianceComponent) multiTenantManagedClustersAccess() []client.Object {
 	var objects []client.Object
 
@@ -16,6 +16,7 @@ package render_test
 
 import (
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -23,6 +24,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,11 +41,15 @@ import (
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/common/podaffinity"
 	rtest "github.com/tigera/operator/pkg/render/common/test"
 	"github.com/tigera/operator/pkg/render/testutils"
 	"github.com/tigera/operator/pkg/tls"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 	"github.com/tigera/operator/test"
 )
 
@@ -171,6 +177,67 @@ var _ = Describe("compliance rendering tests", func() {
 		Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "FIPS_MODE_ENABLED", Value: "true"}))
 	})
 
+	It("should render the compliance server service options", func() {
+		affinity := corev1.ServiceAffinityClientIP
+		serviceType := corev1.ServiceTypeLoadBalancer
+		trafficPolicy := corev1.ServiceExternalTrafficPolicyLocal
+		cfg.Compliance = &operatorv1.Compliance{
+			Spec: operatorv1.ComplianceSpec{
+				ComplianceServerService: &operatorv1.ServiceOptions{
+					Type:                  &serviceType,
+					SessionAffinity:       &affinity,
+					ExternalTrafficPolicy: &trafficPolicy,
+				},
+			},
+		}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+		svc := rtest.GetResource(resources, "compliance", ns, "", "v1", "Service").(*corev1.Service)
+		Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+		Expect(svc.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityClientIP))
+		Expect(svc.Spec.ExternalTrafficPolicy).To(Equal(corev1.ServiceExternalTrafficPolicyLocal))
+		Expect(svc.Spec.ClusterIP).To(BeEmpty())
+	})
+
+	It("should render a headless compliance server service", func() {
+		headless := true
+		cfg.Compliance = &operatorv1.Compliance{
+			Spec: operatorv1.ComplianceSpec{
+				ComplianceServerService: &operatorv1.ServiceOptions{Headless: &headless},
+			},
+		}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+		svc := rtest.GetResource(resources, "compliance", ns, "", "v1", "Service").(*corev1.Service)
+		Expect(svc.Spec.ClusterIP).To(Equal(corev1.ClusterIPNone))
+	})
+
+	It("should render compliance server service annotations", func() {
+		serviceType := corev1.ServiceTypeLoadBalancer
+		cfg.Compliance = &operatorv1.Compliance{
+			Spec: operatorv1.ComplianceSpec{
+				ComplianceServerService: &operatorv1.ServiceOptions{
+					Type: &serviceType,
+					Metadata: &operatorv1.Metadata{
+						Labels:      map[string]string{"team": "compliance"},
+						Annotations: map[string]string{"service.beta.kubernetes.io/azure-load-balancer-internal": "true"},
+					},
+				},
+			},
+		}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+		svc := rtest.GetResource(resources, "compliance", ns, "", "v1", "Service").(*corev1.Service)
+		Expect(svc.Annotations).To(HaveKeyWithValue("service.beta.kubernetes.io/azure-load-balancer-internal", "true"))
+		Expect(svc.Labels).To(HaveKeyWithValue("team", "compliance"))
+	})
+
 	It("should render resource requests and limits for compliance components", func() {
 		cfg.Compliance = &operatorv1.Compliance{
 			Spec: operatorv1.ComplianceSpec{
@@ -354,6 +421,253 @@ var _ = Describe("compliance rendering tests", func() {
 
 	})
 
+	It("should mount the report signing key into the compliance server", func() {
+		cfg.ReportSigningKey = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "user-signing-key", Namespace: common.OperatorNamespace()},
+			Data:       map[string][]byte{render.ComplianceReportSigningKeyKey: []byte("key")},
+		}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, toDelete := component.Objects()
+
+		secret := rtest.GetResource(resources, render.ComplianceReportSigningKeySecret, ns, "", "v1", "Secret").(*corev1.Secret)
+		Expect(secret.Data).To(Equal(map[string][]byte{render.ComplianceReportSigningKeyKey: []byte("key")}))
+		Expect(rtest.GetResource(toDelete, render.ComplianceReportSigningKeySecret, ns, "", "v1", "Secret")).To(BeNil())
+
+		d := rtest.GetResource(resources, render.ComplianceServerName, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Annotations).To(HaveKey("hash.operator.tigera.io/report-signing-key"))
+		container := d.Spec.Template.Spec.Containers[0]
+		rtest.ExpectEnv(container.Env, "TIGERA_COMPLIANCE_REPORT_SIGNING_KEY", "/etc/compliance/signing/signing.key")
+		Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      render.ComplianceReportSigningKeySecret,
+			MountPath: "/etc/compliance/signing",
+			ReadOnly:  true,
+		}))
+		Expect(d.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+			Name: render.ComplianceReportSigningKeySecret,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: render.ComplianceReportSigningKeySecret},
+			},
+		}))
+
+		By("removing the key once signing is disabled")
+		cfg.ReportSigningKey = nil
+		component, err = render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, toDelete = component.Objects()
+		Expect(rtest.GetResource(resources, render.ComplianceReportSigningKeySecret, ns, "", "v1", "Secret")).To(BeNil())
+		Expect(rtest.GetResource(toDelete, render.ComplianceReportSigningKeySecret, ns, "", "v1", "Secret")).NotTo(BeNil())
+	})
+
+	It("should probe the readiness of the compliance server with configurable timings", func() {
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{
+			ComplianceServerDeployment: &operatorv1.ComplianceServerDeployment{
+				Spec: &operatorv1.ComplianceServerDeploymentSpec{
+					Template: &operatorv1.ComplianceServerDeploymentPodTemplateSpec{
+						Spec: &operatorv1.ComplianceServerDeploymentPodSpec{
+							Containers: []operatorv1.ComplianceServerDeploymentContainer{{
+								Name:           render.ComplianceServerName,
+								ReadinessProbe: &operatorv1.ProbeTimings{PeriodSeconds: ptr.Int32ToPtr(30), FailureThreshold: ptr.Int32ToPtr(2)},
+								LivenessProbe:  &operatorv1.ProbeTimings{TimeoutSeconds: ptr.Int32ToPtr(3)},
+							}},
+						},
+					},
+				},
+			},
+		}}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, render.ComplianceServerName, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		container := d.Spec.Template.Spec.Containers[0]
+		Expect(container.Resources).To(Equal(corev1.ResourceRequirements{}))
+		Expect(container.ReadinessProbe.HTTPGet.Path).To(Equal("/compliance/readiness"))
+		Expect(container.ReadinessProbe.PeriodSeconds).To(BeEquivalentTo(30))
+		Expect(container.ReadinessProbe.FailureThreshold).To(BeEquivalentTo(2))
+		Expect(container.ReadinessProbe.TimeoutSeconds).To(BeEquivalentTo(5))
+		Expect(container.LivenessProbe.HTTPGet.Path).To(Equal("/compliance/version"))
+		Expect(container.LivenessProbe.TimeoutSeconds).To(BeEquivalentTo(3))
+		Expect(container.LivenessProbe.FailureThreshold).To(BeEquivalentTo(5))
+	})
+
+	It("should configure the query limits of the compliance server", func() {
+		pageSize, downloads := int32(200), int32(4)
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{
+			ComplianceServerQueryLimits: &operatorv1.ComplianceServerQueryLimits{
+				PageSize:               &pageSize,
+				QueryTimeout:           &metav1.Duration{Duration: 90 * time.Second},
+				MaxConcurrentDownloads: &downloads,
+			},
+		}}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, render.ComplianceServerName, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		env := d.Spec.Template.Spec.Containers[0].Env
+		rtest.ExpectEnv(env, "TIGERA_COMPLIANCE_REPORT_PAGE_SIZE", "200")
+		rtest.ExpectEnv(env, "TIGERA_COMPLIANCE_QUERY_TIMEOUT", "1m30s")
+		rtest.ExpectEnv(env, "TIGERA_COMPLIANCE_MAX_CONCURRENT_DOWNLOADS", "4")
+	})
+
+	It("should pin the compliance deployments with node selector and toleration overrides", func() {
+		cfg.Installation.ControlPlaneNodeSelector = map[string]string{"role": "control-plane"}
+		nodeSelector := map[string]string{"pool": "infra", "role": "ignored"}
+		tolerations := []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "infra", Effect: corev1.TaintEffectNoSchedule}}
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{
+			ComplianceControllerDeployment: &operatorv1.ComplianceControllerDeployment{
+				Spec: &operatorv1.ComplianceControllerDeploymentSpec{
+					Template: &operatorv1.ComplianceControllerDeploymentPodTemplateSpec{
+						Spec: &operatorv1.ComplianceControllerDeploymentPodSpec{NodeSelector: nodeSelector, Tolerations: tolerations},
+					},
+				},
+			},
+			ComplianceServerDeployment: &operatorv1.ComplianceServerDeployment{
+				Spec: &operatorv1.ComplianceServerDeploymentSpec{
+					Template: &operatorv1.ComplianceServerDeploymentPodTemplateSpec{
+						Spec: &operatorv1.ComplianceServerDeploymentPodSpec{NodeSelector: nodeSelector, Tolerations: tolerations},
+					},
+				},
+			},
+			ComplianceSnapshotterDeployment: &operatorv1.ComplianceSnapshotterDeployment{
+				Spec: &operatorv1.ComplianceSnapshotterDeploymentSpec{
+					Template: &operatorv1.ComplianceSnapshotterDeploymentPodTemplateSpec{
+						Spec: &operatorv1.ComplianceSnapshotterDeploymentPodSpec{NodeSelector: nodeSelector, Tolerations: tolerations},
+					},
+				},
+			},
+		}}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		for _, name := range []string{render.ComplianceControllerName, render.ComplianceServerName, render.ComplianceSnapshotterName} {
+			d := rtest.GetResource(resources, name, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(d.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"pool": "infra", "role": "control-plane"}), name)
+			Expect(d.Spec.Template.Spec.Tolerations).To(Equal(tolerations), name)
+		}
+	})
+
+	It("should spread the compliance server and controller with topology spread constraint overrides", func() {
+		constraints := []corev1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+		}}
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{
+			ComplianceControllerDeployment: &operatorv1.ComplianceControllerDeployment{
+				Spec: &operatorv1.ComplianceControllerDeploymentSpec{
+					Template: &operatorv1.ComplianceControllerDeploymentPodTemplateSpec{
+						Spec: &operatorv1.ComplianceControllerDeploymentPodSpec{TopologySpreadConstraints: constraints},
+					},
+				},
+			},
+			ComplianceServerDeployment: &operatorv1.ComplianceServerDeployment{
+				Spec: &operatorv1.ComplianceServerDeploymentSpec{
+					Template: &operatorv1.ComplianceServerDeploymentPodTemplateSpec{
+						Spec: &operatorv1.ComplianceServerDeploymentPodSpec{TopologySpreadConstraints: constraints},
+					},
+				},
+			},
+		}}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		for _, name := range []string{render.ComplianceControllerName, render.ComplianceServerName} {
+			d := rtest.GetResource(resources, name, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(d.Spec.Template.Spec.TopologySpreadConstraints).To(HaveLen(1), name)
+			Expect(d.Spec.Template.Spec.TopologySpreadConstraints[0].TopologyKey).To(Equal("topology.kubernetes.io/zone"), name)
+			Expect(d.Spec.Template.Spec.TopologySpreadConstraints[0].LabelSelector.MatchLabels).To(HaveKeyWithValue("k8s-app", name), name)
+		}
+		Expect(constraints[0].LabelSelector).To(BeNil())
+	})
+
+	It("should serve, probe and allow the compliance server on the same port", func() {
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		svc := rtest.GetResource(resources, render.ComplianceServiceName, ns, "", "v1", "Service").(*corev1.Service)
+		Expect(svc.Spec.Ports).To(HaveLen(1))
+		port := svc.Spec.Ports[0].TargetPort
+
+		d := rtest.GetResource(resources, render.ComplianceServerName, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		container := d.Spec.Template.Spec.Containers[0]
+		Expect(container.LivenessProbe.HTTPGet.Port).To(Equal(port))
+		Expect(container.ReadinessProbe.HTTPGet.Port).To(Equal(port))
+		Expect(container.StartupProbe.HTTPGet.Port).To(Equal(port))
+
+		policyPorts := networkpolicy.Ports(uint16(port.IntValue()))
+		policy := rtest.GetResource(resources, render.ComplianceServerPolicyName, ns, "projectcalico.org", "v3", "NetworkPolicy").(*v3.NetworkPolicy)
+		Expect(policy.Spec.Ingress[0].Destination.Ports).To(Equal(policyPorts))
+		Expect(networkpolicy.DefaultHelper().ComplianceServerEntityRule().Ports).To(Equal(policyPorts))
+	})
+
+	It("should take snapshots at the configured hour", func() {
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+		d := rtest.GetResource(resources, render.ComplianceSnapshotterName, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		rtest.ExpectEnv(d.Spec.Template.Spec.Containers[0].Env, "TIGERA_COMPLIANCE_SNAPSHOT_HOUR", "0")
+
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{SnapshotHour: ptr.Int32ToPtr(3)}}
+		component, err = render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ = component.Objects()
+		d = rtest.GetResource(resources, render.ComplianceSnapshotterName, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		rtest.ExpectEnv(d.Spec.Template.Spec.Containers[0].Env, "TIGERA_COMPLIANCE_SNAPSHOT_HOUR", "3")
+	})
+
+	It("should limit the scope of the snapshots", func() {
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{SnapshotScope: &operatorv1.SnapshotScope{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			ExcludeNamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "ephemeral", Operator: metav1.LabelSelectorOpExists},
+			}},
+			Resources: []operatorv1.SnapshotResource{"pods", "networkpolicies.projectcalico.org"},
+		}}}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, render.ComplianceSnapshotterName, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		env := d.Spec.Template.Spec.Containers[0].Env
+		rtest.ExpectEnv(env, "TIGERA_COMPLIANCE_SNAPSHOT_NAMESPACE_SELECTOR", "team=payments")
+		rtest.ExpectEnv(env, "TIGERA_COMPLIANCE_SNAPSHOT_EXCLUDE_NAMESPACE_SELECTOR", "ephemeral")
+		rtest.ExpectEnv(env, "TIGERA_COMPLIANCE_SNAPSHOT_RESOURCES", "pods,networkpolicies.projectcalico.org")
+
+		By("rejecting invalid selectors")
+		cfg.Compliance.Spec.SnapshotScope.NamespaceSelector.MatchLabels["team"] = "not a label value"
+		_, err = render.Compliance(cfg)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should set the time zone of the components that schedule and generate reports", func() {
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{TimeZone: "America/New_York"}}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		controller := rtest.GetResource(resources, render.ComplianceControllerName, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		rtest.ExpectEnv(controller.Spec.Template.Spec.Containers[0].Env, "TZ", "America/New_York")
+		snapshotter := rtest.GetResource(resources, render.ComplianceSnapshotterName, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		rtest.ExpectEnv(snapshotter.Spec.Template.Spec.Containers[0].Env, "TZ", "America/New_York")
+		reporter := rtest.GetResource(resources, "tigera.io.report", ns, "", "v1", "PodTemplate").(*corev1.PodTemplate)
+		rtest.ExpectEnv(reporter.Template.Spec.Containers[0].Env, "TZ", "America/New_York")
+
+		By("leaving the components in UTC when no time zone is set")
+		cfg.Compliance = nil
+		component, err = render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ = component.Objects()
+		snapshotter = rtest.GetResource(resources, render.ComplianceSnapshotterName, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		for _, env := range snapshotter.Spec.Template.Spec.Containers[0].Env {
+			Expect(env.Name).NotTo(Equal("TZ"))
+		}
+	})
+
 	Context("Standalone cluster", func() {
 		It("should render all resources for a default configuration", func() {
 			component, err := render.Compliance(cfg)
@@ -397,6 +711,7 @@ var _ = Describe("compliance rendering tests", func() {
 				{"tigera-compliance-server", "", rbac, "v1", "ClusterRole"},
 				{"compliance", ns, "", "v1", "Service"},
 				{"compliance-server", ns, "apps", "v1", "Deployment"},
+				{"compliance-server", ns, "policy", "v1", "PodDisruptionBudget"},
 			}
 
 			Expect(len(resources)).To(Equal(len(expectedResources)))
@@ -543,6 +858,7 @@ var _ = Describe("compliance rendering tests", func() {
 				{"tigera-compliance-server", "", rbac, "v1", "ClusterRole"},
 				{"compliance", ns, "", "v1", "Service"},
 				{"compliance-server", ns, "apps", "v1", "Deployment"},
+				{"compliance-server", ns, "policy", "v1", "PodDisruptionBudget"},
 			}
 
 			Expect(len(resources)).To(Equal(len(expectedResources)))
@@ -708,6 +1024,25 @@ var _ = Describe("compliance rendering tests", func() {
 			Expect(complianceBenchmarker.Spec.Template.Spec.Tolerations).To(ContainElements(rmeta.TolerateAll))
 		})
 
+		It("should not tolerate control plane taints when the control plane is hosted", func() {
+			t := corev1.Toleration{
+				Key:      "foo",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "bar",
+				Effect:   corev1.TaintEffectNoExecute,
+			}
+			hosted := operatorv1.ControlPlaneTopologyHosted
+			dpComplianceServer, dpComplianceController, complianceSnapshotter, complianceReporter, complianceBenchmarker := renderCompliance(&operatorv1.InstallationSpec{
+				ControlPlaneTolerations: []corev1.Toleration{t},
+				ControlPlaneTopology:    &hosted,
+			})
+			Expect(dpComplianceServer.Spec.Template.Spec.Tolerations).To(ConsistOf(t))
+			Expect(dpComplianceController.Spec.Template.Spec.Tolerations).To(ConsistOf(t))
+			Expect(complianceSnapshotter.Spec.Template.Spec.Tolerations).To(ConsistOf(t))
+			Expect(complianceReporter.Template.Spec.Tolerations).To(ConsistOf(t))
+			Expect(complianceBenchmarker.Spec.Template.Spec.Tolerations).To(ContainElements(rmeta.TolerateAll))
+		})
+
 		It("should apply controlPlaneNodeSelectors", func() {
 			dpComplianceServer, dpComplianceController, complianceSnapshotter, _, _ := renderCompliance(&operatorv1.InstallationSpec{
 				ControlPlaneNodeSelector: map[string]string{"foo": "bar"},
@@ -788,6 +1123,7 @@ var _ = Describe("compliance rendering tests", func() {
 				{"tigera-compliance-server", "", rbac, "v1", "ClusterRole"},
 				{"compliance", ns, "", "v1", "Service"},
 				{"compliance-server", ns, "apps", "v1", "Deployment"},
+				{"compliance-server", ns, "policy", "v1", "PodDisruptionBudget"},
 			}
 
 			for i, expectedRes := range expectedResources {
@@ -979,6 +1315,23 @@ var _ = Describe("compliance rendering tests", func() {
 			Expect(volumeMounts[6].MountPath).To(Equal("/tigera-compliance-benchmarker-tls"))
 		})
 
+		It("should not mount the etcd data directory when the control plane is hosted", func() {
+			hosted := operatorv1.ControlPlaneTopologyHosted
+			cfg.Installation.KubernetesProvider = operatorv1.ProviderOpenShift
+			cfg.Installation.ControlPlaneTopology = &hosted
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			resources, _ := component.Objects()
+
+			dsBenchMarker := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+			volumeMounts := dsBenchMarker.Spec.Template.Spec.Containers[0].VolumeMounts
+			Expect(volumeMounts).To(HaveLen(6))
+			Expect(volumeMounts[0].Name).To(Equal("var-lib-kubelet"))
+			for _, v := range dsBenchMarker.Spec.Template.Spec.Volumes {
+				Expect(v.Name).NotTo(Equal("var-lib-etcd"))
+			}
+		})
+
 		It("should render benchmarker properly for GKE environments", func() {
 			cfg.Installation.KubernetesProvider = operatorv1.ProviderGKE
 			component, err := render.Compliance(cfg)
@@ -988,25 +1341,331 @@ var _ = Describe("compliance rendering tests", func() {
 			dsBenchMarker := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
 			volumeMounts := dsBenchMarker.Spec.Template.Spec.Containers[0].VolumeMounts
 
-			Expect(volumeMounts).To(HaveLen(8))
+			Expect(volumeMounts).To(HaveLen(7))
 
-			Expect(volumeMounts[0].Name).To(Equal("var-lib-etcd"))
-			Expect(volumeMounts[0].MountPath).To(Equal("/var/lib/etcd"))
-			Expect(volumeMounts[1].Name).To(Equal("var-lib-kubelet"))
-			Expect(volumeMounts[1].MountPath).To(Equal("/var/lib/kubelet"))
-			Expect(volumeMounts[2].Name).To(Equal("etc-systemd"))
-			Expect(volumeMounts[2].MountPath).To(Equal("/etc/systemd"))
-			Expect(volumeMounts[3].Name).To(Equal("etc-kubernetes"))
-			Expect(volumeMounts[3].MountPath).To(Equal("/etc/kubernetes"))
-			Expect(volumeMounts[4].Name).To(Equal("usr-bin"))
-			Expect(volumeMounts[4].MountPath).To(Equal("/usr/local/bin"))
-			Expect(volumeMounts[5].Name).To(Equal("tigera-ca-bundle"))
-			Expect(volumeMounts[5].MountPath).To(Equal("/etc/pki/tls/certs"))
-			Expect(volumeMounts[6].Name).To(Equal("tigera-compliance-benchmarker-tls"))
-			Expect(volumeMounts[6].MountPath).To(Equal("/tigera-compliance-benchmarker-tls"))
-			Expect(volumeMounts[7].Name).To(Equal("home-kubernetes"))
-			Expect(volumeMounts[7].MountPath).To(Equal("/home/kubernetes"))
+			Expect(volumeMounts[0].Name).To(Equal("var-lib-kubelet"))
+			Expect(volumeMounts[0].MountPath).To(Equal("/var/lib/kubelet"))
+			Expect(volumeMounts[1].Name).To(Equal("etc-systemd"))
+			Expect(volumeMounts[1].MountPath).To(Equal("/etc/systemd"))
+			Expect(volumeMounts[2].Name).To(Equal("etc-kubernetes"))
+			Expect(volumeMounts[2].MountPath).To(Equal("/etc/kubernetes"))
+			Expect(volumeMounts[3].Name).To(Equal("usr-bin"))
+			Expect(volumeMounts[3].MountPath).To(Equal("/usr/local/bin"))
+			Expect(volumeMounts[4].Name).To(Equal("tigera-ca-bundle"))
+			Expect(volumeMounts[4].MountPath).To(Equal("/etc/pki/tls/certs"))
+			Expect(volumeMounts[5].Name).To(Equal("tigera-compliance-benchmarker-tls"))
+			Expect(volumeMounts[5].MountPath).To(Equal("/tigera-compliance-benchmarker-tls"))
+			Expect(volumeMounts[6].Name).To(Equal("home-kubernetes"))
+			Expect(volumeMounts[6].MountPath).To(Equal("/home/kubernetes"))
 		})
+
+		It("should render benchmarker properly for AKS environments", func() {
+			cfg.Installation.KubernetesProvider = operatorv1.ProviderAKS
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			resources, _ := component.Objects()
+
+			dsBenchMarker := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+			volumeMounts := dsBenchMarker.Spec.Template.Spec.Containers[0].VolumeMounts
+
+			Expect(volumeMounts).To(HaveLen(7))
+			Expect(volumeMounts[0].Name).To(Equal("var-lib-kubelet"))
+			Expect(volumeMounts[6].Name).To(Equal("etc-default"))
+			Expect(volumeMounts[6].MountPath).To(Equal("/etc/default"))
+			for _, v := range dsBenchMarker.Spec.Template.Spec.Volumes {
+				Expect(v.Name).NotTo(Equal("var-lib-etcd"))
+			}
+		})
+
+		DescribeTable("should run the CIS benchmark variant of the provider",
+			func(provider operatorv1.Provider, override, expected string) {
+				cfg.Installation.KubernetesProvider = provider
+				if override != "" {
+					cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{
+						CISBenchmark: &operatorv1.CISBenchmark{BenchmarkVersion: override},
+					}}
+				}
+				component, err := render.Compliance(cfg)
+				Expect(err).ShouldNot(HaveOccurred())
+				resources, _ := component.Objects()
+
+				ds := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+				env := ds.Spec.Template.Spec.Containers[0].Env
+				if expected == "" {
+					for _, e := range env {
+						Expect(e.Name).NotTo(Equal("TIGERA_COMPLIANCE_BENCHMARK_VERSION"))
+					}
+					return
+				}
+				rtest.ExpectEnv(env, "TIGERA_COMPLIANCE_BENCHMARK_VERSION", expected)
+			},
+			Entry("self-managed", operatorv1.ProviderNone, "", ""),
+			Entry("AKS", operatorv1.ProviderAKS, "", render.ComplianceBenchmarkVersionAKS),
+			Entry("EKS", operatorv1.ProviderEKS, "", render.ComplianceBenchmarkVersionEKS),
+			Entry("GKE", operatorv1.ProviderGKE, "", render.ComplianceBenchmarkVersionGKE),
+			Entry("self-managed with an override", operatorv1.ProviderNone, "cis-1.23", "cis-1.23"),
+			Entry("EKS with an override", operatorv1.ProviderEKS, "eks-1.1.0", "eks-1.1.0"),
+		)
+
+		DescribeTable("should skip the control plane checks of managed services",
+			func(provider operatorv1.Provider, hosted bool, mode *operatorv1.ControlPlaneChecks, extra []string, expected string) {
+				cfg.Installation.KubernetesProvider = provider
+				if hosted {
+					topology := operatorv1.ControlPlaneTopologyHosted
+					cfg.Installation.ControlPlaneTopology = &topology
+				}
+				cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{
+					CISBenchmark: &operatorv1.CISBenchmark{ControlPlaneChecks: mode, SkipChecks: extra},
+				}}
+				component, err := render.Compliance(cfg)
+				Expect(err).ShouldNot(HaveOccurred())
+				resources, _ := component.Objects()
+
+				ds := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+				env := ds.Spec.Template.Spec.Containers[0].Env
+				if expected == "" {
+					for _, e := range env {
+						Expect(e.Name).NotTo(Equal("TIGERA_COMPLIANCE_BENCHMARK_SKIP_CHECKS"))
+					}
+					return
+				}
+				rtest.ExpectEnv(env, "TIGERA_COMPLIANCE_BENCHMARK_SKIP_CHECKS", expected)
+			},
+			Entry("self-managed", operatorv1.ProviderNone, false, nil, nil, ""),
+			Entry("EKS", operatorv1.ProviderEKS, false, nil, nil, "1,2,3"),
+			Entry("GKE", operatorv1.ProviderGKE, false, nil, nil, "1,2,3"),
+			Entry("AKS with extra checks", operatorv1.ProviderAKS, false, nil, []string{"4.2.6"}, "1,2,3,4.2.6"),
+			Entry("hosted control plane", operatorv1.ProviderOpenShift, true, nil, nil, "1,2,3"),
+			Entry("EKS with control plane checks enabled", operatorv1.ProviderEKS, false, checksMode(operatorv1.ControlPlaneChecksEnabled), []string{"4.2.6"}, "4.2.6"),
+			Entry("self-managed with control plane checks disabled", operatorv1.ProviderNone, false, checksMode(operatorv1.ControlPlaneChecksDisabled), nil, "1,2,3"),
+		)
+	})
+
+	It("should set the priority class of all compliance pods", func() {
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{PriorityClassName: "compliance-critical"}}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		for _, name := range []string{"compliance-controller", "compliance-snapshotter", "compliance-server"} {
+			d := rtest.GetResource(resources, name, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(d.Spec.Template.Spec.PriorityClassName).To(Equal("compliance-critical"), name)
+		}
+		ds := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		Expect(ds.Spec.Template.Spec.PriorityClassName).To(Equal("compliance-critical"))
+		reporter := rtest.GetResource(resources, "tigera.io.report", ns, "", "v1", "PodTemplate").(*corev1.PodTemplate)
+		Expect(reporter.Template.Spec.PriorityClassName).To(Equal("compliance-critical"))
+	})
+
+	It("should mount the benchmarker trusted bundle in the benchmarker only", func() {
+		cfg.BenchmarkerTrustedBundle = certificatemanagement.CreateNamedTrustedBundle(render.ComplianceBenchmarkerTrustedBundle)
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		ds := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		Expect(ds.Spec.Template.Spec.Volumes).To(ContainElement(cfg.BenchmarkerTrustedBundle.Volume()))
+		Expect(ds.Spec.Template.Spec.Volumes).NotTo(ContainElement(cfg.TrustedBundle.Volume()))
+		Expect(ds.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      render.ComplianceBenchmarkerTrustedBundle,
+			MountPath: "/etc/pki/tls/certs",
+			ReadOnly:  true,
+		}))
+
+		d := rtest.GetResource(resources, "compliance-server", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Volumes).To(ContainElement(cfg.TrustedBundle.Volume()))
+	})
+
+	It("should set the log levels of the compliance components", func() {
+		debug, warn := operatorv1.LogLevelDebug, operatorv1.LogLevelWarn
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{
+			Logging: &operatorv1.ComplianceLogging{LogSeverity: &warn, Server: &debug},
+		}}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		for name, level := range map[string]string{
+			"compliance-controller":  "warn",
+			"compliance-snapshotter": "warn",
+			"compliance-server":      "debug",
+		} {
+			d := rtest.GetResource(resources, name, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "LOG_LEVEL", Value: level}), name)
+		}
+		ds := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		Expect(ds.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "LOG_LEVEL", Value: "warn"}))
+		reporter := rtest.GetResource(resources, "tigera.io.report", ns, "", "v1", "PodTemplate").(*corev1.PodTemplate)
+		Expect(reporter.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "LOG_LEVEL", Value: "warn"}))
+	})
+
+	Context("report retention", func() {
+		BeforeEach(func() {
+			cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{
+				ReportRetention: &operatorv1.ReportRetention{
+					Duration: &metav1.Duration{Duration: 48 * time.Hour},
+					Archive: &operatorv1.ReportArchive{
+						Provider:              operatorv1.ReportArchiveProviderS3,
+						Bucket:                "reports",
+						Prefix:                "cluster-a/",
+						Endpoint:              "https://minio.example.com:9000",
+						CredentialsSecretName: "archive-credentials",
+					},
+				},
+			}}
+			cfg.ReportArchiveCredentials = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "archive-credentials", Namespace: common.OperatorNamespace()},
+				Data: map[string][]byte{
+					render.ComplianceReportArchiveAccessKeyIDKey: []byte("id"),
+					render.ComplianceReportArchiveSecretKeyKey:   []byte("secret"),
+				},
+			}
+		})
+
+		It("should configure the reporter and the server with an S3 archive", func() {
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			resources, _ := component.Objects()
+
+			s := rtest.GetResource(resources, render.ComplianceReportArchiveCredentialsSecret, ns, "", "v1", "Secret").(*corev1.Secret)
+			Expect(s.Data).To(Equal(cfg.ReportArchiveCredentials.Data))
+
+			credentialRef := func(key string) *corev1.EnvVarSource {
+				return &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: render.ComplianceReportArchiveCredentialsSecret},
+					Key:                  key,
+				}}
+			}
+			expectedEnv := []corev1.EnvVar{
+				{Name: "TIGERA_COMPLIANCE_REPORT_RETENTION", Value: "48h0m0s"},
+				{Name: "TIGERA_COMPLIANCE_REPORT_ARCHIVE_PROVIDER", Value: "S3"},
+				{Name: "TIGERA_COMPLIANCE_REPORT_ARCHIVE_BUCKET", Value: "reports"},
+				{Name: "TIGERA_COMPLIANCE_REPORT_ARCHIVE_PREFIX", Value: "cluster-a/"},
+				{Name: "TIGERA_COMPLIANCE_REPORT_ARCHIVE_REGION", Value: ""},
+				{Name: "TIGERA_COMPLIANCE_REPORT_ARCHIVE_ENDPOINT", Value: "https://minio.example.com:9000"},
+				{Name: "AWS_ACCESS_KEY_ID", ValueFrom: credentialRef(render.ComplianceReportArchiveAccessKeyIDKey)},
+				{Name: "AWS_SECRET_ACCESS_KEY", ValueFrom: credentialRef(render.ComplianceReportArchiveSecretKeyKey)},
+			}
+			d := rtest.GetResource(resources, "compliance-server", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElements(expectedEnv))
+			Expect(d.Spec.Template.Annotations).To(HaveKey("hash.operator.tigera.io/report-archive-credentials"))
+			reporter := rtest.GetResource(resources, "tigera.io.report", ns, "", "v1", "PodTemplate").(*corev1.PodTemplate)
+			Expect(reporter.Template.Spec.Containers[0].Env).To(ContainElements(expectedEnv))
+
+			policy := rtest.GetResource(resources, render.ComplianceReportArchivePolicyName, ns, "projectcalico.org", "v3", "NetworkPolicy").(*v3.NetworkPolicy)
+			Expect(policy.Spec.Selector).To(Equal(networkpolicy.KubernetesAppSelector(render.ComplianceReporterName, render.ComplianceServerName)))
+			Expect(policy.Spec.Egress[len(policy.Spec.Egress)-1].Destination.Ports).To(Equal(networkpolicy.Ports(9000)))
+		})
+
+		It("should mount the key of a GCS archive", func() {
+			cfg.Compliance.Spec.ReportRetention.Archive.Provider = operatorv1.ReportArchiveProviderGCS
+			cfg.Compliance.Spec.ReportRetention.Archive.Endpoint = ""
+			cfg.ReportArchiveCredentials.Data = map[string][]byte{render.ComplianceReportArchiveGCSKeyKey: []byte("{}")}
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			resources, _ := component.Objects()
+
+			reporter := rtest.GetResource(resources, "tigera.io.report", ns, "", "v1", "PodTemplate").(*corev1.PodTemplate)
+			Expect(reporter.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{
+				Name:  "GOOGLE_APPLICATION_CREDENTIALS",
+				Value: "/etc/compliance/archive/key.json",
+			}))
+			Expect(reporter.Template.Spec.Containers[0].Env).NotTo(ContainElement(HaveField("Name", "AWS_ACCESS_KEY_ID")))
+			Expect(reporter.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      render.ComplianceReportArchiveCredentialsSecret,
+				MountPath: "/etc/compliance/archive",
+				ReadOnly:  true,
+			}))
+			Expect(reporter.Template.Spec.Volumes).To(ContainElement(HaveField("Name", render.ComplianceReportArchiveCredentialsSecret)))
+
+			policy := rtest.GetResource(resources, render.ComplianceReportArchivePolicyName, ns, "projectcalico.org", "v3", "NetworkPolicy").(*v3.NetworkPolicy)
+			Expect(policy.Spec.Egress[len(policy.Spec.Egress)-1].Destination.Ports).To(Equal(networkpolicy.Ports(443)))
+		})
+
+		It("should remove the archive credentials and policy without an archive", func() {
+			cfg.Compliance.Spec.ReportRetention.Archive = nil
+			cfg.ReportArchiveCredentials = nil
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			resources, toDelete := component.Objects()
+
+			Expect(rtest.GetResource(resources, render.ComplianceReportArchiveCredentialsSecret, ns, "", "v1", "Secret")).To(BeNil())
+			Expect(rtest.GetResource(toDelete, render.ComplianceReportArchiveCredentialsSecret, ns, "", "v1", "Secret")).NotTo(BeNil())
+			Expect(rtest.GetResource(toDelete, render.ComplianceReportArchivePolicyName, ns, "projectcalico.org", "v3", "NetworkPolicy")).NotTo(BeNil())
+
+			d := rtest.GetResource(resources, "compliance-server", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "TIGERA_COMPLIANCE_REPORT_RETENTION", Value: "48h0m0s"}))
+			Expect(d.Spec.Template.Spec.Containers[0].Env).NotTo(ContainElement(HaveField("Name", "TIGERA_COMPLIANCE_REPORT_ARCHIVE_PROVIDER")))
+		})
+	})
+
+	It("should run the compliance server with the control plane replicas", func() {
+		var replicas int32 = 2
+		cfg.Installation.ControlPlaneReplicas = &replicas
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "compliance-server", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Replicas).To(Equal(&replicas))
+		Expect(d.Spec.Strategy.Type).To(Equal(appsv1.RollingUpdateDeploymentStrategyType))
+		Expect(d.Spec.Strategy.RollingUpdate.MaxUnavailable.IntValue()).To(Equal(0))
+		Expect(d.Spec.Template.Spec.Affinity).To(Equal(podaffinity.NewPodAntiAffinity("compliance-server", ns)))
+
+		pdb := rtest.GetResource(resources, "compliance-server", ns, "policy", "v1", "PodDisruptionBudget").(*policyv1.PodDisruptionBudget)
+		Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(1))
+		Expect(pdb.Spec.Selector.MatchLabels).To(Equal(map[string]string{"k8s-app": "compliance-server"}))
+	})
+
+	Context("report templates", func() {
+		It("should merge the templates of the built-in report types and add custom types", func() {
+			cfg.ReportTemplates = map[string]v3.ReportTypeSpec{
+				"inventory": {
+					UISummaryTemplate: v3.ReportTemplate{Name: "ui-summary.json", Template: "{}"},
+					DownloadTemplates: []v3.ReportTemplate{
+						{Name: "summary.csv", Template: "custom summary"},
+						{Name: "extra.csv", Template: "extra"},
+					},
+				},
+				"my-report": {
+					UISummaryTemplate:   v3.ReportTemplate{Name: "ui-summary.json", Template: "{}"},
+					IncludeEndpointData: true,
+				},
+			}
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			resources, _ := component.Objects()
+
+			inventory := rtest.GetResource(resources, "inventory", "", "projectcalico.org", "v3", "GlobalReportType").(*v3.GlobalReportType)
+			Expect(inventory.Spec.UISummaryTemplate).To(Equal(v3.ReportTemplate{Name: "ui-summary.json", Template: "{}"}))
+			var names []string
+			for _, t := range inventory.Spec.DownloadTemplates {
+				names = append(names, t.Name)
+			}
+			Expect(names).To(Equal([]string{"summary.csv", "endpoints.csv", "namespaces.csv", "services.csv", "extra.csv"}))
+			Expect(inventory.Spec.DownloadTemplates[0].Template).To(Equal("custom summary"))
+
+			custom := rtest.GetResource(resources, "my-report", "", "projectcalico.org", "v3", "GlobalReportType").(*v3.GlobalReportType)
+			Expect(custom.Labels).To(HaveKeyWithValue("global-report-type", "my-report"))
+			Expect(custom.Spec).To(Equal(cfg.ReportTemplates["my-report"]))
+		})
+
+		DescribeTable("should parse the report templates ConfigMap",
+			func(data map[string]string, expectedErr string) {
+				_, err := render.ParseComplianceReportTemplates(&corev1.ConfigMap{Data: data})
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				}
+			},
+			Entry("built-in type with only download templates", map[string]string{"inventory": "downloadTemplates:\n- name: a.csv\n  template: x\n"}, ""),
+			Entry("custom type", map[string]string{"my-report": "uiSummaryTemplate:\n  name: ui.json\n  template: x\n"}, ""),
+			Entry("custom type without a summary template", map[string]string{"my-report": "includeEndpointData: true\n"}, "must have a uiSummaryTemplate"),
+			Entry("template without a name", map[string]string{"inventory": "downloadTemplates:\n- template: x\n"}, "must have a name and a template"),
+			Entry("unknown field", map[string]string{"inventory": "downloadTemplate: []\n"}, `invalid report type "inventory"`),
+			Entry("invalid name", map[string]string{"My Report": "{}"}, `invalid report type name "My Report"`),
+		)
 	})
 
 	Context("allow-tigera rendering", func() {
@@ -1054,6 +1713,24 @@ var _ = Describe("compliance rendering tests", func() {
 			Entry("for managed, kube-dns", testutils.AllowTigeraScenario{ManagedCluster: true, OpenShift: false}),
 			Entry("for managed, openshift-dns", testutils.AllowTigeraScenario{ManagedCluster: true, OpenShift: true}),
 		)
+
+		It("should select every compliance workload with a policy and deny other traffic in the namespace", func() {
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			resources, _ := component.Objects()
+
+			var selectors []string
+			for _, r := range resources {
+				if p, ok := r.(*v3.NetworkPolicy); ok && p.Namespace == ns {
+					Expect(p.Spec.Tier).To(Equal(networkpolicy.TigeraComponentTierName))
+					selectors = append(selectors, p.Spec.Selector)
+				}
+			}
+			for _, name := range []string{render.ComplianceServerName, render.ComplianceControllerName, render.ComplianceSnapshotterName, render.ComplianceBenchmarkerName, render.ComplianceReporterName} {
+				Expect(selectors).To(ContainElement(ContainSubstring(fmt.Sprintf("k8s-app == '%s'", name))))
+			}
+			Expect(rtest.GetResource(resources, networkpolicy.TigeraComponentDefaultDenyPolicyName, ns, "projectcalico.org", "v3", "NetworkPolicy")).To(Equal(networkpolicy.AllowTigeraDefaultDeny(ns)))
+		})
 	})
 
 	Context("multi-tenant rendering", func() {
@@ -1093,6 +1770,7 @@ var _ = Describe("compliance rendering tests", func() {
 				&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "tigera-compliance-server"}},
 				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "compliance", Namespace: tenantANamespace}},
 				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "compliance-server", Namespace: tenantANamespace}},
+				&policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: "compliance-server", Namespace: tenantANamespace}},
 			}
 
 			rtest.ExpectResources(tenantAResources, tenantAExpectedResources)
@@ -1135,6 +1813,7 @@ var _ = Describe("compliance rendering tests", func() {
 				&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "tigera-compliance-server"}},
 				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "compliance", Namespace: tenantBNamespace}},
 				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "compliance-server", Namespace: tenantBNamespace}},
+				&policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: "compliance-server", Namespace: tenantBNamespace}},
 			}
 
 			rtest.ExpectResources(tenantBResources, tenantBExpectedResources)
@@ -1455,3 +2134,7 @@ var _ = Describe("compliance rendering tests", func() {
 		})
 	})
 })
+
+func checksMode(m operatorv1.ControlPlaneChecks) *operatorv1.ControlPlaneChecks {
+	return &m
+}
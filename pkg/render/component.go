@@ -39,3 +39,69 @@ type Component interface {
 	// that create pods. Return OSTypeAny means that no node selector should be set for the "kubernetes.io/os" label.
 	SupportedOSType() rmeta.OSType
 }
+
+// WrappingComponent is implemented by components that wrap another component to change the objects that it renders.
+type WrappingComponent interface {
+	Component
+
+	// Unwrap returns the wrapped component.
+	Unwrap() Component
+}
+
+// UnwrapComponent returns the innermost component that c wraps, or c itself if it doesn't wrap one.
+func UnwrapComponent(c Component) Component {
+	for {
+		w, ok := c.(WrappingComponent)
+		if !ok {
+			return c
+		}
+		c = w.Unwrap()
+	}
+}
+
+// SecretReplicatingComponent is implemented by components that copy secrets from other namespaces using
+// secret.CopyToNamespace. The component handler labels the copies it creates for such a component, and deletes
+// the copies that the component no longer renders, for example because the source secret was removed.
+type SecretReplicatingComponent interface {
+	Component
+
+	// ReplicatedSecretsKey returns the value used to label the secrets copied by the component. It must be unique
+	// among the components that are reconciled for the same custom resource, and be a valid label value.
+	ReplicatedSecretsKey() string
+}
+
+// OwnershipStrategy specifies how the component handler ties the objects of a component to the custom resource it
+// reconciles, so that they are cleaned up when the custom resource is deleted.
+type OwnershipStrategy string
+
+const (
+	// OwnershipReferences sets owner references on the objects that Kubernetes allows them on. The objects of a
+	// namespaced custom resource that are cluster scoped or in another namespace are left without an owner. This is
+	// the default.
+	OwnershipReferences OwnershipStrategy = "References"
+
+	// OwnershipLabels sets owner references like OwnershipReferences, and labels the objects that can't have one with
+	// the UID of the custom resource. A finalizer is added to the custom resource, so that the controller can delete
+	// the labeled objects with utils.DeleteLabelOwnedObjects before the custom resource goes away. Objects with the
+	// multiple owners label are shared, and are never labeled.
+	OwnershipLabels OwnershipStrategy = "Labels"
+)
+
+// OwnershipComponent is implemented by components that choose how their objects are tied to the custom resource that
+// they are rendered for. Components that don't implement it use OwnershipReferences.
+type OwnershipComponent interface {
+	Component
+
+	// OwnershipStrategy returns the strategy for the objects of the component.
+	OwnershipStrategy() OwnershipStrategy
+}
+
+// TracingComponent is implemented by components whose containers can export traces with the OpenTelemetry SDK. When
+// the OperatorConfig enables tracing for components, the component handler configures those containers to export
+// their traces to the same receiver as the operator.
+type TracingComponent interface {
+	Component
+
+	// TracingContainers returns the names of the containers that export traces.
+	TracingContainers() []string
+}
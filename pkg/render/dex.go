@@ -246,7 +246,7 @@ func (c *dexComponent) deployment() client.Object {
 				Spec: corev1.PodSpec{
 					NodeSelector:       c.cfg.Installation.ControlPlaneNodeSelector,
 					ServiceAccountName: DexObjectName,
-					Tolerations:        append(c.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateControlPlane...),
+					Tolerations:        rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.TolerateControlPlane...),
 					ImagePullSecrets:   secret.GetReferenceList(c.cfg.PullSecrets),
 					InitContainers:     initContainers,
 					Containers: []corev1.Container{
@@ -332,45 +332,92 @@ func (c *dexComponent) probe() *corev1.Probe {
 	}
 }
 
+// dexServerConfig is the config.yaml of Dex. The fields are in the order of their keys, so that the file is the same
+// as when it was marshaled from maps, whose keys are sorted.
+type dexServerConfig struct {
+	Connectors    []map[string]interface{} `yaml:"connectors"`
+	Expiry        dexExpiryConfig          `yaml:"expiry"`
+	Issuer        string                   `yaml:"issuer"`
+	OAuth2        dexOAuth2Config          `yaml:"oauth2"`
+	StaticClients []dexStaticClient        `yaml:"staticClients"`
+	Storage       dexStorageConfig         `yaml:"storage"`
+	Web           dexWebConfig             `yaml:"web"`
+}
+
+type dexExpiryConfig struct {
+	IDTokens string `yaml:"idTokens"`
+}
+
+type dexOAuth2Config struct {
+	ResponseTypes      []string `yaml:"responseTypes"`
+	SkipApprovalScreen bool     `yaml:"skipApprovalScreen"`
+}
+
+type dexStaticClient struct {
+	ID           string   `yaml:"id"`
+	Name         string   `yaml:"name"`
+	RedirectURIs []string `yaml:"redirectURIs"`
+	SecretEnv    string   `yaml:"secretEnv"`
+}
+
+type dexStorageConfig struct {
+	Config dexKubernetesStorageConfig `yaml:"config"`
+	Type   string                     `yaml:"type"`
+}
+
+type dexKubernetesStorageConfig struct {
+	InCluster bool `yaml:"inCluster"`
+}
+
+type dexWebConfig struct {
+	AllowedOrigins          []string          `yaml:"allowedOrigins"`
+	DiscoveryAllowedOrigins []string          `yaml:"discoveryAllowedOrigins"`
+	Headers                 map[string]string `yaml:"headers"`
+	HTTPS                   string            `yaml:"https"`
+	TLSCert                 string            `yaml:"tlsCert"`
+	TLSKey                  string            `yaml:"tlsKey"`
+}
+
 func (c *dexComponent) configMap() *corev1.ConfigMap {
-	bytes, err := yaml.Marshal(map[string]interface{}{
-		"issuer": c.cfg.DexConfig.Issuer(),
-		"storage": map[string]interface{}{
-			"type": "kubernetes",
-			"config": map[string]bool{
-				"inCluster": true,
-			},
+	// Default duration is 24h. This is too high for most organizations. Setting it to 15m.
+	idTokens := "15m"
+	if c.cfg.Authentication != nil && c.cfg.Authentication.Spec.Session != nil && c.cfg.Authentication.Spec.Session.TokenLifetime != nil {
+		idTokens = c.cfg.Authentication.Spec.Session.TokenLifetime.Duration.String()
+	}
+
+	bytes, err := yaml.Marshal(dexServerConfig{
+		Issuer: c.cfg.DexConfig.Issuer(),
+		Storage: dexStorageConfig{
+			Type:   "kubernetes",
+			Config: dexKubernetesStorageConfig{InCluster: true},
 		},
-		"web": map[string]interface{}{
-			"https":                   "0.0.0.0:5556",
-			"tlsCert":                 c.cfg.TLSKeyPair.VolumeMountCertificateFilePath(),
-			"tlsKey":                  c.cfg.TLSKeyPair.VolumeMountKeyFilePath(),
-			"allowedOrigins":          []string{"*"},
-			"discoveryAllowedOrigins": []string{"*"},
-			"headers": map[string]string{
+		Web: dexWebConfig{
+			HTTPS:                   ":5556",
+			TLSCert:                 c.cfg.TLSKeyPair.VolumeMountCertificateFilePath(),
+			TLSKey:                  c.cfg.TLSKeyPair.VolumeMountKeyFilePath(),
+			AllowedOrigins:          []string{"*"},
+			DiscoveryAllowedOrigins: []string{"*"},
+			Headers: map[string]string{
 				"X-Content-Type-Options":    "nosniff",
 				"X-XSS-Protection":          "1; mode=block",
 				"X-Frame-Options":           "DENY",
 				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
 			},
 		},
-		"connectors": []map[string]interface{}{c.connector},
-		"oauth2": map[string]interface{}{
-			"skipApprovalScreen": true,
-			"responseTypes":      []string{"id_token", "code", "token"},
+		Connectors: []map[string]interface{}{c.connector},
+		OAuth2: dexOAuth2Config{
+			SkipApprovalScreen: true,
+			ResponseTypes:      []string{"id_token", "code", "token"},
 		},
-		"staticClients": []map[string]interface{}{
+		StaticClients: []dexStaticClient{
 			{
-				"id":           DexClientId,
-				"redirectURIs": c.cfg.DexConfig.RedirectURIs(),
-				"name":         "Calico Enterprise Manager",
-				"secretEnv":    dexSecretEnv,
+				ID:           DexClientId,
+				RedirectURIs: c.cfg.DexConfig.RedirectURIs(),
+				Name:         "Calico Enterprise Manager",
+				SecretEnv:    dexSecretEnv,
 			},
 		},
-		"expiry": map[string]string{
-			// Default duration is 24h. This is too high for most organizations. Setting it to 15m.
-			"idTokens": "15m",
-		},
+		Expiry: dexExpiryConfig{IDTokens: idTokens},
 	})
 	if err != nil {
 		// Panic since this would be a developer error, as the marshaled struct is one created by our code.
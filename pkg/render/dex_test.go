@@ -16,8 +16,10 @@ package render_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/tigera/operator/test"
+	"gopkg.in/yaml.v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	. "github.com/onsi/ginkgo"
@@ -261,6 +263,19 @@ var _ = Describe("dex rendering tests", func() {
 			Expect(cm.Data["config.yaml"]).To(ContainSubstring("idTokens: 15m"))
 		})
 
+		It("should render the token lifetime of the Authentication", func() {
+			cfg.Authentication = &operatorv1.Authentication{
+				Spec: operatorv1.AuthenticationSpec{
+					Session: &operatorv1.AuthenticationSession{TokenLifetime: &metav1.Duration{Duration: time.Hour}},
+				},
+			}
+			resources, _ := render.Dex(cfg).Objects()
+
+			cm, ok := rtest.GetResource(resources, "tigera-dex", "tigera-dex", "", "v1", "ConfigMap").(*corev1.ConfigMap)
+			Expect(ok).To(BeTrue())
+			Expect(cm.Data["config.yaml"]).To(ContainSubstring("idTokens: 1h0m0s"))
+		})
+
 		It("should render config Map with the HSTS headers", func() {
 			component := render.Dex(cfg)
 			resources, _ := component.Objects()
@@ -279,6 +294,51 @@ var _ = Describe("dex rendering tests", func() {
 			Expect(cm.Data["config.yaml"]).To(ContainSubstring("Strict-Transport-Security: max-age=31536000; includeSubDomains"))
 		})
 
+		It("should render the same config as the legacy map based config", func() {
+			component := render.Dex(cfg)
+			resources, _ := component.Objects()
+
+			legacy, err := yaml.Marshal(map[string]interface{}{
+				"issuer": cfg.DexConfig.Issuer(),
+				"storage": map[string]interface{}{
+					"type":   "kubernetes",
+					"config": map[string]bool{"inCluster": true},
+				},
+				"web": map[string]interface{}{
+					"https":                   ":5556",
+					"tlsCert":                 cfg.TLSKeyPair.VolumeMountCertificateFilePath(),
+					"tlsKey":                  cfg.TLSKeyPair.VolumeMountKeyFilePath(),
+					"allowedOrigins":          []string{"*"},
+					"discoveryAllowedOrigins": []string{"*"},
+					"headers": map[string]string{
+						"X-Content-Type-Options":    "nosniff",
+						"X-XSS-Protection":          "1; mode=block",
+						"X-Frame-Options":           "DENY",
+						"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+					},
+				},
+				"connectors": []map[string]interface{}{cfg.DexConfig.Connector()},
+				"oauth2": map[string]interface{}{
+					"skipApprovalScreen": true,
+					"responseTypes":      []string{"id_token", "code", "token"},
+				},
+				"staticClients": []map[string]interface{}{
+					{
+						"id":           render.DexClientId,
+						"redirectURIs": cfg.DexConfig.RedirectURIs(),
+						"name":         "Calico Enterprise Manager",
+						"secretEnv":    "DEX_SECRET",
+					},
+				},
+				"expiry": map[string]string{"idTokens": "15m"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			cm, ok := rtest.GetResource(resources, "tigera-dex", "tigera-dex", "", "v1", "ConfigMap").(*corev1.ConfigMap)
+			Expect(ok).To(BeTrue())
+			Expect(cm.Data["config.yaml"]).To(Equal(string(legacy)))
+		})
+
 		DescribeTable("should render the cluster name properly in the validator", func(clusterDomain string) {
 			validatorConfig := render.NewDexKeyValidatorConfig(authentication, idpSecret, clusterDomain)
 			validatorEnv := validatorConfig.RequiredEnv("")
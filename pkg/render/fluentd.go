@@ -1198,39 +1198,11 @@ func (c *fluentdComponent) eksLogForwarderClusterRole() *rbacv1.ClusterRole {
 }
 
 func (c *fluentdComponent) allowTigeraPolicy() *v3.NetworkPolicy {
-	egressRules := []v3.Rule{}
-	if c.cfg.ManagedCluster {
-		egressRules = append(egressRules, v3.Rule{
-			Action:   v3.Deny,
-			Protocol: &networkpolicy.TCPProtocol,
-			Source:   v3.EntityRule{},
-			Destination: v3.EntityRule{
-				NamespaceSelector: fmt.Sprintf("projectcalico.org/name == '%s'", GuardianNamespace),
-				Selector:          networkpolicy.KubernetesAppSelector(GuardianServiceName),
-				NotPorts:          networkpolicy.Ports(8080),
-			},
-		})
-	} else {
-		egressRules = append(egressRules, v3.Rule{
-			Action:   v3.Deny,
-			Protocol: &networkpolicy.TCPProtocol,
-			Source:   v3.EntityRule{},
-			Destination: v3.EntityRule{
-				NamespaceSelector: fmt.Sprintf("projectcalico.org/name == '%s'", ElasticsearchNamespace),
-				Selector:          networkpolicy.KubernetesAppSelector("tigera-secure-es-gateway"),
-				NotPorts:          networkpolicy.Ports(5554),
-			},
-		})
-		egressRules = append(egressRules, v3.Rule{
-			Action:   v3.Deny,
-			Protocol: &networkpolicy.TCPProtocol,
-			Source:   v3.EntityRule{},
-			Destination: v3.EntityRule{
-				NamespaceSelector: fmt.Sprintf("projectcalico.org/name == '%s'", ElasticsearchNamespace),
-				Selector:          networkpolicy.KubernetesAppSelector("tigera-linseed"),
-				NotPorts:          networkpolicy.Ports(8444),
-			},
-		})
+	egressRules := networkpolicy.DefaultHelper().DataStoreDenyOtherPortsRules(networkpolicy.DataStoreConfig{
+		ManagedCluster: c.cfg.ManagedCluster,
+		ESGateway:      true,
+	})
+	if !c.cfg.ManagedCluster {
 		egressRules = networkpolicy.AppendDNSEgressRules(egressRules, c.cfg.Installation.KubernetesProvider.IsOpenShift())
 	}
 	egressRules = append(egressRules, v3.Rule{
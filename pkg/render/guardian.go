@@ -18,6 +18,7 @@ package render
 
 import (
 	"net"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -32,8 +33,10 @@ import (
 	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/components"
 	rcomponents "github.com/tigera/operator/pkg/render/common/components"
+	"github.com/tigera/operator/pkg/render/common/configmap"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/common/portregistry"
 	"github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/render/common/securitycontext"
 	"github.com/tigera/operator/pkg/render/common/securitycontextconstraints"
@@ -51,12 +54,15 @@ const (
 	GuardianServiceName            = "tigera-guardian"
 	GuardianVolumeName             = "tigera-guardian-certs"
 	GuardianSecretName             = "tigera-managed-cluster-connection"
-	GuardianTargetPort             = 8080
+	GuardianTargetPort             = portregistry.Guardian
 	GuardianPolicyName             = networkpolicy.TigeraComponentPolicyPrefix + "guardian-access"
+
+	// guardianShutdownTimeoutEnvVar tells guardian how long it may drain its tunnel after it is told to terminate.
+	guardianShutdownTimeoutEnvVar = "GUARDIAN_SHUTDOWN_TIMEOUT_SECS"
 )
 
 var (
-	GuardianEntityRule                = networkpolicy.CreateEntityRule(GuardianNamespace, GuardianDeploymentName, GuardianTargetPort)
+	GuardianEntityRule                = networkpolicy.CreateEntityRule(GuardianNamespace, GuardianDeploymentName, uint16(GuardianTargetPort))
 	GuardianSourceEntityRule          = networkpolicy.CreateSourceEntityRule(GuardianNamespace, GuardianDeploymentName)
 	GuardianServiceSelectorEntityRule = networkpolicy.CreateServiceSelectorEntityRule(GuardianNamespace, GuardianName)
 )
@@ -82,6 +88,7 @@ func GuardianPolicy(cfg *GuardianConfiguration) (Component, error) {
 // GuardianConfiguration contains all the config information needed to render the component.
 type GuardianConfiguration struct {
 	URL               string
+	FailoverURLs      []string
 	PullSecrets       []*corev1.Secret
 	OpenShift         bool
 	Installation      *operatorv1.InstallationSpec
@@ -110,6 +117,10 @@ func (c *GuardianComponent) SupportedOSType() rmeta.OSType {
 	return rmeta.OSTypeLinux
 }
 
+func (c *GuardianComponent) ReplicatedSecretsKey() string {
+	return "guardian"
+}
+
 func (c *GuardianComponent) Objects() ([]client.Object, []client.Object) {
 	objs := []client.Object{
 		CreateNamespace(GuardianNamespace, c.cfg.Installation.KubernetesProvider, PSSRestricted),
@@ -123,8 +134,9 @@ func (c *GuardianComponent) Objects() ([]client.Object, []client.Object) {
 		c.deployment(),
 		c.service(),
 		secret.CopyToNamespace(GuardianNamespace, c.cfg.TunnelSecret)[0],
-		c.cfg.TrustedCertBundle.ConfigMap(GuardianNamespace),
-
+	)
+	objs = append(objs, configmap.ToRuntimeObjects(c.cfg.TrustedCertBundle.ConfigMaps(GuardianNamespace)...)...)
+	objs = append(objs,
 		// Add tigera-manager service account for impersonation. In managed clusters, the tigera-manager
 		// service account is always within the tigera-manager namespace - regardless of (multi)tenancy mode.
 		CreateNamespace(ManagerNamespace, c.cfg.Installation.KubernetesProvider, PSSRestricted),
@@ -267,7 +279,7 @@ func (c *GuardianComponent) deployment() *appsv1.Deployment {
 				Spec: corev1.PodSpec{
 					NodeSelector:       c.cfg.Installation.ControlPlaneNodeSelector,
 					ServiceAccountName: GuardianServiceAccountName,
-					Tolerations:        append(c.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateCriticalAddonsAndControlPlane...),
+					Tolerations:        rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.TolerateCriticalAddonsAndControlPlane...),
 					ImagePullSecrets:   secret.GetReferenceList(c.cfg.PullSecrets),
 					Containers:         c.container(),
 					Volumes:            c.volumes(),
@@ -281,6 +293,7 @@ func (c *GuardianComponent) deployment() *appsv1.Deployment {
 			rcomponents.ApplyDeploymentOverrides(d, overrides)
 		}
 	}
+	SetShutdownTimeout(&d.Spec.Template.Spec, GuardianDeploymentName, guardianShutdownTimeoutEnvVar)
 	return d
 }
 
@@ -299,22 +312,27 @@ func (c *GuardianComponent) volumes() []corev1.Volume {
 }
 
 func (c *GuardianComponent) container() []corev1.Container {
+	env := []corev1.EnvVar{
+		{Name: "GUARDIAN_PORT", Value: "9443"},
+		{Name: "GUARDIAN_LOGLEVEL", Value: "INFO"},
+		{Name: "GUARDIAN_VOLTRON_URL", Value: c.cfg.URL},
+		{Name: "GUARDIAN_VOLTRON_CA_TYPE", Value: string(c.cfg.TunnelCAType)},
+		{Name: "GUARDIAN_PACKET_CAPTURE_CA_BUNDLE_PATH", Value: c.cfg.TrustedCertBundle.MountPath()},
+		{Name: "GUARDIAN_PROMETHEUS_CA_BUNDLE_PATH", Value: c.cfg.TrustedCertBundle.MountPath()},
+		{Name: "GUARDIAN_QUERYSERVER_CA_BUNDLE_PATH", Value: c.cfg.TrustedCertBundle.MountPath()},
+		{Name: "GUARDIAN_FIPS_MODE_ENABLED", Value: operatorv1.IsFIPSModeEnabledString(c.cfg.Installation.FIPSMode)},
+	}
+	if len(c.cfg.FailoverURLs) > 0 {
+		env = append(env, corev1.EnvVar{Name: "GUARDIAN_VOLTRON_FAILOVER_URLS", Value: strings.Join(c.cfg.FailoverURLs, ",")})
+	}
+
 	return []corev1.Container{
 		{
 			Name:            GuardianDeploymentName,
 			Image:           c.image,
 			ImagePullPolicy: ImagePullPolicy(),
-			Env: []corev1.EnvVar{
-				{Name: "GUARDIAN_PORT", Value: "9443"},
-				{Name: "GUARDIAN_LOGLEVEL", Value: "INFO"},
-				{Name: "GUARDIAN_VOLTRON_URL", Value: c.cfg.URL},
-				{Name: "GUARDIAN_VOLTRON_CA_TYPE", Value: string(c.cfg.TunnelCAType)},
-				{Name: "GUARDIAN_PACKET_CAPTURE_CA_BUNDLE_PATH", Value: c.cfg.TrustedCertBundle.MountPath()},
-				{Name: "GUARDIAN_PROMETHEUS_CA_BUNDLE_PATH", Value: c.cfg.TrustedCertBundle.MountPath()},
-				{Name: "GUARDIAN_QUERYSERVER_CA_BUNDLE_PATH", Value: c.cfg.TrustedCertBundle.MountPath()},
-				{Name: "GUARDIAN_FIPS_MODE_ENABLED", Value: operatorv1.IsFIPSModeEnabledString(c.cfg.Installation.FIPSMode)},
-			},
-			VolumeMounts: c.volumeMounts(),
+			Env:             env,
+			VolumeMounts:    c.volumeMounts(),
 			LivenessProbe: &corev1.Probe{
 				ProbeHandler: corev1.ProbeHandler{
 					HTTPGet: &corev1.HTTPGetAction{
@@ -378,42 +396,13 @@ func guardianAllowTigeraPolicy(cfg *GuardianConfiguration) (*v3.NetworkPolicy, e
 		},
 	}...)
 
-	// Assumes address has the form "host:port", required by net.Dial for TCP.
-	host, port, err := net.SplitHostPort(cfg.URL)
-	if err != nil {
-		return nil, err
-	}
-	parsedPort, err := numorstring.PortFromString(port)
-	if err != nil {
-		return nil, err
-	}
-	parsedIp := net.ParseIP(host)
-	if parsedIp == nil {
-		// Assume host is a valid hostname.
-		egressRules = append(egressRules, v3.Rule{
-			Action:   v3.Allow,
-			Protocol: &networkpolicy.TCPProtocol,
-			Destination: v3.EntityRule{
-				Domains: []string{host},
-				Ports:   []numorstring.Port{parsedPort},
-			},
-		})
-	} else {
-		var netSuffix string
-		if parsedIp.To4() != nil {
-			netSuffix = "/32"
-		} else {
-			netSuffix = "/128"
+	// Allow Guardian to reach the management cluster at each of the addresses that it may connect to.
+	for _, addr := range append([]string{cfg.URL}, cfg.FailoverURLs...) {
+		rule, err := guardianManagementClusterEgressRule(addr)
+		if err != nil {
+			return nil, err
 		}
-
-		egressRules = append(egressRules, v3.Rule{
-			Action:   v3.Allow,
-			Protocol: &networkpolicy.TCPProtocol,
-			Destination: v3.EntityRule{
-				Nets:  []string{parsedIp.String() + netSuffix},
-				Ports: []numorstring.Port{parsedPort},
-			},
-		})
+		egressRules = append(egressRules, rule)
 	}
 
 	egressRules = append(egressRules, v3.Rule{Action: v3.Pass})
@@ -488,3 +477,41 @@ func guardianAllowTigeraPolicy(cfg *GuardianConfiguration) (*v3.NetworkPolicy, e
 
 	return policy, nil
 }
+
+// guardianManagementClusterEgressRule returns a rule that allows egress to the given management cluster address.
+func guardianManagementClusterEgressRule(addr string) (v3.Rule, error) {
+	// Assumes address has the form "host:port", required by net.Dial for TCP.
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return v3.Rule{}, err
+	}
+	parsedPort, err := numorstring.PortFromString(port)
+	if err != nil {
+		return v3.Rule{}, err
+	}
+	parsedIp := net.ParseIP(host)
+	if parsedIp == nil {
+		// Assume host is a valid hostname.
+		return v3.Rule{
+			Action:   v3.Allow,
+			Protocol: &networkpolicy.TCPProtocol,
+			Destination: v3.EntityRule{
+				Domains: []string{host},
+				Ports:   []numorstring.Port{parsedPort},
+			},
+		}, nil
+	}
+
+	netSuffix := "/128"
+	if parsedIp.To4() != nil {
+		netSuffix = "/32"
+	}
+	return v3.Rule{
+		Action:   v3.Allow,
+		Protocol: &networkpolicy.TCPProtocol,
+		Destination: v3.EntityRule{
+			Nets:  []string{parsedIp.String() + netSuffix},
+			Ports: []numorstring.Port{parsedPort},
+		},
+	}, nil
+}
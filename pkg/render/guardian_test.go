@@ -231,6 +231,22 @@ var _ = Describe("Rendering tests", func() {
 				Expect(managementClusterEgressRule.Destination.Domains).To(Equal([]string{"mydomain.io"}))
 				Expect(managementClusterEgressRule.Destination.Ports).To(Equal(networkpolicy.Ports(8080)))
 			})
+
+			It("should allow Guardian to reach the failover management cluster addresses", func() {
+				cfg := createGuardianConfig(operatorv1.InstallationSpec{Registry: "my-reg/"}, "127.0.0.1:1234", false)
+				cfg.FailoverURLs = []string{"backup.mydomain.io:8080", "[fd00::12]:9449"}
+				g, err := render.GuardianPolicy(cfg)
+				Expect(err).NotTo(HaveOccurred())
+				resources, _ = g.Objects()
+
+				policy := testutils.GetAllowTigeraPolicyFromResources(policyName, resources)
+				Expect(policy.Spec.Egress[5].Destination.Nets).To(Equal([]string{"127.0.0.1/32"}))
+				Expect(policy.Spec.Egress[6].Destination.Domains).To(Equal([]string{"backup.mydomain.io"}))
+				Expect(policy.Spec.Egress[6].Destination.Ports).To(Equal(networkpolicy.Ports(8080)))
+				Expect(policy.Spec.Egress[7].Destination.Nets).To(Equal([]string{"fd00::12/128"}))
+				Expect(policy.Spec.Egress[7].Destination.Ports).To(Equal(networkpolicy.Ports(9449)))
+				Expect(policy.Spec.Egress[8].Action).To(BeEquivalentTo(v3.Pass))
+			})
 		})
 	})
 })
@@ -274,6 +290,21 @@ var _ = Describe("guardian", func() {
 			rtest.ExpectEnv(container.Env, "GUARDIAN_VOLTRON_CA_TYPE", "Tigera")
 		})
 
+		It("should render the failover management cluster addresses", func() {
+			resources, _ := render.Guardian(cfg).Objects()
+			deployment := rtest.GetResource(resources, render.GuardianDeploymentName, render.GuardianNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			container := rtest.GetContainer(deployment.Spec.Template.Spec.Containers, "tigera-guardian")
+			Expect(container.Env).NotTo(ContainElement(HaveField("Name", "GUARDIAN_VOLTRON_FAILOVER_URLS")))
+
+			cfg.URL = "voltron.example.com:9449"
+			cfg.FailoverURLs = []string{"voltron-backup.example.com:9449", "10.0.0.2:9449"}
+			resources, _ = render.Guardian(cfg).Objects()
+			deployment = rtest.GetResource(resources, render.GuardianDeploymentName, render.GuardianNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			container = rtest.GetContainer(deployment.Spec.Template.Spec.Containers, "tigera-guardian")
+			rtest.ExpectEnv(container.Env, "GUARDIAN_VOLTRON_URL", "voltron.example.com:9449")
+			rtest.ExpectEnv(container.Env, "GUARDIAN_VOLTRON_FAILOVER_URLS", "voltron-backup.example.com:9449,10.0.0.2:9449")
+		})
+
 		It("should render when enabled", func() {
 			cfg.TunnelCAType = operatorv1.CATypePublic
 
@@ -329,5 +360,35 @@ var _ = Describe("guardian", func() {
 			Expect(container).NotTo(BeNil())
 			Expect(container.Resources).To(Equal(guardianResources))
 		})
+
+		It("should override the termination grace period and pass it to guardian as its shutdown timeout", func() {
+			var gracePeriod int64 = 120
+			cfg.ManagementClusterConnection = &operatorv1.ManagementClusterConnection{
+				Spec: operatorv1.ManagementClusterConnectionSpec{
+					GuardianDeployment: &operatorv1.GuardianDeployment{
+						Spec: &operatorv1.GuardianDeploymentSpec{
+							Template: &operatorv1.GuardianDeploymentPodTemplateSpec{
+								Spec: &operatorv1.GuardianDeploymentPodSpec{
+									TerminationGracePeriodSeconds: &gracePeriod,
+								},
+							},
+						},
+					},
+				},
+			}
+
+			g := render.Guardian(cfg)
+			resources, _ := g.Objects()
+			Expect(resources).ToNot(BeNil())
+
+			deployment, ok := rtest.GetResource(resources, render.GuardianDeploymentName, render.GuardianNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue())
+			Expect(deployment.Spec.Template.Spec.TerminationGracePeriodSeconds).To(Equal(&gracePeriod))
+
+			container := rtest.GetContainer(deployment.Spec.Template.Spec.Containers, "tigera-guardian")
+			Expect(container).NotTo(BeNil())
+			Expect(container.Lifecycle).To(BeNil())
+			Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "GUARDIAN_SHUTDOWN_TIMEOUT_SECS", Value: "120"}))
+		})
 	})
 })
@@ -115,6 +115,16 @@ type IntrusionDetectionConfiguration struct {
 	BindNamespaces  []string
 	Tenant          *operatorv1.Tenant
 	ExternalElastic bool
+
+	// ThreatFeedMirror, if set, renders a GlobalThreatFeed for each feed in the index of the mirror.
+	ThreatFeedMirror *ThreatFeedMirrorConfiguration
+
+	// StaleThreatFeeds are the names of the GlobalThreatFeeds that were read from the mirror but are no longer in
+	// its index, or whose mirror has been removed.
+	StaleThreatFeeds []string
+
+	// ThreatFeedExport, if set, is written to the threat feed export ConfigMap.
+	ThreatFeedExport *ThreatFeedIndex
 }
 
 type intrusionDetectionComponent struct {
@@ -150,6 +160,10 @@ func (c *intrusionDetectionComponent) SupportedOSType() rmeta.OSType {
 	return rmeta.OSTypeLinux
 }
 
+func (c *intrusionDetectionComponent) ReplicatedSecretsKey() string {
+	return "intrusion-detection"
+}
+
 func (c *intrusionDetectionComponent) Objects() ([]client.Object, []client.Object) {
 	// Configure pod security standard. If syslog forwarding is enabled, we
 	// need hostpath volumes which require a privileged PSS.
@@ -167,7 +181,18 @@ func (c *intrusionDetectionComponent) Objects() ([]client.Object, []client.Objec
 		objs = append(objs, c.globalAlertTemplates()...)
 	}
 
-	objs = append(objs, secret.ToRuntimeObjects(secret.CopyToNamespace(c.cfg.Namespace, c.cfg.PullSecrets...)...)...)
+	var threatFeedObjsToDelete []client.Object
+	if !c.cfg.Tenant.MultiTenant() {
+		// The GlobalThreatFeeds are cluster-scoped, so they're only mirrored and exported for a single tenant.
+		var threatFeedObjs []client.Object
+		threatFeedObjs, threatFeedObjsToDelete = c.threatFeedObjects()
+		objs = append(objs, threatFeedObjs...)
+	}
+
+	if !c.cfg.Tenant.MultiTenant() {
+		// In multi-tenant management clusters, the pull secrets are copied into the tenant namespace by the tenant controller.
+		objs = append(objs, secret.ToRuntimeObjects(secret.CopyToNamespace(c.cfg.Namespace, c.cfg.PullSecrets...)...)...)
+	}
 
 	objs = append(objs,
 		c.intrusionDetectionControllerAllowTigeraPolicy(),
@@ -190,6 +215,7 @@ func (c *intrusionDetectionComponent) Objects() ([]client.Object, []client.Objec
 		c.intrusionDetectionPSPClusterRole(),
 		c.intrusionDetectionPSPClusterRoleBinding(),
 	}
+	objsToDelete = append(objsToDelete, threatFeedObjsToDelete...)
 
 	if !c.cfg.ManagedCluster && !c.cfg.Tenant.MultiTenant() {
 		// Delete any anomaly detection components that might still exist.
@@ -748,19 +774,25 @@ func (c *intrusionDetectionComponent) intrusionDetectionControllerContainer() co
 		ImagePullPolicy: ImagePullPolicy(),
 		Env:             envs,
 		// Needed for permissions to write to the audit log
-		LivenessProbe: &corev1.Probe{
-			ProbeHandler: corev1.ProbeHandler{
-				Exec: &corev1.ExecAction{
-					Command: []string{
-						"/usr/bin/healthz",
-						"liveness",
-					},
+		LivenessProbe:   c.intrusionDetectionControllerProbe(),
+		StartupProbe:    StartupProbe(c.intrusionDetectionControllerProbe()),
+		SecurityContext: sc,
+		VolumeMounts:    volumeMounts,
+	}
+}
+
+// intrusionDetectionControllerProbe returns the liveness probe for the intrusion detection controller container.
+func (c *intrusionDetectionComponent) intrusionDetectionControllerProbe() *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{
+					"/usr/bin/healthz",
+					"liveness",
 				},
 			},
-			InitialDelaySeconds: 5,
 		},
-		SecurityContext: sc,
-		VolumeMounts:    volumeMounts,
+		InitialDelaySeconds: 5,
 	}
 }
 
@@ -1047,19 +1079,7 @@ func (c *intrusionDetectionComponent) intrusionDetectionControllerAllowTigeraPol
 		},
 	}
 	egressRules = networkpolicy.AppendDNSEgressRules(egressRules, c.cfg.OpenShift)
-	if c.cfg.ManagedCluster {
-		egressRules = append(egressRules, v3.Rule{
-			Action:      v3.Allow,
-			Protocol:    &networkpolicy.TCPProtocol,
-			Destination: GuardianEntityRule,
-		})
-	} else {
-		egressRules = append(egressRules, v3.Rule{
-			Action:      v3.Allow,
-			Protocol:    &networkpolicy.TCPProtocol,
-			Destination: helper.LinseedEntityRule(),
-		})
-	}
+	egressRules = append(egressRules, helper.DataStoreEgressRules(networkpolicy.DataStoreConfig{ManagedCluster: c.cfg.ManagedCluster})...)
 	egressRules = append(egressRules, []v3.Rule{
 		{
 			Action:      v3.Allow,
@@ -597,11 +597,72 @@ var _ = Describe("Intrusion Detection rendering tests", func() {
 			&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "tigera-linseed", Namespace: "tigera-intrusion-detection"}},
 			&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "intrusion-detection-psp"}},
 			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "intrusion-detection-psp"}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "tigera-threat-feed-mirror-auth", Namespace: "tigera-intrusion-detection"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "tigera-threat-feed-export", Namespace: "tigera-operator"}},
 		}
 
 		rtest.ExpectResources(toRemove, expectedDeletes)
 	})
 
+	It("should render the GlobalThreatFeeds of a threat feed mirror", func() {
+		index, err := render.ParseThreatFeedIndex([]byte(`{"feeds": [
+			{"name": "ips", "file": "ips.txt", "period": "1h"},
+			{"name": "domains", "content": "DomainNameSet", "file": "dns/domains.json", "format": {"json": {"path": "$.domains"}}}
+		]}`))
+		Expect(err).NotTo(HaveOccurred())
+		cfg.ThreatFeedMirror = &render.ThreatFeedMirrorConfiguration{
+			URL:   "https://mirror.example.com/feeds",
+			Index: index,
+			AuthSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "mirror-auth", Namespace: common.OperatorNamespace()},
+				Data:       map[string][]byte{render.ThreatFeedMirrorAuthKey: []byte("Bearer token")},
+			},
+		}
+		cfg.StaleThreatFeeds = []string{"removed"}
+		cfg.ThreatFeedExport = &render.ThreatFeedIndex{Feeds: []render.ThreatFeedIndexEntry{{Name: "source", File: "source"}}}
+
+		toCreate, toRemove := render.IntrusionDetection(cfg).Objects()
+
+		ips := rtest.GetResource(toCreate, "ips", "", "projectcalico.org", "v3", "GlobalThreatFeed").(*v3.GlobalThreatFeed)
+		Expect(ips.Labels).To(HaveKeyWithValue(render.ThreatFeedMirrorLabel, "true"))
+		Expect(ips.Spec.Pull.Period).To(Equal("1h"))
+		Expect(ips.Spec.Pull.HTTP.URL).To(Equal("https://mirror.example.com/feeds/ips.txt"))
+		Expect(ips.Spec.Pull.HTTP.Headers).To(Equal([]v3.HTTPHeader{{
+			Name: "Authorization",
+			ValueFrom: &v3.HTTPHeaderSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: render.ThreatFeedMirrorAuthSecretName},
+				Key:                  render.ThreatFeedMirrorAuthKey,
+			}},
+		}}))
+
+		domains := rtest.GetResource(toCreate, "domains", "", "projectcalico.org", "v3", "GlobalThreatFeed").(*v3.GlobalThreatFeed)
+		Expect(domains.Spec.Content).To(Equal(v3.ThreatFeedContentDomainNameSet))
+		Expect(domains.Spec.Pull.HTTP.URL).To(Equal("https://mirror.example.com/feeds/dns/domains.json"))
+		Expect(domains.Spec.Pull.HTTP.Format.JSON.Path).To(Equal("$.domains"))
+
+		auth := rtest.GetResource(toCreate, render.ThreatFeedMirrorAuthSecretName, render.IntrusionDetectionNamespace, "", "v1", "Secret").(*corev1.Secret)
+		Expect(auth.Data).To(Equal(map[string][]byte{render.ThreatFeedMirrorAuthKey: []byte("Bearer token")}))
+
+		export := rtest.GetResource(toCreate, render.ThreatFeedExportConfigMapName, common.OperatorNamespace(), "", "v1", "ConfigMap").(*corev1.ConfigMap)
+		Expect(export.Data[render.ThreatFeedIndexKey]).To(ContainSubstring(`"name": "source"`))
+
+		Expect(rtest.GetResource(toRemove, "removed", "", "projectcalico.org", "v3", "GlobalThreatFeed")).NotTo(BeNil())
+	})
+
+	It("should reject threat feed indexes with invalid feeds", func() {
+		for _, index := range []string{
+			`{"feeds": [{"name": "Invalid_Name", "file": "a"}]}`,
+			`{"feeds": [{"name": "a", "file": "a"}, {"name": "a", "file": "b"}]}`,
+			`{"feeds": [{"name": "a", "file": "../a"}]}`,
+			`{"feeds": [{"name": "a", "file": "/a"}]}`,
+			`{"feeds": [{"name": "a", "file": "https://example.com/a"}]}`,
+			`{"feeds": [{"name": "a", "file": "a", "content": "URLs"}]}`,
+		} {
+			_, err := render.ParseThreatFeedIndex([]byte(index))
+			Expect(err).To(HaveOccurred(), index)
+		}
+	})
+
 	It("should render an init container for pods when certificate management is enabled", func() {
 		ca, _ := tls.MakeCA(rmeta.DefaultOperatorCASignerName())
 		cert, _, _ := ca.Config.GetPEMBytes() // create a valid pem block
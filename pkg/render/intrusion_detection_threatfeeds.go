@@ -0,0 +1,210 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/tigera/operator/pkg/common"
+)
+
+const (
+	// ThreatFeedExportConfigMapName is the ConfigMap in the operator namespace that the index of the pulled threat
+	// feeds is exported to, under ThreatFeedIndexKey.
+	ThreatFeedExportConfigMapName = "tigera-threat-feed-export"
+	ThreatFeedIndexKey            = "index.json"
+
+	// ThreatFeedMirrorAuthSecretName is the copy of the mirror's auth secret in the intrusion detection namespace,
+	// which the mirrored GlobalThreatFeeds read their Authorization header from.
+	ThreatFeedMirrorAuthSecretName = "tigera-threat-feed-mirror-auth"
+	ThreatFeedMirrorAuthKey        = "authorization"
+
+	// ThreatFeedMirrorLabel is set on the GlobalThreatFeeds that are read from the mirror, so that the operator can
+	// tell them apart from the feeds that it doesn't manage.
+	ThreatFeedMirrorLabel = "operator.tigera.io/threat-feed-mirror"
+)
+
+// ThreatFeedIndex lists threat feeds and the files of their content. It is the format that the pulled feeds of a
+// cluster are exported in, and that the feeds in a mirror are imported from.
+type ThreatFeedIndex struct {
+	Feeds []ThreatFeedIndexEntry `json:"feeds"`
+}
+
+// ThreatFeedIndexEntry is a threat feed in a ThreatFeedIndex.
+type ThreatFeedIndexEntry struct {
+	// Name is the name of the GlobalThreatFeed.
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// Content is IPSet or DomainNameSet. Default: IPSet
+	Content v3.ThreatFeedContent `json:"content,omitempty"`
+
+	// Period is how often the feed is pulled, e.g. 24h.
+	Period string `json:"period,omitempty"`
+
+	// File is the path of the content of the feed, relative to the URL of the mirror.
+	File string `json:"file"`
+
+	// SourceURL is where the feed was pulled from in the cluster that it was exported from. It isn't used on import.
+	SourceURL string `json:"sourceURL,omitempty"`
+
+	Format *v3.ThreatFeedFormat `json:"format,omitempty"`
+}
+
+// ThreatFeedMirrorConfiguration contains the information needed to render the GlobalThreatFeeds of a mirror.
+type ThreatFeedMirrorConfiguration struct {
+	// URL is the URL of the mirror, which the file of each feed is relative to.
+	URL   string
+	Index *ThreatFeedIndex
+
+	// AuthSecret holds the Authorization header that is sent to the mirror, if any.
+	AuthSecret *corev1.Secret
+}
+
+// ParseThreatFeedIndex parses and validates a ThreatFeedIndex.
+func ParseThreatFeedIndex(data []byte) (*ThreatFeedIndex, error) {
+	index := &ThreatFeedIndex{}
+	if err := json.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("failed to parse threat feed index: %w", err)
+	}
+	seen := map[string]bool{}
+	for _, f := range index.Feeds {
+		if errs := validation.IsDNS1123Subdomain(f.Name); len(errs) > 0 {
+			return nil, fmt.Errorf("threat feed name %q is invalid: %s", f.Name, strings.Join(errs, ", "))
+		}
+		if seen[f.Name] {
+			return nil, fmt.Errorf("threat feed %q is listed more than once", f.Name)
+		}
+		seen[f.Name] = true
+
+		if f.File == "" || path.IsAbs(f.File) || strings.Contains(f.File, "..") || strings.Contains(f.File, "://") {
+			return nil, fmt.Errorf("file %q of threat feed %q must be a relative path", f.File, f.Name)
+		}
+		switch f.Content {
+		case "", v3.ThreatFeedContentIPset, v3.ThreatFeedContentDomainNameSet:
+		default:
+			return nil, fmt.Errorf("content %q of threat feed %q is not supported", f.Content, f.Name)
+		}
+	}
+	return index, nil
+}
+
+// ExportThreatFeeds returns the index of the GlobalThreatFeeds that are pulled over HTTP, leaving out those that are
+// themselves read from a mirror. The headers of the feeds aren't exported, since they may reference credentials.
+func ExportThreatFeeds(feeds []v3.GlobalThreatFeed) *ThreatFeedIndex {
+	index := &ThreatFeedIndex{Feeds: []ThreatFeedIndexEntry{}}
+	for _, f := range feeds {
+		if f.Spec.Pull == nil || f.Spec.Pull.HTTP == nil || f.Labels[ThreatFeedMirrorLabel] != "" {
+			continue
+		}
+		entry := ThreatFeedIndexEntry{
+			Name:        f.Name,
+			Description: f.Spec.Description,
+			Content:     f.Spec.Content,
+			Period:      f.Spec.Pull.Period,
+			File:        f.Name,
+			SourceURL:   f.Spec.Pull.HTTP.URL,
+		}
+		if format := f.Spec.Pull.HTTP.Format; format != (v3.ThreatFeedFormat{}) {
+			entry.Format = format.DeepCopy()
+		}
+		index.Feeds = append(index.Feeds, entry)
+	}
+	sort.Slice(index.Feeds, func(i, j int) bool { return index.Feeds[i].Name < index.Feeds[j].Name })
+	return index
+}
+
+// threatFeedObjects returns the objects of the threat feed mirror and export, and those to delete.
+func (c *intrusionDetectionComponent) threatFeedObjects() ([]client.Object, []client.Object) {
+	var objs, objsToDelete []client.Object
+
+	mirror := c.cfg.ThreatFeedMirror
+	authSecret := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: ThreatFeedMirrorAuthSecretName, Namespace: c.cfg.Namespace},
+	}
+	if mirror != nil && mirror.AuthSecret != nil {
+		authSecret.Data = map[string][]byte{ThreatFeedMirrorAuthKey: mirror.AuthSecret.Data[ThreatFeedMirrorAuthKey]}
+		objs = append(objs, authSecret)
+	} else {
+		objsToDelete = append(objsToDelete, authSecret)
+	}
+	if mirror != nil {
+		for _, f := range mirror.Index.Feeds {
+			objs = append(objs, c.mirroredThreatFeed(f))
+		}
+	}
+	for _, name := range c.cfg.StaleThreatFeeds {
+		objsToDelete = append(objsToDelete, &v3.GlobalThreatFeed{
+			TypeMeta:   metav1.TypeMeta{Kind: v3.KindGlobalThreatFeed, APIVersion: "projectcalico.org/v3"},
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+		})
+	}
+
+	export := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: ThreatFeedExportConfigMapName, Namespace: common.OperatorNamespace()},
+	}
+	if c.cfg.ThreatFeedExport != nil {
+		// The index only contains types that marshal without error.
+		data, _ := json.MarshalIndent(c.cfg.ThreatFeedExport, "", "  ")
+		export.Data = map[string]string{ThreatFeedIndexKey: string(data)}
+		objs = append(objs, export)
+	} else {
+		objsToDelete = append(objsToDelete, export)
+	}
+	return objs, objsToDelete
+}
+
+func (c *intrusionDetectionComponent) mirroredThreatFeed(f ThreatFeedIndexEntry) *v3.GlobalThreatFeed {
+	mirror := c.cfg.ThreatFeedMirror
+	httpPull := &v3.HTTPPull{URL: strings.TrimSuffix(mirror.URL, "/") + "/" + f.File}
+	if f.Format != nil {
+		httpPull.Format = *f.Format
+	}
+	if mirror.AuthSecret != nil {
+		httpPull.Headers = []v3.HTTPHeader{{
+			Name: "Authorization",
+			ValueFrom: &v3.HTTPHeaderSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: ThreatFeedMirrorAuthSecretName},
+					Key:                  ThreatFeedMirrorAuthKey,
+				},
+			},
+		}}
+	}
+	return &v3.GlobalThreatFeed{
+		TypeMeta: metav1.TypeMeta{Kind: v3.KindGlobalThreatFeed, APIVersion: "projectcalico.org/v3"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   f.Name,
+			Labels: map[string]string{ThreatFeedMirrorLabel: "true"},
+		},
+		Spec: v3.GlobalThreatFeedSpec{
+			Content:     f.Content,
+			Description: f.Description,
+			Pull:        &v3.Pull{Period: f.Period, HTTP: httpPull},
+		},
+	}
+}
@@ -629,7 +629,7 @@ func (c *kubeControllersComponent) controllersDeployment() *appsv1.Deployment {
 	}
 	podSpec := corev1.PodSpec{
 		NodeSelector:       c.cfg.Installation.ControlPlaneNodeSelector,
-		Tolerations:        append(c.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateCriticalAddonsAndControlPlane...),
+		Tolerations:        rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.TolerateCriticalAddonsAndControlPlane...),
 		ImagePullSecrets:   c.cfg.Installation.ImagePullSecrets,
 		ServiceAccountName: c.kubeControllerServiceAccountName,
 		InitContainers:     initContainers,
@@ -745,6 +745,9 @@ func (c *kubeControllersComponent) annotations() map[string]string {
 	if c.cfg.KubeControllersGatewaySecret != nil {
 		am[render.ElasticsearchUserHashAnnotation] = rmeta.AnnotationHash(c.cfg.KubeControllersGatewaySecret.Data)
 	}
+	if c.kubeControllerName == KubeController {
+		rmeta.AddNetworkAttachments(am, c.cfg.Installation, operatorv1.MultusWorkloadCalicoKubeControllers)
+	}
 	return am
 }
 
@@ -876,6 +876,23 @@ var _ = Describe("kube-controllers rendering tests", func() {
 			Expect(d.Spec.Template.Spec.NodeSelector).To(HaveKeyWithValue("custom-node-selector", "value"))
 		})
 
+		It("should request Multus network attachments when configured", func() {
+			instance.CalicoNetwork.MultusCompatibility = &operatorv1.MultusCompatibility{
+				NetworkAttachments: []operatorv1.WorkloadNetworkAttachment{
+					{Workload: operatorv1.MultusWorkloadAPIServer, Networks: "other-net"},
+					{Workload: operatorv1.MultusWorkloadCalicoKubeControllers, Networks: "sriov-net1"},
+				},
+			}
+			component := kubecontrollers.NewCalicoKubeControllers(&cfg)
+			Expect(component.ResolveImages(nil)).To(BeNil())
+			resources, _ := component.Objects()
+
+			depResource := rtest.GetResource(resources, kubecontrollers.KubeController, common.CalicoNamespace, "apps", "v1", "Deployment")
+			Expect(depResource).ToNot(BeNil())
+			d := depResource.(*appsv1.Deployment)
+			Expect(d.Spec.Template.Annotations).To(HaveKeyWithValue(rmeta.MultusNetworksAnnotation, "sriov-net1"))
+		})
+
 		It("should override ControlPlaneTolerations when specified", func() {
 			cfg.Installation.ControlPlaneTolerations = rmeta.TolerateControlPlane
 
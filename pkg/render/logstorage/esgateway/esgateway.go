@@ -22,7 +22,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
@@ -31,10 +30,12 @@ import (
 	"github.com/tigera/operator/pkg/components"
 	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
+	rcomponents "github.com/tigera/operator/pkg/render/common/components"
 	"github.com/tigera/operator/pkg/render/common/elasticsearch"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/common/podaffinity"
+	"github.com/tigera/operator/pkg/render/common/portregistry"
 	"github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/render/common/securitycontext"
 	"github.com/tigera/operator/pkg/render/common/securitycontextconstraints"
@@ -51,11 +52,14 @@ const (
 	PolicyName            = networkpolicy.TigeraComponentPolicyPrefix + "es-gateway-access"
 	ElasticsearchPortName = "es-gateway-elasticsearch-port"
 	KibanaPortName        = "es-gateway-kibana-port"
-	Port                  = 5554
+	Port                  = portregistry.ESGateway
 
 	ElasticsearchHTTPSEndpoint = "https://tigera-secure-es-http.tigera-elasticsearch.svc:9200"
 
 	KibanaHTTPSEndpoint = "https://tigera-secure-kb-http.tigera-kibana.svc:5601"
+
+	// shutdownTimeoutEnvVar tells es-gateway how long it may drain its connections after it is told to terminate.
+	shutdownTimeoutEnvVar = "ES_GATEWAY_SHUTDOWN_TIMEOUT_SECS"
 )
 
 func EsGateway(c *Config) render.Component {
@@ -81,6 +85,7 @@ type Config struct {
 	EsAdminUserName            string
 	Namespace                  string
 	TruthNamespace             string
+	LogStorage                 *operatorv1.LogStorage
 }
 
 func (e *esGateway) ResolveImages(is *operatorv1.ImageSet) error {
@@ -245,16 +250,8 @@ func (e *esGateway) esGatewayDeployment() *appsv1.Deployment {
 					ImagePullPolicy: render.ImagePullPolicy(),
 					Env:             envVars,
 					VolumeMounts:    volumeMounts,
-					ReadinessProbe: &corev1.Probe{
-						ProbeHandler: corev1.ProbeHandler{
-							HTTPGet: &corev1.HTTPGetAction{
-								Path:   "/health",
-								Port:   intstr.FromInt(Port),
-								Scheme: corev1.URISchemeHTTPS,
-							},
-						},
-						InitialDelaySeconds: 10,
-					},
+					ReadinessProbe:  e.readinessProbe(),
+					StartupProbe:    render.StartupProbe(e.readinessProbe()),
 					SecurityContext: securitycontext.NewNonRootContext(),
 				},
 			},
@@ -265,7 +262,7 @@ func (e *esGateway) esGatewayDeployment() *appsv1.Deployment {
 		podTemplate.Spec.Affinity = podaffinity.NewPodAntiAffinity(DeploymentName, e.cfg.Namespace)
 	}
 
-	return &appsv1.Deployment{
+	d := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      DeploymentName,
@@ -286,6 +283,29 @@ func (e *esGateway) esGatewayDeployment() *appsv1.Deployment {
 			Replicas: e.cfg.Installation.ControlPlaneReplicas,
 		},
 	}
+
+	if e.cfg.LogStorage != nil {
+		if overrides := e.cfg.LogStorage.Spec.ESGatewayDeployment; overrides != nil {
+			rcomponents.ApplyDeploymentOverrides(d, overrides)
+		}
+	}
+	render.SetShutdownTimeout(&d.Spec.Template.Spec, DeploymentName, shutdownTimeoutEnvVar)
+
+	return d
+}
+
+// readinessProbe returns the readiness probe for the es-gateway container.
+func (e *esGateway) readinessProbe() *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:   "/health",
+				Port:   Port.IntOrString(),
+				Scheme: corev1.URISchemeHTTPS,
+			},
+		},
+		InitialDelaySeconds: 10,
+	}
 }
 
 func (e *esGateway) esGatewayServiceAccount() *corev1.ServiceAccount {
@@ -311,13 +331,13 @@ func (e *esGateway) esGatewayService() *corev1.Service {
 				{
 					Name:       ElasticsearchPortName,
 					Port:       int32(render.ElasticsearchDefaultPort),
-					TargetPort: intstr.FromInt(Port),
+					TargetPort: Port.IntOrString(),
 					Protocol:   corev1.ProtocolTCP,
 				},
 				{
 					Name:       KibanaPortName,
 					Port:       int32(kibana.Port),
-					TargetPort: intstr.FromInt(Port),
+					TargetPort: Port.IntOrString(),
 					Protocol:   corev1.ProtocolTCP,
 				},
 			},
@@ -353,7 +373,7 @@ func (e *esGateway) esGatewayAllowTigeraPolicy() *v3.NetworkPolicy {
 	}...)
 
 	esgatewayIngressDestinationEntityRule := v3.EntityRule{
-		Ports: networkpolicy.Ports(Port),
+		Ports: networkpolicy.Ports(uint16(Port)),
 	}
 	return &v3.NetworkPolicy{
 		TypeMeta: metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "projectcalico.org/v3"},
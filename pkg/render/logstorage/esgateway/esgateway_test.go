@@ -24,6 +24,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -205,6 +206,117 @@ var _ = Describe("ES Gateway rendering tests", func() {
 			Expect(d.Spec.Template.Spec.Tolerations).To(ConsistOf(t))
 		})
 
+		It("should override the termination grace period and pass it to es-gateway as its shutdown timeout", func() {
+			resources, _ := EsGateway(cfg).Objects()
+			d, ok := rtest.GetResource(resources, DeploymentName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue())
+			Expect(d.Spec.Template.Spec.TerminationGracePeriodSeconds).To(BeNil())
+			Expect(d.Spec.Template.Spec.Containers[0].Lifecycle).To(BeNil())
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "ES_GATEWAY_SHUTDOWN_TIMEOUT_SECS", Value: "30"}))
+
+			var gracePeriod int64 = 60
+			cfg.LogStorage = &operatorv1.LogStorage{
+				Spec: operatorv1.LogStorageSpec{
+					ESGatewayDeployment: &operatorv1.ESGatewayDeployment{
+						Spec: &operatorv1.ESGatewayDeploymentSpec{
+							Template: &operatorv1.ESGatewayDeploymentPodTemplateSpec{
+								Spec: &operatorv1.ESGatewayDeploymentPodSpec{
+									TerminationGracePeriodSeconds: &gracePeriod,
+								},
+							},
+						},
+					},
+				},
+			}
+
+			resources, _ = EsGateway(cfg).Objects()
+			d, ok = rtest.GetResource(resources, DeploymentName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(ok).To(BeTrue())
+			Expect(d.Spec.Template.Spec.TerminationGracePeriodSeconds).To(Equal(&gracePeriod))
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "ES_GATEWAY_SHUTDOWN_TIMEOUT_SECS", Value: "60"}))
+		})
+
+		Context("with ESGatewayDeployment overrides", func() {
+			const initContainerName = "tigera-secure-elasticsearch-cert-key-cert-provisioner"
+
+			BeforeEach(func() {
+				secret, err := certificatemanagement.CreateSelfSignedSecret("", "", "", nil)
+				Expect(err).NotTo(HaveOccurred())
+				installation.CertificateManagement = &operatorv1.CertificateManagement{CACert: secret.Data[corev1.TLSCertKey]}
+				cfg.ESGatewayKeyPair, _ = getTLS(installation)
+			})
+
+			It("should override the containers and init containers", func() {
+				resources := corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						"cpu":    resource.MustParse("2"),
+						"memory": resource.MustParse("300Mi"),
+					},
+					Requests: corev1.ResourceList{
+						"cpu":    resource.MustParse("1"),
+						"memory": resource.MustParse("150Mi"),
+					},
+				}
+				cfg.LogStorage = &operatorv1.LogStorage{
+					Spec: operatorv1.LogStorageSpec{
+						ESGatewayDeployment: &operatorv1.ESGatewayDeployment{
+							Spec: &operatorv1.ESGatewayDeploymentSpec{
+								Template: &operatorv1.ESGatewayDeploymentPodTemplateSpec{
+									Spec: &operatorv1.ESGatewayDeploymentPodSpec{
+										InitContainers: []operatorv1.ESGatewayDeploymentInitContainer{{
+											Name:  initContainerName,
+											Image: "registry.example.com/csr-init:patched",
+											Env:   []corev1.EnvVar{{Name: "EXTRA", Value: "value"}},
+										}},
+										Containers: []operatorv1.ESGatewayDeploymentContainer{{
+											Name:      DeploymentName,
+											Resources: &resources,
+										}},
+									},
+								},
+							},
+						},
+					},
+				}
+
+				objs, _ := EsGateway(cfg).Objects()
+				d, ok := rtest.GetResource(objs, DeploymentName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+				Expect(ok).To(BeTrue())
+				Expect(d.Spec.Template.Spec.Containers).To(HaveLen(1))
+				Expect(d.Spec.Template.Spec.Containers[0].Resources).To(Equal(resources))
+				Expect(d.Spec.Template.Spec.InitContainers).To(HaveLen(1))
+				initContainer := d.Spec.Template.Spec.InitContainers[0]
+				Expect(initContainer.Name).To(Equal(initContainerName))
+				Expect(initContainer.Image).To(Equal("registry.example.com/csr-init:patched"))
+				Expect(initContainer.Env).To(ContainElement(corev1.EnvVar{Name: "EXTRA", Value: "value"}))
+			})
+
+			It("should remove a disabled init container", func() {
+				disabled := true
+				cfg.LogStorage = &operatorv1.LogStorage{
+					Spec: operatorv1.LogStorageSpec{
+						ESGatewayDeployment: &operatorv1.ESGatewayDeployment{
+							Spec: &operatorv1.ESGatewayDeploymentSpec{
+								Template: &operatorv1.ESGatewayDeploymentPodTemplateSpec{
+									Spec: &operatorv1.ESGatewayDeploymentPodSpec{
+										InitContainers: []operatorv1.ESGatewayDeploymentInitContainer{{
+											Name:     initContainerName,
+											Disabled: &disabled,
+										}},
+									},
+								},
+							},
+						},
+					},
+				}
+
+				resources, _ := EsGateway(cfg).Objects()
+				d, ok := rtest.GetResource(resources, DeploymentName, render.ElasticsearchNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+				Expect(ok).To(BeTrue())
+				Expect(d.Spec.Template.Spec.InitContainers).To(BeEmpty())
+			})
+		})
+
 		Context("allow-tigera rendering", func() {
 			policyName := types.NamespacedName{Name: "allow-tigera.es-gateway-access", Namespace: "tigera-elasticsearch"}
 
@@ -23,7 +23,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apiserver/pkg/authentication/serviceaccount"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -39,6 +38,7 @@ import (
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/common/podaffinity"
+	"github.com/tigera/operator/pkg/render/common/portregistry"
 	"github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/render/common/securitycontext"
 	"github.com/tigera/operator/pkg/render/common/securitycontextconstraints"
@@ -52,8 +52,8 @@ const (
 	ServiceAccountName                                     = "tigera-linseed"
 	PolicyName                                             = networkpolicy.TigeraComponentPolicyPrefix + "linseed-access"
 	PortName                                               = "tigera-linseed"
-	TargetPort                                             = 8444
-	Port                                                   = 443
+	TargetPort                                             = portregistry.Linseed
+	Port                                                   = portregistry.LinseedService
 	ClusterRoleName                                        = "tigera-linseed"
 	MultiTenantManagedClustersAccessClusterRoleBindingName = "tigera-linseed-managed-cluster-access"
 )
@@ -522,8 +522,8 @@ func (l *linseed) linseedService() *corev1.Service {
 			Ports: []corev1.ServicePort{
 				{
 					Name:       PortName,
-					Port:       Port,
-					TargetPort: intstr.FromInt(TargetPort),
+					Port:       Port.Int32(),
+					TargetPort: TargetPort.IntOrString(),
 					Protocol:   corev1.ProtocolTCP,
 				},
 			},
@@ -565,7 +565,7 @@ func (l *linseed) linseedAllowTigeraPolicy() *v3.NetworkPolicy {
 
 	// Ingress needs to be allowed from all clients.
 	linseedIngressDestinationEntityRule := v3.EntityRule{
-		Ports: networkpolicy.Ports(TargetPort),
+		Ports: networkpolicy.Ports(uint16(TargetPort)),
 	}
 
 	ingressRules := []v3.Rule{
@@ -641,6 +641,18 @@ func (l *linseed) linseedAllowTigeraPolicy() *v3.NetworkPolicy {
 			Source:      networkpolicyHelper.PolicyRecommendationSourceEntityRule(),
 			Destination: linseedIngressDestinationEntityRule,
 		},
+		{
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Source:      networkpolicyHelper.ComplianceSIEMExportSourceEntityRule(),
+			Destination: linseedIngressDestinationEntityRule,
+		},
+		{
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Source:      networkpolicyHelper.IntrusionDetectionSIEMExportSourceEntityRule(),
+			Destination: linseedIngressDestinationEntityRule,
+		},
 	}
 
 	if l.cfg.HasDPIResource {
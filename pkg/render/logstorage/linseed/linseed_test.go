@@ -918,7 +918,7 @@ func compareResources(resources []client.Object, expectedResources []resourceTes
 		{
 			Name:       PortName,
 			Port:       443,
-			TargetPort: intstr.FromInt(TargetPort),
+			TargetPort: intstr.FromInt(8444),
 			Protocol:   corev1.ProtocolTCP,
 		},
 	}))
@@ -22,11 +22,13 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	routev1 "github.com/openshift/api/route/v1"
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
 
 	operatorv1 "github.com/tigera/operator/api/v1"
@@ -40,6 +42,7 @@ import (
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/common/podaffinity"
+	"github.com/tigera/operator/pkg/render/common/portregistry"
 	"github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/render/common/securitycontext"
 	"github.com/tigera/operator/pkg/render/common/securitycontextconstraints"
@@ -49,8 +52,8 @@ import (
 )
 
 const (
-	managerPort                  = 9443
-	managerTargetPort            = 9443
+	managerPort                  = portregistry.Manager
+	managerTargetPort            = portregistry.Manager
 	ManagerServiceName           = "tigera-manager"
 	ManagerDeploymentName        = "tigera-manager"
 	ManagerNamespace             = "tigera-manager"
@@ -82,8 +85,14 @@ const (
 const (
 	VoltronName              = "tigera-voltron"
 	VoltronTunnelSecretName  = "tigera-management-cluster-connection"
+	ManagerTunnelServiceName = "tigera-manager-tunnel"
+	ManagerIngressName       = "tigera-manager"
 	defaultVoltronPort       = "9443"
 	defaultTunnelVoltronPort = "9449"
+
+	// voltronShutdownTimeoutEnvVar tells voltron how long it may drain its tunnels and connections after it is told
+	// to terminate.
+	voltronShutdownTimeoutEnvVar = "VOLTRON_SHUTDOWN_TIMEOUT_SECS"
 )
 
 // Manager returns a component for rendering namespaced manager resources.
@@ -150,6 +159,14 @@ type ManagerConfiguration struct {
 	// by clients as part of mTLS authentication.
 	TrustedCertBundle certificatemanagement.TrustedBundleRO
 
+	// The Authentication, if users log in through an identity provider. Its session settings are passed to the
+	// manager and Voltron.
+	Authentication *operatorv1.Authentication
+
+	// The secret named by the Manager's spec.ingress.tlsSecretName. Only read on OpenShift, where its certificate and
+	// key are copied into the Route.
+	IngressTLSSecret *corev1.Secret
+
 	ClusterDomain           string
 	ESLicenseType           ElasticsearchLicenseType
 	Replicas                *int32
@@ -208,6 +225,10 @@ func (c *managerComponent) SupportedOSType() rmeta.OSType {
 	return rmeta.OSTypeLinux
 }
 
+func (c *managerComponent) ReplicatedSecretsKey() string {
+	return "manager"
+}
+
 func (c *managerComponent) Objects() ([]client.Object, []client.Object) {
 	objs := []client.Object{}
 
@@ -234,7 +255,10 @@ func (c *managerComponent) Objects() ([]client.Object, []client.Object) {
 		objs = append(objs, c.multiTenantManagedClustersAccess()...)
 	}
 
-	objs = append(objs, secret.ToRuntimeObjects(secret.CopyToNamespace(c.cfg.Namespace, c.cfg.PullSecrets...)...)...)
+	if !c.cfg.Tenant.MultiTenant() {
+		// In multi-tenant management clusters, the pull secrets are copied into the tenant namespace by the tenant controller.
+		objs = append(objs, secret.ToRuntimeObjects(secret.CopyToNamespace(c.cfg.Namespace, c.cfg.PullSecrets...)...)...)
+	}
 	objs = append(objs,
 		c.managerAllowTigeraNetworkPolicy(),
 		networkpolicy.AllowTigeraDefaultDeny(c.cfg.Namespace),
@@ -243,6 +267,26 @@ func (c *managerComponent) Objects() ([]client.Object, []client.Object) {
 	objs = append(objs, c.getTLSObjects()...)
 	objs = append(objs, c.managerService())
 
+	var objsToDelete []client.Object
+	if tunnelService := c.managerTunnelService(); tunnelService != nil {
+		objs = append(objs, tunnelService)
+	} else if !c.cfg.Tenant.MultiTenant() {
+		objsToDelete = append(objsToDelete, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: ManagerTunnelServiceName, Namespace: c.cfg.Namespace}})
+	}
+
+	if c.cfg.Manager != nil && c.cfg.Manager.Spec.Ingress != nil {
+		if c.cfg.OpenShift {
+			objs = append(objs, c.managerRoute())
+		} else {
+			objs = append(objs, c.managerIngress())
+		}
+	} else {
+		objsToDelete = append(objsToDelete, &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: ManagerIngressName, Namespace: c.cfg.Namespace}})
+		if c.cfg.OpenShift {
+			objsToDelete = append(objsToDelete, &routev1.Route{ObjectMeta: metav1.ObjectMeta{Name: ManagerIngressName, Namespace: c.cfg.Namespace}})
+		}
+	}
+
 	if c.cfg.VoltronRouteConfig != nil {
 		objs = append(objs, c.cfg.VoltronRouteConfig.RoutesConfigMap(c.cfg.Namespace))
 	}
@@ -262,7 +306,7 @@ func (c *managerComponent) Objects() ([]client.Object, []client.Object) {
 		}
 	}
 
-	return objs, nil
+	return objs, objsToDelete
 }
 
 func (c *managerComponent) Ready() bool {
@@ -336,6 +380,7 @@ func (c *managerComponent) managerDeployment() *appsv1.Deployment {
 			rcomponents.ApplyDeploymentOverrides(d, overrides)
 		}
 	}
+	SetShutdownTimeout(&d.Spec.Template.Spec, VoltronName, voltronShutdownTimeoutEnvVar)
 	return d
 }
 
@@ -377,7 +422,7 @@ func (c *managerComponent) managerProbe() *corev1.Probe {
 		ProbeHandler: corev1.ProbeHandler{
 			HTTPGet: &corev1.HTTPGetAction{
 				Path:   "/",
-				Port:   intstr.FromInt(managerPort),
+				Port:   managerPort.IntOrString(),
 				Scheme: corev1.URISchemeHTTPS,
 			},
 		},
@@ -391,7 +436,7 @@ func (c *managerComponent) managerEsProxyProbe() *corev1.Probe {
 		ProbeHandler: corev1.ProbeHandler{
 			HTTPGet: &corev1.HTTPGetAction{
 				Path:   "/tigera-elasticsearch/version",
-				Port:   intstr.FromInt(managerPort),
+				Port:   managerPort.IntOrString(),
 				Scheme: corev1.URISchemeHTTPS,
 			},
 		},
@@ -405,7 +450,7 @@ func (c *managerComponent) managerProxyProbe() *corev1.Probe {
 		ProbeHandler: corev1.ProbeHandler{
 			HTTPGet: &corev1.HTTPGetAction{
 				Path:   "/voltron/api/health",
-				Port:   intstr.FromInt(managerPort),
+				Port:   managerPort.IntOrString(),
 				Scheme: corev1.URISchemeHTTPS,
 			},
 		},
@@ -450,6 +495,7 @@ func (c *managerComponent) managerContainer() corev1.Container {
 		ImagePullPolicy: ImagePullPolicy(),
 		Env:             c.managerEnvVars(),
 		LivenessProbe:   c.managerProbe(),
+		StartupProbe:    StartupProbe(c.managerProbe()),
 		SecurityContext: securitycontext.NewNonRootContext(),
 		VolumeMounts:    c.managerVolumeMounts(),
 	}
@@ -474,9 +520,21 @@ func (c *managerComponent) managerOAuth2EnvVars() []corev1.EnvVar {
 			envs = append(envs, corev1.EnvVar{Name: "CNX_WEB_OIDC_AUTHORITY", Value: ""})
 		}
 	}
+	if idleTimeout := c.sessionIdleTimeout(); idleTimeout != nil {
+		envs = append(envs, corev1.EnvVar{Name: "CNX_WEB_SESSION_IDLE_TIMEOUT_SECONDS", Value: strconv.Itoa(int(idleTimeout.Seconds()))})
+	}
 	return envs
 }
 
+// sessionIdleTimeout returns how long a session of the Manager UI may be idle before the user is logged out, or nil
+// if users aren't logged out for being idle.
+func (c *managerComponent) sessionIdleTimeout() *metav1.Duration {
+	if c.cfg.Authentication == nil || c.cfg.Authentication.Spec.Session == nil {
+		return nil
+	}
+	return c.cfg.Authentication.Spec.Session.IdleTimeout
+}
+
 // voltronContainer returns the container for the manager proxy container - voltron.
 func (c *managerComponent) voltronContainer() corev1.Container {
 	var keyPath, certPath, intKeyPath, intCertPath, tunnelKeyPath, tunnelCertPath string
@@ -531,6 +589,10 @@ func (c *managerComponent) voltronContainer() corev1.Container {
 		env = append(env, c.cfg.VoltronRouteConfig.EnvVars()...)
 	}
 
+	if idleTimeout := c.sessionIdleTimeout(); idleTimeout != nil {
+		env = append(env, corev1.EnvVar{Name: "VOLTRON_SESSION_IDLE_TIMEOUT", Value: idleTimeout.Duration.String()})
+	}
+
 	if c.cfg.ManagementCluster != nil {
 		env = append(env, corev1.EnvVar{Name: "VOLTRON_USE_HTTPS_CERT_ON_TUNNEL", Value: strconv.FormatBool(c.cfg.ManagementCluster.Spec.TLS != nil && c.cfg.ManagementCluster.Spec.TLS.SecretName == ManagerTLSSecretName)})
 		env = append(env, corev1.EnvVar{Name: "VOLTRON_LINSEED_SERVER_KEY", Value: linseedKeyPath})
@@ -582,6 +644,7 @@ func (c *managerComponent) voltronContainer() corev1.Container {
 		Env:             env,
 		VolumeMounts:    mounts,
 		LivenessProbe:   c.managerProxyProbe(),
+		StartupProbe:    StartupProbe(c.managerProxyProbe()),
 		SecurityContext: securitycontext.NewNonRootContext(),
 	}
 }
@@ -637,6 +700,7 @@ func (c *managerComponent) managerEsProxyContainer() corev1.Container {
 		Image:           c.esProxyImage,
 		ImagePullPolicy: ImagePullPolicy(),
 		LivenessProbe:   c.managerEsProxyProbe(),
+		StartupProbe:    StartupProbe(c.managerEsProxyProbe()),
 		SecurityContext: securitycontext.NewNonRootContext(),
 		Env:             env,
 		VolumeMounts:    volumeMounts,
@@ -645,12 +709,12 @@ func (c *managerComponent) managerEsProxyContainer() corev1.Container {
 
 // managerTolerations returns the tolerations for the Tigera Secure manager deployment pods.
 func (c *managerComponent) managerTolerations() []corev1.Toleration {
-	return append(c.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateCriticalAddonsAndControlPlane...)
+	return rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.TolerateCriticalAddonsAndControlPlane...)
 }
 
 // managerService returns the service exposing the Tigera Secure web app.
 func (c *managerComponent) managerService() *corev1.Service {
-	return &corev1.Service{
+	s := &corev1.Service{
 		TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      ManagerServiceName,
@@ -659,9 +723,9 @@ func (c *managerComponent) managerService() *corev1.Service {
 		Spec: corev1.ServiceSpec{
 			Ports: []corev1.ServicePort{
 				{
-					Port:       managerPort,
+					Port:       managerPort.Int32(),
 					Protocol:   corev1.ProtocolTCP,
-					TargetPort: intstr.FromInt(managerTargetPort),
+					TargetPort: managerTargetPort.IntOrString(),
 				},
 			},
 			Selector: map[string]string{
@@ -669,6 +733,128 @@ func (c *managerComponent) managerService() *corev1.Service {
 			},
 		},
 	}
+
+	if c.cfg.Manager != nil {
+		rcomponents.ApplyServiceOptions(s, c.cfg.Manager.Spec.ManagerService)
+	}
+
+	return s
+}
+
+// managerTunnelService returns the Service that managed clusters connect to, if the ManagementCluster asks for it.
+func (c *managerComponent) managerTunnelService() *corev1.Service {
+	if c.cfg.ManagementCluster == nil || c.cfg.ManagementCluster.Spec.TunnelService == nil || c.cfg.Tenant.MultiTenant() {
+		return nil
+	}
+	opts := c.cfg.ManagementCluster.Spec.TunnelService
+
+	tunnelPort, _ := strconv.ParseInt(defaultTunnelVoltronPort, 10, 32)
+	port := corev1.ServicePort{
+		Name:       "tunnels",
+		Port:       int32(tunnelPort),
+		Protocol:   corev1.ProtocolTCP,
+		TargetPort: intstr.FromInt(int(tunnelPort)),
+	}
+	s := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ManagerTunnelServiceName,
+			Namespace: c.cfg.Namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:                     corev1.ServiceTypeNodePort,
+			LoadBalancerSourceRanges: opts.LoadBalancerSourceRanges,
+			Selector: map[string]string{
+				"k8s-app": ManagerDeploymentName,
+			},
+		},
+	}
+	if opts.Type != nil {
+		s.Spec.Type = *opts.Type
+	}
+	if opts.ExternalTrafficPolicy != nil {
+		s.Spec.ExternalTrafficPolicy = *opts.ExternalTrafficPolicy
+	}
+	if opts.Port != nil {
+		if s.Spec.Type == corev1.ServiceTypeNodePort {
+			port.NodePort = *opts.Port
+		} else {
+			port.Port = *opts.Port
+		}
+	}
+	s.Spec.Ports = []corev1.ServicePort{port}
+	return s
+}
+
+// managerIngress returns the Ingress that exposes the Manager UI through the Manager Service.
+func (c *managerComponent) managerIngress() *networkingv1.Ingress {
+	opts := c.cfg.Manager.Spec.Ingress
+	pathType := networkingv1.PathTypePrefix
+	ingress := &networkingv1.Ingress{
+		TypeMeta: metav1.TypeMeta{Kind: "Ingress", APIVersion: "networking.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        ManagerIngressName,
+			Namespace:   c.cfg.Namespace,
+			Annotations: opts.Annotations,
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: opts.IngressClassName,
+			Rules: []networkingv1.IngressRule{{
+				Host: opts.Host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     "/",
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: ManagerServiceName,
+									Port: networkingv1.ServiceBackendPort{Number: managerPort.Int32()},
+								},
+							},
+						}},
+					},
+				},
+			}},
+		},
+	}
+	if opts.TLSSecretName != "" {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{opts.Host}, SecretName: opts.TLSSecretName}}
+	}
+	return ingress
+}
+
+// managerRoute returns the OpenShift Route that exposes the Manager UI through the Manager Service. The router
+// re-encrypts the traffic to the Manager, trusting the CA that issued the Manager's certificate.
+func (c *managerComponent) managerRoute() *routev1.Route {
+	opts := c.cfg.Manager.Spec.Ingress
+	tlsConfig := &routev1.TLSConfig{
+		Termination:                   routev1.TLSTerminationReencrypt,
+		InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+	}
+	if issuer := c.cfg.TLSKeyPair.GetIssuer(); issuer != nil {
+		tlsConfig.DestinationCACertificate = string(issuer.GetCertificatePEM())
+	} else {
+		tlsConfig.DestinationCACertificate = string(c.cfg.TLSKeyPair.GetCertificatePEM())
+	}
+	if c.cfg.IngressTLSSecret != nil {
+		tlsConfig.Certificate = string(c.cfg.IngressTLSSecret.Data[corev1.TLSCertKey])
+		tlsConfig.Key = string(c.cfg.IngressTLSSecret.Data[corev1.TLSPrivateKeyKey])
+	}
+	return &routev1.Route{
+		TypeMeta: metav1.TypeMeta{Kind: "Route", APIVersion: "route.openshift.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        ManagerIngressName,
+			Namespace:   c.cfg.Namespace,
+			Annotations: opts.Annotations,
+		},
+		Spec: routev1.RouteSpec{
+			Host: opts.Host,
+			To:   routev1.RouteTargetReference{Kind: "Service", Name: ManagerServiceName},
+			Port: &routev1.RoutePort{TargetPort: managerPort.IntOrString()},
+			TLS:  tlsConfig,
+		},
+	}
 }
 
 // managerServiceAccount creates the serviceaccount used by the Tigera Secure web app.
@@ -874,18 +1060,9 @@ func (c *managerComponent) managerAllowTigeraNetworkPolicy() *v3.NetworkPolicy {
 			Protocol:    &networkpolicy.TCPProtocol,
 			Destination: TigeraAPIServerEntityRule,
 		},
-		{
-			Action:      v3.Allow,
-			Protocol:    &networkpolicy.TCPProtocol,
-			Source:      v3.EntityRule{},
-			Destination: networkpolicyHelper.ESGatewayEntityRule(),
-		},
-		{
-			Action:      v3.Allow,
-			Protocol:    &networkpolicy.TCPProtocol,
-			Source:      v3.EntityRule{},
-			Destination: networkpolicyHelper.LinseedEntityRule(),
-		},
+	}
+	egressRules = append(egressRules, networkpolicyHelper.DataStoreEgressRules(networkpolicy.DataStoreConfig{ESGateway: true})...)
+	egressRules = append(egressRules, []v3.Rule{
 		{
 			Action:      v3.Allow,
 			Protocol:    &networkpolicy.TCPProtocol,
@@ -906,7 +1083,7 @@ func (c *managerComponent) managerAllowTigeraNetworkPolicy() *v3.NetworkPolicy {
 			Protocol:    &networkpolicy.TCPProtocol,
 			Destination: networkpolicy.KubeAPIServerServiceSelectorEntityRule,
 		},
-	}
+	}...)
 	egressRules = networkpolicy.AppendDNSEgressRules(egressRules, c.cfg.OpenShift)
 	egressRules = append(egressRules, v3.Rule{
 		Action:      v3.Allow,
@@ -924,7 +1101,7 @@ func (c *managerComponent) managerAllowTigeraNetworkPolicy() *v3.NetworkPolicy {
 			},
 			Destination: v3.EntityRule{
 				// By default, Calico Enterprise Manager is accessed over https
-				Ports: networkpolicy.Ports(managerTargetPort),
+				Ports: networkpolicy.Ports(uint16(managerTargetPort)),
 			},
 		},
 		{
@@ -936,7 +1113,7 @@ func (c *managerComponent) managerAllowTigeraNetworkPolicy() *v3.NetworkPolicy {
 			},
 			Destination: v3.EntityRule{
 				// By default, Calico Enterprise Manager is accessed over https
-				Ports: networkpolicy.Ports(managerTargetPort),
+				Ports: networkpolicy.Ports(uint16(managerTargetPort)),
 			},
 		},
 	}
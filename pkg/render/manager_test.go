@@ -18,11 +18,13 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	routev1 "github.com/openshift/api/route/v1"
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
 	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/apis"
@@ -31,6 +33,7 @@ import (
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	"github.com/tigera/operator/pkg/render/common/authentication"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
@@ -42,11 +45,13 @@ import (
 	"github.com/tigera/operator/test"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -445,6 +450,128 @@ var _ = Describe("Tigera Secure Manager rendering tests", func() {
 		})
 	})
 
+	It("should render the tunnel service if the ManagementCluster asks for it", func() {
+		resources := renderObjects(renderConfig{
+			managementCluster: &operatorv1.ManagementCluster{
+				Spec: operatorv1.ManagementClusterSpec{
+					TunnelService: &operatorv1.TunnelServiceOptions{
+						Type:                     ptr.ToPtr(corev1.ServiceTypeLoadBalancer),
+						Port:                     ptr.Int32ToPtr(443),
+						LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+						ExternalTrafficPolicy:    ptr.ToPtr(corev1.ServiceExternalTrafficPolicyLocal),
+					},
+				},
+			},
+			installation:            installation,
+			compliance:              compliance,
+			complianceFeatureActive: true,
+			ns:                      render.ManagerNamespace,
+		})
+
+		svc := rtest.GetResource(resources, render.ManagerTunnelServiceName, render.ManagerNamespace, "", "v1", "Service").(*corev1.Service)
+		Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+		Expect(svc.Spec.LoadBalancerSourceRanges).To(ConsistOf("10.0.0.0/8"))
+		Expect(svc.Spec.ExternalTrafficPolicy).To(Equal(corev1.ServiceExternalTrafficPolicyLocal))
+		Expect(svc.Spec.Selector).To(Equal(map[string]string{"k8s-app": "tigera-manager"}))
+		Expect(svc.Spec.Ports).To(ConsistOf(corev1.ServicePort{
+			Name:       "tunnels",
+			Port:       443,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(9449),
+		}))
+	})
+
+	It("should set the node port of the tunnel service", func() {
+		resources := renderObjects(renderConfig{
+			managementCluster: &operatorv1.ManagementCluster{
+				Spec: operatorv1.ManagementClusterSpec{
+					TunnelService: &operatorv1.TunnelServiceOptions{Port: ptr.Int32ToPtr(30449)},
+				},
+			},
+			installation:            installation,
+			compliance:              compliance,
+			complianceFeatureActive: true,
+			ns:                      render.ManagerNamespace,
+		})
+
+		svc := rtest.GetResource(resources, render.ManagerTunnelServiceName, render.ManagerNamespace, "", "v1", "Service").(*corev1.Service)
+		Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeNodePort))
+		Expect(svc.Spec.Ports).To(HaveLen(1))
+		Expect(svc.Spec.Ports[0].Port).To(Equal(int32(9449)))
+		Expect(svc.Spec.Ports[0].NodePort).To(Equal(int32(30449)))
+	})
+
+	It("should render the session idle timeout of the Authentication", func() {
+		resources := renderObjects(renderConfig{
+			oidc:                    true,
+			session:                 &operatorv1.AuthenticationSession{IdleTimeout: &metav1.Duration{Duration: 30 * time.Minute}},
+			installation:            installation,
+			compliance:              compliance,
+			complianceFeatureActive: true,
+			ns:                      render.ManagerNamespace,
+		})
+
+		deployment := rtest.GetResource(resources, "tigera-manager", render.ManagerNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		manager := test.GetContainer(deployment.Spec.Template.Spec.Containers, "tigera-manager")
+		rtest.ExpectEnv(manager.Env, "CNX_WEB_SESSION_IDLE_TIMEOUT_SECONDS", "1800")
+		voltron := test.GetContainer(deployment.Spec.Template.Spec.Containers, render.VoltronName)
+		rtest.ExpectEnv(voltron.Env, "VOLTRON_SESSION_IDLE_TIMEOUT", "30m0s")
+	})
+
+	It("should render an Ingress for the Manager UI if the Manager asks for it", func() {
+		resources := renderObjects(renderConfig{
+			manager: &operatorv1.Manager{
+				Spec: operatorv1.ManagerSpec{
+					Ingress: &operatorv1.ManagerIngress{
+						Host:             "manager.example.com",
+						TLSSecretName:    "manager-ingress-tls",
+						IngressClassName: ptr.ToPtr("nginx"),
+						Annotations:      map[string]string{"nginx.ingress.kubernetes.io/backend-protocol": "HTTPS"},
+					},
+				},
+			},
+			installation:            installation,
+			compliance:              compliance,
+			complianceFeatureActive: true,
+			ns:                      render.ManagerNamespace,
+		})
+
+		ingress := rtest.GetResource(resources, render.ManagerIngressName, render.ManagerNamespace, "networking.k8s.io", "v1", "Ingress").(*networkingv1.Ingress)
+		Expect(ingress.Annotations).To(Equal(map[string]string{"nginx.ingress.kubernetes.io/backend-protocol": "HTTPS"}))
+		Expect(ingress.Spec.IngressClassName).To(Equal(ptr.ToPtr("nginx")))
+		Expect(ingress.Spec.TLS).To(ConsistOf(networkingv1.IngressTLS{Hosts: []string{"manager.example.com"}, SecretName: "manager-ingress-tls"}))
+		Expect(ingress.Spec.Rules).To(HaveLen(1))
+		Expect(ingress.Spec.Rules[0].Host).To(Equal("manager.example.com"))
+		Expect(ingress.Spec.Rules[0].HTTP.Paths).To(HaveLen(1))
+		Expect(ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service).To(Equal(&networkingv1.IngressServiceBackend{
+			Name: render.ManagerServiceName,
+			Port: networkingv1.ServiceBackendPort{Number: 9443},
+		}))
+		Expect(rtest.GetResource(resources, render.ManagerIngressName, render.ManagerNamespace, "route.openshift.io", "v1", "Route")).To(BeNil())
+	})
+
+	It("should render a Route for the Manager UI on OpenShift", func() {
+		resources := renderObjects(renderConfig{
+			manager: &operatorv1.Manager{
+				Spec: operatorv1.ManagerSpec{Ingress: &operatorv1.ManagerIngress{Host: "manager.apps.example.com"}},
+			},
+			installation:            installation,
+			compliance:              compliance,
+			complianceFeatureActive: true,
+			openshift:               true,
+			ns:                      render.ManagerNamespace,
+		})
+
+		route := rtest.GetResource(resources, render.ManagerIngressName, render.ManagerNamespace, "route.openshift.io", "v1", "Route").(*routev1.Route)
+		Expect(route.Spec.Host).To(Equal("manager.apps.example.com"))
+		Expect(route.Spec.To).To(Equal(routev1.RouteTargetReference{Kind: "Service", Name: render.ManagerServiceName}))
+		Expect(route.Spec.Port).To(Equal(&routev1.RoutePort{TargetPort: intstr.FromInt(9443)}))
+		Expect(route.Spec.TLS.Termination).To(Equal(routev1.TLSTerminationReencrypt))
+		Expect(route.Spec.TLS.DestinationCACertificate).To(ContainSubstring("BEGIN CERTIFICATE"))
+		Expect(route.Spec.TLS.Certificate).To(BeEmpty())
+		Expect(rtest.GetResource(resources, render.ManagerIngressName, render.ManagerNamespace, "networking.k8s.io", "v1", "Ingress")).To(BeNil())
+	})
+
 	It("should render multicluster settings properly", func() {
 		resources := renderObjects(renderConfig{
 			oidc:                    false,
@@ -863,6 +990,67 @@ var _ = Describe("Tigera Secure Manager rendering tests", func() {
 		Expect(container.Resources).To(Equal(managerResources))
 	})
 
+	It("should apply the scheduling and metadata overrides from the Manager CR", func() {
+		affinity := &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{
+							Key:      "custom-affinity-key",
+							Operator: corev1.NodeSelectorOpExists,
+						}},
+					}},
+				},
+			},
+		}
+		toleration := corev1.Toleration{Key: "foo", Operator: corev1.TolerationOpEqual, Value: "bar"}
+
+		managercfg := operatorv1.Manager{
+			Spec: operatorv1.ManagerSpec{
+				ManagerDeployment: &operatorv1.ManagerDeployment{
+					Metadata: &operatorv1.Metadata{
+						Labels:      map[string]string{"top-level": "label"},
+						Annotations: map[string]string{"top-level": "annotation"},
+					},
+					Spec: &operatorv1.ManagerDeploymentSpec{
+						Template: &operatorv1.ManagerDeploymentPodTemplateSpec{
+							Metadata: &operatorv1.Metadata{
+								Labels:      map[string]string{"template-level": "label"},
+								Annotations: map[string]string{"template-level": "annotation"},
+							},
+							Spec: &operatorv1.ManagerDeploymentPodSpec{
+								Affinity:     affinity,
+								NodeSelector: map[string]string{"custom-node-selector": "value"},
+								Tolerations:  []corev1.Toleration{toleration},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		resources := renderObjects(renderConfig{
+			oidc:                    false,
+			managementCluster:       nil,
+			installation:            &operatorv1.InstallationSpec{ControlPlaneReplicas: &replicas},
+			compliance:              compliance,
+			complianceFeatureActive: true,
+			ns:                      render.ManagerNamespace,
+			manager:                 &managercfg,
+		})
+
+		d, ok := rtest.GetResource(resources, "tigera-manager", render.ManagerNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(ok).To(BeTrue())
+
+		Expect(d.Labels).To(HaveKeyWithValue("top-level", "label"))
+		Expect(d.Annotations).To(HaveKeyWithValue("top-level", "annotation"))
+		Expect(d.Spec.Template.Labels).To(HaveKeyWithValue("template-level", "label"))
+		Expect(d.Spec.Template.Annotations).To(HaveKeyWithValue("template-level", "annotation"))
+		Expect(d.Spec.Template.Spec.Affinity).To(Equal(affinity))
+		Expect(d.Spec.Template.Spec.NodeSelector).To(HaveKeyWithValue("custom-node-selector", "value"))
+		Expect(d.Spec.Template.Spec.Tolerations).To(ConsistOf(toleration))
+	})
+
 	It("should override init container's resource request with the value from Manager CR", func() {
 		ca, _ := tls.MakeCA(rmeta.DefaultOperatorCASignerName())
 		cert, _, _ := ca.Config.GetPEMBytes() // create a valid pem block
@@ -918,6 +1106,107 @@ var _ = Describe("Tigera Secure Manager rendering tests", func() {
 		Expect(initContainer.Resources).To(Equal(managerResources))
 	})
 
+	It("should render startup probes and override their timings with the values from Manager CR", func() {
+		managercfg := operatorv1.Manager{
+			Spec: operatorv1.ManagerSpec{
+				ManagerDeployment: &operatorv1.ManagerDeployment{
+					Spec: &operatorv1.ManagerDeploymentSpec{
+						Template: &operatorv1.ManagerDeploymentPodTemplateSpec{
+							Spec: &operatorv1.ManagerDeploymentPodSpec{
+								Containers: []operatorv1.ManagerDeploymentContainer{{
+									Name: "tigera-voltron",
+									StartupProbe: &operatorv1.ProbeTimings{
+										PeriodSeconds:    ptr.Int32ToPtr(20),
+										FailureThreshold: ptr.Int32ToPtr(60),
+									},
+								}},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		resources := renderObjects(renderConfig{
+			oidc:                    false,
+			managementCluster:       nil,
+			installation:            &operatorv1.InstallationSpec{ControlPlaneReplicas: &replicas},
+			compliance:              compliance,
+			complianceFeatureActive: true,
+			ns:                      render.ManagerNamespace,
+			manager:                 &managercfg,
+		})
+
+		d, ok := rtest.GetResource(resources, "tigera-manager", render.ManagerNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(ok).To(BeTrue())
+
+		manager := test.GetContainer(d.Spec.Template.Spec.Containers, "tigera-manager")
+		Expect(manager).NotTo(BeNil())
+		Expect(manager.StartupProbe.HTTPGet).To(Equal(manager.LivenessProbe.HTTPGet))
+		Expect(manager.StartupProbe.PeriodSeconds).To(BeEquivalentTo(10))
+		Expect(manager.StartupProbe.FailureThreshold).To(BeEquivalentTo(30))
+
+		voltron := test.GetContainer(d.Spec.Template.Spec.Containers, "tigera-voltron")
+		Expect(voltron).NotTo(BeNil())
+		Expect(voltron.StartupProbe.HTTPGet).To(Equal(voltron.LivenessProbe.HTTPGet))
+		Expect(voltron.StartupProbe.PeriodSeconds).To(BeEquivalentTo(20))
+		Expect(voltron.StartupProbe.FailureThreshold).To(BeEquivalentTo(60))
+	})
+
+	It("should pass the termination grace period to voltron as its shutdown timeout", func() {
+		resources := renderObjects(renderConfig{
+			oidc:                    false,
+			managementCluster:       nil,
+			installation:            &operatorv1.InstallationSpec{ControlPlaneReplicas: &replicas},
+			compliance:              compliance,
+			complianceFeatureActive: true,
+			ns:                      render.ManagerNamespace,
+		})
+
+		d, ok := rtest.GetResource(resources, "tigera-manager", render.ManagerNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(ok).To(BeTrue())
+		Expect(d.Spec.Template.Spec.TerminationGracePeriodSeconds).To(BeNil())
+
+		// Without an override voltron drains for the default grace period of the pod.
+		voltron := test.GetContainer(d.Spec.Template.Spec.Containers, "tigera-voltron")
+		Expect(voltron).NotTo(BeNil())
+		Expect(voltron.Lifecycle).To(BeNil())
+		Expect(voltron.Env).To(ContainElement(corev1.EnvVar{Name: "VOLTRON_SHUTDOWN_TIMEOUT_SECS", Value: "30"}))
+
+		var gracePeriod int64 = 90
+		managercfg := operatorv1.Manager{
+			Spec: operatorv1.ManagerSpec{
+				ManagerDeployment: &operatorv1.ManagerDeployment{
+					Spec: &operatorv1.ManagerDeploymentSpec{
+						Template: &operatorv1.ManagerDeploymentPodTemplateSpec{
+							Spec: &operatorv1.ManagerDeploymentPodSpec{
+								TerminationGracePeriodSeconds: &gracePeriod,
+							},
+						},
+					},
+				},
+			},
+		}
+		resources = renderObjects(renderConfig{
+			oidc:                    false,
+			managementCluster:       nil,
+			installation:            &operatorv1.InstallationSpec{ControlPlaneReplicas: &replicas},
+			compliance:              compliance,
+			complianceFeatureActive: true,
+			ns:                      render.ManagerNamespace,
+			manager:                 &managercfg,
+		})
+
+		d, ok = rtest.GetResource(resources, "tigera-manager", render.ManagerNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(ok).To(BeTrue())
+		Expect(d.Spec.Template.Spec.TerminationGracePeriodSeconds).To(Equal(&gracePeriod))
+
+		voltron = test.GetContainer(d.Spec.Template.Spec.Containers, "tigera-voltron")
+		Expect(voltron).NotTo(BeNil())
+		Expect(voltron.Env).To(ContainElement(corev1.EnvVar{Name: "VOLTRON_SHUTDOWN_TIMEOUT_SECS", Value: "90"}))
+		Expect(test.GetContainer(d.Spec.Template.Spec.Containers, "tigera-manager").Env).NotTo(ContainElement(HaveField("Name", "VOLTRON_SHUTDOWN_TIMEOUT_SECS")))
+	})
+
 	Context("allow-tigera rendering", func() {
 		policyName := types.NamespacedName{Name: "allow-tigera.manager-access", Namespace: "tigera-manager"}
 
@@ -1295,19 +1584,22 @@ type renderConfig struct {
 	tenant                  *operatorv1.Tenant
 	manager                 *operatorv1.Manager
 	externalElastic         bool
+	session                 *operatorv1.AuthenticationSession
 }
 
 func renderObjects(roc renderConfig) []client.Object {
 	var dexCfg authentication.KeyValidatorConfig
+	var authenticationCR *operatorv1.Authentication
 	if roc.oidc {
-		authentication := &operatorv1.Authentication{
+		authenticationCR = &operatorv1.Authentication{
 			Spec: operatorv1.AuthenticationSpec{
 				ManagerDomain: "https://127.0.0.1",
 				OIDC:          &operatorv1.AuthenticationOIDC{IssuerURL: "https://accounts.google.com", UsernameClaim: "email"},
+				Session:       roc.session,
 			},
 		}
 
-		dexCfg = render.NewDexKeyValidatorConfig(authentication, nil, dns.DefaultClusterDomain)
+		dexCfg = render.NewDexKeyValidatorConfig(authenticationCR, nil, dns.DefaultClusterDomain)
 	}
 
 	var tunnelSecret certificatemanagement.KeyPairInterface
@@ -1341,6 +1633,7 @@ func renderObjects(roc renderConfig) []client.Object {
 
 	cfg := &render.ManagerConfiguration{
 		KeyValidatorConfig:      dexCfg,
+		Authentication:          authenticationCR,
 		TrustedCertBundle:       bundle,
 		TLSKeyPair:              managerTLS,
 		Installation:            roc.installation,
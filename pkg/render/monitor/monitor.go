@@ -18,6 +18,8 @@ import (
 	"crypto/x509"
 	_ "embed"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
@@ -133,6 +135,29 @@ type Config struct {
 	KubeControllerPort       int
 }
 
+var labelNameRegexp = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+
+// ValidateMetrics returns an error if the metric relabelings of the Monitor are invalid.
+func ValidateMetrics(metrics *operatorv1.MonitorMetrics) error {
+	if metrics == nil {
+		return nil
+	}
+	for i, rule := range metrics.Relabelings {
+		if _, err := regexp.Compile(rule.Regex); err != nil {
+			return fmt.Errorf("spec.metrics.relabelings[%d].regex is invalid: %w", i, err)
+		}
+		if rule.Action == operatorv1.MetricRelabelingLabelDrop && len(rule.SourceLabels) > 0 {
+			return fmt.Errorf("spec.metrics.relabelings[%d].sourceLabels must not be set for the %s action", i, rule.Action)
+		}
+		for _, l := range rule.SourceLabels {
+			if !labelNameRegexp.MatchString(l) {
+				return fmt.Errorf("spec.metrics.relabelings[%d].sourceLabels: %q is not a valid label name", i, l)
+			}
+		}
+	}
+	return nil
+}
+
 type monitorComponent struct {
 	cfg                    *Config
 	alertmanagerImage      string
@@ -821,20 +846,22 @@ func (mc *monitorComponent) serviceMonitorCalicoNode() *monitoringv1.ServiceMoni
 			NamespaceSelector: monitoringv1.NamespaceSelector{MatchNames: []string{"calico-system"}},
 			Endpoints: []monitoringv1.Endpoint{
 				{
-					HonorLabels:   true,
-					Interval:      "5s",
-					Port:          "calico-metrics-port",
-					ScrapeTimeout: "5s",
-					Scheme:        "https",
-					TLSConfig:     mc.tlsConfig(render.CalicoNodeMetricsService),
+					HonorLabels:          true,
+					Interval:             "5s",
+					Port:                 "calico-metrics-port",
+					ScrapeTimeout:        "5s",
+					Scheme:               "https",
+					TLSConfig:            mc.tlsConfig(render.CalicoNodeMetricsService),
+					MetricRelabelConfigs: mc.metricRelabelings(operatorv1.MetricsTargetCalicoNode),
 				},
 				{
-					HonorLabels:   true,
-					Interval:      "5s",
-					Port:          "calico-bgp-metrics-port",
-					ScrapeTimeout: "5s",
-					Scheme:        "https",
-					TLSConfig:     mc.tlsConfig(render.CalicoNodeMetricsService),
+					HonorLabels:          true,
+					Interval:             "5s",
+					Port:                 "calico-bgp-metrics-port",
+					ScrapeTimeout:        "5s",
+					Scheme:               "https",
+					TLSConfig:            mc.tlsConfig(render.CalicoNodeMetricsService),
+					MetricRelabelConfigs: mc.metricRelabelings(operatorv1.MetricsTargetCalicoNode),
 				},
 			},
 		},
@@ -852,6 +879,55 @@ func (mc *monitorComponent) tlsConfig(serverName string) *monitoringv1.TLSConfig
 	}
 }
 
+// defaultMetricRelabelings drop the high-cardinality series that Calico's dashboards and alerts don't use.
+var defaultMetricRelabelings = []operatorv1.MetricRelabeling{
+	{
+		// Felix's histograms add a series per bucket on every node.
+		Targets: []operatorv1.MetricsTarget{operatorv1.MetricsTargetCalicoNode},
+		Action:  operatorv1.MetricRelabelingDrop,
+		Regex:   "felix_.+_bucket",
+	},
+}
+
+// metricRelabelings returns the relabelings of the Monitor, after the defaults, that apply to the metrics of the
+// given target.
+func (mc *monitorComponent) metricRelabelings(target operatorv1.MetricsTarget) []*monitoringv1.RelabelConfig {
+	var rules []operatorv1.MetricRelabeling
+	if mc.cfg.Monitor.Metrics.UseDefaultRelabelings() {
+		rules = append(rules, defaultMetricRelabelings...)
+	}
+	if mc.cfg.Monitor.Metrics != nil {
+		rules = append(rules, mc.cfg.Monitor.Metrics.Relabelings...)
+	}
+
+	var configs []*monitoringv1.RelabelConfig
+	for _, rule := range rules {
+		if len(rule.Targets) > 0 && !slices.Contains(rule.Targets, target) {
+			continue
+		}
+		cfg := &monitoringv1.RelabelConfig{Regex: rule.Regex}
+		switch rule.Action {
+		case operatorv1.MetricRelabelingLabelDrop:
+			cfg.Action = "labeldrop"
+			configs = append(configs, cfg)
+			continue
+		case operatorv1.MetricRelabelingKeep:
+			cfg.Action = "keep"
+		default:
+			cfg.Action = "drop"
+		}
+		sourceLabels := rule.SourceLabels
+		if len(sourceLabels) == 0 {
+			sourceLabels = []string{"__name__"}
+		}
+		for _, l := range sourceLabels {
+			cfg.SourceLabels = append(cfg.SourceLabels, monitoringv1.LabelName(l))
+		}
+		configs = append(configs, cfg)
+	}
+	return configs
+}
+
 func (mc *monitorComponent) serviceMonitorElasticsearch() *monitoringv1.ServiceMonitor {
 	return &monitoringv1.ServiceMonitor{
 		TypeMeta: metav1.TypeMeta{Kind: monitoringv1.ServiceMonitorsKind, APIVersion: MonitoringAPIVersion},
@@ -865,12 +941,13 @@ func (mc *monitorComponent) serviceMonitorElasticsearch() *monitoringv1.ServiceM
 			NamespaceSelector: monitoringv1.NamespaceSelector{MatchNames: []string{"tigera-elasticsearch"}},
 			Endpoints: []monitoringv1.Endpoint{
 				{
-					HonorLabels:   true,
-					Interval:      "5s",
-					Port:          "metrics-port",
-					ScrapeTimeout: "5s",
-					Scheme:        "https",
-					TLSConfig:     mc.tlsConfig(esmetrics.ElasticsearchMetricsName),
+					HonorLabels:          true,
+					Interval:             "5s",
+					Port:                 "metrics-port",
+					ScrapeTimeout:        "5s",
+					Scheme:               "https",
+					TLSConfig:            mc.tlsConfig(esmetrics.ElasticsearchMetricsName),
+					MetricRelabelConfigs: mc.metricRelabelings(operatorv1.MetricsTargetElasticsearch),
 				},
 			},
 		},
@@ -901,12 +978,13 @@ func (mc *monitorComponent) serviceMonitorFluentd() *monitoringv1.ServiceMonitor
 			NamespaceSelector: monitoringv1.NamespaceSelector{MatchNames: []string{render.LogCollectorNamespace}},
 			Endpoints: []monitoringv1.Endpoint{
 				{
-					HonorLabels:   true,
-					Interval:      "5s",
-					Port:          render.FluentdMetricsPortName,
-					ScrapeTimeout: "5s",
-					Scheme:        "https",
-					TLSConfig:     mc.tlsConfig(render.FluentdPrometheusTLSSecretName),
+					HonorLabels:          true,
+					Interval:             "5s",
+					Port:                 render.FluentdMetricsPortName,
+					ScrapeTimeout:        "5s",
+					Scheme:               "https",
+					TLSConfig:            mc.tlsConfig(render.FluentdPrometheusTLSSecretName),
+					MetricRelabelConfigs: mc.metricRelabelings(operatorv1.MetricsTargetFluentd),
 				},
 			},
 		},
@@ -938,6 +1016,7 @@ func (mc *monitorComponent) serviceMonitorQueryServer() *monitoringv1.ServiceMon
 							ServerName: render.ProjectCalicoAPIServerServiceName(mc.cfg.Installation.Variant),
 						},
 					},
+					MetricRelabelConfigs: mc.metricRelabelings(operatorv1.MetricsTargetQueryServer),
 				},
 			},
 		},
@@ -1092,6 +1171,11 @@ func allowTigeraAlertManagerMeshPolicy(cfg *Config) *v3.NetworkPolicy {
 
 // Creates a network policy to allow traffic to access the Prometheus (TCP port 9095).
 func allowTigeraPrometheusPolicy(cfg *Config) *v3.NetworkPolicy {
+	felixMetricsPorts := []uint16{9081, 9091}
+	if nodeMetricsPort := cfg.Installation.NodeMetricsPort; nodeMetricsPort != nil && *nodeMetricsPort != 9091 {
+		felixMetricsPorts = append(felixMetricsPorts, uint16(*nodeMetricsPort))
+	}
+
 	egressRules := []v3.Rule{}
 	egressRules = networkpolicy.AppendDNSEgressRules(egressRules, cfg.OpenShift)
 	egressRules = append(egressRules, []v3.Rule{
@@ -1105,7 +1189,7 @@ func allowTigeraPrometheusPolicy(cfg *Config) *v3.NetworkPolicy {
 			Protocol: &networkpolicy.TCPProtocol,
 			Destination: v3.EntityRule{
 				// Egress access for Felix metrics
-				Ports: networkpolicy.Ports(9081, 9091),
+				Ports: networkpolicy.Ports(felixMetricsPorts...),
 			},
 		},
 		{
@@ -1262,12 +1346,13 @@ func (mc *monitorComponent) serviceMonitorCalicoKubeControllers() *monitoringv1.
 			NamespaceSelector: monitoringv1.NamespaceSelector{MatchNames: []string{"calico-system"}},
 			Endpoints: []monitoringv1.Endpoint{
 				{
-					HonorLabels:   true,
-					Interval:      "5s",
-					Port:          "metrics-port",
-					ScrapeTimeout: "5s",
-					Scheme:        "https",
-					TLSConfig:     mc.tlsConfig(KubeControllerMetrics),
+					HonorLabels:          true,
+					Interval:             "5s",
+					Port:                 "metrics-port",
+					ScrapeTimeout:        "5s",
+					Scheme:               "https",
+					TLSConfig:            mc.tlsConfig(KubeControllerMetrics),
+					MetricRelabelConfigs: mc.metricRelabelings(operatorv1.MetricsTargetKubeControllers),
 				},
 			},
 		},
@@ -1438,11 +1523,12 @@ func (mc *monitorComponent) typhaServiceMonitor() client.Object {
 		Spec: monitoringv1.ServiceMonitorSpec{
 			Endpoints: []monitoringv1.Endpoint{
 				{
-					HonorLabels:   true,
-					Interval:      "5s",
-					Port:          render.TyphaMetricsName,
-					Scheme:        "http",
-					ScrapeTimeout: "5s",
+					HonorLabels:          true,
+					Interval:             "5s",
+					Port:                 render.TyphaMetricsName,
+					Scheme:               "http",
+					ScrapeTimeout:        "5s",
+					MetricRelabelConfigs: mc.metricRelabelings(operatorv1.MetricsTargetTypha),
 				},
 			},
 			NamespaceSelector: monitoringv1.NamespaceSelector{
@@ -43,6 +43,7 @@ import (
 	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	rtest "github.com/tigera/operator/pkg/render/common/test"
 	"github.com/tigera/operator/pkg/render/monitor"
 	"github.com/tigera/operator/pkg/render/testutils"
@@ -516,6 +517,13 @@ var _ = Describe("monitor rendering tests", func() {
 		Expect(servicemonitorObj.Spec.Endpoints[1].Port).To(Equal("calico-bgp-metrics-port"))
 		Expect(servicemonitorObj.Spec.Endpoints[1].ScrapeTimeout).To(BeEquivalentTo("5s"))
 		Expect(servicemonitorObj.Spec.Endpoints[1].Scheme).To(Equal("https"))
+		for _, ep := range servicemonitorObj.Spec.Endpoints {
+			Expect(ep.MetricRelabelConfigs).To(ConsistOf(&monitoringv1.RelabelConfig{
+				Action:       "drop",
+				SourceLabels: []monitoringv1.LabelName{"__name__"},
+				Regex:        "felix_.+_bucket",
+			}))
+		}
 
 		servicemonitorObj, ok = rtest.GetResource(toCreate, monitor.ElasticsearchMetrics, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.ServiceMonitorsKind).(*monitoringv1.ServiceMonitor)
 		Expect(ok).To(BeTrue())
@@ -824,6 +832,21 @@ var _ = Describe("monitor rendering tests", func() {
 
 			Expect(len(zeroedPolicy.Spec.Egress)).To(Equal(len(baselinePolicy.Spec.Egress) - 1))
 		})
+
+		It("prometheus policy should allow egress to a custom node metrics port", func() {
+			cfg.Installation.NodeMetricsPort = ptr.Int32ToPtr(9191)
+			component := monitor.MonitorPolicy(cfg)
+			resourcesToCreate, _ := component.Objects()
+			policy := testutils.GetAllowTigeraPolicyFromResources(types.NamespacedName{Name: "allow-tigera.prometheus", Namespace: "tigera-prometheus"}, resourcesToCreate)
+
+			Expect(policy.Spec.Egress).To(ContainElement(v3.Rule{
+				Action:   v3.Allow,
+				Protocol: &networkpolicy.TCPProtocol,
+				Destination: v3.EntityRule{
+					Ports: networkpolicy.Ports(9081, 9091, 9191),
+				},
+			}))
+		})
 	})
 
 	It("Should render external prometheus resources with service monitor", func() {
@@ -907,6 +930,61 @@ var _ = Describe("monitor rendering tests", func() {
 		Expect(toCreate).To(HaveLen(len(expectedResources)))
 		Expect(toDelete).To(HaveLen(3))
 	})
+	It("Should apply the metric relabelings of the Monitor to the service monitors", func() {
+		disabled := operatorv1.DefaultRelabelingsDisabled
+		cfg.Monitor.Metrics = &operatorv1.MonitorMetrics{
+			DefaultRelabelings: &disabled,
+			Relabelings: []operatorv1.MetricRelabeling{
+				{
+					Targets: []operatorv1.MetricsTarget{operatorv1.MetricsTargetCalicoNode},
+					Action:  operatorv1.MetricRelabelingDrop,
+					Regex:   "cnx_policy_rule_.+",
+				},
+				{
+					Action:       operatorv1.MetricRelabelingKeep,
+					SourceLabels: []string{"__name__", "tier"},
+					Regex:        ".+;default",
+				},
+				{
+					Targets: []operatorv1.MetricsTarget{operatorv1.MetricsTargetKubeControllers},
+					Action:  operatorv1.MetricRelabelingLabelDrop,
+					Regex:   "pod",
+				},
+			},
+		}
+		toCreate, _ := monitor.Monitor(cfg).Objects()
+
+		keep := &monitoringv1.RelabelConfig{Action: "keep", SourceLabels: []monitoringv1.LabelName{"__name__", "tier"}, Regex: ".+;default"}
+		sm := rtest.GetResource(toCreate, monitor.CalicoNodeMonitor, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.ServiceMonitorsKind).(*monitoringv1.ServiceMonitor)
+		Expect(sm.Spec.Endpoints[0].MetricRelabelConfigs).To(Equal([]*monitoringv1.RelabelConfig{
+			{Action: "drop", SourceLabels: []monitoringv1.LabelName{"__name__"}, Regex: "cnx_policy_rule_.+"},
+			keep,
+		}))
+		sm = rtest.GetResource(toCreate, monitor.KubeControllerMetrics, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.ServiceMonitorsKind).(*monitoringv1.ServiceMonitor)
+		Expect(sm.Spec.Endpoints[0].MetricRelabelConfigs).To(Equal([]*monitoringv1.RelabelConfig{
+			keep,
+			{Action: "labeldrop", Regex: "pod"},
+		}))
+		sm = rtest.GetResource(toCreate, monitor.ElasticsearchMetrics, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.ServiceMonitorsKind).(*monitoringv1.ServiceMonitor)
+		Expect(sm.Spec.Endpoints[0].MetricRelabelConfigs).To(Equal([]*monitoringv1.RelabelConfig{keep}))
+	})
+
+	DescribeTable("validating the metric relabelings",
+		func(rule operatorv1.MetricRelabeling, expectedErr string) {
+			err := monitor.ValidateMetrics(&operatorv1.MonitorMetrics{Relabelings: []operatorv1.MetricRelabeling{rule}})
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			}
+		},
+		Entry("valid drop", operatorv1.MetricRelabeling{Action: operatorv1.MetricRelabelingDrop, Regex: "felix_.+", SourceLabels: []string{"__name__", "tier"}}, ""),
+		Entry("valid label drop", operatorv1.MetricRelabeling{Action: operatorv1.MetricRelabelingLabelDrop, Regex: "pod|instance"}, ""),
+		Entry("invalid regex", operatorv1.MetricRelabeling{Action: operatorv1.MetricRelabelingKeep, Regex: "felix_("}, "relabelings[0].regex is invalid"),
+		Entry("source labels with label drop", operatorv1.MetricRelabeling{Action: operatorv1.MetricRelabelingLabelDrop, Regex: "pod", SourceLabels: []string{"pod"}}, "must not be set for the LabelDrop action"),
+		Entry("invalid source label", operatorv1.MetricRelabeling{Action: operatorv1.MetricRelabelingDrop, Regex: ".+", SourceLabels: []string{"not-a-label"}}, `"not-a-label" is not a valid label name`),
+	)
+
 	It("Should render typha service monitor if typha metrics are enabled", func() {
 		cfg.Installation.TyphaMetricsPort = ptr.Int32ToPtr(9093)
 		component := monitor.Monitor(cfg)
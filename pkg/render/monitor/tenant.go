@@ -0,0 +1,184 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/components"
+	"github.com/tigera/operator/pkg/render"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/secret"
+	"github.com/tigera/operator/pkg/render/common/securitycontext"
+)
+
+// TenantPrometheusConfig contains the information needed to render the Prometheus instance of a tenant.
+type TenantPrometheusConfig struct {
+	Installation *operatorv1.InstallationSpec
+	Tenant       *operatorv1.Tenant
+	// PullSecrets are the pull secrets of the Installation. In multi-tenant mode the tenant controller copies them
+	// into the tenant namespace, so they are only referenced here.
+	PullSecrets []*corev1.Secret
+}
+
+// TenantPrometheus renders a Prometheus instance in the namespace of a tenant. It only discovers and scrapes targets
+// in that namespace, so that a tenant can't query the metrics of another tenant.
+func TenantPrometheus(cfg *TenantPrometheusConfig) render.Component {
+	return &tenantPrometheusComponent{cfg: cfg}
+}
+
+type tenantPrometheusComponent struct {
+	cfg             *TenantPrometheusConfig
+	prometheusImage string
+}
+
+func (c *tenantPrometheusComponent) ResolveImages(is *operatorv1.ImageSet) error {
+	var err error
+	c.prometheusImage, err = components.GetReference(components.ComponentPrometheus, c.cfg.Installation.Registry, c.cfg.Installation.ImagePath, c.cfg.Installation.ImagePrefix, is)
+	return err
+}
+
+func (c *tenantPrometheusComponent) SupportedOSType() rmeta.OSType {
+	return rmeta.OSTypeLinux
+}
+
+func (c *tenantPrometheusComponent) Objects() ([]client.Object, []client.Object) {
+	return []client.Object{
+		c.serviceAccount(),
+		c.role(),
+		c.roleBinding(),
+		c.prometheus(),
+		c.service(),
+	}, nil
+}
+
+func (c *tenantPrometheusComponent) Ready() bool {
+	return true
+}
+
+func (c *tenantPrometheusComponent) serviceAccount() *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		TypeMeta:   metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: PrometheusServiceAccountName, Namespace: c.cfg.Tenant.Namespace},
+	}
+}
+
+// role only grants access to the tenant namespace, unlike the ClusterRole of the cluster's own Prometheus.
+func (c *tenantPrometheusComponent) role() *rbacv1.Role {
+	return &rbacv1.Role{
+		TypeMeta:   metav1.TypeMeta{Kind: "Role", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: TigeraPrometheusRole, Namespace: c.cfg.Tenant.Namespace},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"endpoints", "pods", "services"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+				Verbs:     []string{"get"},
+			},
+		},
+	}
+}
+
+func (c *tenantPrometheusComponent) roleBinding() *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		TypeMeta:   metav1.TypeMeta{Kind: "RoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: TigeraPrometheusRoleBinding, Namespace: c.cfg.Tenant.Namespace},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     TigeraPrometheusRole,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      PrometheusServiceAccountName,
+				Namespace: c.cfg.Tenant.Namespace,
+			},
+		},
+	}
+}
+
+func (c *tenantPrometheusComponent) prometheus() *monitoringv1.Prometheus {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "network-operators"}}
+	return &monitoringv1.Prometheus{
+		TypeMeta: metav1.TypeMeta{Kind: monitoringv1.PrometheusesKind, APIVersion: MonitoringAPIVersion},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CalicoNodePrometheus,
+			Namespace: c.cfg.Tenant.Namespace,
+		},
+		Spec: monitoringv1.PrometheusSpec{
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				PodMetadata: &monitoringv1.EmbeddedObjectMetadata{
+					Labels: map[string]string{
+						"k8s-app": TigeraPrometheusObjectName,
+					},
+				},
+				Image:            &c.prometheusImage,
+				ImagePullPolicy:  render.ImagePullPolicy(),
+				ImagePullSecrets: secret.GetReferenceList(c.cfg.PullSecrets),
+				NodeSelector:     c.cfg.Installation.ControlPlaneNodeSelector,
+				// Leaving the namespace selectors unset limits discovery to the namespace of the tenant. Namespace
+				// selectors within the monitors are ignored, so that a monitor can't point this instance elsewhere.
+				PodMonitorSelector:       selector,
+				ServiceMonitorSelector:   selector,
+				IgnoreNamespaceSelectors: true,
+				EnforcedNamespaceLabel:   "namespace",
+				Resources:                corev1.ResourceRequirements{Requests: corev1.ResourceList{"memory": resource.MustParse("400Mi")}},
+				SecurityContext:          securitycontext.NewNonRootPodContext(),
+				ServiceAccountName:       PrometheusServiceAccountName,
+				Tolerations:              c.cfg.Installation.ControlPlaneTolerations,
+				Version:                  components.ComponentCoreOSPrometheus.Version,
+			},
+			Retention: "24h",
+		},
+	}
+}
+
+// service exposes the Prometheus API of the tenant under the same name as the cluster's own Prometheus, so that the
+// manager of the tenant only needs to change the namespace it queries.
+func (c *tenantPrometheusComponent) service() *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      calicoNodePrometheusServiceName,
+			Namespace: c.cfg.Tenant.Namespace,
+			Labels:    map[string]string{"k8s-app": TigeraPrometheusObjectName},
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "web",
+					Port:       PrometheusDefaultPort,
+					Protocol:   corev1.ProtocolTCP,
+					TargetPort: intstr.FromInt(PrometheusDefaultPort),
+				},
+			},
+			Selector: map[string]string{"prometheus": CalicoNodePrometheus},
+		},
+	}
+}
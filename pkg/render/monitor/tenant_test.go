@@ -0,0 +1,78 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	rtest "github.com/tigera/operator/pkg/render/common/test"
+	"github.com/tigera/operator/pkg/render/monitor"
+)
+
+var _ = Describe("tenant Prometheus rendering tests", func() {
+	const tenantNS = "tenant-a"
+
+	var cfg *monitor.TenantPrometheusConfig
+
+	BeforeEach(func() {
+		cfg = &monitor.TenantPrometheusConfig{
+			Installation: &operatorv1.InstallationSpec{},
+			Tenant: &operatorv1.Tenant{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: tenantNS},
+				Spec:       operatorv1.TenantSpec{ID: "tenant-a"},
+			},
+			PullSecrets: []*corev1.Secret{
+				{ObjectMeta: metav1.ObjectMeta{Name: "tigera-pull-secret"}},
+			},
+		}
+	})
+
+	It("should render a Prometheus instance limited to the tenant namespace", func() {
+		component := monitor.TenantPrometheus(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+		Expect(toDelete).To(BeEmpty())
+
+		expectedResources := []resource{
+			{"prometheus", tenantNS, "", "v1", "ServiceAccount"},
+			{"tigera-prometheus-role", tenantNS, "rbac.authorization.k8s.io", "v1", "Role"},
+			{"tigera-prometheus-role-binding", tenantNS, "rbac.authorization.k8s.io", "v1", "RoleBinding"},
+			{"calico-node-prometheus", tenantNS, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind},
+			{"calico-node-prometheus", tenantNS, "", "v1", "Service"},
+		}
+		Expect(toCreate).To(HaveLen(len(expectedResources)))
+		for i, expectedRes := range expectedResources {
+			rtest.ExpectResourceTypeAndObjectMetadata(toCreate[i], expectedRes.name, expectedRes.ns, expectedRes.group, expectedRes.version, expectedRes.kind)
+		}
+
+		prometheus := rtest.GetResource(toCreate, "calico-node-prometheus", tenantNS, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.ServiceMonitorNamespaceSelector).To(BeNil())
+		Expect(prometheus.Spec.PodMonitorNamespaceSelector).To(BeNil())
+		Expect(prometheus.Spec.IgnoreNamespaceSelectors).To(BeTrue())
+		Expect(prometheus.Spec.ServiceAccountName).To(Equal("prometheus"))
+		Expect(prometheus.Spec.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: "tigera-pull-secret"}))
+
+		binding := rtest.GetResource(toCreate, "tigera-prometheus-role-binding", tenantNS, "rbac.authorization.k8s.io", "v1", "RoleBinding").(*rbacv1.RoleBinding)
+		Expect(binding.Subjects).To(ConsistOf(rbacv1.Subject{Kind: "ServiceAccount", Name: "prometheus", Namespace: tenantNS}))
+	})
+})
@@ -124,6 +124,14 @@ type NodeConfiguration struct {
 	// The bindMode read from the default BGPConfiguration. Used to trigger rolling updates
 	// should this value change.
 	BindMode string
+
+	// The nftables mode that Felix runs with. It takes precedence over the FelixConfiguration,
+	// so that a change of the mode only reaches the nodes as the controller rolls it out.
+	NFTablesMode string
+
+	// NFTablesModeCanary is set while the controller replaces the pods of the canary nodes
+	// with a new nftables mode, which needs the OnDelete update strategy.
+	NFTablesModeCanary bool
 }
 
 // Node creates the node daemonset and other resources for the daemonset to operate normally.
@@ -141,6 +149,20 @@ type nodeComponent struct {
 	nodeImage    string
 }
 
+// NodeImage returns the image of the calico-node container for the given installation.
+func NodeImage(installation *operatorv1.InstallationSpec, is *operatorv1.ImageSet) (string, error) {
+	reg := installation.Registry
+	path := installation.ImagePath
+	prefix := installation.ImagePrefix
+	if installation.Variant == operatorv1.TigeraSecureEnterprise {
+		return components.GetReference(components.ComponentTigeraNode, reg, path, prefix, is)
+	}
+	if operatorv1.IsFIPSModeEnabled(installation.FIPSMode) {
+		return components.GetReference(components.ComponentCalicoNodeFIPS, reg, path, prefix, is)
+	}
+	return components.GetReference(components.ComponentCalicoNode, reg, path, prefix, is)
+}
+
 func (c *nodeComponent) ResolveImages(is *operatorv1.ImageSet) error {
 	reg := c.cfg.Installation.Registry
 	path := c.cfg.Installation.ImagePath
@@ -744,7 +766,12 @@ func (c *nodeComponent) nodeCNIConfigMap() *corev1.ConfigMap {
 
 	plugins := make([]interface{}, 0)
 	plugins = append(plugins, c.createCalicoPluginConfig())
-	plugins = append(plugins, c.createBandwidthPlugin())
+
+	// optional bandwidth plugin, enabled unless explicitly disabled
+	if c.cfg.Installation.CalicoNetwork.BandwidthPlugin == nil ||
+		*c.cfg.Installation.CalicoNetwork.BandwidthPlugin == operatorv1.BandwidthPluginEnabled {
+		plugins = append(plugins, c.createBandwidthPlugin())
+	}
 
 	// optional portmap plugin
 	if c.cfg.Installation.CalicoNetwork.HostPorts != nil &&
@@ -990,6 +1017,12 @@ func (c *nodeComponent) nodeDaemonset(cniCfgMap *corev1.ConfigMap) *appsv1.Daemo
 		},
 	}
 
+	// A coordinated rollout replaces the pods itself, one node at a time, and so does the canary
+	// phase of a change of the nftables mode.
+	if c.cfg.Installation.CoordinatedNodeRollout != nil || c.cfg.NFTablesModeCanary {
+		ds.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType}
+	}
+
 	if c.cfg.Installation.CNI.Type == operatorv1.PluginCalico {
 		ds.Spec.Template.Spec.InitContainers = append(ds.Spec.Template.Spec.InitContainers, c.cniContainer())
 	}
@@ -1439,6 +1472,10 @@ func (c *nodeComponent) nodeEnvVars() []corev1.EnvVar {
 		{Name: "NO_DEFAULT_POOLS", Value: "true"},
 	}
 
+	if c.cfg.NFTablesMode != "" {
+		nodeEnv = append(nodeEnv, corev1.EnvVar{Name: "FELIX_NFTABLESMODE", Value: c.cfg.NFTablesMode})
+	}
+
 	// We need at least the CN or URISAN set, we depend on the validation
 	// done by the core_controller that the Secret will have one.
 	if c.cfg.TLS.TyphaCommonName != "" {
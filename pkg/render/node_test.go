@@ -15,6 +15,7 @@
 package render_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -2353,6 +2354,38 @@ var _ = Describe("Node rendering tests", func() {
 				Expect(ds.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable).To(Equal(&two))
 			})
 
+			It("should leave replacing pods to the operator for a coordinated rollout", func() {
+				defaultInstance.CoordinatedNodeRollout = &operatorv1.CoordinatedNodeRollout{}
+				component := render.Node(&cfg)
+				Expect(component.ResolveImages(nil)).To(BeNil())
+				resources, _ := component.Objects()
+
+				dsResource := rtest.GetResource(resources, "calico-node", "calico-system", "apps", "v1", "DaemonSet")
+				Expect(dsResource).ToNot(BeNil())
+				ds := dsResource.(*appsv1.DaemonSet)
+				Expect(ds.Spec.UpdateStrategy.Type).To(Equal(appsv1.OnDeleteDaemonSetStrategyType))
+				Expect(ds.Spec.UpdateStrategy.RollingUpdate).To(BeNil())
+			})
+
+			It("should pin the nftables mode and leave replacing the canary pods to the operator", func() {
+				cfg.NFTablesMode = "Enabled"
+				cfg.NFTablesModeCanary = true
+				component := render.Node(&cfg)
+				Expect(component.ResolveImages(nil)).To(BeNil())
+				resources, _ := component.Objects()
+
+				dsResource := rtest.GetResource(resources, "calico-node", "calico-system", "apps", "v1", "DaemonSet")
+				Expect(dsResource).ToNot(BeNil())
+				ds := dsResource.(*appsv1.DaemonSet)
+				Expect(ds.Spec.UpdateStrategy.Type).To(Equal(appsv1.OnDeleteDaemonSetStrategyType))
+				rtest.ExpectEnv(ds.Spec.Template.Spec.Containers[0].Env, "FELIX_NFTABLESMODE", "Enabled")
+
+				cfg.NFTablesModeCanary = false
+				resources, _ = render.Node(&cfg).Objects()
+				ds = rtest.GetResource(resources, "calico-node", "calico-system", "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+				Expect(ds.Spec.UpdateStrategy).To(Equal(defaultInstance.NodeUpdateStrategy))
+			})
+
 			It("should render LinuxPolicySetupTimeoutSeconds if a custom value was set", func() {
 				two := int32(2)
 				defaultInstance.CalicoNetwork.LinuxPolicySetupTimeoutSeconds = &two
@@ -2374,6 +2407,27 @@ var _ = Describe("Node rendering tests", func() {
 				}
 			})
 
+			It("should render cni config without the bandwidth plugin when it is disabled", func() {
+				bpd := operatorv1.BandwidthPluginDisabled
+				defaultInstance.CalicoNetwork.BandwidthPlugin = &bpd
+				component := render.Node(&cfg)
+				Expect(component.ResolveImages(nil)).To(BeNil())
+				resources, _ := component.Objects()
+
+				cniCmResource := rtest.GetResource(resources, "cni-config", "calico-system", "", "v1", "ConfigMap")
+				Expect(cniCmResource).ToNot(BeNil())
+				cniCm := cniCmResource.(*corev1.ConfigMap)
+
+				var config struct {
+					Plugins []map[string]interface{} `json:"plugins"`
+				}
+				Expect(json.Unmarshal([]byte(cniCm.Data["config"]), &config)).To(Succeed())
+				for _, plugin := range config.Plugins {
+					Expect(plugin["type"]).NotTo(Equal("bandwidth"))
+				}
+				Expect(config.Plugins).To(ContainElement(HaveKeyWithValue("type", "portmap")))
+			})
+
 			It("should render cni config without portmap when HostPorts disabled", func() {
 				expectedResources := []struct {
 					name    string
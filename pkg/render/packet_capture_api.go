@@ -123,7 +123,7 @@ func (pc *packetCaptureApiComponent) Objects() ([]client.Object, []client.Object
 	}
 
 	if pc.cfg.TrustedBundle != nil {
-		objs = append(objs, pc.cfg.TrustedBundle.ConfigMap(PacketCaptureNamespace))
+		objs = append(objs, configmap.ToRuntimeObjects(pc.cfg.TrustedBundle.ConfigMaps(PacketCaptureNamespace)...)...)
 	}
 
 	return objs, nil
@@ -247,7 +247,7 @@ func (pc *packetCaptureApiComponent) deployment() *appsv1.Deployment {
 				Spec: corev1.PodSpec{
 					NodeSelector:       pc.cfg.Installation.ControlPlaneNodeSelector,
 					ServiceAccountName: PacketCaptureServiceAccountName,
-					Tolerations:        append(pc.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateCriticalAddonsAndControlPlane...),
+					Tolerations:        rmeta.ControlPlaneTolerations(pc.cfg.Installation, rmeta.TolerateCriticalAddonsAndControlPlane...),
 					ImagePullSecrets:   secret.GetReferenceList(pc.cfg.PullSecrets),
 					InitContainers:     pc.initContainers(),
 					Containers:         []corev1.Container{pc.container()},
@@ -126,7 +126,10 @@ func (pr *policyRecommendationComponent) Objects() ([]client.Object, []client.Ob
 		return objs, nil
 	}
 
-	objs = append(objs, secret.ToRuntimeObjects(secret.CopyToNamespace(pr.cfg.Namespace, pr.cfg.PullSecrets...)...)...)
+	if !pr.cfg.Tenant.MultiTenant() {
+		// In multi-tenant management clusters, the pull secrets are copied into the tenant namespace by the tenant controller.
+		objs = append(objs, secret.ToRuntimeObjects(secret.CopyToNamespace(pr.cfg.Namespace, pr.cfg.PullSecrets...)...)...)
+	}
 
 	// The deployment is created on management/standalone clusters only
 	objs = append(objs,
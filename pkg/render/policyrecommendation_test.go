@@ -531,6 +531,24 @@ var _ = Describe("Policy recommendation rendering tests", func() {
 			Expect(envs).To(ContainElement(corev1.EnvVar{Name: "LINSEED_URL", Value: fmt.Sprintf("https://tigera-linseed.%s.svc", render.ElasticsearchNamespace)}))
 		})
 
+		It("should leave copying the pull secrets to the tenant controller", func() {
+			cfg.Namespace = tenantANamespace
+			cfg.Tenant = &operatorv1.Tenant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tenantA",
+					Namespace: tenantANamespace,
+				},
+				Spec: operatorv1.TenantSpec{
+					ID: "tenant-a-id",
+				},
+			}
+			cfg.PullSecrets = []*corev1.Secret{{ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: common.OperatorNamespace()}}}
+			tenantPolicyRec := render.PolicyRecommendation(cfg)
+
+			createdResources, _ := tenantPolicyRec.Objects()
+			Expect(rtest.GetResource(createdResources, "pull-secret", tenantANamespace, "", "v1", "Secret")).To(BeNil())
+		})
+
 		It("should render RBAC per tenant", func() {
 			cfg.Namespace = tenantANamespace
 			cfg.Tenant = &operatorv1.Tenant{
@@ -0,0 +1,187 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/securitycontext"
+)
+
+const (
+	PreflightName                 = "calico-preflight"
+	PreflightChecksContainer      = "checks"
+	PreflightChecksHashAnnotation = "operator.tigera.io/preflight-checks-hash"
+
+	// The checks that the preflight can run. A port check is PreflightCheckPortPrefix followed by the port and
+	// protocol, e.g. "port:179/tcp".
+	PreflightCheckBPFKernel  = "bpf-kernel"
+	PreflightCheckWireGuard  = "wireguard"
+	PreflightCheckRPFilter   = "rp-filter"
+	PreflightCheckPortPrefix = "port:"
+)
+
+// preflightScript runs the checks listed in $CHECKS and writes a line for each failure to the termination message of
+// the container, as "FAIL <check> <message>", followed by "DONE". The operator reads the results from the pod status.
+const preflightScript = `out=/dev/termination-log
+: > "$out"
+for check in $CHECKS; do
+  case "$check" in
+  bpf-kernel)
+    release=$(uname -r)
+    major=${release%%.*}
+    rest=${release#*.}
+    minor=${rest%%.*}
+    if [ "$major" -lt 5 ] || { [ "$major" -eq 5 ] && [ "$minor" -lt 3 ]; }; then
+      echo "FAIL $check kernel $release is older than 5.3, which the eBPF dataplane requires" >> "$out"
+    fi
+    ;;
+  wireguard)
+    if [ ! -d /sys/module/wireguard ] && [ -z "$(find /lib/modules/$(uname -r) -name 'wireguard.ko*' 2>/dev/null)" ]; then
+      echo "FAIL $check the wireguard kernel module is neither loaded nor installed" >> "$out"
+    fi
+    ;;
+  rp-filter)
+    if [ "$(cat /proc/sys/net/ipv4/conf/all/rp_filter)" = "2" ]; then
+      echo "FAIL $check net.ipv4.conf.all.rp_filter is 2 (loose), which Felix refuses unless ignoreLooseRPF is set in FelixConfiguration" >> "$out"
+    fi
+    ;;
+  port:*)
+    spec=${check#port:}
+    port=$(printf '%04X' "${spec%/*}")
+    if [ "${spec#*/}" = "udp" ]; then
+      pattern="^ *[0-9]+: [0-9A-F]+:$port "
+      files="/proc/net/udp /proc/net/udp6"
+    else
+      pattern="^ *[0-9]+: [0-9A-F]+:$port [0-9A-F]+:[0-9A-F]+ 0A "
+      files="/proc/net/tcp /proc/net/tcp6"
+    fi
+    if cat $files 2>/dev/null | grep -Eq "$pattern"; then
+      echo "FAIL $check port $spec is already in use on the host" >> "$out"
+    fi
+    ;;
+  esac
+done
+echo DONE >> "$out"
+`
+
+// PreflightConfiguration is the configuration of the DaemonSet that runs the preflight checks on every Linux node.
+type PreflightConfiguration struct {
+	Installation *operatorv1.InstallationSpec
+
+	// Checks are the checks to run.
+	Checks []string
+
+	// ChecksHash identifies the checks. It's set on the pods, so that results of other checks are ignored.
+	ChecksHash string
+
+	// Done removes the DaemonSet, once every node has reported its results or the checks are no longer needed.
+	Done bool
+}
+
+func Preflight(cfg *PreflightConfiguration) Component {
+	return &preflightComponent{cfg: cfg}
+}
+
+type preflightComponent struct {
+	cfg   *PreflightConfiguration
+	image string
+}
+
+func (c *preflightComponent) ResolveImages(is *operatorv1.ImageSet) error {
+	// The checks only need a shell and basic tools, which the calico/node image has.
+	var err error
+	c.image, err = NodeImage(c.cfg.Installation, is)
+	return err
+}
+
+func (c *preflightComponent) Objects() ([]client.Object, []client.Object) {
+	ds := c.daemonSet()
+	if c.cfg.Done {
+		return nil, []client.Object{ds}
+	}
+	return []client.Object{ds}, nil
+}
+
+func (c *preflightComponent) Ready() bool {
+	return true
+}
+
+func (c *preflightComponent) SupportedOSType() rmeta.OSType {
+	return rmeta.OSTypeLinux
+}
+
+func (c *preflightComponent) daemonSet() *appsv1.DaemonSet {
+	labels := map[string]string{"k8s-app": PreflightName}
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      labels,
+			Annotations: map[string]string{PreflightChecksHashAnnotation: c.cfg.ChecksHash},
+		},
+		Spec: corev1.PodSpec{
+			// The checks look at the host's network namespace and kernel.
+			HostNetwork:      true,
+			DNSPolicy:        corev1.DNSClusterFirstWithHostNet,
+			NodeSelector:     map[string]string{"kubernetes.io/os": "linux"},
+			Tolerations:      rmeta.TolerateAll,
+			ImagePullSecrets: c.cfg.Installation.ImagePullSecrets,
+			InitContainers: []corev1.Container{{
+				Name:            PreflightChecksContainer,
+				Image:           c.image,
+				ImagePullPolicy: ImagePullPolicy(),
+				Command:         []string{"/bin/sh", "-c", preflightScript},
+				Env:             []corev1.EnvVar{{Name: "CHECKS", Value: strings.Join(c.cfg.Checks, " ")}},
+				SecurityContext: securitycontext.NewRootContext(true),
+				VolumeMounts:    []corev1.VolumeMount{{Name: "lib-modules", MountPath: "/lib/modules", ReadOnly: true}},
+			}},
+			// The pod stays up once the checks have run, so that the DaemonSet doesn't restart it.
+			Containers: []corev1.Container{{
+				Name:            "wait",
+				Image:           c.image,
+				ImagePullPolicy: ImagePullPolicy(),
+				Command:         []string{"/bin/sh", "-c", "while true; do sleep 3600; done"},
+				SecurityContext: securitycontext.NewNonRootContext(),
+			}},
+			Volumes: []corev1.Volume{{
+				Name:         "lib-modules",
+				VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/lib/modules"}},
+			}},
+		},
+	}
+	setNodeCriticalPod(&template)
+
+	return &appsv1.DaemonSet{
+		TypeMeta:   metav1.TypeMeta{Kind: "DaemonSet", APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: PreflightName, Namespace: common.CalicoNamespace},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: template,
+		},
+	}
+}
+
+// PreflightPortCheck returns the check that the port is free on every node.
+func PreflightPortCheck(port int32, protocol string) string {
+	return fmt.Sprintf("%s%d/%s", PreflightCheckPortPrefix, port, protocol)
+}
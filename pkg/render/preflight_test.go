@@ -0,0 +1,70 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/render"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	rtest "github.com/tigera/operator/pkg/render/common/test"
+)
+
+var _ = Describe("Preflight rendering tests", func() {
+	var cfg *render.PreflightConfiguration
+
+	BeforeEach(func() {
+		cfg = &render.PreflightConfiguration{
+			Installation: &operatorv1.InstallationSpec{Variant: operatorv1.Calico, Registry: "test-reg/"},
+			Checks:       []string{render.PreflightCheckRPFilter, render.PreflightPortCheck(179, "tcp")},
+			ChecksHash:   "abc",
+		}
+	})
+
+	It("should render a DaemonSet that runs the checks on every node", func() {
+		component := render.Preflight(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+		Expect(toDelete).To(BeEmpty())
+		Expect(toCreate).To(HaveLen(1))
+
+		ds := rtest.GetResource(toCreate, render.PreflightName, common.CalicoNamespace, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		spec := ds.Spec.Template.Spec
+		Expect(ds.Spec.Template.Annotations).To(HaveKeyWithValue(render.PreflightChecksHashAnnotation, "abc"))
+		Expect(spec.HostNetwork).To(BeTrue())
+		Expect(spec.Tolerations).To(ConsistOf(rmeta.TolerateAll))
+		Expect(spec.InitContainers).To(HaveLen(1))
+		checks := spec.InitContainers[0]
+		Expect(checks.Name).To(Equal(render.PreflightChecksContainer))
+		Expect(checks.Image).To(HavePrefix("test-reg/calico/node:"))
+		Expect(checks.Env).To(ContainElement(corev1.EnvVar{Name: "CHECKS", Value: "rp-filter port:179/tcp"}))
+		Expect(*checks.SecurityContext.Privileged).To(BeTrue())
+	})
+
+	It("should delete the DaemonSet once the checks are done", func() {
+		cfg.Done = true
+		component := render.Preflight(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+		Expect(toCreate).To(BeEmpty())
+		Expect(toDelete).To(HaveLen(1))
+		Expect(toDelete[0].GetName()).To(Equal(render.PreflightName))
+	})
+})
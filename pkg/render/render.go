@@ -15,6 +15,8 @@
 package render
 
 import (
+	"fmt"
+
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -49,3 +51,37 @@ func SetClusterCriticalPod(t *corev1.PodTemplateSpec) {
 func ImagePullPolicy() corev1.PullPolicy {
 	return corev1.PullIfNotPresent
 }
+
+// SetShutdownTimeout sets the env var of the named container that tells it how long it has to drain its connections
+// after it receives its termination signal. It is set to the termination grace period of the pod, so that it must be
+// called after the overrides are applied, which may change the grace period.
+func SetShutdownTimeout(spec *corev1.PodSpec, containerName, envVar string) {
+	gracePeriod := int64(corev1.DefaultTerminationGracePeriodSeconds)
+	if spec.TerminationGracePeriodSeconds != nil {
+		gracePeriod = *spec.TerminationGracePeriodSeconds
+	}
+	for i := range spec.Containers {
+		if spec.Containers[i].Name != containerName {
+			continue
+		}
+		env := corev1.EnvVar{Name: envVar, Value: fmt.Sprint(gracePeriod)}
+		for j, e := range spec.Containers[i].Env {
+			if e.Name == envVar {
+				spec.Containers[i].Env[j] = env
+				return
+			}
+		}
+		spec.Containers[i].Env = append(spec.Containers[i].Env, env)
+		return
+	}
+}
+
+// StartupProbe returns a startup probe that uses the handler of the given probe. It gives a slow starting
+// container up to five minutes to come up before its liveness and readiness probes take effect.
+func StartupProbe(p *corev1.Probe) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler:     *p.ProbeHandler.DeepCopy(),
+		PeriodSeconds:    10,
+		FailureThreshold: 30,
+	}
+}
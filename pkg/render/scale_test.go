@@ -0,0 +1,223 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render_test
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/certificatemanager"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/render"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
+)
+
+// The scale harness renders the components that a multi-tenant management cluster runs for each tenant, for many
+// tenants, so that render regressions that only show at scale are caught. The benchmarks can be run with:
+//
+//	go test ./pkg/render/ -run '^$' -bench RenderTenants -benchmem
+//
+// The unit tests always check the allocation budgets. They only check the time budget if RENDER_TIME_BUDGET is set,
+// since the time taken depends on the machine the tests run on. RENDER_SCALE_TENANTS sets the number of tenants.
+const (
+	// tenantRenderAllocsBudget is the most allocations that rendering the components of a tenant may make. It's set
+	// well above the current number so that only real regressions fail.
+	tenantRenderAllocsBudget = 1000
+
+	defaultScaleTenants = 10
+)
+
+// tenantRenderer renders the per-tenant components. The key pairs and bundle are shared by all tenants, as creating
+// them would otherwise dominate the time taken.
+type tenantRenderer struct {
+	manager    render.ManagerConfiguration
+	compliance render.ComplianceConfiguration
+}
+
+func newTenantRenderer() (*tenantRenderer, error) {
+	scheme := runtime.NewScheme()
+	if err := apis.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	cli := ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+	replicas := int32(2)
+	installation := &operatorv1.InstallationSpec{
+		KubernetesProvider:   operatorv1.ProviderNone,
+		Registry:             "testregistry.com/",
+		ControlPlaneReplicas: &replicas,
+	}
+
+	certificateManager, err := certificatemanager.Create(cli, installation, dns.DefaultClusterDomain, common.OperatorNamespace(), certificatemanager.AllowCACreation())
+	if err != nil {
+		return nil, err
+	}
+	keyPair := func(name string) (certificatemanagement.KeyPairInterface, error) {
+		return certificateManager.GetOrCreateKeyPair(cli, name, common.OperatorNamespace(), []string{name})
+	}
+	r := &tenantRenderer{}
+	bundle := certificateManager.CreateTrustedBundle()
+
+	r.manager = render.ManagerConfiguration{
+		TrustedCertBundle:       bundle,
+		Installation:            installation,
+		ClusterDomain:           dns.DefaultClusterDomain,
+		ESLicenseType:           render.ElasticsearchLicenseTypeEnterpriseTrial,
+		Replicas:                installation.ControlPlaneReplicas,
+		ComplianceLicenseActive: true,
+		TruthNamespace:          common.OperatorNamespace(),
+		ExternalElastic:         true,
+	}
+	if r.manager.TLSKeyPair, err = keyPair(render.ManagerTLSSecretName); err != nil {
+		return nil, err
+	}
+	if r.manager.InternalTLSKeyPair, err = keyPair(render.ManagerInternalTLSSecretName); err != nil {
+		return nil, err
+	}
+
+	r.compliance = render.ComplianceConfiguration{
+		Installation:    installation,
+		ClusterDomain:   dns.DefaultClusterDomain,
+		TrustedBundle:   bundle,
+		ExternalElastic: true,
+	}
+	if r.compliance.ServerKeyPair, err = keyPair(render.ComplianceServerCertSecret); err != nil {
+		return nil, err
+	}
+	if r.compliance.ControllerKeyPair, err = keyPair(render.ComplianceControllerSecret); err != nil {
+		return nil, err
+	}
+	if r.compliance.BenchmarkerKeyPair, err = keyPair(render.ComplianceBenchmarkerSecret); err != nil {
+		return nil, err
+	}
+	if r.compliance.ReporterKeyPair, err = keyPair(render.ComplianceReporterSecret); err != nil {
+		return nil, err
+	}
+	if r.compliance.SnapshotterKeyPair, err = keyPair(render.ComplianceSnapshotterSecret); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// render renders the components of the i-th tenant and returns the number of objects they rendered.
+func (r *tenantRenderer) render(i int) (int, error) {
+	ns := fmt.Sprintf("tenant-%d", i)
+	tenant := &operatorv1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: ns},
+		Spec:       operatorv1.TenantSpec{ID: fmt.Sprintf("tenant-%d-id", i)},
+	}
+
+	managerCfg := r.manager
+	managerCfg.Namespace = ns
+	managerCfg.BindingNamespaces = []string{ns}
+	managerCfg.Tenant = tenant
+	complianceCfg := r.compliance
+	complianceCfg.Namespace = ns
+	complianceCfg.Tenant = tenant
+
+	manager, err := render.Manager(&managerCfg)
+	if err != nil {
+		return 0, err
+	}
+	compliance, err := render.Compliance(&complianceCfg)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, component := range []render.Component{manager, compliance} {
+		if err := component.ResolveImages(nil); err != nil {
+			return 0, err
+		}
+		objsToCreate, objsToDelete := component.Objects()
+		count += len(objsToCreate) + len(objsToDelete)
+	}
+	return count, nil
+}
+
+func BenchmarkRenderTenants(b *testing.B) {
+	r, err := newTenantRenderer()
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, tenants := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("tenants=%d", tenants), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				for i := 0; i < tenants; i++ {
+					if _, err := r.render(i); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+var _ = Describe("render scale", func() {
+	var r *tenantRenderer
+
+	BeforeEach(func() {
+		var err error
+		r, err = newTenantRenderer()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should render the components of a tenant within the allocation budget", func() {
+		var err error
+		allocs := testing.AllocsPerRun(5, func() {
+			_, err = r.render(0)
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allocs).To(BeNumerically("<=", tenantRenderAllocsBudget))
+	})
+
+	It("should render the same objects for every tenant within the time budget", func() {
+		tenants := defaultScaleTenants
+		if s := os.Getenv("RENDER_SCALE_TENANTS"); s != "" {
+			var err error
+			tenants, err = strconv.Atoi(s)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		expected, err := r.render(0)
+		Expect(err).NotTo(HaveOccurred())
+		start := time.Now()
+		for i := 0; i < tenants; i++ {
+			count, err := r.render(i)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(expected), "tenant %d", i)
+		}
+		perTenant := time.Since(start) / time.Duration(tenants)
+
+		if s := os.Getenv("RENDER_TIME_BUDGET"); s != "" {
+			budget, err := time.ParseDuration(s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(perTenant).To(BeNumerically("<=", budget), "rendering took %s per tenant", perTenant)
+		}
+	})
+})
@@ -0,0 +1,379 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package siemexport renders the CronJob that exports compliance reports and security events from Linseed to object
+// storage in JSON Lines format, for SIEMs to ingest.
+package siemexport
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/components"
+	"github.com/tigera/operator/pkg/ptr"
+	"github.com/tigera/operator/pkg/render"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/common/secret"
+	"github.com/tigera/operator/pkg/render/common/securitycontext"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
+)
+
+const (
+	// Name is the name of the CronJob, its ServiceAccount and its pods' k8s-app label.
+	Name       = "tigera-siem-export"
+	PolicyName = networkpolicy.TigeraComponentPolicyPrefix + Name
+
+	// CredentialsSecretName is the copy of the bucket's credentials in the namespace of the CronJob. The credentials
+	// secret in the operator namespace holds the AccessKeyIDKey and SecretAccessKeyKey keys.
+	CredentialsSecretName = "tigera-siem-export-credentials"
+	AccessKeyIDKey        = "access-key-id"
+	SecretAccessKeyKey    = "secret-access-key"
+
+	DefaultSchedule = "0 * * * *"
+)
+
+// Source is the data that an export reads from Linseed.
+type Source string
+
+const (
+	SourceComplianceReports Source = "compliance-reports"
+	SourceSecurityEvents    Source = "security-events"
+)
+
+// KeyPairName returns the name of the secret of the key pair that the export of the source presents to Linseed.
+func KeyPairName(source Source) string {
+	return fmt.Sprintf("%s-%s-tls", Name, source)
+}
+
+// Config contains the information needed to render the export of a source.
+type Config struct {
+	Source    Source
+	Namespace string
+
+	// Export is the configuration of the export. If it's nil, the export is removed.
+	Export *operatorv1.SIEMExport
+
+	// Credentials is the secret in the operator namespace that holds the access key of the bucket.
+	Credentials *corev1.Secret
+
+	// TimeZone is the time zone that the schedule is interpreted in. If it's empty, the schedule is in UTC.
+	TimeZone string
+
+	Installation  *operatorv1.InstallationSpec
+	PullSecrets   []*corev1.Secret
+	OpenShift     bool
+	TrustedBundle certificatemanagement.TrustedBundleRO
+	KeyPair       certificatemanagement.KeyPairInterface
+}
+
+// SIEMExport returns a component that renders the export CronJob of a source.
+func SIEMExport(cfg *Config) render.Component {
+	return &component{cfg: cfg}
+}
+
+type component struct {
+	cfg   *Config
+	image string
+}
+
+func (c *component) ResolveImages(is *operatorv1.ImageSet) error {
+	if c.cfg.Export == nil {
+		return nil
+	}
+	reg := c.cfg.Installation.Registry
+	path := c.cfg.Installation.ImagePath
+	prefix := c.cfg.Installation.ImagePrefix
+	var err error
+	c.image, err = components.GetReference(components.ComponentSIEMExporter, reg, path, prefix, is)
+	return err
+}
+
+func (c *component) SupportedOSType() rmeta.OSType {
+	return rmeta.OSTypeLinux
+}
+
+func (c *component) Ready() bool {
+	return true
+}
+
+func (c *component) Objects() ([]client.Object, []client.Object) {
+	objs := []client.Object{
+		c.allowTigeraPolicy(),
+		c.serviceAccount(),
+		c.clusterRole(),
+		c.clusterRoleBinding(),
+		c.credentialsSecret(),
+		c.cronJob(),
+	}
+	if c.cfg.Export == nil {
+		return nil, objs
+	}
+	return objs, nil
+}
+
+func (c *component) serviceAccount() *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		TypeMeta:   metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: c.cfg.Namespace},
+	}
+}
+
+// clusterRoleName is unique to the source, since the export of each source runs in the namespace of its component.
+func (c *component) clusterRoleName() string {
+	return fmt.Sprintf("%s-%s", Name, c.cfg.Source)
+}
+
+func (c *component) clusterRole() *rbacv1.ClusterRole {
+	resource := "events"
+	if c.cfg.Source == SourceComplianceReports {
+		resource = "compliancereports"
+	}
+	return &rbacv1.ClusterRole{
+		TypeMeta:   metav1.TypeMeta{Kind: "ClusterRole", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: c.clusterRoleName()},
+		Rules: []rbacv1.PolicyRule{
+			{
+				// The export only reads the data of its source from Linseed.
+				APIGroups: []string{"linseed.tigera.io"},
+				Resources: []string{resource},
+				Verbs:     []string{"get"},
+			},
+		},
+	}
+}
+
+func (c *component) clusterRoleBinding() *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		TypeMeta:   metav1.TypeMeta{Kind: "ClusterRoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: c.clusterRoleName()},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     c.clusterRoleName(),
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      Name,
+				Namespace: c.cfg.Namespace,
+			},
+		},
+	}
+}
+
+func (c *component) credentialsSecret() *corev1.Secret {
+	s := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: CredentialsSecretName, Namespace: c.cfg.Namespace},
+	}
+	if c.cfg.Credentials != nil {
+		s.Data = map[string][]byte{
+			AccessKeyIDKey:     c.cfg.Credentials.Data[AccessKeyIDKey],
+			SecretAccessKeyKey: c.cfg.Credentials.Data[SecretAccessKeyKey],
+		}
+	}
+	return s
+}
+
+func (c *component) cronJob() *batchv1.CronJob {
+	cj := &batchv1.CronJob{
+		TypeMeta:   metav1.TypeMeta{Kind: "CronJob", APIVersion: "batch/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: c.cfg.Namespace},
+	}
+	if c.cfg.Export == nil {
+		return cj
+	}
+
+	schedule := c.cfg.Export.Schedule
+	if schedule == "" {
+		schedule = DefaultSchedule
+	}
+	storage := c.cfg.Export.ObjectStorage
+	credentialRef := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: CredentialsSecretName},
+			Key:                  key,
+		}}
+	}
+	env := []corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "SIEM_EXPORT_SOURCE", Value: string(c.cfg.Source)},
+		{Name: "SIEM_EXPORT_SCHEDULE", Value: schedule},
+		{Name: "LINSEED_URL", Value: fmt.Sprintf("https://tigera-linseed.%s.svc", render.ElasticsearchNamespace)},
+		{Name: "LINSEED_CA", Value: c.cfg.TrustedBundle.MountPath()},
+		{Name: "LINSEED_CLIENT_CERT", Value: c.cfg.KeyPair.VolumeMountCertificateFilePath()},
+		{Name: "LINSEED_CLIENT_KEY", Value: c.cfg.KeyPair.VolumeMountKeyFilePath()},
+		{Name: "LINSEED_TOKEN", Value: render.GetLinseedTokenPath(false)},
+		{Name: "S3_BUCKET", Value: storage.Bucket},
+		{Name: "S3_PREFIX", Value: storage.Prefix},
+		{Name: "S3_REGION", Value: storage.Region},
+		{Name: "S3_ENDPOINT", Value: storage.Endpoint},
+		{Name: "AWS_ACCESS_KEY_ID", ValueFrom: credentialRef(AccessKeyIDKey)},
+		{Name: "AWS_SECRET_ACCESS_KEY", ValueFrom: credentialRef(SecretAccessKeyKey)},
+	}
+
+	var initContainers []corev1.Container
+	if c.cfg.KeyPair.UseCertificateManagement() {
+		initContainers = append(initContainers, c.cfg.KeyPair.InitContainer(c.cfg.Namespace))
+	}
+
+	annotations := c.cfg.TrustedBundle.HashAnnotations()
+	annotations[c.cfg.KeyPair.HashAnnotationKey()] = c.cfg.KeyPair.HashAnnotationValue()
+
+	var timeZone *string
+	if c.cfg.TimeZone != "" {
+		timeZone = &c.cfg.TimeZone
+	}
+
+	cj.Spec = batchv1.CronJobSpec{
+		Schedule: schedule,
+		TimeZone: timeZone,
+		// Each run exports the data since the previous one, so runs must not overlap.
+		ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+		SuccessfulJobsHistoryLimit: ptr.Int32ToPtr(1),
+		FailedJobsHistoryLimit:     ptr.Int32ToPtr(3),
+		JobTemplate: batchv1.JobTemplateSpec{
+			Spec: batchv1.JobSpec{
+				BackoffLimit: ptr.Int32ToPtr(3),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels:      map[string]string{"k8s-app": Name},
+						Annotations: annotations,
+					},
+					Spec: corev1.PodSpec{
+						ServiceAccountName: Name,
+						RestartPolicy:      corev1.RestartPolicyOnFailure,
+						Tolerations:        rmeta.ControlPlaneTolerations(c.cfg.Installation, rmeta.TolerateControlPlane...),
+						NodeSelector:       c.cfg.Installation.ControlPlaneNodeSelector,
+						ImagePullSecrets:   secret.GetReferenceList(c.cfg.PullSecrets),
+						InitContainers:     initContainers,
+						Containers: []corev1.Container{
+							{
+								Name:            Name,
+								Image:           c.image,
+								ImagePullPolicy: render.ImagePullPolicy(),
+								Env:             env,
+								SecurityContext: securitycontext.NewNonRootContext(),
+								VolumeMounts: append(c.cfg.TrustedBundle.VolumeMounts(c.SupportedOSType()),
+									c.cfg.KeyPair.VolumeMount(c.SupportedOSType())),
+							},
+						},
+						Volumes: []corev1.Volume{
+							c.cfg.TrustedBundle.Volume(),
+							c.cfg.KeyPair.Volume(),
+						},
+					},
+				},
+			},
+		},
+	}
+	return cj
+}
+
+// allowTigeraPolicy allows the export to reach Linseed and the object storage.
+func (c *component) allowTigeraPolicy() *v3.NetworkPolicy {
+	policy := &v3.NetworkPolicy{
+		TypeMeta:   metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "projectcalico.org/v3"},
+		ObjectMeta: metav1.ObjectMeta{Name: PolicyName, Namespace: c.cfg.Namespace},
+	}
+	if c.cfg.Export == nil {
+		return policy
+	}
+
+	egressRules := []v3.Rule{
+		// Block any link local IPs, e.g. cloud metadata, which are often targets of server-side request forgery (SSRF) attacks
+		{
+			Action:      v3.Deny,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Destination: v3.EntityRule{Nets: []string{"169.254.0.0/16"}},
+		},
+		{
+			Action:      v3.Deny,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Destination: v3.EntityRule{Nets: []string{"fe80::/10"}},
+		},
+	}
+	egressRules = networkpolicy.AppendDNSEgressRules(egressRules, c.cfg.OpenShift)
+	egressRules = append(egressRules,
+		v3.Rule{
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Destination: networkpolicy.DefaultHelper().LinseedEntityRule(),
+		},
+		v3.Rule{
+			// The object storage is outside the cluster, at an address that isn't known.
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Destination: v3.EntityRule{Ports: networkpolicy.Ports(networkpolicy.ObjectStoragePort(c.cfg.Export.ObjectStorage.Endpoint))},
+		},
+	)
+
+	policy.Spec = v3.NetworkPolicySpec{
+		Order:    &networkpolicy.HighPrecedenceOrder,
+		Tier:     networkpolicy.TigeraComponentTierName,
+		Selector: networkpolicy.KubernetesAppSelector(Name),
+		Types:    []v3.PolicyType{v3.PolicyTypeIngress, v3.PolicyTypeEgress},
+		Ingress: []v3.Rule{
+			{
+				// The export doesn't listen on any ports.
+				Action: v3.Deny,
+			},
+		},
+		Egress: egressRules,
+	}
+	return policy
+}
+
+// ValidateExport returns an error if the export configuration is invalid.
+func ValidateExport(export *operatorv1.SIEMExport) error {
+	storage := export.ObjectStorage
+	if storage.Bucket == "" {
+		return fmt.Errorf("siemExport.objectStorage.bucket must be specified")
+	}
+	if storage.CredentialsSecretName == "" {
+		return fmt.Errorf("siemExport.objectStorage.credentialsSecretName must be specified")
+	}
+	return ValidateEndpoint("siemExport.objectStorage.endpoint", storage.Endpoint)
+}
+
+// ValidateEndpoint returns an error if the endpoint of an S3 compatible object storage service, in the given field, is
+// not an https URL. An empty endpoint is valid.
+func ValidateEndpoint(field, endpoint string) error {
+	if endpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("%s %q is invalid: %w", field, endpoint, err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("%s %q must be an https URL", field, endpoint)
+	}
+	if port := u.Port(); port != "" {
+		if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+			return fmt.Errorf("%s %q has an invalid port", field, endpoint)
+		}
+	}
+	return nil
+}
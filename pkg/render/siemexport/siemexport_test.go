@@ -0,0 +1,194 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package siemexport
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/certificatemanager"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/render"
+	rtest "github.com/tigera/operator/pkg/render/common/test"
+)
+
+var _ = Describe("SIEM export rendering tests", func() {
+	var cfg *Config
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		cli := ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		certificateManager, err := certificatemanager.Create(cli, nil, "", common.OperatorNamespace(), certificatemanager.AllowCACreation())
+		Expect(err).NotTo(HaveOccurred())
+		keyPair, err := certificateManager.GetOrCreateKeyPair(cli, KeyPairName(SourceComplianceReports), common.OperatorNamespace(), []string{"localhost"})
+		Expect(err).NotTo(HaveOccurred())
+
+		cfg = &Config{
+			Source:    SourceComplianceReports,
+			Namespace: render.ComplianceNamespace,
+			Export: &operatorv1.SIEMExport{
+				ObjectStorage: operatorv1.SIEMExportObjectStorage{
+					Bucket:                "siem",
+					Prefix:                "cluster-a/",
+					Region:                "us-east-1",
+					CredentialsSecretName: "siem-credentials",
+				},
+			},
+			Credentials: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "siem-credentials", Namespace: common.OperatorNamespace()},
+				Data: map[string][]byte{
+					AccessKeyIDKey:     []byte("id"),
+					SecretAccessKeyKey: []byte("secret"),
+				},
+			},
+			Installation:  &operatorv1.InstallationSpec{Registry: "testregistry.com/"},
+			TrustedBundle: certificateManager.CreateTrustedBundle(),
+			KeyPair:       keyPair,
+		}
+	})
+
+	expectedResources := []struct {
+		name    string
+		ns      string
+		group   string
+		version string
+		kind    string
+	}{
+		{PolicyName, render.ComplianceNamespace, "projectcalico.org", "v3", "NetworkPolicy"},
+		{Name, render.ComplianceNamespace, "", "v1", "ServiceAccount"},
+		{"tigera-siem-export-compliance-reports", "", "rbac.authorization.k8s.io", "v1", "ClusterRole"},
+		{"tigera-siem-export-compliance-reports", "", "rbac.authorization.k8s.io", "v1", "ClusterRoleBinding"},
+		{CredentialsSecretName, render.ComplianceNamespace, "", "v1", "Secret"},
+		{Name, render.ComplianceNamespace, "batch", "v1", "CronJob"},
+	}
+
+	It("should render the export CronJob", func() {
+		component := SIEMExport(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		resources, toDelete := component.Objects()
+		Expect(toDelete).To(BeEmpty())
+		Expect(resources).To(HaveLen(len(expectedResources)))
+		for i, expectedRes := range expectedResources {
+			rtest.ExpectResourceTypeAndObjectMetadata(resources[i], expectedRes.name, expectedRes.ns, expectedRes.group, expectedRes.version, expectedRes.kind)
+		}
+
+		secret := rtest.GetResource(resources, CredentialsSecretName, render.ComplianceNamespace, "", "v1", "Secret").(*corev1.Secret)
+		Expect(secret.Data).To(Equal(map[string][]byte{AccessKeyIDKey: []byte("id"), SecretAccessKeyKey: []byte("secret")}))
+
+		role := rtest.GetResource(resources, "tigera-siem-export-compliance-reports", "", "rbac.authorization.k8s.io", "v1", "ClusterRole").(*rbacv1.ClusterRole)
+		Expect(role.Rules).To(ConsistOf(rbacv1.PolicyRule{
+			APIGroups: []string{"linseed.tigera.io"},
+			Resources: []string{"compliancereports"},
+			Verbs:     []string{"get"},
+		}))
+
+		cj := rtest.GetResource(resources, Name, render.ComplianceNamespace, "batch", "v1", "CronJob").(*batchv1.CronJob)
+		Expect(cj.Spec.Schedule).To(Equal(DefaultSchedule))
+		Expect(cj.Spec.TimeZone).To(BeNil())
+		Expect(cj.Spec.ConcurrencyPolicy).To(Equal(batchv1.ForbidConcurrent))
+		pod := cj.Spec.JobTemplate.Spec.Template
+		Expect(pod.Labels).To(HaveKeyWithValue("k8s-app", Name))
+		Expect(pod.Spec.ServiceAccountName).To(Equal(Name))
+		Expect(pod.Spec.Containers).To(HaveLen(1))
+		container := pod.Spec.Containers[0]
+		Expect(container.Image).To(Equal("testregistry.com/tigera/siem-exporter:master"))
+		rtest.ExpectEnv(container.Env, "SIEM_EXPORT_SOURCE", "compliance-reports")
+		rtest.ExpectEnv(container.Env, "S3_BUCKET", "siem")
+		rtest.ExpectEnv(container.Env, "S3_PREFIX", "cluster-a/")
+		rtest.ExpectEnv(container.Env, "LINSEED_URL", "https://tigera-linseed.tigera-elasticsearch.svc")
+		Expect(container.Env).To(ContainElement(corev1.EnvVar{
+			Name: "AWS_SECRET_ACCESS_KEY",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: CredentialsSecretName},
+				Key:                  SecretAccessKeyKey,
+			}},
+		}))
+		Expect(pod.Spec.Volumes).To(HaveLen(2))
+	})
+
+	It("should read security events for the intrusion detection export", func() {
+		cfg.Source = SourceSecurityEvents
+		cfg.Namespace = render.IntrusionDetectionNamespace
+		cfg.Export.Schedule = "*/15 * * * *"
+		resources, _ := SIEMExport(cfg).Objects()
+
+		role := rtest.GetResource(resources, "tigera-siem-export-security-events", "", "rbac.authorization.k8s.io", "v1", "ClusterRole").(*rbacv1.ClusterRole)
+		Expect(role.Rules[0].Resources).To(Equal([]string{"events"}))
+		cj := rtest.GetResource(resources, Name, render.IntrusionDetectionNamespace, "batch", "v1", "CronJob").(*batchv1.CronJob)
+		Expect(cj.Spec.Schedule).To(Equal("*/15 * * * *"))
+		rtest.ExpectEnv(cj.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Env, "SIEM_EXPORT_SOURCE", "security-events")
+	})
+
+	It("should interpret the schedule in the configured time zone", func() {
+		cfg.TimeZone = "Europe/Amsterdam"
+		resources, _ := SIEMExport(cfg).Objects()
+		cj := rtest.GetResource(resources, Name, render.ComplianceNamespace, "batch", "v1", "CronJob").(*batchv1.CronJob)
+		Expect(cj.Spec.TimeZone).To(Equal(&cfg.TimeZone))
+	})
+
+	It("should only allow egress to Linseed, DNS and the object storage port", func() {
+		cfg.Export.ObjectStorage.Endpoint = "https://minio.example.com:9000"
+		resources, _ := SIEMExport(cfg).Objects()
+		policy := rtest.GetResource(resources, PolicyName, render.ComplianceNamespace, "projectcalico.org", "v3", "NetworkPolicy").(*v3.NetworkPolicy)
+		Expect(policy.Spec.Ingress).To(Equal([]v3.Rule{{Action: v3.Deny}}))
+		last := policy.Spec.Egress[len(policy.Spec.Egress)-1]
+		Expect(last.Action).To(Equal(v3.Allow))
+		Expect(last.Destination.Ports).To(HaveLen(1))
+		Expect(last.Destination.Ports[0].MinPort).To(Equal(uint16(9000)))
+	})
+
+	It("should delete the export when it's disabled", func() {
+		cfg.Export = nil
+		cfg.Credentials = nil
+		cfg.KeyPair = nil
+		component := SIEMExport(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		resources, toDelete := component.Objects()
+		Expect(resources).To(BeEmpty())
+		Expect(toDelete).To(HaveLen(len(expectedResources)))
+		for i, expectedRes := range expectedResources {
+			rtest.ExpectResourceTypeAndObjectMetadata(toDelete[i], expectedRes.name, expectedRes.ns, expectedRes.group, expectedRes.version, expectedRes.kind)
+		}
+	})
+
+	DescribeTable("validating the export",
+		func(storage operatorv1.SIEMExportObjectStorage, expectedErr string) {
+			err := ValidateExport(&operatorv1.SIEMExport{ObjectStorage: storage})
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			}
+		},
+		Entry("valid", operatorv1.SIEMExportObjectStorage{Bucket: "b", CredentialsSecretName: "c"}, ""),
+		Entry("valid endpoint", operatorv1.SIEMExportObjectStorage{Bucket: "b", CredentialsSecretName: "c", Endpoint: "https://minio:9000"}, ""),
+		Entry("no bucket", operatorv1.SIEMExportObjectStorage{CredentialsSecretName: "c"}, "bucket must be specified"),
+		Entry("no credentials", operatorv1.SIEMExportObjectStorage{Bucket: "b"}, "credentialsSecretName must be specified"),
+		Entry("http endpoint", operatorv1.SIEMExportObjectStorage{Bucket: "b", CredentialsSecretName: "c", Endpoint: "http://minio"}, "must be an https URL"),
+		Entry("invalid port", operatorv1.SIEMExportObjectStorage{Bucket: "b", CredentialsSecretName: "c", Endpoint: "https://minio:0"}, "invalid port"),
+	)
+})
@@ -0,0 +1,56 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/secret"
+)
+
+// TenantPullSecrets returns a component that copies the image pull secrets of the Installation into the namespace of a
+// tenant. In a multi-tenant management cluster the tenant controller owns these copies, so that the components of the
+// tenant only reference them.
+func TenantPullSecrets(namespace string, pullSecrets []*corev1.Secret) Component {
+	return &tenantPullSecretsComponent{namespace: namespace, pullSecrets: pullSecrets}
+}
+
+type tenantPullSecretsComponent struct {
+	namespace   string
+	pullSecrets []*corev1.Secret
+}
+
+func (c *tenantPullSecretsComponent) ResolveImages(is *operatorv1.ImageSet) error {
+	return nil
+}
+
+func (c *tenantPullSecretsComponent) SupportedOSType() rmeta.OSType {
+	return rmeta.OSTypeAny
+}
+
+func (c *tenantPullSecretsComponent) ReplicatedSecretsKey() string {
+	return "tenant"
+}
+
+func (c *tenantPullSecretsComponent) Objects() ([]client.Object, []client.Object) {
+	return secret.ToRuntimeObjects(secret.CopyToNamespace(c.namespace, c.pullSecrets...)...), nil
+}
+
+func (c *tenantPullSecretsComponent) Ready() bool {
+	return true
+}
@@ -486,7 +486,7 @@ func (c *typhaComponent) typhaVolumeMounts() []corev1.VolumeMount {
 func (c *typhaComponent) typhaPorts() []corev1.ContainerPort {
 	return []corev1.ContainerPort{
 		{
-			ContainerPort: TyphaPort,
+			ContainerPort: c.typhaPort(),
 			Name:          TyphaPortName,
 			Protocol:      corev1.ProtocolTCP,
 		},
@@ -570,6 +570,10 @@ func (c *typhaComponent) typhaEnvVars() []corev1.EnvVar {
 
 	typhaEnv = append(typhaEnv, c.cfg.K8sServiceEp.EnvVars(true, c.cfg.Installation.KubernetesProvider)...)
 
+	if c.cfg.Installation.TyphaPort != nil {
+		typhaEnv = append(typhaEnv, corev1.EnvVar{Name: "TYPHA_SERVERPORT", Value: fmt.Sprintf("%d", *c.cfg.Installation.TyphaPort)})
+	}
+
 	if c.cfg.Installation.TyphaMetricsPort != nil {
 		// If a typha metrics port was given, then enable typha prometheus metrics and set the port.
 		typhaEnv = append(typhaEnv,
@@ -581,6 +585,19 @@ func (c *typhaComponent) typhaEnvVars() []corev1.EnvVar {
 	return typhaEnv
 }
 
+// typhaPort returns the port that typha listens on for connections from calico/node.
+func (c *typhaComponent) typhaPort() int32 {
+	return typhaPort(c.cfg.Installation)
+}
+
+// typhaPort returns the port that typha listens on for connections from calico/node in the installation.
+func typhaPort(installation *operatorv1.InstallationSpec) int32 {
+	if installation.TyphaPort != nil {
+		return *installation.TyphaPort
+	}
+	return TyphaPort
+}
+
 // healthPort returns the liveness and readiness port to use for typha.
 func (c *typhaComponent) healthPort() int {
 	// We use the felix health port, minus one, to determine the port to use for Typha.
@@ -627,7 +644,7 @@ func (c *typhaComponent) typhaService() *corev1.Service {
 		Spec: corev1.ServiceSpec{
 			Ports: []corev1.ServicePort{
 				{
-					Port:       TyphaPort,
+					Port:       c.typhaPort(),
 					Protocol:   corev1.ProtocolTCP,
 					TargetPort: intstr.FromString(TyphaPortName),
 					Name:       TyphaPortName,
@@ -408,6 +408,27 @@ var _ = Describe("Typha rendering tests", func() {
 		Expect(d.Spec.Template.Spec.Containers[0].Env).ToNot(ContainElement(notExpectedEnvVar))
 	})
 
+	It("should listen on a custom port if TyphaPort is set", func() {
+		installation.TyphaPort = ptr.Int32ToPtr(5474)
+		component := render.Typha(&cfg)
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "calico-typha", "calico-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+			corev1.EnvVar{Name: "TYPHA_SERVERPORT", Value: "5474"}))
+		Expect(d.Spec.Template.Spec.Containers[0].Ports).To(ConsistOf(corev1.ContainerPort{
+			ContainerPort: 5474,
+			Name:          "calico-typha",
+			Protocol:      corev1.ProtocolTCP,
+		}))
+
+		svc := rtest.GetResource(resources, "calico-typha", "calico-system", "", "v1", "Service").(*corev1.Service)
+		Expect(svc.Spec.Ports).To(HaveLen(1))
+		Expect(svc.Spec.Ports[0].Port).To(Equal(int32(5474)))
+		Expect(svc.Spec.Ports[0].TargetPort).To(Equal(intstr.FromString("calico-typha")))
+	})
+
 	It("should set TYPHA_PROMETHEUSMETRICSPORT with a custom value if TyphaMetricsPort is set", func() {
 		var typhaMetricsPort int32 = 1234
 		installation.Variant = operatorv1.TigeraSecureEnterprise
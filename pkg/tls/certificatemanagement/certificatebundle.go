@@ -16,10 +16,13 @@ package certificatemanagement
 
 import (
 	"bytes"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"os"
 	"path"
 	"sort"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +37,10 @@ const (
 	SSLCertFile = "cert.pem"
 
 	sslCertDir = "certs"
+
+	// systemConfigMapSuffix is appended to the name of a bundle for the ConfigMap that holds its system certificates,
+	// when they don't fit in the ConfigMap of the bundle.
+	systemConfigMapSuffix = "-system"
 )
 
 type trustedBundle struct {
@@ -56,6 +63,17 @@ func CreateTrustedBundle(certificates ...CertificateInterface) TrustedBundle {
 	return bundle
 }
 
+// CreateNamedTrustedBundle creates a TrustedBundle like CreateTrustedBundle, but with its own ConfigMap name. This lets a
+// component mount a bundle of only the certificates that it needs, instead of the bundle that is shared by the
+// namespace.
+func CreateNamedTrustedBundle(name string, certificates ...CertificateInterface) TrustedBundle {
+	bundle, err := createTrustedBundle(false, name, certificates...)
+	if err != nil {
+		panic(err) // This should never happen.
+	}
+	return bundle
+}
+
 // CreateTrustedBundleWithSystemRootCertificates creates a TrustedBundle, which provides standardized methods for mounting a bundle of certificates to trust.
 // It will include:
 // - A bundle with Calico's root certificates + any user supplied certificates in /etc/pki/tls/certs/tigera-ca-bundle.crt.
@@ -91,9 +109,13 @@ func createTrustedBundle(includeSystemBundle bool, name string, certificates ...
 	return bundle, err
 }
 
-// AddCertificates Adds the certificates to the bundle.
+// AddCertificates Adds the certificates to the bundle. Expired certificates are left out, and a certificate replaces
+// one of the same name that was added before, so that the bundle only holds the certificates that are still in use.
 func (t *trustedBundle) AddCertificates(certificates ...CertificateInterface) {
 	for _, cert := range certificates {
+		if cert == nil || expired(cert.GetCertificatePEM()) {
+			continue
+		}
 		// Check if we already trust an issuer of this cert. In practice, this will be 0 or 1 iteration,
 		// because the issuer is only set when the tigera-ca-private is the issuer.
 		cur := cert
@@ -105,14 +127,39 @@ func (t *trustedBundle) AddCertificates(certificates ...CertificateInterface) {
 				skip = true
 			}
 		}
-		if cert != nil && !skip {
-			// Add the leaf certificate
+		if !skip {
+			// Remove the certificate that this one supersedes and add the leaf certificate.
+			for hash, c := range t.certificates {
+				if cert.GetName() != "" && c.GetName() == cert.GetName() && c.GetNamespace() == cert.GetNamespace() {
+					delete(t.certificates, hash)
+				}
+			}
 			hash := rmeta.AnnotationHash(cert.GetCertificatePEM())
 			t.certificates[hash] = cert
 		}
 	}
 }
 
+// expired returns whether all of the certificates in the PEM have expired. PEM that can't be parsed is not considered
+// expired, so that it is still trusted as before.
+func expired(certPEM []byte) bool {
+	var found bool
+	for block, rest := pem.Decode(certPEM); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return false
+		}
+		if !cert.NotAfter.Before(time.Now()) {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
 func (t *trustedBundle) MountPath() string {
 	return TrustedCertBundleMountPath
 }
@@ -159,6 +206,20 @@ func (t *trustedBundle) VolumeMounts(osType rmeta.OSType) []corev1.VolumeMount {
 }
 
 func (t *trustedBundle) Volume() corev1.Volume {
+	if t.splitSystemCertificates() {
+		// Project both ConfigMaps into the volume, so that the files are at the same paths as without the split.
+		return corev1.Volume{
+			Name: t.name,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{
+						{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: t.name}}},
+						{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: t.name + systemConfigMapSuffix}}},
+					},
+				},
+			},
+		}
+	}
 	return corev1.Volume{
 		Name: t.name,
 		VolumeSource: corev1.VolumeSource{
@@ -169,7 +230,55 @@ func (t *trustedBundle) Volume() corev1.Volume {
 	}
 }
 
+// ConfigMap returns the ConfigMap of the bundle. If the system certificates are split into a ConfigMap of their own,
+// it doesn't hold them: use ConfigMaps to render all of the ConfigMaps of the bundle.
 func (t *trustedBundle) ConfigMap(namespace string) *corev1.ConfigMap {
+	data := map[string]string{TrustedCertConfigMapKeyName: t.certificatesPEM()}
+	if !t.splitSystemCertificates() {
+		data[RHELRootCertificateBundleName] = string(t.systemCertificates)
+	}
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      t.name,
+			Namespace: namespace,
+
+			// Include the hash annotations on the configmap, so that downstrream controllers
+			// can easily acquire them without loading all of the certificates.
+			Annotations: t.HashAnnotations(),
+		},
+		Data: data,
+	}
+}
+
+// ConfigMaps returns the ConfigMaps of the bundle. Kubernetes limits the data of a ConfigMap to 1MiB, so when the
+// system certificates and the other certificates don't fit together, the system certificates are put in a ConfigMap of
+// their own. The volume of the bundle projects both of them, so the files are mounted at the same paths either way.
+func (t *trustedBundle) ConfigMaps(namespace string) []*corev1.ConfigMap {
+	configMaps := []*corev1.ConfigMap{t.ConfigMap(namespace)}
+	if t.splitSystemCertificates() {
+		configMaps = append(configMaps, &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        t.name + systemConfigMapSuffix,
+				Namespace:   namespace,
+				Annotations: map[string]string{"hash.operator.tigera.io/system": rmeta.AnnotationHash(t.systemCertificates)},
+			},
+			Data: map[string]string{RHELRootCertificateBundleName: string(t.systemCertificates)},
+		})
+	}
+	return configMaps
+}
+
+// splitSystemCertificates returns whether the system certificates don't fit in the ConfigMap of the bundle with the
+// other certificates. The certificates of tigera-ca-bundle.crt are never split, since components read them from that
+// one file.
+func (t *trustedBundle) splitSystemCertificates() bool {
+	return len(t.systemCertificates) > 0 && len(t.systemCertificates)+len(t.certificatesPEM()) > corev1.MaxSecretSize
+}
+
+// certificatesPEM returns the content of tigera-ca-bundle.crt.
+func (t *trustedBundle) certificatesPEM() string {
 	pemBuf := bytes.Buffer{}
 
 	// Sort the certificates so that we get a consistent ordering.
@@ -184,22 +293,7 @@ func (t *trustedBundle) ConfigMap(namespace string) *corev1.ConfigMap {
 	for _, cert := range certs {
 		pemBuf.WriteString(fmt.Sprintf("# certificate name: %s/%s\n%s\n\n", cert.GetNamespace(), cert.GetName(), string(cert.GetCertificatePEM())))
 	}
-
-	return &corev1.ConfigMap{
-		TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      t.name,
-			Namespace: namespace,
-
-			// Include the hash annotations on the configmap, so that downstrream controllers
-			// can easily acquire them without loading all of the certificates.
-			Annotations: t.HashAnnotations(),
-		},
-		Data: map[string]string{
-			RHELRootCertificateBundleName: string(t.systemCertificates),
-			TrustedCertConfigMapKeyName:   pemBuf.String(),
-		},
-	}
+	return pemBuf.String()
 }
 
 // NewCertificate creates a new certificate.
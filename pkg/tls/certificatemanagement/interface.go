@@ -36,6 +36,11 @@ const (
 	// TrustedCertConfigMapNamePublic is the name of the trusted certificate bundle ConfigMap that includes public CAs, used
 	// only in multi-tenant environments as a single namespace requires both a trusted bundle with public CAs as well as one without.
 	TrustedCertConfigMapNamePublic = "tigera-ca-bundle-system-certs"
+
+	// UserTrustedCertConfigMapName is the name of an optional ConfigMap in a tenant's namespace that users can add PEM
+	// encoded CA certificates to, for example those of an external Elasticsearch or identity provider. The operator only
+	// reads it, and merges its certificates into the tenant's trusted bundles.
+	UserTrustedCertConfigMapName = "tigera-ca-bundle-user"
 )
 
 // KeyPairInterface wraps a Secret object that contains a private key and a certificate. Whether CertificateManagement is
@@ -68,6 +73,7 @@ type CertificateInterface interface {
 type TrustedBundle interface {
 	MountPath() string
 	ConfigMap(namespace string) *corev1.ConfigMap
+	ConfigMaps(namespace string) []*corev1.ConfigMap
 	HashAnnotations() map[string]string
 	VolumeMounts(osType meta.OSType) []corev1.VolumeMount
 	Volume() corev1.Volume
@@ -0,0 +1,174 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tls
+
+import (
+	gocrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/openshift/library-go/pkg/crypto"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+)
+
+// caDuration is the lifetime of the CAs that the operator creates.
+const caDuration = 100 * 365 * 24 * time.Hour
+
+// KeyAlgorithmOrDefault returns the key algorithm, or RSA 2048 if it isn't set.
+func KeyAlgorithmOrDefault(alg *operatorv1.CertificateKeyAlgorithm) operatorv1.CertificateKeyAlgorithm {
+	if alg == nil || *alg == "" {
+		return operatorv1.CertificateKeyAlgorithmRSA2048
+	}
+	return *alg
+}
+
+// HasKeyAlgorithm returns whether the public key of the certificate is of the given algorithm and size.
+func HasKeyAlgorithm(cert *x509.Certificate, alg operatorv1.CertificateKeyAlgorithm) bool {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return (alg == operatorv1.CertificateKeyAlgorithmRSA2048 && key.N.BitLen() == 2048) ||
+			(alg == operatorv1.CertificateKeyAlgorithmRSA4096 && key.N.BitLen() == 4096)
+	case *ecdsa.PublicKey:
+		return alg == operatorv1.CertificateKeyAlgorithmECDSAP256 && key.Curve == elliptic.P256()
+	}
+	return false
+}
+
+// MakeCAForKeyAlgorithm creates a self-signed CA whose key is of the given algorithm and size.
+func MakeCAForKeyAlgorithm(signerName string, alg operatorv1.CertificateKeyAlgorithm) (*crypto.CA, error) {
+	if alg == operatorv1.CertificateKeyAlgorithmRSA2048 {
+		return MakeCA(signerName)
+	}
+
+	key, keyID, err := newKey(alg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CA: %s", err)
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: signerName},
+		NotBefore:             now.Add(-1 * time.Second),
+		NotAfter:              now.Add(caDuration),
+		SerialNumber:          big.NewInt(now.UnixNano()),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		AuthorityKeyId:        keyID,
+		SubjectKeyId:          keyID,
+	}
+	if alg == operatorv1.CertificateKeyAlgorithmECDSAP256 {
+		// ECDSA keys can't be used for key encipherment.
+		template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CA: %s", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CA: %s", err)
+	}
+	return &crypto.CA{
+		SerialGenerator: &crypto.RandomSerialGenerator{},
+		Config:          &crypto.TLSCertificateConfig{Certs: []*x509.Certificate{cert}, Key: key},
+	}, nil
+}
+
+// MakeServerCertForKeyAlgorithm creates a certificate for the hostnames, signed by the CA, whose key is of the given
+// algorithm and size.
+func MakeServerCertForKeyAlgorithm(ca *crypto.CA, alg operatorv1.CertificateKeyAlgorithm, hostnames sets.String, lifetime time.Duration, fns ...crypto.CertificateExtensionFunc) (*crypto.TLSCertificateConfig, error) {
+	if alg == operatorv1.CertificateKeyAlgorithmRSA2048 {
+		return ca.MakeServerCertForDuration(hostnames, lifetime, fns...)
+	}
+
+	key, keyID, err := newKey(alg)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: hostnames.List()[0]},
+		NotBefore:             now.Add(-1 * time.Second),
+		NotAfter:              now.Add(lifetime),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		AuthorityKeyId:        ca.Config.Certs[0].SubjectKeyId,
+		SubjectKeyId:          keyID,
+	}
+	if alg == operatorv1.CertificateKeyAlgorithmECDSAP256 {
+		// ECDSA keys can't be used for key encipherment.
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+	}
+	template.IPAddresses, template.DNSNames = crypto.IPAddressesDNSNames(hostnames.List())
+	for _, fn := range fns {
+		if err := fn(template); err != nil {
+			return nil, err
+		}
+	}
+	serial, err := ca.SerialGenerator.Next(template)
+	if err != nil {
+		return nil, err
+	}
+	template.SerialNumber = big.NewInt(serial)
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Config.Certs[0], key.Public(), ca.Config.Key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	return &crypto.TLSCertificateConfig{
+		Certs: append([]*x509.Certificate{cert}, ca.Config.Certs...),
+		Key:   key,
+	}, nil
+}
+
+// newKey generates a private key of the given algorithm and size and returns it with the identifier of its public key.
+func newKey(alg operatorv1.CertificateKeyAlgorithm) (gocrypto.Signer, []byte, error) {
+	var key gocrypto.Signer
+	var err error
+	switch alg {
+	case operatorv1.CertificateKeyAlgorithmRSA2048:
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case operatorv1.CertificateKeyAlgorithmRSA4096:
+		key, err = rsa.GenerateKey(rand.Reader, 4096)
+	case operatorv1.CertificateKeyAlgorithmECDSAP256:
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, nil, fmt.Errorf("unsupported certificate key algorithm %q", alg)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	pub, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, nil, err
+	}
+	id := sha1.Sum(pub)
+	return key, id[:], nil
+}
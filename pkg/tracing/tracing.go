@@ -0,0 +1,181 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing exports traces of the operator's reconciles to an OTLP receiver, when the OperatorConfig asks for
+// it. Until then, and after it stops asking for it, spans are started with a tracer that drops them.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	corev1 "k8s.io/api/core/v1"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+)
+
+const (
+	// ServiceName is the name the operator's spans are exported under.
+	ServiceName = "tigera-operator"
+
+	tracerName = "github.com/tigera/operator"
+
+	defaultSamplingPercentage = 100
+)
+
+var (
+	// lock serializes changes to the configuration.
+	lock     sync.Mutex
+	current  *operatorv1.OperatorTracing
+	provider *sdktrace.TracerProvider
+
+	// tracer holds the trace.Tracer that spans are started with. It's swapped when the configuration changes, without
+	// holding up the reconciles that start spans.
+	tracer atomic.Value
+)
+
+// tracerHolder gives the tracers of the noop and SDK providers the same type, as atomic.Value requires.
+type tracerHolder struct {
+	trace.Tracer
+}
+
+func init() {
+	tracer.Store(tracerHolder{noop.NewTracerProvider().Tracer(tracerName)})
+}
+
+// Configure sets up the export of spans as the given configuration asks for, replacing the previous configuration.
+// Spans are not exported if cfg is nil. It does nothing if the configuration hasn't changed since it was last called.
+func Configure(ctx context.Context, cfg *operatorv1.OperatorTracing) error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if reflect.DeepEqual(cfg, current) {
+		return nil
+	}
+	if err := Validate(cfg); err != nil {
+		return err
+	}
+	if cfg == nil {
+		return use(ctx, nil, nil)
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	if err != nil {
+		return fmt.Errorf("failed to create the exporter for %s: %w", cfg.Endpoint, err)
+	}
+	return use(ctx, cfg, sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sampler(cfg)),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", ServiceName))),
+	))
+}
+
+// Shutdown stops the export of spans, after exporting the spans that have ended and are yet to be exported.
+func Shutdown(ctx context.Context) error {
+	return Configure(ctx, nil)
+}
+
+// use makes tp the provider of the tracer that spans are started with. The provider it replaces is shut down, which
+// exports the spans it still holds. Must be called with the lock held.
+func use(ctx context.Context, cfg *operatorv1.OperatorTracing, tp *sdktrace.TracerProvider) error {
+	previous := provider
+	current, provider = cfg.DeepCopy(), tp
+	if tp == nil {
+		tracer.Store(tracerHolder{noop.NewTracerProvider().Tracer(tracerName)})
+	} else {
+		tracer.Store(tracerHolder{tp.Tracer(tracerName)})
+	}
+
+	if previous == nil {
+		return nil
+	}
+	if err := previous.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shut down the previous exporter: %w", err)
+	}
+	return nil
+}
+
+// sampler returns the sampler for the configured percentage of traces. The spans of a trace that was started
+// elsewhere follow that trace's sampling decision.
+func sampler(cfg *operatorv1.OperatorTracing) sdktrace.Sampler {
+	return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(samplingRatio(cfg)))
+}
+
+func samplingRatio(cfg *operatorv1.OperatorTracing) float64 {
+	percentage := int32(defaultSamplingPercentage)
+	if cfg.SamplingPercentage != nil {
+		percentage = *cfg.SamplingPercentage
+	}
+	return float64(percentage) / 100
+}
+
+// Validate returns an error if the tracing configuration is invalid.
+func Validate(cfg *operatorv1.OperatorTracing) error {
+	if cfg == nil {
+		return nil
+	}
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("spec.tracing.endpoint %q is invalid: %w", cfg.Endpoint, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("spec.tracing.endpoint %q must be an http or https URL", cfg.Endpoint)
+	}
+	if p := cfg.SamplingPercentage; p != nil && (*p < 0 || *p > 100) {
+		return fmt.Errorf("spec.tracing.samplingPercentage %d must be between 0 and 100", *p)
+	}
+	return nil
+}
+
+// Start starts a span that is a child of the span in ctx, if there is one. The returned context holds the new span.
+// The span must be ended by the caller.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Load().(tracerHolder).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends the span, marking it as failed if err is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ComponentEnv returns the standard OpenTelemetry SDK variables that make a component export its traces to the
+// configured endpoint. It returns nothing if the components are not configured to export traces.
+func ComponentEnv(cfg *operatorv1.OperatorTracing) []corev1.EnvVar {
+	if !cfg.ComponentsEnabled() {
+		return nil
+	}
+	return []corev1.EnvVar{
+		{Name: "OTEL_TRACES_EXPORTER", Value: "otlp"},
+		{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: "http/protobuf"},
+		{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: cfg.Endpoint},
+		{Name: "OTEL_TRACES_SAMPLER", Value: "parentbased_traceidratio"},
+		{Name: "OTEL_TRACES_SAMPLER_ARG", Value: strconv.FormatFloat(samplingRatio(cfg), 'f', -1, 64)},
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+var _ = Describe("tracing", func() {
+	var (
+		ctx context.Context
+		cfg *operatorv1.OperatorTracing
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		cfg = &operatorv1.OperatorTracing{Endpoint: "http://collector.observability:4318"}
+	})
+
+	AfterEach(func() {
+		Expect(Shutdown(ctx)).NotTo(HaveOccurred())
+	})
+
+	// useExporter has spans exported to the returned exporter as soon as they end.
+	useExporter := func() *tracetest.InMemoryExporter {
+		exporter := tracetest.NewInMemoryExporter()
+		lock.Lock()
+		defer lock.Unlock()
+		Expect(use(ctx, cfg, sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter), sdktrace.WithSampler(sampler(cfg))))).NotTo(HaveOccurred())
+		return exporter
+	}
+
+	It("drops spans until it's configured", func() {
+		_, span := Start(ctx, "reconcile")
+		Expect(span.IsRecording()).To(BeFalse())
+		Expect(span.SpanContext().IsValid()).To(BeFalse())
+	})
+
+	It("exports spans as children of the span in the context", func() {
+		exporter := useExporter()
+
+		ctx, parent := Start(ctx, "reconcile", attribute.String("request.name", "default"))
+		_, child := Start(ctx, "apply")
+		End(child, fmt.Errorf("conflict"))
+		End(parent, nil)
+
+		spans := exporter.GetSpans()
+		Expect(spans).To(HaveLen(2))
+		Expect(spans[0].Name).To(Equal("apply"))
+		Expect(spans[0].Parent.SpanID()).To(Equal(spans[1].SpanContext.SpanID()))
+		Expect(spans[0].Status.Code).To(Equal(codes.Error))
+		Expect(spans[0].Status.Description).To(Equal("conflict"))
+		Expect(spans[1].Name).To(Equal("reconcile"))
+		Expect(spans[1].Attributes).To(ContainElement(attribute.String("request.name", "default")))
+		Expect(spans[1].Status.Code).To(Equal(codes.Unset))
+	})
+
+	It("samples the configured percentage of traces", func() {
+		cfg.SamplingPercentage = ptr.Int32ToPtr(0)
+		exporter := useExporter()
+
+		_, span := Start(ctx, "reconcile")
+		End(span, nil)
+		Expect(exporter.GetSpans()).To(BeEmpty())
+	})
+
+	It("stops exporting spans when the configuration is removed", func() {
+		exporter := useExporter()
+		Expect(Configure(ctx, nil)).NotTo(HaveOccurred())
+
+		_, span := Start(ctx, "reconcile")
+		End(span, nil)
+		Expect(exporter.GetSpans()).To(BeEmpty())
+	})
+
+	It("keeps the exporter when the configuration hasn't changed", func() {
+		exporter := useExporter()
+		Expect(Configure(ctx, cfg.DeepCopy())).NotTo(HaveOccurred())
+
+		_, span := Start(ctx, "reconcile")
+		End(span, nil)
+		Expect(exporter.GetSpans()).To(HaveLen(1))
+	})
+
+	It("rejects an invalid configuration", func() {
+		cfg.Endpoint = "collector:4318"
+		Expect(Configure(ctx, cfg)).To(HaveOccurred())
+	})
+
+	DescribeTable("validation",
+		func(endpoint string, percentage *int32, valid bool) {
+			err := Validate(&operatorv1.OperatorTracing{Endpoint: endpoint, SamplingPercentage: percentage})
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("http endpoint", "http://collector:4318", nil, true),
+		Entry("https endpoint with a path", "https://collector.example.com/otlp", ptr.Int32ToPtr(10), true),
+		Entry("endpoint without a scheme", "collector:4318", nil, false),
+		Entry("grpc endpoint", "grpc://collector:4317", nil, false),
+		Entry("percentage over 100", "http://collector:4318", ptr.Int32ToPtr(101), false),
+		Entry("negative percentage", "http://collector:4318", ptr.Int32ToPtr(-1), false),
+	)
+
+	Context("component env", func() {
+		It("is empty unless components are enabled", func() {
+			Expect(ComponentEnv(nil)).To(BeEmpty())
+			Expect(ComponentEnv(cfg)).To(BeEmpty())
+			cfg.Components = ptr.ToPtr(operatorv1.ComponentTracingDisabled)
+			Expect(ComponentEnv(cfg)).To(BeEmpty())
+		})
+
+		It("points the components at the endpoint with the same sampling", func() {
+			cfg.Components = ptr.ToPtr(operatorv1.ComponentTracingEnabled)
+			cfg.SamplingPercentage = ptr.Int32ToPtr(5)
+			Expect(ComponentEnv(cfg)).To(ConsistOf(
+				corev1.EnvVar{Name: "OTEL_TRACES_EXPORTER", Value: "otlp"},
+				corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: "http/protobuf"},
+				corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://collector.observability:4318"},
+				corev1.EnvVar{Name: "OTEL_TRACES_SAMPLER", Value: "parentbased_traceidratio"},
+				corev1.EnvVar{Name: "OTEL_TRACES_SAMPLER_ARG", Value: "0.05"},
+			))
+		})
+	})
+})
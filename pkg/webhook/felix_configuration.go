@@ -0,0 +1,95 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"github.com/tigera/operator/pkg/common/validation"
+)
+
+// felixConfigurationValidator rejects FelixConfigurations whose fields felix cannot use together. Updates are only
+// rejected for the errors that they introduce, so that a FelixConfiguration that is already invalid can still be
+// updated, including by the operator; the errors that it already had are returned as warnings.
+type felixConfigurationValidator struct{}
+
+var _ admission.CustomValidator = &felixConfigurationValidator{}
+
+func (v *felixConfigurationValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	fc, err := asFelixConfiguration(obj)
+	if err != nil {
+		return nil, err
+	}
+	return nil, invalidFelixConfiguration(fc, validation.FelixConfigurationSpecErrors(&fc.Spec))
+}
+
+func (v *felixConfigurationValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldFC, err := asFelixConfiguration(oldObj)
+	if err != nil {
+		return nil, err
+	}
+	newFC, err := asFelixConfiguration(newObj)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := validation.FelixConfigurationSpecErrors(&oldFC.Spec)
+	var warnings admission.Warnings
+	var introduced field.ErrorList
+	for _, e := range validation.FelixConfigurationSpecErrors(&newFC.Spec) {
+		if containsError(existing, e) {
+			warnings = append(warnings, fmt.Sprintf("FelixConfiguration %s is invalid: %s", newFC.Name, e.Error()))
+		} else {
+			introduced = append(introduced, e)
+		}
+	}
+	return warnings, invalidFelixConfiguration(newFC, introduced)
+}
+
+func (v *felixConfigurationValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func asFelixConfiguration(obj runtime.Object) (*crdv1.FelixConfiguration, error) {
+	fc, ok := obj.(*crdv1.FelixConfiguration)
+	if !ok {
+		return nil, fmt.Errorf("expected a FelixConfiguration but got %T", obj)
+	}
+	return fc, nil
+}
+
+func invalidFelixConfiguration(fc *crdv1.FelixConfiguration, errs field.ErrorList) error {
+	if err := errs.ToAggregate(); err != nil {
+		return fmt.Errorf("FelixConfiguration %s is invalid: %w", fc.Name, err)
+	}
+	return nil
+}
+
+// containsError returns true if errs has an error of the same type for the same field, with the same value.
+func containsError(errs field.ErrorList, err *field.Error) bool {
+	for _, e := range errs {
+		if e.Type == err.Type && e.Field == err.Field && reflect.DeepEqual(e.BadValue, err.BadValue) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"github.com/tigera/operator/pkg/ptr"
+)
+
+var _ = Describe("FelixConfiguration validation webhook", func() {
+	var (
+		ctx       context.Context
+		validator *felixConfigurationValidator
+		fc        *crdv1.FelixConfiguration
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		validator = &felixConfigurationValidator{}
+		fc = &crdv1.FelixConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: crdv1.FelixConfigurationSpec{
+				BPFEnabled:      ptr.BoolToPtr(true),
+				IptablesBackend: ptr.ToPtr(crdv1.IptablesBackend(crdv1.IptablesBackendLegacy)),
+			},
+		}
+	})
+
+	It("should admit a valid FelixConfiguration", func() {
+		_, err := validator.ValidateCreate(ctx, fc)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject an update that makes the FelixConfiguration invalid", func() {
+		updated := fc.DeepCopy()
+		updated.Spec.IptablesBackend = ptr.ToPtr(crdv1.IptablesBackend(crdv1.IptablesBackendNFTables))
+
+		_, err := validator.ValidateUpdate(ctx, fc, updated)
+		Expect(err).To(MatchError(ContainSubstring("FelixConfiguration default is invalid")))
+		Expect(err).To(MatchError(ContainSubstring("spec.iptablesBackend")))
+	})
+
+	It("should admit an update of a FelixConfiguration that was already invalid with a warning", func() {
+		fc.Spec.IptablesBackend = ptr.ToPtr(crdv1.IptablesBackend(crdv1.IptablesBackendNFTables))
+		updated := fc.DeepCopy()
+		updated.Spec.HealthPort = ptr.ToPtr(9199)
+
+		warnings, err := validator.ValidateUpdate(ctx, fc, updated)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(ContainSubstring("spec.iptablesBackend")))
+	})
+
+	It("should reject the errors that an update of an invalid FelixConfiguration introduces", func() {
+		fc.Spec.IptablesBackend = ptr.ToPtr(crdv1.IptablesBackend(crdv1.IptablesBackendNFTables))
+		updated := fc.DeepCopy()
+		updated.Spec.HealthPort = ptr.ToPtr(0)
+
+		_, err := validator.ValidateUpdate(ctx, fc, updated)
+		Expect(err).To(MatchError(ContainSubstring("spec.healthPort")))
+		Expect(err).NotTo(MatchError(ContainSubstring("spec.iptablesBackend")))
+	})
+
+	It("should always admit deletes", func() {
+		fc.Spec.RouteTableRange = &crdv1.RouteTableRange{Min: 0, Max: 255}
+		_, err := validator.ValidateDelete(ctx, fc)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject objects of other kinds", func() {
+		_, err := validator.ValidateCreate(ctx, &corev1.ConfigMap{})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,65 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/installation"
+	"github.com/tigera/operator/pkg/controller/utils"
+)
+
+// installationValidator rejects Installations that the core controller would refuse to render. The checks run on
+// the Installation with its defaults filled in, so they depend on the state of the cluster in the same way as the
+// core controller's do.
+type installationValidator struct {
+	client   client.Client
+	provider operatorv1.Provider
+}
+
+var _ admission.CustomValidator = &installationValidator{}
+
+func (v *installationValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj)
+}
+
+func (v *installationValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, newObj)
+}
+
+func (v *installationValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *installationValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	instance, ok := obj.(*operatorv1.Installation)
+	if !ok {
+		return nil, fmt.Errorf("expected an Installation but got %T", obj)
+	}
+	if instance.Name != utils.DefaultInstanceKey.Name {
+		// The overlay is only validated once the core controller has merged it into the default Installation.
+		return admission.Warnings{fmt.Sprintf("Installation %s is only validated after it is merged into the %s Installation", instance.Name, utils.DefaultInstanceKey.Name)}, nil
+	}
+	if err := installation.ValidateInstallation(ctx, v.client, instance, v.provider); err != nil {
+		return nil, fmt.Errorf("Installation %s is invalid: %w", instance.Name, err)
+	}
+	return nil, nil
+}
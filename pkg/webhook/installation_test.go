@@ -0,0 +1,97 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+var _ = Describe("Installation validation webhook", func() {
+	var (
+		ctx       context.Context
+		validator *installationValidator
+		instance  *operatorv1.Installation
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(appsv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		validator = &installationValidator{
+			client:   ctrlrfake.DefaultFakeClientBuilder(scheme).Build(),
+			provider: operatorv1.ProviderNone,
+		}
+		instance = &operatorv1.Installation{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	})
+
+	It("should admit an Installation that relies on the defaults, without filling them in", func() {
+		_, err := validator.ValidateCreate(ctx, instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(instance.Spec).To(Equal(operatorv1.InstallationSpec{}))
+	})
+
+	It("should reject an update that makes the Installation invalid", func() {
+		updated := instance.DeepCopy()
+		updated.Spec.CalicoNetwork = &operatorv1.CalicoNetworkSpec{
+			MultusCompatibility: &operatorv1.MultusCompatibility{InterfaceExclude: []string{"/^vf[0-9+$/"}},
+		}
+
+		_, err := validator.ValidateUpdate(ctx, instance, updated)
+		Expect(err).To(MatchError(ContainSubstring("Installation default is invalid")))
+		Expect(err).To(MatchError(ContainSubstring("is not a valid regular expression")))
+	})
+
+	It("should reject a provider that doesn't match the detected one", func() {
+		validator.provider = operatorv1.ProviderGKE
+		instance.Spec.KubernetesProvider = operatorv1.ProviderEKS
+
+		_, err := validator.ValidateCreate(ctx, instance)
+		Expect(err).To(MatchError(ContainSubstring("Installation default is invalid")))
+	})
+
+	It("should only warn about the overlay", func() {
+		instance.Name = "overlay"
+		instance.Spec.CalicoNetwork = &operatorv1.CalicoNetworkSpec{
+			MultusCompatibility: &operatorv1.MultusCompatibility{InterfaceExclude: []string{"/^vf[0-9+$/"}},
+		}
+
+		warnings, err := validator.ValidateCreate(ctx, instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(HaveLen(1))
+	})
+
+	It("should always admit deletes", func() {
+		_, err := validator.ValidateDelete(ctx, instance)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject objects of other kinds", func() {
+		_, err := validator.ValidateCreate(ctx, &corev1.ConfigMap{})
+		Expect(err).To(HaveOccurred())
+	})
+})
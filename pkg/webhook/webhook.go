@@ -0,0 +1,41 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhook contains the admission webhooks that the operator serves to reject invalid resources before
+// they are persisted. The ValidatingWebhookConfiguration that points the API server at them, and the serving
+// certificate in the webhook server's cert dir, are provided by the operator's deployment manifests.
+package webhook
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	crdv1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"github.com/tigera/operator/pkg/controller/options"
+)
+
+// AddToManager registers the operator's admission webhooks with the webhook server of the given manager.
+func AddToManager(mgr manager.Manager, opts options.AddOptions) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&crdv1.FelixConfiguration{}).
+		WithValidator(&felixConfigurationValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&operatorv1.Installation{}).
+		WithValidator(&installationValidator{client: mgr.GetClient(), provider: opts.DetectedProvider}).
+		Complete()
+}
@@ -16,3 +16,6 @@ package version
 
 // VERSION is filled out during the build process (using git describe output)
 var VERSION = "unknown"
+
+// GitSHA is filled out during the build process (using git rev-parse output)
+var GitSHA = "unknown"